@@ -13,6 +13,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/balance"
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/ec_vacuum"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/vacuum"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -29,6 +30,7 @@ const (
 	ECTaskConfigFile          = "task_erasure_coding.pb"
 	BalanceTaskConfigFile     = "task_balance.pb"
 	ReplicationTaskConfigFile = "task_replication.pb"
+	EcVacuumTaskConfigFile    = "task_ec_vacuum.pb"
 
 	// JSON reference files
 	MaintenanceConfigJSONFile     = "maintenance.json"
@@ -53,6 +55,7 @@ type (
 	ErasureCodingTaskConfig = worker_pb.ErasureCodingTaskConfig
 	BalanceTaskConfig       = worker_pb.BalanceTaskConfig
 	ReplicationTaskConfig   = worker_pb.ReplicationTaskConfig
+	EcVacuumTaskConfig      = worker_pb.EcVacuumTaskConfig
 )
 
 // isValidTaskID validates that a task ID is safe for use in file paths
@@ -520,6 +523,46 @@ func (cp *ConfigPersistence) LoadBalanceTaskPolicy() (*worker_pb.TaskPolicy, err
 	return nil, fmt.Errorf("failed to unmarshal balance task configuration")
 }
 
+// SaveEcVacuumTaskPolicy saves complete EC vacuum task policy to protobuf file
+func (cp *ConfigPersistence) SaveEcVacuumTaskPolicy(policy *worker_pb.TaskPolicy) error {
+	return cp.saveTaskConfig(EcVacuumTaskConfigFile, policy)
+}
+
+// LoadEcVacuumTaskPolicy loads complete EC vacuum task policy from protobuf file
+func (cp *ConfigPersistence) LoadEcVacuumTaskPolicy() (*worker_pb.TaskPolicy, error) {
+	if cp.dataDir == "" {
+		// Return default policy if no data directory
+		return ec_vacuum.NewDefaultConfig().ToTaskPolicy(), nil
+	}
+
+	confDir := filepath.Join(cp.dataDir, ConfigSubdir)
+	configPath := filepath.Join(confDir, EcVacuumTaskConfigFile)
+
+	// Check if file exists
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		// Return default policy if file doesn't exist
+		return ec_vacuum.NewDefaultConfig().ToTaskPolicy(), nil
+	}
+
+	// Read file
+	configData, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EC vacuum task config file: %w", err)
+	}
+
+	// Try to unmarshal as TaskPolicy
+	var policy worker_pb.TaskPolicy
+	if err := proto.Unmarshal(configData, &policy); err == nil {
+		// Validate that it's actually a TaskPolicy with EC vacuum config
+		if policy.GetEcVacuumConfig() != nil {
+			glog.V(1).Infof("Loaded EC vacuum task policy from %s", configPath)
+			return &policy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to unmarshal EC vacuum task configuration")
+}
+
 // SaveReplicationTaskConfig saves replication task configuration to protobuf file
 func (cp *ConfigPersistence) SaveReplicationTaskConfig(config *ReplicationTaskConfig) error {
 	return cp.saveTaskConfig(ReplicationTaskConfigFile, config)
@@ -631,6 +674,8 @@ func (cp *ConfigPersistence) SaveTaskPolicy(taskType string, policy *worker_pb.T
 		return cp.SaveBalanceTaskPolicy(policy)
 	case "replication":
 		return cp.SaveReplicationTaskPolicy(policy)
+	case "ec_vacuum":
+		return cp.SaveEcVacuumTaskPolicy(policy)
 	}
 	return fmt.Errorf("unknown task type: %s", taskType)
 }
@@ -739,11 +784,17 @@ func buildPolicyFromTaskConfigs() *worker_pb.MaintenancePolicy {
 				BalanceConfig: &worker_pb.BalanceTaskConfig{
 					ImbalanceThreshold: float64(balanceConfig.ImbalanceThreshold),
 					MinServerCount:     int32(balanceConfig.MinServerCount),
+					LoadAware:          balanceConfig.LoadAware,
 				},
 			},
 		}
 	}
 
+	// Load EC vacuum task configuration
+	if ecVacuumConfig := ec_vacuum.LoadConfigFromPersistence(nil); ecVacuumConfig != nil {
+		policy.TaskPolicies["ec_vacuum"] = ecVacuumConfig.ToTaskPolicy()
+	}
+
 	glog.V(1).Infof("Built maintenance policy from separate task configs - %d task policies loaded", len(policy.TaskPolicies))
 	return policy
 }
@@ -240,9 +240,16 @@ type VolumeDetailsData struct {
 	Replicas         []VolumeWithTopology `json:"replicas"`
 	VolumeSizeLimit  uint64               `json:"volume_size_limit"`
 	ReplicationCount int                  `json:"replication_count"`
+	HotNeedles       []HotNeedle          `json:"hot_needles"`
 	LastUpdated      time.Time            `json:"last_updated"`
 }
 
+// HotNeedle is a most read file of a volume server
+type HotNeedle struct {
+	FileId    string `json:"file_id"`
+	ReadCount uint64 `json:"read_count"`
+}
+
 // Collection management structures
 type CollectionInfo struct {
 	Name          string   `json:"name"`
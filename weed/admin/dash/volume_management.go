@@ -9,6 +9,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 )
 
 // GetClusterVolumes retrieves cluster volumes data with pagination, sorting, and filtering
@@ -312,6 +313,7 @@ func (s *AdminServer) sortVolumes(volumes []VolumeWithTopology, sortBy string, s
 func (s *AdminServer) GetVolumeDetails(volumeID int, server string) (*VolumeDetailsData, error) {
 	var primaryVolume VolumeWithTopology
 	var replicas []VolumeWithTopology
+	var hotNeedles []HotNeedle
 	var volumeSizeLimit uint64
 	var found bool
 
@@ -326,6 +328,16 @@ func (s *AdminServer) GetVolumeDetails(volumeID int, server string) (*VolumeDeta
 			for _, dc := range resp.TopologyInfo.DataCenterInfos {
 				for _, rack := range dc.RackInfos {
 					for _, node := range rack.DataNodeInfos {
+						if node.Id == server {
+							for _, hotNeedle := range node.HotNeedles {
+								if int(hotNeedle.VolumeId) == volumeID {
+									hotNeedles = append(hotNeedles, HotNeedle{
+										FileId:    needle.NewFileId(needle.VolumeId(hotNeedle.VolumeId), hotNeedle.NeedleId, hotNeedle.Cookie).String(),
+										ReadCount: hotNeedle.ReadCount,
+									})
+								}
+							}
+						}
 						for _, diskInfo := range node.DiskInfos {
 							for _, volInfo := range diskInfo.VolumeInfos {
 								if int(volInfo.Id) == volumeID {
@@ -387,6 +399,7 @@ func (s *AdminServer) GetVolumeDetails(volumeID int, server string) (*VolumeDeta
 		Replicas:         replicas,
 		VolumeSizeLimit:  volumeSizeLimit,
 		ReplicationCount: len(replicas) + 1, // Include the primary volume
+		HotNeedles:       hotNeedles,
 		LastUpdated:      time.Now(),
 	}, nil
 }
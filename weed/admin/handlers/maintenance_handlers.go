@@ -18,6 +18,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/balance"
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/ec_vacuum"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/vacuum"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types"
@@ -237,6 +238,8 @@ func (h *MaintenanceHandlers) UpdateTaskConfig(c *gin.Context) {
 		config = &balance.Config{}
 	case types.TaskTypeErasureCoding:
 		config = &erasure_coding.Config{}
+	case types.TaskTypeEcVacuum:
+		config = &ec_vacuum.Config{}
 	default:
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported task type: " + taskTypeName})
 		return
@@ -296,6 +299,11 @@ func (h *MaintenanceHandlers) UpdateTaskConfig(c *gin.Context) {
 			glog.V(1).Infof("Parsed balance config - Enabled: %v, MaxConcurrent: %d, ScanIntervalSeconds: %d, ImbalanceThreshold: %f, MinServerCount: %d",
 				balanceConfig.Enabled, balanceConfig.MaxConcurrent, balanceConfig.ScanIntervalSeconds, balanceConfig.ImbalanceThreshold, balanceConfig.MinServerCount)
 		}
+	case types.TaskTypeEcVacuum:
+		if ecVacuumConfig, ok := config.(*ec_vacuum.Config); ok {
+			glog.V(1).Infof("Parsed EC vacuum config - GarbageThreshold: %f, MinVolumeSizeMB: %d, CollectionFilter: '%s'",
+				ecVacuumConfig.GarbageThreshold, ecVacuumConfig.MinVolumeSizeMB, ecVacuumConfig.CollectionFilter)
+		}
 	}
 
 	// Validate the configuration
@@ -582,6 +590,8 @@ func (h *MaintenanceHandlers) saveTaskConfigToProtobuf(taskType types.TaskType,
 		return configPersistence.SaveErasureCodingTaskPolicy(taskPolicy)
 	case types.TaskTypeBalance:
 		return configPersistence.SaveBalanceTaskPolicy(taskPolicy)
+	case types.TaskTypeEcVacuum:
+		return configPersistence.SaveEcVacuumTaskPolicy(taskPolicy)
 	default:
 		return fmt.Errorf("unsupported task type for protobuf persistence: %s", taskType)
 	}
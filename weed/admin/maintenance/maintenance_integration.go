@@ -474,7 +474,7 @@ func (s *MaintenanceIntegration) mapMaintenanceTaskTypeToPendingOperationType(ta
 		return OpTypeVolumeBalance
 	case MaintenanceTaskType("erasure_coding"):
 		return OpTypeErasureCoding
-	case MaintenanceTaskType("vacuum"):
+	case MaintenanceTaskType("vacuum"), MaintenanceTaskType("ec_vacuum"):
 		return OpTypeVacuum
 	case MaintenanceTaskType("replication"):
 		return OpTypeReplication
@@ -9,6 +9,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/balance"
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/ec_vacuum"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/vacuum"
 )
@@ -68,11 +69,17 @@ func buildPolicyFromTaskConfigs() *worker_pb.MaintenancePolicy {
 				BalanceConfig: &worker_pb.BalanceTaskConfig{
 					ImbalanceThreshold: float64(balanceConfig.ImbalanceThreshold),
 					MinServerCount:     int32(balanceConfig.MinServerCount),
+					LoadAware:          balanceConfig.LoadAware,
 				},
 			},
 		}
 	}
 
+	// Load EC vacuum task configuration
+	if ecVacuumConfig := ec_vacuum.LoadConfigFromPersistence(nil); ecVacuumConfig != nil {
+		policy.TaskPolicies["ec_vacuum"] = ecVacuumConfig.ToTaskPolicy()
+	}
+
 	glog.V(1).Infof("Built maintenance policy from separate task configs - %d task policies loaded", len(policy.TaskPolicies))
 	return policy
 }
@@ -866,7 +866,7 @@ func (mq *MaintenanceQueue) trackPendingOperation(task *MaintenanceTask) {
 		opType = OpTypeVolumeBalance
 	case MaintenanceTaskType("erasure_coding"):
 		opType = OpTypeErasureCoding
-	case MaintenanceTaskType("vacuum"):
+	case MaintenanceTaskType("vacuum"), MaintenanceTaskType("ec_vacuum"):
 		opType = OpTypeVacuum
 	case MaintenanceTaskType("replication"):
 		opType = OpTypeReplication
@@ -128,6 +128,12 @@ func (ms *MaintenanceScanner) getVolumeHealthMetrics() ([]*VolumeHealthMetrics,
 								IsECVolume:       false, // Will be determined from volume structure
 								ReplicaCount:     1,     // Will be counted
 								ExpectedReplicas: int(volInfo.ReplicaPlacement),
+								ReadCount:        volInfo.ReadCount,
+								ReadBytes:        volInfo.ReadBytes,
+								ReadMicros:       volInfo.ReadMicros,
+								WriteCount:       volInfo.WriteCount,
+								WriteBytes:       volInfo.WriteBytes,
+								WriteMicros:      volInfo.WriteMicros,
 							}
 
 							// Calculate derived metrics
@@ -225,6 +231,12 @@ func (ms *MaintenanceScanner) convertToTaskMetrics(metrics []*VolumeHealthMetric
 			HasRemoteCopy:    metric.HasRemoteCopy,
 			IsECVolume:       metric.IsECVolume,
 			FullnessRatio:    metric.FullnessRatio,
+			ReadCount:        metric.ReadCount,
+			ReadBytes:        metric.ReadBytes,
+			ReadMicros:       metric.ReadMicros,
+			WriteCount:       metric.WriteCount,
+			WriteBytes:       metric.WriteBytes,
+			WriteMicros:      metric.WriteMicros,
 		})
 	}
 
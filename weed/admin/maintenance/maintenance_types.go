@@ -382,6 +382,14 @@ type VolumeHealthMetrics struct {
 	HasRemoteCopy    bool          `json:"has_remote_copy"`
 	IsECVolume       bool          `json:"is_ec_volume"`
 	FullnessRatio    float64       `json:"fullness_ratio"`
+
+	// io counters since the volume is loaded
+	ReadCount   uint64 `json:"read_count"`
+	ReadBytes   uint64 `json:"read_bytes"`
+	ReadMicros  uint64 `json:"read_micros"`
+	WriteCount  uint64 `json:"write_count"`
+	WriteBytes  uint64 `json:"write_bytes"`
+	WriteMicros uint64 `json:"write_micros"`
 }
 
 // MaintenanceStats provides statistics about maintenance operations
@@ -86,9 +86,10 @@ func (at *ActiveTopology) isDiskAvailable(disk *activeDisk, taskType TaskType) b
 func (at *ActiveTopology) areTaskTypesConflicting(existing, new TaskType) bool {
 	// Examples of conflicting task types
 	conflictMap := map[TaskType][]TaskType{
-		TaskTypeVacuum:        {TaskTypeBalance, TaskTypeErasureCoding},
-		TaskTypeBalance:       {TaskTypeVacuum, TaskTypeErasureCoding},
-		TaskTypeErasureCoding: {TaskTypeVacuum, TaskTypeBalance},
+		TaskTypeVacuum:        {TaskTypeBalance, TaskTypeErasureCoding, TaskTypeEcVacuum},
+		TaskTypeBalance:       {TaskTypeVacuum, TaskTypeErasureCoding, TaskTypeEcVacuum},
+		TaskTypeErasureCoding: {TaskTypeVacuum, TaskTypeBalance, TaskTypeEcVacuum},
+		TaskTypeEcVacuum:      {TaskTypeVacuum, TaskTypeBalance, TaskTypeErasureCoding},
 	}
 
 	if conflicts, exists := conflictMap[existing]; exists {
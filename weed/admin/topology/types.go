@@ -15,6 +15,7 @@ const (
 	TaskTypeBalance       TaskType = "balance"
 	TaskTypeErasureCoding TaskType = "erasure_coding"
 	TaskTypeReplication   TaskType = "replication"
+	TaskTypeEcVacuum      TaskType = "ec_vacuum"
 )
 
 // Common task status constants
@@ -3,17 +3,22 @@ package command
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"math"
 	"math/rand"
+	"net/http"
 	"os"
 	"runtime"
 	"runtime/pprof"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/quic-go/quic-go/http3"
+
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
@@ -43,6 +48,8 @@ type BenchmarkOptions struct {
 	grpcDialOption   grpc.DialOption
 	masterClient     *wdclient.MasterClient
 	fsync            *bool
+	http3            *bool
+	http3Client      *http.Client
 }
 
 var (
@@ -69,6 +76,7 @@ func init() {
 	b.cpuprofile = cmdBenchmark.Flag.String("cpuprofile", "", "cpu profile output file")
 	b.maxCpu = cmdBenchmark.Flag.Int("maxCpu", 0, "maximum number of CPUs. 0 means all available CPUs")
 	b.fsync = cmdBenchmark.Flag.Bool("fsync", false, "flush data to disk after write")
+	b.http3 = cmdBenchmark.Flag.Bool("http3", false, "read files over HTTP/3, from volume servers started with -http3")
 	sharedBytes = make([]byte, 1024)
 }
 
@@ -87,6 +95,10 @@ var cmdBenchmark = &Command{
     -readOnly   only benchmark read operations
     -writeOnly  only benchmark write operations
 
+  To compare the read paths of volume servers, e.g. started with -sendFile or -http3:
+    -size=4194304   write large files, which are streamed from the .dat files when read back
+    -http3          read over HTTP/3 instead of HTTP/1.1
+
   During write, the list of uploaded file ids is stored in "-list" specified file.
   You can also use your own list of file ids to run read test.
 
@@ -117,6 +129,13 @@ func runBenchmark(cmd *Command, args []string) bool {
 
 	util.LoadSecurityConfiguration()
 	b.grpcDialOption = security.LoadClientTLS(util.GetViper(), "grpc.client")
+	if *b.http3 {
+		var tlsConfig *tls.Config
+		if clientTlsConfig := util_http.GetGlobalHttpClient().GetClientTransport().TLSClientConfig; clientTlsConfig != nil {
+			tlsConfig = clientTlsConfig.Clone()
+		}
+		b.http3Client = &http.Client{Transport: &http3.Transport{TLSClientConfig: tlsConfig}}
+	}
 
 	fmt.Printf("This is SeaweedFS version %s %s %s\n", version.Version(), runtime.GOOS, runtime.GOARCH)
 	if *b.maxCpu < 1 {
@@ -316,7 +335,7 @@ func readFiles(fileIdLineChan chan string, s *stat) {
 		}
 		var bytes []byte
 		for _, url := range urls {
-			bytes, _, err = util_http.Get(url)
+			bytes, err = readFile(url)
 			if err == nil {
 				break
 			}
@@ -333,6 +352,24 @@ func readFiles(fileIdLineChan chan string, s *stat) {
 	}
 }
 
+func readFile(fileUrl string) ([]byte, error) {
+	if b.http3Client == nil {
+		data, _, err := util_http.Get(fileUrl)
+		return data, err
+	}
+	// HTTP/3 is served on the udp port with the same number as the https port
+	fileUrl = "https://" + strings.TrimPrefix(strings.TrimPrefix(fileUrl, "http://"), "https://")
+	resp, err := b.http3Client.Get(fileUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer util_http.CloseResponse(resp)
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s: %s", fileUrl, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
 func writeFileIds(fileName string, fileIdLineChan chan string, finishChan chan bool) {
 	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
@@ -467,6 +504,9 @@ func (s *stats) printStats() {
 	}
 	timeTaken := float64(int64(s.end.Sub(s.start))) / 1000000000
 	fmt.Printf("\nConcurrency Level:      %d\n", *b.concurrency)
+	if b.http3Client != nil {
+		fmt.Printf("Protocol:               HTTP/3\n")
+	}
 	fmt.Printf("Time taken for tests:   %.3f seconds\n", timeTaken)
 	fmt.Printf("Completed requests:      %d\n", completed)
 	fmt.Printf("Failed requests:        %d\n", failed)
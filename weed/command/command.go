@@ -20,6 +20,7 @@ var Commands = []*Command{
 	cmdFiler,
 	cmdFilerBackup,
 	cmdFilerCat,
+	cmdFilerCdc,
 	cmdFilerCopy,
 	cmdFilerMetaBackup,
 	cmdFilerMetaTail,
@@ -37,6 +38,10 @@ var Commands = []*Command{
 	cmdMqAgent,
 	cmdMqBroker,
 	cmdMqKafkaGateway,
+	cmdMqMqttGateway,
+	cmdMqSchemaRegistry,
+	cmdMqSqsGateway,
+	cmdNfs,
 	cmdDB,
 	cmdS3,
 	cmdScaffold,
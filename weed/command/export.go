@@ -19,6 +19,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -197,7 +198,11 @@ func runExport(cmd *Command, args []string) bool {
 	}
 	vid := needle.VolumeId(*export.volumeId)
 
-	needleMap := needle_map.NewMemDb()
+	offsetWidth, err := volume_info.MaybeLoadOffsetWidth(path.Join(util.ResolvePath(*export.dir), fileName+".vif"))
+	if err != nil {
+		glog.Fatalf("cannot read offset width from %s.vif: %s", fileName, err)
+	}
+	needleMap := needle_map.NewMemDbWithOffsetWidth(offsetWidth)
 	defer needleMap.Close()
 
 	if err := needleMap.LoadFromIdx(path.Join(util.ResolvePath(*export.dir), fileName+".idx")); err != nil {
@@ -80,6 +80,7 @@ type FilerOptions struct {
 	allowedOrigins            *string
 	exposeDirectoryData       *bool
 	tusBasePath               *string
+	http3                     *bool
 	certProvider              certprovider.Provider
 }
 
@@ -116,6 +117,7 @@ func init() {
 	f.allowedOrigins = cmdFiler.Flag.String("allowedOrigins", "*", "comma separated list of allowed origins")
 	f.exposeDirectoryData = cmdFiler.Flag.Bool("exposeDirectoryData", true, "whether to return directory metadata and content in Filer UI")
 	f.tusBasePath = cmdFiler.Flag.String("tusBasePath", "/.tus", "TUS resumable upload endpoint base path (e.g., /.tus)")
+	f.http3 = cmdFiler.Flag.Bool("http3", false, "also serve HTTP/3 on the udp port of -port, needs https.filer.cert and https.filer.key in security.toml")
 
 	// start s3 on filer
 	filerStartS3 = cmdFiler.Flag.Bool("s3", false, "whether to start S3 gateway")
@@ -474,14 +476,19 @@ func (fo *FilerOptions) startFiler() {
 
 		security.FixTlsConfig(util.GetViper(), tlsConfig)
 
+		var handler http.Handler = defaultMux
+		if *fo.http3 {
+			handler = startHttp3Server("filer", util.JoinHostPort(*fo.bindIp, *fo.port), tlsConfig, defaultMux)
+		}
+
 		if filerLocalListener != nil {
 			go func() {
-				if err := newHttpServer(defaultMux, tlsConfig).ServeTLS(filerLocalListener, "", ""); err != nil {
+				if err := newHttpServer(handler, tlsConfig).ServeTLS(filerLocalListener, "", ""); err != nil {
 					glog.Errorf("Filer Fail to serve: %v", err)
 				}
 			}()
 		}
-		httpS := newHttpServer(defaultMux, tlsConfig)
+		httpS := newHttpServer(handler, tlsConfig)
 		if MiniClusterCtx != nil {
 			ctx := MiniClusterCtx
 			go func() {
@@ -494,6 +501,9 @@ func (fo *FilerOptions) startFiler() {
 			glog.Fatalf("Filer Fail to serve: %v", err)
 		}
 	} else {
+		if *fo.http3 {
+			glog.Fatalf("filer HTTP/3 needs https.filer.cert and https.filer.key in security.toml")
+		}
 		if filerLocalListener != nil {
 			go func() {
 				if err := newHttpServer(defaultMux, nil).Serve(filerLocalListener); err != nil {
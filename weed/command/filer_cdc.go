@@ -0,0 +1,185 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer/cdc"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/pub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+type FilerCdcOptions struct {
+	filerAddress       *string
+	paths              *string
+	brokers            *string
+	namespace          *string
+	topic              *string
+	partitionCount     *int
+	timeAgo            *time.Duration
+	checkpointInterval *time.Duration
+	clientId           int32
+	clientEpoch        int32
+}
+
+var (
+	filerCdcOptions FilerCdcOptions
+)
+
+func init() {
+	cmdFilerCdc.Run = runFilerCdc // break init cycle
+	filerCdcOptions.filerAddress = cmdFilerCdc.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	filerCdcOptions.paths = cmdFilerCdc.Flag.String("paths", "/", "comma-separated directories to capture changes from")
+	filerCdcOptions.brokers = cmdFilerCdc.Flag.String("broker", "localhost:17777", "comma-separated message queue brokers")
+	filerCdcOptions.namespace = cmdFilerCdc.Flag.String("namespace", "filer", "message queue namespace, which is the database in weed sql")
+	filerCdcOptions.topic = cmdFilerCdc.Flag.String("topic", "events", "message queue topic, which is the table in weed sql")
+	filerCdcOptions.partitionCount = cmdFilerCdc.Flag.Int("partitionCount", 4, "partition count when creating the topic")
+	filerCdcOptions.timeAgo = cmdFilerCdc.Flag.Duration("timeAgo", 0, "start time before now, if there is no previous checkpoint. \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\"")
+	filerCdcOptions.checkpointInterval = cmdFilerCdc.Flag.Duration("checkpointInterval", 10*time.Second, "how often to wait for the brokers to acknowledge published events and checkpoint the offset")
+	filerCdcOptions.clientId = util.RandomInt32()
+}
+
+var cmdFilerCdc = &Command{
+	UsageLine: "filer.cdc [-filer=localhost:8888] [-paths=/] [-broker=localhost:17777] [-namespace=filer] [-topic=events]",
+	Short:     "continuously publish filer metadata changes into a message queue topic",
+	Long: `continuously publish filer metadata changes into a message queue topic
+
+	Every create, update, delete and rename under the -paths directories is published
+	as a record keyed by its path, with these fields:
+
+		event_type    create, update, delete or rename
+		event_time    when the filer made the change
+		path          the path of the entry, or its new path for renames
+		old_path      the previous path for renames
+		is_directory, size, mtime, owner, uid, gid, chunk_count
+
+	The topic can be queried with "weed sql", or the Postgres wire server, e.g.
+
+		weed sql -database=filer -query="SELECT path, event_type, size FROM events
+			WHERE path LIKE '/data/x/%' AND event_time >= '2024-06-01' AND event_time < '2024-06-02'"
+
+	and consumed by Kafka clients through "weed mq.kafka.gateway".
+
+	The offset is checkpointed in the filer after the brokers acknowledged the published events.
+	If publishing fails, the command exits. When restarted, publishing resumes from the last checkpoint,
+	so events are delivered at least once.
+
+`,
+}
+
+func runFilerCdc(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	filerAddress := pb.ServerAddress(*filerCdcOptions.filerAddress)
+	pathFilter := cdc.NewPathFilter(util.StringSplit(*filerCdcOptions.paths, ","))
+
+	for {
+		if err := filerCdcOptions.followUpdatesAndPublish(grpcDialOption, filerAddress, pathFilter); err != nil {
+			glog.Errorf("publish changes of %s: %v", filerAddress, err)
+			time.Sleep(1747 * time.Millisecond)
+		}
+	}
+}
+
+func (option *FilerCdcOptions) newPublisher() (*pub_client.TopicPublisher, error) {
+	var brokers []string
+	for _, broker := range pb.ServerAddresses(*option.brokers).ToAddresses() {
+		brokers = append(brokers, broker.String())
+	}
+	return pub_client.NewTopicPublisher(&pub_client.PublisherConfiguration{
+		Topic:          topic.NewTopic(*option.namespace, *option.topic),
+		PartitionCount: int32(*option.partitionCount),
+		Brokers:        brokers,
+		PublisherName:  fmt.Sprintf("filer.cdc.%d", option.clientId),
+		RecordType:     cdc.RecordType(),
+	})
+}
+
+// flushPublisher waits until the brokers acknowledged all published events.
+func flushPublisher(publisher *pub_client.TopicPublisher) error {
+	if err := publisher.FinishPublish(); err != nil {
+		return err
+	}
+	return publisher.Shutdown()
+}
+
+func (option *FilerCdcOptions) followUpdatesAndPublish(grpcDialOption grpc.DialOption, filerAddress pb.ServerAddress, pathFilter *cdc.PathFilter) error {
+
+	topicName := *option.namespace + "." + *option.topic
+	lastOffsetTsNs, err := cdc.GetOffset(grpcDialOption, filerAddress, topicName, pathFilter)
+	if err != nil {
+		return fmt.Errorf("read offset: %w", err)
+	}
+	if lastOffsetTsNs == 0 && *option.timeAgo > 0 {
+		lastOffsetTsNs = time.Now().Add(-*option.timeAgo).UnixNano()
+	}
+	if lastOffsetTsNs == 0 {
+		lastOffsetTsNs = time.Now().UnixNano()
+	}
+	glog.V(0).Infof("publish changes of %s %v to %s from %v", filerAddress, pathFilter.Prefixes(), topicName, time.Unix(0, lastOffsetTsNs))
+
+	// the publisher is created on the first event after each checkpoint
+	var publisher *pub_client.TopicPublisher
+	defer func() {
+		if publisher != nil {
+			publisher.Shutdown()
+		}
+	}()
+
+	eachEntryFunc := func(resp *filer_pb.SubscribeMetadataResponse) error {
+		key, record, ok := cdc.EventToRecord(resp, pathFilter)
+		if !ok {
+			return nil
+		}
+		if publisher == nil {
+			if publisher, err = option.newPublisher(); err != nil {
+				return fmt.Errorf("create publisher: %w", err)
+			}
+		}
+		return publisher.PublishRecord(key, record)
+	}
+
+	processEventFnWithOffset := pb.AddOffsetFunc(eachEntryFunc, *option.checkpointInterval, func(counter int64, lastTsNs int64) error {
+		if publisher != nil {
+			flushErr := flushPublisher(publisher)
+			publisher = nil
+			if flushErr != nil {
+				return fmt.Errorf("flush published events: %w", flushErr)
+			}
+		}
+		if err := cdc.SetOffset(grpcDialOption, filerAddress, topicName, pathFilter, lastTsNs); err != nil {
+			return fmt.Errorf("checkpoint offset: %w", err)
+		}
+		glog.V(0).Infof("published changes of %s up to %v %0.2f/sec", filerAddress, time.Unix(0, lastTsNs), float64(counter)/option.checkpointInterval.Seconds())
+		return nil
+	})
+
+	option.clientEpoch++
+
+	prefixes := pathFilter.Prefixes()
+	metadataFollowOption := &pb.MetadataFollowOption{
+		ClientName:             "filer.cdc",
+		ClientId:               option.clientId,
+		ClientEpoch:            option.clientEpoch,
+		SelfSignature:          0,
+		PathPrefix:             prefixes[0],
+		AdditionalPathPrefixes: prefixes[1:],
+		DirectoriesToWatch:     nil,
+		StartTsNs:              lastOffsetTsNs,
+		StopTsNs:               0,
+		// exit instead of skipping events that may not have been published,
+		// so that a restart resumes from the last checkpoint
+		EventErrorType: pb.FatalOnError,
+	}
+
+	return pb.FollowMetadata(filerAddress, grpcDialOption, metadataFollowOption, processEventFnWithOffset)
+}
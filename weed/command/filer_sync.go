@@ -55,6 +55,9 @@ type SyncOptions struct {
 	clientEpoch     atomic.Int32
 	debug           *bool
 	debugPort       *int
+	conflictPolicy  *string
+	conflictPaths   *string
+	conflictLog     *string
 }
 
 const (
@@ -112,6 +115,9 @@ func init() {
 	syncOptions.bDoDeleteFiles = cmdFilerSynchronize.Flag.Bool("b.doDeleteFiles", true, "delete and update files when synchronizing on filer B")
 	syncOptions.debug = cmdFilerSynchronize.Flag.Bool("debug", false, "serves runtime profiling data via pprof on the port specified by -debug.port")
 	syncOptions.debugPort = cmdFilerSynchronize.Flag.Int("debug.port", 6060, "http port for debugging")
+	syncOptions.conflictPolicy = cmdFilerSynchronize.Flag.String("conflictPolicy", string(filersink.ConflictNewestWins), "[newest-wins|keep-both|prefer-a|prefer-b] how to resolve a file changed on both filers concurrently in active-active mode")
+	syncOptions.conflictPaths = cmdFilerSynchronize.Flag.String("conflictPolicy.paths", "", "comma separated <path>=<policy> overrides of -conflictPolicy, with paths relative to -a.path and -b.path, longest prefix wins")
+	syncOptions.conflictLog = cmdFilerSynchronize.Flag.String("conflictLog", "", "append resolved conflicts as JSON lines to this file")
 	syncOptions.clientId = util.RandomInt32()
 }
 
@@ -130,6 +136,18 @@ var cmdFilerSynchronize = &Command{
 	If restarted, the synchronization will resume from the previous checkpoints, persisted every minute.
 	A fresh sync will start from the earliest metadata logs.
 
+	In active-active mode, each synchronized file records a version vector in its extended attributes.
+	A file changed on both filers before either change is synchronized is a conflict, resolved by -conflictPolicy:
+
+	* newest-wins: keep the version with the later modification time. This is the default.
+	* keep-both: keep the newest version, and save the other as "<name>.conflict-<filerSignature>-<mtime>".
+	* prefer-a, prefer-b: always keep the version of filer A or filer B.
+
+	A deletion only overrides a concurrent modification with prefer-a or prefer-b.
+	Writers that replace all extended attributes, e.g. S3 PUT, start a new version history,
+	so their changes are resolved by the policy as well.
+	Conflicts are counted by the filerSync conflict_total metric, and logged to -conflictLog if set.
+
 `,
 }
 
@@ -162,6 +180,23 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 		return true
 	}
 
+	// in active-active mode, detect files changed on both filers concurrently
+	var resolverA2B, resolverB2A *filersink.ConflictResolver
+	if !*syncOptions.isActivePassive {
+		conflictPolicies, err := filersink.ParseConflictPolicies(*syncOptions.conflictPolicy, *syncOptions.conflictPaths)
+		if err != nil {
+			glog.Errorf("parse conflict policy: %v", err)
+			return true
+		}
+		conflictLog, err := filersink.NewConflictLog(*syncOptions.conflictLog)
+		if err != nil {
+			glog.Errorf("%v", err)
+			return true
+		}
+		resolverA2B = filersink.NewConflictResolver(conflictPolicies, conflictLog, filerA.String(), filerB.String(), aFilerSignature, bFilerSignature, true)
+		resolverB2A = filersink.NewConflictResolver(conflictPolicies, conflictLog, filerB.String(), filerA.String(), bFilerSignature, aFilerSignature, false)
+	}
+
 	// register graceful shutdown hook to save checkpoints
 	grace.OnInterrupt(func() {
 		saveCheckpoint := func(name string, state *syncState) {
@@ -213,6 +248,7 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 				*syncOptions.bDoDeleteFiles,
 				aFilerSignature,
 				bFilerSignature,
+				resolverA2B,
 				&syncStateA2B)
 			if err != nil {
 				glog.Errorf("sync from %s to %s: %v", *syncOptions.filerA, *syncOptions.filerB, err)
@@ -252,6 +288,7 @@ func runFilerSynchronize(cmd *Command, args []string) bool {
 					*syncOptions.aDoDeleteFiles,
 					bFilerSignature,
 					aFilerSignature,
+					resolverB2A,
 					&syncStateB2A)
 				if err != nil {
 					glog.Errorf("sync from %s to %s: %v", *syncOptions.filerB, *syncOptions.filerA, err)
@@ -281,7 +318,7 @@ func initOffsetFromTsMs(grpcDialOption grpc.DialOption, targetFiler pb.ServerAdd
 }
 
 func doSubscribeFilerMetaChanges(clientId int32, clientEpoch int32, grpcDialOption grpc.DialOption, sourceFiler pb.ServerAddress, sourcePath string, sourceExcludePaths []string, sourceReadChunkFromFiler bool, targetFiler pb.ServerAddress, targetPath string,
-	replicationStr, collection string, ttlSec int, sinkWriteChunkByFiler bool, diskType string, debug bool, concurrency int, doDeleteFiles bool, sourceFilerSignature int32, targetFilerSignature int32, statePtr *atomic.Pointer[syncState]) error {
+	replicationStr, collection string, ttlSec int, sinkWriteChunkByFiler bool, diskType string, debug bool, concurrency int, doDeleteFiles bool, sourceFilerSignature int32, targetFilerSignature int32, conflictResolver *filersink.ConflictResolver, statePtr *atomic.Pointer[syncState]) error {
 
 	// if first time, start from now
 	// if has previously synced, resume from that point of time
@@ -298,6 +335,7 @@ func doSubscribeFilerMetaChanges(clientId int32, clientEpoch int32, grpcDialOpti
 	filerSink := &filersink.FilerSink{}
 	filerSink.DoInitialize(targetFiler.ToHttpAddress(), targetFiler.ToGrpcAddress(), targetPath, replicationStr, collection, ttlSec, diskType, grpcDialOption, sinkWriteChunkByFiler)
 	filerSink.SetSourceFiler(filerSource)
+	filerSink.SetConflictResolver(conflictResolver)
 
 	persistEventFn := genProcessFunction(sourcePath, targetPath, sourceExcludePaths, nil, filerSink, doDeleteFiles, debug)
 
@@ -478,6 +516,9 @@ func genProcessFunction(sourcePath string, targetPath string, excludePaths []str
 				return nil
 			}
 			key := buildKey(dataSink, message, targetPath, sourceOldKey, sourcePath)
+			if versionedSink, ok := dataSink.(sink.VersionedDeleteSink); ok {
+				return versionedSink.DeleteEntryVersion(key, message.OldEntry, message.DeleteChunks, message.Signatures)
+			}
 			return dataSink.DeleteEntry(key, message.OldEntry.IsDirectory, message.DeleteChunks, message.Signatures)
 		}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -43,6 +44,7 @@ type VolumeFileScanner4Fix struct {
 	nm             *needle_map.MemDb
 	nmDeleted      *needle_map.MemDb
 	includeDeleted bool
+	offsetWidth    types.OffsetWidth
 }
 
 func (scanner *VolumeFileScanner4Fix) VisitSuperBlock(superBlock super_block.SuperBlock) error {
@@ -144,10 +146,10 @@ func SaveToIdx(scaner *VolumeFileScanner4Fix, idxName string) (ret error) {
 	}()
 
 	return scaner.nm.AscendingVisit(func(value needle_map.NeedleValue) error {
-		_, err := idxFile.Write(value.ToBytes())
+		_, err := idxFile.Write(value.ToBytesWithWidth(scaner.offsetWidth))
 		if scaner.includeDeleted && err == nil {
 			if deleted, ok := scaner.nmDeleted.Get(value.Key); ok {
-				_, err = idxFile.Write(deleted.ToBytes())
+				_, err = idxFile.Write(deleted.ToBytesWithWidth(scaner.offsetWidth))
 			}
 		}
 		return err
@@ -157,8 +159,19 @@ func SaveToIdx(scaner *VolumeFileScanner4Fix, idxName string) (ret error) {
 func doFixOneVolume(basepath string, baseFileName string, collection string, volumeId int64, fixIncludeDeleted bool) {
 	indexFileName := path.Join(basepath, baseFileName+".idx")
 
-	nm := needle_map.NewMemDb()
-	nmDeleted := needle_map.NewMemDb()
+	// the .idx file is recreated with the offset width recorded in the .vif file
+	offsetWidth, err := volume_info.MaybeLoadOffsetWidth(path.Join(basepath, baseFileName+".vif"))
+	if err != nil {
+		if *fixIgnoreError {
+			glog.Error(err)
+			return
+		} else {
+			glog.Fatal(err)
+		}
+	}
+
+	nm := needle_map.NewMemDbWithOffsetWidth(offsetWidth)
+	nmDeleted := needle_map.NewMemDbWithOffsetWidth(offsetWidth)
 	defer nm.Close()
 	defer nmDeleted.Close()
 
@@ -179,6 +192,7 @@ func doFixOneVolume(basepath string, baseFileName string, collection string, vol
 		nm:             nm,
 		nmDeleted:      nmDeleted,
 		includeDeleted: fixIncludeDeleted,
+		offsetWidth:    offsetWidth,
 	}
 
 	if err := storage.ScanVolumeFile(basepath, collection, vid, storage.NeedleMapInMemory, scanner); err != nil {
@@ -199,4 +213,14 @@ func doFixOneVolume(basepath string, baseFileName string, collection string, vol
 			glog.Fatal(err)
 		}
 	}
+
+	// the pebble index entries are rebuilt from the new .idx file
+	if err := storage.DropPebbleNeedleMap(basepath, vid); err != nil {
+		err := fmt.Errorf("drop pebble index: %w", err)
+		if *fixIgnoreError {
+			glog.Error(err)
+		} else {
+			glog.Fatal(err)
+		}
+	}
 }
@@ -200,6 +200,8 @@ func runFuse(cmd *Command, args []string) bool {
 			}
 		case "volumeServerAccess":
 			mountOptions.volumeServerAccess = &parameter.value
+		case "consistency":
+			mountOptions.consistency = &parameter.value
 		case "map.uid":
 			mountOptions.uidMap = &parameter.value
 		case "map.gid":
@@ -0,0 +1,32 @@
+package command
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// startHttp3Server serves the handler over HTTP/3 on the udp port of the tcp listening address.
+// The returned handler is for the tcp listener, and advertises HTTP/3 to clients with the Alt-Svc header.
+func startHttp3Server(name string, address string, tlsConfig *tls.Config, handler http.Handler) http.Handler {
+	http3Server := &http3.Server{
+		Addr:      address,
+		Handler:   handler,
+		TLSConfig: tlsConfig.Clone(),
+	}
+	go func() {
+		glog.V(0).Infof("Start %s HTTP/3 at udp %s", name, address)
+		if err := http3Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			glog.Errorf("%s fail to serve HTTP/3: %v", name, err)
+		}
+	}()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := http3Server.SetQUICHeaders(w.Header()); err != nil {
+			glog.V(4).Infof("set HTTP/3 Alt-Svc header: %v", err)
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
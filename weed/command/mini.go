@@ -23,6 +23,7 @@ import (
 
 	// Import task packages to trigger their auto-registration
 	_ "github.com/seaweedfs/seaweedfs/weed/worker/tasks/balance"
+	_ "github.com/seaweedfs/seaweedfs/weed/worker/tasks/ec_vacuum"
 	_ "github.com/seaweedfs/seaweedfs/weed/worker/tasks/erasure_coding"
 	_ "github.com/seaweedfs/seaweedfs/weed/worker/tasks/vacuum"
 )
@@ -189,6 +190,7 @@ func initMiniFilerFlags() {
 	miniFilerOptions.allowedOrigins = cmdMini.Flag.String("filer.allowedOrigins", "*", "comma separated list of allowed origins")
 	miniFilerOptions.exposeDirectoryData = cmdMini.Flag.Bool("filer.exposeDirectoryData", true, "whether to return directory metadata and content in Filer UI")
 	miniFilerOptions.tusBasePath = cmdMini.Flag.String("filer.tusBasePath", "/.tus", "TUS resumable upload endpoint base path")
+	miniFilerOptions.http3 = cmdMini.Flag.Bool("filer.http3", false, "also serve HTTP/3 on the udp port of -filer.port, needs https.filer.cert and https.filer.key")
 }
 
 // initMiniVolumeFlags initializes Volume server flag options
@@ -198,7 +200,7 @@ func initMiniVolumeFlags() {
 	miniOptions.v.publicPort = cmdMini.Flag.Int("volume.port.public", 0, "volume server public port")
 	miniOptions.v.id = cmdMini.Flag.String("volume.id", "", "volume server id. If empty, default to ip:port")
 	miniOptions.v.publicUrl = cmdMini.Flag.String("volume.publicUrl", "", "publicly accessible address")
-	miniOptions.v.indexType = cmdMini.Flag.String("volume.index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge] mode for memory~performance balance.")
+	miniOptions.v.indexType = cmdMini.Flag.String("volume.index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge|pebble] mode for memory~performance balance. pebble shares one db per disk for servers with many volumes.")
 	miniOptions.v.diskType = cmdMini.Flag.String("volume.disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	miniOptions.v.fixJpgOrientation = cmdMini.Flag.Bool("volume.images.fix.orientation", false, "Adjust jpg orientation when uploading.")
 	miniOptions.v.readMode = cmdMini.Flag.String("volume.readMode", "proxy", "[local|proxy|redirect] how to deal with non-local volume: 'not found|read in remote node|redirect volume location'.")
@@ -214,6 +216,14 @@ func initMiniVolumeFlags() {
 	miniOptions.v.inflightDownloadDataTimeout = cmdMini.Flag.Duration("volume.inflightDownloadDataTimeout", 60*time.Second, "inflight download data wait timeout")
 	miniOptions.v.hasSlowRead = cmdMini.Flag.Bool("volume.hasSlowRead", true, "if true, prevents slow reads from blocking other requests")
 	miniOptions.v.readBufferSizeMB = cmdMini.Flag.Int("volume.readBufferSizeMB", 4, "read buffer size in MB")
+	miniOptions.v.readCacheDir = cmdMini.Flag.String("volume.readCache.dir", "", "local fast disk folder to cache needles read from remote tiered volumes and erasure coded volumes")
+	miniOptions.v.readCacheCapacityMB = cmdMini.Flag.String("volume.readCache.capacityMB", "1024", "read cache capacity in MB")
+	miniOptions.v.writeConsistency = cmdMini.Flag.String("volume.writeConsistency", "all", "copies of replicated volumes to acknowledge a write, all, quorum or one, optionally per collection")
+	miniOptions.v.imageCacheDir = cmdMini.Flag.String("volume.imageCache.dir", "", "local folder to cache transformed images")
+	miniOptions.v.imageCacheCapacityMB = cmdMini.Flag.Int64("volume.imageCache.capacityMB", 1024, "image rendition cache capacity in MB")
+	miniOptions.v.replicationStream = cmdMini.Flag.Bool("volume.replicationStream", true, "write replicas over pipelined gRPC streams")
+	miniOptions.v.sendFile = cmdMini.Flag.Bool("volume.sendFile", false, "send large needles on local disk with sendfile, without verifying the needle checksum")
+	miniOptions.v.http3 = cmdMini.Flag.Bool("volume.http3", false, "also serve HTTP/3 on the udp port of -volume.port, needs https.volume.cert and https.volume.key")
 	miniOptions.v.preStopSeconds = cmdMini.Flag.Int("volume.preStopSeconds", 1, "number of seconds between stop send heartbeats and stop volume server (default: 1 for mini)")
 }
 
@@ -40,6 +40,9 @@ type MountOptions struct {
 	// Periodic metadata flush to protect against orphan chunk cleanup
 	metadataFlushSeconds *int
 
+	// metadata consistency across clients
+	consistency *string
+
 	// RDMA acceleration options
 	rdmaEnabled       *bool
 	rdmaSidecarAddr   *string
@@ -99,6 +102,8 @@ func init() {
 	// Periodic metadata flush to protect against orphan chunk cleanup
 	mountOptions.metadataFlushSeconds = cmdMount.Flag.Int("metadataFlushSeconds", 120, "periodically flush file metadata to filer in seconds (0 to disable). This protects chunks from being purged by volume.fsck for long-running writes")
 
+	mountOptions.consistency = cmdMount.Flag.String("consistency", "eventual", "[eventual|cto|strict] eventual trusts the metadata cache, cto revalidates with the filer on open, strict also revalidates on lookup. Can be changed per directory by mount.configure")
+
 	// RDMA acceleration flags
 	mountOptions.rdmaEnabled = cmdMount.Flag.Bool("rdma.enabled", false, "enable RDMA acceleration for reads")
 	mountOptions.rdmaSidecarAddr = cmdMount.Flag.String("rdma.sidecar", "", "RDMA sidecar address (e.g., localhost:8081)")
@@ -228,6 +228,12 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		mountRoot = mountRoot[0 : len(mountRoot)-1]
 	}
 
+	consistencyMode, err := mount.ParseConsistencyMode(*option.consistency)
+	if err != nil {
+		fmt.Printf("failed to parse -consistency: %v\n", err)
+		return false
+	}
+
 	cacheDirForWrite := *option.cacheDirForWrite
 	if cacheDirForWrite == "" {
 		cacheDirForWrite = *option.cacheDirForRead
@@ -263,6 +269,7 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		DisableXAttr:         *option.disableXAttr,
 		IsMacOs:              runtime.GOOS == "darwin",
 		MetadataFlushSeconds: *option.metadataFlushSeconds,
+		ConsistencyMode:      consistencyMode,
 		// RDMA acceleration options
 		RdmaEnabled:       *option.rdmaEnabled,
 		RdmaSidecarAddr:   *option.rdmaSidecarAddr,
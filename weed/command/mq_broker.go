@@ -8,6 +8,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	"github.com/seaweedfs/seaweedfs/weed/security"
+	stats_collect "github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/util/grace"
 )
@@ -17,18 +18,21 @@ var (
 )
 
 type MessageQueueBrokerOptions struct {
-	masters          map[string]pb.ServerAddress
-	mastersString    *string
-	filerGroup       *string
-	ip               *string
-	port             *int
-	dataCenter       *string
-	rack             *string
-	cpuprofile       *string
-	memprofile       *string
-	logFlushInterval *int
-	debug            *bool
-	debugPort        *int
+	masters            map[string]pb.ServerAddress
+	mastersString      *string
+	filerGroup         *string
+	ip                 *string
+	port               *int
+	dataCenter         *string
+	rack               *string
+	cpuprofile         *string
+	memprofile         *string
+	logFlushInterval   *int
+	compactionInterval *int
+	debug              *bool
+	debugPort          *int
+	metricsHttpPort    *int
+	metricsHttpIp      *string
 }
 
 func init() {
@@ -42,8 +46,11 @@ func init() {
 	mqBrokerStandaloneOptions.cpuprofile = cmdMqBroker.Flag.String("cpuprofile", "", "cpu profile output file")
 	mqBrokerStandaloneOptions.memprofile = cmdMqBroker.Flag.String("memprofile", "", "memory profile output file")
 	mqBrokerStandaloneOptions.logFlushInterval = cmdMqBroker.Flag.Int("logFlushInterval", 5, "log buffer flush interval in seconds")
+	mqBrokerStandaloneOptions.compactionInterval = cmdMqBroker.Flag.Int("compactionInterval", 600, "interval in seconds to compact topics with cleanup.policy=compact, 0 to disable")
 	mqBrokerStandaloneOptions.debug = cmdMqBroker.Flag.Bool("debug", false, "serves runtime profiling data via pprof on the port specified by -debug.port")
 	mqBrokerStandaloneOptions.debugPort = cmdMqBroker.Flag.Int("debug.port", 6060, "http port for debugging")
+	mqBrokerStandaloneOptions.metricsHttpPort = cmdMqBroker.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
+	mqBrokerStandaloneOptions.metricsHttpIp = cmdMqBroker.Flag.String("metricsIp", "", "metrics listen ip. If empty, default to same as -ip option.")
 }
 
 var cmdMqBroker = &Command{
@@ -66,6 +73,11 @@ func runMqBroker(cmd *Command, args []string) bool {
 
 	mqBrokerStandaloneOptions.masters = pb.ServerAddresses(*mqBrokerStandaloneOptions.mastersString).ToAddressMap()
 
+	if *mqBrokerStandaloneOptions.metricsHttpIp == "" {
+		*mqBrokerStandaloneOptions.metricsHttpIp = *mqBrokerStandaloneOptions.ip
+	}
+	go stats_collect.StartMetricsServer(*mqBrokerStandaloneOptions.metricsHttpIp, *mqBrokerStandaloneOptions.metricsHttpPort)
+
 	return mqBrokerStandaloneOptions.startQueueServer()
 
 }
@@ -86,6 +98,7 @@ func (mqBrokerOpt *MessageQueueBrokerOptions) startQueueServer() bool {
 		Ip:                 *mqBrokerOpt.ip,
 		Port:               *mqBrokerOpt.port,
 		LogFlushInterval:   *mqBrokerOpt.logFlushInterval,
+		CompactionInterval: *mqBrokerOpt.compactionInterval,
 	}, grpcDialOption)
 	if err != nil {
 		glog.Fatalf("failed to create new message broker for queue server: %v", err)
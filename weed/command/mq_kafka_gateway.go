@@ -8,6 +8,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/gateway"
+	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/schema_registry"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -16,14 +17,15 @@ var (
 )
 
 type mqKafkaGatewayOpts struct {
-	ip                *string
-	ipBind            *string
-	port              *int
-	pprofPort         *int
-	master            *string
-	filerGroup        *string
-	schemaRegistryURL *string
-	defaultPartitions *int
+	ip                 *string
+	ipBind             *string
+	port               *int
+	pprofPort          *int
+	master             *string
+	filerGroup         *string
+	schemaRegistryURL  *string
+	schemaRegistryPort *int
+	defaultPartitions  *int
 }
 
 func init() {
@@ -35,6 +37,7 @@ func init() {
 	mqKafkaGatewayOptions.master = cmdMqKafkaGateway.Flag.String("master", "localhost:9333", "comma-separated SeaweedFS master servers")
 	mqKafkaGatewayOptions.filerGroup = cmdMqKafkaGateway.Flag.String("filerGroup", "", "filer group name")
 	mqKafkaGatewayOptions.schemaRegistryURL = cmdMqKafkaGateway.Flag.String("schema-registry-url", "", "Schema Registry URL (required for schema management)")
+	mqKafkaGatewayOptions.schemaRegistryPort = cmdMqKafkaGateway.Flag.Int("schema-registry-port", 0, "start an embedded schema registry on this port (0 to disable)")
 	mqKafkaGatewayOptions.defaultPartitions = cmdMqKafkaGateway.Flag.Int("default-partitions", 4, "Default number of partitions for auto-created topics")
 }
 
@@ -53,10 +56,13 @@ Options:
   -port                Listen port (default: 9092)
   -default-partitions  Default number of partitions for auto-created topics (default: 4)
   -schema-registry-url Schema Registry URL (REQUIRED for schema management)
+  -schema-registry-port Start an embedded schema registry on this port, see "weed help mq.schema.registry".
+                       -schema-registry-url defaults to the embedded registry.
 
 Examples:
   weed mq.kafka.gateway -port=9092 -master=localhost:9333 -schema-registry-url=http://localhost:8081
   weed mq.kafka.gateway -ip=gateway1 -port=9092 -master=master1:9333,master2:9333 -schema-registry-url=http://schema-registry:8081
+  weed mq.kafka.gateway -port=9092 -master=localhost:9333 -schema-registry-port=8081
   weed mq.kafka.gateway -ip=external.host.com -ip.bind=0.0.0.0 -master=localhost:9333 -schema-registry-url=http://schema-registry:8081
 
 This is experimental and currently supports a minimal subset for development.
@@ -70,18 +76,41 @@ func runMqKafkaGateway(cmd *Command, args []string) bool {
 		return false
 	}
 
-	// Schema Registry URL is required for schema management
-	if *mqKafkaGatewayOptions.schemaRegistryURL == "" {
-		glog.Fatalf("Schema Registry URL is required (-schema-registry-url)")
-		return false
-	}
-
 	// Determine bind address - default to advertised IP if not specified
 	bindIP := *mqKafkaGatewayOptions.ipBind
 	if bindIP == "" {
 		bindIP = *mqKafkaGatewayOptions.ip
 	}
 
+	// Start the embedded schema registry before the gateway uses it
+	if *mqKafkaGatewayOptions.schemaRegistryPort > 0 {
+		registry, err := schema_registry.NewService(schema_registry.ServiceOptions{
+			Listen:     fmt.Sprintf("%s:%d", bindIP, *mqKafkaGatewayOptions.schemaRegistryPort),
+			ClientHost: fmt.Sprintf("%s:%d", *mqKafkaGatewayOptions.ip, *mqKafkaGatewayOptions.schemaRegistryPort),
+			Masters:    *mqKafkaGatewayOptions.master,
+			FilerGroup: *mqKafkaGatewayOptions.filerGroup,
+			Namespace:  "kafka",
+		})
+		if err != nil {
+			glog.Fatalf("mq kafka gateway schema registry: %v", err)
+			return false
+		}
+		if err = registry.Start(); err != nil {
+			glog.Fatalf("mq kafka gateway schema registry start: %v", err)
+			return false
+		}
+		defer registry.Close()
+		if *mqKafkaGatewayOptions.schemaRegistryURL == "" {
+			*mqKafkaGatewayOptions.schemaRegistryURL = fmt.Sprintf("http://%s:%d", *mqKafkaGatewayOptions.ip, *mqKafkaGatewayOptions.schemaRegistryPort)
+		}
+	}
+
+	// Schema Registry URL is required for schema management
+	if *mqKafkaGatewayOptions.schemaRegistryURL == "" {
+		glog.Fatalf("Schema Registry URL is required (-schema-registry-url or -schema-registry-port)")
+		return false
+	}
+
 	// Construct listen address from bind IP and port
 	listenAddr := fmt.Sprintf("%s:%d", bindIP, *mqKafkaGatewayOptions.port)
 
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/mqtt"
+	stats_collect "github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/grace"
+)
+
+var (
+	mqMqttGatewayOptions mqMqttGatewayOpts
+)
+
+type mqMqttGatewayOpts struct {
+	ip                *string
+	ipBind            *string
+	port              *int
+	master            *string
+	filerGroup        *string
+	namespace         *string
+	topicMap          *string
+	defaultPartitions *int
+	maxPacketSize     *int
+	auth              *bool
+	metricsHttpPort   *int
+	metricsHttpIp     *string
+}
+
+func init() {
+	cmdMqMqttGateway.Run = runMqMqttGateway
+	mqMqttGatewayOptions.ip = cmdMqMqttGateway.Flag.String("ip", util.DetectedHostAddress(), "MQTT gateway host address")
+	mqMqttGatewayOptions.ipBind = cmdMqMqttGateway.Flag.String("ip.bind", "", "MQTT gateway bind address (default: same as -ip)")
+	mqMqttGatewayOptions.port = cmdMqMqttGateway.Flag.Int("port", 1883, "MQTT gateway listen port")
+	mqMqttGatewayOptions.master = cmdMqMqttGateway.Flag.String("master", "localhost:9333", "comma-separated SeaweedFS master servers")
+	mqMqttGatewayOptions.filerGroup = cmdMqMqttGateway.Flag.String("filerGroup", "", "filer group name")
+	mqMqttGatewayOptions.namespace = cmdMqMqttGateway.Flag.String("namespace", "mqtt", "MQ namespace of the MQTT topics without a topic mapping")
+	mqMqttGatewayOptions.topicMap = cmdMqMqttGateway.Flag.String("topicMap", "", "comma-separated mappings from MQTT topic filters to MQ topics, e.g. \"sensors/#=iot.sensors\"")
+	mqMqttGatewayOptions.defaultPartitions = cmdMqMqttGateway.Flag.Int("default-partitions", 4, "Default number of partitions for auto-created topics")
+	mqMqttGatewayOptions.maxPacketSize = cmdMqMqttGateway.Flag.Int("maxPacketSizeMB", 1, "maximum MQTT packet size in MB")
+	mqMqttGatewayOptions.auth = cmdMqMqttGateway.Flag.Bool("auth", true, "authenticate clients with the S3 identities, using the access key as user name and the secret key as password")
+	mqMqttGatewayOptions.metricsHttpPort = cmdMqMqttGateway.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
+	mqMqttGatewayOptions.metricsHttpIp = cmdMqMqttGateway.Flag.String("metricsIp", "", "metrics listen ip. If empty, default to same as -ip.bind option.")
+}
+
+var cmdMqMqttGateway = &Command{
+	UsageLine: "mq.mqtt.gateway [-port=1883] [-master=<master_servers>] [-namespace=mqtt] [-topicMap=<filter>=<namespace>.<topic>,...]",
+	Short:     "start an MQTT 3.1.1/5.0 gateway for SeaweedMQ",
+	Long: `Start an MQTT gateway that stores the published messages into SeaweedMQ topics.
+
+The messages of an MQTT topic go to the MQ topic of the first matching -topicMap entry.
+Without a matching entry, they go to the MQ topic of the -namespace named after the first
+level of the MQTT topic, e.g. "home/kitchen/temperature" is stored in "mqtt.home".
+Payloads are stored as bytes, with the MQTT topic, QoS, retain flag and client id.
+
+Supported:
+  QoS 0 and 1. QoS 2 is refused.
+  Persistent sessions, whose subscriptions and delivery progress survive reconnects.
+  Retained messages and last will.
+  User name and password authentication, with the access keys and secret keys of the
+  S3 identities. "Write" allows publishing and "Read" allows subscribing, optionally
+  limited to an MQ namespace, e.g. "Write:mqtt".
+
+Examples:
+  weed mq.mqtt.gateway -master=localhost:9333
+  weed mq.mqtt.gateway -master=localhost:9333 -topicMap="sensors/#=iot.sensors,alerts/+=iot.alerts"
+
+`,
+}
+
+func runMqMqttGateway(cmd *Command, args []string) bool {
+	util.LoadSecurityConfiguration()
+
+	if *mqMqttGatewayOptions.master == "" {
+		glog.Fatalf("SeaweedFS master address is required (-master)")
+		return false
+	}
+	topicMappings, err := mqtt.ParseTopicMappings(*mqMqttGatewayOptions.topicMap)
+	if err != nil {
+		glog.Fatalf("invalid -topicMap: %v", err)
+		return false
+	}
+
+	bindIP := *mqMqttGatewayOptions.ipBind
+	if bindIP == "" {
+		bindIP = *mqMqttGatewayOptions.ip
+	}
+	listenAddr := fmt.Sprintf("%s:%d", bindIP, *mqMqttGatewayOptions.port)
+
+	options := mqtt.Options{
+		Listen:            listenAddr,
+		ClientHost:        fmt.Sprintf("%s:%d", *mqMqttGatewayOptions.ip, *mqMqttGatewayOptions.port),
+		Masters:           *mqMqttGatewayOptions.master,
+		FilerGroup:        *mqMqttGatewayOptions.filerGroup,
+		Namespace:         *mqMqttGatewayOptions.namespace,
+		TopicMappings:     topicMappings,
+		DefaultPartitions: int32(*mqMqttGatewayOptions.defaultPartitions),
+		MaxPacketSize:     *mqMqttGatewayOptions.maxPacketSize * 1024 * 1024,
+	}
+	if *mqMqttGatewayOptions.auth {
+		credentialManager, err := credential.NewCredentialManagerWithDefaults("")
+		if err != nil {
+			glog.Fatalf("failed to initialize credential manager: %v", err)
+			return false
+		}
+		options.Authenticator = mqtt.NewCredentialAuthenticator(credentialManager)
+	} else {
+		glog.Warningf("MQTT gateway accepts clients without authentication")
+	}
+
+	srv, err := mqtt.NewServer(options)
+	if err != nil {
+		glog.Fatalf("mq mqtt gateway: %v", err)
+		return false
+	}
+
+	metricsIp := *mqMqttGatewayOptions.metricsHttpIp
+	if metricsIp == "" {
+		metricsIp = bindIP
+	}
+	go stats_collect.StartMetricsServer(metricsIp, *mqMqttGatewayOptions.metricsHttpPort)
+
+	if err := srv.Start(); err != nil {
+		glog.Fatalf("mq mqtt gateway start: %v", err)
+		return false
+	}
+	grace.OnInterrupt(func() {
+		glog.V(0).Infof("Shutting down MQ MQTT Gateway...")
+		if err := srv.Close(); err != nil {
+			glog.Errorf("mq mqtt gateway close: %v", err)
+		}
+	})
+
+	if err := srv.Wait(); err != nil {
+		glog.Errorf("mq mqtt gateway wait: %v", err)
+		return false
+	}
+	return true
+}
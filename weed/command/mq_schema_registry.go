@@ -0,0 +1,93 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/schema_registry"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/grace"
+)
+
+var (
+	mqSchemaRegistryOptions mqSchemaRegistryOpts
+)
+
+type mqSchemaRegistryOpts struct {
+	ip         *string
+	ipBind     *string
+	port       *int
+	master     *string
+	filerGroup *string
+	namespace  *string
+}
+
+func init() {
+	cmdMqSchemaRegistry.Run = runMqSchemaRegistry
+	mqSchemaRegistryOptions.ip = cmdMqSchemaRegistry.Flag.String("ip", util.DetectedHostAddress(), "schema registry host address")
+	mqSchemaRegistryOptions.ipBind = cmdMqSchemaRegistry.Flag.String("ip.bind", "", "schema registry bind address (default: same as -ip)")
+	mqSchemaRegistryOptions.port = cmdMqSchemaRegistry.Flag.Int("port", 8081, "schema registry listen port")
+	mqSchemaRegistryOptions.master = cmdMqSchemaRegistry.Flag.String("master", "localhost:9333", "comma-separated SeaweedFS master servers")
+	mqSchemaRegistryOptions.filerGroup = cmdMqSchemaRegistry.Flag.String("filerGroup", "", "filer group name")
+	mqSchemaRegistryOptions.namespace = cmdMqSchemaRegistry.Flag.String("namespace", "kafka", "MQ namespace of the Kafka topics whose record types follow the registered schemas, empty to disable")
+}
+
+var cmdMqSchemaRegistry = &Command{
+	UsageLine: "mq.schema.registry [-port=8081] [-master=<master_servers>] [-namespace=kafka]",
+	Short:     "start a Confluent-compatible schema registry for SeaweedMQ",
+	Long: `Start a schema registry serving the REST API of the Confluent Schema Registry,
+so that Kafka clients and their Avro, Protobuf and JSON Schema serializers work unchanged.
+
+Subjects, versions and compatibility levels are stored on the filer under /etc/schema_registry,
+so several registries can run against the same cluster.
+
+New versions are checked against the compatibility level of the subject, which defaults to
+the global level, BACKWARD unless configured. Supported levels are NONE, BACKWARD, FORWARD,
+FULL, and their _TRANSITIVE variants.
+
+When a schema is registered for "<topic>-key" or "<topic>-value", the record type of the
+existing MQ topic "<namespace>.<topic>" is updated, so that SQL queries see the new columns.
+
+Examples:
+  weed mq.schema.registry -master=localhost:9333
+  weed mq.kafka.gateway -master=localhost:9333 -schema-registry-url=http://localhost:8081
+
+`,
+}
+
+func runMqSchemaRegistry(cmd *Command, args []string) bool {
+	util.LoadSecurityConfiguration()
+
+	bindIP := *mqSchemaRegistryOptions.ipBind
+	if bindIP == "" {
+		bindIP = *mqSchemaRegistryOptions.ip
+	}
+
+	service, err := schema_registry.NewService(schema_registry.ServiceOptions{
+		Listen:     fmt.Sprintf("%s:%d", bindIP, *mqSchemaRegistryOptions.port),
+		ClientHost: fmt.Sprintf("%s:%d", *mqSchemaRegistryOptions.ip, *mqSchemaRegistryOptions.port),
+		Masters:    *mqSchemaRegistryOptions.master,
+		FilerGroup: *mqSchemaRegistryOptions.filerGroup,
+		Namespace:  *mqSchemaRegistryOptions.namespace,
+	})
+	if err != nil {
+		glog.Fatalf("mq schema registry: %v", err)
+		return false
+	}
+	if err = service.Start(); err != nil {
+		glog.Fatalf("mq schema registry start: %v", err)
+		return false
+	}
+	grace.OnInterrupt(func() {
+		glog.V(0).Infof("Shutting down MQ Schema Registry...")
+		if err := service.Close(); err != nil {
+			glog.Errorf("mq schema registry close: %v", err)
+		}
+	})
+
+	if err = service.Wait(); err != nil {
+		glog.Errorf("mq schema registry: %v", err)
+		return false
+	}
+	return true
+}
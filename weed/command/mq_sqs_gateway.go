@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/sqs"
+	stats_collect "github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/grace"
+)
+
+var (
+	mqSqsGatewayOptions mqSqsGatewayOpts
+)
+
+type mqSqsGatewayOpts struct {
+	ip                *string
+	ipBind            *string
+	port              *int
+	master            *string
+	filerGroup        *string
+	namespace         *string
+	region            *string
+	defaultPartitions *int
+	auth              *bool
+	config            *string
+	metricsHttpPort   *int
+	metricsHttpIp     *string
+}
+
+func init() {
+	cmdMqSqsGateway.Run = runMqSqsGateway
+	mqSqsGatewayOptions.ip = cmdMqSqsGateway.Flag.String("ip", util.DetectedHostAddress(), "SQS gateway host address")
+	mqSqsGatewayOptions.ipBind = cmdMqSqsGateway.Flag.String("ip.bind", "", "SQS gateway bind address (default: same as -ip)")
+	mqSqsGatewayOptions.port = cmdMqSqsGateway.Flag.Int("port", 9324, "SQS gateway listen port")
+	mqSqsGatewayOptions.master = cmdMqSqsGateway.Flag.String("master", "localhost:9333", "comma-separated SeaweedFS master servers")
+	mqSqsGatewayOptions.filerGroup = cmdMqSqsGateway.Flag.String("filerGroup", "", "filer group name")
+	mqSqsGatewayOptions.namespace = cmdMqSqsGateway.Flag.String("namespace", "sqs", "MQ namespace of the queue topics")
+	mqSqsGatewayOptions.region = cmdMqSqsGateway.Flag.String("region", "us-east-1", "region of the queue ARNs")
+	mqSqsGatewayOptions.defaultPartitions = cmdMqSqsGateway.Flag.Int("default-partitions", 4, "number of partitions of the topics of new queues")
+	mqSqsGatewayOptions.auth = cmdMqSqsGateway.Flag.Bool("auth", true, "authenticate requests with AWS signatures of the S3 identities, when any identity is configured")
+	mqSqsGatewayOptions.config = cmdMqSqsGateway.Flag.String("config", "", "path to an S3 identities config file, in addition to the identities on the filer")
+	mqSqsGatewayOptions.metricsHttpPort = cmdMqSqsGateway.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
+	mqSqsGatewayOptions.metricsHttpIp = cmdMqSqsGateway.Flag.String("metricsIp", "", "metrics listen ip. If empty, default to same as -ip.bind option.")
+}
+
+var cmdMqSqsGateway = &Command{
+	UsageLine: "mq.sqs.gateway [-port=9324] [-master=<master_servers>] [-namespace=sqs]",
+	Short:     "start an Amazon SQS compatible queue gateway for SeaweedMQ",
+	Long: `Start a gateway serving the Amazon SQS API, with both the query and the JSON protocols.
+
+Each queue stores its messages in an MQ topic of the -namespace, and deleting a message
+commits its offset in the "sqs" consumer group of the topic. The queue definitions are
+kept on the filer under /etc/sqs/queues, so several gateways can serve the same queues.
+
+Supported:
+  CreateQueue, GetQueueUrl, ListQueues, DeleteQueue, PurgeQueue,
+  GetQueueAttributes, SetQueueAttributes,
+  SendMessage, SendMessageBatch, ReceiveMessage with visibility timeout and long polling,
+  DeleteMessage(Batch), ChangeMessageVisibility(Batch),
+  dead letter queues with RedrivePolicy, and FIFO queues with message groups and deduplication.
+
+Requests are signed with the access keys of the S3 identities, with the queue name in place of
+the bucket name: "Admin" creates, deletes and configures queues, "Write" sends and deletes
+messages, "Read" receives messages, and "List" lists queues.
+
+Limitations:
+  A receipt handle is only valid on the gateway that received the message.
+  FIFO deduplication ids and receive counts are tracked by each gateway.
+
+Examples:
+  weed mq.sqs.gateway -master=localhost:9333
+  aws sqs --endpoint-url http://localhost:9324 create-queue --queue-name jobs
+
+`,
+}
+
+func runMqSqsGateway(cmd *Command, args []string) bool {
+	util.LoadSecurityConfiguration()
+
+	if *mqSqsGatewayOptions.master == "" {
+		glog.Fatalf("SeaweedFS master address is required (-master)")
+		return false
+	}
+
+	bindIP := *mqSqsGatewayOptions.ipBind
+	if bindIP == "" {
+		bindIP = *mqSqsGatewayOptions.ip
+	}
+	listenAddr := fmt.Sprintf("%s:%d", bindIP, *mqSqsGatewayOptions.port)
+
+	srv, err := sqs.NewServer(sqs.Options{
+		Listen:            listenAddr,
+		ClientHost:        fmt.Sprintf("%s:%d", *mqSqsGatewayOptions.ip, *mqSqsGatewayOptions.port),
+		Masters:           *mqSqsGatewayOptions.master,
+		FilerGroup:        *mqSqsGatewayOptions.filerGroup,
+		Namespace:         *mqSqsGatewayOptions.namespace,
+		Region:            *mqSqsGatewayOptions.region,
+		DefaultPartitions: int32(*mqSqsGatewayOptions.defaultPartitions),
+		Auth:              *mqSqsGatewayOptions.auth,
+		AuthConfig:        *mqSqsGatewayOptions.config,
+	})
+	if err != nil {
+		glog.Fatalf("mq sqs gateway: %v", err)
+		return false
+	}
+	if !*mqSqsGatewayOptions.auth {
+		glog.Warningf("SQS gateway accepts requests without authentication")
+	}
+
+	metricsIp := *mqSqsGatewayOptions.metricsHttpIp
+	if metricsIp == "" {
+		metricsIp = bindIP
+	}
+	go stats_collect.StartMetricsServer(metricsIp, *mqSqsGatewayOptions.metricsHttpPort)
+
+	if err := srv.Start(); err != nil {
+		glog.Fatalf("mq sqs gateway start: %v", err)
+		return false
+	}
+	grace.OnInterrupt(func() {
+		glog.V(0).Infof("Shutting down MQ SQS Gateway...")
+		if err := srv.Close(); err != nil {
+			glog.Errorf("mq sqs gateway close: %v", err)
+		}
+	})
+
+	if err := srv.Wait(); err != nil {
+		glog.Errorf("mq sqs gateway wait: %v", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/meta_cache"
+	"github.com/seaweedfs/seaweedfs/weed/nfs"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/grace"
+	"github.com/seaweedfs/seaweedfs/weed/util/version"
+)
+
+var (
+	nfsStandaloneOptions NfsOption
+)
+
+type NfsOption struct {
+	filer              *string
+	filerRootPath      *string
+	ipBind             *string
+	port               *int
+	collection         *string
+	replication        *string
+	disk               *string
+	dataCenter         *string
+	ttlSec             *int
+	cacheDir           *string
+	cacheSizeMB        *int64
+	chunkSizeLimitMB   *int
+	concurrentWriters  *int
+	writeBehindDelay   *time.Duration
+	volumeServerAccess *string
+	uidMap             *string
+	gidMap             *string
+	allowedNetworks    *string
+}
+
+func init() {
+	cmdNfs.Run = runNfs // break init cycle
+	nfsStandaloneOptions.filer = cmdNfs.Flag.String("filer", "localhost:8888", "filer server address")
+	nfsStandaloneOptions.filerRootPath = cmdNfs.Flag.String("filer.path", "/", "export this remote path from filer server")
+	nfsStandaloneOptions.ipBind = cmdNfs.Flag.String("ip.bind", "", "ip address to bind to. Default listen to all.")
+	nfsStandaloneOptions.port = cmdNfs.Flag.Int("port", 2049, "nfs server listen port, serving both the NFS and MOUNT programs")
+	nfsStandaloneOptions.collection = cmdNfs.Flag.String("collection", "", "collection to create the files")
+	nfsStandaloneOptions.replication = cmdNfs.Flag.String("replication", "", "replication to create the files")
+	nfsStandaloneOptions.disk = cmdNfs.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	nfsStandaloneOptions.dataCenter = cmdNfs.Flag.String("dataCenter", "", "prefer to write to the data center")
+	nfsStandaloneOptions.ttlSec = cmdNfs.Flag.Int("ttlSec", 0, "file ttl in seconds")
+	nfsStandaloneOptions.cacheDir = cmdNfs.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks and write buffers")
+	nfsStandaloneOptions.cacheSizeMB = cmdNfs.Flag.Int64("cacheCapacityMB", 0, "local cache capacity in MB")
+	nfsStandaloneOptions.chunkSizeLimitMB = cmdNfs.Flag.Int("chunkSizeLimitMB", 2, "local write buffer size, also chunk large files")
+	nfsStandaloneOptions.concurrentWriters = cmdNfs.Flag.Int("concurrentWriters", 32, "limit concurrent goroutine writers")
+	nfsStandaloneOptions.writeBehindDelay = cmdNfs.Flag.Duration("writeBehind", 2*time.Second, "save buffered unstable writes to the filer after this delay, if not committed earlier by the client")
+	nfsStandaloneOptions.volumeServerAccess = cmdNfs.Flag.String("volumeServerAccess", "direct", "access volume servers by [direct|publicUrl|filerProxy]")
+	nfsStandaloneOptions.uidMap = cmdNfs.Flag.String("map.uid", "", "map client uid to uid on filer, comma-separated <client_uid>:<filer_uid>")
+	nfsStandaloneOptions.gidMap = cmdNfs.Flag.String("map.gid", "", "map client gid to gid on filer, comma-separated <client_gid>:<filer_gid>")
+	nfsStandaloneOptions.allowedNetworks = cmdNfs.Flag.String("allowedNetworks", "", "comma-separated client networks allowed to mount, as <cidr>[:ro|:rw], e.g. 10.0.0.0/8,192.168.1.0/24:ro. Default allows all clients read-write.")
+}
+
+var cmdNfs = &Command{
+	UsageLine: "nfs -port=2049 -filer=<ip:port> -filer.path=/",
+	Short:     "start an NFSv3 server that is backed by a filer",
+	Long: `start an NFSv3 server that is backed by a filer.
+
+	The NFS and MOUNT programs are served on the same TCP port, without a portmapper.
+	Mount it with the ports given explicitly:
+
+		mount -t nfs -o vers=3,proto=tcp,port=2049,mountport=2049,mountproto=tcp,nolock <host>:/ /mnt/weed
+
+	File handles carry the entry inodes. The inode to path mapping is kept in the filer
+	key-value store, so the handles stay valid after the server restarts.
+	Unstable writes are buffered locally and uploaded as chunks, and saved to the filer
+	on COMMIT, on stable writes, or after the -writeBehind delay.
+
+	Client access is controlled by -allowedNetworks. The most specific matching network decides
+	whether a client can mount, and whether it is read-only.
+	Writes, SETATTR, REMOVE and RENAME check the owner and mode of the entries
+	against the AUTH_UNIX uid and gids of the caller, like a local file system.
+
+`,
+}
+
+func runNfs(cmd *Command, args []string) bool {
+
+	util.LoadSecurityConfiguration()
+
+	listenAddress := fmt.Sprintf("%s:%d", *nfsStandaloneOptions.ipBind, *nfsStandaloneOptions.port)
+	glog.V(0).Infof("Starting Seaweed NFS Server %s at %s", version.Version(), listenAddress)
+
+	return nfsStandaloneOptions.startNfsServer()
+
+}
+
+func (no *NfsOption) startNfsServer() bool {
+
+	uidGidMapper, err := meta_cache.NewUidGidMapper(*no.uidMap, *no.gidMap)
+	if err != nil {
+		glog.Fatalf("invalid uid/gid map: %v", err)
+	}
+	exportAcl, err := nfs.NewExportAcl(*no.allowedNetworks)
+	if err != nil {
+		glog.Fatalf("invalid -allowedNetworks: %v", err)
+	}
+
+	filerAddress := pb.ServerAddress(*no.filer)
+
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	var cipher bool
+	// connect to filer
+	for {
+		err := pb.WithGrpcFilerClient(false, 0, filerAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerAddress, err)
+			}
+			cipher = resp.Cipher
+			return nil
+		})
+		if err != nil {
+			glog.V(2).Infof("wait to connect to filer %s grpc address %s", *no.filer, filerAddress.ToGrpcAddress())
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *no.filer, filerAddress.ToGrpcAddress())
+			break
+		}
+	}
+
+	nfsServer, err := nfs.NewNfsServer(&nfs.Option{
+		Filer:              filerAddress,
+		FilerRootPath:      *no.filerRootPath,
+		GrpcDialOption:     grpcDialOption,
+		Collection:         *no.collection,
+		Replication:        *no.replication,
+		DiskType:           *no.disk,
+		DataCenter:         *no.dataCenter,
+		TtlSec:             int32(*no.ttlSec),
+		Cipher:             cipher,
+		VolumeServerAccess: *no.volumeServerAccess,
+		CacheDir:           util.ResolvePath(*no.cacheDir),
+		CacheSizeMB:        *no.cacheSizeMB,
+		ChunkSizeLimit:     int64(*no.chunkSizeLimitMB) * 1024 * 1024,
+		ConcurrentWriters:  *no.concurrentWriters,
+		WriteBehindDelay:   *no.writeBehindDelay,
+		UidGidMapper:       uidGidMapper,
+		ExportAcl:          exportAcl,
+	})
+	if err != nil {
+		glog.Fatalf("NFS Server startup error: %v", err)
+	}
+
+	listenAddress := fmt.Sprintf("%s:%d", *no.ipBind, *no.port)
+	nfsListener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		glog.Fatalf("NFS Server listener on %s error: %v", listenAddress, err)
+	}
+
+	grace.OnInterrupt(func() {
+		nfsListener.Close()
+		nfsServer.Shutdown()
+	})
+	if MiniClusterCtx != nil {
+		go func() {
+			<-MiniClusterCtx.Done()
+			nfsListener.Close()
+		}()
+	}
+
+	glog.V(0).Infof("Start Seaweed NFS Server %s at %s exporting %s", version.Version(), listenAddress, *no.filerRootPath)
+	if err = nfsServer.Serve(nfsListener); err != nil {
+		glog.V(0).Infof("NFS Server stopped: %v", err)
+	}
+
+	return true
+
+}
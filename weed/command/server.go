@@ -130,12 +130,13 @@ func init() {
 	filerOptions.diskType = cmdServer.Flag.String("filer.disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	filerOptions.exposeDirectoryData = cmdServer.Flag.Bool("filer.exposeDirectoryData", true, "expose directory data via filer. If false, filer UI will be innaccessible.")
 	filerOptions.tusBasePath = cmdServer.Flag.String("filer.tusBasePath", "/.tus", "TUS resumable upload endpoint base path (e.g., /.tus)")
+	filerOptions.http3 = cmdServer.Flag.Bool("filer.http3", false, "also serve HTTP/3 on the udp port of -filer.port, needs https.filer.cert and https.filer.key in security.toml")
 
 	serverOptions.v.port = cmdServer.Flag.Int("volume.port", 8080, "volume server http listen port")
 	serverOptions.v.portGrpc = cmdServer.Flag.Int("volume.port.grpc", 0, "volume server grpc listen port")
 	serverOptions.v.publicPort = cmdServer.Flag.Int("volume.port.public", 0, "volume server public port")
 	serverOptions.v.id = cmdServer.Flag.String("volume.id", "", "volume server id. If empty, default to ip:port")
-	serverOptions.v.indexType = cmdServer.Flag.String("volume.index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge] mode for memory~performance balance.")
+	serverOptions.v.indexType = cmdServer.Flag.String("volume.index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge|pebble] mode for memory~performance balance. pebble shares one db per disk for servers with many volumes.")
 	serverOptions.v.diskType = cmdServer.Flag.String("volume.disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	serverOptions.v.fixJpgOrientation = cmdServer.Flag.Bool("volume.images.fix.orientation", false, "Adjust jpg orientation when uploading.")
 	serverOptions.v.readMode = cmdServer.Flag.String("volume.readMode", "proxy", "[local|proxy|redirect] how to deal with non-local volume: 'not found|read in remote node|redirect volume location'.")
@@ -154,6 +155,14 @@ func init() {
 
 	serverOptions.v.hasSlowRead = cmdServer.Flag.Bool("volume.hasSlowRead", true, "<experimental> if true, this prevents slow reads from blocking other requests, but large file read P99 latency will increase.")
 	serverOptions.v.readBufferSizeMB = cmdServer.Flag.Int("volume.readBufferSizeMB", 4, "<experimental> larger values can optimize query performance but will increase some memory usage,Use with hasSlowRead normally")
+	serverOptions.v.readCacheDir = cmdServer.Flag.String("volume.readCache.dir", "", "local fast disk folders, e.g. NVMe, to cache needles read from remote tiered volumes and erasure coded volumes")
+	serverOptions.v.readCacheCapacityMB = cmdServer.Flag.String("volume.readCache.capacityMB", "1024", "read cache capacity in MB for each volume folder")
+	serverOptions.v.writeConsistency = cmdServer.Flag.String("volume.writeConsistency", "all", "copies of replicated volumes to acknowledge a write, all, quorum or one, optionally per collection, e.g. \"quorum,logs=one\"")
+	serverOptions.v.imageCacheDir = cmdServer.Flag.String("volume.imageCache.dir", "", "local folder to cache transformed images")
+	serverOptions.v.imageCacheCapacityMB = cmdServer.Flag.Int64("volume.imageCache.capacityMB", 1024, "image rendition cache capacity in MB")
+	serverOptions.v.replicationStream = cmdServer.Flag.Bool("volume.replicationStream", true, "write replicas over pipelined gRPC streams, falling back to http for volume servers without support")
+	serverOptions.v.sendFile = cmdServer.Flag.Bool("volume.sendFile", false, "send large needles on local disk to plain http clients with sendfile, without verifying the needle checksum")
+	serverOptions.v.http3 = cmdServer.Flag.Bool("volume.http3", false, "also serve HTTP/3 on the udp port of -volume.port, needs https.volume.cert and https.volume.key in security.toml")
 
 	s3Options.port = cmdServer.Flag.Int("s3.port", 8333, "s3 server http listen port")
 	s3Options.portHttps = cmdServer.Flag.Int("s3.port.https", 0, "s3 server https listen port")
@@ -204,6 +213,7 @@ func init() {
 
 	mqBrokerOptions.port = cmdServer.Flag.Int("mq.broker.port", 17777, "message queue broker gRPC listen port")
 	mqBrokerOptions.logFlushInterval = cmdServer.Flag.Int("mq.broker.logFlushInterval", 5, "log buffer flush interval in seconds")
+	mqBrokerOptions.compactionInterval = cmdServer.Flag.Int("mq.broker.compactionInterval", 600, "interval in seconds to compact topics with cleanup.policy=compact, 0 to disable")
 
 	mqAgentServerOptions.brokersString = cmdServer.Flag.String("mq.agent.brokers", "localhost:17777", "comma-separated message queue brokers")
 	mqAgentServerOptions.port = cmdServer.Flag.Int("mq.agent.port", 16777, "message queue agent gRPC listen port")
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	httppprof "net/http/pprof"
@@ -72,6 +73,14 @@ type VolumeServerOptions struct {
 	hasSlowRead                 *bool
 	readBufferSizeMB            *int
 	ldbTimeout                  *int64
+	readCacheDir                *string
+	readCacheCapacityMB         *string
+	writeConsistency            *string
+	replicationStream           *bool
+	sendFile                    *bool
+	http3                       *bool
+	imageCacheDir               *string
+	imageCacheCapacityMB        *int64
 	debug                       *bool
 	debugPort                   *int
 }
@@ -92,7 +101,7 @@ func init() {
 	v.idleConnectionTimeout = cmdVolume.Flag.Int("idleTimeout", 30, "connection idle seconds")
 	v.dataCenter = cmdVolume.Flag.String("dataCenter", "", "current volume server's data center name")
 	v.rack = cmdVolume.Flag.String("rack", "", "current volume server's rack name")
-	v.indexType = cmdVolume.Flag.String("index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge] mode for memory~performance balance.")
+	v.indexType = cmdVolume.Flag.String("index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge|pebble] mode for memory~performance balance. pebble shares one db per disk for servers with many volumes.")
 	v.diskType = cmdVolume.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	v.fixJpgOrientation = cmdVolume.Flag.Bool("images.fix.orientation", false, "Adjust jpg orientation when uploading.")
 	v.readMode = cmdVolume.Flag.String("readMode", "proxy", "[local|proxy|redirect] how to deal with non-local volume: 'not found|proxy to remote node|redirect volume location'.")
@@ -112,6 +121,14 @@ func init() {
 	v.inflightDownloadDataTimeout = cmdVolume.Flag.Duration("inflightDownloadDataTimeout", 60*time.Second, "inflight download data wait timeout of volume servers")
 	v.hasSlowRead = cmdVolume.Flag.Bool("hasSlowRead", true, "<experimental> if true, this prevents slow reads from blocking other requests, but large file read P99 latency will increase.")
 	v.readBufferSizeMB = cmdVolume.Flag.Int("readBufferSizeMB", 4, "<experimental> larger values can optimize query performance but will increase some memory usage,Use with hasSlowRead normally.")
+	v.readCacheDir = cmdVolume.Flag.String("readCache.dir", "", "local fast disk folders, e.g. NVMe, to cache needles read from remote tiered volumes and erasure coded volumes. One folder for all -dir, or comma-separated with one for each -dir.")
+	v.readCacheCapacityMB = cmdVolume.Flag.String("readCache.capacityMB", "1024", "read cache capacity in MB for each -dir, or comma-separated with one for each -dir")
+	v.writeConsistency = cmdVolume.Flag.String("writeConsistency", "all", "copies of replicated volumes to acknowledge a write, all, quorum or one, optionally per collection, e.g. \"quorum,logs=one\". Writes missed by replicas are replayed later.")
+	v.imageCacheDir = cmdVolume.Flag.String("imageCache.dir", "", "local folder to cache transformed images, e.g. ?width=200&format=webp, keyed by the file id and the transformation")
+	v.imageCacheCapacityMB = cmdVolume.Flag.Int64("imageCache.capacityMB", 1024, "image rendition cache capacity in MB")
+	v.replicationStream = cmdVolume.Flag.Bool("replicationStream", true, "write replicas over pipelined gRPC streams, falling back to http for volume servers without support")
+	v.sendFile = cmdVolume.Flag.Bool("sendFile", false, "send large needles on local disk to plain http clients with sendfile, without verifying the needle checksum")
+	v.http3 = cmdVolume.Flag.Bool("http3", false, "also serve HTTP/3 on the udp port of -port, needs https.volume.cert and https.volume.key in security.toml")
 	v.debug = cmdVolume.Flag.Bool("debug", false, "serves runtime profiling data via pprof on the port specified by -debug.port")
 	v.debugPort = cmdVolume.Flag.Int("debug.port", 6060, "http port for debugging")
 }
@@ -262,6 +279,8 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		volumeNeedleMapKind = storage.NeedleMapLevelDbMedium
 	case "leveldbLarge":
 		volumeNeedleMapKind = storage.NeedleMapLevelDbLarge
+	case "pebble":
+		volumeNeedleMapKind = storage.NeedleMapPebble
 	}
 
 	// Determine volume server ID: if not specified, use ip:port
@@ -286,6 +305,33 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		*v.readBufferSizeMB,
 		*v.ldbTimeout,
 	)
+	if *v.readCacheDir != "" {
+		var readCacheCapacityMBs []int64
+		for _, capacityString := range strings.Split(*v.readCacheCapacityMB, ",") {
+			capacityMB, e := strconv.ParseInt(capacityString, 10, 64)
+			if e != nil {
+				glog.Fatalf("The capacity specified in -readCache.capacityMB not a valid number %s", capacityString)
+			}
+			readCacheCapacityMBs = append(readCacheCapacityMBs, capacityMB)
+		}
+		if err := volumeServer.EnableReadCache(util.StringSplit(*v.readCacheDir, ","), readCacheCapacityMBs); err != nil {
+			glog.Fatalf("enable read cache: %v", err)
+		}
+	}
+	if err := volumeServer.EnableWriteConsistency(*v.writeConsistency); err != nil {
+		glog.Fatalf("enable write consistency: %v", err)
+	}
+	if *v.replicationStream {
+		volumeServer.EnableReplicationStreams()
+	}
+	if *v.sendFile {
+		volumeServer.EnableSendFile()
+	}
+	if *v.imageCacheDir != "" {
+		if err := volumeServer.EnableImageRenditionCache(util.ResolvePath(*v.imageCacheDir), *v.imageCacheCapacityMB); err != nil {
+			glog.Fatalf("enable image rendition cache: %v", err)
+		}
+	}
 	// starting grpc server
 	grpcS := v.startGrpcService(volumeServer)
 
@@ -428,6 +474,22 @@ func (v VolumeServerOptions) startClusterHttpService(handler http.Handler) httpd
 		security.FixTlsConfig(util.GetViper(), httpS.TLSConfig)
 	}
 
+	if *v.http3 {
+		if certFile == "" {
+			glog.Fatalf("volume server HTTP/3 needs https.volume.cert and https.volume.key in security.toml")
+		}
+		tlsConfig := &tls.Config{}
+		if httpS.TLSConfig != nil {
+			tlsConfig = httpS.TLSConfig.Clone()
+		}
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			glog.Fatalf("load https.volume.cert and https.volume.key: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+		httpS.Handler = startHttp3Server("volume server", listeningAddress, tlsConfig, handler)
+	}
+
 	clusterHttpServer := httpDown.Serve(httpS, listener)
 	go func() {
 		if e := clusterHttpServer.Wait(); e != nil {
@@ -204,3 +204,68 @@ func (cm *CredentialManager) GetServiceAccount(ctx context.Context, id string) (
 func (cm *CredentialManager) ListServiceAccounts(ctx context.Context) ([]*iam_pb.ServiceAccount, error) {
 	return cm.store.ListServiceAccounts(ctx)
 }
+
+// CreateGroup creates a new group
+func (cm *CredentialManager) CreateGroup(ctx context.Context, group *iam_pb.Group) error {
+	return cm.store.CreateGroup(ctx, group)
+}
+
+// UpdateGroup updates an existing group
+func (cm *CredentialManager) UpdateGroup(ctx context.Context, name string, group *iam_pb.Group) error {
+	return cm.store.UpdateGroup(ctx, name, group)
+}
+
+// DeleteGroup removes a group
+func (cm *CredentialManager) DeleteGroup(ctx context.Context, name string) error {
+	return cm.store.DeleteGroup(ctx, name)
+}
+
+// GetGroup retrieves a group by name
+func (cm *CredentialManager) GetGroup(ctx context.Context, name string) (*iam_pb.Group, error) {
+	return cm.store.GetGroup(ctx, name)
+}
+
+// ListGroups returns all groups
+func (cm *CredentialManager) ListGroups(ctx context.Context) ([]*iam_pb.Group, error) {
+	return cm.store.ListGroups(ctx)
+}
+
+// CreateRole creates a new role
+func (cm *CredentialManager) CreateRole(ctx context.Context, role *iam_pb.Role) error {
+	return cm.store.CreateRole(ctx, role)
+}
+
+// UpdateRole updates an existing role
+func (cm *CredentialManager) UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error {
+	return cm.store.UpdateRole(ctx, name, role)
+}
+
+// DeleteRole removes a role
+func (cm *CredentialManager) DeleteRole(ctx context.Context, name string) error {
+	return cm.store.DeleteRole(ctx, name)
+}
+
+// GetRole retrieves a role by name
+func (cm *CredentialManager) GetRole(ctx context.Context, name string) (*iam_pb.Role, error) {
+	return cm.store.GetRole(ctx, name)
+}
+
+// ListRoles returns all roles
+func (cm *CredentialManager) ListRoles(ctx context.Context) ([]*iam_pb.Role, error) {
+	return cm.store.ListRoles(ctx)
+}
+
+// GetPolicyVersions retrieves the version history of a managed policy
+func (cm *CredentialManager) GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error) {
+	return cm.store.GetPolicyVersions(ctx, policyName)
+}
+
+// PutPolicyVersions replaces the version history of a managed policy
+func (cm *CredentialManager) PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error {
+	return cm.store.PutPolicyVersions(ctx, versions)
+}
+
+// UpdateAccessKeyLastUsed records the last use of an access key
+func (cm *CredentialManager) UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error {
+	return cm.store.UpdateAccessKeyLastUsed(ctx, username, accessKey, lastUsedDate, service, region)
+}
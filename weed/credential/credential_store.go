@@ -15,6 +15,10 @@ var (
 	ErrUserAlreadyExists      = errors.New("user already exists")
 	ErrAccessKeyNotFound      = errors.New("access key not found")
 	ErrServiceAccountNotFound = errors.New("service account not found")
+	ErrGroupNotFound          = errors.New("group not found")
+	ErrGroupAlreadyExists     = errors.New("group already exists")
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrRoleAlreadyExists      = errors.New("role already exists")
 )
 
 // CredentialStoreTypeName represents the type name of a credential store
@@ -81,6 +85,29 @@ type CredentialStore interface {
 	ListServiceAccounts(ctx context.Context) ([]*iam_pb.ServiceAccount, error)
 	GetServiceAccountByAccessKey(ctx context.Context, accessKey string) (*iam_pb.ServiceAccount, error)
 
+	// Group Management
+	CreateGroup(ctx context.Context, group *iam_pb.Group) error
+	UpdateGroup(ctx context.Context, name string, group *iam_pb.Group) error
+	DeleteGroup(ctx context.Context, name string) error
+	GetGroup(ctx context.Context, name string) (*iam_pb.Group, error)
+	ListGroups(ctx context.Context) ([]*iam_pb.Group, error)
+
+	// Role Management
+	CreateRole(ctx context.Context, role *iam_pb.Role) error
+	UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error
+	DeleteRole(ctx context.Context, name string) error
+	GetRole(ctx context.Context, name string) (*iam_pb.Role, error)
+	ListRoles(ctx context.Context) ([]*iam_pb.Role, error)
+
+	// GetPolicyVersions returns the version history of a managed policy, or nil if it has none.
+	GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error)
+	// PutPolicyVersions replaces the version history of a managed policy.
+	// It is removed together with the policy by DeletePolicy.
+	PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error
+
+	// UpdateAccessKeyLastUsed records when and where an access key was last used
+	UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error
+
 	// Shutdown performs cleanup when the store is being shut down
 	Shutdown()
 }
@@ -0,0 +1,103 @@
+package filer_etc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	IamGroupsDirectory = "groups"
+)
+
+func (store *FilerEtcStore) loadGroupsFromMultiFile(ctx context.Context, s3cfg *iam_pb.S3ApiConfiguration) error {
+	groups, err := store.ListGroups(ctx)
+	if err != nil {
+		return err
+	}
+	s3cfg.Groups = append(s3cfg.Groups, groups...)
+	return nil
+}
+
+func (store *FilerEtcStore) saveGroup(ctx context.Context, group *iam_pb.Group) error {
+	if group == nil {
+		return fmt.Errorf("group is nil")
+	}
+	if err := credential.ValidateGroupName(group.Name); err != nil {
+		return err
+	}
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := json.MarshalIndent(group, "", "  ")
+		if err != nil {
+			return err
+		}
+		return filer.SaveInsideFiler(client, filer.IamConfigDirectory+"/"+IamGroupsDirectory, group.Name+".json", data)
+	})
+}
+
+func (store *FilerEtcStore) CreateGroup(ctx context.Context, group *iam_pb.Group) error {
+	if _, err := store.GetGroup(ctx, group.Name); err == nil {
+		return credential.ErrGroupAlreadyExists
+	} else if err != credential.ErrGroupNotFound {
+		return err
+	}
+	return store.saveGroup(ctx, group)
+}
+
+func (store *FilerEtcStore) UpdateGroup(ctx context.Context, name string, group *iam_pb.Group) error {
+	if group.Name != name {
+		return fmt.Errorf("group name mismatch")
+	}
+	if _, err := store.GetGroup(ctx, name); err != nil {
+		return err
+	}
+	return store.saveGroup(ctx, group)
+}
+
+func (store *FilerEtcStore) DeleteGroup(ctx context.Context, name string) error {
+	if err := credential.ValidateGroupName(name); err != nil {
+		return err
+	}
+	return store.deleteJsonEntry(ctx, IamGroupsDirectory, name, credential.ErrGroupNotFound)
+}
+
+func (store *FilerEtcStore) GetGroup(ctx context.Context, name string) (*iam_pb.Group, error) {
+	if err := credential.ValidateGroupName(name); err != nil {
+		return nil, err
+	}
+	var group *iam_pb.Group
+	err := store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := filer.ReadInsideFiler(client, filer.IamConfigDirectory+"/"+IamGroupsDirectory, name+".json")
+		if err != nil {
+			if err == filer_pb.ErrNotFound {
+				return credential.ErrGroupNotFound
+			}
+			return err
+		}
+		if len(data) == 0 {
+			return credential.ErrGroupNotFound
+		}
+		group = &iam_pb.Group{}
+		return json.Unmarshal(data, group)
+	})
+	return group, err
+}
+
+func (store *FilerEtcStore) ListGroups(ctx context.Context) ([]*iam_pb.Group, error) {
+	var groups []*iam_pb.Group
+	err := store.readJsonEntries(ctx, IamGroupsDirectory, func(name string, content []byte) {
+		group := &iam_pb.Group{}
+		if err := json.Unmarshal(content, group); err != nil {
+			glog.Warningf("Failed to unmarshal group %s: %v", name, err)
+			return
+		}
+		groups = append(groups, group)
+	})
+	return groups, err
+}
@@ -45,7 +45,15 @@ func (store *FilerEtcStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 		return s3cfg, fmt.Errorf("failed to load service accounts: %w", err)
 	}
 
-	// 4. Perform migration if we loaded legacy config
+	// 4. Load groups and roles
+	if err := store.loadGroupsFromMultiFile(ctx, s3cfg); err != nil {
+		return s3cfg, fmt.Errorf("failed to load groups: %w", err)
+	}
+	if err := store.loadRolesFromMultiFile(ctx, s3cfg); err != nil {
+		return s3cfg, fmt.Errorf("failed to load roles: %w", err)
+	}
+
+	// 5. Perform migration if we loaded legacy config
 	// This ensures that all identities (including legacy ones) are written to individual files
 	// and the legacy file is renamed.
 	if foundLegacy {
@@ -171,7 +179,19 @@ func (store *FilerEtcStore) SaveConfiguration(ctx context.Context, config *iam_p
 		}
 	}
 
-	// 3. Cleanup removed identities (Full Sync)
+	// 3. Save all groups and roles
+	for _, group := range config.Groups {
+		if err := store.saveGroup(ctx, group); err != nil {
+			return err
+		}
+	}
+	for _, role := range config.Roles {
+		if err := store.saveRole(ctx, role); err != nil {
+			return err
+		}
+	}
+
+	// 4. Cleanup removed identities (Full Sync)
 	if err := store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 		dir := filer.IamConfigDirectory + "/" + IamIdentitiesDirectory
 		entries, err := listEntries(ctx, client, dir)
@@ -203,7 +223,7 @@ func (store *FilerEtcStore) SaveConfiguration(ctx context.Context, config *iam_p
 		return err
 	}
 
-	// 4. Cleanup removed service accounts (Full Sync)
+	// 5. Cleanup removed service accounts (Full Sync)
 	if err := store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 		dir := filer.IamConfigDirectory + "/" + IamServiceAccountsDirectory
 		entries, err := listEntries(ctx, client, dir)
@@ -234,6 +254,22 @@ func (store *FilerEtcStore) SaveConfiguration(ctx context.Context, config *iam_p
 		return err
 	}
 
+	// 6. Cleanup removed groups and roles (Full Sync)
+	validGroups := make(map[string]bool)
+	for _, group := range config.Groups {
+		validGroups[group.Name+".json"] = true
+	}
+	if err := store.deleteObsoleteEntries(ctx, IamGroupsDirectory, validGroups); err != nil {
+		return err
+	}
+	validRoles := make(map[string]bool)
+	for _, role := range config.Roles {
+		validRoles[role.Name+".json"] = true
+	}
+	if err := store.deleteObsoleteEntries(ctx, IamRolesDirectory, validRoles); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -401,6 +437,24 @@ func (store *FilerEtcStore) CreateAccessKey(ctx context.Context, username string
 	return store.saveIdentity(ctx, identity)
 }
 
+func (store *FilerEtcStore) UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error {
+	identity, err := store.GetUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	for _, cred := range identity.Credentials {
+		if cred.AccessKey == accessKey {
+			cred.LastUsedDate = lastUsedDate
+			cred.LastUsedService = service
+			cred.LastUsedRegion = region
+			return store.saveIdentity(ctx, identity)
+		}
+	}
+
+	return credential.ErrAccessKeyNotFound
+}
+
 func (store *FilerEtcStore) DeleteAccessKey(ctx context.Context, username string, accessKey string) error {
 	identity, err := store.GetUser(ctx, username)
 	if err != nil {
@@ -453,6 +507,89 @@ func (store *FilerEtcStore) readInsideFiler(dir string, name string) ([]byte, bo
 	return content, found, err
 }
 
+// readJsonEntries calls fn with the content of every file in an IAM sub directory
+func (store *FilerEtcStore) readJsonEntries(ctx context.Context, subDir string, fn func(name string, content []byte)) error {
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		dir := filer.IamConfigDirectory + "/" + subDir
+		entries, err := listEntries(ctx, client, dir)
+		if err != nil {
+			if err == filer_pb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDirectory || !strings.HasSuffix(entry.Name, ".json") {
+				continue
+			}
+
+			content := entry.Content
+			if len(content) == 0 {
+				c, err := filer.ReadInsideFiler(client, dir, entry.Name)
+				if err != nil {
+					glog.Warningf("Failed to read %s/%s: %v", subDir, entry.Name, err)
+					continue
+				}
+				content = c
+			}
+			if len(content) > 0 {
+				fn(entry.Name, content)
+			}
+		}
+		return nil
+	})
+}
+
+// deleteObsoleteEntries removes files of an IAM sub directory that are not in validNames
+func (store *FilerEtcStore) deleteObsoleteEntries(ctx context.Context, subDir string, validNames map[string]bool) error {
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		dir := filer.IamConfigDirectory + "/" + subDir
+		entries, err := listEntries(ctx, client, dir)
+		if err != nil {
+			if err == filer_pb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDirectory && !validNames[entry.Name] {
+				if _, err := client.DeleteEntry(ctx, &filer_pb.DeleteEntryRequest{
+					Directory: dir,
+					Name:      entry.Name,
+				}); err != nil {
+					glog.Warningf("Failed to delete obsolete file %s/%s: %v", subDir, entry.Name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// deleteJsonEntry removes name.json from an IAM sub directory, returning notFoundErr if it does not exist
+func (store *FilerEtcStore) deleteJsonEntry(ctx context.Context, subDir string, name string, notFoundErr error) error {
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.DeleteEntry(ctx, &filer_pb.DeleteEntryRequest{
+			Directory: filer.IamConfigDirectory + "/" + subDir,
+			Name:      name + ".json",
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
+				return notFoundErr
+			}
+			return err
+		}
+		if resp != nil && resp.Error != "" {
+			if strings.Contains(resp.Error, filer_pb.ErrNotFound.Error()) {
+				return notFoundErr
+			}
+			return fmt.Errorf("delete %s/%s: %s", subDir, name, resp.Error)
+		}
+		return nil
+	})
+}
+
 func listEntries(ctx context.Context, client filer_pb.SeaweedFilerClient, dir string) ([]*filer_pb.Entry, error) {
 	var entries []*filer_pb.Entry
 	err := filer_pb.SeaweedList(ctx, client, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
@@ -9,12 +9,14 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 )
 
 const (
-	IamPoliciesDirectory     = "policies"
-	IamLegacyPoliciesOldFile = "policies.json.old"
+	IamPoliciesDirectory       = "policies"
+	IamLegacyPoliciesOldFile   = "policies.json.old"
+	IamPolicyVersionsDirectory = "policy_versions"
 )
 
 type PoliciesCollection struct {
@@ -185,17 +187,57 @@ func (store *FilerEtcStore) DeletePolicy(ctx context.Context, name string) error
 		return err
 	}
 	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
-		_, err := client.DeleteEntry(ctx, &filer_pb.DeleteEntryRequest{
-			Directory: filer.IamConfigDirectory + "/" + IamPoliciesDirectory,
-			Name:      name + ".json",
-		})
-		if err != nil && !strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
-			return err
+		for _, dir := range []string{IamPoliciesDirectory, IamPolicyVersionsDirectory} {
+			_, err := client.DeleteEntry(ctx, &filer_pb.DeleteEntryRequest{
+				Directory: filer.IamConfigDirectory + "/" + dir,
+				Name:      name + ".json",
+			})
+			if err != nil && !strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
+// GetPolicyVersions retrieves the version history of a managed policy from the filer
+func (store *FilerEtcStore) GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error) {
+	if err := validatePolicyName(policyName); err != nil {
+		return nil, err
+	}
+
+	var versions *iam_pb.PolicyVersions
+	err := store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := filer.ReadInsideFiler(client, filer.IamConfigDirectory+"/"+IamPolicyVersionsDirectory, policyName+".json")
+		if err != nil {
+			if err == filer_pb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		if len(data) == 0 {
+			return nil
+		}
+		versions = &iam_pb.PolicyVersions{}
+		return json.Unmarshal(data, versions)
+	})
+	return versions, err
+}
+
+// PutPolicyVersions replaces the version history of a managed policy in the filer
+func (store *FilerEtcStore) PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error {
+	if err := validatePolicyName(versions.PolicyName); err != nil {
+		return err
+	}
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := json.MarshalIndent(versions, "", "  ")
+		if err != nil {
+			return err
+		}
+		return filer.SaveInsideFiler(client, filer.IamConfigDirectory+"/"+IamPolicyVersionsDirectory, versions.PolicyName+".json", data)
+	})
+}
+
 // GetPolicy retrieves a specific IAM policy by name from the filer
 func (store *FilerEtcStore) GetPolicy(ctx context.Context, name string) (*policy_engine.PolicyDocument, error) {
 	if err := validatePolicyName(name); err != nil {
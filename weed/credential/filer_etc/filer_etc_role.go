@@ -0,0 +1,105 @@
+package filer_etc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+const (
+	// IamRolesDirectory holds roles managed through the IAM API. It is kept apart from
+	// the STS filer role store, which uses "roles" with a different document format.
+	IamRolesDirectory = "iam_roles"
+)
+
+func (store *FilerEtcStore) loadRolesFromMultiFile(ctx context.Context, s3cfg *iam_pb.S3ApiConfiguration) error {
+	roles, err := store.ListRoles(ctx)
+	if err != nil {
+		return err
+	}
+	s3cfg.Roles = append(s3cfg.Roles, roles...)
+	return nil
+}
+
+func (store *FilerEtcStore) saveRole(ctx context.Context, role *iam_pb.Role) error {
+	if role == nil {
+		return fmt.Errorf("role is nil")
+	}
+	if err := credential.ValidateRoleName(role.Name); err != nil {
+		return err
+	}
+	return store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := json.MarshalIndent(role, "", "  ")
+		if err != nil {
+			return err
+		}
+		return filer.SaveInsideFiler(client, filer.IamConfigDirectory+"/"+IamRolesDirectory, role.Name+".json", data)
+	})
+}
+
+func (store *FilerEtcStore) CreateRole(ctx context.Context, role *iam_pb.Role) error {
+	if _, err := store.GetRole(ctx, role.Name); err == nil {
+		return credential.ErrRoleAlreadyExists
+	} else if err != credential.ErrRoleNotFound {
+		return err
+	}
+	return store.saveRole(ctx, role)
+}
+
+func (store *FilerEtcStore) UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error {
+	if role.Name != name {
+		return fmt.Errorf("role name mismatch")
+	}
+	if _, err := store.GetRole(ctx, name); err != nil {
+		return err
+	}
+	return store.saveRole(ctx, role)
+}
+
+func (store *FilerEtcStore) DeleteRole(ctx context.Context, name string) error {
+	if err := credential.ValidateRoleName(name); err != nil {
+		return err
+	}
+	return store.deleteJsonEntry(ctx, IamRolesDirectory, name, credential.ErrRoleNotFound)
+}
+
+func (store *FilerEtcStore) GetRole(ctx context.Context, name string) (*iam_pb.Role, error) {
+	if err := credential.ValidateRoleName(name); err != nil {
+		return nil, err
+	}
+	var role *iam_pb.Role
+	err := store.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		data, err := filer.ReadInsideFiler(client, filer.IamConfigDirectory+"/"+IamRolesDirectory, name+".json")
+		if err != nil {
+			if err == filer_pb.ErrNotFound {
+				return credential.ErrRoleNotFound
+			}
+			return err
+		}
+		if len(data) == 0 {
+			return credential.ErrRoleNotFound
+		}
+		role = &iam_pb.Role{}
+		return json.Unmarshal(data, role)
+	})
+	return role, err
+}
+
+func (store *FilerEtcStore) ListRoles(ctx context.Context) ([]*iam_pb.Role, error) {
+	var roles []*iam_pb.Role
+	err := store.readJsonEntries(ctx, IamRolesDirectory, func(name string, content []byte) {
+		role := &iam_pb.Role{}
+		if err := json.Unmarshal(content, role); err != nil {
+			glog.Warningf("Failed to unmarshal role %s: %v", name, err)
+			return
+		}
+		roles = append(roles, role)
+	})
+	return roles, err
+}
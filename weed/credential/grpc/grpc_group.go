@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// translateGroupError maps gRPC status codes back to the credential package errors
+func translateGroupError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return credential.ErrGroupNotFound
+		case codes.AlreadyExists:
+			return credential.ErrGroupAlreadyExists
+		}
+	}
+	return err
+}
+
+func (store *IamGrpcStore) CreateGroup(ctx context.Context, group *iam_pb.Group) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.CreateGroup(ctx, &iam_pb.CreateGroupRequest{
+			Group: group,
+		})
+		return translateGroupError(err)
+	})
+}
+
+func (store *IamGrpcStore) UpdateGroup(ctx context.Context, name string, group *iam_pb.Group) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.UpdateGroup(ctx, &iam_pb.UpdateGroupRequest{
+			Name:  name,
+			Group: group,
+		})
+		return translateGroupError(err)
+	})
+}
+
+func (store *IamGrpcStore) DeleteGroup(ctx context.Context, name string) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.DeleteGroup(ctx, &iam_pb.DeleteGroupRequest{
+			Name: name,
+		})
+		return translateGroupError(err)
+	})
+}
+
+func (store *IamGrpcStore) GetGroup(ctx context.Context, name string) (*iam_pb.Group, error) {
+	var group *iam_pb.Group
+	err := store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		resp, err := client.GetGroup(ctx, &iam_pb.GetGroupRequest{
+			Name: name,
+		})
+		if err != nil {
+			return translateGroupError(err)
+		}
+		group = resp.Group
+		return nil
+	})
+	return group, err
+}
+
+func (store *IamGrpcStore) ListGroups(ctx context.Context) ([]*iam_pb.Group, error) {
+	var groups []*iam_pb.Group
+	err := store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		resp, err := client.ListGroups(ctx, &iam_pb.ListGroupsRequest{})
+		if err != nil {
+			return err
+		}
+		groups = resp.Groups
+		return nil
+	})
+	return groups, err
+}
@@ -118,3 +118,16 @@ func (store *IamGrpcStore) DeleteAccessKey(ctx context.Context, username string,
 		return err
 	})
 }
+
+func (store *IamGrpcStore) UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.UpdateAccessKeyLastUsed(ctx, &iam_pb.UpdateAccessKeyLastUsedRequest{
+			Username:     username,
+			AccessKey:    accessKey,
+			LastUsedDate: lastUsedDate,
+			Service:      service,
+			Region:       region,
+		})
+		return err
+	})
+}
@@ -83,3 +83,29 @@ func (store *IamGrpcStore) CreatePolicy(ctx context.Context, name string, docume
 func (store *IamGrpcStore) UpdatePolicy(ctx context.Context, name string, document policy_engine.PolicyDocument) error {
 	return store.PutPolicy(ctx, name, document)
 }
+
+// GetPolicyVersions retrieves the version history of a managed policy
+func (store *IamGrpcStore) GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error) {
+	var versions *iam_pb.PolicyVersions
+	err := store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		resp, err := client.GetPolicyVersions(ctx, &iam_pb.GetPolicyVersionsRequest{
+			PolicyName: policyName,
+		})
+		if err != nil {
+			return err
+		}
+		versions = resp.PolicyVersions
+		return nil
+	})
+	return versions, err
+}
+
+// PutPolicyVersions replaces the version history of a managed policy
+func (store *IamGrpcStore) PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.PutPolicyVersions(ctx, &iam_pb.PutPolicyVersionsRequest{
+			PolicyVersions: versions,
+		})
+		return err
+	})
+}
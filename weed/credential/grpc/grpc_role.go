@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// translateRoleError maps gRPC status codes back to the credential package errors
+func translateRoleError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.NotFound:
+			return credential.ErrRoleNotFound
+		case codes.AlreadyExists:
+			return credential.ErrRoleAlreadyExists
+		}
+	}
+	return err
+}
+
+func (store *IamGrpcStore) CreateRole(ctx context.Context, role *iam_pb.Role) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.CreateRole(ctx, &iam_pb.CreateRoleRequest{
+			Role: role,
+		})
+		return translateRoleError(err)
+	})
+}
+
+func (store *IamGrpcStore) UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.UpdateRole(ctx, &iam_pb.UpdateRoleRequest{
+			Name: name,
+			Role: role,
+		})
+		return translateRoleError(err)
+	})
+}
+
+func (store *IamGrpcStore) DeleteRole(ctx context.Context, name string) error {
+	return store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		_, err := client.DeleteRole(ctx, &iam_pb.DeleteRoleRequest{
+			Name: name,
+		})
+		return translateRoleError(err)
+	})
+}
+
+func (store *IamGrpcStore) GetRole(ctx context.Context, name string) (*iam_pb.Role, error) {
+	var role *iam_pb.Role
+	err := store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		resp, err := client.GetRole(ctx, &iam_pb.GetRoleRequest{
+			Name: name,
+		})
+		if err != nil {
+			return translateRoleError(err)
+		}
+		role = resp.Role
+		return nil
+	})
+	return role, err
+}
+
+func (store *IamGrpcStore) ListRoles(ctx context.Context) ([]*iam_pb.Role, error) {
+	var roles []*iam_pb.Role
+	err := store.withIamClient(func(client iam_pb.SeaweedIdentityAccessManagementClient) error {
+		resp, err := client.ListRoles(ctx, &iam_pb.ListRolesRequest{})
+		if err != nil {
+			return err
+		}
+		roles = resp.Roles
+		return nil
+	})
+	return roles, err
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"google.golang.org/protobuf/proto"
 )
 
 func (store *MemoryStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3ApiConfiguration, error) {
@@ -26,6 +27,13 @@ func (store *MemoryStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3ApiC
 		config.Identities = append(config.Identities, identityCopy)
 	}
 
+	for _, group := range store.groups {
+		config.Groups = append(config.Groups, proto.Clone(group).(*iam_pb.Group))
+	}
+	for _, role := range store.roles {
+		config.Roles = append(config.Roles, proto.Clone(role).(*iam_pb.Role))
+	}
+
 	return config, nil
 }
 
@@ -53,6 +61,15 @@ func (store *MemoryStore) SaveConfiguration(ctx context.Context, config *iam_pb.
 		}
 	}
 
+	store.groups = make(map[string]*iam_pb.Group)
+	for _, group := range config.Groups {
+		store.groups[group.Name] = proto.Clone(group).(*iam_pb.Group)
+	}
+	store.roles = make(map[string]*iam_pb.Role)
+	for _, role := range config.Roles {
+		store.roles[role.Name] = proto.Clone(role).(*iam_pb.Role)
+	}
+
 	return nil
 }
 
@@ -224,8 +241,10 @@ func (store *MemoryStore) CreateAccessKey(ctx context.Context, username string,
 
 	// Add credential to user
 	user.Credentials = append(user.Credentials, &iam_pb.Credential{
-		AccessKey: cred.AccessKey,
-		SecretKey: cred.SecretKey,
+		AccessKey:  cred.AccessKey,
+		SecretKey:  cred.SecretKey,
+		Status:     cred.Status,
+		CreateDate: cred.CreateDate,
 	})
 
 	// Index the access key
@@ -268,6 +287,31 @@ func (store *MemoryStore) DeleteAccessKey(ctx context.Context, username string,
 	return nil
 }
 
+func (store *MemoryStore) UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if !store.initialized {
+		return fmt.Errorf("store not initialized")
+	}
+
+	user, exists := store.users[username]
+	if !exists {
+		return credential.ErrUserNotFound
+	}
+
+	for _, cred := range user.Credentials {
+		if cred.AccessKey == accessKey {
+			cred.LastUsedDate = lastUsedDate
+			cred.LastUsedService = service
+			cred.LastUsedRegion = region
+			return nil
+		}
+	}
+
+	return credential.ErrAccessKeyNotFound
+}
+
 // deepCopyIdentity creates a deep copy of an identity to avoid mutation issues
 func (store *MemoryStore) deepCopyIdentity(identity *iam_pb.Identity) *iam_pb.Identity {
 	if identity == nil {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+	"google.golang.org/protobuf/proto"
 )
 
 // GetPolicies retrieves all IAM policies from memory
@@ -86,5 +88,31 @@ func (store *MemoryStore) DeletePolicy(ctx context.Context, name string) error {
 	}
 
 	delete(store.policies, name)
+	delete(store.policyVersions, name)
+	return nil
+}
+
+// GetPolicyVersions retrieves the version history of a managed policy from memory
+func (store *MemoryStore) GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if versions, exists := store.policyVersions[policyName]; exists {
+		return proto.Clone(versions).(*iam_pb.PolicyVersions), nil
+	}
+
+	return nil, nil // No version history
+}
+
+// PutPolicyVersions replaces the version history of a managed policy in memory
+func (store *MemoryStore) PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if !store.initialized {
+		return fmt.Errorf("store not initialized")
+	}
+
+	store.policyVersions[versions.PolicyName] = proto.Clone(versions).(*iam_pb.PolicyVersions)
 	return nil
 }
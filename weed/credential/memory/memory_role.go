@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func (store *MemoryStore) CreateRole(ctx context.Context, role *iam_pb.Role) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if !store.initialized {
+		return fmt.Errorf("store not initialized")
+	}
+
+	if _, exists := store.roles[role.Name]; exists {
+		return credential.ErrRoleAlreadyExists
+	}
+	store.roles[role.Name] = proto.Clone(role).(*iam_pb.Role)
+	return nil
+}
+
+func (store *MemoryStore) UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if !store.initialized {
+		return fmt.Errorf("store not initialized")
+	}
+
+	if _, exists := store.roles[name]; !exists {
+		return credential.ErrRoleNotFound
+	}
+	if role.Name != name {
+		return fmt.Errorf("role name mismatch")
+	}
+	store.roles[name] = proto.Clone(role).(*iam_pb.Role)
+	return nil
+}
+
+func (store *MemoryStore) DeleteRole(ctx context.Context, name string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, exists := store.roles[name]; !exists {
+		return credential.ErrRoleNotFound
+	}
+	delete(store.roles, name)
+	return nil
+}
+
+func (store *MemoryStore) GetRole(ctx context.Context, name string) (*iam_pb.Role, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	if role, exists := store.roles[name]; exists {
+		return proto.Clone(role).(*iam_pb.Role), nil
+	}
+	return nil, credential.ErrRoleNotFound
+}
+
+func (store *MemoryStore) ListRoles(ctx context.Context) ([]*iam_pb.Role, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	var roles []*iam_pb.Role
+	for _, role := range store.roles {
+		roles = append(roles, proto.Clone(role).(*iam_pb.Role))
+	}
+	return roles, nil
+}
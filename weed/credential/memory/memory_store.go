@@ -23,6 +23,9 @@ type MemoryStore struct {
 	serviceAccounts          map[string]*iam_pb.ServiceAccount       // id -> service_account
 	serviceAccountAccessKeys map[string]string                       // access_key -> id
 	policies                 map[string]policy_engine.PolicyDocument // policy_name -> policy_document
+	policyVersions           map[string]*iam_pb.PolicyVersions       // policy_name -> version history
+	groups                   map[string]*iam_pb.Group                // group_name -> group
+	roles                    map[string]*iam_pb.Role                 // role_name -> role
 	initialized              bool
 }
 
@@ -43,6 +46,9 @@ func (store *MemoryStore) Initialize(configuration util.Configuration, prefix st
 	store.serviceAccounts = make(map[string]*iam_pb.ServiceAccount)
 	store.serviceAccountAccessKeys = make(map[string]string)
 	store.policies = make(map[string]policy_engine.PolicyDocument)
+	store.policyVersions = make(map[string]*iam_pb.PolicyVersions)
+	store.groups = make(map[string]*iam_pb.Group)
+	store.roles = make(map[string]*iam_pb.Role)
 	store.initialized = true
 
 	return nil
@@ -57,6 +63,9 @@ func (store *MemoryStore) Shutdown() {
 	store.serviceAccounts = nil
 	store.serviceAccountAccessKeys = nil
 	store.policies = nil
+	store.policyVersions = nil
+	store.groups = nil
+	store.roles = nil
 	store.initialized = false
 }
 
@@ -71,6 +80,9 @@ func (store *MemoryStore) Reset() {
 		store.serviceAccounts = make(map[string]*iam_pb.ServiceAccount)
 		store.serviceAccountAccessKeys = make(map[string]string)
 		store.policies = make(map[string]policy_engine.PolicyDocument)
+		store.policyVersions = make(map[string]*iam_pb.PolicyVersions)
+		store.groups = make(map[string]*iam_pb.Group)
+		store.roles = make(map[string]*iam_pb.Role)
 	}
 }
 
@@ -378,3 +378,62 @@ func TestMemoryStoreServiceAccountByAccessKey(t *testing.T) {
 		t.Errorf("Expected ErrAccessKeyNotFound after delete, got %v", err)
 	}
 }
+
+func TestMemoryStoreGroupsRolesAndPolicyVersions(t *testing.T) {
+	store := &MemoryStore{}
+	if err := store.Initialize(util.GetViper(), "credential."); err != nil {
+		t.Fatalf("Failed to initialize store: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.CreateGroup(ctx, &iam_pb.Group{Name: "developers", Members: []string{"alice"}}); err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	if err := store.CreateGroup(ctx, &iam_pb.Group{Name: "developers"}); err != credential.ErrGroupAlreadyExists {
+		t.Errorf("Expected ErrGroupAlreadyExists, got %v", err)
+	}
+	if err := store.CreateRole(ctx, &iam_pb.Role{Name: "reader", PolicyNames: []string{"read"}}); err != nil {
+		t.Fatalf("Failed to create role: %v", err)
+	}
+	if _, err := store.GetRole(ctx, "writer"); err != credential.ErrRoleNotFound {
+		t.Errorf("Expected ErrRoleNotFound, got %v", err)
+	}
+
+	versions := &iam_pb.PolicyVersions{
+		PolicyName:       "read",
+		Versions:         []*iam_pb.PolicyVersion{{VersionId: "v1", Document: "{}"}},
+		DefaultVersionId: "v1",
+		NextVersion:      2,
+	}
+	if err := store.PutPolicyVersions(ctx, versions); err != nil {
+		t.Fatalf("Failed to put policy versions: %v", err)
+	}
+	retrievedVersions, err := store.GetPolicyVersions(ctx, "read")
+	if err != nil || retrievedVersions == nil || retrievedVersions.DefaultVersionId != "v1" {
+		t.Errorf("Unexpected policy versions %v: %v", retrievedVersions, err)
+	}
+
+	// Groups and roles are part of the saved configuration
+	config, err := store.LoadConfiguration(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+	if len(config.Groups) != 1 || len(config.Roles) != 1 {
+		t.Errorf("Expected 1 group and 1 role, got %d and %d", len(config.Groups), len(config.Roles))
+	}
+	config.Groups = nil
+	if err := store.SaveConfiguration(ctx, config); err != nil {
+		t.Fatalf("Failed to save configuration: %v", err)
+	}
+	if groups, _ := store.ListGroups(ctx); len(groups) != 0 {
+		t.Errorf("Expected groups to be removed by SaveConfiguration, got %d", len(groups))
+	}
+
+	// Deleting a policy removes its version history
+	if err := store.DeletePolicy(ctx, "read"); err != nil {
+		t.Fatalf("Failed to delete policy: %v", err)
+	}
+	if retrievedVersions, _ := store.GetPolicyVersions(ctx, "read"); retrievedVersions != nil {
+		t.Errorf("Expected policy versions to be deleted, got %v", retrievedVersions)
+	}
+}
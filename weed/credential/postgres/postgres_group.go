@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+func (store *PostgresStore) CreateGroup(ctx context.Context, group *iam_pb.Group) error {
+	if group == nil {
+		return fmt.Errorf("group is nil")
+	}
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to marshal group: %w", err)
+	}
+
+	result, err := store.db.ExecContext(ctx,
+		"INSERT INTO iam_groups (name, content) VALUES ($1, $2) ON CONFLICT (name) DO NOTHING",
+		group.Name, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert group: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrGroupAlreadyExists
+	}
+	return nil
+}
+
+func (store *PostgresStore) UpdateGroup(ctx context.Context, name string, group *iam_pb.Group) error {
+	if group == nil {
+		return fmt.Errorf("group is nil")
+	}
+	if group.Name != name {
+		return fmt.Errorf("group name mismatch")
+	}
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	data, err := json.Marshal(group)
+	if err != nil {
+		return fmt.Errorf("failed to marshal group: %w", err)
+	}
+
+	result, err := store.db.ExecContext(ctx,
+		"UPDATE iam_groups SET content = $2, updated_at = CURRENT_TIMESTAMP WHERE name = $1",
+		name, data)
+	if err != nil {
+		return fmt.Errorf("failed to update group: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrGroupNotFound
+	}
+	return nil
+}
+
+func (store *PostgresStore) DeleteGroup(ctx context.Context, name string) error {
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	result, err := store.db.ExecContext(ctx, "DELETE FROM iam_groups WHERE name = $1", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrGroupNotFound
+	}
+	return nil
+}
+
+func (store *PostgresStore) GetGroup(ctx context.Context, name string) (*iam_pb.Group, error) {
+	if !store.configured {
+		return nil, fmt.Errorf("store not configured")
+	}
+
+	var content []byte
+	err := store.db.QueryRowContext(ctx, "SELECT content FROM iam_groups WHERE name = $1", name).Scan(&content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, credential.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	group := &iam_pb.Group{}
+	if err := json.Unmarshal(content, group); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+	return group, nil
+}
+
+func (store *PostgresStore) ListGroups(ctx context.Context) ([]*iam_pb.Group, error) {
+	if !store.configured {
+		return nil, fmt.Errorf("store not configured")
+	}
+
+	rows, err := store.db.QueryContext(ctx, "SELECT content FROM iam_groups ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*iam_pb.Group
+	for rows.Next() {
+		var content []byte
+		if err := rows.Scan(&content); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		group := &iam_pb.Group{}
+		if err := json.Unmarshal(content, group); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating groups: %w", err)
+	}
+
+	return groups, nil
+}
@@ -51,28 +51,32 @@ func (store *PostgresStore) LoadConfiguration(ctx context.Context) (*iam_pb.S3Ap
 		}
 
 		// Query credentials for this user
-		credRows, err := store.db.QueryContext(ctx, "SELECT access_key, secret_key FROM credentials WHERE username = $1", username)
+		credRows, err := store.db.QueryContext(ctx, selectCredentialsQuery, username)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query credentials for user %s: %v", username, err)
 		}
 
 		for credRows.Next() {
-			var accessKey, secretKey string
-			if err := credRows.Scan(&accessKey, &secretKey); err != nil {
+			cred, err := scanCredential(credRows)
+			if err != nil {
 				credRows.Close()
 				return nil, fmt.Errorf("failed to scan credential row for user %s: %v", username, err)
 			}
 
-			identity.Credentials = append(identity.Credentials, &iam_pb.Credential{
-				AccessKey: accessKey,
-				SecretKey: secretKey,
-			})
+			identity.Credentials = append(identity.Credentials, cred)
 		}
 		credRows.Close()
 
 		config.Identities = append(config.Identities, identity)
 	}
 
+	if config.Groups, err = store.ListGroups(ctx); err != nil {
+		return nil, err
+	}
+	if config.Roles, err = store.ListRoles(ctx); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -127,17 +131,59 @@ func (store *PostgresStore) SaveConfiguration(ctx context.Context, config *iam_p
 		// Insert credentials
 		for _, cred := range identity.Credentials {
 			_, err := tx.ExecContext(ctx,
-				"INSERT INTO credentials (username, access_key, secret_key) VALUES ($1, $2, $3)",
-				identity.Name, cred.AccessKey, cred.SecretKey)
+				insertCredentialQuery, insertCredentialArgs(identity.Name, cred)...)
 			if err != nil {
 				return fmt.Errorf("failed to insert credential for user %s: %v", identity.Name, err)
 			}
 		}
 	}
 
+	// Replace groups and roles
+	if _, err := tx.ExecContext(ctx, "DELETE FROM iam_groups"); err != nil {
+		return fmt.Errorf("failed to clear groups: %w", err)
+	}
+	for _, group := range config.Groups {
+		data, err := json.Marshal(group)
+		if err != nil {
+			return fmt.Errorf("failed to marshal group %s: %v", group.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO iam_groups (name, content) VALUES ($1, $2)", group.Name, data); err != nil {
+			return fmt.Errorf("failed to insert group %s: %v", group.Name, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM iam_roles"); err != nil {
+		return fmt.Errorf("failed to clear roles: %w", err)
+	}
+	for _, role := range config.Roles {
+		data, err := json.Marshal(role)
+		if err != nil {
+			return fmt.Errorf("failed to marshal role %s: %v", role.Name, err)
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO iam_roles (name, content) VALUES ($1, $2)", role.Name, data); err != nil {
+			return fmt.Errorf("failed to insert role %s: %v", role.Name, err)
+		}
+	}
+
 	return tx.Commit()
 }
 
+const (
+	insertCredentialQuery = `INSERT INTO credentials (username, access_key, secret_key, status, create_date, last_used_date, last_used_service, last_used_region)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	selectCredentialsQuery = `SELECT access_key, secret_key, status, create_date, last_used_date, last_used_service, last_used_region
+		FROM credentials WHERE username = $1`
+)
+
+func insertCredentialArgs(username string, cred *iam_pb.Credential) []any {
+	return []any{username, cred.AccessKey, cred.SecretKey, cred.Status, cred.CreateDate, cred.LastUsedDate, cred.LastUsedService, cred.LastUsedRegion}
+}
+
+func scanCredential(rows *sql.Rows) (*iam_pb.Credential, error) {
+	cred := &iam_pb.Credential{}
+	err := rows.Scan(&cred.AccessKey, &cred.SecretKey, &cred.Status, &cred.CreateDate, &cred.LastUsedDate, &cred.LastUsedService, &cred.LastUsedRegion)
+	return cred, err
+}
+
 func (store *PostgresStore) CreateUser(ctx context.Context, identity *iam_pb.Identity) error {
 	if !store.configured {
 		return fmt.Errorf("store not configured")
@@ -189,8 +235,7 @@ func (store *PostgresStore) CreateUser(ctx context.Context, identity *iam_pb.Ide
 	// Insert credentials
 	for _, cred := range identity.Credentials {
 		_, err = tx.ExecContext(ctx,
-			"INSERT INTO credentials (username, access_key, secret_key) VALUES ($1, $2, $3)",
-			identity.Name, cred.AccessKey, cred.SecretKey)
+			insertCredentialQuery, insertCredentialArgs(identity.Name, cred)...)
 		if err != nil {
 			return fmt.Errorf("failed to insert credential: %w", err)
 		}
@@ -236,22 +281,19 @@ func (store *PostgresStore) GetUser(ctx context.Context, username string) (*iam_
 	}
 
 	// Query credentials
-	rows, err := store.db.QueryContext(ctx, "SELECT access_key, secret_key FROM credentials WHERE username = $1", username)
+	rows, err := store.db.QueryContext(ctx, selectCredentialsQuery, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query credentials: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var accessKey, secretKey string
-		if err := rows.Scan(&accessKey, &secretKey); err != nil {
+		cred, err := scanCredential(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan credential: %w", err)
 		}
 
-		identity.Credentials = append(identity.Credentials, &iam_pb.Credential{
-			AccessKey: accessKey,
-			SecretKey: secretKey,
-		})
+		identity.Credentials = append(identity.Credentials, cred)
 	}
 
 	return identity, nil
@@ -314,8 +356,7 @@ func (store *PostgresStore) UpdateUser(ctx context.Context, username string, ide
 	// Insert new credentials
 	for _, cred := range identity.Credentials {
 		_, err = tx.ExecContext(ctx,
-			"INSERT INTO credentials (username, access_key, secret_key) VALUES ($1, $2, $3)",
-			username, cred.AccessKey, cred.SecretKey)
+			insertCredentialQuery, insertCredentialArgs(username, cred)...)
 		if err != nil {
 			return fmt.Errorf("failed to insert credential: %w", err)
 		}
@@ -403,8 +444,7 @@ func (store *PostgresStore) CreateAccessKey(ctx context.Context, username string
 
 	// Insert credential
 	_, err = store.db.ExecContext(ctx,
-		"INSERT INTO credentials (username, access_key, secret_key) VALUES ($1, $2, $3)",
-		username, cred.AccessKey, cred.SecretKey)
+		insertCredentialQuery, insertCredentialArgs(username, cred)...)
 	if err != nil {
 		return fmt.Errorf("failed to insert credential: %w", err)
 	}
@@ -444,3 +484,26 @@ func (store *PostgresStore) DeleteAccessKey(ctx context.Context, username string
 
 	return nil
 }
+
+func (store *PostgresStore) UpdateAccessKeyLastUsed(ctx context.Context, username string, accessKey string, lastUsedDate int64, service string, region string) error {
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	result, err := store.db.ExecContext(ctx,
+		"UPDATE credentials SET last_used_date = $3, last_used_service = $4, last_used_region = $5 WHERE username = $1 AND access_key = $2",
+		username, accessKey, lastUsedDate, service, region)
+	if err != nil {
+		return fmt.Errorf("failed to update access key last used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return credential.ErrAccessKeyNotFound
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 )
 
@@ -107,6 +109,9 @@ func (store *PostgresStore) DeletePolicy(ctx context.Context, name string) error
 	if err != nil {
 		return fmt.Errorf("failed to delete policy: %w", err)
 	}
+	if _, err := store.db.ExecContext(ctx, "DELETE FROM policy_versions WHERE name = $1", name); err != nil {
+		return fmt.Errorf("failed to delete policy versions: %w", err)
+	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
@@ -133,3 +138,46 @@ func (store *PostgresStore) GetPolicy(ctx context.Context, name string) (*policy
 
 	return nil, nil // Policy not found
 }
+
+// GetPolicyVersions retrieves the version history of a managed policy from PostgreSQL
+func (store *PostgresStore) GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error) {
+	if !store.configured {
+		return nil, fmt.Errorf("store not configured")
+	}
+
+	var content []byte
+	err := store.db.QueryRowContext(ctx, "SELECT content FROM policy_versions WHERE name = $1", policyName).Scan(&content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get policy versions: %w", err)
+	}
+
+	versions := &iam_pb.PolicyVersions{}
+	if err := json.Unmarshal(content, versions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy versions: %w", err)
+	}
+	return versions, nil
+}
+
+// PutPolicyVersions replaces the version history of a managed policy in PostgreSQL
+func (store *PostgresStore) PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error {
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	data, err := json.Marshal(versions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy versions: %w", err)
+	}
+
+	_, err = store.db.ExecContext(ctx,
+		`INSERT INTO policy_versions (name, content) VALUES ($1, $2)
+		 ON CONFLICT (name) DO UPDATE SET content = $2, updated_at = CURRENT_TIMESTAMP`,
+		versions.PolicyName, data)
+	if err != nil {
+		return fmt.Errorf("failed to put policy versions: %w", err)
+	}
+	return nil
+}
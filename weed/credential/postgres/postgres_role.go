@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+func (store *PostgresStore) CreateRole(ctx context.Context, role *iam_pb.Role) error {
+	if role == nil {
+		return fmt.Errorf("role is nil")
+	}
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		return fmt.Errorf("failed to marshal role: %w", err)
+	}
+
+	result, err := store.db.ExecContext(ctx,
+		"INSERT INTO iam_roles (name, content) VALUES ($1, $2) ON CONFLICT (name) DO NOTHING",
+		role.Name, data)
+	if err != nil {
+		return fmt.Errorf("failed to insert role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrRoleAlreadyExists
+	}
+	return nil
+}
+
+func (store *PostgresStore) UpdateRole(ctx context.Context, name string, role *iam_pb.Role) error {
+	if role == nil {
+		return fmt.Errorf("role is nil")
+	}
+	if role.Name != name {
+		return fmt.Errorf("role name mismatch")
+	}
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	data, err := json.Marshal(role)
+	if err != nil {
+		return fmt.Errorf("failed to marshal role: %w", err)
+	}
+
+	result, err := store.db.ExecContext(ctx,
+		"UPDATE iam_roles SET content = $2, updated_at = CURRENT_TIMESTAMP WHERE name = $1",
+		name, data)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (store *PostgresStore) DeleteRole(ctx context.Context, name string) error {
+	if !store.configured {
+		return fmt.Errorf("store not configured")
+	}
+
+	result, err := store.db.ExecContext(ctx, "DELETE FROM iam_roles WHERE name = $1", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return credential.ErrRoleNotFound
+	}
+	return nil
+}
+
+func (store *PostgresStore) GetRole(ctx context.Context, name string) (*iam_pb.Role, error) {
+	if !store.configured {
+		return nil, fmt.Errorf("store not configured")
+	}
+
+	var content []byte
+	err := store.db.QueryRowContext(ctx, "SELECT content FROM iam_roles WHERE name = $1", name).Scan(&content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, credential.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	role := &iam_pb.Role{}
+	if err := json.Unmarshal(content, role); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal role: %w", err)
+	}
+	return role, nil
+}
+
+func (store *PostgresStore) ListRoles(ctx context.Context) ([]*iam_pb.Role, error) {
+	if !store.configured {
+		return nil, fmt.Errorf("store not configured")
+	}
+
+	rows, err := store.db.QueryContext(ctx, "SELECT content FROM iam_roles ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []*iam_pb.Role
+	for rows.Next() {
+		var content []byte
+		if err := rows.Scan(&content); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		role := &iam_pb.Role{}
+		if err := json.Unmarshal(content, role); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating roles: %w", err)
+	}
+
+	return roles, nil
+}
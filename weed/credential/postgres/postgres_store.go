@@ -110,6 +110,11 @@ func (store *PostgresStore) createTables() error {
 		);
 		CREATE INDEX IF NOT EXISTS idx_credentials_username ON credentials(username);
 		CREATE INDEX IF NOT EXISTS idx_credentials_access_key ON credentials(access_key);
+		ALTER TABLE credentials ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT '';
+		ALTER TABLE credentials ADD COLUMN IF NOT EXISTS create_date BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE credentials ADD COLUMN IF NOT EXISTS last_used_date BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE credentials ADD COLUMN IF NOT EXISTS last_used_service VARCHAR(64) NOT NULL DEFAULT '';
+		ALTER TABLE credentials ADD COLUMN IF NOT EXISTS last_used_region VARCHAR(64) NOT NULL DEFAULT '';
 	`
 
 	// Create policies table
@@ -134,6 +139,31 @@ func (store *PostgresStore) createTables() error {
 		);
 	`
 
+	// Create groups, roles and policy version tables
+	groupsTable := `
+		CREATE TABLE IF NOT EXISTS iam_groups (
+			name VARCHAR(255) PRIMARY KEY,
+			content JSONB NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	rolesTable := `
+		CREATE TABLE IF NOT EXISTS iam_roles (
+			name VARCHAR(255) PRIMARY KEY,
+			content JSONB NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	policyVersionsTable := `
+		CREATE TABLE IF NOT EXISTS policy_versions (
+			name VARCHAR(255) PRIMARY KEY,
+			content JSONB NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+
 	// Execute table creation
 	if _, err := store.db.Exec(usersTable); err != nil {
 		return fmt.Errorf("failed to create users table: %w", err)
@@ -151,6 +181,18 @@ func (store *PostgresStore) createTables() error {
 		return fmt.Errorf("failed to create service_accounts table: %w", err)
 	}
 
+	if _, err := store.db.Exec(groupsTable); err != nil {
+		return fmt.Errorf("failed to create iam_groups table: %w", err)
+	}
+
+	if _, err := store.db.Exec(rolesTable); err != nil {
+		return fmt.Errorf("failed to create iam_roles table: %w", err)
+	}
+
+	if _, err := store.db.Exec(policyVersionsTable); err != nil {
+		return fmt.Errorf("failed to create policy_versions table: %w", err)
+	}
+
 	return nil
 }
 
@@ -8,6 +8,9 @@ import (
 var (
 	PolicyNamePattern       = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
 	ServiceAccountIdPattern = regexp.MustCompile(`^sa:[A-Za-z0-9_-]+:[a-z0-9-]+$`)
+	// Group and role names follow the AWS IAM character set and length limits
+	GroupNamePattern = regexp.MustCompile(`^[\w+=,.@-]{1,128}$`)
+	RoleNamePattern  = regexp.MustCompile(`^[\w+=,.@-]{1,64}$`)
 )
 
 func ValidatePolicyName(name string) error {
@@ -26,3 +29,17 @@ func ValidateServiceAccountId(id string) error {
 	}
 	return nil
 }
+
+func ValidateGroupName(name string) error {
+	if !GroupNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid group name: %s", name)
+	}
+	return nil
+}
+
+func ValidateRoleName(name string) error {
+	if !RoleNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid role name: %s", name)
+	}
+	return nil
+}
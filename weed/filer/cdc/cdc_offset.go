@@ -0,0 +1,77 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/grpc"
+)
+
+const (
+	OffsetKeyPrefix = "cdc.offset."
+)
+
+// offsetKey identifies one pipeline by its topic and watched directories.
+func offsetKey(topic string, pathFilter *PathFilter) []byte {
+	name := topic
+	for _, prefix := range pathFilter.Prefixes() {
+		name += "," + prefix
+	}
+	key := []byte(OffsetKeyPrefix + "____")
+	util.Uint32toBytes(key[len(OffsetKeyPrefix):len(OffsetKeyPrefix)+4], uint32(util.HashStringToLong(name)))
+	return key
+}
+
+// GetOffset reads the timestamp of the last event published to the topic, or 0 if none.
+func GetOffset(grpcDialOption grpc.DialOption, filer pb.ServerAddress, topic string, pathFilter *PathFilter) (lastOffsetTsNs int64, readErr error) {
+
+	readErr = pb.WithFilerClient(false, 0, filer, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: offsetKey(topic, pathFilter)})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Error) != 0 {
+			return errors.New(resp.Error)
+		}
+		if len(resp.Value) < 8 {
+			return nil
+		}
+
+		lastOffsetTsNs = int64(util.BytesToUint64(resp.Value))
+
+		return nil
+	})
+
+	return
+
+}
+
+// SetOffset records that all events up to offsetTsNs have been published to the topic.
+func SetOffset(grpcDialOption grpc.DialOption, filer pb.ServerAddress, topic string, pathFilter *PathFilter, offsetTsNs int64) error {
+
+	return pb.WithFilerClient(false, 0, filer, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+
+		valueBuf := make([]byte, 8)
+		util.Uint64toBytes(valueBuf, uint64(offsetTsNs))
+
+		resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{
+			Key:   offsetKey(topic, pathFilter),
+			Value: valueBuf,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Error) != 0 {
+			return errors.New(resp.Error)
+		}
+
+		return nil
+
+	})
+
+}
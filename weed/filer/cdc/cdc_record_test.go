@@ -0,0 +1,89 @@
+package cdc
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestEventToRecord(t *testing.T) {
+	pathFilter := NewPathFilter([]string{"/data/x"})
+	entry := &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{FileSize: 42, Mtime: 1700000000, Uid: 1000, UserName: "alice"},
+		Chunks:     []*filer_pb.FileChunk{{FileId: "1,01", Size: 42}},
+	}
+
+	tests := []struct {
+		name      string
+		resp      *filer_pb.SubscribeMetadataResponse
+		eventType string
+		path      string
+		oldPath   string
+	}{
+		{
+			name: "create",
+			resp: &filer_pb.SubscribeMetadataResponse{Directory: "/data/x", EventNotification: &filer_pb.EventNotification{
+				NewEntry: entry, NewParentPath: "/data/x"}},
+			eventType: EventCreate, path: "/data/x/a.txt",
+		},
+		{
+			name: "update",
+			resp: &filer_pb.SubscribeMetadataResponse{Directory: "/data/x", EventNotification: &filer_pb.EventNotification{
+				OldEntry: entry, NewEntry: entry, NewParentPath: "/data/x"}},
+			eventType: EventUpdate, path: "/data/x/a.txt",
+		},
+		{
+			name: "delete",
+			resp: &filer_pb.SubscribeMetadataResponse{Directory: "/data/x", EventNotification: &filer_pb.EventNotification{
+				OldEntry: entry}},
+			eventType: EventDelete, path: "/data/x/a.txt",
+		},
+		{
+			name: "rename out of the watched directory",
+			resp: &filer_pb.SubscribeMetadataResponse{Directory: "/data/x", EventNotification: &filer_pb.EventNotification{
+				OldEntry: entry, NewEntry: entry, NewParentPath: "/archive"}},
+			eventType: EventRename, path: "/archive/a.txt", oldPath: "/data/x/a.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, record, ok := EventToRecord(tt.resp, pathFilter)
+			if !ok {
+				t.Fatalf("event skipped")
+			}
+			if string(key) != tt.path {
+				t.Errorf("key = %s, want %s", key, tt.path)
+			}
+			if got := record.Fields[FieldEventType].GetStringValue(); got != tt.eventType {
+				t.Errorf("event type = %s, want %s", got, tt.eventType)
+			}
+			if got := record.Fields[FieldOldPath].GetStringValue(); got != tt.oldPath {
+				t.Errorf("old path = %s, want %s", got, tt.oldPath)
+			}
+			if got := record.Fields[FieldSize].GetInt64Value(); got != 42 {
+				t.Errorf("size = %d, want 42", got)
+			}
+			if got := record.Fields[FieldOwner].GetStringValue(); got != "alice" {
+				t.Errorf("owner = %s, want alice", got)
+			}
+			if got := record.Fields[FieldChunkCount].GetInt32Value(); got != 1 {
+				t.Errorf("chunk count = %d, want 1", got)
+			}
+			if got := record.Fields[FieldMtime].GetTimestampValue().GetTimestampMicros(); got != 1700000000_000000 {
+				t.Errorf("mtime = %d", got)
+			}
+			for _, field := range RecordType().Fields {
+				if _, found := record.Fields[field.Name]; !found {
+					t.Errorf("missing field %s", field.Name)
+				}
+			}
+		})
+	}
+
+	outside := &filer_pb.SubscribeMetadataResponse{Directory: "/data/xy", EventNotification: &filer_pb.EventNotification{
+		NewEntry: entry, NewParentPath: "/data/xy"}}
+	if _, _, ok := EventToRecord(outside, pathFilter); ok {
+		t.Errorf("expected event outside /data/x to be skipped")
+	}
+}
@@ -73,6 +73,14 @@ func (f *Filer) LoadConfiguration(config *util.ViperProxy) (isFresh bool) {
 		if err := store.Initialize(config, key+"."); err != nil {
 			glog.Fatalf("Failed to initialize store for %s: %+v", key, err)
 		}
+		if storeId == StoreMigrationConfigId {
+			// the target of an online migration of the default store
+			if fsw, ok := f.Store.(*FilerStoreWrapper); ok {
+				f.StoreMigration = fsw.MigrateDefaultStoreTo(store)
+				glog.V(0).Infof("configure filer %s as migration target of %s", store.GetName(), fsw.GetName())
+			}
+			continue
+		}
 		location := config.GetString(key + ".location")
 		if location == "" {
 			glog.Errorf("path-specific filer store needs %s", key+".location")
@@ -0,0 +1,151 @@
+package filer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/karlseguin/ccache/v2"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/images"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+)
+
+// ImageRenditionCache keeps the recently read renditions in memory, up to a total size,
+// keyed by the entry path and etag and the negotiated transformation.
+type ImageRenditionCache struct {
+	cache *ccache.Cache
+}
+
+type imageRendition struct {
+	data        []byte
+	contentType string
+}
+
+// Size is the cost of the rendition in the cache
+func (r *imageRendition) Size() int64 {
+	return int64(len(r.data))
+}
+
+func NewImageRenditionCache(capacity int64) *ImageRenditionCache {
+	return &ImageRenditionCache{
+		cache: ccache.New(ccache.Configure().MaxSize(capacity).ItemsToPrune(16)),
+	}
+}
+
+func (c *ImageRenditionCache) Get(key string) (data []byte, contentType string, found bool) {
+	item := c.cache.Get(key)
+	if item == nil || item.Expired() {
+		return nil, "", false
+	}
+	rendition := item.Value().(*imageRendition)
+	return rendition.data, rendition.contentType, true
+}
+
+func (c *ImageRenditionCache) Set(key string, data []byte, contentType string) {
+	c.cache.Set(key, &imageRendition{data: data, contentType: contentType}, time.Hour)
+}
+
+// ImageRenditionKey identifies the rendition of the entry content with the etag
+func ImageRenditionKey(fullPath string, etag string, transform *images.Transform) string {
+	return fullPath + "\x00" + etag + "\x00" + transform.Key()
+}
+
+// ReadImageRendition returns the transformed image of an entry, for the formats in the Accept header.
+// An image in one chunk is transformed by the volume server holding the chunk, which caches the renditions.
+// Other images are read and transformed here.
+// The filer keeps the renditions of both in an ImageRenditionCache.
+// The extension, e.g. ".jpg", tells the volume server the chunk is an image.
+// The transformations forwarded to the volume servers are signed with the signing key, if not empty.
+func ReadImageRendition(ctx context.Context, masterClient wdclient.HasLookupFileIdFunction, jwtFunc VolumeServerJwtFunction, content []byte, chunks []*filer_pb.FileChunk, fileSize int64, ext string, transform *images.Transform, signingKey []byte, accept string) (data []byte, contentType string, err error) {
+	if fileSize > images.MaxTransformSourceSize {
+		return nil, "", fmt.Errorf("image of %d bytes is too large to transform", fileSize)
+	}
+
+	if len(content) == 0 && len(chunks) == 1 {
+		chunk := chunks[0]
+		if chunk.Offset == 0 && int64(chunk.Size) == fileSize && len(chunk.CipherKey) == 0 && !chunk.IsChunkManifest {
+			fileId := chunk.GetFileIdString()
+			urlStrings, lookupErr := masterClient.GetLookupFileIdFunction()(ctx, fileId)
+			if lookupErr != nil {
+				return nil, "", lookupErr
+			}
+			if jwtFunc == nil {
+				jwtFunc = noJwtFunc
+			}
+			return fetchImageRendition(ctx, urlStrings, ext, jwtFunc(fileId), transform, signingKey, accept)
+		}
+	}
+
+	if len(content) == 0 {
+		streamFn, prepareErr := PrepareStreamContentWithThrottler(ctx, masterClient, jwtFunc, chunks, 0, fileSize, 0)
+		if prepareErr != nil {
+			return nil, "", prepareErr
+		}
+		var buf bytes.Buffer
+		if err = streamFn(&buf); err != nil {
+			return nil, "", err
+		}
+		content = buf.Bytes()
+	}
+	return images.Transformed(content, transform.Negotiate(accept))
+}
+
+func fetchImageRendition(ctx context.Context, urlStrings []string, ext string, jwt string, transform *images.Transform, signingKey []byte, accept string) (data []byte, contentType string, err error) {
+	for _, urlString := range urlStrings {
+		var u *url.URL
+		if u, err = url.Parse(urlString); err != nil {
+			continue
+		}
+		u.Path += ext
+		query := transform.Values()
+		if len(signingKey) > 0 {
+			query.Set(images.TransformSignatureParam, transform.Sign(signingKey, u.Path))
+		}
+		u.RawQuery = query.Encode()
+
+		data, contentType, err = fetchImageRenditionFrom(ctx, u.String(), jwt, accept)
+		if err == nil {
+			return
+		}
+		glog.V(1).InfofCtx(ctx, "fetch image rendition %s: %v", u.String(), err)
+	}
+	return nil, "", err
+}
+
+func fetchImageRenditionFrom(ctx context.Context, urlString string, jwt string, accept string) (data []byte, contentType string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlString, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if jwt != "" {
+		req.Header.Set("Authorization", "BEARER "+jwt)
+	}
+	resp, err := util_http.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer util_http.CloseResponse(resp)
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return data, resp.Header.Get("Content-Type"), nil
+	case http.StatusBadRequest:
+		return nil, "", fmt.Errorf("%w: %s", images.ErrUnsupportedFormat, string(data))
+	}
+	return nil, "", fmt.Errorf("status %d: %s", resp.StatusCode, string(data))
+}
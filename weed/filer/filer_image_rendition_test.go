@@ -0,0 +1,24 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/images"
+)
+
+func TestImageRenditionCache(t *testing.T) {
+	cache := NewImageRenditionCache(1024)
+	small := ImageRenditionKey("/a.jpg", "etag1", &images.Transform{Width: 100, Format: "webp"})
+	cache.Set(small, make([]byte, 100), "image/webp")
+
+	data, contentType, found := cache.Get(small)
+	if !found || len(data) != 100 || contentType != "image/webp" {
+		t.Fatalf("cached rendition %d bytes %q found %v", len(data), contentType, found)
+	}
+	if _, _, found = cache.Get(ImageRenditionKey("/a.jpg", "etag2", &images.Transform{Width: 100, Format: "webp"})); found {
+		t.Fatalf("rendition of a changed entry should not be found")
+	}
+	if _, _, found = cache.Get(ImageRenditionKey("/a.jpg", "etag1", &images.Transform{Width: 200, Format: "webp"})); found {
+		t.Fatalf("rendition of another transformation should not be found")
+	}
+}
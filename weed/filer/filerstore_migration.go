@@ -0,0 +1,444 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Online migration of the default filer store.
+//
+// A store configured as "<storeName>.migration" in filer.toml becomes the migration
+// target of the default store. Once started, every write goes to both stores, existing
+// entries are copied over by a resumable traversal, and both stores are compared entry
+// by entry. After a clean verification, reads are cut over to the target store, while
+// writes still go to both, so that the source store remains a complete fallback.
+// The migration is finished by making the target the default store in filer.toml.
+
+const (
+	StoreMigrationIdle        = "idle"
+	StoreMigrationBackfilling = "backfilling"
+	StoreMigrationPaused      = "paused"
+	StoreMigrationVerifying   = "verifying"
+	StoreMigrationVerified    = "verified"
+	StoreMigrationCutover     = "cutover"
+
+	StoreMigrationConfigId = "migration"
+
+	storeMigrationPollInterval    = 10 * time.Second
+	storeMigrationCheckpointEvery = 5 * time.Second
+	storeMigrationMaxVerifyPasses = 3
+)
+
+var (
+	storeMigrationStateKey = []byte("filer.store.migration")
+
+	_ = FilerStore(&MigratingFilerStore{})
+	_ = BucketAware(&MigratingFilerStore{})
+	_ = Debuggable(&MigratingFilerStore{})
+)
+
+type MigratingFilerStore struct {
+	source          FilerStore
+	target          FilerStore
+	self            string
+	bucketsPath     string
+	dualWrite       atomic.Bool
+	readsFromTarget atomic.Bool
+	dualWriteErrors atomic.Int64
+	pathLocks       [256]sync.Mutex
+
+	mu           sync.Mutex // protects status and the worker
+	status       *filer_pb.StoreMigrationStatus
+	cancelWorker context.CancelFunc
+	workerDone   chan struct{}
+}
+
+func NewMigratingFilerStore(source, target FilerStore) *MigratingFilerStore {
+	s := &MigratingFilerStore{
+		source: source,
+		target: target,
+		status: &filer_pb.StoreMigrationStatus{Phase: StoreMigrationIdle},
+	}
+	if err := s.loadStatus(); err != nil {
+		glog.Errorf("load filer store migration status: %v", err)
+	}
+	return s
+}
+
+// Run resumes a migration owned by this filer, and follows changes made by other filers.
+func (s *MigratingFilerStore) Run(self string, bucketsPath string) {
+	s.mu.Lock()
+	s.self, s.bucketsPath = self, bucketsPath
+	if s.status.Owner == self && (s.status.Phase == StoreMigrationBackfilling || s.status.Phase == StoreMigrationVerifying) {
+		glog.V(0).Infof("resume filer store migration %s from %s", s.status.Phase, s.status.Cursor)
+		s.startWorker()
+	}
+	s.mu.Unlock()
+
+	go func() {
+		for {
+			time.Sleep(storeMigrationPollInterval)
+			s.mu.Lock()
+			if s.workerDone == nil {
+				if err := s.loadStatus(); err != nil {
+					glog.V(1).Infof("load filer store migration status: %v", err)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}()
+}
+
+func (s *MigratingFilerStore) loadStatus() error {
+	data, err := s.source.KvGet(context.Background(), storeMigrationStateKey)
+	if err == ErrKvNotFound || (err == nil && len(data) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	status := &filer_pb.StoreMigrationStatus{}
+	if err = proto.Unmarshal(data, status); err != nil {
+		return err
+	}
+	s.status = status
+	s.applyStatus()
+	return nil
+}
+
+func (s *MigratingFilerStore) saveStatus() error {
+	s.status.SourceStore = s.source.GetName()
+	s.status.TargetStore = s.target.GetName()
+	s.status.UpdatedAtNs = time.Now().UnixNano()
+	data, err := proto.Marshal(s.status)
+	if err != nil {
+		return err
+	}
+	s.applyStatus()
+	return s.source.KvPut(context.Background(), storeMigrationStateKey, data)
+}
+
+func (s *MigratingFilerStore) applyStatus() {
+	s.dualWrite.Store(s.status.Phase != StoreMigrationIdle)
+	if s.readsFromTarget.Swap(s.status.ReadsFromTarget) != s.status.ReadsFromTarget {
+		glog.V(0).Infof("filer store migration: reads from %s", s.primary().GetName())
+	}
+}
+
+// Status returns a snapshot of the migration status.
+func (s *MigratingFilerStore) Status() *filer_pb.StoreMigrationStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statusSnapshot()
+}
+
+func (s *MigratingFilerStore) statusSnapshot() *filer_pb.StoreMigrationStatus {
+	status := proto.Clone(s.status).(*filer_pb.StoreMigrationStatus)
+	status.SourceStore = s.source.GetName()
+	status.TargetStore = s.target.GetName()
+	status.DualWriteErrors = s.dualWriteErrors.Load()
+	return status
+}
+
+// Do executes one of the actions: status, start, pause, resume, verify, cutover.
+func (s *MigratingFilerStore) Do(action string) (*filer_pb.StoreMigrationStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if action != "status" && action != "" && s.workerDone == nil {
+		// another filer may have changed the migration
+		if err := s.loadStatus(); err != nil {
+			return s.statusSnapshot(), err
+		}
+	}
+
+	var err error
+	phase := s.status.Phase
+	switch action {
+	case "status", "":
+	case "start":
+		if phase != StoreMigrationIdle {
+			err = fmt.Errorf("migration is already %s", phase)
+			break
+		}
+		s.status = &filer_pb.StoreMigrationStatus{
+			Phase:       StoreMigrationBackfilling,
+			Owner:       s.self,
+			StartedAtNs: time.Now().UnixNano(),
+		}
+		if err = s.saveStatus(); err == nil {
+			s.startWorker()
+		}
+	case "pause":
+		if phase != StoreMigrationBackfilling && phase != StoreMigrationVerifying {
+			err = fmt.Errorf("can not pause a migration that is %s", phase)
+			break
+		}
+		s.stopWorker()
+		s.status.PausedPhase, s.status.Phase = phase, StoreMigrationPaused
+		err = s.saveStatus()
+	case "resume":
+		if phase != StoreMigrationPaused {
+			err = fmt.Errorf("can not resume a migration that is %s", phase)
+			break
+		}
+		s.status.Phase, s.status.PausedPhase = s.status.PausedPhase, ""
+		s.status.Owner, s.status.LastError = s.self, ""
+		if err = s.saveStatus(); err == nil {
+			s.startWorker()
+		}
+	case "verify":
+		if phase != StoreMigrationVerified && phase != StoreMigrationCutover {
+			err = fmt.Errorf("can not verify a migration that is %s", phase)
+			break
+		}
+		s.status.Phase, s.status.Owner, s.status.Cursor = StoreMigrationVerifying, s.self, ""
+		s.status.VerifyPasses, s.status.VerifiedEntries, s.status.MismatchedEntries = 0, 0, 0
+		if err = s.saveStatus(); err == nil {
+			s.startWorker()
+		}
+	case "cutover":
+		if phase != StoreMigrationVerified || s.status.MismatchedEntries > 0 {
+			err = fmt.Errorf("can only cut over after a verification without mismatches, the migration is %s with %d mismatches", phase, s.status.MismatchedEntries)
+			break
+		}
+		s.status.Phase, s.status.ReadsFromTarget = StoreMigrationCutover, true
+		err = s.saveStatus()
+	default:
+		err = fmt.Errorf("unknown action %q", action)
+	}
+
+	return s.statusSnapshot(), err
+}
+
+// startWorker runs the backfill or verification in the background. It is called with s.mu held.
+func (s *MigratingFilerStore) startWorker() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.cancelWorker, s.workerDone = cancel, done
+	go func() {
+		defer close(done)
+		err := s.work(ctx)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.workerDone != done {
+			return
+		}
+		s.cancelWorker, s.workerDone = nil, nil
+		if err != nil && ctx.Err() == nil {
+			glog.Errorf("filer store migration %s: %v", s.status.Phase, err)
+			s.status.PausedPhase, s.status.Phase = s.status.Phase, StoreMigrationPaused
+			s.status.LastError = err.Error()
+		}
+		if saveErr := s.saveStatus(); saveErr != nil {
+			glog.Errorf("save filer store migration status: %v", saveErr)
+		}
+	}()
+}
+
+// stopWorker cancels the background worker and waits for it. It is called with s.mu held.
+func (s *MigratingFilerStore) stopWorker() {
+	if s.workerDone == nil {
+		return
+	}
+	cancel, done := s.cancelWorker, s.workerDone
+	s.cancelWorker, s.workerDone = nil, nil
+	cancel()
+	s.mu.Unlock()
+	<-done
+	s.mu.Lock()
+}
+
+func (s *MigratingFilerStore) primary() FilerStore {
+	if s.readsFromTarget.Load() {
+		return s.target
+	}
+	return s.source
+}
+
+func (s *MigratingFilerStore) secondary() FilerStore {
+	if s.readsFromTarget.Load() {
+		return s.source
+	}
+	return s.target
+}
+
+func (s *MigratingFilerStore) pathLock(fp util.FullPath) *sync.Mutex {
+	return &s.pathLocks[uint32(util.HashToInt32([]byte(fp)))%uint32(len(s.pathLocks))]
+}
+
+// secondaryContext hides the transaction of the primary store from the secondary store.
+func secondaryContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, "tx", nil)
+}
+
+func (s *MigratingFilerStore) writeSecondary(op string, fp util.FullPath, err error) {
+	if err != nil {
+		s.dualWriteErrors.Add(1)
+		glog.Errorf("filer store migration: %s %s on %s: %v", op, fp, s.secondary().GetName(), err)
+	}
+}
+
+func (s *MigratingFilerStore) GetName() string {
+	return s.source.GetName()
+}
+
+func (s *MigratingFilerStore) Initialize(configuration util.Configuration, prefix string) error {
+	return s.source.Initialize(configuration, prefix)
+}
+
+func (s *MigratingFilerStore) InsertEntry(ctx context.Context, entry *Entry) error {
+	if !s.dualWrite.Load() {
+		return s.source.InsertEntry(ctx, entry)
+	}
+	lock := s.pathLock(entry.FullPath)
+	lock.Lock()
+	defer lock.Unlock()
+	if err := s.primary().InsertEntry(ctx, entry); err != nil {
+		return err
+	}
+	s.writeSecondary("insert", entry.FullPath, s.secondary().InsertEntry(secondaryContext(ctx), entry))
+	return nil
+}
+
+func (s *MigratingFilerStore) UpdateEntry(ctx context.Context, entry *Entry) error {
+	if !s.dualWrite.Load() {
+		return s.source.UpdateEntry(ctx, entry)
+	}
+	lock := s.pathLock(entry.FullPath)
+	lock.Lock()
+	defer lock.Unlock()
+	if err := s.primary().UpdateEntry(ctx, entry); err != nil {
+		return err
+	}
+	s.writeSecondary("update", entry.FullPath, s.secondary().UpdateEntry(secondaryContext(ctx), entry))
+	return nil
+}
+
+func (s *MigratingFilerStore) FindEntry(ctx context.Context, fp util.FullPath) (*Entry, error) {
+	return s.primary().FindEntry(ctx, fp)
+}
+
+func (s *MigratingFilerStore) DeleteEntry(ctx context.Context, fp util.FullPath) error {
+	if !s.dualWrite.Load() {
+		return s.source.DeleteEntry(ctx, fp)
+	}
+	lock := s.pathLock(fp)
+	lock.Lock()
+	defer lock.Unlock()
+	if err := s.primary().DeleteEntry(ctx, fp); err != nil {
+		return err
+	}
+	s.writeSecondary("delete", fp, s.secondary().DeleteEntry(secondaryContext(ctx), fp))
+	return nil
+}
+
+func (s *MigratingFilerStore) DeleteFolderChildren(ctx context.Context, fp util.FullPath) error {
+	if !s.dualWrite.Load() {
+		return s.source.DeleteFolderChildren(ctx, fp)
+	}
+	if err := s.primary().DeleteFolderChildren(ctx, fp); err != nil {
+		return err
+	}
+	s.writeSecondary("delete children", fp, s.secondary().DeleteFolderChildren(secondaryContext(ctx), fp))
+	return nil
+}
+
+func (s *MigratingFilerStore) ListDirectoryEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int64, eachEntryFunc ListEachEntryFunc) (string, error) {
+	return s.primary().ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit, eachEntryFunc)
+}
+
+func (s *MigratingFilerStore) ListDirectoryPrefixedEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int64, prefix string, eachEntryFunc ListEachEntryFunc) (string, error) {
+	return s.primary().ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, prefix, eachEntryFunc)
+}
+
+func (s *MigratingFilerStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return s.primary().BeginTransaction(ctx)
+}
+
+func (s *MigratingFilerStore) CommitTransaction(ctx context.Context) error {
+	return s.primary().CommitTransaction(ctx)
+}
+
+func (s *MigratingFilerStore) RollbackTransaction(ctx context.Context) error {
+	return s.primary().RollbackTransaction(ctx)
+}
+
+func (s *MigratingFilerStore) KvPut(ctx context.Context, key []byte, value []byte) error {
+	if !s.dualWrite.Load() {
+		return s.source.KvPut(ctx, key, value)
+	}
+	if err := s.primary().KvPut(ctx, key, value); err != nil {
+		return err
+	}
+	s.writeSecondary("kv put", util.FullPath(key), s.secondary().KvPut(secondaryContext(ctx), key, value))
+	return nil
+}
+
+// KvGet falls back to the source store after the cutover, since key values written
+// before the migration started are only copied for hard links.
+func (s *MigratingFilerStore) KvGet(ctx context.Context, key []byte) ([]byte, error) {
+	value, err := s.primary().KvGet(ctx, key)
+	if err == ErrKvNotFound && s.readsFromTarget.Load() {
+		return s.source.KvGet(ctx, key)
+	}
+	return value, err
+}
+
+func (s *MigratingFilerStore) KvDelete(ctx context.Context, key []byte) error {
+	if !s.dualWrite.Load() {
+		return s.source.KvDelete(ctx, key)
+	}
+	if err := s.primary().KvDelete(ctx, key); err != nil {
+		return err
+	}
+	s.writeSecondary("kv delete", util.FullPath(key), s.secondary().KvDelete(secondaryContext(ctx), key))
+	return nil
+}
+
+func (s *MigratingFilerStore) Shutdown() {
+	s.mu.Lock()
+	s.stopWorker()
+	s.mu.Unlock()
+	s.source.Shutdown()
+	s.target.Shutdown()
+}
+
+func (s *MigratingFilerStore) OnBucketCreation(bucket string) {
+	for _, store := range []FilerStore{s.source, s.target} {
+		if ba, ok := store.(BucketAware); ok {
+			ba.OnBucketCreation(bucket)
+		}
+	}
+}
+
+func (s *MigratingFilerStore) OnBucketDeletion(bucket string) {
+	for _, store := range []FilerStore{s.source, s.target} {
+		if ba, ok := store.(BucketAware); ok {
+			ba.OnBucketDeletion(bucket)
+		}
+	}
+}
+
+func (s *MigratingFilerStore) CanDropWholeBucket() bool {
+	if ba, ok := s.primary().(BucketAware); ok {
+		return ba.CanDropWholeBucket()
+	}
+	return false
+}
+
+func (s *MigratingFilerStore) Debug(writer io.Writer) {
+	if debuggable, ok := s.primary().(Debuggable); ok {
+		debuggable.Debug(writer)
+	}
+}
@@ -0,0 +1,205 @@
+package filer
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// memStore is a minimal in-memory filer store for testing.
+type memStore struct {
+	sync.Mutex
+	entries map[util.FullPath]*Entry
+	kv      map[string][]byte
+}
+
+func newMemStore() *memStore {
+	return &memStore{entries: make(map[util.FullPath]*Entry), kv: make(map[string][]byte)}
+}
+
+func (m *memStore) GetName() string { return "mem" }
+func (m *memStore) Initialize(util.Configuration, string) error {
+	return nil
+}
+func (m *memStore) InsertEntry(ctx context.Context, entry *Entry) error {
+	m.Lock()
+	defer m.Unlock()
+	m.entries[entry.FullPath] = entry.ShallowClone()
+	return nil
+}
+func (m *memStore) UpdateEntry(ctx context.Context, entry *Entry) error {
+	return m.InsertEntry(ctx, entry)
+}
+func (m *memStore) FindEntry(ctx context.Context, fp util.FullPath) (*Entry, error) {
+	m.Lock()
+	defer m.Unlock()
+	if entry, found := m.entries[fp]; found {
+		return entry.ShallowClone(), nil
+	}
+	return nil, filer_pb.ErrNotFound
+}
+func (m *memStore) DeleteEntry(ctx context.Context, fp util.FullPath) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.entries, fp)
+	return nil
+}
+func (m *memStore) DeleteFolderChildren(ctx context.Context, fp util.FullPath) error {
+	m.Lock()
+	defer m.Unlock()
+	for p := range m.entries {
+		if strings.HasPrefix(string(p), string(fp)+"/") {
+			delete(m.entries, p)
+		}
+	}
+	return nil
+}
+func (m *memStore) ListDirectoryEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int64, eachEntryFunc ListEachEntryFunc) (string, error) {
+	return m.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, "", eachEntryFunc)
+}
+func (m *memStore) ListDirectoryPrefixedEntries(ctx context.Context, dirPath util.FullPath, startFileName string, includeStartFile bool, limit int64, prefix string, eachEntryFunc ListEachEntryFunc) (lastFileName string, err error) {
+	m.Lock()
+	var children []*Entry
+	for p, entry := range m.entries {
+		dir, name := p.DirAndName()
+		if util.FullPath(dir) != dirPath || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if name < startFileName || (name == startFileName && !includeStartFile) {
+			continue
+		}
+		children = append(children, entry.ShallowClone())
+	}
+	m.Unlock()
+	sort.Slice(children, func(i, j int) bool { return children[i].Name() < children[j].Name() })
+	for i, entry := range children {
+		if int64(i) >= limit {
+			break
+		}
+		lastFileName = entry.Name()
+		if more, err := eachEntryFunc(entry); err != nil || !more {
+			return lastFileName, err
+		}
+	}
+	return lastFileName, nil
+}
+func (m *memStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+func (m *memStore) CommitTransaction(ctx context.Context) error   { return nil }
+func (m *memStore) RollbackTransaction(ctx context.Context) error { return nil }
+func (m *memStore) KvPut(ctx context.Context, key []byte, value []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	m.kv[string(key)] = value
+	return nil
+}
+func (m *memStore) KvGet(ctx context.Context, key []byte) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	if value, found := m.kv[string(key)]; found {
+		return value, nil
+	}
+	return nil, ErrKvNotFound
+}
+func (m *memStore) KvDelete(ctx context.Context, key []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.kv, string(key))
+	return nil
+}
+func (m *memStore) Shutdown() {}
+
+func addTestEntry(store FilerStore, fp string, isDirectory bool) {
+	entry := &Entry{FullPath: util.FullPath(fp)}
+	if isDirectory {
+		entry.Attr.Mode = 0755 | 1<<31
+	} else {
+		entry.Attr.Mode = 0644
+	}
+	store.InsertEntry(context.Background(), entry)
+}
+
+func TestWalkStoreFromCursor(t *testing.T) {
+	store := newMemStore()
+	addTestEntry(store, "/a", true)
+	addTestEntry(store, "/a/x", false)
+	addTestEntry(store, "/a/y", true)
+	addTestEntry(store, "/a/y/1", false)
+	addTestEntry(store, "/a/z", false)
+	addTestEntry(store, "/b", false)
+
+	walk := func(cursor util.FullPath) (visited []string) {
+		err := walkStoreFrom(context.Background(), store, "/", cursor, func(entry *Entry) error {
+			visited = append(visited, string(entry.FullPath))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("walk: %v", err)
+		}
+		return
+	}
+
+	all := walk("")
+	expected := []string{"/a", "/a/x", "/a/y", "/a/y/1", "/a/z", "/b"}
+	if strings.Join(all, ",") != strings.Join(expected, ",") {
+		t.Fatalf("walk all: %v", all)
+	}
+
+	// resuming visits the cursor entry again, and everything after it
+	resumed := walk("/a/y/1")
+	if strings.Join(resumed, ",") != "/a,/a/y,/a/y/1,/a/z,/b" {
+		t.Fatalf("walk from /a/y/1: %v", resumed)
+	}
+}
+
+func TestStoreMigrationCopyAndVerify(t *testing.T) {
+	source, target := newMemStore(), newMemStore()
+	addTestEntry(source, "/d", true)
+	addTestEntry(source, "/d/f1", false)
+	addTestEntry(source, "/d/f2", false)
+	addTestEntry(target, "/d/stale", false)
+
+	s := NewMigratingFilerStore(source, target)
+	ctx := context.Background()
+
+	if err := s.backfill(ctx, ""); err != nil {
+		t.Fatalf("backfill: %v", err)
+	}
+	if s.status.BackfilledEntries != 3 {
+		t.Errorf("backfilled %d entries", s.status.BackfilledEntries)
+	}
+
+	s.status.Cursor = ""
+
+	// a change that missed the target store
+	changed := &Entry{FullPath: "/d/f1"}
+	changed.Attr.Mode = 0600
+	source.UpdateEntry(ctx, changed)
+
+	if err := s.verify(ctx); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if s.status.MismatchedEntries != 2 || s.status.RepairedEntries != 2 {
+		t.Errorf("mismatched %d repaired %d", s.status.MismatchedEntries, s.status.RepairedEntries)
+	}
+	if _, err := target.FindEntry(ctx, "/d/stale"); err != filer_pb.ErrNotFound {
+		t.Errorf("stale entry not removed: %v", err)
+	}
+	if entry, _ := target.FindEntry(ctx, "/d/f1"); entry == nil || entry.Attr.Mode != 0600 {
+		t.Errorf("changed entry not repaired: %+v", entry)
+	}
+
+	s.status.Cursor, s.status.VerifiedEntries, s.status.MismatchedEntries = "", 0, 0
+	if err := s.verify(ctx); err != nil {
+		t.Fatalf("verify again: %v", err)
+	}
+	if s.status.MismatchedEntries != 0 || s.status.VerifiedEntries != 3 {
+		t.Errorf("second pass mismatched %d verified %d", s.status.MismatchedEntries, s.status.VerifiedEntries)
+	}
+}
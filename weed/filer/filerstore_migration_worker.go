@@ -0,0 +1,301 @@
+package filer
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	storeMigrationListLimit    = 1024
+	verifyCursorSourcePrefix   = "source:"
+	verifyCursorTargetPrefix   = "target:"
+	storeMigrationCursorMaxLen = 4096
+)
+
+func (s *MigratingFilerStore) work(ctx context.Context) error {
+	s.mu.Lock()
+	phase, cursor := s.status.Phase, s.status.Cursor
+	s.mu.Unlock()
+
+	if phase == StoreMigrationBackfilling {
+		if cursor == "" {
+			// let other filers notice the migration and start writing to both stores
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(2 * storeMigrationPollInterval):
+			}
+		}
+		if err := s.backfill(ctx, util.FullPath(cursor)); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.status.Phase, s.status.Cursor = StoreMigrationVerifying, ""
+		err := s.saveStatus()
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		if err := s.verify(ctx); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.status.VerifyPasses++
+		glog.V(0).Infof("filer store migration: verify pass %d found %d mismatches in %d entries",
+			s.status.VerifyPasses, s.status.MismatchedEntries, s.status.VerifiedEntries)
+		done := s.status.MismatchedEntries == 0 || s.status.VerifyPasses >= storeMigrationMaxVerifyPasses
+		if done {
+			s.status.Phase, s.status.Cursor = StoreMigrationVerified, ""
+		} else {
+			// repaired entries are verified again in the next pass
+			s.status.Cursor, s.status.VerifiedEntries, s.status.MismatchedEntries = "", 0, 0
+		}
+		err := s.saveStatus()
+		s.mu.Unlock()
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// checkpointer saves the traversal cursor and counters from time to time.
+type checkpointer struct {
+	s        *MigratingFilerStore
+	lastSave time.Time
+}
+
+func (c *checkpointer) update(cursor string, fn func(status *filer_pb.StoreMigrationStatus)) error {
+	c.s.mu.Lock()
+	defer c.s.mu.Unlock()
+	fn(c.s.status)
+	if len(cursor) < storeMigrationCursorMaxLen {
+		c.s.status.Cursor = cursor
+	}
+	if time.Since(c.lastSave) < storeMigrationCheckpointEvery {
+		return nil
+	}
+	c.lastSave = time.Now()
+	return c.s.saveStatus()
+}
+
+func (s *MigratingFilerStore) backfill(ctx context.Context, cursor util.FullPath) error {
+	c := &checkpointer{s: s, lastSave: time.Now()}
+	return walkStoreFrom(ctx, s.source, "/", cursor, func(entry *Entry) error {
+		if err := s.copyEntry(ctx, entry.FullPath); err != nil {
+			return err
+		}
+		return c.update(string(entry.FullPath), func(status *filer_pb.StoreMigrationStatus) {
+			status.BackfilledEntries++
+		})
+	})
+}
+
+func (s *MigratingFilerStore) verify(ctx context.Context) error {
+	s.mu.Lock()
+	cursor := s.status.Cursor
+	s.mu.Unlock()
+
+	c := &checkpointer{s: s, lastSave: time.Now()}
+
+	// every entry of the source store should be the same in the target store
+	if !strings.HasPrefix(cursor, verifyCursorTargetPrefix) {
+		err := walkStoreFrom(ctx, s.source, "/", util.FullPath(strings.TrimPrefix(cursor, verifyCursorSourcePrefix)), func(entry *Entry) error {
+			mismatched, repaired, err := s.verifyEntry(ctx, entry)
+			if err != nil {
+				return err
+			}
+			return c.update(verifyCursorSourcePrefix+string(entry.FullPath), func(status *filer_pb.StoreMigrationStatus) {
+				status.VerifiedEntries++
+				if mismatched {
+					status.MismatchedEntries++
+				}
+				if repaired {
+					status.RepairedEntries++
+				}
+			})
+		})
+		if err != nil {
+			return err
+		}
+		cursor = ""
+	}
+
+	// the target store should not have any other entries
+	return walkStoreFrom(ctx, s.target, "/", util.FullPath(strings.TrimPrefix(cursor, verifyCursorTargetPrefix)), func(entry *Entry) error {
+		_, err := s.source.FindEntry(ctx, entry.FullPath)
+		if err != filer_pb.ErrNotFound {
+			return err
+		}
+		repaired, err := s.removeExtraEntry(ctx, entry.FullPath)
+		if err != nil {
+			return err
+		}
+		return c.update(verifyCursorTargetPrefix+string(entry.FullPath), func(status *filer_pb.StoreMigrationStatus) {
+			if repaired {
+				status.MismatchedEntries++
+				status.RepairedEntries++
+			}
+		})
+	})
+}
+
+// verifyEntry compares the checksums of an entry in both stores, and copies the
+// source version over if they differ.
+func (s *MigratingFilerStore) verifyEntry(ctx context.Context, entry *Entry) (mismatched, repaired bool, err error) {
+	targetEntry, err := s.target.FindEntry(ctx, entry.FullPath)
+	if err != nil && err != filer_pb.ErrNotFound {
+		return false, false, err
+	}
+	if err == nil {
+		if same, checksumErr := sameEntryChecksum(entry, targetEntry); checksumErr != nil || same {
+			return false, false, checksumErr
+		}
+	}
+	glog.V(1).Infof("filer store migration: repair %s", entry.FullPath)
+	if err = s.copyEntry(ctx, entry.FullPath); err != nil {
+		return true, false, err
+	}
+	return true, true, nil
+}
+
+// copyEntry copies the current version of an entry from the source store to the target store.
+// Writes to the same path are blocked meanwhile, so an older version can not overwrite a newer one.
+func (s *MigratingFilerStore) copyEntry(ctx context.Context, fp util.FullPath) error {
+	lock := s.pathLock(fp)
+	lock.Lock()
+	defer lock.Unlock()
+
+	entry, err := s.source.FindEntry(ctx, fp)
+	if err == filer_pb.ErrNotFound {
+		// deleted meanwhile
+		if err = s.target.DeleteEntry(ctx, fp); err == filer_pb.ErrNotFound {
+			err = nil
+		}
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("read %s from %s: %w", fp, s.source.GetName(), err)
+	}
+
+	if entry.IsDirectory() && s.bucketsPath != "" {
+		if dir, name := fp.DirAndName(); dir == s.bucketsPath {
+			if ba, ok := s.target.(BucketAware); ok {
+				ba.OnBucketCreation(name)
+			}
+		}
+	}
+
+	if err = s.target.InsertEntry(ctx, entry); err != nil {
+		if err = s.target.UpdateEntry(ctx, entry); err != nil {
+			return fmt.Errorf("write %s to %s: %w", fp, s.target.GetName(), err)
+		}
+	}
+
+	if len(entry.HardLinkId) > 0 {
+		value, kvErr := s.source.KvGet(ctx, entry.HardLinkId)
+		if kvErr == nil {
+			kvErr = s.target.KvPut(ctx, entry.HardLinkId, value)
+		}
+		if kvErr != nil && kvErr != ErrKvNotFound {
+			return fmt.Errorf("copy hard link of %s: %w", fp, kvErr)
+		}
+	}
+	return nil
+}
+
+// removeExtraEntry deletes an entry that only exists in the target store.
+func (s *MigratingFilerStore) removeExtraEntry(ctx context.Context, fp util.FullPath) (bool, error) {
+	lock := s.pathLock(fp)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// check again, it may have been created meanwhile
+	if _, err := s.source.FindEntry(ctx, fp); err != filer_pb.ErrNotFound {
+		return false, err
+	}
+	glog.V(1).Infof("filer store migration: remove %s only in %s", fp, s.target.GetName())
+	if err := s.target.DeleteEntry(ctx, fp); err != nil && err != filer_pb.ErrNotFound {
+		return false, err
+	}
+	return true, nil
+}
+
+func entryChecksum(entry *Entry) ([]byte, error) {
+	data, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return nil, err
+	}
+	sum := md5.Sum(data)
+	return sum[:], nil
+}
+
+func sameEntryChecksum(a, b *Entry) (bool, error) {
+	aSum, err := entryChecksum(a)
+	if err != nil {
+		return false, err
+	}
+	bSum, err := entryChecksum(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(aSum, bSum), nil
+}
+
+// walkStoreFrom visits the entries under dir depth first, in name order.
+// If cursor is set, entries visited before the cursor are skipped, so that an
+// interrupted traversal can continue where it stopped.
+func walkStoreFrom(ctx context.Context, store FilerStore, dir util.FullPath, cursor util.FullPath, fn func(entry *Entry) error) error {
+	childPrefix := string(dir) + "/"
+	if dir == "/" {
+		childPrefix = "/"
+	}
+
+	startFileName := ""
+	if strings.HasPrefix(string(cursor), childPrefix) {
+		startFileName, _, _ = strings.Cut(string(cursor)[len(childPrefix):], "/")
+	}
+
+	includeStartFile := true
+	for {
+		var entries []*Entry
+		_, err := store.ListDirectoryEntries(ctx, dir, startFileName, includeStartFile, storeMigrationListLimit, func(entry *Entry) (bool, error) {
+			entries = append(entries, entry)
+			return true, nil
+		})
+		if err != nil {
+			return fmt.Errorf("list %s in %s: %w", dir, store.GetName(), err)
+		}
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+			if entry.IsDirectory() {
+				var childCursor util.FullPath
+				if strings.HasPrefix(string(cursor), string(entry.FullPath)+"/") {
+					childCursor = cursor
+				}
+				if err := walkStoreFrom(ctx, store, entry.FullPath, childCursor, fn); err != nil {
+					return err
+				}
+			}
+		}
+		if len(entries) < storeMigrationListLimit {
+			return nil
+		}
+		startFileName, includeStartFile = entries[len(entries)-1].Name(), false
+	}
+}
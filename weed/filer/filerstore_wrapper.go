@@ -86,6 +86,13 @@ func (fsw *FilerStoreWrapper) AddPathSpecificStore(path string, storeId string,
 	fsw.hasPathSpecificStore = true
 }
 
+// MigrateDefaultStoreTo routes the default store through an online migration to the target store.
+func (fsw *FilerStoreWrapper) MigrateDefaultStoreTo(target FilerStore) *MigratingFilerStore {
+	migration := NewMigratingFilerStore(fsw.defaultStore, target)
+	fsw.defaultStore = migration
+	return migration
+}
+
 func (fsw *FilerStoreWrapper) getActualStore(path util.FullPath) (store FilerStore) {
 	store = fsw.defaultStore
 	// Fast path: skip MatchPrefix if no path-specific stores are configured (common case)
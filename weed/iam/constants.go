@@ -35,3 +35,26 @@ const (
 	AccessKeyStatusActive   = "Active"
 	AccessKeyStatusInactive = "Inactive"
 )
+
+// ARN prefixes of IAM entities
+const (
+	PolicyArnPrefix = "arn:aws:iam:::policy/"
+	GroupArnPrefix  = "arn:aws:iam:::group/"
+	RoleArnPrefix   = "arn:aws:iam::role/"
+)
+
+// Unique ID prefixes of IAM entities (AWS IAM compatible)
+const (
+	GroupIdPrefix = "AGPA"
+	RoleIdPrefix  = "AROA"
+)
+
+// Role session duration limits, in seconds
+const (
+	DefaultRoleMaxSessionDuration = 3600
+	MinRoleMaxSessionDuration     = 3600
+	MaxRoleMaxSessionDuration     = 43200
+)
+
+// MaxPolicyVersions is the number of versions a managed policy may keep.
+const MaxPolicyVersions = 5
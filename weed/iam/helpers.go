@@ -134,6 +134,7 @@ var baseS3ActionMap = map[string]string{
 	"PutObjectRetention":        s3_constants.ACTION_WRITE,
 	"GetObjectLegalHold":        s3_constants.ACTION_READ,
 	"PutObjectLegalHold":        s3_constants.ACTION_WRITE,
+	"GetObjectAttributes":       s3_constants.ACTION_READ,
 	"BypassGovernanceRetention": s3_constants.ACTION_WRITE,
 }
 
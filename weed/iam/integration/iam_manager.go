@@ -585,6 +585,21 @@ func (m *IAMManager) GetSTSService() *sts.STSService {
 	return m.stsService
 }
 
+// GetPolicyEngine returns the policy engine instance
+func (m *IAMManager) GetPolicyEngine() *policy.PolicyEngine {
+	return m.policyEngine
+}
+
+// GetRoleStore returns the role store
+func (m *IAMManager) GetRoleStore() RoleStore {
+	return m.roleStore
+}
+
+// SetRoleStore replaces the role store, e.g. to resolve roles managed through the IAM API
+func (m *IAMManager) SetRoleStore(roleStore RoleStore) {
+	m.roleStore = roleStore
+}
+
 // parseJWTTokenForTrustPolicy parses a JWT token to extract claims for trust policy evaluation
 func parseJWTTokenForTrustPolicy(tokenString string) (map[string]interface{}, error) {
 	// Simple JWT parsing without verification (for trust policy context only)
@@ -0,0 +1,1114 @@
+package iam
+
+// This file implements the IAM group, role, managed policy and access key
+// lifecycle actions shared by the standalone IAM server and the IAM API
+// embedded in the S3 server.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+)
+
+// ManagedPolicyStore persists managed policy documents and their version history.
+// It is implemented by credential.CredentialManager.
+type ManagedPolicyStore interface {
+	GetPolicies(ctx context.Context) (map[string]policy_engine.PolicyDocument, error)
+	PutPolicy(ctx context.Context, name string, document policy_engine.PolicyDocument) error
+	DeletePolicy(ctx context.Context, name string) error
+	GetPolicyVersions(ctx context.Context, policyName string) (*iam_pb.PolicyVersions, error)
+	PutPolicyVersions(ctx context.Context, versions *iam_pb.PolicyVersions) error
+}
+
+// managedActions lists the actions handled by ExecuteManagedAction and whether they only read state.
+var managedActions = map[string]bool{
+	"CreateGroup":               false,
+	"GetGroup":                  true,
+	"ListGroups":                true,
+	"UpdateGroup":               false,
+	"DeleteGroup":               false,
+	"AddUserToGroup":            false,
+	"RemoveUserFromGroup":       false,
+	"ListGroupsForUser":         true,
+	"AttachGroupPolicy":         false,
+	"DetachGroupPolicy":         false,
+	"ListAttachedGroupPolicies": true,
+	"CreateRole":                false,
+	"GetRole":                   true,
+	"ListRoles":                 true,
+	"UpdateRole":                false,
+	"DeleteRole":                false,
+	"UpdateAssumeRolePolicy":    false,
+	"AttachRolePolicy":          false,
+	"DetachRolePolicy":          false,
+	"ListAttachedRolePolicies":  true,
+	"AttachUserPolicy":          false,
+	"DetachUserPolicy":          false,
+	"ListAttachedUserPolicies":  true,
+	"CreatePolicy":              false,
+	"GetPolicy":                 true,
+	"ListPolicies":              true,
+	"DeletePolicy":              false,
+	"CreatePolicyVersion":       false,
+	"GetPolicyVersion":          true,
+	"ListPolicyVersions":        true,
+	"DeletePolicyVersion":       false,
+	"SetDefaultPolicyVersion":   false,
+	"UpdateAccessKey":           false,
+	"GetAccessKeyLastUsed":      true,
+}
+
+// IsManagedAction reports whether action is handled by ExecuteManagedAction.
+func IsManagedAction(action string) bool {
+	_, found := managedActions[action]
+	return found
+}
+
+// IsReadOnlyManagedAction reports whether action is a managed action that does not modify state.
+func IsReadOnlyManagedAction(action string) bool {
+	return managedActions[action]
+}
+
+// ExecuteManagedAction runs a group, role, managed policy or access key action.
+// Groups, roles, user policy attachments and access keys live in s3cfg; changed
+// reports whether s3cfg was modified and must be saved by the caller.
+// Managed policy documents and versions are written to store directly.
+func ExecuteManagedAction(ctx context.Context, store ManagedPolicyStore, s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (response interface{}, changed bool, iamErr *Error) {
+	m := &managedActionContext{ctx: ctx, store: store, s3cfg: s3cfg, values: values}
+	switch values.Get("Action") {
+	case "CreateGroup":
+		response, iamErr = m.createGroup()
+	case "GetGroup":
+		response, iamErr = m.getGroup()
+	case "ListGroups":
+		response, iamErr = m.listGroups()
+	case "UpdateGroup":
+		response, iamErr = m.updateGroup()
+	case "DeleteGroup":
+		response, iamErr = m.deleteGroup()
+	case "AddUserToGroup":
+		response, iamErr = m.addUserToGroup()
+	case "RemoveUserFromGroup":
+		response, iamErr = m.removeUserFromGroup()
+	case "ListGroupsForUser":
+		response, iamErr = m.listGroupsForUser()
+	case "AttachGroupPolicy":
+		response, iamErr = m.attachGroupPolicy()
+	case "DetachGroupPolicy":
+		response, iamErr = m.detachGroupPolicy()
+	case "ListAttachedGroupPolicies":
+		response, iamErr = m.listAttachedGroupPolicies()
+	case "CreateRole":
+		response, iamErr = m.createRole()
+	case "GetRole":
+		response, iamErr = m.getRole()
+	case "ListRoles":
+		response, iamErr = m.listRoles()
+	case "UpdateRole":
+		response, iamErr = m.updateRole()
+	case "DeleteRole":
+		response, iamErr = m.deleteRole()
+	case "UpdateAssumeRolePolicy":
+		response, iamErr = m.updateAssumeRolePolicy()
+	case "AttachRolePolicy":
+		response, iamErr = m.attachRolePolicy()
+	case "DetachRolePolicy":
+		response, iamErr = m.detachRolePolicy()
+	case "ListAttachedRolePolicies":
+		response, iamErr = m.listAttachedRolePolicies()
+	case "AttachUserPolicy":
+		response, iamErr = m.attachUserPolicy()
+	case "DetachUserPolicy":
+		response, iamErr = m.detachUserPolicy()
+	case "ListAttachedUserPolicies":
+		response, iamErr = m.listAttachedUserPolicies()
+	case "CreatePolicy":
+		response, iamErr = m.createPolicy()
+	case "GetPolicy":
+		response, iamErr = m.getPolicy()
+	case "ListPolicies":
+		response, iamErr = m.listPolicies()
+	case "DeletePolicy":
+		response, iamErr = m.deletePolicy()
+	case "CreatePolicyVersion":
+		response, iamErr = m.createPolicyVersion()
+	case "GetPolicyVersion":
+		response, iamErr = m.getPolicyVersion()
+	case "ListPolicyVersions":
+		response, iamErr = m.listPolicyVersions()
+	case "DeletePolicyVersion":
+		response, iamErr = m.deletePolicyVersion()
+	case "SetDefaultPolicyVersion":
+		response, iamErr = m.setDefaultPolicyVersion()
+	case "UpdateAccessKey":
+		response, iamErr = m.updateAccessKey()
+	case "GetAccessKeyLastUsed":
+		response, iamErr = m.getAccessKeyLastUsed()
+	default:
+		return nil, false, &Error{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf("unsupported action %s", values.Get("Action"))}
+	}
+	if iamErr != nil {
+		return nil, false, iamErr
+	}
+	return response, m.changed, nil
+}
+
+// PolicyArn returns the ARN of a managed policy.
+func PolicyArn(policyName string) string {
+	return PolicyArnPrefix + policyName
+}
+
+// PolicyNameFromArn extracts the policy name from a managed policy ARN.
+func PolicyNameFromArn(policyArn string) string {
+	if idx := strings.LastIndex(policyArn, "policy/"); idx >= 0 {
+		return policyArn[idx+len("policy/"):]
+	}
+	return policyArn
+}
+
+// RoleArn returns the ARN of a role, in the form used by the STS service.
+func RoleArn(roleName string) string {
+	return RoleArnPrefix + roleName
+}
+
+// GroupPolicyNames returns the managed policies attached to the groups userName belongs to.
+func GroupPolicyNames(s3cfg *iam_pb.S3ApiConfiguration, userName string) []string {
+	var policyNames []string
+	for _, group := range s3cfg.Groups {
+		for _, member := range group.Members {
+			if member == userName {
+				policyNames = append(policyNames, group.PolicyNames...)
+				break
+			}
+		}
+	}
+	return policyNames
+}
+
+type managedActionContext struct {
+	ctx     context.Context
+	store   ManagedPolicyStore
+	s3cfg   *iam_pb.S3ApiConfiguration
+	values  url.Values
+	changed bool
+}
+
+func noSuchEntity(format string, args ...interface{}) *Error {
+	return &Error{Code: iam.ErrCodeNoSuchEntityException, Error: fmt.Errorf(format, args...)}
+}
+
+func invalidInput(format string, args ...interface{}) *Error {
+	return &Error{Code: iam.ErrCodeInvalidInputException, Error: fmt.Errorf(format, args...)}
+}
+
+func serviceFailure(err error) *Error {
+	return &Error{Code: iam.ErrCodeServiceFailureException, Error: err}
+}
+
+// required returns the named request parameter, failing if it is empty.
+func (m *managedActionContext) required(name string) (string, *Error) {
+	value := m.values.Get(name)
+	if value == "" {
+		return "", invalidInput("%s is required", name)
+	}
+	return value, nil
+}
+
+func (m *managedActionContext) findIdentity(userName string) *iam_pb.Identity {
+	for _, ident := range m.s3cfg.Identities {
+		if ident.Name == userName {
+			return ident
+		}
+	}
+	return nil
+}
+
+func (m *managedActionContext) findGroup(groupName string) (int, *iam_pb.Group) {
+	for i, group := range m.s3cfg.Groups {
+		if group.Name == groupName {
+			return i, group
+		}
+	}
+	return -1, nil
+}
+
+func (m *managedActionContext) findRole(roleName string) (int, *iam_pb.Role) {
+	for i, role := range m.s3cfg.Roles {
+		if role.Name == roleName {
+			return i, role
+		}
+	}
+	return -1, nil
+}
+
+func (m *managedActionContext) requireGroup() (int, *iam_pb.Group, *Error) {
+	groupName, iamErr := m.required("GroupName")
+	if iamErr != nil {
+		return -1, nil, iamErr
+	}
+	i, group := m.findGroup(groupName)
+	if group == nil {
+		return -1, nil, noSuchEntity("the group with name %s cannot be found", groupName)
+	}
+	return i, group, nil
+}
+
+func (m *managedActionContext) requireRole() (int, *iam_pb.Role, *Error) {
+	roleName, iamErr := m.required("RoleName")
+	if iamErr != nil {
+		return -1, nil, iamErr
+	}
+	i, role := m.findRole(roleName)
+	if role == nil {
+		return -1, nil, noSuchEntity("the role with name %s cannot be found", roleName)
+	}
+	return i, role, nil
+}
+
+func (m *managedActionContext) requireUser() (*iam_pb.Identity, *Error) {
+	userName, iamErr := m.required("UserName")
+	if iamErr != nil {
+		return nil, iamErr
+	}
+	ident := m.findIdentity(userName)
+	if ident == nil {
+		return nil, noSuchEntity(UserDoesNotExist, userName)
+	}
+	return ident, nil
+}
+
+func (m *managedActionContext) requireStore() *Error {
+	if m.store == nil {
+		return serviceFailure(fmt.Errorf("managed policy store is not configured"))
+	}
+	return nil
+}
+
+// requirePolicy resolves the PolicyArn parameter to the name and document of an existing managed policy.
+func (m *managedActionContext) requirePolicy() (string, policy_engine.PolicyDocument, *Error) {
+	policyArn, iamErr := m.required("PolicyArn")
+	if iamErr != nil {
+		return "", policy_engine.PolicyDocument{}, iamErr
+	}
+	if iamErr := m.requireStore(); iamErr != nil {
+		return "", policy_engine.PolicyDocument{}, iamErr
+	}
+	policyName := PolicyNameFromArn(policyArn)
+	policies, err := m.store.GetPolicies(m.ctx)
+	if err != nil {
+		return "", policy_engine.PolicyDocument{}, serviceFailure(err)
+	}
+	document, found := policies[policyName]
+	if !found {
+		return "", policy_engine.PolicyDocument{}, noSuchEntity("policy %s does not exist", policyArn)
+	}
+	return policyName, document, nil
+}
+
+func unixTime(seconds int64) *time.Time {
+	if seconds == 0 {
+		return nil
+	}
+	t := time.Unix(seconds, 0).UTC()
+	return &t
+}
+
+func generateEntityId(prefix string) (string, *Error) {
+	suffix, err := GenerateRandomString(AccessKeyIdLength-len(prefix), CharsetUpper)
+	if err != nil {
+		return "", serviceFailure(err)
+	}
+	return prefix + suffix, nil
+}
+
+func attachedPolicies(policyNames []string) []*iam.AttachedPolicy {
+	var attached []*iam.AttachedPolicy
+	for _, policyName := range policyNames {
+		name, arn := policyName, PolicyArn(policyName)
+		attached = append(attached, &iam.AttachedPolicy{PolicyName: &name, PolicyArn: &arn})
+	}
+	return attached
+}
+
+func addName(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
+
+func removeName(names []string, name string) ([]string, bool) {
+	for i, n := range names {
+		if n == name {
+			return append(names[:i], names[i+1:]...), true
+		}
+	}
+	return names, false
+}
+
+// Groups
+
+func groupToIam(group *iam_pb.Group) *iam.Group {
+	name, id, arn, path := group.Name, group.GroupId, GroupArnPrefix+group.Name, "/"
+	return &iam.Group{GroupName: &name, GroupId: &id, Arn: &arn, Path: &path, CreateDate: unixTime(group.CreateDate)}
+}
+
+func (m *managedActionContext) createGroup() (resp CreateGroupResponse, iamErr *Error) {
+	groupName, iamErr := m.required("GroupName")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if !credential.GroupNamePattern.MatchString(groupName) {
+		return resp, invalidInput("invalid group name %s", groupName)
+	}
+	if _, existing := m.findGroup(groupName); existing != nil {
+		return resp, &Error{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("group with name %s already exists", groupName)}
+	}
+	groupId, iamErr := generateEntityId(GroupIdPrefix)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	group := &iam_pb.Group{Name: groupName, GroupId: groupId, CreateDate: time.Now().Unix()}
+	m.s3cfg.Groups = append(m.s3cfg.Groups, group)
+	m.changed = true
+	resp.CreateGroupResult.Group = *groupToIam(group)
+	return resp, nil
+}
+
+func (m *managedActionContext) getGroup() (resp GetGroupResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.GetGroupResult.Group = *groupToIam(group)
+	for _, member := range group.Members {
+		userName := member
+		resp.GetGroupResult.Users = append(resp.GetGroupResult.Users, &iam.User{UserName: &userName})
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) listGroups() (resp ListGroupsResponse, iamErr *Error) {
+	for _, group := range m.s3cfg.Groups {
+		resp.ListGroupsResult.Groups = append(resp.ListGroupsResult.Groups, groupToIam(group))
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) updateGroup() (resp UpdateGroupResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if newGroupName := m.values.Get("NewGroupName"); newGroupName != "" && newGroupName != group.Name {
+		if !credential.GroupNamePattern.MatchString(newGroupName) {
+			return resp, invalidInput("invalid group name %s", newGroupName)
+		}
+		if _, existing := m.findGroup(newGroupName); existing != nil {
+			return resp, &Error{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("group with name %s already exists", newGroupName)}
+		}
+		group.Name = newGroupName
+		m.changed = true
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) deleteGroup() (resp DeleteGroupResponse, iamErr *Error) {
+	i, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if len(group.Members) > 0 || len(group.PolicyNames) > 0 {
+		return resp, &Error{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("group %s must have no members and no attached policies before deletion", group.Name)}
+	}
+	m.s3cfg.Groups = append(m.s3cfg.Groups[:i], m.s3cfg.Groups[i+1:]...)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) addUserToGroup() (resp AddUserToGroupResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	group.Members = addName(group.Members, ident.Name)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) removeUserFromGroup() (resp RemoveUserFromGroupResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	userName, iamErr := m.required("UserName")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var removed bool
+	if group.Members, removed = removeName(group.Members, userName); !removed {
+		return resp, noSuchEntity("the user with name %s is not a member of group %s", userName, group.Name)
+	}
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) listGroupsForUser() (resp ListGroupsForUserResponse, iamErr *Error) {
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	for _, group := range m.s3cfg.Groups {
+		for _, member := range group.Members {
+			if member == ident.Name {
+				resp.ListGroupsForUserResult.Groups = append(resp.ListGroupsForUserResult.Groups, groupToIam(group))
+				break
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) attachGroupPolicy() (resp AttachGroupPolicyResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyName, _, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	group.PolicyNames = addName(group.PolicyNames, policyName)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) detachGroupPolicy() (resp DetachGroupPolicyResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyArn, iamErr := m.required("PolicyArn")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var removed bool
+	if group.PolicyNames, removed = removeName(group.PolicyNames, PolicyNameFromArn(policyArn)); !removed {
+		return resp, noSuchEntity("policy %s is not attached to group %s", policyArn, group.Name)
+	}
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) listAttachedGroupPolicies() (resp ListAttachedGroupPoliciesResponse, iamErr *Error) {
+	_, group, iamErr := m.requireGroup()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.ListAttachedGroupPoliciesResult.AttachedPolicies = attachedPolicies(group.PolicyNames)
+	return resp, nil
+}
+
+// Roles
+
+func roleToIam(role *iam_pb.Role) *iam.Role {
+	name, id, arn, path := role.Name, role.RoleId, RoleArn(role.Name), "/"
+	r := &iam.Role{RoleName: &name, RoleId: &id, Arn: &arn, Path: &path, CreateDate: unixTime(role.CreateDate)}
+	if role.AssumeRolePolicyDocument != "" {
+		// AWS returns the trust policy URL-encoded
+		document := url.QueryEscape(role.AssumeRolePolicyDocument)
+		r.AssumeRolePolicyDocument = &document
+	}
+	if role.Description != "" {
+		description := role.Description
+		r.Description = &description
+	}
+	maxSessionDuration := role.MaxSessionDuration
+	if maxSessionDuration == 0 {
+		maxSessionDuration = DefaultRoleMaxSessionDuration
+	}
+	r.MaxSessionDuration = &maxSessionDuration
+	return r
+}
+
+func parseMaxSessionDuration(value string) (int64, *Error) {
+	if value == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < MinRoleMaxSessionDuration || seconds > MaxRoleMaxSessionDuration {
+		return 0, invalidInput("MaxSessionDuration must be between %d and %d seconds", MinRoleMaxSessionDuration, MaxRoleMaxSessionDuration)
+	}
+	return seconds, nil
+}
+
+// validateTrustPolicy checks the structure of a role trust policy. Trust policies
+// carry principals, so they are validated separately from S3 policy documents.
+func validateTrustPolicy(document string) *Error {
+	var trustPolicy struct {
+		Version   string            `json:"Version"`
+		Statement []json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &trustPolicy); err != nil {
+		return &Error{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+	}
+	if len(trustPolicy.Statement) == 0 {
+		return &Error{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: fmt.Errorf("trust policy has no statements")}
+	}
+	return nil
+}
+
+func (m *managedActionContext) createRole() (resp CreateRoleResponse, iamErr *Error) {
+	roleName, iamErr := m.required("RoleName")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if !credential.RoleNamePattern.MatchString(roleName) {
+		return resp, invalidInput("invalid role name %s", roleName)
+	}
+	trustPolicy, iamErr := m.required("AssumeRolePolicyDocument")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if iamErr := validateTrustPolicy(trustPolicy); iamErr != nil {
+		return resp, iamErr
+	}
+	maxSessionDuration, iamErr := parseMaxSessionDuration(m.values.Get("MaxSessionDuration"))
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if _, existing := m.findRole(roleName); existing != nil {
+		return resp, &Error{Code: iam.ErrCodeEntityAlreadyExistsException, Error: fmt.Errorf("role with name %s already exists", roleName)}
+	}
+	roleId, iamErr := generateEntityId(RoleIdPrefix)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	role := &iam_pb.Role{
+		Name:                     roleName,
+		RoleId:                   roleId,
+		Description:              m.values.Get("Description"),
+		AssumeRolePolicyDocument: trustPolicy,
+		MaxSessionDuration:       maxSessionDuration,
+		CreateDate:               time.Now().Unix(),
+	}
+	m.s3cfg.Roles = append(m.s3cfg.Roles, role)
+	m.changed = true
+	resp.CreateRoleResult.Role = *roleToIam(role)
+	return resp, nil
+}
+
+func (m *managedActionContext) getRole() (resp GetRoleResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.GetRoleResult.Role = *roleToIam(role)
+	return resp, nil
+}
+
+func (m *managedActionContext) listRoles() (resp ListRolesResponse, iamErr *Error) {
+	for _, role := range m.s3cfg.Roles {
+		resp.ListRolesResult.Roles = append(resp.ListRolesResult.Roles, roleToIam(role))
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) updateRole() (resp UpdateRoleResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if _, found := m.values["Description"]; found {
+		role.Description = m.values.Get("Description")
+	}
+	if value := m.values.Get("MaxSessionDuration"); value != "" {
+		if role.MaxSessionDuration, iamErr = parseMaxSessionDuration(value); iamErr != nil {
+			return resp, iamErr
+		}
+	}
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) deleteRole() (resp DeleteRoleResponse, iamErr *Error) {
+	i, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if len(role.PolicyNames) > 0 {
+		return resp, &Error{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("role %s must have no attached policies before deletion", role.Name)}
+	}
+	m.s3cfg.Roles = append(m.s3cfg.Roles[:i], m.s3cfg.Roles[i+1:]...)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) updateAssumeRolePolicy() (resp UpdateAssumeRolePolicyResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	trustPolicy, iamErr := m.required("PolicyDocument")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if iamErr := validateTrustPolicy(trustPolicy); iamErr != nil {
+		return resp, iamErr
+	}
+	role.AssumeRolePolicyDocument = trustPolicy
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) attachRolePolicy() (resp AttachRolePolicyResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyName, _, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	role.PolicyNames = addName(role.PolicyNames, policyName)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) detachRolePolicy() (resp DetachRolePolicyResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyArn, iamErr := m.required("PolicyArn")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var removed bool
+	if role.PolicyNames, removed = removeName(role.PolicyNames, PolicyNameFromArn(policyArn)); !removed {
+		return resp, noSuchEntity("policy %s is not attached to role %s", policyArn, role.Name)
+	}
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) listAttachedRolePolicies() (resp ListAttachedRolePoliciesResponse, iamErr *Error) {
+	_, role, iamErr := m.requireRole()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.ListAttachedRolePoliciesResult.AttachedPolicies = attachedPolicies(role.PolicyNames)
+	return resp, nil
+}
+
+// User policy attachments
+
+func (m *managedActionContext) attachUserPolicy() (resp AttachUserPolicyResponse, iamErr *Error) {
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyName, _, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	ident.PolicyNames = addName(ident.PolicyNames, policyName)
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) detachUserPolicy() (resp DetachUserPolicyResponse, iamErr *Error) {
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyArn, iamErr := m.required("PolicyArn")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var removed bool
+	if ident.PolicyNames, removed = removeName(ident.PolicyNames, PolicyNameFromArn(policyArn)); !removed {
+		return resp, noSuchEntity("policy %s is not attached to user %s", policyArn, ident.Name)
+	}
+	m.changed = true
+	return resp, nil
+}
+
+func (m *managedActionContext) listAttachedUserPolicies() (resp ListAttachedUserPoliciesResponse, iamErr *Error) {
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.ListAttachedUserPoliciesResult.AttachedPolicies = attachedPolicies(ident.PolicyNames)
+	return resp, nil
+}
+
+// Managed policies
+
+// attachmentCount counts the users, groups and roles a managed policy is attached to.
+func (m *managedActionContext) attachmentCount(policyName string) int64 {
+	var count int64
+	contains := func(names []string) {
+		for _, name := range names {
+			if name == policyName {
+				count++
+				return
+			}
+		}
+	}
+	for _, ident := range m.s3cfg.Identities {
+		contains(ident.PolicyNames)
+	}
+	for _, group := range m.s3cfg.Groups {
+		contains(group.PolicyNames)
+	}
+	for _, role := range m.s3cfg.Roles {
+		contains(role.PolicyNames)
+	}
+	return count
+}
+
+// loadPolicyVersions returns the version history of a policy. Policies created
+// before versioning was available get a single default version "v1".
+func (m *managedActionContext) loadPolicyVersions(policyName string, document policy_engine.PolicyDocument) (*iam_pb.PolicyVersions, *Error) {
+	versions, err := m.store.GetPolicyVersions(m.ctx, policyName)
+	if err != nil {
+		return nil, serviceFailure(err)
+	}
+	if versions != nil && len(versions.Versions) > 0 {
+		return versions, nil
+	}
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return nil, serviceFailure(err)
+	}
+	return &iam_pb.PolicyVersions{
+		PolicyName:       policyName,
+		Versions:         []*iam_pb.PolicyVersion{{VersionId: "v1", Document: string(documentBytes)}},
+		DefaultVersionId: "v1",
+		NextVersion:      2,
+	}, nil
+}
+
+func (m *managedActionContext) policyToIam(policyName string, document policy_engine.PolicyDocument) (*iam.Policy, *Error) {
+	versions, iamErr := m.loadPolicyVersions(policyName, document)
+	if iamErr != nil {
+		return nil, iamErr
+	}
+	name, arn, path := policyName, PolicyArn(policyName), "/"
+	defaultVersionId := versions.DefaultVersionId
+	attachable := true
+	attachmentCount := m.attachmentCount(policyName)
+	policyId := Hash(&arn)
+	var updateDate *time.Time
+	for _, version := range versions.Versions {
+		if version.VersionId == defaultVersionId {
+			updateDate = unixTime(version.CreateDate)
+		}
+	}
+	return &iam.Policy{
+		PolicyName:       &name,
+		PolicyId:         &policyId,
+		Arn:              &arn,
+		Path:             &path,
+		DefaultVersionId: &defaultVersionId,
+		AttachmentCount:  &attachmentCount,
+		IsAttachable:     &attachable,
+		CreateDate:       unixTime(versions.CreateDate),
+		UpdateDate:       updateDate,
+	}, nil
+}
+
+func (m *managedActionContext) createPolicy() (resp CreatePolicyResponse, iamErr *Error) {
+	policyName, iamErr := m.required("PolicyName")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyDocumentString, iamErr := m.required("PolicyDocument")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var document policy_engine.PolicyDocument
+	if err := json.Unmarshal([]byte(policyDocumentString), &document); err != nil {
+		return resp, &Error{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+	}
+	if iamErr := m.requireStore(); iamErr != nil {
+		return resp, iamErr
+	}
+	now := time.Now().Unix()
+	if err := m.store.PutPolicy(m.ctx, policyName, document); err != nil {
+		return resp, serviceFailure(err)
+	}
+	versions := &iam_pb.PolicyVersions{
+		PolicyName:       policyName,
+		Versions:         []*iam_pb.PolicyVersion{{VersionId: "v1", Document: policyDocumentString, CreateDate: now}},
+		DefaultVersionId: "v1",
+		NextVersion:      2,
+		CreateDate:       now,
+	}
+	if err := m.store.PutPolicyVersions(m.ctx, versions); err != nil {
+		return resp, serviceFailure(err)
+	}
+	policy, iamErr := m.policyToIam(policyName, document)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.CreatePolicyResult.Policy = *policy
+	return resp, nil
+}
+
+func (m *managedActionContext) getPolicy() (resp GetPolicyResponse, iamErr *Error) {
+	policyName, document, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policy, iamErr := m.policyToIam(policyName, document)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.GetPolicyResult.Policy = *policy
+	return resp, nil
+}
+
+func (m *managedActionContext) listPolicies() (resp ListPoliciesResponse, iamErr *Error) {
+	if iamErr := m.requireStore(); iamErr != nil {
+		return resp, iamErr
+	}
+	policies, err := m.store.GetPolicies(m.ctx)
+	if err != nil {
+		return resp, serviceFailure(err)
+	}
+	policyNames := make([]string, 0, len(policies))
+	for policyName := range policies {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+	onlyAttached := m.values.Get("OnlyAttached") == "true"
+	for _, policyName := range policyNames {
+		policy, iamErr := m.policyToIam(policyName, policies[policyName])
+		if iamErr != nil {
+			return resp, iamErr
+		}
+		if onlyAttached && *policy.AttachmentCount == 0 {
+			continue
+		}
+		resp.ListPoliciesResult.Policies = append(resp.ListPoliciesResult.Policies, policy)
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) deletePolicy() (resp DeletePolicyResponse, iamErr *Error) {
+	policyName, _, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if m.attachmentCount(policyName) > 0 {
+		return resp, &Error{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("policy %s must be detached from all users, groups and roles before deletion", policyName)}
+	}
+	if err := m.store.DeletePolicy(m.ctx, policyName); err != nil {
+		return resp, serviceFailure(err)
+	}
+	return resp, nil
+}
+
+func policyVersionToIam(version *iam_pb.PolicyVersion, defaultVersionId string, withDocument bool) *iam.PolicyVersion {
+	versionId := version.VersionId
+	isDefault := version.VersionId == defaultVersionId
+	v := &iam.PolicyVersion{VersionId: &versionId, IsDefaultVersion: &isDefault, CreateDate: unixTime(version.CreateDate)}
+	if withDocument {
+		// AWS returns policy documents URL-encoded
+		document := url.QueryEscape(version.Document)
+		v.Document = &document
+	}
+	return v
+}
+
+func (m *managedActionContext) createPolicyVersion() (resp CreatePolicyVersionResponse, iamErr *Error) {
+	policyName, document, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	policyDocumentString, iamErr := m.required("PolicyDocument")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var newDocument policy_engine.PolicyDocument
+	if err := json.Unmarshal([]byte(policyDocumentString), &newDocument); err != nil {
+		return resp, &Error{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
+	}
+	versions, iamErr := m.loadPolicyVersions(policyName, document)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if len(versions.Versions) >= MaxPolicyVersions {
+		return resp, &Error{Code: iam.ErrCodeLimitExceededException, Error: fmt.Errorf("policy %s already has the maximum of %d versions", policyName, MaxPolicyVersions)}
+	}
+	version := &iam_pb.PolicyVersion{
+		VersionId:  fmt.Sprintf("v%d", versions.NextVersion),
+		Document:   policyDocumentString,
+		CreateDate: time.Now().Unix(),
+	}
+	versions.NextVersion++
+	versions.Versions = append(versions.Versions, version)
+	setAsDefault := m.values.Get("SetAsDefault") == "true"
+	if setAsDefault {
+		versions.DefaultVersionId = version.VersionId
+	}
+	if err := m.store.PutPolicyVersions(m.ctx, versions); err != nil {
+		return resp, serviceFailure(err)
+	}
+	if setAsDefault {
+		if err := m.store.PutPolicy(m.ctx, policyName, newDocument); err != nil {
+			return resp, serviceFailure(err)
+		}
+	}
+	resp.CreatePolicyVersionResult.PolicyVersion = *policyVersionToIam(version, versions.DefaultVersionId, false)
+	return resp, nil
+}
+
+func (m *managedActionContext) requirePolicyVersion() (string, *iam_pb.PolicyVersions, int, *Error) {
+	policyName, document, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return "", nil, -1, iamErr
+	}
+	versionId, iamErr := m.required("VersionId")
+	if iamErr != nil {
+		return "", nil, -1, iamErr
+	}
+	versions, iamErr := m.loadPolicyVersions(policyName, document)
+	if iamErr != nil {
+		return "", nil, -1, iamErr
+	}
+	for i, version := range versions.Versions {
+		if version.VersionId == versionId {
+			return policyName, versions, i, nil
+		}
+	}
+	return "", nil, -1, noSuchEntity("policy %s has no version %s", policyName, versionId)
+}
+
+func (m *managedActionContext) getPolicyVersion() (resp GetPolicyVersionResponse, iamErr *Error) {
+	_, versions, i, iamErr := m.requirePolicyVersion()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	resp.GetPolicyVersionResult.PolicyVersion = *policyVersionToIam(versions.Versions[i], versions.DefaultVersionId, true)
+	return resp, nil
+}
+
+func (m *managedActionContext) listPolicyVersions() (resp ListPolicyVersionsResponse, iamErr *Error) {
+	policyName, document, iamErr := m.requirePolicy()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	versions, iamErr := m.loadPolicyVersions(policyName, document)
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	for _, version := range versions.Versions {
+		resp.ListPolicyVersionsResult.Versions = append(resp.ListPolicyVersionsResult.Versions, policyVersionToIam(version, versions.DefaultVersionId, false))
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) deletePolicyVersion() (resp DeletePolicyVersionResponse, iamErr *Error) {
+	_, versions, i, iamErr := m.requirePolicyVersion()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	if versions.Versions[i].VersionId == versions.DefaultVersionId {
+		return resp, &Error{Code: iam.ErrCodeDeleteConflictException, Error: fmt.Errorf("cannot delete the default version %s of a policy", versions.DefaultVersionId)}
+	}
+	versions.Versions = append(versions.Versions[:i], versions.Versions[i+1:]...)
+	if err := m.store.PutPolicyVersions(m.ctx, versions); err != nil {
+		return resp, serviceFailure(err)
+	}
+	return resp, nil
+}
+
+func (m *managedActionContext) setDefaultPolicyVersion() (resp SetDefaultPolicyVersionResponse, iamErr *Error) {
+	policyName, versions, i, iamErr := m.requirePolicyVersion()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	var document policy_engine.PolicyDocument
+	if err := json.Unmarshal([]byte(versions.Versions[i].Document), &document); err != nil {
+		return resp, serviceFailure(err)
+	}
+	versions.DefaultVersionId = versions.Versions[i].VersionId
+	if err := m.store.PutPolicyVersions(m.ctx, versions); err != nil {
+		return resp, serviceFailure(err)
+	}
+	if err := m.store.PutPolicy(m.ctx, policyName, document); err != nil {
+		return resp, serviceFailure(err)
+	}
+	return resp, nil
+}
+
+// Access keys
+
+func (m *managedActionContext) updateAccessKey() (resp UpdateAccessKeyResponse, iamErr *Error) {
+	ident, iamErr := m.requireUser()
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	accessKeyId, iamErr := m.required("AccessKeyId")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	status := m.values.Get("Status")
+	if status != AccessKeyStatusActive && status != AccessKeyStatusInactive {
+		return resp, invalidInput("Status must be %s or %s", AccessKeyStatusActive, AccessKeyStatusInactive)
+	}
+	for _, cred := range ident.Credentials {
+		if cred.AccessKey == accessKeyId {
+			cred.Status = status
+			m.changed = true
+			return resp, nil
+		}
+	}
+	return resp, noSuchEntity("the access key with id %s for user %s cannot be found", accessKeyId, ident.Name)
+}
+
+func (m *managedActionContext) getAccessKeyLastUsed() (resp GetAccessKeyLastUsedResponse, iamErr *Error) {
+	accessKeyId, iamErr := m.required("AccessKeyId")
+	if iamErr != nil {
+		return resp, iamErr
+	}
+	for _, ident := range m.s3cfg.Identities {
+		for _, cred := range ident.Credentials {
+			if cred.AccessKey != accessKeyId {
+				continue
+			}
+			resp.GetAccessKeyLastUsedResult.UserName = ident.Name
+			// AWS reports "N/A" for the service and region of keys that were never used
+			serviceName, region := "N/A", "N/A"
+			if cred.LastUsedDate != 0 {
+				serviceName, region = cred.LastUsedService, cred.LastUsedRegion
+			}
+			resp.GetAccessKeyLastUsedResult.AccessKeyLastUsed = iam.AccessKeyLastUsed{
+				LastUsedDate: unixTime(cred.LastUsedDate),
+				ServiceName:  &serviceName,
+				Region:       &region,
+			}
+			return resp, nil
+		}
+	}
+	return resp, noSuchEntity("the access key with id %s cannot be found", accessKeyId)
+}
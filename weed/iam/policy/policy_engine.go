@@ -324,6 +324,16 @@ func (e *PolicyEngine) IsInitialized() bool {
 	return e.initialized
 }
 
+// GetStore returns the policy store
+func (e *PolicyEngine) GetStore() PolicyStore {
+	return e.store
+}
+
+// SetStore replaces the policy store, e.g. to resolve policies managed through the IAM API
+func (e *PolicyEngine) SetStore(store PolicyStore) {
+	e.store = store
+}
+
 // AddPolicy adds a policy to the engine (filerAddress ignored for memory stores)
 func (e *PolicyEngine) AddPolicy(filerAddress string, name string, policy *PolicyDocument) error {
 	if !e.initialized {
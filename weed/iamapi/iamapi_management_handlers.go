@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -75,16 +76,22 @@ func (iama *IamApiServer) ListUsers(s3cfg *iam_pb.S3ApiConfiguration, values url
 }
 
 func (iama *IamApiServer) ListAccessKeys(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp ListAccessKeysResponse) {
-	status := iam.StatusTypeActive
 	userName := values.Get("UserName")
 	for _, ident := range s3cfg.Identities {
 		if userName != "" && userName != ident.Name {
 			continue
 		}
 		for _, cred := range ident.Credentials {
-			resp.ListAccessKeysResult.AccessKeyMetadata = append(resp.ListAccessKeysResult.AccessKeyMetadata,
-				&iam.AccessKeyMetadata{UserName: &ident.Name, AccessKeyId: &cred.AccessKey, Status: &status},
-			)
+			status := iam.StatusTypeActive
+			if cred.Status == iamlib.AccessKeyStatusInactive {
+				status = iam.StatusTypeInactive
+			}
+			metadata := &iam.AccessKeyMetadata{UserName: &ident.Name, AccessKeyId: &cred.AccessKey, Status: &status}
+			if cred.CreateDate != 0 {
+				createDate := time.Unix(cred.CreateDate, 0).UTC()
+				metadata.CreateDate = &createDate
+			}
+			resp.ListAccessKeysResult.AccessKeyMetadata = append(resp.ListAccessKeysResult.AccessKeyMetadata, metadata)
 		}
 	}
 	return resp
@@ -141,34 +148,7 @@ func GetPolicyDocument(policy *string) (policy_engine.PolicyDocument, error) {
 	return policyDocument, nil
 }
 
-func (iama *IamApiServer) CreatePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp CreatePolicyResponse, iamError *IamError) {
-	policyName := values.Get("PolicyName")
-	policyDocumentString := values.Get("PolicyDocument")
-	policyDocument, err := GetPolicyDocument(&policyDocumentString)
-	if err != nil {
-		return CreatePolicyResponse{}, &IamError{Code: iam.ErrCodeMalformedPolicyDocumentException, Error: err}
-	}
-	policyId := Hash(&policyDocumentString)
-	arn := fmt.Sprintf("arn:aws:iam:::policy/%s", policyName)
-	resp.CreatePolicyResult.Policy.PolicyName = &policyName
-	resp.CreatePolicyResult.Policy.Arn = &arn
-	resp.CreatePolicyResult.Policy.PolicyId = &policyId
-	policies := Policies{}
-	// Note: Lock is already held by DoActions, no need to acquire here
-	if err = iama.s3ApiConfig.GetPolicies(&policies); err != nil {
-		return resp, &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
-	}
-	policies.Policies[policyName] = policyDocument
-	if err = iama.s3ApiConfig.PutPolicies(&policies); err != nil {
-		return resp, &IamError{Code: iam.ErrCodeServiceFailureException, Error: err}
-	}
-	return resp, nil
-}
-
-type IamError struct {
-	Code  string
-	Error error
-}
+type IamError = iamlib.Error
 
 // https://docs.aws.amazon.com/IAM/latest/APIReference/API_PutUserPolicy.html
 func (iama *IamApiServer) PutUserPolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (resp PutUserPolicyResponse, iamError *IamError) {
@@ -321,11 +301,13 @@ func (iama *IamApiServer) CreateAccessKey(s3cfg *iam_pb.S3ApiConfiguration, valu
 	resp.CreateAccessKeyResult.AccessKey.SecretAccessKey = &secretAccessKey
 	resp.CreateAccessKeyResult.AccessKey.UserName = &userName
 	resp.CreateAccessKeyResult.AccessKey.Status = &status
+	createDate := time.Now().UTC()
+	resp.CreateAccessKeyResult.AccessKey.CreateDate = &createDate
 	changed := false
 	for _, ident := range s3cfg.Identities {
 		if userName == ident.Name {
 			ident.Credentials = append(ident.Credentials,
-				&iam_pb.Credential{AccessKey: accessKeyId, SecretKey: secretAccessKey})
+				&iam_pb.Credential{AccessKey: accessKeyId, SecretKey: secretAccessKey, Status: iamlib.AccessKeyStatusActive, CreateDate: createDate.Unix()})
 			changed = true
 			break
 		}
@@ -336,8 +318,10 @@ func (iama *IamApiServer) CreateAccessKey(s3cfg *iam_pb.S3ApiConfiguration, valu
 				Name: userName,
 				Credentials: []*iam_pb.Credential{
 					{
-						AccessKey: accessKeyId,
-						SecretKey: secretAccessKey,
+						AccessKey:  accessKeyId,
+						SecretKey:  secretAccessKey,
+						Status:     iamlib.AccessKeyStatusActive,
+						CreateDate: createDate.Unix(),
 					},
 				},
 			},
@@ -475,16 +459,6 @@ func (iama *IamApiServer) DoActions(w http.ResponseWriter, r *http.Request) {
 	case "DeleteAccessKey":
 		iama.handleImplicitUsername(r, values)
 		response = iama.DeleteAccessKey(s3cfg, values)
-	case "CreatePolicy":
-		response, iamError = iama.CreatePolicy(s3cfg, values)
-		if iamError != nil {
-			glog.Errorf("CreatePolicy:  %+v", iamError.Error)
-			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
-			return
-		}
-		// CreatePolicy persists the policy document via iama.s3ApiConfig.PutPolicies().
-		// The `changed` flag is false because this does not modify the main s3cfg.Identities configuration.
-		changed = false
 	case "PutUserPolicy":
 		var iamError *IamError
 		response, iamError = iama.PutUserPolicy(s3cfg, values)
@@ -507,6 +481,21 @@ func (iama *IamApiServer) DoActions(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	default:
+		action := r.Form.Get("Action")
+		if iamlib.IsManagedAction(action) {
+			if action == "UpdateAccessKey" {
+				iama.handleImplicitUsername(r, values)
+			}
+			// Groups, roles and user policy attachments live in s3cfg; managed policy
+			// documents and their versions are persisted to iama.policyStore directly.
+			response, changed, iamError = iamlib.ExecuteManagedAction(r.Context(), iama.policyStore, s3cfg, values)
+			if iamError != nil {
+				glog.Errorf("%s: %+v", action, iamError.Error)
+				writeIamErrorResponse(w, r, iamError)
+				return
+			}
+			break
+		}
 		errNotImplemented := s3err.GetAPIError(s3err.ErrNotImplemented)
 		errorResponse := ErrorResponse{}
 		errorResponse.Error.Code = &errNotImplemented.Code
@@ -5,7 +5,6 @@ package iamapi
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -13,11 +12,11 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	iamlib "github.com/seaweedfs/seaweedfs/weed/iam"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api"
-	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
 	. "github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -29,8 +28,6 @@ import (
 type IamS3ApiConfig interface {
 	GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error)
 	PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error)
-	GetPolicies(policies *Policies) (err error)
-	PutPolicies(policies *Policies) (err error)
 }
 
 type IamS3ApiConfigure struct {
@@ -48,6 +45,7 @@ type IamServerOption struct {
 
 type IamApiServer struct {
 	s3ApiConfig     IamS3ApiConfig
+	policyStore     iamlib.ManagedPolicyStore
 	iam             *s3api.IdentityAccessManagement
 	shutdownContext context.Context
 	shutdownCancel  context.CancelFunc
@@ -94,6 +92,7 @@ func NewIamApiServerWithStore(router *mux.Router, option *IamServerOption, expli
 
 	iamApiServer = &IamApiServer{
 		s3ApiConfig:     s3ApiConfigure,
+		policyStore:     configure.credentialManager,
 		iam:             iam,
 		shutdownContext: shutdownCtx,
 		shutdownCancel:  shutdownCancel,
@@ -190,37 +189,3 @@ func (iama *IamS3ApiConfigure) PutS3ApiConfigurationToFiler(s3cfg *iam_pb.S3ApiC
 		return nil
 	})
 }
-
-func (iama *IamS3ApiConfigure) GetPolicies(policies *Policies) (err error) {
-	var buf bytes.Buffer
-	err = pb.WithOneOfGrpcFilerClients(false, iama.option.Filers, iama.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
-		if err = filer.ReadEntry(iama.masterClient, client, filer.IamConfigDirectory, filer.IamPoliciesFile, &buf); err != nil {
-			return err
-		}
-		return nil
-	})
-	if err != nil && err != filer_pb.ErrNotFound {
-		return err
-	}
-	if err == filer_pb.ErrNotFound || buf.Len() == 0 {
-		policies.Policies = make(map[string]policy_engine.PolicyDocument)
-		return nil
-	}
-	if err := json.Unmarshal(buf.Bytes(), policies); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (iama *IamS3ApiConfigure) PutPolicies(policies *Policies) (err error) {
-	var b []byte
-	if b, err = json.Marshal(policies); err != nil {
-		return err
-	}
-	return pb.WithOneOfGrpcFilerClients(false, iama.option.Filers, iama.option.GrpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
-		if err := filer.SaveInsideFiler(client, filer.IamConfigDirectory, filer.IamPoliciesFile, b); err != nil {
-			return err
-		}
-		return nil
-	})
-}
@@ -14,40 +14,43 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/copier"
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	_ "github.com/seaweedfs/seaweedfs/weed/credential/memory"
+	iamlib "github.com/seaweedfs/seaweedfs/weed/iam"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api"
-	"github.com/seaweedfs/seaweedfs/weed/s3api/policy_engine"
+	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/stretchr/testify/assert"
 )
 
 var GetS3ApiConfiguration func(s3cfg *iam_pb.S3ApiConfiguration) (err error)
 var PutS3ApiConfiguration func(s3cfg *iam_pb.S3ApiConfiguration) (err error)
-var GetPolicies func(policies *Policies) (err error)
-var PutPolicies func(policies *Policies) (err error)
 
 var s3config = iam_pb.S3ApiConfiguration{}
-var policiesFile = Policies{Policies: make(map[string]policy_engine.PolicyDocument)}
-var ias = IamApiServer{s3ApiConfig: iamS3ApiConfigureMock{}}
+var ias = IamApiServer{s3ApiConfig: iamS3ApiConfigureMock{}, policyStore: newMemoryPolicyStore()}
+
+func newMemoryPolicyStore() *credential.CredentialManager {
+	cm, err := credential.NewCredentialManager(credential.StoreTypeMemory, util.GetViper(), "")
+	if err != nil {
+		panic(err)
+	}
+	return cm
+}
 
 type iamS3ApiConfigureMock struct{}
 
 func (iam iamS3ApiConfigureMock) GetS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
 	_ = copier.Copy(&s3cfg.Identities, &s3config.Identities)
+	_ = copier.Copy(&s3cfg.Groups, &s3config.Groups)
+	_ = copier.Copy(&s3cfg.Roles, &s3config.Roles)
 	return nil
 }
 
 func (iam iamS3ApiConfigureMock) PutS3ApiConfiguration(s3cfg *iam_pb.S3ApiConfiguration) (err error) {
 	_ = copier.Copy(&s3config.Identities, &s3cfg.Identities)
-	return nil
-}
-
-func (iam iamS3ApiConfigureMock) GetPolicies(policies *Policies) (err error) {
-	_ = copier.Copy(&policies, &policiesFile)
-	return nil
-}
-
-func (iam iamS3ApiConfigureMock) PutPolicies(policies *Policies) (err error) {
-	_ = copier.Copy(&policiesFile, &policies)
+	s3config.Groups, s3config.Roles = nil, nil
+	_ = copier.Copy(&s3config.Groups, &s3cfg.Groups)
+	_ = copier.Copy(&s3config.Roles, &s3cfg.Roles)
 	return nil
 }
 
@@ -305,3 +308,197 @@ func mustMarshalJSON(t *testing.T, v interface{}) []byte {
 	}
 	return data
 }
+
+const testPolicyDocument = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":["arn:aws:s3:::EXAMPLE-BUCKET/*"]}]}`
+
+func TestGroupLifecycle(t *testing.T) {
+	svc := iam.New(session.New())
+	executeRequestOK := func(req *http.Request, v interface{}) {
+		t.Helper()
+		response, err := executeRequest(req, v)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	}
+
+	req, _ := svc.CreateUserRequest(&iam.CreateUserInput{UserName: aws.String("GroupMember")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, CreateUserResponse{})
+
+	req, _ = svc.CreateGroupRequest(&iam.CreateGroupInput{GroupName: aws.String("Developers")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.CreateGroupResponse{})
+
+	req, _ = svc.CreateGroupRequest(&iam.CreateGroupInput{GroupName: aws.String("Developers")})
+	_ = req.Build()
+	response, _ := executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	req, _ = svc.CreatePolicyRequest(&iam.CreatePolicyInput{PolicyName: aws.String("DevelopersRead"), PolicyDocument: aws.String(testPolicyDocument)})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, CreatePolicyResponse{})
+
+	req, _ = svc.AddUserToGroupRequest(&iam.AddUserToGroupInput{GroupName: aws.String("Developers"), UserName: aws.String("GroupMember")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.AddUserToGroupResponse{})
+
+	req, _ = svc.AttachGroupPolicyRequest(&iam.AttachGroupPolicyInput{GroupName: aws.String("Developers"), PolicyArn: aws.String("arn:aws:iam:::policy/DevelopersRead")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.AttachGroupPolicyResponse{})
+
+	req, _ = svc.GetGroupRequest(&iam.GetGroupInput{GroupName: aws.String("Developers")})
+	_ = req.Build()
+	getGroup := iamlib.GetGroupResponse{}
+	executeRequestOK(req.HTTPRequest, &getGroup)
+	assert.Equal(t, 1, len(getGroup.GetGroupResult.Users))
+	assert.Equal(t, []string{"DevelopersRead"}, iamlib.GroupPolicyNames(&s3config, "GroupMember"))
+
+	// a group with members and attached policies cannot be deleted
+	req, _ = svc.DeleteGroupRequest(&iam.DeleteGroupInput{GroupName: aws.String("Developers")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	// an attached policy cannot be deleted
+	req, _ = svc.DeletePolicyRequest(&iam.DeletePolicyInput{PolicyArn: aws.String("arn:aws:iam:::policy/DevelopersRead")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	req, _ = svc.DetachGroupPolicyRequest(&iam.DetachGroupPolicyInput{GroupName: aws.String("Developers"), PolicyArn: aws.String("arn:aws:iam:::policy/DevelopersRead")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.DetachGroupPolicyResponse{})
+
+	req, _ = svc.RemoveUserFromGroupRequest(&iam.RemoveUserFromGroupInput{GroupName: aws.String("Developers"), UserName: aws.String("GroupMember")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.RemoveUserFromGroupResponse{})
+
+	req, _ = svc.DeleteGroupRequest(&iam.DeleteGroupInput{GroupName: aws.String("Developers")})
+	_ = req.Build()
+	executeRequestOK(req.HTTPRequest, iamlib.DeleteGroupResponse{})
+	assert.Equal(t, 0, len(s3config.Groups))
+}
+
+func TestRoleLifecycle(t *testing.T) {
+	svc := iam.New(session.New())
+	trustPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Federated":"test-oidc"},"Action":["sts:AssumeRoleWithWebIdentity"]}]}`
+
+	req, _ := svc.CreateRoleRequest(&iam.CreateRoleInput{RoleName: aws.String("Reader"), AssumeRolePolicyDocument: aws.String(trustPolicy)})
+	_ = req.Build()
+	createRole := iamlib.CreateRoleResponse{}
+	response, err := executeRequest(req.HTTPRequest, &createRole)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "arn:aws:iam::role/Reader", aws.StringValue(createRole.CreateRoleResult.Role.Arn))
+	assert.Equal(t, int64(3600), aws.Int64Value(createRole.CreateRoleResult.Role.MaxSessionDuration))
+
+	req, _ = svc.CreateRoleRequest(&iam.CreateRoleInput{RoleName: aws.String("Broken"), AssumeRolePolicyDocument: aws.String("{")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	req, _ = svc.UpdateRoleRequest(&iam.UpdateRoleInput{RoleName: aws.String("Reader"), Description: aws.String("read only"), MaxSessionDuration: aws.Int64(7200)})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	req, _ = svc.GetRoleRequest(&iam.GetRoleInput{RoleName: aws.String("Reader")})
+	_ = req.Build()
+	getRole := iamlib.GetRoleResponse{}
+	_, err = executeRequest(req.HTTPRequest, &getRole)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "read only", aws.StringValue(getRole.GetRoleResult.Role.Description))
+	assert.Equal(t, int64(7200), aws.Int64Value(getRole.GetRoleResult.Role.MaxSessionDuration))
+
+	req, _ = svc.DeleteRoleRequest(&iam.DeleteRoleInput{RoleName: aws.String("Reader")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	req, _ = svc.GetRoleRequest(&iam.GetRoleInput{RoleName: aws.String("Reader")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestPolicyVersions(t *testing.T) {
+	svc := iam.New(session.New())
+	policyArn := aws.String("arn:aws:iam:::policy/Versioned")
+
+	req, _ := svc.CreatePolicyRequest(&iam.CreatePolicyInput{PolicyName: aws.String("Versioned"), PolicyDocument: aws.String(testPolicyDocument)})
+	_ = req.Build()
+	response, _ := executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	// v1 exists already, so four more versions reach the limit of five
+	for i := 0; i < 4; i++ {
+		req, _ = svc.CreatePolicyVersionRequest(&iam.CreatePolicyVersionInput{PolicyArn: policyArn, PolicyDocument: aws.String(testPolicyDocument), SetAsDefault: aws.Bool(i == 0)})
+		_ = req.Build()
+		response, _ = executeRequest(req.HTTPRequest, nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+	req, _ = svc.CreatePolicyVersionRequest(&iam.CreatePolicyVersionInput{PolicyArn: policyArn, PolicyDocument: aws.String(testPolicyDocument)})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	req, _ = svc.GetPolicyRequest(&iam.GetPolicyInput{PolicyArn: policyArn})
+	_ = req.Build()
+	getPolicy := iamlib.GetPolicyResponse{}
+	_, err := executeRequest(req.HTTPRequest, &getPolicy)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "v2", aws.StringValue(getPolicy.GetPolicyResult.Policy.DefaultVersionId))
+
+	// the default version cannot be deleted
+	req, _ = svc.DeletePolicyVersionRequest(&iam.DeletePolicyVersionInput{PolicyArn: policyArn, VersionId: aws.String("v2")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	req, _ = svc.SetDefaultPolicyVersionRequest(&iam.SetDefaultPolicyVersionInput{PolicyArn: policyArn, VersionId: aws.String("v1")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	req, _ = svc.DeletePolicyVersionRequest(&iam.DeletePolicyVersionInput{PolicyArn: policyArn, VersionId: aws.String("v2")})
+	_ = req.Build()
+	response, _ = executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	req, _ = svc.ListPolicyVersionsRequest(&iam.ListPolicyVersionsInput{PolicyArn: policyArn})
+	_ = req.Build()
+	listVersions := iamlib.ListPolicyVersionsResponse{}
+	_, err = executeRequest(req.HTTPRequest, &listVersions)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(listVersions.ListPolicyVersionsResult.Versions))
+}
+
+func TestAccessKeyLifecycle(t *testing.T) {
+	svc := iam.New(session.New())
+	req, _ := svc.CreateAccessKeyRequest(&iam.CreateAccessKeyInput{UserName: aws.String("KeyOwner")})
+	_ = req.Build()
+	createAccessKey := CreateAccessKeyResponse{}
+	_, err := executeRequest(req.HTTPRequest, &createAccessKey)
+	assert.Equal(t, nil, err)
+	accessKeyId := createAccessKey.CreateAccessKeyResult.AccessKey.AccessKeyId
+
+	req, _ = svc.UpdateAccessKeyRequest(&iam.UpdateAccessKeyInput{UserName: aws.String("KeyOwner"), AccessKeyId: accessKeyId, Status: aws.String(iam.StatusTypeInactive)})
+	_ = req.Build()
+	response, _ := executeRequest(req.HTTPRequest, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	req, _ = svc.ListAccessKeysRequest(&iam.ListAccessKeysInput{UserName: aws.String("KeyOwner")})
+	_ = req.Build()
+	listAccessKeys := ListAccessKeysResponse{}
+	_, err = executeRequest(req.HTTPRequest, &listAccessKeys)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(listAccessKeys.ListAccessKeysResult.AccessKeyMetadata))
+	assert.Equal(t, iam.StatusTypeInactive, aws.StringValue(listAccessKeys.ListAccessKeysResult.AccessKeyMetadata[0].Status))
+
+	req, _ = svc.GetAccessKeyLastUsedRequest(&iam.GetAccessKeyLastUsedInput{AccessKeyId: accessKeyId})
+	_ = req.Build()
+	lastUsed := iamlib.GetAccessKeyLastUsedResponse{}
+	_, err = executeRequest(req.HTTPRequest, &lastUsed)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "KeyOwner", lastUsed.GetAccessKeyLastUsedResult.UserName)
+	assert.Equal(t, "N/A", aws.StringValue(lastUsed.GetAccessKeyLastUsedResult.AccessKeyLastUsed.ServiceName))
+}
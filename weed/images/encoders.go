@@ -0,0 +1,96 @@
+package images
+
+import (
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"sync"
+)
+
+// An Encoder writes the image in its format, with the quality from 1 to 100, or 0 for its default.
+type Encoder func(w io.Writer, img image.Image, quality int) error
+
+type registeredEncoder struct {
+	contentType string
+	encode      Encoder
+}
+
+var (
+	encodersLock sync.RWMutex
+	encoders     = map[string]registeredEncoder{
+		"jpeg": {"image/jpeg", encodeJpeg},
+		"png":  {"image/png", encodePng},
+		"gif":  {"image/gif", encodeGif},
+	}
+	// the formats which can be decoded, and requested as output with a registered encoder
+	knownFormats = []string{"jpeg", "png", "gif", "webp", "avif"}
+)
+
+// RegisterEncoder adds an encoder for the format, e.g. for webp or avif, which have no encoder in the standard library.
+func RegisterEncoder(format, contentType string, encode Encoder) {
+	encodersLock.Lock()
+	defer encodersLock.Unlock()
+	encoders[format] = registeredEncoder{contentType, encode}
+}
+
+// HasEncoder is true if images can be transformed into the format.
+func HasEncoder(format string) bool {
+	encodersLock.RLock()
+	defer encodersLock.RUnlock()
+	_, found := encoders[format]
+	return found
+}
+
+func getEncoder(format string) (registeredEncoder, bool) {
+	encodersLock.RLock()
+	defer encodersLock.RUnlock()
+	encoder, found := encoders[format]
+	return encoder, found
+}
+
+func isKnownFormat(format string) bool {
+	for _, known := range knownFormats {
+		if known == format {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeFormat(format string) string {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	if format == "jpg" {
+		return "jpeg"
+	}
+	return format
+}
+
+// IsTransformableImage is true for the file extensions and mime types of images which can be transformed.
+func IsTransformableImage(ext, mimeType string) bool {
+	if mimeType != "" {
+		format, found := strings.CutPrefix(strings.ToLower(mimeType), "image/")
+		if found {
+			return isKnownFormat(normalizeFormat(format))
+		}
+	}
+	return isKnownFormat(normalizeFormat(ext))
+}
+
+func encodeJpeg(w io.Writer, img image.Image, quality int) error {
+	var options *jpeg.Options
+	if quality > 0 {
+		options = &jpeg.Options{Quality: quality}
+	}
+	return jpeg.Encode(w, img, options)
+}
+
+func encodePng(w io.Writer, img image.Image, quality int) error {
+	return png.Encode(w, img)
+}
+
+func encodeGif(w io.Writer, img image.Image, quality int) error {
+	return gif.Encode(w, img, nil)
+}
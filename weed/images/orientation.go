@@ -12,24 +12,48 @@ import (
 
 // many code is copied from http://camlistore.org/pkg/images/images.go
 func FixJpgOrientation(data []byte) (oriented []byte) {
+	angle, flipMode, found := jpgOrientation(data)
+	if !found {
+		return data
+	}
+
+	if srcImage, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+		dstImage := flip(rotate(srcImage, angle), flipMode)
+		var buf bytes.Buffer
+		jpeg.Encode(&buf, dstImage, nil)
+		return buf.Bytes()
+	}
+
+	return data
+}
+
+// orientImage rotates and flips the decoded image as its exif orientation says
+func orientImage(data []byte, img image.Image) image.Image {
+	angle, flipMode, found := jpgOrientation(data)
+	if !found {
+		return img
+	}
+	return flip(rotate(img, angle), flipMode)
+}
+
+// jpgOrientation returns how to rotate and flip the image, or false if the image is already upright
+func jpgOrientation(data []byte) (angle int, flipMode FlipDirection, found bool) {
 	ex, err := exif.Decode(bytes.NewReader(data))
 	if err != nil {
-		return data
+		return
 	}
 	tag, err := ex.Get(exif.Orientation)
 	if err != nil {
-		return data
+		return
 	}
-	angle := 0
-	flipMode := FlipDirection(0)
 	orient, err := tag.Int(0)
 	if err != nil {
-		return data
+		return
 	}
 	switch orient {
 	case topLeftSide:
 		// do nothing
-		return data
+		return
 	case topRightSide:
 		flipMode = 2
 	case bottomRightSide:
@@ -47,16 +71,10 @@ func FixJpgOrientation(data []byte) (oriented []byte) {
 		flipMode = 2
 	case leftSideBottom:
 		angle = 90
+	default:
+		return
 	}
-
-	if srcImage, _, err := image.Decode(bytes.NewReader(data)); err == nil {
-		dstImage := flip(rotate(srcImage, angle), flipMode)
-		var buf bytes.Buffer
-		jpeg.Encode(&buf, dstImage, nil)
-		return buf.Bytes()
-	}
-
-	return data
+	return angle, flipMode, true
 }
 
 // Exif Orientation Tag values
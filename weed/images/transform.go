@@ -0,0 +1,218 @@
+package images
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// the largest width or height of a transformed image
+	MaxTransformDimension = 8192
+	MaxTransformDPR       = 4
+	// the largest source image to transform, in bytes
+	MaxTransformSourceSize = 32 * 1024 * 1024
+	// the signature of a transformation, see Transform.Sign
+	TransformSignatureParam = "sig"
+)
+
+// the fit modes
+const (
+	// FitScale resizes to the width and height, or keeps the aspect ratio if one of them is 0.
+	// A square thumbnail is cropped from a non-square image.
+	FitScale = "scale"
+	// FitContain resizes to fit within the width and height, keeping the aspect ratio
+	FitContain = "fit"
+	// FitFill resizes and crops the center to fill the width and height
+	FitFill = "fill"
+	// FitSmart resizes and crops the most detailed part to fill the width and height
+	FitSmart = "smart"
+)
+
+// FormatAuto picks the best format accepted by the client
+const FormatAuto = "auto"
+
+// Transform is an image transformation requested by the query parameters of a read:
+//
+//	width, height: the size in css pixels, never larger than the source image
+//	mode, or fit: scale (default), fit, fill or smart
+//	dpr: the device pixel ratio multiplying the width and height, 1 to 4
+//	crop_x1, crop_y1, crop_x2, crop_y2: a rectangle cropped before resizing
+//	format: jpeg, png, gif, webp, avif, or auto to negotiate with the Accept header
+//	quality: 1 to 100 for lossy formats
+//	strip: true to drop the metadata, even without other changes
+type Transform struct {
+	Width   int
+	Height  int
+	Fit     string
+	DPR     float64
+	Crop    image.Rectangle
+	Format  string
+	Quality int
+	Strip   bool
+}
+
+// ParseTransform returns the transformation of the query, or nil if the query has none.
+func ParseTransform(query url.Values) (*Transform, error) {
+	t := &Transform{}
+	var err error
+	if t.Width, err = parseTransformInt(query, "width", 0, MaxTransformDimension); err != nil {
+		return nil, err
+	}
+	if t.Height, err = parseTransformInt(query, "height", 0, MaxTransformDimension); err != nil {
+		return nil, err
+	}
+	if t.Quality, err = parseTransformInt(query, "quality", 0, 100); err != nil {
+		return nil, err
+	}
+
+	t.Fit = strings.ToLower(query.Get("fit"))
+	if t.Fit == "" {
+		t.Fit = strings.ToLower(query.Get("mode"))
+	}
+	switch t.Fit {
+	case "", FitScale, FitContain, FitFill, FitSmart:
+	default:
+		return nil, fmt.Errorf("unknown fit %q", t.Fit)
+	}
+	if t.Fit == FitScale {
+		t.Fit = ""
+	}
+
+	if s := query.Get("dpr"); s != "" {
+		if t.DPR, err = strconv.ParseFloat(s, 64); err != nil || t.DPR < 1 || t.DPR > MaxTransformDPR {
+			return nil, fmt.Errorf("dpr %q should be from 1 to %d", s, MaxTransformDPR)
+		}
+		if t.DPR == 1 {
+			t.DPR = 0
+		}
+	}
+
+	var x1, y1, x2, y2 int
+	for name, v := range map[string]*int{"crop_x1": &x1, "crop_y1": &y1, "crop_x2": &x2, "crop_y2": &y2} {
+		if *v, err = parseTransformInt(query, name, 0, math.MaxInt32); err != nil {
+			return nil, err
+		}
+	}
+	if x2 > x1 && y2 > y1 {
+		t.Crop = image.Rect(x1, y1, x2, y2)
+	}
+
+	t.Format = normalizeFormat(query.Get("format"))
+	if t.Format != "" && t.Format != FormatAuto && !isKnownFormat(t.Format) {
+		return nil, fmt.Errorf("unknown format %q", query.Get("format"))
+	}
+
+	if s := query.Get("strip"); s != "" {
+		if t.Strip, err = strconv.ParseBool(s); err != nil {
+			return nil, fmt.Errorf("strip %q: %v", s, err)
+		}
+	}
+
+	if t.IsEmpty() {
+		return nil, nil
+	}
+	return t, nil
+}
+
+func parseTransformInt(query url.Values, name string, minValue, maxValue int) (int, error) {
+	s := query.Get(name)
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < minValue || v > maxValue {
+		return 0, fmt.Errorf("%s %q should be from %d to %d", name, s, minValue, maxValue)
+	}
+	return v, nil
+}
+
+// IsEmpty is true if the transformation keeps the image as is.
+func (t *Transform) IsEmpty() bool {
+	return t.Width == 0 && t.Height == 0 && t.Crop.Empty() && t.Format == "" && t.Quality == 0 && !t.Strip
+}
+
+// Values encodes the transformation as query parameters, in the same form as parsed.
+func (t *Transform) Values() url.Values {
+	values := url.Values{}
+	if t.Width > 0 {
+		values.Set("width", strconv.Itoa(t.Width))
+	}
+	if t.Height > 0 {
+		values.Set("height", strconv.Itoa(t.Height))
+	}
+	if t.Fit != "" {
+		values.Set("fit", t.Fit)
+	}
+	if t.DPR > 0 {
+		values.Set("dpr", strconv.FormatFloat(t.DPR, 'f', -1, 64))
+	}
+	if !t.Crop.Empty() {
+		values.Set("crop_x1", strconv.Itoa(t.Crop.Min.X))
+		values.Set("crop_y1", strconv.Itoa(t.Crop.Min.Y))
+		values.Set("crop_x2", strconv.Itoa(t.Crop.Max.X))
+		values.Set("crop_y2", strconv.Itoa(t.Crop.Max.Y))
+	}
+	if t.Format != "" {
+		values.Set("format", t.Format)
+	}
+	if t.Quality > 0 {
+		values.Set("quality", strconv.Itoa(t.Quality))
+	}
+	if t.Strip {
+		values.Set("strip", "true")
+	}
+	return values
+}
+
+// Key is the canonical form of the transformation, e.g. to cache its renditions.
+func (t *Transform) Key() string {
+	return t.Values().Encode()
+}
+
+// Negotiate resolves the auto format to the best format in the Accept header with an encoder,
+// or to the format of the source image.
+func (t *Transform) Negotiate(accept string) *Transform {
+	if t.Format != FormatAuto {
+		return t
+	}
+	resolved := *t
+	resolved.Format = ""
+	for _, format := range []string{"avif", "webp"} {
+		if HasEncoder(format) && strings.Contains(accept, "image/"+format) {
+			resolved.Format = format
+			break
+		}
+	}
+	return &resolved
+}
+
+// Sign returns the signature of the transformation of the image at the path, with the signing key.
+func (t *Transform) Sign(signingKey []byte, path string) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(path))
+	mac.Write([]byte{'?'})
+	mac.Write([]byte(t.Key()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature of the transformation of the image at the path.
+// Without a signing key, all transformations are allowed.
+func (t *Transform) VerifySignature(signingKey []byte, path string, signature string) error {
+	if len(signingKey) == 0 {
+		return nil
+	}
+	if signature == "" {
+		return fmt.Errorf("image transformation is not signed")
+	}
+	if !hmac.Equal([]byte(signature), []byte(t.Sign(signingKey, path))) {
+		return fmt.Errorf("image transformation signature does not match")
+	}
+	return nil
+}
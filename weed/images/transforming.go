@@ -0,0 +1,148 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/cognusion/imaging"
+)
+
+// the largest source image to decode, in pixels
+const maxTransformSourcePixels = 100 * 1000 * 1000
+
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Transformed applies the transformation to the image data.
+// It returns the data as is if nothing needs to change, e.g. when the image is already smaller than the requested size.
+// The auto format should be negotiated before.
+func Transformed(data []byte, t *Transform) (transformed []byte, contentType string, err error) {
+	config, sourceFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	if config.Width*config.Height > maxTransformSourcePixels {
+		return nil, "", fmt.Errorf("image of %dx%d pixels is too large to transform", config.Width, config.Height)
+	}
+
+	format := t.Format
+	if format == "" || format == FormatAuto {
+		format = sourceFormat
+	}
+	encoder, found := getEncoder(format)
+	if !found {
+		return nil, "", fmt.Errorf("%w: no encoder for %s", ErrUnsupportedFormat, format)
+	}
+
+	srcImage, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("decode %s image: %v", sourceFormat, err)
+	}
+	if sourceFormat == "jpeg" {
+		// the exif orientation is lost when encoding again
+		srcImage = orientImage(data, srcImage)
+	}
+	dstImage, changed := t.apply(srcImage)
+	if !changed && format == sourceFormat && t.Quality == 0 && !t.Strip {
+		return data, encoder.contentType, nil
+	}
+
+	var buf bytes.Buffer
+	if err = encoder.encode(&buf, dstImage, t.Quality); err != nil {
+		return nil, "", fmt.Errorf("encode %s image: %v", format, err)
+	}
+	return buf.Bytes(), encoder.contentType, nil
+}
+
+// apply crops and resizes the image, and returns whether it changed
+func (t *Transform) apply(srcImage image.Image) (image.Image, bool) {
+	changed := false
+	bounds := srcImage.Bounds()
+	if !t.Crop.Empty() && t.Crop.Max.X <= bounds.Dx() && t.Crop.Max.Y <= bounds.Dy() {
+		srcImage = imaging.Crop(srcImage, t.Crop.Add(bounds.Min))
+		bounds = srcImage.Bounds()
+		changed = true
+	}
+
+	width, height := t.Width, t.Height
+	if t.DPR > 0 {
+		width = min(int(math.Round(float64(width)*t.DPR)), MaxTransformDimension)
+		height = min(int(math.Round(float64(height)*t.DPR)), MaxTransformDimension)
+	}
+	// only shrink
+	if !(bounds.Dx() > width && width != 0 || bounds.Dy() > height && height != 0) {
+		return srcImage, changed
+	}
+	// fit, fill and smart need both dimensions, or scale keeping the aspect ratio
+	fit := t.Fit
+	if width == 0 || height == 0 {
+		fit = ""
+	}
+
+	switch fit {
+	case FitContain:
+		return imaging.Fit(srcImage, width, height, imaging.Lanczos), true
+	case FitFill:
+		return imaging.Fill(srcImage, width, height, imaging.Center, imaging.Lanczos), true
+	case FitSmart:
+		cropped := imaging.Crop(srcImage, smartCrop(srcImage, width, height))
+		return imaging.Resize(cropped, width, height, imaging.Lanczos), true
+	}
+	if t.Width == t.Height && bounds.Dx() != bounds.Dy() {
+		return imaging.Thumbnail(srcImage, width, height, imaging.Lanczos), true
+	}
+	return imaging.Resize(srcImage, width, height, imaging.Lanczos), true
+}
+
+// smartCrop returns the rectangle with the aspect ratio of width and height, and the most edges,
+// which are likely where the subject of the image is.
+func smartCrop(img image.Image, width, height int) image.Rectangle {
+	bounds := img.Bounds()
+	cropW, cropH := bounds.Dx(), bounds.Dy()
+	if cropW*height > cropH*width {
+		cropW = max(1, cropH*width/height)
+	} else {
+		cropH = max(1, cropW*height/width)
+	}
+	if cropW == bounds.Dx() && cropH == bounds.Dy() {
+		return bounds
+	}
+
+	// score a small copy of the image
+	const sampleSize = 64
+	scale := float64(sampleSize) / float64(max(bounds.Dx(), bounds.Dy()))
+	sampleW, sampleH := max(1, int(float64(bounds.Dx())*scale)), max(1, int(float64(bounds.Dy())*scale))
+	sample := imaging.Grayscale(imaging.Resize(img, sampleW, sampleH, imaging.Box))
+	edges := make([]float64, sampleW*sampleH)
+	gray := func(x, y int) float64 {
+		return float64(sample.Pix[y*sample.Stride+x*4])
+	}
+	for y := 1; y < sampleH-1; y++ {
+		for x := 1; x < sampleW-1; x++ {
+			edges[y*sampleW+x] = math.Abs(4*gray(x, y) - gray(x-1, y) - gray(x+1, y) - gray(x, y-1) - gray(x, y+1))
+		}
+	}
+
+	// slide the window of the crop over the sample
+	windowW, windowH := max(1, int(float64(cropW)*scale)), max(1, int(float64(cropH)*scale))
+	bestX, bestY, bestScore := 0, 0, -1.0
+	for y := 0; y+windowH <= sampleH; y++ {
+		for x := 0; x+windowW <= sampleW; x++ {
+			score := 0.0
+			for wy := y; wy < y+windowH; wy++ {
+				for wx := x; wx < x+windowW; wx++ {
+					score += edges[wy*sampleW+wx]
+				}
+			}
+			if score > bestScore {
+				bestX, bestY, bestScore = x, y, score
+			}
+		}
+	}
+
+	minX := min(bounds.Min.X+int(float64(bestX)/scale), bounds.Max.X-cropW)
+	minY := min(bounds.Min.Y+int(float64(bestY)/scale), bounds.Max.Y-cropH)
+	return image.Rect(minX, minY, minX+cropW, minY+cropH)
+}
@@ -0,0 +1,137 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/url"
+	"testing"
+)
+
+func TestParseTransform(t *testing.T) {
+	transform, err := ParseTransform(url.Values{"width": {"100"}, "mode": {"fill"}, "dpr": {"2"}, "format": {"JPG"}, "quality": {"80"}})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if transform.Width != 100 || transform.Fit != FitFill || transform.DPR != 2 || transform.Format != "jpeg" || transform.Quality != 80 {
+		t.Errorf("parsed %+v", transform)
+	}
+	if key := transform.Key(); key != "dpr=2&fit=fill&format=jpeg&quality=80&width=100" {
+		t.Errorf("key %q", key)
+	}
+
+	// the key is canonical
+	again, _ := ParseTransform(transform.Values())
+	if again.Key() != transform.Key() {
+		t.Errorf("key %q after parsing %q", again.Key(), transform.Key())
+	}
+
+	if transform, err = ParseTransform(url.Values{"collection": {"pictures"}}); transform != nil || err != nil {
+		t.Errorf("expected no transformation, got %+v %v", transform, err)
+	}
+	for _, query := range []url.Values{
+		{"width": {"100000"}},
+		{"fit": {"stretch"}},
+		{"dpr": {"10"}},
+		{"format": {"bmp"}},
+		{"quality": {"101"}},
+	} {
+		if _, err = ParseTransform(query); err == nil {
+			t.Errorf("expected an error for %v", query)
+		}
+	}
+}
+
+func TestTransformSignature(t *testing.T) {
+	transform, _ := ParseTransform(url.Values{"width": {"100"}})
+	key := []byte("secret")
+	signature := transform.Sign(key, "/3,01637037d6")
+	if err := transform.VerifySignature(key, "/3,01637037d6", signature); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+	if err := transform.VerifySignature(key, "/3,01637037d7", signature); err == nil {
+		t.Errorf("expected the signature of another path to fail")
+	}
+	if err := transform.VerifySignature(key, "/3,01637037d6", ""); err == nil {
+		t.Errorf("expected a missing signature to fail")
+	}
+	if err := transform.VerifySignature(nil, "/3,01637037d6", ""); err != nil {
+		t.Errorf("expected no signature without a key: %v", err)
+	}
+}
+
+func TestTransformNegotiate(t *testing.T) {
+	transform := &Transform{Width: 10, Format: FormatAuto}
+	if resolved := transform.Negotiate("image/webp,image/*"); resolved.Format != "" {
+		t.Errorf("expected the source format without a webp encoder, got %q", resolved.Format)
+	}
+	RegisterEncoder("webp", "image/webp", encodePng)
+	defer func() {
+		encodersLock.Lock()
+		delete(encoders, "webp")
+		encodersLock.Unlock()
+	}()
+	if resolved := transform.Negotiate("image/avif,image/webp,image/*"); resolved.Format != "webp" {
+		t.Errorf("expected webp, got %q", resolved.Format)
+	}
+}
+
+// testImage is gray with a detailed checkered square at the right
+func testImage(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{128, 128, 128, 255}
+			if x >= width-height && (x/4+y/4)%2 == 0 {
+				c = color.NRGBA{255, 255, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestTransformed(t *testing.T) {
+	data := testImage(400, 200)
+
+	transformed, contentType, err := Transformed(data, &Transform{Width: 100, Format: "jpeg", Quality: 70})
+	if err != nil {
+		t.Fatalf("transform: %v", err)
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(transformed))
+	if err != nil || format != "jpeg" || contentType != "image/jpeg" || config.Width != 100 || config.Height != 50 {
+		t.Errorf("got %s %s %dx%d: %v", format, contentType, config.Width, config.Height, err)
+	}
+
+	// dpr multiplies the size
+	transformed, _, _ = Transformed(data, &Transform{Width: 100, Height: 100, Fit: FitFill, DPR: 1.5})
+	if config, _, _ = image.DecodeConfig(bytes.NewReader(transformed)); config.Width != 150 || config.Height != 150 {
+		t.Errorf("fill with dpr got %dx%d", config.Width, config.Height)
+	}
+
+	// a larger size keeps the image
+	transformed, _, _ = Transformed(data, &Transform{Width: 1000})
+	if !bytes.Equal(transformed, data) {
+		t.Errorf("expected the image unchanged")
+	}
+
+	if _, _, err = Transformed(data, &Transform{Format: "avif"}); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("expected an unsupported format, got %v", err)
+	}
+}
+
+func TestSmartCrop(t *testing.T) {
+	srcImage, _, _ := image.Decode(bytes.NewReader(testImage(400, 200)))
+	rect := smartCrop(srcImage, 100, 100)
+	if rect.Dx() != 200 || rect.Dy() != 200 {
+		t.Fatalf("crop %v, expected 200x200", rect)
+	}
+	// the checkered square is at the right
+	if rect.Min.X < 150 {
+		t.Errorf("crop %v, expected the detailed right part", rect)
+	}
+}
@@ -67,6 +67,9 @@ type Option struct {
 	// This protects chunks from being purged by volume.fsck for long-running writes
 	MetadataFlushSeconds int
 
+	// consistency mode of the whole mount, can be changed per directory by mount.configure
+	ConsistencyMode ConsistencyMode
+
 	// RDMA acceleration options
 	RdmaEnabled       bool
 	RdmaSidecarAddr   string
@@ -102,6 +105,7 @@ type WFS struct {
 	rdmaClient           *RDMAMountClient
 	FilerConf            *filer.FilerConf
 	filerClient          *wdclient.FilerClient // Cached volume location client
+	consistency          *consistencyRules
 }
 
 func NewSeaweedFileSystem(option *Option) *WFS {
@@ -136,6 +140,7 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 		dhMap:         NewDirectoryHandleToInode(),
 		filerClient:   filerClient, // nil for proxy mode, initialized for direct access
 		fhLockTable:   util.NewLockTable[FileHandleId](),
+		consistency:   newConsistencyRules(util.FullPath(option.FilerMountRootPath), option.ConsistencyMode),
 	}
 
 	wfs.option.filerIndex = int32(rand.IntN(len(option.FilerAddresses)))
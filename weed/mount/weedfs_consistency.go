@@ -0,0 +1,238 @@
+package mount
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/seaweedfs/go-fuse/v2/fuse"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// ConsistencyMode controls how much the mount trusts its metadata cache,
+// which is otherwise only updated asynchronously by the filer metadata subscription.
+type ConsistencyMode int
+
+const (
+	// ConsistencyEventual trusts the metadata cache
+	ConsistencyEventual ConsistencyMode = iota
+	// ConsistencyCloseToOpen revalidates the entry with the filer on open(),
+	// so a file closed by one client is seen completely by the next open on another client.
+	ConsistencyCloseToOpen
+	// ConsistencyStrict also revalidates on every lookup,
+	// and asks the kernel not to cache the lookup results.
+	ConsistencyStrict
+)
+
+const consistencyInherit = "inherit"
+
+func ParseConsistencyMode(mode string) (ConsistencyMode, error) {
+	switch mode {
+	case "", "eventual":
+		return ConsistencyEventual, nil
+	case "cto", "closeToOpen":
+		return ConsistencyCloseToOpen, nil
+	case "strict":
+		return ConsistencyStrict, nil
+	}
+	return ConsistencyEventual, fmt.Errorf("unknown consistency mode %q, expecting eventual, cto or strict", mode)
+}
+
+func (mode ConsistencyMode) String() string {
+	switch mode {
+	case ConsistencyCloseToOpen:
+		return "cto"
+	case ConsistencyStrict:
+		return "strict"
+	}
+	return "eventual"
+}
+
+// consistencyRules maps directories to consistency modes.
+// A path uses the mode of its nearest configured ancestor directory.
+type consistencyRules struct {
+	sync.RWMutex
+	rules map[util.FullPath]ConsistencyMode
+}
+
+func newConsistencyRules(root util.FullPath, mode ConsistencyMode) *consistencyRules {
+	r := &consistencyRules{
+		rules: make(map[util.FullPath]ConsistencyMode),
+	}
+	if mode != ConsistencyEventual {
+		r.rules[root] = mode
+	}
+	return r
+}
+
+func (r *consistencyRules) set(dir util.FullPath, mode ConsistencyMode) {
+	r.Lock()
+	defer r.Unlock()
+	r.rules[dir] = mode
+}
+
+func (r *consistencyRules) remove(dir util.FullPath) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.rules, dir)
+}
+
+func (r *consistencyRules) match(fullpath util.FullPath) ConsistencyMode {
+	r.RLock()
+	defer r.RUnlock()
+	if len(r.rules) == 0 {
+		return ConsistencyEventual
+	}
+	for p := fullpath; ; {
+		if mode, found := r.rules[p]; found {
+			return mode
+		}
+		if p == "/" || p == "" {
+			return ConsistencyEventual
+		}
+		dir, _ := p.DirAndName()
+		p = util.FullPath(dir)
+	}
+}
+
+func (r *consistencyRules) list() (dirs []util.FullPath, modes []ConsistencyMode) {
+	r.RLock()
+	defer r.RUnlock()
+	for dir := range r.rules {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i] < dirs[j] })
+	for _, dir := range dirs {
+		modes = append(modes, r.rules[dir])
+	}
+	return
+}
+
+func (wfs *WFS) consistencyMode(fullpath util.FullPath) ConsistencyMode {
+	return wfs.consistency.match(fullpath)
+}
+
+// revalidateEntry fetches the entry from the filer and compares it with the cached one.
+// Changes are written back to the metadata cache, so later lookups see them
+// before the metadata subscription catches up.
+// If the filer can not be reached, the cached entry is used.
+func (wfs *WFS) revalidateEntry(fullpath util.FullPath, cached *filer_pb.Entry) (entry *filer_pb.Entry, changed bool, status fuse.Status) {
+	if string(fullpath) == wfs.option.FilerMountRootPath {
+		return cached, false, fuse.OK
+	}
+	dir, _ := fullpath.DirAndName()
+
+	entry, err := filer_pb.GetEntry(context.Background(), wfs, fullpath)
+	if err == filer_pb.ErrNotFound {
+		if cached != nil {
+			glog.V(3).Infof("revalidate %s: removed on filer", fullpath)
+			if deleteErr := wfs.metaCache.DeleteEntry(context.Background(), fullpath); deleteErr != nil {
+				glog.Warningf("revalidate %s: delete cached entry: %v", fullpath, deleteErr)
+			}
+		}
+		return nil, cached != nil, fuse.ENOENT
+	}
+	if err != nil {
+		glog.V(1).Infof("revalidate %s: %v", fullpath, err)
+		if cached == nil {
+			return nil, false, fuse.ENOENT
+		}
+		return cached, false, fuse.OK
+	}
+
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	if wfs.option.UidGidMapper != nil {
+		entry.Attributes.Uid, entry.Attributes.Gid = wfs.option.UidGidMapper.FilerToLocal(entry.Attributes.Uid, entry.Attributes.Gid)
+	}
+	if !isEntryChanged(cached, entry) {
+		return cached, false, fuse.OK
+	}
+
+	glog.V(3).Infof("revalidate %s: changed on filer", fullpath)
+	if wfs.metaCache.IsDirectoryCached(util.FullPath(dir)) {
+		// the meta cache keeps the filer side uid and gid
+		cacheEntry := filer.FromPbEntry(dir, entry)
+		if wfs.option.UidGidMapper != nil {
+			cacheEntry.Attr.Uid, cacheEntry.Attr.Gid = wfs.option.UidGidMapper.LocalToFiler(cacheEntry.Attr.Uid, cacheEntry.Attr.Gid)
+		}
+		if insertErr := wfs.metaCache.InsertEntry(context.Background(), cacheEntry); insertErr != nil {
+			glog.Warningf("revalidate %s: update cached entry: %v", fullpath, insertErr)
+		}
+	}
+	return entry, true, fuse.OK
+}
+
+// isEntryChanged is a cheap version check, comparing the metadata that changes with the content
+func isEntryChanged(cached, entry *filer_pb.Entry) bool {
+	if cached == nil || cached.Attributes == nil {
+		return true
+	}
+	if cached.IsDirectory != entry.IsDirectory ||
+		cached.Attributes.Mtime != entry.Attributes.Mtime ||
+		cached.Attributes.Inode != entry.Attributes.Inode ||
+		cached.Attributes.FileMode != entry.Attributes.FileMode ||
+		cached.Attributes.SymlinkTarget != entry.Attributes.SymlinkTarget ||
+		filer.FileSize(cached) != filer.FileSize(entry) ||
+		cached.HardLinkCounter != entry.HardLinkCounter ||
+		len(cached.GetChunks()) != len(entry.GetChunks()) ||
+		!bytes.Equal(cached.Content, entry.Content) {
+		return true
+	}
+	// same mtime and size could still be a different content written within the same second
+	for i, chunk := range entry.GetChunks() {
+		if chunk.GetFileIdString() != cached.GetChunks()[i].GetFileIdString() {
+			return true
+		}
+	}
+	return false
+}
+
+// revalidateLookup rechecks a lookup result with the filer.
+// A cache miss is also rechecked, since the file may have just been created by another client.
+func (wfs *WFS) revalidateLookup(fullpath util.FullPath, cached *filer.Entry, status fuse.Status) (*filer.Entry, fuse.Status) {
+	var cachedEntry *filer_pb.Entry
+	if status == fuse.OK {
+		cachedEntry = cached.ToProtoEntry()
+	} else if status != fuse.ENOENT {
+		return cached, status
+	}
+	entry, changed, status := wfs.revalidateEntry(fullpath, cachedEntry)
+	if status != fuse.OK {
+		return nil, status
+	}
+	if !changed {
+		return cached, fuse.OK
+	}
+	dir, _ := fullpath.DirAndName()
+	return filer.FromPbEntry(dir, entry), fuse.OK
+}
+
+// revalidateOpen gives close-to-open consistency: the data flushed when another client closed the file
+// is visible to the following open. An open file with local changes not yet flushed keeps its own view.
+func (wfs *WFS) revalidateOpen(fullpath util.FullPath, fh *FileHandle, entry *filer_pb.Entry) (*filer_pb.Entry, fuse.Status) {
+	if fh != nil && fh.dirtyMetadata {
+		return entry, fuse.OK
+	}
+	latest, changed, status := wfs.revalidateEntry(fullpath, entry)
+	if status != fuse.OK || !changed || fh == nil {
+		return latest, status
+	}
+
+	fhActiveLock := wfs.fhLockTable.AcquireLock("revalidateOpen", fh.fh, util.ExclusiveLock)
+	defer wfs.fhLockTable.ReleaseLock(fh.fh, fhActiveLock)
+	if fh.dirtyMetadata {
+		return entry, fuse.OK
+	}
+	// drop the pages read or written with the previous version
+	fh.dirtyPages.Destroy()
+	fh.dirtyPages = newPageWriter(fh, wfs.option.ChunkSizeLimit)
+	return latest, fuse.OK
+}
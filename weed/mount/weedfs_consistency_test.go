@@ -0,0 +1,107 @@
+package mount
+
+import (
+	"context"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mount_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestConsistencyRulesMatch(t *testing.T) {
+	rules := newConsistencyRules("/buckets", ConsistencyEventual)
+	if got := rules.match("/buckets/a/b"); got != ConsistencyEventual {
+		t.Errorf("default: got %v", got)
+	}
+
+	rules.set("/buckets/ci", ConsistencyCloseToOpen)
+	rules.set("/buckets/ci/queue", ConsistencyStrict)
+
+	tests := []struct {
+		path util.FullPath
+		want ConsistencyMode
+	}{
+		{"/buckets/ci", ConsistencyCloseToOpen},
+		{"/buckets/ci/out/file", ConsistencyCloseToOpen},
+		{"/buckets/ci/queue/job1", ConsistencyStrict},
+		{"/buckets/cizzz/file", ConsistencyEventual},
+		{"/buckets/other", ConsistencyEventual},
+	}
+	for _, tt := range tests {
+		if got := rules.match(tt.path); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	rules.remove("/buckets/ci/queue")
+	if got := rules.match("/buckets/ci/queue/job1"); got != ConsistencyCloseToOpen {
+		t.Errorf("after remove: got %v", got)
+	}
+
+	rules = newConsistencyRules("/", ConsistencyStrict)
+	if got := rules.match("/any/where"); got != ConsistencyStrict {
+		t.Errorf("mount wide: got %v", got)
+	}
+}
+
+func TestIsEntryChanged(t *testing.T) {
+	newEntry := func(mtime int64, fileIds ...string) *filer_pb.Entry {
+		entry := &filer_pb.Entry{
+			Name:       "f",
+			Attributes: &filer_pb.FuseAttributes{Mtime: mtime, FileMode: 0644},
+		}
+		for i, fileId := range fileIds {
+			entry.Chunks = append(entry.Chunks, &filer_pb.FileChunk{FileId: fileId, Offset: int64(i) * 10, Size: 10})
+		}
+		return entry
+	}
+
+	if !isEntryChanged(nil, newEntry(1)) {
+		t.Errorf("missing cached entry should be changed")
+	}
+	if isEntryChanged(newEntry(1, "1,01"), newEntry(1, "1,01")) {
+		t.Errorf("same entry should not be changed")
+	}
+	if !isEntryChanged(newEntry(1, "1,01"), newEntry(2, "1,01")) {
+		t.Errorf("mtime change should be detected")
+	}
+	if !isEntryChanged(newEntry(1, "1,01"), newEntry(1, "1,01", "1,02")) {
+		t.Errorf("appended chunk should be detected")
+	}
+	if !isEntryChanged(newEntry(1, "1,01"), newEntry(1, "2,03")) {
+		t.Errorf("rewrite within the same second should be detected")
+	}
+}
+
+func TestConfigureConsistency(t *testing.T) {
+	wfs := &WFS{
+		option: &Option{
+			MountDirectory:     "/mnt/weed",
+			FilerMountRootPath: "/buckets",
+		},
+		consistency: newConsistencyRules("/buckets", ConsistencyEventual),
+	}
+
+	if _, err := wfs.Configure(context.Background(), &mount_pb.ConfigureRequest{ConsistencyPath: "/mnt/weed/ci", ConsistencyMode: "cto"}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	resp, err := wfs.Configure(context.Background(), &mount_pb.ConfigureRequest{ConsistencyPath: "ci/queue", ConsistencyMode: "strict"})
+	if err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if len(resp.ConsistencyRules) != 2 || resp.ConsistencyRules[0].Path != "/buckets/ci" || resp.ConsistencyRules[1].Mode != "strict" {
+		t.Errorf("unexpected rules %+v", resp.ConsistencyRules)
+	}
+	if _, err := wfs.Configure(context.Background(), &mount_pb.ConfigureRequest{ConsistencyPath: "ci", ConsistencyMode: "bogus"}); err == nil {
+		t.Errorf("expected error for unknown mode")
+	}
+
+	resp, err = wfs.Configure(context.Background(), &mount_pb.ConfigureRequest{ConsistencyPath: "/mnt/weed/ci", ConsistencyMode: "inherit"})
+	if err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if len(resp.ConsistencyRules) != 1 || resp.ConsistencyRules[0].Path != "/buckets/ci/queue" {
+		t.Errorf("unexpected rules after inherit %+v", resp.ConsistencyRules)
+	}
+}
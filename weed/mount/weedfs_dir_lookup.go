@@ -27,6 +27,10 @@ func (wfs *WFS) Lookup(cancel <-chan struct{}, header *fuse.InHeader, name strin
 
 	// Use shared lookup logic that checks cache first, then filer if needed
 	localEntry, status := wfs.lookupEntry(fullFilePath)
+	isStrict := wfs.consistencyMode(fullFilePath) == ConsistencyStrict
+	if isStrict {
+		localEntry, status = wfs.revalidateLookup(fullFilePath, localEntry, status)
+	}
 	if status != fuse.OK {
 		return status
 	}
@@ -43,6 +47,10 @@ func (wfs *WFS) Lookup(cancel <-chan struct{}, header *fuse.InHeader, name strin
 	}
 
 	wfs.outputFilerEntry(out, inode, localEntry)
+	if isStrict {
+		// let the kernel come back for every path resolution
+		out.EntryValid = 0
+	}
 
 	return fuse.OK
 
@@ -9,7 +9,11 @@ import (
 func (wfs *WFS) AcquireHandle(inode uint64, flags, uid, gid uint32) (fileHandle *FileHandle, status fuse.Status) {
 	var entry *filer_pb.Entry
 	var path util.FullPath
-	path, _, entry, status = wfs.maybeReadEntry(inode)
+	var fh *FileHandle
+	path, fh, entry, status = wfs.maybeReadEntry(inode)
+	if status == fuse.OK && wfs.consistencyMode(path) != ConsistencyEventual {
+		entry, status = wfs.revalidateOpen(path, fh, entry)
+	}
 	if status == fuse.OK {
 		if wormEnforced, _ := wfs.wormEnforcedForEntry(path, entry); wormEnforced && flags&fuse.O_ANYWRITE != 0 {
 			return nil, fuse.EPERM
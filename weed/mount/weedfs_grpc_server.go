@@ -3,12 +3,17 @@ package mount
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mount_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
 func (wfs *WFS) Configure(ctx context.Context, request *mount_pb.ConfigureRequest) (*mount_pb.ConfigureResponse, error) {
+	if request.ConsistencyPath != "" {
+		return wfs.configureConsistency(request)
+	}
 	if wfs.option.Collection == "" {
 		return nil, fmt.Errorf("mount quota only works when mounted to a new folder with a collection")
 	}
@@ -16,3 +21,37 @@ func (wfs *WFS) Configure(ctx context.Context, request *mount_pb.ConfigureReques
 	wfs.option.Quota = request.GetCollectionCapacity()
 	return &mount_pb.ConfigureResponse{}, nil
 }
+
+func (wfs *WFS) configureConsistency(request *mount_pb.ConfigureRequest) (*mount_pb.ConfigureResponse, error) {
+	// accept either the local path under the mount directory, or the path relative to it
+	relativePath := request.ConsistencyPath
+	if relativePath == wfs.option.MountDirectory || strings.HasPrefix(relativePath, wfs.option.MountDirectory+"/") {
+		relativePath = relativePath[len(wfs.option.MountDirectory):]
+	}
+	dir := util.FullPath(wfs.option.FilerMountRootPath)
+	if relativePath = strings.Trim(relativePath, "/"); relativePath != "" {
+		dir = dir.Child(relativePath)
+	}
+
+	if request.ConsistencyMode == consistencyInherit {
+		glog.V(0).Infof("consistency of %s is inherited from its parent directory", dir)
+		wfs.consistency.remove(dir)
+	} else {
+		mode, err := ParseConsistencyMode(request.ConsistencyMode)
+		if err != nil {
+			return nil, err
+		}
+		glog.V(0).Infof("consistency of %s changed to %s", dir, mode)
+		wfs.consistency.set(dir, mode)
+	}
+
+	resp := &mount_pb.ConfigureResponse{}
+	dirs, modes := wfs.consistency.list()
+	for i, d := range dirs {
+		resp.ConsistencyRules = append(resp.ConsistencyRules, &mount_pb.ConsistencyRule{
+			Path: string(d),
+			Mode: modes[i].String(),
+		})
+	}
+	return resp, nil
+}
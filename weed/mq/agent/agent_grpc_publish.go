@@ -2,6 +2,7 @@ package agent
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_agent_pb"
 )
@@ -25,7 +26,7 @@ func (a *MessageQueueAgent) PublishRecord(stream mq_agent_pb.SeaweedMessagingAge
 	}()
 
 	if m.Value != nil {
-		if err := publisherEntry.entry.PublishRecord(m.Key, m.Value); err != nil {
+		if err := publisherEntry.entry.PublishRecordAt(m.Key, m.Value, time.Unix(0, m.DeliverAtNs)); err != nil {
 			return err
 		}
 	}
@@ -38,7 +39,7 @@ func (a *MessageQueueAgent) PublishRecord(stream mq_agent_pb.SeaweedMessagingAge
 		if m.Value == nil {
 			continue
 		}
-		if err := publisherEntry.entry.PublishRecord(m.Key, m.Value); err != nil {
+		if err := publisherEntry.entry.PublishRecordAt(m.Key, m.Value, time.Unix(0, m.DeliverAtNs)); err != nil {
 			return err
 		}
 	}
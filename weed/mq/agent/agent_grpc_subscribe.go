@@ -39,9 +39,10 @@ func (a *MessageQueueAgent) SubscribeRecord(stream mq_agent_pb.SeaweedMessagingA
 				return
 			}
 			if sendErr := stream.Send(&mq_agent_pb.SubscribeRecordResponse{
-				Key:   m.Data.Key,
-				Value: record,
-				TsNs:  m.Data.TsNs,
+				Key:             m.Data.Key,
+				Value:           record,
+				TsNs:            m.Data.TsNs,
+				DeliveryAttempt: m.Data.DeliveryAttempt,
 			}); sendErr != nil {
 				glog.V(0).Infof("send record: %v", sendErr)
 				if lastErr == nil {
@@ -69,8 +70,10 @@ func (a *MessageQueueAgent) SubscribeRecord(stream mq_agent_pb.SeaweedMessagingA
 		}
 		if m != nil {
 			subscriber.PartitionOffsetChan <- sub_client.KeyedTimestamp{
-				Key:  m.AckKey,
-				TsNs: m.AckSequence, // Note: AckSequence should be renamed to AckTsNs in agent protocol
+				Key:    m.AckKey,
+				TsNs:   m.AckSequence, // Note: AckSequence should be renamed to AckTsNs in agent protocol
+				IsNack: m.IsNack,
+				Error:  m.NackError,
 			}
 		}
 	}
@@ -84,6 +87,9 @@ func (a *MessageQueueAgent) handleInitSubscribeRecordRequest(ctx context.Context
 		GrpcDialOption:          grpc.WithTransportCredentials(insecure.NewCredentials()),
 		MaxPartitionCount:       req.MaxSubscribedPartitions,
 		SlidingWindowSize:       req.SlidingWindowSize,
+		DeadLetterPolicy:        req.DeadLetterPolicy,
+		// with bounded redelivery, the client acks or nacks each record
+		ManualAck: req.DeadLetterPolicy.GetMaxDeliveryAttempts() > 0,
 	}
 
 	contentConfig := &sub_client.ContentConfiguration{
@@ -0,0 +1,596 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/pub_balancer"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+	"google.golang.org/protobuf/proto"
+)
+
+// Delayed delivery
+//  1. A published message with deliver_at_ns in the future is appended to the segment of its
+//     time bucket, and acknowledged once the segment is persisted on the filer.
+//     The messages scheduled together are appended as one chunk per segment.
+//  2. The leader of the partition loads the segments shortly before they are due,
+//     and publishes each message into the partition when it is due.
+//  3. A segment is deleted once all its messages are delivered or cancelled.
+//
+// If the broker stops between delivering a message and deleting its segment,
+// the message is delivered again. So the delivery is at least once.
+
+const (
+	delayedLoadAhead    = 2 * topic.DelayedBucketInterval
+	delayedListInterval = 10 * time.Second
+
+	// scheduled messages are persisted in batches, after the interval or once the batch is large
+	delayedFlushInterval = 200 * time.Millisecond
+	delayedFlushSize     = 4 * 1024 * 1024
+)
+
+// delayedDelivery holds the delayed messages of one partition range of a topic.
+type delayedDelivery struct {
+	b           *MessageQueueBroker
+	t           topic.Topic
+	rangeStart  int32
+	rangeStop   int32
+	dir         string
+	lock        sync.Mutex
+	queue       topic.DelayedQueue
+	remaining   map[string]int   // loaded segment => messages not delivered yet
+	loadedUntil time.Time        // all segments starting before this are loaded
+	cancelled   map[string]int64 // key => cancellation time, applied to messages published before it
+	segments    []string         // segments on the filer, as of the last listing
+	wakeCh      chan struct{}
+	// messages scheduled but not persisted yet
+	pendingLock  sync.Mutex
+	pending      *delayedBatch
+	appendToFile func(targetFile string, data []byte) error
+	// cancellations are read from the filer once
+	isCancellationLoaded bool
+	isStopped            bool
+}
+
+func (b *MessageQueueBroker) getOrStartDelayedDelivery(t topic.Topic, rangeStart, rangeStop int32) *delayedDelivery {
+	dir := topic.DelayedPartitionDir(t, topic.Partition{RangeStart: rangeStart, RangeStop: rangeStop})
+
+	b.delayedDeliveriesLock.Lock()
+	defer b.delayedDeliveriesLock.Unlock()
+	if d, found := b.delayedDeliveries[dir]; found {
+		return d
+	}
+	d := &delayedDelivery{
+		b:          b,
+		t:          t,
+		rangeStart: rangeStart,
+		rangeStop:  rangeStop,
+		dir:        dir,
+		remaining:  make(map[string]int),
+		cancelled:  make(map[string]int64),
+		wakeCh:     make(chan struct{}, 1),
+	}
+	d.appendToFile = b.appendToFile
+	b.delayedDeliveries[dir] = d
+	go d.loop()
+	glog.V(0).Infof("start delayed delivery of %v partition %04d-%04d", t, rangeStart, rangeStop)
+	return d
+}
+
+// stopUnlocked stops accepting messages, so that later messages start a new delivery.
+func (d *delayedDelivery) stopUnlocked() {
+	d.isStopped = true
+	d.b.removeDelayedDelivery(d)
+}
+
+func (b *MessageQueueBroker) removeDelayedDelivery(d *delayedDelivery) {
+	b.delayedDeliveriesLock.Lock()
+	defer b.delayedDeliveriesLock.Unlock()
+	if b.delayedDeliveries[d.dir] == d {
+		delete(b.delayedDeliveries, d.dir)
+	}
+	partition := fmt.Sprintf("%04d-%04d", d.rangeStart, d.rangeStop)
+	stats.MqDelayedMessagesGauge.DeleteLabelValues(d.t.String(), partition)
+	stats.MqDelayedSegmentsGauge.DeleteLabelValues(d.t.String(), partition)
+}
+
+// delayedBatch collects the messages scheduled within one flush interval
+type delayedBatch struct {
+	data     map[string][]byte // segment => encoded messages
+	messages []*mq_pb.DataMessage
+	size     int
+	isDone   bool
+	done     chan struct{}
+	errs     map[string]error // segment => persisting error
+}
+
+// schedule persists the message into its segment, to be delivered at its deliver_at_ns.
+// It returns after the batch with the message is persisted.
+func (d *delayedDelivery) schedule(message *mq_pb.DataMessage) error {
+	// the broker time decides whether a later cancellation applies to this message
+	message = proto.Clone(message).(*mq_pb.DataMessage)
+	message.TsNs = time.Now().UnixNano()
+	data, err := topic.EncodeDelayedMessage(message)
+	if err != nil {
+		return fmt.Errorf("encode delayed message: %w", err)
+	}
+	segment := topic.DelayedBucketName(message.DeliverAtNs)
+
+	d.pendingLock.Lock()
+	batch := d.pending
+	if batch == nil {
+		batch = &delayedBatch{
+			data: make(map[string][]byte),
+			done: make(chan struct{}),
+			errs: make(map[string]error),
+		}
+		d.pending = batch
+		time.AfterFunc(delayedFlushInterval, func() {
+			d.flush(batch)
+		})
+	}
+	batch.data[segment] = append(batch.data[segment], data...)
+	batch.messages = append(batch.messages, message)
+	batch.size += len(data)
+	isFull := batch.size >= delayedFlushSize
+	d.pendingLock.Unlock()
+
+	if isFull {
+		d.flush(batch)
+	}
+	<-batch.done
+	if err = batch.errs[segment]; err != nil {
+		return fmt.Errorf("append delayed message to %s/%s: %w", d.dir, segment, err)
+	}
+	return nil
+}
+
+// flush appends the batch to its segments, one chunk per segment.
+func (d *delayedDelivery) flush(batch *delayedBatch) {
+	d.pendingLock.Lock()
+	if d.pending == batch {
+		d.pending = nil
+	}
+	d.pendingLock.Unlock()
+
+	// appending under the lock keeps the segments and the loaded messages consistent
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if batch.isDone {
+		return
+	}
+	batch.isDone = true
+	defer close(batch.done)
+
+	for segment, data := range batch.data {
+		if err := d.appendToFile(d.dir+"/"+segment, data); err != nil {
+			batch.errs[segment] = err
+		}
+	}
+	if d.isStopped {
+		// the delivery just found no messages left, let a new one load these messages
+		d.b.getOrStartDelayedDelivery(d.t, d.rangeStart, d.rangeStop)
+		return
+	}
+	for _, message := range batch.messages {
+		segment := topic.DelayedBucketName(message.DeliverAtNs)
+		if batch.errs[segment] != nil {
+			continue
+		}
+		if time.Unix(0, message.DeliverAtNs).Before(d.loadedUntil) {
+			d.queue.Push(message)
+			d.remaining[segment]++
+			d.wakeUp()
+		}
+		stats.MqDelayedMessagesCounter.WithLabelValues(d.t.String(), "scheduled").Inc()
+	}
+	d.updateGaugesUnlocked()
+}
+
+// cancel drops the undelivered messages of the key, and returns how many were dropped.
+func (d *delayedDelivery) cancel(key []byte) (count int64, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err = d.loadCancellationsUnlocked(); err != nil {
+		return 0, err
+	}
+	nowNs := time.Now().UnixNano()
+	data, err := topic.EncodeDelayedMessage(&mq_pb.DataMessage{Key: key, TsNs: nowNs})
+	if err != nil {
+		return 0, err
+	}
+	if err = d.appendToFile(d.dir+"/"+topic.DelayedCancelFile, data); err != nil {
+		return 0, fmt.Errorf("append cancellation to %s: %w", d.dir, err)
+	}
+	previouslyCancelledTsNs := d.cancelled[string(key)]
+	d.cancelled[string(key)] = nowNs
+
+	for _, message := range d.queue.Cancel(key) {
+		d.remaining[topic.DelayedBucketName(message.DeliverAtNs)]--
+		count++
+	}
+	// the segments not loaded yet skip the key when they are loaded
+	segments, _, err := d.listSegments()
+	if err != nil {
+		glog.Warningf("list delayed segments of %s: %v", d.dir, err)
+	}
+	for _, segment := range segments {
+		bucketStart, _ := topic.ParseDelayedBucketName(segment)
+		if bucketStart.Before(d.loadedUntil) {
+			continue
+		}
+		if err = d.eachSegmentMessage(segment, func(message *mq_pb.DataMessage) {
+			if string(message.Key) == string(key) && previouslyCancelledTsNs < message.TsNs && message.TsNs <= nowNs {
+				count++
+			}
+		}); err != nil {
+			glog.Warningf("count cancelled messages in %s/%s: %v", d.dir, segment, err)
+		}
+	}
+
+	stats.MqDelayedMessagesCounter.WithLabelValues(d.t.String(), "cancelled").Add(float64(count))
+	d.updateGaugesUnlocked()
+	return count, nil
+}
+
+func (d *delayedDelivery) wakeUp() {
+	select {
+	case d.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+func (d *delayedDelivery) loop() {
+	var lastListTime time.Time
+	for {
+		if time.Since(lastListTime) >= delayedListInterval {
+			lastListTime = time.Now()
+			isLeader, err := d.isLeader()
+			if err != nil {
+				glog.V(0).Infof("delayed delivery of %s: %v", d.dir, err)
+			} else if !isLeader {
+				glog.V(0).Infof("stop delayed delivery of %s, not the partition leader", d.dir)
+				d.lock.Lock()
+				d.stopUnlocked()
+				d.lock.Unlock()
+				return
+			} else if isEmpty, err := d.loadSegments(); err != nil {
+				glog.Errorf("load delayed segments of %s: %v", d.dir, err)
+			} else if isEmpty {
+				glog.V(0).Infof("stop delayed delivery of %s, no delayed messages", d.dir)
+				return
+			}
+		}
+
+		if err := d.release(); err != nil {
+			glog.Errorf("release delayed messages of %s: %v", d.dir, err)
+		}
+
+		wait := delayedListInterval - time.Since(lastListTime)
+		d.lock.Lock()
+		if next := d.queue.NextDeliverAtNs(); next > 0 {
+			wait = min(wait, time.Until(time.Unix(0, next)))
+		}
+		d.lock.Unlock()
+		if wait <= 0 {
+			wait = time.Millisecond
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-d.wakeCh:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// isLeader checks whether this broker leads the partition holding the start of the range.
+func (d *delayedDelivery) isLeader() (bool, error) {
+	assignment, err := d.b.findDelayedPartitionAssignment(d.t, d.rangeStart)
+	if err != nil {
+		return false, err
+	}
+	return assignment.LeaderBroker == d.b.option.BrokerAddress().String(), nil
+}
+
+// loadSegments lists the segments, loads the ones due soon, and deletes the delivered ones.
+// It returns true if there are no delayed messages left.
+func (d *delayedDelivery) loadSegments() (isEmpty bool, err error) {
+	segments, hasCancelFile, err := d.listSegments()
+	if err != nil {
+		return false, err
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err = d.loadCancellationsUnlocked(); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	loadUntil := now.Add(delayedLoadAhead).Truncate(topic.DelayedBucketInterval)
+	for _, segment := range segments {
+		bucketStart, _ := topic.ParseDelayedBucketName(segment)
+		if bucketStart.Before(d.loadedUntil) || !bucketStart.Before(loadUntil) {
+			continue
+		}
+		count := 0
+		if err = d.eachSegmentMessage(segment, func(message *mq_pb.DataMessage) {
+			if cancelledTsNs, found := d.cancelled[string(message.Key)]; found && message.TsNs <= cancelledTsNs {
+				return
+			}
+			d.queue.Push(message)
+			count++
+		}); err != nil {
+			// the messages read so far stay in the queue, and are delivered at least once
+			return false, fmt.Errorf("load segment %s: %w", segment, err)
+		}
+		d.remaining[segment] += count
+	}
+	if loadUntil.After(d.loadedUntil) {
+		d.loadedUntil = loadUntil
+	}
+
+	// delete the segments with all messages delivered or cancelled
+	var kept []string
+	for _, segment := range segments {
+		bucketStart, _ := topic.ParseDelayedBucketName(segment)
+		remaining, isLoaded := d.remaining[segment]
+		if isLoaded && remaining <= 0 && !bucketStart.Add(topic.DelayedBucketInterval).After(now) {
+			if err := filer_pb.Remove(context.Background(), d.b, d.dir, segment, true, false, false, false, nil); err != nil {
+				glog.Warningf("delete delayed segment %s/%s: %v", d.dir, segment, err)
+				kept = append(kept, segment)
+				continue
+			}
+			delete(d.remaining, segment)
+			continue
+		}
+		kept = append(kept, segment)
+	}
+	d.segments = kept
+
+	if len(kept) == 0 && d.queue.Len() == 0 {
+		if hasCancelFile {
+			if err := filer_pb.Remove(context.Background(), d.b, d.dir, topic.DelayedCancelFile, true, false, false, false, nil); err != nil {
+				glog.Warningf("delete delayed cancellations %s: %v", d.dir, err)
+			}
+		}
+		d.stopUnlocked()
+		return true, nil
+	}
+	d.updateGaugesUnlocked()
+	return false, nil
+}
+
+func (d *delayedDelivery) listSegments() (segments []string, hasCancelFile bool, err error) {
+	err = filer_pb.ReadDirAllEntries(context.Background(), d.b, util.FullPath(d.dir), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if entry.IsDirectory {
+			return nil
+		}
+		if entry.Name == topic.DelayedCancelFile {
+			hasCancelFile = true
+			return nil
+		}
+		if _, parseErr := topic.ParseDelayedBucketName(entry.Name); parseErr == nil {
+			segments = append(segments, entry.Name)
+		}
+		return nil
+	})
+	if err == filer_pb.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+// release publishes the due messages into the partition.
+func (d *delayedDelivery) release() error {
+	d.lock.Lock()
+	due := d.queue.PopDue(time.Now().UnixNano())
+	d.lock.Unlock()
+	if len(due) == 0 {
+		return nil
+	}
+
+	published := 0
+	defer func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		for i, message := range due {
+			if i < published {
+				d.remaining[topic.DelayedBucketName(message.DeliverAtNs)]--
+			} else {
+				// try again later
+				d.queue.Push(message)
+			}
+		}
+		d.updateGaugesUnlocked()
+	}()
+
+	assignment, err := d.b.findDelayedPartitionAssignment(d.t, d.rangeStart)
+	if err != nil {
+		return err
+	}
+	if assignment.LeaderBroker != d.b.option.BrokerAddress().String() {
+		return fmt.Errorf("partition moved to %s", assignment.LeaderBroker)
+	}
+	p := topic.FromPbPartition(assignment.Partition)
+	localPartition, err := d.b.GetOrGenerateLocalPartition(d.t, p)
+	if err != nil {
+		return fmt.Errorf("get local partition %v: %w", p, err)
+	}
+	assignOffsetFn := func() (int64, error) {
+		return d.b.offsetManager.AssignOffset(d.t, p)
+	}
+
+	for _, message := range due {
+		released := proto.Clone(message).(*mq_pb.DataMessage)
+		released.TsNs = time.Now().UnixNano()
+		if _, err = localPartition.PublishWithOffset(released, assignOffsetFn); err != nil {
+			return fmt.Errorf("publish delayed message: %w", err)
+		}
+		published++
+		stats.MqDelayedDeliveryLagHistogram.WithLabelValues(d.t.String()).Observe(float64(released.TsNs-message.DeliverAtNs) / 1e9)
+		stats.MqDelayedMessagesCounter.WithLabelValues(d.t.String(), "delivered").Inc()
+	}
+	return nil
+}
+
+// loadCancellationsUnlocked reads the cancelled keys once.
+func (d *delayedDelivery) loadCancellationsUnlocked() error {
+	if d.isCancellationLoaded {
+		return nil
+	}
+	err := d.eachSegmentMessage(topic.DelayedCancelFile, func(message *mq_pb.DataMessage) {
+		if message.TsNs > d.cancelled[string(message.Key)] {
+			d.cancelled[string(message.Key)] = message.TsNs
+		}
+	})
+	if err != nil && err != filer_pb.ErrNotFound {
+		return fmt.Errorf("read cancellations: %w", err)
+	}
+	d.isCancellationLoaded = true
+	return nil
+}
+
+// eachSegmentMessage reads a segment file. Each appended chunk holds whole messages.
+func (d *delayedDelivery) eachSegmentMessage(name string, fn func(message *mq_pb.DataMessage)) error {
+	entry, err := filer_pb.GetEntry(context.Background(), d.b, util.NewFullPath(d.dir, name))
+	if err != nil {
+		return err
+	}
+	lookupFileIdFn := filer.LookupFn(d.b)
+	for _, chunk := range entry.GetChunks() {
+		urlStrings, err := lookupFileIdFn(context.Background(), chunk.FileId)
+		if err != nil {
+			return fmt.Errorf("lookup %s: %w", chunk.FileId, err)
+		}
+		var data []byte
+		for _, urlString := range urlStrings {
+			if data, _, err = util_http.Get(urlString); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", chunk.FileId, err)
+		}
+		if err = topic.EachDelayedMessage(data, func(message *mq_pb.DataMessage) error {
+			fn(message)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if len(entry.Content) > 0 {
+		return topic.EachDelayedMessage(entry.Content, func(message *mq_pb.DataMessage) error {
+			fn(message)
+			return nil
+		})
+	}
+	return nil
+}
+
+func (d *delayedDelivery) updateGaugesUnlocked() {
+	partition := fmt.Sprintf("%04d-%04d", d.rangeStart, d.rangeStop)
+	stats.MqDelayedMessagesGauge.WithLabelValues(d.t.String(), partition).Set(float64(d.queue.Len()))
+	stats.MqDelayedSegmentsGauge.WithLabelValues(d.t.String(), partition).Set(float64(len(d.segments)))
+}
+
+// findDelayedPartitionAssignment looks up the current partition holding the slot of the ring.
+func (b *MessageQueueBroker) findDelayedPartitionAssignment(t topic.Topic, slot int32) (*mq_pb.BrokerPartitionAssignment, error) {
+	lookupReq := &mq_pb.LookupTopicBrokersRequest{
+		Topic: t.ToPbTopic(),
+	}
+	var lookupResp *mq_pb.LookupTopicBrokersResponse
+	var err error
+	if b.isLockOwner() {
+		lookupResp, err = b.LookupTopicBrokers(context.Background(), lookupReq)
+	} else if b.lockAsBalancer != nil {
+		err = b.withBrokerClient(false, pb.ServerAddress(b.lockAsBalancer.LockOwner()), func(client mq_pb.SeaweedMessagingClient) error {
+			lookupResp, err = client.LookupTopicBrokers(context.Background(), lookupReq)
+			return err
+		})
+	} else {
+		err = fmt.Errorf("no balancer found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup topic %v: %w", t, err)
+	}
+	for _, assignment := range lookupResp.BrokerPartitionAssignments {
+		if assignment.Partition.RangeStart <= slot && slot < assignment.Partition.RangeStop {
+			return assignment, nil
+		}
+	}
+	return nil, fmt.Errorf("no partition of topic %v holds slot %d", t, slot)
+}
+
+// keySlot is the slot of the partition ring that publishers send the key to.
+func keySlot(key []byte) int32 {
+	slot := util.HashToInt32(key) % pub_balancer.MaxPartitionCount
+	if slot < 0 {
+		slot = -slot
+	}
+	return slot
+}
+
+// loopDelayedDeliveries resumes the delayed deliveries of the partitions led by this broker,
+// e.g. after a restart or after partitions moved to this broker.
+func (b *MessageQueueBroker) loopDelayedDeliveries(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		if b.currentFiler == "" {
+			continue
+		}
+		b.resumeDelayedDeliveries()
+	}
+}
+
+func (b *MessageQueueBroker) resumeDelayedDeliveries() {
+	err := filer_pb.ReadDirAllEntries(context.Background(), b, util.FullPath(filer.TopicsDir), "", func(namespaceEntry *filer_pb.Entry, isLast bool) error {
+		if !namespaceEntry.IsDirectory {
+			return nil
+		}
+		return filer_pb.ReadDirAllEntries(context.Background(), b, util.FullPath(filer.TopicsDir).Child(namespaceEntry.Name), "", func(topicEntry *filer_pb.Entry, isLast bool) error {
+			if !topicEntry.IsDirectory {
+				return nil
+			}
+			t := topic.NewTopic(namespaceEntry.Name, topicEntry.Name)
+			delayedDir := util.FullPath(t.Dir()).Child(topic.DelayedDirName)
+			listErr := filer_pb.ReadDirAllEntries(context.Background(), b, delayedDir, "", func(rangeEntry *filer_pb.Entry, isLast bool) error {
+				if !rangeEntry.IsDirectory || !topic.IsValidPartitionDirectory(rangeEntry.Name) {
+					return nil
+				}
+				rangeStart, rangeStop := topic.ParsePartitionBoundary(rangeEntry.Name)
+				b.delayedDeliveriesLock.Lock()
+				_, found := b.delayedDeliveries[string(delayedDir.Child(rangeEntry.Name))]
+				b.delayedDeliveriesLock.Unlock()
+				if found {
+					return nil
+				}
+				if assignment, err := b.findDelayedPartitionAssignment(t, rangeStart); err == nil && assignment.LeaderBroker == b.option.BrokerAddress().String() {
+					b.getOrStartDelayedDelivery(t, rangeStart, rangeStop)
+				}
+				return nil
+			})
+			if listErr != nil && listErr != filer_pb.ErrNotFound {
+				glog.V(1).Infof("list delayed messages of %v: %v", t, listErr)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		glog.V(0).Infof("list topics with delayed messages: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package broker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+func TestDelayedDeliveryBatchesScheduledMessages(t *testing.T) {
+	var lock sync.Mutex
+	appended := make(map[string][][]byte)
+	d := &delayedDelivery{
+		t:         topic.NewTopic("ns", "jobs"),
+		dir:       "/topics/ns/jobs/.delayed/0000-1024",
+		remaining: make(map[string]int),
+		cancelled: make(map[string]int64),
+		wakeCh:    make(chan struct{}, 1),
+	}
+	brokenSegment := topic.DelayedBucketName(time.Now().Add(3 * time.Hour).UnixNano())
+	d.appendToFile = func(targetFile string, data []byte) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if strings.HasSuffix(targetFile, "/"+brokenSegment) {
+			return fmt.Errorf("filer is down")
+		}
+		appended[targetFile] = append(appended[targetFile], data)
+		return nil
+	}
+
+	// the messages of a job scheduler, due in two buckets, and some in a bucket failing to persist
+	var wg sync.WaitGroup
+	errs := make([]error, 100)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deliverAt := time.Now().Add(time.Duration(1+i%2) * time.Hour)
+			if i%10 == 0 {
+				deliverAt = time.Now().Add(3 * time.Hour)
+			}
+			errs[i] = d.schedule(&mq_pb.DataMessage{Key: []byte(fmt.Sprintf("job-%d", i)), DeliverAtNs: deliverAt.UnixNano()})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if (err != nil) != (i%10 == 0) {
+			t.Errorf("message %d scheduled with error %v", i, err)
+		}
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	chunks, messages := 0, 0
+	for _, datas := range appended {
+		chunks += len(datas)
+		for _, data := range datas {
+			if err := topic.EachDelayedMessage(data, func(message *mq_pb.DataMessage) error {
+				messages++
+				return nil
+			}); err != nil {
+				t.Fatalf("decode chunk: %v", err)
+			}
+		}
+	}
+	if messages != 90 {
+		t.Errorf("persisted %d messages, expected 90", messages)
+	}
+	if chunks >= 20 {
+		t.Errorf("persisted %d chunks for %d messages, expected them batched", chunks, messages)
+	}
+}
@@ -30,6 +30,14 @@ func (b *MessageQueueBroker) ConfigureTopic(ctx context.Context, request *mq_pb.
 		return resp, err
 	}
 
+	if request.Retention != nil {
+		policy, err := topic.NormalizeCleanupPolicy(request.Retention.CleanupPolicy)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid retention: %v", err)
+		}
+		request.Retention.CleanupPolicy = policy
+	}
+
 	// Validate flat schema format
 	if request.MessageRecordType != nil && len(request.KeyColumns) > 0 {
 		if err := schema.ValidateKeyColumns(request.MessageRecordType, request.KeyColumns); err != nil {
@@ -63,24 +71,32 @@ func (b *MessageQueueBroker) ConfigureTopic(ctx context.Context, request *mq_pb.
 			schemaChanged = true
 		}
 
-		if !schemaChanged {
+		// A nil retention keeps the existing retention and cleanup policy
+		retentionChanged := request.Retention != nil && !proto.Equal(request.Retention, resp.Retention)
+
+		if !schemaChanged && !retentionChanged {
 			glog.V(0).Infof("existing topic partitions %d: %+v", len(resp.BrokerPartitionAssignments), resp.BrokerPartitionAssignments)
 			return resp, nil
 		}
 
-		// Update schema in existing configuration
-		resp.MessageRecordType = request.MessageRecordType
-		resp.KeyColumns = request.KeyColumns
-		resp.SchemaFormat = request.SchemaFormat
+		if schemaChanged {
+			// Update schema in existing configuration
+			resp.MessageRecordType = request.MessageRecordType
+			resp.KeyColumns = request.KeyColumns
+			resp.SchemaFormat = request.SchemaFormat
+		}
+		if retentionChanged {
+			resp.Retention = request.Retention
+		}
 
 		if err := b.fca.SaveTopicConfToFiler(t, resp); err != nil {
-			return nil, fmt.Errorf("update topic schemas: %w", err)
+			return nil, fmt.Errorf("update topic configuration: %w", err)
 		}
 
 		// Invalidate topic cache since we just updated the topic
 		b.invalidateTopicCache(t)
 
-		glog.V(0).Infof("updated schemas for topic %s", request.Topic)
+		glog.V(0).Infof("updated topic %s: schema changed %v, retention changed %v", request.Topic, schemaChanged, retentionChanged)
 		return resp, nil
 	}
 
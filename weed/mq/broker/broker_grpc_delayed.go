@@ -0,0 +1,55 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+// CancelDelayedMessage cancels the delayed messages of the key that are not delivered yet.
+// The request is forwarded to the leader of the partition of the key.
+func (b *MessageQueueBroker) CancelDelayedMessage(ctx context.Context, request *mq_pb.CancelDelayedMessageRequest) (*mq_pb.CancelDelayedMessageResponse, error) {
+	if request.Topic == nil || len(request.Key) == 0 {
+		return &mq_pb.CancelDelayedMessageResponse{
+			Error: "topic and key are required",
+		}, nil
+	}
+	t := topic.FromPbTopic(request.Topic)
+
+	assignment, err := b.findDelayedPartitionAssignment(t, keySlot(request.Key))
+	if err != nil {
+		return &mq_pb.CancelDelayedMessageResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	if assignment.LeaderBroker != b.option.BrokerAddress().String() {
+		var resp *mq_pb.CancelDelayedMessageResponse
+		err = b.withBrokerClient(false, pb.ServerAddress(assignment.LeaderBroker), func(client mq_pb.SeaweedMessagingClient) error {
+			resp, err = client.CancelDelayedMessage(ctx, request)
+			return err
+		})
+		if err != nil {
+			return &mq_pb.CancelDelayedMessageResponse{
+				Error: fmt.Sprintf("forward to %s: %v", assignment.LeaderBroker, err),
+			}, nil
+		}
+		return resp, nil
+	}
+
+	d := b.getOrStartDelayedDelivery(t, assignment.Partition.RangeStart, assignment.Partition.RangeStop)
+	count, err := d.cancel(request.Key)
+	if err != nil {
+		return &mq_pb.CancelDelayedMessageResponse{
+			Error: err.Error(),
+		}, nil
+	}
+	glog.V(1).Infof("cancelled %d delayed messages of key %s in %v", count, string(request.Key), t)
+	return &mq_pb.CancelDelayedMessageResponse{
+		CancelledCount: count,
+	}, nil
+}
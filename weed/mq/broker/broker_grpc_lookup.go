@@ -301,6 +301,7 @@ func (b *MessageQueueBroker) GetTopicConfiguration(ctx context.Context, request
 		PartitionCount:             int32(len(conf.BrokerPartitionAssignments)),
 		MessageRecordType:          conf.MessageRecordType,
 		KeyColumns:                 conf.KeyColumns,
+		SchemaFormat:               conf.SchemaFormat,
 		BrokerPartitionAssignments: conf.BrokerPartitionAssignments,
 		CreatedAtNs:                createdAtNs,
 		LastUpdatedNs:              modifiedAtNs,
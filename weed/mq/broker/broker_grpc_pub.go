@@ -182,6 +182,19 @@ func (b *MessageQueueBroker) PublishMessage(stream mq_pb.SeaweedMessaging_Publis
 		// Send to the local partition with offset assignment
 		t, p := topic.FromPbTopic(initMessage.Topic), topic.FromPbPartition(initMessage.Partition)
 
+		// Hold the messages to deliver later, without assigning offsets yet
+		if dataMessage.DeliverAtNs > time.Now().UnixNano() && dataMessage.Ctrl == nil {
+			if err := b.getOrStartDelayedDelivery(t, p.RangeStart, p.RangeStop).schedule(dataMessage); err != nil {
+				return fmt.Errorf("topic %v partition %v schedule delayed message: %w", initMessage.Topic, initMessage.Partition, err)
+			}
+			if err := stream.Send(&mq_pb.PublishMessageResponse{
+				AckTsNs: dataMessage.TsNs,
+			}); err != nil {
+				return fmt.Errorf("failed to send ack: %v", err)
+			}
+			continue
+		}
+
 		// Create offset assignment function for this partition
 		assignOffsetFn := func() (int64, error) {
 			return b.offsetManager.AssignOffset(t, p)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -58,8 +59,22 @@ func (b *MessageQueueBroker) SubscribeMessage(stream mq_pb.SeaweedMessaging_Subs
 	startPosition := b.getRequestPosition(req.GetInit())
 	imt := sub_coordinator.NewInflightMessageTracker(int(req.GetInit().SlidingWindowSize))
 
+	// data messages are sent by the subscribe loop and by redeliveries
+	var sendLock sync.Mutex
+	sendData := func(dataMsg *mq_pb.DataMessage) error {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		return stream.Send(&mq_pb.SubscribeMessageResponse{Message: &mq_pb.SubscribeMessageResponse_Data{
+			Data: dataMsg,
+		}})
+	}
+	redelivery := b.newSubscriptionRedelivery(req.GetInit(), t, partition, clientName, imt, sendData)
+
 	defer func() {
 		isConnected = false
+		if redelivery != nil {
+			redelivery.stop()
+		}
 		// Clean up any in-flight messages to prevent them from blocking other subscribers
 		if cleanedCount := imt.Cleanup(); cleanedCount > 0 {
 			glog.V(0).Infof("Subscriber %s cleaned up %d in-flight messages on disconnect", clientName, cleanedCount)
@@ -156,6 +171,16 @@ func (b *MessageQueueBroker) SubscribeMessage(stream mq_pb.SeaweedMessaging_Subs
 				// skip ack for control messages
 				continue
 			}
+			if ack.GetAck().IsNack {
+				if redelivery == nil {
+					glog.V(1).Infof("Subscriber %s nacked key %s without dead letter policy: %s", clientName, string(ack.GetAck().Key), ack.GetAck().Error)
+				} else if !redelivery.onFailure(ack.GetAck().Key, ack.GetAck().TsNs, ack.GetAck().Error) {
+					// to be redelivered
+					continue
+				}
+			} else if redelivery != nil {
+				redelivery.untrack(ack.GetAck().Key, ack.GetAck().TsNs)
+			}
 			imt.AcknowledgeMessage(ack.GetAck().Key, ack.GetAck().TsNs)
 
 			currentLastOffset := imt.GetOldestAckedTimestamp()
@@ -237,7 +262,15 @@ subscribeLoop:
 
 				for imt.IsInflight(logEntry.Key) {
 					// Check if we've exceeded the maximum wait time
-					if time.Since(startTime) > maxWaitTime {
+					if time.Since(startTime) > maxWaitTime && redelivery != nil {
+						// count the missing ack as a failed delivery
+						if inflightTsNs, found := imt.InflightTimestamp(logEntry.Key); found {
+							if redelivery.onFailure(logEntry.Key, inflightTsNs, fmt.Sprintf("not acknowledged within %v", maxWaitTime)) {
+								imt.AcknowledgeMessage(logEntry.Key, inflightTsNs)
+							}
+						}
+						startTime = time.Now()
+					} else if time.Since(startTime) > maxWaitTime {
 						glog.Warningf("Subscriber %s: message with key %s has been in-flight for more than %v, forcing acknowledgment",
 							clientName, string(logEntry.Key), maxWaitTime)
 						// Force remove the message from in-flight tracking to prevent infinite loop
@@ -267,14 +300,16 @@ subscribeLoop:
 
 				// Create the message to send
 				dataMsg := &mq_pb.DataMessage{
-					Key:   logEntry.Key,
-					Value: logEntry.Data,
-					TsNs:  logEntry.TsNs,
+					Key:             logEntry.Key,
+					Value:           logEntry.Data,
+					TsNs:            logEntry.TsNs,
+					DeliveryAttempt: 1,
+				}
+				if redelivery != nil && logEntry.Key != nil {
+					redelivery.track(dataMsg)
 				}
 
-				if err := stream.Send(&mq_pb.SubscribeMessageResponse{Message: &mq_pb.SubscribeMessageResponse_Data{
-					Data: dataMsg,
-				}}); err != nil {
+				if err := sendData(dataMsg); err != nil {
 					glog.Errorf("Error sending data: %v", err)
 					return false, err
 				}
@@ -0,0 +1,178 @@
+package broker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/pub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/sub_coordinator"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// subscriptionRedelivery redelivers the messages that failed processing on one subscription,
+// and publishes the messages failing too many times to the dead letter topic.
+type subscriptionRedelivery struct {
+	b             *MessageQueueBroker
+	policy        *sub_coordinator.RedeliveryPolicy
+	t             topic.Topic
+	partition     topic.Partition
+	consumerGroup string
+	clientName    string
+	imt           *sub_coordinator.InflightMessageTracker
+	sendFn        func(dataMsg *mq_pb.DataMessage) error
+	inflight      map[string]*mq_pb.DataMessage
+	pending       map[string]bool // keys waiting for the redelivery backoff
+	inflightLock  sync.Mutex
+	isStopped     bool
+}
+
+func (b *MessageQueueBroker) newSubscriptionRedelivery(init *mq_pb.SubscribeMessageRequest_InitMessage, t topic.Topic, partition topic.Partition, clientName string,
+	imt *sub_coordinator.InflightMessageTracker, sendFn func(dataMsg *mq_pb.DataMessage) error) *subscriptionRedelivery {
+	policy := sub_coordinator.NewRedeliveryPolicy(init.GetDeadLetterPolicy(), t, init.ConsumerGroup)
+	if policy == nil {
+		return nil
+	}
+	glog.V(0).Infof("Subscriber %s redelivers up to %d times, dead letter topic %v", clientName, policy.MaxDeliveryAttempts, policy.DeadLetterTopic)
+	return &subscriptionRedelivery{
+		b:             b,
+		policy:        policy,
+		t:             t,
+		partition:     partition,
+		consumerGroup: init.ConsumerGroup,
+		clientName:    clientName,
+		imt:           imt,
+		sendFn:        sendFn,
+		inflight:      make(map[string]*mq_pb.DataMessage),
+		pending:       make(map[string]bool),
+	}
+}
+
+// track keeps the delivered message, in case it needs to be redelivered.
+func (r *subscriptionRedelivery) track(dataMsg *mq_pb.DataMessage) {
+	r.inflightLock.Lock()
+	defer r.inflightLock.Unlock()
+	r.inflight[string(dataMsg.Key)] = dataMsg
+}
+
+func (r *subscriptionRedelivery) untrack(key []byte, tsNs int64) {
+	r.inflightLock.Lock()
+	defer r.inflightLock.Unlock()
+	if dataMsg, found := r.inflight[string(key)]; found && dataMsg.TsNs == tsNs {
+		delete(r.inflight, string(key))
+	}
+}
+
+func (r *subscriptionRedelivery) stop() {
+	r.inflightLock.Lock()
+	defer r.inflightLock.Unlock()
+	r.isStopped = true
+	r.inflight = make(map[string]*mq_pb.DataMessage)
+	r.pending = make(map[string]bool)
+}
+
+// onFailure handles a failed delivery of the inflight message of the key.
+// It returns true if the message was dead lettered and can be acknowledged,
+// or false if the message will be redelivered.
+func (r *subscriptionRedelivery) onFailure(key []byte, tsNs int64, failure string) (isDeadLettered bool) {
+	r.inflightLock.Lock()
+	dataMsg, found := r.inflight[string(key)]
+	isPending := r.pending[string(key)]
+	r.inflightLock.Unlock()
+	if !found || dataMsg.TsNs != tsNs || isPending {
+		// unknown, already acknowledged, or already waiting to be redelivered
+		return false
+	}
+
+	attempts := r.imt.DeliveryAttempts(dataMsg.Key, dataMsg.TsNs)
+	if attempts == 0 {
+		return false
+	}
+	if r.policy.ShouldDeadLetter(attempts) {
+		if err := r.deadLetter(dataMsg, attempts, failure); err != nil {
+			glog.Errorf("Subscriber %s dead letter key %s to %v: %v", r.clientName, string(key), r.policy.DeadLetterTopic, err)
+			// keep the message, and try again later
+			r.scheduleRedelivery(dataMsg, r.policy.MaxBackoff)
+			return false
+		}
+		r.untrack(dataMsg.Key, dataMsg.TsNs)
+		return true
+	}
+
+	r.scheduleRedelivery(dataMsg, r.policy.RedeliveryDelay(attempts))
+	return false
+}
+
+func (r *subscriptionRedelivery) scheduleRedelivery(dataMsg *mq_pb.DataMessage, delay time.Duration) {
+	r.inflightLock.Lock()
+	r.pending[string(dataMsg.Key)] = true
+	r.inflightLock.Unlock()
+	time.AfterFunc(delay, func() {
+		r.inflightLock.Lock()
+		isStopped := r.isStopped
+		delete(r.pending, string(dataMsg.Key))
+		r.inflightLock.Unlock()
+		if isStopped {
+			return
+		}
+		attempt := r.imt.RedeliverMessage(dataMsg.Key, dataMsg.TsNs)
+		if attempt == 0 {
+			// acknowledged in the meantime
+			return
+		}
+		redelivered := proto.Clone(dataMsg).(*mq_pb.DataMessage)
+		redelivered.DeliveryAttempt = attempt
+		glog.V(1).Infof("Subscriber %s redelivers key %s attempt %d", r.clientName, string(dataMsg.Key), attempt)
+		if err := r.sendFn(redelivered); err != nil {
+			glog.V(0).Infof("Subscriber %s redeliver key %s: %v", r.clientName, string(dataMsg.Key), err)
+		}
+	})
+}
+
+// deadLetter publishes the message with its failure to the dead letter topic,
+// and waits until the brokers have persisted it.
+func (r *subscriptionRedelivery) deadLetter(dataMsg *mq_pb.DataMessage, attempts int32, failure string) error {
+	deadLetter := &sub_coordinator.DeadLetter{
+		Topic:            r.t,
+		ConsumerGroup:    r.consumerGroup,
+		Partition:        r.partition,
+		Key:              dataMsg.Key,
+		Value:            dataMsg.Value,
+		TsNs:             dataMsg.TsNs,
+		DeliveryAttempts: attempts,
+		Error:            failure,
+		FailedAt:         time.Now(),
+	}
+
+	dlqTopic := r.policy.DeadLetterTopic
+	partitionCount := int32(1)
+	if conf, err := r.b.fca.ReadTopicConfFromFiler(dlqTopic); err == nil && len(conf.BrokerPartitionAssignments) > 0 {
+		partitionCount = int32(len(conf.BrokerPartitionAssignments))
+	}
+	publisher, err := pub_client.NewTopicPublisher(&pub_client.PublisherConfiguration{
+		Topic:          dlqTopic,
+		PartitionCount: partitionCount,
+		Brokers:        []string{r.b.option.BrokerAddress().String()},
+		PublisherName:  fmt.Sprintf("dlq.%s", r.clientName),
+		RecordType:     sub_coordinator.DeadLetterRecordType(),
+	})
+	if err != nil {
+		return fmt.Errorf("create publisher: %w", err)
+	}
+	if err = publisher.PublishRecord(dataMsg.Key, deadLetter.ToRecord()); err != nil {
+		publisher.Shutdown()
+		return fmt.Errorf("publish: %w", err)
+	}
+	if err = publisher.FinishPublish(); err != nil {
+		publisher.Shutdown()
+		return fmt.Errorf("finish publish: %w", err)
+	}
+	if err = publisher.Shutdown(); err != nil {
+		return err
+	}
+	glog.V(0).Infof("Subscriber %s dead lettered key %s after %d attempts to %v: %s", r.clientName, string(dataMsg.Key), attempts, dlqTopic, failure)
+	return nil
+}
@@ -0,0 +1,63 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/logstore"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// loopCompactTopicsByKey periodically compacts the topics with the "compact" cleanup policy.
+// Only the balancer broker compacts, so that each topic is compacted by one broker at a time.
+func (b *MessageQueueBroker) loopCompactTopicsByKey(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		if b.lockAsBalancer == nil || !b.isLockOwner() {
+			continue
+		}
+		b.compactTopicsByKey()
+	}
+}
+
+func (b *MessageQueueBroker) compactTopicsByKey() {
+	var topics []topic.Topic
+	err := filer_pb.ReadDirAllEntries(context.Background(), b, util.FullPath(filer.TopicsDir), "", func(namespaceEntry *filer_pb.Entry, isLast bool) error {
+		if !namespaceEntry.IsDirectory {
+			return nil
+		}
+		return filer_pb.ReadDirAllEntries(context.Background(), b, util.FullPath(filer.TopicsDir).Child(namespaceEntry.Name), "", func(topicEntry *filer_pb.Entry, isLast bool) error {
+			if topicEntry.IsDirectory {
+				topics = append(topics, topic.NewTopic(namespaceEntry.Name, topicEntry.Name))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		glog.V(0).Infof("list topics to compact: %v", err)
+		return
+	}
+
+	for _, t := range topics {
+		conf, err := b.fca.ReadTopicConfFromFiler(t)
+		if err != nil || !topic.IsCompacted(conf.Retention) {
+			continue
+		}
+		stats, err := logstore.CompactTopicByKey(b, t, &logstore.KeyCompactionOption{
+			DeleteRetention:  topic.DeleteRetention(conf.Retention),
+			MinCompactionLag: topic.MinCompactionLag(conf.Retention),
+			Retention:        topic.CompactionRetention(conf.Retention),
+			Preference: &operation.StoragePreference{
+				Replication: b.option.DefaultReplication,
+				Collection:  "topics",
+				DataCenter:  b.option.DataCenter,
+			},
+		})
+		logstore.LogKeyCompactionStats(t, stats, err)
+	}
+}
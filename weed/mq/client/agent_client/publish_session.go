@@ -3,6 +3,7 @@ package agent_client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/mq/schema"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_agent_pb"
@@ -80,3 +81,12 @@ func (a *PublishSession) PublishMessageRecord(key []byte, record *schema_pb.Reco
 		Value: record,
 	})
 }
+
+// PublishMessageRecordAt publishes the record to be delivered to subscribers at deliverAt.
+func (a *PublishSession) PublishMessageRecordAt(key []byte, record *schema_pb.RecordValue, deliverAt time.Time) error {
+	return a.stream.Send(&mq_agent_pb.PublishRecordRequest{
+		Key:         key,
+		Value:       record,
+		DeliverAtNs: deliverAt.UnixNano(),
+	})
+}
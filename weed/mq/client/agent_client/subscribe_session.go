@@ -6,6 +6,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_agent_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -20,6 +21,7 @@ type SubscribeOption struct {
 	Filter                  string
 	MaxSubscribedPartitions int32
 	SlidingWindowSize       int32
+	DeadLetterPolicy        *mq_pb.DeadLetterPolicy // requires acking each record, see SubscribeMessageRecordWithAck
 }
 
 type SubscribeSession struct {
@@ -47,6 +49,7 @@ func NewSubscribeSession(agentAddress string, option *SubscribeOption) (*Subscri
 		MaxSubscribedPartitions: option.MaxSubscribedPartitions,
 		Filter:                  option.Filter,
 		SlidingWindowSize:       option.SlidingWindowSize,
+		DeadLetterPolicy:        option.DeadLetterPolicy,
 	}
 
 	stream, err := agentClient.SubscribeRecord(context.Background())
@@ -85,3 +88,29 @@ func (a *SubscribeSession) SubscribeMessageRecord(
 		onEachMessageFn(resp.Key, resp.Value)
 	}
 }
+
+// SubscribeMessageRecordWithAck acks each record after it is processed.
+// If processing returns an error, the record is nacked, and redelivered according to the dead letter policy.
+func (a *SubscribeSession) SubscribeMessageRecordWithAck(
+	onEachMessageFn func(key []byte, record *schema_pb.RecordValue, deliveryAttempt int32) error,
+	onCompletionFn func()) error {
+	for {
+		resp, err := a.stream.Recv()
+		if err != nil {
+			if onCompletionFn != nil {
+				onCompletionFn()
+			}
+			return err
+		}
+		ack := &mq_agent_pb.SubscribeRecordRequest{
+			AckSequence: resp.TsNs,
+			AckKey:      resp.Key,
+		}
+		if processErr := onEachMessageFn(resp.Key, resp.Value, resp.DeliveryAttempt); processErr != nil {
+			ack.IsNack, ack.NackError = true, processErr.Error()
+		}
+		if err = a.stream.Send(ack); err != nil {
+			return fmt.Errorf("send ack: %w", err)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package pub_client
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/seaweedfs/seaweedfs/weed/mq/pub_balancer"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
 	"github.com/seaweedfs/seaweedfs/weed/util"
@@ -15,10 +17,18 @@ func (p *TopicPublisher) Publish(key, value []byte) error {
 	if p.config.RecordType != nil {
 		return fmt.Errorf("record type is set, use PublishRecord instead")
 	}
-	return p.doPublish(key, value)
+	return p.doPublish(key, value, 0)
 }
 
-func (p *TopicPublisher) doPublish(key, value []byte) error {
+// PublishAt publishes the message to be delivered to subscribers at deliverAt.
+func (p *TopicPublisher) PublishAt(key, value []byte, deliverAt time.Time) error {
+	if p.config.RecordType != nil {
+		return fmt.Errorf("record type is set, use PublishRecordAt instead")
+	}
+	return p.doPublish(key, value, deliverAt.UnixNano())
+}
+
+func (p *TopicPublisher) doPublish(key, value []byte, deliverAtNs int64) error {
 	hashKey := util.HashToInt32(key) % pub_balancer.MaxPartitionCount
 	if hashKey < 0 {
 		hashKey = -hashKey
@@ -29,20 +39,53 @@ func (p *TopicPublisher) doPublish(key, value []byte) error {
 	}
 
 	return inputBuffer.Enqueue(&mq_pb.DataMessage{
-		Key:   key,
-		Value: value,
-		TsNs:  time.Now().UnixNano(),
+		Key:         key,
+		Value:       value,
+		TsNs:        time.Now().UnixNano(),
+		DeliverAtNs: deliverAtNs,
 	})
 }
 
 func (p *TopicPublisher) PublishRecord(key []byte, recordValue *schema_pb.RecordValue) error {
+	return p.doPublishRecord(key, recordValue, 0)
+}
+
+// PublishRecordAt publishes the record to be delivered to subscribers at deliverAt.
+// The record is delivered right away if deliverAt is not in the future.
+func (p *TopicPublisher) PublishRecordAt(key []byte, recordValue *schema_pb.RecordValue, deliverAt time.Time) error {
+	return p.doPublishRecord(key, recordValue, deliverAt.UnixNano())
+}
+
+func (p *TopicPublisher) doPublishRecord(key []byte, recordValue *schema_pb.RecordValue, deliverAtNs int64) error {
 	// serialize record value
 	value, err := proto.Marshal(recordValue)
 	if err != nil {
 		return fmt.Errorf("failed to marshal record value: %w", err)
 	}
 
-	return p.doPublish(key, value)
+	return p.doPublish(key, value, deliverAtNs)
+}
+
+// CancelDelayed cancels the delayed messages of the key that are not delivered yet.
+func (p *TopicPublisher) CancelDelayed(key []byte) (cancelledCount int64, err error) {
+	if len(p.config.Brokers) == 0 {
+		return 0, fmt.Errorf("no bootstrap brokers")
+	}
+	err = pb.WithBrokerGrpcClient(false, p.config.Brokers[0], p.grpcDialOption, func(client mq_pb.SeaweedMessagingClient) error {
+		resp, err := client.CancelDelayedMessage(context.Background(), &mq_pb.CancelDelayedMessageRequest{
+			Topic: p.config.Topic.ToPbTopic(),
+			Key:   key,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		cancelledCount = resp.CancelledCount
+		return nil
+	})
+	return
 }
 
 func (p *TopicPublisher) FinishPublish() error {
@@ -13,11 +13,15 @@ import (
 )
 
 type KeyedTimestamp struct {
-	Key  []byte
-	TsNs int64 // Timestamp in nanoseconds for acknowledgment
+	Key    []byte
+	TsNs   int64  // Timestamp in nanoseconds for acknowledgment
+	IsNack bool   // the message failed processing
+	Error  string // the processing failure of a nack
 }
 
-func (sub *TopicSubscriber) onEachPartition(assigned *mq_pb.BrokerPartitionAssignment, stopCh chan struct{}, onDataMessageFn OnDataMessageFn) error {
+// onEachPartition subscribes to one partition. The acks of the processed messages come from ackChan,
+// and the manual acks from PartitionOffsetChan.
+func (sub *TopicSubscriber) onEachPartition(assigned *mq_pb.BrokerPartitionAssignment, stopCh chan struct{}, ackChan chan KeyedTimestamp, onDataMessageFn OnDataMessageFn) error {
 	// connect to the partition broker
 	return pb.WithBrokerGrpcClient(true, assigned.LeaderBroker, sub.SubscriberConfig.GrpcDialOption, func(client mq_pb.SeaweedMessagingClient) error {
 
@@ -50,6 +54,7 @@ func (sub *TopicSubscriber) onEachPartition(assigned *mq_pb.BrokerPartitionAssig
 					Filter:            sub.ContentConfig.Filter,
 					FollowerBroker:    assigned.FollowerBroker,
 					SlidingWindowSize: slidingWindowSize,
+					DeadLetterPolicy:  sub.SubscriberConfig.DeadLetterPolicy,
 				},
 			},
 		}); err != nil {
@@ -62,6 +67,18 @@ func (sub *TopicSubscriber) onEachPartition(assigned *mq_pb.BrokerPartitionAssig
 			defer sub.OnCompletionFunc()
 		}
 
+		sendAck := func(ack KeyedTimestamp) {
+			subscribeClient.SendMsg(&mq_pb.SubscribeMessageRequest{
+				Message: &mq_pb.SubscribeMessageRequest_Ack{
+					Ack: &mq_pb.SubscribeMessageRequest_AckMessage{
+						Key:    ack.Key,
+						TsNs:   ack.TsNs,
+						IsNack: ack.IsNack,
+						Error:  ack.Error,
+					},
+				},
+			})
+		}
 		go func() {
 			for {
 				select {
@@ -71,19 +88,14 @@ func (sub *TopicSubscriber) onEachPartition(assigned *mq_pb.BrokerPartitionAssig
 				case <-stopCh:
 					subscribeClient.CloseSend()
 					return
+				case ack := <-ackChan:
+					sendAck(ack)
 				case ack, ok := <-sub.PartitionOffsetChan:
 					if !ok {
 						subscribeClient.CloseSend()
 						return
 					}
-					subscribeClient.SendMsg(&mq_pb.SubscribeMessageRequest{
-						Message: &mq_pb.SubscribeMessageRequest_Ack{
-							Ack: &mq_pb.SubscribeMessageRequest_AckMessage{
-								Key:  ack.Key,
-								TsNs: ack.TsNs,
-							},
-						},
-					})
+					sendAck(ack)
 				}
 			}
 		}()
@@ -70,20 +70,38 @@ func (sub *TopicSubscriber) startProcessors() {
 					},
 				}
 
+				// acks go back on the stream of their own partition
+				ackChan := make(chan KeyedTimestamp, 1024)
+				partitionDone := make(chan struct{})
 				executors := util.NewLimitedConcurrentExecutor(int(sub.SubscriberConfig.SlidingWindowSize))
 				onDataMessageFn := func(m *mq_pb.SubscribeMessageResponse_Data) {
 					executors.Execute(func() {
 						if sub.OnDataMessageFunc != nil {
 							sub.OnDataMessageFunc(m)
 						}
-						sub.PartitionOffsetChan <- KeyedTimestamp{
+						var processErr error
+						if sub.OnDataMessageWithErrorFunc != nil {
+							processErr = sub.OnDataMessageWithErrorFunc(m)
+						}
+						if sub.SubscriberConfig.ManualAck {
+							return
+						}
+						ack := KeyedTimestamp{
 							Key:  m.Data.Key,
 							TsNs: m.Data.TsNs,
 						}
+						if processErr != nil {
+							ack.IsNack, ack.Error = true, processErr.Error()
+						}
+						select {
+						case ackChan <- ack:
+						case <-partitionDone:
+						}
 					})
 				}
 
-				err := sub.onEachPartition(assigned, stopChan, onDataMessageFn)
+				err := sub.onEachPartition(assigned, stopChan, ackChan, onDataMessageFn)
+				close(partitionDone)
 				if err != nil {
 					glog.V(0).Infof("subscriber %s/%s partition %+v at %v: %v", sub.ContentConfig.Topic, sub.SubscriberConfig.ConsumerGroup, assigned.Partition, assigned.LeaderBroker, err)
 				} else {
@@ -17,6 +17,8 @@ type SubscriberConfiguration struct {
 	GrpcDialOption          grpc.DialOption
 	MaxPartitionCount       int32 // how many partitions to process concurrently
 	SlidingWindowSize       int32 // how many messages to process concurrently per partition
+	DeadLetterPolicy        *mq_pb.DeadLetterPolicy
+	ManualAck               bool // the caller acks or nacks each message through PartitionOffsetChan
 }
 
 func (s *SubscriberConfiguration) String() string {
@@ -32,6 +34,10 @@ type ContentConfiguration struct {
 }
 
 type OnDataMessageFn func(m *mq_pb.SubscribeMessageResponse_Data)
+
+// OnDataMessageWithErrorFn returns an error if the message failed processing,
+// so that the broker redelivers it, or sends it to the dead letter topic.
+type OnDataMessageWithErrorFn func(m *mq_pb.SubscribeMessageResponse_Data) error
 type OnCompletionFunc func()
 
 type TopicSubscriber struct {
@@ -41,6 +47,7 @@ type TopicSubscriber struct {
 	brokerPartitionAssignmentChan    chan *mq_pb.SubscriberToSubCoordinatorResponse
 	brokerPartitionAssignmentAckChan chan *mq_pb.SubscriberToSubCoordinatorRequest
 	OnDataMessageFunc                OnDataMessageFn
+	OnDataMessageWithErrorFunc       OnDataMessageWithErrorFn
 	OnCompletionFunc                 OnCompletionFunc
 	bootstrapBrokers                 []string
 	activeProcessors                 map[topic.Partition]*ProcessorState
@@ -65,6 +72,10 @@ func (sub *TopicSubscriber) SetOnDataMessageFn(fn OnDataMessageFn) {
 	sub.OnDataMessageFunc = fn
 }
 
+func (sub *TopicSubscriber) SetOnDataMessageWithErrorFn(fn OnDataMessageWithErrorFn) {
+	sub.OnDataMessageWithErrorFunc = fn
+}
+
 func (sub *TopicSubscriber) SetCompletionFunc(onCompletionFn OnCompletionFunc) {
 	sub.OnCompletionFunc = onCompletionFn
 }
@@ -2222,6 +2222,7 @@ func (h *Handler) handleCreateTopicsV2To4(correlationID uint32, requestBody []by
 		name        string
 		partitions  uint32
 		replication uint16
+		configs     map[string]string
 	}, 0, topicsCount)
 	for i := uint32(0); i < topicsCount; i++ {
 		if len(requestBody) < offset+2 {
@@ -2268,19 +2269,25 @@ func (h *Handler) handleCreateTopicsV2To4(correlationID uint32, requestBody []by
 			offset += int(replicasCount) * 4
 		}
 
-		// Configs array (array of (name,value) strings) - skip contents
+		// Configs array (array of (name,value) strings)
 		if len(requestBody) < offset+4 {
 			return nil, fmt.Errorf("CreateTopics v2-4: truncated configs count")
 		}
 		configs := binary.BigEndian.Uint32(requestBody[offset : offset+4])
 		offset += 4
+		topicConfigs := make(map[string]string)
 		for j := uint32(0); j < configs; j++ {
 			// name (string)
 			if len(requestBody) < offset+2 {
 				return nil, fmt.Errorf("CreateTopics v2-4: truncated config name length")
 			}
 			nameLen := binary.BigEndian.Uint16(requestBody[offset : offset+2])
-			offset += 2 + int(nameLen)
+			offset += 2
+			if len(requestBody) < offset+int(nameLen) {
+				return nil, fmt.Errorf("CreateTopics v2-4: truncated config name")
+			}
+			configName := string(requestBody[offset : offset+int(nameLen)])
+			offset += int(nameLen)
 			// value (nullable string)
 			if len(requestBody) < offset+2 {
 				return nil, fmt.Errorf("CreateTopics v2-4: truncated config value length")
@@ -2288,6 +2295,10 @@ func (h *Handler) handleCreateTopicsV2To4(correlationID uint32, requestBody []by
 			valueLen := int16(binary.BigEndian.Uint16(requestBody[offset : offset+2]))
 			offset += 2
 			if valueLen >= 0 {
+				if len(requestBody) < offset+int(valueLen) {
+					return nil, fmt.Errorf("CreateTopics v2-4: truncated config value")
+				}
+				topicConfigs[configName] = string(requestBody[offset : offset+int(valueLen)])
 				offset += int(valueLen)
 			}
 		}
@@ -2296,7 +2307,8 @@ func (h *Handler) handleCreateTopicsV2To4(correlationID uint32, requestBody []by
 			name        string
 			partitions  uint32
 			replication uint16
-		}{topicName, numPartitions, replication})
+			configs     map[string]string
+		}{topicName, numPartitions, replication, topicConfigs})
 	}
 
 	// timeout_ms
@@ -2337,9 +2349,7 @@ func (h *Handler) handleCreateTopicsV2To4(correlationID uint32, requestBody []by
 			errCode = 38 // INVALID_REPLICATION_FACTOR
 		} else {
 			// Use schema-aware topic creation
-			if err := h.createTopicWithSchemaSupport(t.name, int32(t.partitions)); err != nil {
-				errCode = 0xFFFF // UNKNOWN_SERVER_ERROR (-1 as uint16)
-			}
+			errCode = h.createTopicWithConfigs(t.name, int32(t.partitions), t.configs)
 		}
 		eb := make([]byte, 2)
 		binary.BigEndian.PutUint16(eb, errCode)
@@ -2425,21 +2435,30 @@ func (h *Handler) handleCreateTopicsV0V1(correlationID uint32, requestBody []byt
 		}
 
 		// Parse configs array (4 bytes count, then configs)
+		topicConfigs := make(map[string]string)
 		if len(requestBody) >= offset+4 {
 			configsCount := binary.BigEndian.Uint32(requestBody[offset : offset+4])
 			offset += 4
 
-			// Skip configs (simplified)
 			for j := uint32(0); j < configsCount && offset < len(requestBody); j++ {
-				// Skip config name (string: 2 bytes length + bytes)
+				// Config name (string: 2 bytes length + bytes)
+				var configName string
 				if len(requestBody) >= offset+2 {
-					configNameLength := binary.BigEndian.Uint16(requestBody[offset : offset+2])
-					offset += 2 + int(configNameLength)
+					configNameLength := int(binary.BigEndian.Uint16(requestBody[offset : offset+2]))
+					offset += 2
+					if len(requestBody) >= offset+configNameLength {
+						configName = string(requestBody[offset : offset+configNameLength])
+					}
+					offset += configNameLength
 				}
-				// Skip config value (string: 2 bytes length + bytes)
+				// Config value (nullable string: 2 bytes length + bytes)
 				if len(requestBody) >= offset+2 {
-					configValueLength := binary.BigEndian.Uint16(requestBody[offset : offset+2])
-					offset += 2 + int(configValueLength)
+					configValueLength := int(int16(binary.BigEndian.Uint16(requestBody[offset : offset+2])))
+					offset += 2
+					if configValueLength >= 0 && len(requestBody) >= offset+configValueLength {
+						topicConfigs[configName] = string(requestBody[offset : offset+configValueLength])
+						offset += configValueLength
+					}
 				}
 			}
 		}
@@ -2467,9 +2486,7 @@ func (h *Handler) handleCreateTopicsV0V1(correlationID uint32, requestBody []byt
 			errorCode = 36 // TOPIC_ALREADY_EXISTS
 		} else {
 			// Create the topic in SeaweedMQ with schema support
-			if err := h.createTopicWithSchemaSupport(topicName, int32(numPartitions)); err != nil {
-				errorCode = 0xFFFF // UNKNOWN_SERVER_ERROR (-1 as uint16)
-			}
+			errorCode = h.createTopicWithConfigs(topicName, int32(numPartitions), topicConfigs)
 		}
 
 		// Error code (2 bytes)
@@ -2522,6 +2539,7 @@ func (h *Handler) handleCreateTopicsV2Plus(correlationID uint32, apiVersion uint
 		name        string
 		partitions  uint32
 		replication uint16
+		configs     map[string]string
 	}
 	topics := make([]topicSpec, 0, topicsCount)
 
@@ -2592,27 +2610,29 @@ func (h *Handler) handleCreateTopicsV2Plus(correlationID uint32, apiVersion uint
 			offset += consumed
 		}
 
-		// Configs (compact array) - skip entries
+		// Configs (compact array)
 		cfgCount, consumed, err := DecodeCompactArrayLength(requestBody[offset:])
 		if err != nil {
 			return nil, fmt.Errorf("CreateTopics v%d: decode topic[%d] configs array: %w", apiVersion, i, err)
 		}
 		offset += consumed
 
+		topicConfigs := make(map[string]string)
 		for j := uint32(0); j < cfgCount; j++ {
 			// name (compact string)
-			_, consumed, err := DecodeFlexibleString(requestBody[offset:])
+			configName, consumed, err := DecodeFlexibleString(requestBody[offset:])
 			if err != nil {
 				return nil, fmt.Errorf("CreateTopics v%d: decode topic[%d] config[%d] name: %w", apiVersion, i, j, err)
 			}
 			offset += consumed
 
 			// value (nullable compact string)
-			_, consumed, err = DecodeFlexibleString(requestBody[offset:])
+			configValue, consumed, err := DecodeFlexibleString(requestBody[offset:])
 			if err != nil {
 				return nil, fmt.Errorf("CreateTopics v%d: decode topic[%d] config[%d] value: %w", apiVersion, i, j, err)
 			}
 			offset += consumed
+			topicConfigs[configName] = configValue
 
 			// tagged fields for each config
 			_, consumed, err = DecodeTaggedFields(requestBody[offset:])
@@ -2629,7 +2649,7 @@ func (h *Handler) handleCreateTopicsV2Plus(correlationID uint32, apiVersion uint
 		}
 		offset += consumed
 
-		topics = append(topics, topicSpec{name: name, partitions: partitions, replication: replication})
+		topics = append(topics, topicSpec{name: name, partitions: partitions, replication: replication, configs: topicConfigs})
 	}
 
 	for range topics {
@@ -2742,9 +2762,7 @@ func (h *Handler) handleCreateTopicsV2Plus(correlationID uint32, apiVersion uint
 			errCode = 0 // SUCCESS - AdminClient can handle this gracefully
 		} else {
 			// Use corrected values for error checking and topic creation with schema support
-			if err := h.createTopicWithSchemaSupport(t.name, int32(actualPartitions)); err != nil {
-				errCode = 0xFFFF // UNKNOWN_SERVER_ERROR (-1 as uint16)
-			}
+			errCode = h.createTopicWithConfigs(t.name, int32(actualPartitions), t.configs)
 		}
 		eb := make([]byte, 2)
 		binary.BigEndian.PutUint16(eb, errCode)
@@ -3678,6 +3696,19 @@ func (h *Handler) getTopicConfigs(topicName string, requestedConfigs []string) [
 		},
 	}
 
+	// Configs stored with the topic override the defaults
+	if retention := h.getTopicRetention(topicName); retention != nil {
+		for _, config := range retentionToTopicConfigs(retention) {
+			allConfigs[config.Name] = config
+		}
+	} else {
+		for _, config := range retentionToTopicConfigs(nil) {
+			if _, exists := allConfigs[config.Name]; !exists {
+				allConfigs[config.Name] = config
+			}
+		}
+	}
+
 	// If specific configs requested, filter to those
 	if len(requestedConfigs) > 0 {
 		filteredConfigs := make([]ConfigEntry, 0, len(requestedConfigs))
@@ -0,0 +1,186 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Kafka topic configs that are stored in the SeaweedMQ topic retention
+const (
+	topicConfigCleanupPolicy      = "cleanup.policy"
+	topicConfigRetentionMs        = "retention.ms"
+	topicConfigDeleteRetentionMs  = "delete.retention.ms"
+	topicConfigMinCompactionLagMs = "min.compaction.lag.ms"
+)
+
+// topicConfigsToRetention converts the topic configs of a CreateTopics request.
+// Empty or null values are ignored. It returns nil if none of the supported configs is set.
+func topicConfigsToRetention(configs map[string]string) (*mq_pb.TopicRetention, error) {
+	retention := &mq_pb.TopicRetention{}
+	for name, value := range configs {
+		if value == "" {
+			delete(configs, name)
+		}
+	}
+	found := false
+	parseMs := func(name string) (int64, error) {
+		ms, err := strconv.ParseInt(configs[name], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q", name, configs[name])
+		}
+		return ms, nil
+	}
+
+	if value, ok := configs[topicConfigCleanupPolicy]; ok {
+		policy, err := topic.NormalizeCleanupPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		retention.CleanupPolicy, found = policy, true
+	}
+	if _, ok := configs[topicConfigRetentionMs]; ok {
+		ms, err := parseMs(topicConfigRetentionMs)
+		if err != nil {
+			return nil, err
+		}
+		if ms >= 0 {
+			retention.RetentionSeconds, retention.Enabled = ms/1000, true
+		}
+		found = true
+	}
+	if _, ok := configs[topicConfigDeleteRetentionMs]; ok {
+		ms, err := parseMs(topicConfigDeleteRetentionMs)
+		if err != nil {
+			return nil, err
+		}
+		retention.DeleteRetentionSeconds, found = ms/1000, true
+	}
+	if _, ok := configs[topicConfigMinCompactionLagMs]; ok {
+		ms, err := parseMs(topicConfigMinCompactionLagMs)
+		if err != nil {
+			return nil, err
+		}
+		retention.MinCompactionLagSeconds, found = ms/1000, true
+	}
+
+	if !found {
+		return nil, nil
+	}
+	return retention, nil
+}
+
+// retentionToTopicConfigs returns the topic configs stored in a topic retention.
+func retentionToTopicConfigs(retention *mq_pb.TopicRetention) []ConfigEntry {
+	retentionMs := "-1"
+	if retention.GetEnabled() {
+		retentionMs = strconv.FormatInt(retention.GetRetentionSeconds()*1000, 10)
+	}
+	return []ConfigEntry{
+		{
+			Name:      topicConfigCleanupPolicy,
+			Value:     topic.CleanupPolicy(retention),
+			IsDefault: retention.GetCleanupPolicy() == "",
+		},
+		{
+			Name:      topicConfigRetentionMs,
+			Value:     retentionMs,
+			IsDefault: !retention.GetEnabled(),
+		},
+		{
+			Name:      topicConfigDeleteRetentionMs,
+			Value:     strconv.FormatInt(topic.DeleteRetention(retention).Milliseconds(), 10),
+			IsDefault: retention.GetDeleteRetentionSeconds() == 0,
+		},
+		{
+			Name:      topicConfigMinCompactionLagMs,
+			Value:     strconv.FormatInt(topic.MinCompactionLag(retention).Milliseconds(), 10),
+			IsDefault: retention.GetMinCompactionLagSeconds() == 0,
+		},
+	}
+}
+
+func (h *Handler) withTopicBrokerClient(fn func(client mq_pb.SeaweedMessagingClient) error) error {
+	if h.seaweedMQHandler == nil {
+		return fmt.Errorf("no SeaweedMQ handler available for broker access")
+	}
+	brokerAddresses := h.seaweedMQHandler.GetBrokerAddresses()
+	if len(brokerAddresses) == 0 {
+		return fmt.Errorf("no broker addresses available")
+	}
+	util.LoadSecurityConfiguration()
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.mq")
+	return pb.WithBrokerGrpcClient(false, brokerAddresses[0], grpcDialOption, fn)
+}
+
+// setTopicRetention stores the retention and cleanup policy of an existing topic.
+func (h *Handler) setTopicRetention(topicName string, retention *mq_pb.TopicRetention) error {
+	seaweedTopic := &schema_pb.Topic{
+		Namespace: DefaultKafkaNamespace,
+		Name:      topicName,
+	}
+	return h.withTopicBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		conf, err := client.GetTopicConfiguration(context.Background(), &mq_pb.GetTopicConfigurationRequest{
+			Topic: seaweedTopic,
+		})
+		if err != nil {
+			return fmt.Errorf("get topic %s configuration: %w", topicName, err)
+		}
+		_, err = client.ConfigureTopic(context.Background(), &mq_pb.ConfigureTopicRequest{
+			Topic:             seaweedTopic,
+			PartitionCount:    conf.PartitionCount,
+			MessageRecordType: conf.MessageRecordType,
+			KeyColumns:        conf.KeyColumns,
+			SchemaFormat:      conf.SchemaFormat,
+			Retention:         retention,
+		})
+		return err
+	})
+}
+
+// getTopicRetention reads the retention and cleanup policy of a topic, nil if not available.
+func (h *Handler) getTopicRetention(topicName string) *mq_pb.TopicRetention {
+	var retention *mq_pb.TopicRetention
+	err := h.withTopicBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		conf, err := client.GetTopicConfiguration(context.Background(), &mq_pb.GetTopicConfigurationRequest{
+			Topic: &schema_pb.Topic{
+				Namespace: DefaultKafkaNamespace,
+				Name:      topicName,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		retention = conf.Retention
+		return nil
+	})
+	if err != nil {
+		return nil
+	}
+	return retention
+}
+
+// createTopicWithConfigs creates a topic, and applies the supported topic configs.
+// It returns the Kafka error code.
+func (h *Handler) createTopicWithConfigs(topicName string, partitions int32, configs map[string]string) uint16 {
+	retention, err := topicConfigsToRetention(configs)
+	if err != nil {
+		return 40 // INVALID_CONFIG
+	}
+	if err := h.createTopicWithSchemaSupport(topicName, partitions); err != nil {
+		return 0xFFFF // UNKNOWN_SERVER_ERROR (-1 as uint16)
+	}
+	if retention != nil {
+		if err := h.setTopicRetention(topicName, retention); err != nil {
+			return 0xFFFF
+		}
+	}
+	return 0
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // CompatibilityLevel defines the schema compatibility level
@@ -311,25 +312,114 @@ func (checker *SchemaEvolutionChecker) checkProtobufCompatibility(
 		Level:      level,
 	}
 
-	// For now, implement basic Protobuf compatibility rules
-	// In a full implementation, this would parse .proto files and check field numbers, types, etc.
-
-	// Basic check: if schemas are identical, they're compatible
 	if oldSchemaStr == newSchemaStr {
 		return result, nil
 	}
 
-	// For protobuf, we need to parse the schema and check:
-	// - Field numbers haven't changed
-	// - Required fields haven't been removed
-	// - Field types are compatible
+	oldMessage, err := parseProtobufMessageDescriptor(oldSchemaStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse old Protobuf schema: %w", err)
+	}
+	newMessage, err := parseProtobufMessageDescriptor(newSchemaStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse new Protobuf schema: %w", err)
+	}
+
+	if oldMessage.FullName() != newMessage.FullName() {
+		result.Compatible = false
+		result.Issues = append(result.Issues,
+			fmt.Sprintf("Message '%s' was renamed to '%s'", oldMessage.FullName(), newMessage.FullName()))
+		return result, nil
+	}
 
-	// Simplified implementation - mark as compatible with warning
-	result.Issues = append(result.Issues, "Protobuf compatibility checking is simplified - manual review recommended")
+	checkBackward := level == CompatibilityBackward || level == CompatibilityFull
+	checkForward := level == CompatibilityForward || level == CompatibilityFull
+	checker.checkProtobufMessageCompatibility(oldMessage, newMessage, checkBackward, checkForward, result, make(map[protoreflect.FullName]bool))
 
 	return result, nil
 }
 
+// checkProtobufMessageCompatibility compares the fields of two versions of a message by field number.
+// Protobuf readers skip unknown fields, so adding or removing optional fields is compatible.
+// Changing the wire type or the cardinality of a field is not, and neither is adding (backward)
+// or removing (forward) a proto2 required field.
+func (checker *SchemaEvolutionChecker) checkProtobufMessageCompatibility(
+	oldMessage, newMessage protoreflect.MessageDescriptor,
+	checkBackward, checkForward bool,
+	result *CompatibilityResult,
+	visited map[protoreflect.FullName]bool,
+) {
+	if visited[oldMessage.FullName()] {
+		return
+	}
+	visited[oldMessage.FullName()] = true
+
+	oldFields, newFields := oldMessage.Fields(), newMessage.Fields()
+	for i := 0; i < oldFields.Len(); i++ {
+		oldField := oldFields.Get(i)
+		newField := newFields.ByNumber(oldField.Number())
+		if newField == nil {
+			if checkForward && oldField.Cardinality() == protoreflect.Required {
+				result.Compatible = false
+				result.Issues = append(result.Issues,
+					fmt.Sprintf("Required field '%s' was removed, breaking forward compatibility", oldField.FullName()))
+			}
+			continue
+		}
+		if oldField.IsList() != newField.IsList() || oldField.IsMap() != newField.IsMap() {
+			result.Compatible = false
+			result.Issues = append(result.Issues,
+				fmt.Sprintf("Field '%s' cardinality changed", oldField.FullName()))
+			continue
+		}
+		if !areProtobufKindsCompatible(oldField.Kind(), newField.Kind()) {
+			result.Compatible = false
+			result.Issues = append(result.Issues,
+				fmt.Sprintf("Field '%s' type changed incompatibly from %s to %s", oldField.FullName(), oldField.Kind(), newField.Kind()))
+			continue
+		}
+		if oldField.Message() != nil && newField.Message() != nil {
+			checker.checkProtobufMessageCompatibility(oldField.Message(), newField.Message(), checkBackward, checkForward, result, visited)
+		}
+	}
+
+	if checkBackward {
+		for i := 0; i < newFields.Len(); i++ {
+			newField := newFields.Get(i)
+			if oldFields.ByNumber(newField.Number()) == nil && newField.Cardinality() == protoreflect.Required {
+				result.Compatible = false
+				result.Issues = append(result.Issues,
+					fmt.Sprintf("New required field '%s' added, breaking backward compatibility", newField.FullName()))
+			}
+		}
+	}
+}
+
+// areProtobufKindsCompatible checks whether values encoded as one kind can be decoded as the other
+func areProtobufKindsCompatible(oldKind, newKind protoreflect.Kind) bool {
+	if oldKind == newKind {
+		return true
+	}
+	group := func(kind protoreflect.Kind) int {
+		switch kind {
+		case protoreflect.Int32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Uint64Kind,
+			protoreflect.BoolKind, protoreflect.EnumKind:
+			return 1
+		case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+			return 2
+		case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+			return 3
+		case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+			return 4
+		case protoreflect.StringKind, protoreflect.BytesKind:
+			return 5
+		default:
+			return 0
+		}
+	}
+	return group(oldKind) != 0 && group(oldKind) == group(newKind)
+}
+
 // checkJSONSchemaCompatibility checks JSON Schema compatibility
 func (checker *SchemaEvolutionChecker) checkJSONSchemaCompatibility(
 	oldSchemaStr, newSchemaStr string,
@@ -54,6 +54,15 @@ func NewProtobufDecoderFromDescriptor(msgDesc protoreflect.MessageDescriptor) *P
 // NewProtobufDecoderFromString creates a Protobuf decoder from a schema string
 // This parses text .proto format from Schema Registry
 func NewProtobufDecoderFromString(schemaStr string) (*ProtobufDecoder, error) {
+	msgDesc, err := parseProtobufMessageDescriptor(schemaStr)
+	if err != nil {
+		return nil, err
+	}
+	return NewProtobufDecoderFromDescriptor(msgDesc), nil
+}
+
+// parseProtobufMessageDescriptor parses a text .proto schema, and returns its first message
+func parseProtobufMessageDescriptor(schemaStr string) (protoreflect.MessageDescriptor, error) {
 	// Use protoparse to parse the text .proto schema
 	parser := protoparse.Parser{
 		Accessor: protoparse.FileContentsFromMap(map[string]string{
@@ -89,9 +98,7 @@ func NewProtobufDecoderFromString(schemaStr string) (*ProtobufDecoder, error) {
 	}
 
 	// Get the first message descriptor
-	msgDesc := messages.Get(0)
-
-	return NewProtobufDecoderFromDescriptor(msgDesc), nil
+	return messages.Get(0), nil
 }
 
 // Decode decodes Protobuf binary data to a Go map representation
@@ -0,0 +1,148 @@
+package schema_registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/schema"
+	mq_schema "github.com/seaweedfs/seaweedfs/weed/mq/schema"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+)
+
+const (
+	keySubjectSuffix   = "-key"
+	valueSubjectSuffix = "-value"
+)
+
+// BrokerClientFn runs fn with a client of an available MQ broker.
+type BrokerClientFn func(fn func(client mq_pb.SeaweedMessagingClient) error) error
+
+// RecordTypeSyncer updates the record type of the MQ topic of a subject when a new version
+// of the subject is registered, so that the SQL engine sees the registered schema.
+// Subjects follow the TopicNameStrategy of Kafka serializers, "<topic>-key" and "<topic>-value".
+type RecordTypeSyncer struct {
+	namespace        string
+	withBrokerClient BrokerClientFn
+	configureTimeout time.Duration
+}
+
+func NewRecordTypeSyncer(namespace string, withBrokerClient BrokerClientFn) *RecordTypeSyncer {
+	return &RecordTypeSyncer{
+		namespace:        namespace,
+		withBrokerClient: withBrokerClient,
+		configureTimeout: 30 * time.Second,
+	}
+}
+
+// OnRegistered can be passed to NewRegistry. Failures are logged, since the schema is already registered.
+func (s *RecordTypeSyncer) OnRegistered(subject string, registered *Schema) {
+	if err := s.Sync(subject, registered); err != nil {
+		glog.Warningf("update record type of subject %s: %v", subject, err)
+	}
+}
+
+// Sync sets the key or value part of the record type of the topic of the subject.
+// Topics that do not exist yet are left to be created by the Kafka gateway.
+func (s *RecordTypeSyncer) Sync(subject string, registered *Schema) error {
+	topicName, isKey := subjectTopic(subject)
+	if topicName == "" {
+		return nil
+	}
+	recordType, err := inferRecordType(registered)
+	if err != nil {
+		return fmt.Errorf("infer record type: %w", err)
+	}
+	t := topic.NewTopic(s.namespace, topicName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.configureTimeout)
+	defer cancel()
+	return s.withBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		conf, err := client.GetTopicConfiguration(ctx, &mq_pb.GetTopicConfigurationRequest{
+			Topic: t.ToPbTopic(),
+		})
+		if err != nil {
+			glog.V(1).Infof("skip record type of subject %s: topic %v: %v", subject, t, err)
+			return nil
+		}
+
+		var keyRecordType, valueRecordType *schema_pb.RecordType
+		if conf.MessageRecordType != nil && len(conf.MessageRecordType.Fields) > 0 {
+			keyRecordType, valueRecordType, err = mq_schema.SplitFlatSchemaToKeyValue(conf.MessageRecordType, conf.KeyColumns)
+			if err != nil {
+				// replace an inconsistent record type
+				keyRecordType, valueRecordType = nil, nil
+			}
+		}
+		if isKey {
+			keyRecordType = recordType
+		} else {
+			valueRecordType = recordType
+		}
+		flatRecordType, keyColumns := mq_schema.CombineFlatSchemaFromKeyValue(keyRecordType, valueRecordType)
+
+		// keep the partition count, since a different partition count would re-assign the partitions
+		_, err = client.ConfigureTopic(ctx, &mq_pb.ConfigureTopicRequest{
+			Topic:             t.ToPbTopic(),
+			PartitionCount:    conf.PartitionCount,
+			MessageRecordType: flatRecordType,
+			KeyColumns:        keyColumns,
+			SchemaFormat:      topicSchemaFormat(registered.SchemaType),
+		})
+		if err != nil {
+			return fmt.Errorf("configure topic %v: %w", t, err)
+		}
+		glog.V(0).Infof("updated record type of topic %v from subject %s schema %d", t, subject, registered.Id)
+		return nil
+	})
+}
+
+// subjectTopic returns the topic of the subject, and whether the subject is for the record keys.
+func subjectTopic(subject string) (string, bool) {
+	if strings.HasSuffix(subject, valueSubjectSuffix) {
+		return strings.TrimSuffix(subject, valueSubjectSuffix), false
+	}
+	if strings.HasSuffix(subject, keySubjectSuffix) {
+		return strings.TrimSuffix(subject, keySubjectSuffix), true
+	}
+	return "", false
+}
+
+func inferRecordType(s *Schema) (*schema_pb.RecordType, error) {
+	switch s.SchemaType {
+	case SchemaTypeProtobuf:
+		decoder, err := schema.NewProtobufDecoderFromString(s.Schema)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.InferRecordType()
+	case SchemaTypeJSON:
+		decoder, err := schema.NewJSONSchemaDecoder(s.Schema)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.InferRecordType()
+	default:
+		decoder, err := schema.NewAvroDecoder(s.Schema)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.InferRecordType()
+	}
+}
+
+// topicSchemaFormat returns the schema format stored in the topic configuration.
+func topicSchemaFormat(schemaType string) string {
+	switch schemaType {
+	case SchemaTypeProtobuf:
+		return "PROTOBUF"
+	case SchemaTypeJSON:
+		return "JSON_SCHEMA"
+	default:
+		return "AVRO"
+	}
+}
@@ -0,0 +1,593 @@
+package schema_registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/schema"
+)
+
+const (
+	schemasDir      = StoreDir + "/schemas"
+	fingerprintsDir = StoreDir + "/fingerprints"
+	subjectsDir     = StoreDir + "/subjects"
+	configName      = "config"
+
+	DefaultCompatibilityLevel = "BACKWARD"
+)
+
+// compatibility levels and whether they check against all previous versions
+var compatibilityLevels = map[string]struct {
+	level      schema.CompatibilityLevel
+	transitive bool
+}{
+	"NONE":                {schema.CompatibilityNone, false},
+	"BACKWARD":            {schema.CompatibilityBackward, false},
+	"BACKWARD_TRANSITIVE": {schema.CompatibilityBackward, true},
+	"FORWARD":             {schema.CompatibilityForward, false},
+	"FORWARD_TRANSITIVE":  {schema.CompatibilityForward, true},
+	"FULL":                {schema.CompatibilityFull, false},
+	"FULL_TRANSITIVE":     {schema.CompatibilityFull, true},
+}
+
+// OnRegisteredFn is called after a new version of a subject is registered.
+type OnRegisteredFn func(subject string, s *Schema)
+
+// Registry implements the schema registry on top of a Storage.
+// Registering is serialized within one registry, and schema ids are allocated with exclusive
+// creates, so that several registries can share the same storage.
+type Registry struct {
+	storage      Storage
+	checker      *schema.SchemaEvolutionChecker
+	lock         sync.Mutex
+	onRegistered OnRegisteredFn
+}
+
+func NewRegistry(storage Storage, onRegistered OnRegisteredFn) *Registry {
+	return &Registry{
+		storage:      storage,
+		checker:      schema.NewSchemaEvolutionChecker(),
+		onRegistered: onRegistered,
+	}
+}
+
+// Register registers the schema under the subject, and returns its id.
+// Registering a schema that is already a version of the subject returns the existing id.
+func (r *Registry) Register(subject string, s *Schema) (uint32, error) {
+	if subject == "" {
+		return 0, &Error{Code: ErrorCodeInvalidSchema, Message: "empty subject"}
+	}
+	schemaType, err := normalizeSchemaType(s.SchemaType)
+	if err != nil {
+		return 0, err
+	}
+	s.SchemaType = schemaType
+	if err = validateSchema(s); err != nil {
+		return 0, err
+	}
+	fingerprint := schemaFingerprint(s)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	versions, err := r.listSubjectVersions(subject)
+	if err != nil {
+		return 0, storeError(err)
+	}
+	var liveVersions []*SubjectVersion
+	for _, v := range versions {
+		if v.Deleted {
+			continue
+		}
+		liveVersions = append(liveVersions, v)
+		existing, err := r.GetSchema(v.Id)
+		if err != nil {
+			return 0, err
+		}
+		if schemaFingerprint(existing) == fingerprint {
+			return v.Id, nil
+		}
+	}
+
+	if messages, err := r.checkCompatibility(subject, s, liveVersions); err != nil {
+		return 0, err
+	} else if len(messages) > 0 {
+		return 0, &Error{
+			Code:    ErrorCodeIncompatibleSchema,
+			Message: "Schema being registered is incompatible with an earlier schema for subject \"" + subject + "\", details: " + strings.Join(messages, "; "),
+		}
+	}
+
+	id, err := r.allocateSchemaId(s, fingerprint)
+	if err != nil {
+		return 0, storeError(err)
+	}
+	s.Id = id
+
+	version := 1
+	if len(versions) > 0 {
+		version = versions[len(versions)-1].Version + 1
+	}
+	if err = r.saveSubjectVersion(&SubjectVersion{Subject: subject, Version: version, Id: id}); err != nil {
+		return 0, storeError(err)
+	}
+	glog.V(0).Infof("registered schema %d as version %d of subject %s", id, version, subject)
+
+	if r.onRegistered != nil {
+		r.onRegistered(subject, s)
+	}
+	return id, nil
+}
+
+// allocateSchemaId returns the id of an identical schema registered under any subject,
+// or stores the schema with a new id.
+func (r *Registry) allocateSchemaId(s *Schema, fingerprint string) (uint32, error) {
+	if data, err := r.storage.ReadFile(fingerprintsDir, fingerprint); err == nil {
+		id, err := strconv.ParseUint(string(data), 10, 32)
+		if err == nil {
+			return uint32(id), nil
+		}
+	} else if !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
+
+	names, err := r.storage.ListNames(schemasDir)
+	if err != nil {
+		return 0, err
+	}
+	var maxId uint32
+	for _, name := range names {
+		if id, err := strconv.ParseUint(name, 10, 32); err == nil && uint32(id) > maxId {
+			maxId = uint32(id)
+		}
+	}
+	for id := maxId + 1; ; id++ {
+		stored := *s
+		stored.Id = id
+		data, err := json.Marshal(&stored)
+		if err != nil {
+			return 0, err
+		}
+		err = r.storage.WriteFile(schemasDir, strconv.FormatUint(uint64(id), 10), data, true)
+		if errors.Is(err, ErrExists) {
+			// allocated by another registry
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err = r.storage.WriteFile(fingerprintsDir, fingerprint, []byte(strconv.FormatUint(uint64(id), 10)), false); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+}
+
+func (r *Registry) GetSchema(id uint32) (*Schema, error) {
+	data, err := r.storage.ReadFile(schemasDir, strconv.FormatUint(uint64(id), 10))
+	if errors.Is(err, ErrNotFound) {
+		return nil, &Error{Code: ErrorCodeSchemaNotFound, Message: fmt.Sprintf("Schema %d not found", id)}
+	}
+	if err != nil {
+		return nil, storeError(err)
+	}
+	s := &Schema{}
+	if err = json.Unmarshal(data, s); err != nil {
+		return nil, storeError(fmt.Errorf("parse schema %d: %w", id, err))
+	}
+	return s, nil
+}
+
+// GetSchemaVersions returns the subject versions of a schema id.
+func (r *Registry) GetSchemaVersions(id uint32) ([]*SubjectVersion, error) {
+	if _, err := r.GetSchema(id); err != nil {
+		return nil, err
+	}
+	subjects, err := r.ListSubjects(false)
+	if err != nil {
+		return nil, err
+	}
+	result := []*SubjectVersion{}
+	for _, subject := range subjects {
+		versions, err := r.listSubjectVersions(subject)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		for _, v := range versions {
+			if v.Id == id && !v.Deleted {
+				result = append(result, v)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *Registry) ListSubjects(includeDeleted bool) ([]string, error) {
+	names, err := r.storage.ListNames(subjectsDir)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	subjects := []string{}
+	for _, name := range names {
+		subject, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		versions, err := r.listSubjectVersions(subject)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		for _, v := range versions {
+			if includeDeleted || !v.Deleted {
+				subjects = append(subjects, subject)
+				break
+			}
+		}
+	}
+	sort.Strings(subjects)
+	return subjects, nil
+}
+
+func (r *Registry) ListVersions(subject string, includeDeleted bool) ([]int, error) {
+	versions, err := r.subjectVersions(subject, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]int, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, v.Version)
+	}
+	return result, nil
+}
+
+// GetVersion returns the version of the subject, and its schema.
+// The version is a number, or "latest" for the latest version not deleted.
+func (r *Registry) GetVersion(subject, version string, includeDeleted bool) (*SubjectVersion, *Schema, error) {
+	versions, err := r.subjectVersions(subject, includeDeleted)
+	if err != nil {
+		return nil, nil, err
+	}
+	var found *SubjectVersion
+	if version == "latest" || version == "-1" {
+		for i := len(versions) - 1; i >= 0; i-- {
+			if !versions[i].Deleted {
+				found = versions[i]
+				break
+			}
+		}
+	} else {
+		number, err := strconv.Atoi(version)
+		if err != nil || number <= 0 {
+			return nil, nil, &Error{Code: ErrorCodeInvalidVersion, Message: fmt.Sprintf("The specified version '%s' is not a valid version id", version)}
+		}
+		for _, v := range versions {
+			if v.Version == number {
+				found = v
+			}
+		}
+	}
+	if found == nil {
+		return nil, nil, &Error{Code: ErrorCodeVersionNotFound, Message: fmt.Sprintf("Version %s not found", version)}
+	}
+	s, err := r.GetSchema(found.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return found, s, nil
+}
+
+// Lookup finds the version of the subject with the same schema.
+func (r *Registry) Lookup(subject string, s *Schema, includeDeleted bool) (*SubjectVersion, *Schema, error) {
+	schemaType, err := normalizeSchemaType(s.SchemaType)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.SchemaType = schemaType
+	versions, err := r.subjectVersions(subject, includeDeleted)
+	if err != nil {
+		return nil, nil, err
+	}
+	fingerprint := schemaFingerprint(s)
+	for _, v := range versions {
+		existing, err := r.GetSchema(v.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if schemaFingerprint(existing) == fingerprint {
+			return v, existing, nil
+		}
+	}
+	return nil, nil, &Error{Code: ErrorCodeSchemaNotFound, Message: "Schema not found"}
+}
+
+// DeleteSubject soft deletes the versions of the subject, or removes the soft deleted versions if permanent.
+func (r *Registry) DeleteSubject(subject string, permanent bool) ([]int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	versions, err := r.subjectVersions(subject, permanent)
+	if err != nil {
+		return nil, err
+	}
+	deleted := []int{}
+	for _, v := range versions {
+		if permanent && !v.Deleted {
+			return nil, &Error{Code: ErrorCodeSubjectNotSoftDeleted, Message: fmt.Sprintf("Subject '%s' was not deleted first before being permanently deleted", subject)}
+		}
+	}
+	for _, v := range versions {
+		if err = r.deleteVersion(v, permanent); err != nil {
+			return nil, storeError(err)
+		}
+		deleted = append(deleted, v.Version)
+	}
+	if permanent {
+		if err = r.storage.DeleteFile(subjectsDir, url.PathEscape(subject)); err != nil {
+			return nil, storeError(err)
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteVersion soft deletes a version of the subject, or removes a soft deleted version if permanent.
+func (r *Registry) DeleteVersion(subject, version string, permanent bool) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	v, _, err := r.GetVersion(subject, version, permanent)
+	if err != nil {
+		return 0, err
+	}
+	if permanent && !v.Deleted {
+		return 0, &Error{Code: ErrorCodeVersionNotSoftDeleted, Message: fmt.Sprintf("Subject '%s' Version %d was not deleted first before being permanently deleted", subject, v.Version)}
+	}
+	if err = r.deleteVersion(v, permanent); err != nil {
+		return 0, storeError(err)
+	}
+	return v.Version, nil
+}
+
+func (r *Registry) deleteVersion(v *SubjectVersion, permanent bool) error {
+	if permanent {
+		return r.storage.DeleteFile(subjectDir(v.Subject), versionFileName(v.Version))
+	}
+	v.Deleted = true
+	return r.saveSubjectVersion(v)
+}
+
+// CheckCompatibility checks the schema against a version of the subject, or against
+// the versions the compatibility level of the subject requires if version is "latest".
+func (r *Registry) CheckCompatibility(subject, version string, s *Schema) ([]string, error) {
+	schemaType, err := normalizeSchemaType(s.SchemaType)
+	if err != nil {
+		return nil, err
+	}
+	s.SchemaType = schemaType
+	if err = validateSchema(s); err != nil {
+		return nil, err
+	}
+	if version == "latest" || version == "-1" {
+		versions, err := r.subjectVersions(subject, false)
+		if err != nil {
+			return nil, err
+		}
+		return r.checkCompatibility(subject, s, versions)
+	}
+	v, _, err := r.GetVersion(subject, version, false)
+	if err != nil {
+		return nil, err
+	}
+	return r.checkCompatibility(subject, s, []*SubjectVersion{v})
+}
+
+// checkCompatibility returns the compatibility issues of the schema with the latest version,
+// or with all versions if the compatibility level is transitive.
+func (r *Registry) checkCompatibility(subject string, s *Schema, versions []*SubjectVersion) ([]string, error) {
+	levelName, err := r.GetCompatibilityLevel(subject, true)
+	if err != nil {
+		return nil, err
+	}
+	level := compatibilityLevels[levelName]
+	if level.level == schema.CompatibilityNone || len(versions) == 0 {
+		return nil, nil
+	}
+	if !level.transitive {
+		versions = versions[len(versions)-1:]
+	}
+	var messages []string
+	for _, v := range versions {
+		existing, err := r.GetSchema(v.Id)
+		if err != nil {
+			return nil, err
+		}
+		if existing.SchemaType != s.SchemaType {
+			messages = append(messages, fmt.Sprintf("version %d has schema type %s instead of %s", v.Version, existing.SchemaType, s.SchemaType))
+			continue
+		}
+		result, err := r.checker.CheckCompatibility(existing.Schema, s.Schema, schemaFormat(s.SchemaType), level.level)
+		if err != nil {
+			return nil, &Error{Code: ErrorCodeInvalidSchema, Message: err.Error()}
+		}
+		if !result.Compatible {
+			for _, issue := range result.Issues {
+				messages = append(messages, fmt.Sprintf("version %d: %s", v.Version, issue))
+			}
+		}
+	}
+	return messages, nil
+}
+
+// GetCompatibilityLevel returns the compatibility level of the subject, or the global one
+// if the subject is empty. With defaultToGlobal, a subject without its own level uses the global one.
+func (r *Registry) GetCompatibilityLevel(subject string, defaultToGlobal bool) (string, error) {
+	if subject != "" {
+		config, err := r.readConfig(subjectDir(subject))
+		if err != nil {
+			return "", err
+		}
+		if config != nil {
+			return config.CompatibilityLevel, nil
+		}
+		if !defaultToGlobal {
+			return "", &Error{Code: ErrorCodeSubjectLevelConfigNotFound, Message: fmt.Sprintf("Subject '%s' does not have subject-level compatibility configured", subject)}
+		}
+	}
+	config, err := r.readConfig(StoreDir)
+	if err != nil {
+		return "", err
+	}
+	if config == nil {
+		return DefaultCompatibilityLevel, nil
+	}
+	return config.CompatibilityLevel, nil
+}
+
+// SetCompatibilityLevel sets the compatibility level of the subject, or the global one if the subject is empty.
+func (r *Registry) SetCompatibilityLevel(subject, level string) (string, error) {
+	level = strings.ToUpper(level)
+	if _, found := compatibilityLevels[level]; !found {
+		return "", &Error{Code: ErrorCodeInvalidCompatibilityLevel, Message: fmt.Sprintf("Invalid compatibility level %q", level)}
+	}
+	dir := StoreDir
+	if subject != "" {
+		dir = subjectDir(subject)
+	}
+	data, _ := json.Marshal(&Config{CompatibilityLevel: level})
+	if err := r.storage.WriteFile(dir, configName, data, false); err != nil {
+		return "", storeError(err)
+	}
+	return level, nil
+}
+
+// DeleteCompatibilityLevel removes the compatibility level of the subject, and returns the removed level.
+func (r *Registry) DeleteCompatibilityLevel(subject string) (string, error) {
+	level, err := r.GetCompatibilityLevel(subject, false)
+	if err != nil {
+		return "", err
+	}
+	if err = r.storage.DeleteFile(subjectDir(subject), configName); err != nil {
+		return "", storeError(err)
+	}
+	return level, nil
+}
+
+func (r *Registry) readConfig(dir string) (*Config, error) {
+	data, err := r.storage.ReadFile(dir, configName)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, storeError(err)
+	}
+	config := &Config{}
+	if err = json.Unmarshal(data, config); err != nil {
+		return nil, storeError(fmt.Errorf("parse %s/%s: %w", dir, configName, err))
+	}
+	return config, nil
+}
+
+// subjectVersions returns the versions of an existing subject, ordered by version.
+func (r *Registry) subjectVersions(subject string, includeDeleted bool) ([]*SubjectVersion, error) {
+	versions, err := r.listSubjectVersions(subject)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	var result []*SubjectVersion
+	for _, v := range versions {
+		if includeDeleted || !v.Deleted {
+			result = append(result, v)
+		}
+	}
+	if len(result) == 0 {
+		return nil, &Error{Code: ErrorCodeSubjectNotFound, Message: fmt.Sprintf("Subject '%s' not found.", subject)}
+	}
+	return result, nil
+}
+
+// listSubjectVersions returns all versions of the subject including the deleted ones, ordered by version.
+func (r *Registry) listSubjectVersions(subject string) ([]*SubjectVersion, error) {
+	dir := subjectDir(subject)
+	names, err := r.storage.ListNames(dir)
+	if err != nil {
+		return nil, err
+	}
+	var versions []*SubjectVersion
+	for _, name := range names {
+		if _, err := strconv.Atoi(name); err != nil {
+			continue
+		}
+		data, err := r.storage.ReadFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		v := &SubjectVersion{}
+		if err = json.Unmarshal(data, v); err != nil {
+			return nil, fmt.Errorf("parse %s/%s: %w", dir, name, err)
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	return versions, nil
+}
+
+func (r *Registry) saveSubjectVersion(v *SubjectVersion) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.storage.WriteFile(subjectDir(v.Subject), versionFileName(v.Version), data, false)
+}
+
+func subjectDir(subject string) string {
+	return subjectsDir + "/" + url.PathEscape(subject)
+}
+
+func versionFileName(version int) string {
+	return fmt.Sprintf("%08d", version)
+}
+
+// validateSchema parses the schema with the decoder of its type.
+func validateSchema(s *Schema) error {
+	var err error
+	switch s.SchemaType {
+	case SchemaTypeProtobuf:
+		_, err = schema.NewProtobufDecoderFromString(s.Schema)
+	case SchemaTypeJSON:
+		_, err = schema.NewJSONSchemaDecoder(s.Schema)
+	default:
+		_, err = schema.NewAvroDecoder(s.Schema)
+	}
+	if err != nil {
+		return &Error{Code: ErrorCodeInvalidSchema, Message: fmt.Sprintf("Invalid schema: %v", err)}
+	}
+	return nil
+}
+
+// schemaFingerprint identifies a schema regardless of the white spaces of JSON based schemas.
+func schemaFingerprint(s *Schema) string {
+	text := []byte(s.Schema)
+	if s.SchemaType != SchemaTypeProtobuf {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, text); err == nil {
+			text = compacted.Bytes()
+		}
+	}
+	h := sha256.New()
+	h.Write([]byte(s.SchemaType))
+	h.Write([]byte{0})
+	h.Write(text)
+	for _, ref := range s.References {
+		fmt.Fprintf(h, "\x00%s\x00%s\x00%d", ref.Name, ref.Subject, ref.Version)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,291 @@
+package schema_registry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type memoryStorage struct {
+	sync.Mutex
+	files map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) ReadFile(dir, name string) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	data, found := m.files[dir+"/"+name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (m *memoryStorage) WriteFile(dir, name string, data []byte, exclusive bool) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, found := m.files[dir+"/"+name]; found && exclusive {
+		return ErrExists
+	}
+	m.files[dir+"/"+name] = data
+	return nil
+}
+
+func (m *memoryStorage) DeleteFile(dir, name string) error {
+	m.Lock()
+	defer m.Unlock()
+	prefix := dir + "/" + name
+	for path := range m.files {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			delete(m.files, path)
+		}
+	}
+	return nil
+}
+
+func (m *memoryStorage) ListNames(dir string) ([]string, error) {
+	m.Lock()
+	defer m.Unlock()
+	found := make(map[string]bool)
+	for path := range m.files {
+		if rest, ok := strings.CutPrefix(path, dir+"/"); ok {
+			name, _, _ := strings.Cut(rest, "/")
+			found[name] = true
+		}
+	}
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+const (
+	userSchemaV1 = `{"type":"record","name":"User","fields":[{"name":"id","type":"int"}]}`
+	userSchemaV2 = `{"type":"record","name":"User","fields":[{"name":"id","type":"int"},{"name":"email","type":"string","default":""}]}`
+	// adds a field without a default, which readers of the new schema cannot fill in from old records
+	userSchemaBreaking = `{"type":"record","name":"User","fields":[{"name":"id","type":"int"},{"name":"age","type":"int"}]}`
+)
+
+func errorCode(err error) int {
+	var registryErr *Error
+	if errors.As(err, &registryErr) {
+		return registryErr.Code
+	}
+	return 0
+}
+
+func TestRegisterVersions(t *testing.T) {
+	var registered []string
+	registry := NewRegistry(newMemoryStorage(), func(subject string, s *Schema) {
+		registered = append(registered, subject)
+	})
+
+	id1, err := registry.Register("users-value", &Schema{Schema: userSchemaV1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same schema with different white spaces keeps its id
+	id, err := registry.Register("users-value", &Schema{Schema: strings.ReplaceAll(userSchemaV1, ",", ", ")})
+	if err != nil || id != id1 {
+		t.Fatalf("re-register: id %d err %v, expected id %d", id, err, id1)
+	}
+	id2, err := registry.Register("users-value", &Schema{Schema: userSchemaV2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id2 == id1 {
+		t.Errorf("new schema got the same id %d", id1)
+	}
+	// the same schema under another subject shares the id
+	if id, err = registry.Register("other-value", &Schema{Schema: userSchemaV1}); err != nil || id != id1 {
+		t.Errorf("other subject: id %d err %v, expected id %d", id, err, id1)
+	}
+
+	if _, err = registry.Register("users-value", &Schema{Schema: userSchemaBreaking}); errorCode(err) != ErrorCodeIncompatibleSchema {
+		t.Errorf("breaking change: expected incompatible, got %v", err)
+	}
+	if _, err = registry.Register("users-value", &Schema{Schema: "{not json"}); errorCode(err) != ErrorCodeInvalidSchema {
+		t.Errorf("invalid schema: expected invalid, got %v", err)
+	}
+
+	versions, err := registry.ListVersions("users-value", false)
+	if err != nil || len(versions) != 2 || versions[0] != 1 || versions[1] != 2 {
+		t.Errorf("versions %v err %v", versions, err)
+	}
+	v, s, err := registry.GetVersion("users-value", "latest", false)
+	if err != nil || v.Version != 2 || s.Id != id2 {
+		t.Errorf("latest %+v %+v err %v", v, s, err)
+	}
+	if v, _, err = registry.Lookup("users-value", &Schema{Schema: userSchemaV1}, false); err != nil || v.Version != 1 {
+		t.Errorf("lookup %+v err %v", v, err)
+	}
+	schemaVersions, err := registry.GetSchemaVersions(id1)
+	if err != nil || len(schemaVersions) != 2 {
+		t.Errorf("schema versions %+v err %v", schemaVersions, err)
+	}
+	if len(registered) != 3 {
+		t.Errorf("registered callbacks %v", registered)
+	}
+}
+
+func TestCompatibilityLevels(t *testing.T) {
+	registry := NewRegistry(newMemoryStorage(), nil)
+	if _, err := registry.Register("users-value", &Schema{Schema: userSchemaV1}); err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := registry.CheckCompatibility("users-value", "latest", &Schema{Schema: userSchemaBreaking})
+	if err != nil || len(messages) == 0 {
+		t.Errorf("check breaking: %v %v", messages, err)
+	}
+
+	if _, err = registry.SetCompatibilityLevel("users-value", "none"); err != nil {
+		t.Fatal(err)
+	}
+	if level, _ := registry.GetCompatibilityLevel("users-value", false); level != "NONE" {
+		t.Errorf("subject level %s", level)
+	}
+	if level, _ := registry.GetCompatibilityLevel("", false); level != DefaultCompatibilityLevel {
+		t.Errorf("global level %s", level)
+	}
+	if _, err = registry.Register("users-value", &Schema{Schema: userSchemaBreaking}); err != nil {
+		t.Errorf("register with NONE: %v", err)
+	}
+	if _, err = registry.SetCompatibilityLevel("", "sometimes"); errorCode(err) != ErrorCodeInvalidCompatibilityLevel {
+		t.Errorf("invalid level: %v", err)
+	}
+
+	if _, err = registry.DeleteCompatibilityLevel("users-value"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = registry.GetCompatibilityLevel("users-value", false); errorCode(err) != ErrorCodeSubjectLevelConfigNotFound {
+		t.Errorf("deleted subject level: %v", err)
+	}
+}
+
+func TestDeleteSubject(t *testing.T) {
+	registry := NewRegistry(newMemoryStorage(), nil)
+	for _, s := range []string{userSchemaV1, userSchemaV2} {
+		if _, err := registry.Register("users-value", &Schema{Schema: s}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := registry.DeleteSubject("users-value", true); errorCode(err) != ErrorCodeSubjectNotSoftDeleted {
+		t.Errorf("permanent delete before soft delete: %v", err)
+	}
+	if version, err := registry.DeleteVersion("users-value", "1", false); err != nil || version != 1 {
+		t.Errorf("delete version: %d %v", version, err)
+	}
+	if versions, _ := registry.ListVersions("users-value", false); len(versions) != 1 || versions[0] != 2 {
+		t.Errorf("versions after delete %v", versions)
+	}
+	if versions, _ := registry.ListVersions("users-value", true); len(versions) != 2 {
+		t.Errorf("versions including deleted %v", versions)
+	}
+
+	if deleted, err := registry.DeleteSubject("users-value", false); err != nil || len(deleted) != 1 {
+		t.Errorf("soft delete: %v %v", deleted, err)
+	}
+	if subjects, _ := registry.ListSubjects(false); len(subjects) != 0 {
+		t.Errorf("subjects after soft delete %v", subjects)
+	}
+	if deleted, err := registry.DeleteSubject("users-value", true); err != nil || len(deleted) != 2 {
+		t.Errorf("permanent delete: %v %v", deleted, err)
+	}
+	if subjects, _ := registry.ListSubjects(true); len(subjects) != 0 {
+		t.Errorf("subjects after permanent delete %v", subjects)
+	}
+
+	// versions continue after a soft deleted subject is registered again
+	registry2 := NewRegistry(newMemoryStorage(), nil)
+	registry2.Register("s", &Schema{Schema: userSchemaV1})
+	registry2.DeleteSubject("s", false)
+	registry2.Register("s", &Schema{Schema: userSchemaV2})
+	if versions, _ := registry2.ListVersions("s", false); len(versions) != 1 || versions[0] != 2 {
+		t.Errorf("versions after re-register %v", versions)
+	}
+}
+
+func TestServer(t *testing.T) {
+	server := httptest.NewServer(NewServer(NewRegistry(newMemoryStorage(), nil)).NewRouter())
+	defer server.Close()
+
+	request := func(method, path, body string, response interface{}) int {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if response != nil {
+			if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+	schemaBody := func(s string) string {
+		data, _ := json.Marshal(map[string]string{"schema": s})
+		return string(data)
+	}
+
+	registered := struct{ Id uint32 }{}
+	if status := request("POST", "/subjects/my%2Ftopic-value/versions", schemaBody(userSchemaV1), &registered); status != http.StatusOK || registered.Id == 0 {
+		t.Fatalf("register: status %d id %d", status, registered.Id)
+	}
+
+	var subjects []string
+	request("GET", "/subjects", "", &subjects)
+	if len(subjects) != 1 || subjects[0] != "my/topic-value" {
+		t.Errorf("subjects %v", subjects)
+	}
+
+	version := struct {
+		Subject string
+		Version int
+		Id      uint32
+		Schema  string
+	}{}
+	if status := request("GET", "/subjects/my%2Ftopic-value/versions/latest", "", &version); status != http.StatusOK || version.Version != 1 || version.Schema != userSchemaV1 {
+		t.Errorf("latest: status %d %+v", status, version)
+	}
+
+	compatibility := struct {
+		IsCompatible bool `json:"is_compatible"`
+	}{}
+	request("POST", "/compatibility/subjects/my%2Ftopic-value/versions/latest", schemaBody(userSchemaBreaking), &compatibility)
+	if compatibility.IsCompatible {
+		t.Errorf("breaking schema reported as compatible")
+	}
+
+	registryErr := &Error{}
+	if status := request("POST", "/subjects/my%2Ftopic-value/versions", schemaBody(userSchemaBreaking), registryErr); status != http.StatusConflict || registryErr.Code != ErrorCodeIncompatibleSchema {
+		t.Errorf("incompatible: status %d %+v", status, registryErr)
+	}
+	if status := request("GET", "/schemas/ids/999", "", registryErr); status != http.StatusNotFound || registryErr.Code != ErrorCodeSchemaNotFound {
+		t.Errorf("missing schema: status %d %+v", status, registryErr)
+	}
+
+	config := &Config{}
+	request("PUT", "/config", `{"compatibility":"FULL"}`, nil)
+	if request("GET", "/config", "", config); config.CompatibilityLevel != "FULL" {
+		t.Errorf("global config %+v", config)
+	}
+}
@@ -0,0 +1,428 @@
+package schema_registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// maxRequestSize limits the size of a registered schema.
+const maxRequestSize = 8 << 20
+
+// Server serves the REST API of the Confluent Schema Registry, so that Kafka clients and
+// their serializers can use the registry unchanged.
+type Server struct {
+	registry *Registry
+}
+
+func NewServer(registry *Registry) *Server {
+	return &Server{registry: registry}
+}
+
+// NewRouter returns a router serving the registry API. Subjects are percent encoded in the paths.
+func (s *Server) NewRouter() *mux.Router {
+	router := mux.NewRouter().UseEncodedPath()
+	s.RegisterRoutes(router)
+	return router
+}
+
+func (s *Server) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/", s.handleRoot).Methods(http.MethodGet)
+	router.HandleFunc("/schemas/types", s.handleSchemaTypes).Methods(http.MethodGet)
+	router.HandleFunc("/schemas/ids/{id}", s.handleGetSchema).Methods(http.MethodGet)
+	router.HandleFunc("/schemas/ids/{id}/schema", s.handleGetSchemaText).Methods(http.MethodGet)
+	router.HandleFunc("/schemas/ids/{id}/versions", s.handleGetSchemaVersions).Methods(http.MethodGet)
+	router.HandleFunc("/subjects", s.handleListSubjects).Methods(http.MethodGet)
+	router.HandleFunc("/subjects/{subject}", s.handleLookup).Methods(http.MethodPost)
+	router.HandleFunc("/subjects/{subject}", s.handleDeleteSubject).Methods(http.MethodDelete)
+	router.HandleFunc("/subjects/{subject}/versions", s.handleListVersions).Methods(http.MethodGet)
+	router.HandleFunc("/subjects/{subject}/versions", s.handleRegister).Methods(http.MethodPost)
+	router.HandleFunc("/subjects/{subject}/versions/{version}", s.handleGetVersion).Methods(http.MethodGet)
+	router.HandleFunc("/subjects/{subject}/versions/{version}", s.handleDeleteVersion).Methods(http.MethodDelete)
+	router.HandleFunc("/subjects/{subject}/versions/{version}/schema", s.handleGetVersionSchema).Methods(http.MethodGet)
+	router.HandleFunc("/compatibility/subjects/{subject}/versions/{version}", s.handleCheckCompatibility).Methods(http.MethodPost)
+	router.HandleFunc("/compatibility/subjects/{subject}/versions", s.handleCheckCompatibilityAll).Methods(http.MethodPost)
+	router.HandleFunc("/config", s.handleGetConfig).Methods(http.MethodGet)
+	router.HandleFunc("/config", s.handleSetConfig).Methods(http.MethodPut)
+	router.HandleFunc("/config/{subject}", s.handleGetConfig).Methods(http.MethodGet)
+	router.HandleFunc("/config/{subject}", s.handleSetConfig).Methods(http.MethodPut)
+	router.HandleFunc("/config/{subject}", s.handleDeleteConfig).Methods(http.MethodDelete)
+	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &Error{Code: http.StatusNotFound, Message: "HTTP 404 Not Found"})
+	})
+	router.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, &Error{Code: http.StatusMethodNotAllowed, Message: "HTTP 405 Method Not Allowed"})
+	})
+}
+
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, struct{}{})
+}
+
+func (s *Server) handleSchemaTypes(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, []string{SchemaTypeJSON, SchemaTypeProtobuf, SchemaTypeAvro})
+}
+
+func (s *Server) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	schema, err := s.schemaById(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response := struct {
+		SchemaType string            `json:"schemaType,omitempty"`
+		Schema     string            `json:"schema"`
+		References []SchemaReference `json:"references,omitempty"`
+	}{
+		Schema:     schema.Schema,
+		References: schema.References,
+	}
+	if schema.SchemaType != SchemaTypeAvro {
+		response.SchemaType = schema.SchemaType
+	}
+	writeResponse(w, &response)
+}
+
+func (s *Server) handleGetSchemaText(w http.ResponseWriter, r *http.Request) {
+	schema, err := s.schemaById(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeRawSchema(w, schema.Schema)
+}
+
+func (s *Server) handleGetSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	id, err := parseSchemaId(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versions, err := s.registry.GetSchemaVersions(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	type subjectVersion struct {
+		Subject string `json:"subject"`
+		Version int    `json:"version"`
+	}
+	response := make([]subjectVersion, 0, len(versions))
+	for _, v := range versions {
+		response = append(response, subjectVersion{Subject: v.Subject, Version: v.Version})
+	}
+	writeResponse(w, response)
+}
+
+func (s *Server) handleListSubjects(w http.ResponseWriter, r *http.Request) {
+	subjects, err := s.registry.ListSubjects(queryBool(r, "deleted"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, subjects)
+}
+
+func (s *Server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	subject, request, err := parseSchemaRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	version, schema, err := s.registry.Lookup(subject, request, queryBool(r, "deleted"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, versionResponse(version, schema))
+}
+
+func (s *Server) handleDeleteSubject(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versions, err := s.registry.DeleteSubject(subject, queryBool(r, "permanent"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, versions)
+}
+
+func (s *Server) handleListVersions(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versions, err := s.registry.ListVersions(subject, queryBool(r, "deleted"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, versions)
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	subject, request, err := parseSchemaRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	id, err := s.registry.Register(subject, request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, map[string]uint32{"id": id})
+}
+
+func (s *Server) handleGetVersion(w http.ResponseWriter, r *http.Request) {
+	version, schema, err := s.subjectVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, versionResponse(version, schema))
+}
+
+func (s *Server) handleGetVersionSchema(w http.ResponseWriter, r *http.Request) {
+	_, schema, err := s.subjectVersion(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeRawSchema(w, schema.Schema)
+}
+
+func (s *Server) handleDeleteVersion(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	version, err := s.registry.DeleteVersion(subject, mux.Vars(r)["version"], queryBool(r, "permanent"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, version)
+}
+
+func (s *Server) handleCheckCompatibility(w http.ResponseWriter, r *http.Request) {
+	s.checkCompatibility(w, r, mux.Vars(r)["version"])
+}
+
+func (s *Server) handleCheckCompatibilityAll(w http.ResponseWriter, r *http.Request) {
+	s.checkCompatibility(w, r, "latest")
+}
+
+func (s *Server) checkCompatibility(w http.ResponseWriter, r *http.Request, version string) {
+	subject, request, err := parseSchemaRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	messages, err := s.registry.CheckCompatibility(subject, version, request)
+	var registryErr *Error
+	if errors.As(err, &registryErr) && (registryErr.Code == ErrorCodeSubjectNotFound || registryErr.Code == ErrorCodeVersionNotFound) && version == "latest" {
+		// nothing to be incompatible with
+		err, messages = nil, nil
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response := map[string]interface{}{"is_compatible": len(messages) == 0}
+	if queryBool(r, "verbose") {
+		if messages == nil {
+			messages = []string{}
+		}
+		response["messages"] = messages
+	}
+	writeResponse(w, response)
+}
+
+func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	level, err := s.registry.GetCompatibilityLevel(subject, queryBool(r, "defaultToGlobal"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, &Config{CompatibilityLevel: level})
+}
+
+func (s *Server) handleSetConfig(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	request := &struct {
+		Compatibility string `json:"compatibility"`
+	}{}
+	if err = readRequest(r, request); err != nil {
+		writeError(w, err)
+		return
+	}
+	level, err := s.registry.SetCompatibilityLevel(subject, request.Compatibility)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, map[string]string{"compatibility": level})
+}
+
+func (s *Server) handleDeleteConfig(w http.ResponseWriter, r *http.Request) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	level, err := s.registry.DeleteCompatibilityLevel(subject)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, &Config{CompatibilityLevel: level})
+}
+
+func (s *Server) schemaById(r *http.Request) (*Schema, error) {
+	id, err := parseSchemaId(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.registry.GetSchema(id)
+}
+
+func (s *Server) subjectVersion(r *http.Request) (*SubjectVersion, *Schema, error) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.registry.GetVersion(subject, mux.Vars(r)["version"], queryBool(r, "deleted"))
+}
+
+func versionResponse(version *SubjectVersion, schema *Schema) interface{} {
+	response := struct {
+		Subject    string            `json:"subject"`
+		Version    int               `json:"version"`
+		Id         uint32            `json:"id"`
+		SchemaType string            `json:"schemaType,omitempty"`
+		Schema     string            `json:"schema"`
+		References []SchemaReference `json:"references,omitempty"`
+	}{
+		Subject:    version.Subject,
+		Version:    version.Version,
+		Id:         schema.Id,
+		Schema:     schema.Schema,
+		References: schema.References,
+	}
+	if schema.SchemaType != SchemaTypeAvro {
+		response.SchemaType = schema.SchemaType
+	}
+	return &response
+}
+
+func parseSchemaId(r *http.Request) (uint32, error) {
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		return 0, &Error{Code: ErrorCodeSchemaNotFound, Message: fmt.Sprintf("Schema %s not found", mux.Vars(r)["id"])}
+	}
+	return uint32(id), nil
+}
+
+// pathSubject returns the decoded subject of the path, or empty if the route has none.
+func pathSubject(r *http.Request) (string, error) {
+	subject, err := url.PathUnescape(mux.Vars(r)["subject"])
+	if err != nil {
+		return "", &Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid subject: %v", err)}
+	}
+	return subject, nil
+}
+
+func parseSchemaRequest(r *http.Request) (string, *Schema, error) {
+	subject, err := pathSubject(r)
+	if err != nil {
+		return "", nil, err
+	}
+	request := &Schema{}
+	if err = readRequest(r, request); err != nil {
+		return "", nil, err
+	}
+	if request.Schema == "" {
+		return "", nil, &Error{Code: ErrorCodeInvalidSchema, Message: "Empty schema"}
+	}
+	return subject, request, nil
+}
+
+func readRequest(r *http.Request, request interface{}) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return &Error{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	if err = json.Unmarshal(data, request); err != nil {
+		return &Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid request: %v", err)}
+	}
+	return nil
+}
+
+func queryBool(r *http.Request, name string) bool {
+	value, _ := strconv.ParseBool(r.URL.Query().Get(name))
+	return value
+}
+
+func writeResponse(w http.ResponseWriter, response interface{}) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		writeError(w, storeError(err))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(data); err != nil {
+		glog.V(1).Infof("write schema registry response: %v", err)
+	}
+}
+
+func writeRawSchema(w http.ResponseWriter, schema string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, schema); err != nil {
+		glog.V(1).Infof("write schema registry response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var registryErr *Error
+	if !errors.As(err, &registryErr) {
+		registryErr = storeError(err)
+	}
+	status := registryErr.HTTPStatus()
+	if status >= http.StatusInternalServerError {
+		glog.Errorf("schema registry: %v", registryErr)
+	} else {
+		glog.V(1).Infof("schema registry: %v", registryErr)
+	}
+	data, _ := json.Marshal(registryErr)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,166 @@
+package schema_registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/filer_client"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+	"google.golang.org/grpc"
+)
+
+type ServiceOptions struct {
+	Listen     string
+	ClientHost string // the address registered to the masters
+	Masters    string
+	FilerGroup string
+	Namespace  string // the MQ namespace of the Kafka topics, empty to not update topic record types
+}
+
+// Service runs the registry with its state on the filers discovered from the masters.
+type Service struct {
+	opts           ServiceOptions
+	grpcDialOption grpc.DialOption
+	masterClient   *wdclient.MasterClient
+	registry       *Registry
+	httpServer     *http.Server
+	ln             net.Listener
+	ctx            context.Context
+	cancel         context.CancelFunc
+	done           chan error
+}
+
+func NewService(opts ServiceOptions) (*Service, error) {
+	if opts.Masters == "" {
+		return nil, fmt.Errorf("masters are required")
+	}
+	util.LoadSecurityConfiguration()
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.mq")
+	masterDiscovery := pb.ServerAddresses(opts.Masters).ToServiceDiscovery()
+	clientHost := opts.ClientHost
+	if clientHost == "" {
+		clientHost = opts.Listen
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		opts:           opts,
+		grpcDialOption: grpcDialOption,
+		masterClient:   wdclient.NewMasterClient(grpcDialOption, opts.FilerGroup, "schema-registry", pb.ServerAddress(clientHost), "", "", *masterDiscovery),
+		ctx:            ctx,
+		cancel:         cancel,
+		done:           make(chan error, 1),
+	}
+	go s.masterClient.KeepConnectedToMaster(ctx)
+
+	filers, err := s.discover(cluster.FilerType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("discover filers: %w", err)
+	}
+	if len(filers) == 0 {
+		cancel()
+		return nil, fmt.Errorf("no filers discovered from masters %s", opts.Masters)
+	}
+	var filerAddresses []pb.ServerAddress
+	for _, filer := range filers {
+		filerAddresses = append(filerAddresses, pb.ServerAddress(filer))
+	}
+	storage := NewFilerStorage(filer_client.NewFilerClientAccessor(filerAddresses, grpcDialOption))
+
+	var onRegistered OnRegisteredFn
+	if opts.Namespace != "" {
+		onRegistered = NewRecordTypeSyncer(opts.Namespace, s.withBrokerClient).OnRegistered
+	}
+	s.registry = NewRegistry(storage, onRegistered)
+	s.httpServer = &http.Server{
+		Handler:           NewServer(s.registry).NewRouter(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s, nil
+}
+
+func (s *Service) Start() error {
+	ln, err := net.Listen("tcp", s.opts.Listen)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	glog.V(0).Infof("schema registry listening on %s", ln.Addr())
+	go func() {
+		err := s.httpServer.Serve(ln)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		s.done <- err
+	}()
+	return nil
+}
+
+// Wait blocks until the service is closed.
+func (s *Service) Wait() error {
+	return <-s.done
+}
+
+func (s *Service) Close() error {
+	s.cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Addr returns the bound address of the service listener, or empty if not started.
+func (s *Service) Addr() string {
+	if s.ln == nil {
+		return ""
+	}
+	return s.ln.Addr().String()
+}
+
+func (s *Service) discover(clientType string) (addresses []string, err error) {
+	err = s.masterClient.WithClient(false, func(client master_pb.SeaweedClient) error {
+		resp, err := client.ListClusterNodes(context.Background(), &master_pb.ListClusterNodesRequest{
+			ClientType: clientType,
+			FilerGroup: s.opts.FilerGroup,
+			Limit:      1000,
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.ClusterNodes {
+			if node.Address != "" {
+				addresses = append(addresses, node.Address)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// withBrokerClient discovers the brokers on each call, since schemas are rarely registered.
+func (s *Service) withBrokerClient(fn func(client mq_pb.SeaweedMessagingClient) error) error {
+	brokers, err := s.discover(cluster.BrokerType)
+	if err != nil {
+		return fmt.Errorf("discover brokers: %w", err)
+	}
+	for _, broker := range brokers {
+		if err = pb.WithBrokerGrpcClient(false, broker, s.grpcDialOption, fn); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no brokers discovered from masters %s", s.opts.Masters)
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package schema_registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// StoreDir is where the registry keeps its state on the filer:
+//
+//	/etc/schema_registry/config                          the global compatibility level
+//	/etc/schema_registry/schemas/<id>                    the schemas by id
+//	/etc/schema_registry/fingerprints/<fingerprint>      the id of each distinct schema
+//	/etc/schema_registry/subjects/<subject>/<version>    the versions of each subject
+//	/etc/schema_registry/subjects/<subject>/config       the compatibility level of a subject
+const StoreDir = "/etc/schema_registry"
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrExists   = errors.New("already exists")
+)
+
+// Storage keeps small files in directories.
+type Storage interface {
+	ReadFile(dir, name string) ([]byte, error)
+	// WriteFile returns ErrExists if exclusive and the file exists.
+	WriteFile(dir, name string, data []byte, exclusive bool) error
+	DeleteFile(dir, name string) error
+	// ListNames lists the names of the files and directories in the directory.
+	ListNames(dir string) ([]string, error)
+}
+
+type FilerClient interface {
+	WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error
+}
+
+type FilerStorage struct {
+	filerClient FilerClient
+}
+
+func NewFilerStorage(filerClient FilerClient) *FilerStorage {
+	return &FilerStorage{filerClient: filerClient}
+}
+
+func (s *FilerStorage) ReadFile(dir, name string) (data []byte, err error) {
+	err = s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		data, err = filer.ReadInsideFiler(client, dir, name)
+		return err
+	})
+	if errors.Is(err, filer_pb.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return
+}
+
+func (s *FilerStorage) WriteFile(dir, name string, data []byte, exclusive bool) error {
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		if !exclusive {
+			return filer.SaveInsideFiler(client, dir, name, data)
+		}
+		now := time.Now().Unix()
+		err := filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: dir,
+			Entry: &filer_pb.Entry{
+				Name: name,
+				Attributes: &filer_pb.FuseAttributes{
+					Mtime:    now,
+					Crtime:   now,
+					FileMode: uint32(0644),
+					FileSize: uint64(len(data)),
+				},
+				Content: data,
+			},
+			OExcl: true,
+		})
+		if err != nil {
+			if _, lookupErr := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+				Directory: dir,
+				Name:      name,
+			}); lookupErr == nil {
+				return ErrExists
+			}
+			return fmt.Errorf("create %s/%s: %w", dir, name, err)
+		}
+		return nil
+	})
+}
+
+func (s *FilerStorage) DeleteFile(dir, name string) error {
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		err := filer_pb.DoRemove(context.Background(), client, dir, name, true, true, true, false, nil)
+		if err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *FilerStorage) ListNames(dir string) (names []string, err error) {
+	err = s.filerClient.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.SeaweedList(context.Background(), client, dir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			names = append(names, entry.Name)
+			return nil
+		}, "", false, 0)
+	})
+	if errors.Is(err, filer_pb.ErrNotFound) {
+		return nil, nil
+	}
+	return
+}
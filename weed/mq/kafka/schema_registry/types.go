@@ -0,0 +1,107 @@
+package schema_registry
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/kafka/schema"
+)
+
+// Schema types, as named by the Confluent Schema Registry API. An empty type is Avro.
+const (
+	SchemaTypeAvro     = "AVRO"
+	SchemaTypeProtobuf = "PROTOBUF"
+	SchemaTypeJSON     = "JSON"
+)
+
+type SchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Schema is a registered schema. The same schema registered under several subjects has one id.
+type Schema struct {
+	Id         uint32            `json:"id"`
+	SchemaType string            `json:"schemaType,omitempty"`
+	Schema     string            `json:"schema"`
+	References []SchemaReference `json:"references,omitempty"`
+}
+
+// SubjectVersion is one version of a subject.
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+	Id      uint32 `json:"id"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+type Config struct {
+	CompatibilityLevel string `json:"compatibilityLevel"`
+}
+
+func normalizeSchemaType(schemaType string) (string, error) {
+	switch strings.ToUpper(schemaType) {
+	case "", SchemaTypeAvro:
+		return SchemaTypeAvro, nil
+	case SchemaTypeProtobuf:
+		return SchemaTypeProtobuf, nil
+	case SchemaTypeJSON:
+		return SchemaTypeJSON, nil
+	default:
+		return "", &Error{Code: ErrorCodeInvalidSchema, Message: fmt.Sprintf("unsupported schema type %q", schemaType)}
+	}
+}
+
+func schemaFormat(schemaType string) schema.Format {
+	switch schemaType {
+	case SchemaTypeProtobuf:
+		return schema.FormatProtobuf
+	case SchemaTypeJSON:
+		return schema.FormatJSONSchema
+	default:
+		return schema.FormatAvro
+	}
+}
+
+// Error codes of the Confluent Schema Registry API. The HTTP status is the first three digits.
+const (
+	ErrorCodeSubjectNotFound            = 40401
+	ErrorCodeVersionNotFound            = 40402
+	ErrorCodeSchemaNotFound             = 40403
+	ErrorCodeSubjectSoftDeleted         = 40404
+	ErrorCodeSubjectNotSoftDeleted      = 40405
+	ErrorCodeVersionSoftDeleted         = 40406
+	ErrorCodeVersionNotSoftDeleted      = 40407
+	ErrorCodeSubjectLevelConfigNotFound = 40408
+	ErrorCodeIncompatibleSchema         = 409
+	ErrorCodeInvalidSchema              = 42201
+	ErrorCodeInvalidVersion             = 42202
+	ErrorCodeInvalidCompatibilityLevel  = 42203
+	ErrorCodeStoreError                 = 50001
+)
+
+type Error struct {
+	Code    int    `json:"error_code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) HTTPStatus() int {
+	status := e.Code
+	for status >= 1000 {
+		status /= 10
+	}
+	if status < 100 || status > 599 {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+func storeError(err error) *Error {
+	return &Error{Code: ErrorCodeStoreError, Message: err.Error()}
+}
@@ -0,0 +1,496 @@
+package logstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+	"google.golang.org/protobuf/proto"
+)
+
+// Key-based log compaction, similar to Kafka "cleanup.policy=compact".
+//
+// For each partition, the log files and parquet files older than the minimum
+// compaction lag are scanned to find the latest message of each key. Then the files
+// are rewritten without the older messages of the same key. Tombstones, i.e.
+// messages with a key but without a value, are kept for the delete retention period
+// so that consumers can see the deletion, and are dropped afterwards.
+//
+// Messages keep their timestamps and offsets, so offsets of compacted topics have gaps.
+// Messages without a key are never compacted away.
+
+// KeyCompactionOption controls which messages are dropped by CompactTopicByKey.
+type KeyCompactionOption struct {
+	DeleteRetention  time.Duration // how long tombstones are kept
+	MinCompactionLag time.Duration // files modified more recently are not compacted
+	Retention        time.Duration // drop any message older than this, 0 to disable
+	Preference       *operation.StoragePreference
+}
+
+// KeyCompactionStats counts what one compaction run did.
+type KeyCompactionStats struct {
+	FilesScanned    int
+	FilesRewritten  int
+	MessagesScanned int64
+	MessagesDropped int64
+}
+
+func (s *KeyCompactionStats) add(other *KeyCompactionStats) {
+	s.FilesScanned += other.FilesScanned
+	s.FilesRewritten += other.FilesRewritten
+	s.MessagesScanned += other.MessagesScanned
+	s.MessagesDropped += other.MessagesDropped
+}
+
+// messagePosition orders the messages of a partition.
+type messagePosition struct {
+	tsNs   int64
+	offset int64
+}
+
+func (p messagePosition) isAfter(other messagePosition) bool {
+	if p.offset > 0 && other.offset > 0 {
+		return p.offset > other.offset
+	}
+	return p.tsNs > other.tsNs
+}
+
+// isSame also matches rows of schemaless parquet files, which have no offset.
+func (p messagePosition) isSame(other messagePosition) bool {
+	if p.offset > 0 && other.offset > 0 {
+		return p.offset == other.offset
+	}
+	return p.tsNs == other.tsNs
+}
+
+// keyCompactor decides which messages of one partition to keep.
+type keyCompactor struct {
+	latest          map[string]messagePosition
+	tombstones      map[string]bool
+	tombstoneCutoff int64
+	retentionCutoff int64
+}
+
+func newKeyCompactor(option *KeyCompactionOption, now time.Time) *keyCompactor {
+	c := &keyCompactor{
+		latest:          make(map[string]messagePosition),
+		tombstones:      make(map[string]bool),
+		tombstoneCutoff: now.Add(-option.DeleteRetention).UnixNano(),
+	}
+	if option.Retention > 0 {
+		c.retentionCutoff = now.Add(-option.Retention).UnixNano()
+	}
+	return c
+}
+
+func (c *keyCompactor) observe(key []byte, pos messagePosition, isTombstone bool) {
+	if len(key) == 0 {
+		return
+	}
+	if existing, found := c.latest[string(key)]; found && !pos.isAfter(existing) {
+		return
+	}
+	c.latest[string(key)] = pos
+	if isTombstone {
+		c.tombstones[string(key)] = true
+	} else {
+		delete(c.tombstones, string(key))
+	}
+}
+
+func (c *keyCompactor) keep(key []byte, pos messagePosition) bool {
+	if c.retentionCutoff > 0 && pos.tsNs < c.retentionCutoff {
+		return false
+	}
+	if len(key) == 0 {
+		return true
+	}
+	latest, found := c.latest[string(key)]
+	if !found {
+		return true
+	}
+	if !latest.isSame(pos) {
+		return false
+	}
+	if c.tombstones[string(key)] && pos.tsNs < c.tombstoneCutoff {
+		return false
+	}
+	return true
+}
+
+// CompactTopicByKey compacts all partitions of all versions of a topic.
+func CompactTopicByKey(filerClient filer_pb.FilerClient, t topic.Topic, option *KeyCompactionOption) (stats *KeyCompactionStats, err error) {
+	stats = &KeyCompactionStats{}
+	topicVersions, err := collectTopicVersions(filerClient, t, 0)
+	if err != nil {
+		return stats, fmt.Errorf("list topic versions: %w", err)
+	}
+	for _, topicVersion := range topicVersions {
+		partitions, err := collectTopicVersionsPartitions(filerClient, t, topicVersion)
+		if err != nil {
+			return stats, fmt.Errorf("list partitions %s/%s: %v", t, topicVersion, err)
+		}
+		for _, partition := range partitions {
+			partitionDir := topic.PartitionDir(t, partition)
+			partitionStats, err := compactPartitionDirByKey(filerClient, partitionDir, option, time.Now())
+			stats.add(partitionStats)
+			if err != nil {
+				return stats, fmt.Errorf("compact partition %s: %w", partitionDir, err)
+			}
+		}
+	}
+	return stats, nil
+}
+
+func compactPartitionDirByKey(filerClient filer_pb.FilerClient, partitionDir string, option *KeyCompactionOption, now time.Time) (stats *KeyCompactionStats, err error) {
+	stats = &KeyCompactionStats{}
+
+	var logFiles, parquetFiles []*filer_pb.Entry
+	lagCutoff := now.Add(-option.MinCompactionLag).Unix()
+	err = filer_pb.ReadDirAllEntries(context.Background(), filerClient, util.FullPath(partitionDir), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if entry.IsDirectory || len(entry.Content) > 0 || entry.Attributes == nil || entry.Attributes.Mtime > lagCutoff {
+			return nil
+		}
+		if filer.HasChunkManifest(entry.Chunks) {
+			return nil
+		}
+		if strings.HasSuffix(entry.Name, ".parquet") {
+			parquetFiles = append(parquetFiles, entry)
+			return nil
+		}
+		if _, parseErr := time.Parse(topic.TIME_FORMAT, entry.Name); parseErr == nil {
+			logFiles = append(logFiles, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+	if len(logFiles) == 0 && len(parquetFiles) == 0 {
+		return stats, nil
+	}
+
+	lookupFileIdFn := filer.LookupFn(filerClient)
+	compactor := newKeyCompactor(option, now)
+
+	// find the latest message of each key
+	for _, logFile := range logFiles {
+		for _, chunk := range logFile.Chunks {
+			err = eachLogChunkEntry(lookupFileIdFn, chunk, func(logEntry *filer_pb.LogEntry, raw []byte) error {
+				compactor.observe(logEntry.Key, messagePosition{logEntry.TsNs, logEntry.Offset}, len(logEntry.Data) == 0)
+				return nil
+			})
+			if err != nil {
+				return stats, fmt.Errorf("scan %s: %w", logFile.Name, err)
+			}
+		}
+	}
+	for _, parquetFile := range parquetFiles {
+		err = eachParquetRow(lookupFileIdFn, parquetFile, func(schema *parquet.Schema, row parquet.Row, key []byte, pos messagePosition) error {
+			compactor.observe(key, pos, false)
+			return nil
+		})
+		if err != nil {
+			return stats, fmt.Errorf("scan %s: %w", parquetFile.Name, err)
+		}
+	}
+
+	// drop the older messages
+	for _, logFile := range logFiles {
+		fileStats, err := compactLogFile(filerClient, lookupFileIdFn, partitionDir, logFile, compactor, option.Preference)
+		stats.add(fileStats)
+		if err != nil {
+			return stats, fmt.Errorf("compact %s: %w", logFile.Name, err)
+		}
+	}
+	for _, parquetFile := range parquetFiles {
+		fileStats, err := compactParquetFile(filerClient, lookupFileIdFn, partitionDir, parquetFile, compactor, option.Preference)
+		stats.add(fileStats)
+		if err != nil {
+			return stats, fmt.Errorf("compact %s: %w", parquetFile.Name, err)
+		}
+	}
+
+	return stats, nil
+}
+
+// compactLogFile rewrites the chunks of a log file that contain dropped messages.
+// Chunks without dropped messages are kept as they are.
+func compactLogFile(filerClient filer_pb.FilerClient, lookupFileIdFn wdclient.LookupFileIdFunctionType, partitionDir string, logFile *filer_pb.Entry, compactor *keyCompactor, preference *operation.StoragePreference) (stats *KeyCompactionStats, err error) {
+	stats = &KeyCompactionStats{FilesScanned: 1}
+
+	var newChunks []*filer_pb.FileChunk
+	var fileSize int64
+	changed := false
+	for _, chunk := range logFile.Chunks {
+		var kept bytes.Buffer
+		dropped := int64(0)
+		err = eachLogChunkEntry(lookupFileIdFn, chunk, func(logEntry *filer_pb.LogEntry, raw []byte) error {
+			stats.MessagesScanned++
+			if compactor.keep(logEntry.Key, messagePosition{logEntry.TsNs, logEntry.Offset}) {
+				kept.Write(raw)
+			} else {
+				dropped++
+			}
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+		stats.MessagesDropped += dropped
+
+		if dropped == 0 {
+			newChunk := proto.Clone(chunk).(*filer_pb.FileChunk)
+			newChunk.Offset = fileSize
+			newChunks = append(newChunks, newChunk)
+			fileSize += int64(chunk.Size)
+			continue
+		}
+		changed = true
+		if kept.Len() == 0 {
+			continue
+		}
+		newChunk, uploadErr := uploadLogChunk(filerClient, partitionDir+"/"+logFile.Name, kept.Bytes(), fileSize, preference)
+		if uploadErr != nil {
+			return stats, uploadErr
+		}
+		newChunks = append(newChunks, newChunk)
+		fileSize += int64(kept.Len())
+	}
+	if !changed {
+		return stats, nil
+	}
+
+	logFile.Chunks = newChunks
+	logFile.Attributes.FileSize = uint64(fileSize)
+	logFile.Attributes.Mtime = time.Now().Unix()
+	if err = updatePartitionFile(filerClient, partitionDir, logFile); err != nil {
+		return stats, err
+	}
+	stats.FilesRewritten++
+	return stats, nil
+}
+
+// compactParquetFile rewrites a parquet file without the dropped rows.
+// The extended attributes, e.g. the timestamp range and the source log files, are kept.
+func compactParquetFile(filerClient filer_pb.FilerClient, lookupFileIdFn wdclient.LookupFileIdFunctionType, partitionDir string, parquetFile *filer_pb.Entry, compactor *keyCompactor, preference *operation.StoragePreference) (stats *KeyCompactionStats, err error) {
+	stats = &KeyCompactionStats{FilesScanned: 1}
+
+	var keptRows []parquet.Row
+	var fileSchema *parquet.Schema
+	err = eachParquetRow(lookupFileIdFn, parquetFile, func(schema *parquet.Schema, row parquet.Row, key []byte, pos messagePosition) error {
+		fileSchema = schema
+		stats.MessagesScanned++
+		if compactor.keep(key, pos) {
+			keptRows = append(keptRows, row.Clone())
+		} else {
+			stats.MessagesDropped++
+		}
+		return nil
+	})
+	if err != nil || stats.MessagesDropped == 0 {
+		return stats, err
+	}
+
+	tempFile, err := os.CreateTemp(".", "t*.parquet")
+	if err != nil {
+		return stats, fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+
+	writer := parquet.NewWriter(tempFile, fileSchema,
+		parquet.Compression(&zstd.Codec{Level: zstd.DefaultLevel}),
+		parquet.DataPageStatistics(true),
+	)
+	if len(keptRows) > 0 {
+		if _, err = writer.WriteRows(keptRows); err != nil {
+			return stats, fmt.Errorf("write rows: %w", err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return stats, fmt.Errorf("close writer: %w", err)
+	}
+	fileInfo, err := tempFile.Stat()
+	if err != nil {
+		return stats, fmt.Errorf("stat temp file: %w", err)
+	}
+
+	if parquetFile.Chunks, err = uploadParquetFile(filerClient, tempFile, fileInfo.Size(), partitionDir, parquetFile.Name, preference); err != nil {
+		return stats, err
+	}
+	parquetFile.Attributes.FileSize = uint64(fileInfo.Size())
+	parquetFile.Attributes.Mtime = time.Now().Unix()
+	if err = updatePartitionFile(filerClient, partitionDir, parquetFile); err != nil {
+		return stats, err
+	}
+	stats.FilesRewritten++
+	return stats, nil
+}
+
+func updatePartitionFile(filerClient filer_pb.FilerClient, partitionDir string, entry *filer_pb.Entry) error {
+	// UpdateEntry also deletes the chunks that are not used any more
+	return filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(context.Background(), client, &filer_pb.UpdateEntryRequest{
+			Directory: partitionDir,
+			Entry:     entry,
+		})
+	})
+}
+
+func uploadLogChunk(filerClient filer_pb.FilerClient, targetFile string, data []byte, offset int64, preference *operation.StoragePreference) (*filer_pb.FileChunk, error) {
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return nil, fmt.Errorf("new uploader: %w", err)
+	}
+	fileId, uploadResult, err, _ := uploader.UploadWithRetry(
+		filerClient,
+		&filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: preference.Replication,
+			Collection:  preference.Collection,
+			DataCenter:  preference.DataCenter,
+			DiskType:    preference.DiskType,
+			Path:        targetFile,
+		},
+		&operation.UploadOption{},
+		func(host, fileId string) string {
+			return fmt.Sprintf("http://%s/%s", host, fileId)
+		},
+		util.NewBytesReader(data),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upload chunk: %w", err)
+	}
+	if uploadResult.Error != "" {
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+	return uploadResult.ToPbFileChunk(fileId, offset, time.Now().UnixNano()), nil
+}
+
+// eachLogChunkEntry reads one chunk of a log file, and calls fn with each log entry
+// and its raw bytes, including the size prefix.
+func eachLogChunkEntry(lookupFileIdFn wdclient.LookupFileIdFunctionType, chunk *filer_pb.FileChunk, fn func(logEntry *filer_pb.LogEntry, raw []byte) error) error {
+	if chunk.Size == 0 {
+		return nil
+	}
+	data, err := readChunkData(lookupFileIdFn, chunk)
+	if err != nil {
+		return err
+	}
+	for pos := 0; pos+4 < len(data); {
+		size := int(util.BytesToUint32(data[pos : pos+4]))
+		if pos+4+size > len(data) {
+			return fmt.Errorf("read [%d,%d) from [0,%d) of %s", pos, pos+4+size, len(data), chunk.FileId)
+		}
+		logEntry := &filer_pb.LogEntry{}
+		if err = proto.Unmarshal(data[pos+4:pos+4+size], logEntry); err != nil {
+			return fmt.Errorf("unmarshal log entry in %s: %w", chunk.FileId, err)
+		}
+		if err = fn(logEntry, data[pos:pos+4+size]); err != nil {
+			return err
+		}
+		pos += 4 + size
+	}
+	return nil
+}
+
+func readChunkData(lookupFileIdFn wdclient.LookupFileIdFunctionType, chunk *filer_pb.FileChunk) (data []byte, err error) {
+	urlStrings, err := lookupFileIdFn(context.Background(), chunk.FileId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: %v", chunk.FileId, err)
+	}
+	if len(urlStrings) == 0 {
+		return nil, fmt.Errorf("no url found for %s", chunk.FileId)
+	}
+	for _, urlString := range urlStrings {
+		if data, _, err = util_http.Get(urlString); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("read %s: %w", chunk.FileId, err)
+}
+
+// eachParquetRow reads a parquet file, and calls fn with each row and its key and position.
+func eachParquetRow(lookupFileIdFn wdclient.LookupFileIdFunctionType, entry *filer_pb.Entry, fn func(schema *parquet.Schema, row parquet.Row, key []byte, pos messagePosition) error) error {
+	fileSize := filer.FileSize(entry)
+	visibleIntervals, _ := filer.NonOverlappingVisibleIntervals(context.Background(), lookupFileIdFn, entry.Chunks, 0, int64(fileSize))
+	chunkViews := filer.ViewFromVisibleIntervals(visibleIntervals, 0, int64(fileSize))
+	readerCache := filer.NewReaderCache(32, chunkCache, lookupFileIdFn)
+	readerAt := filer.NewChunkReaderAtFromClient(context.Background(), readerCache, chunkViews, int64(fileSize), filer.DefaultPrefetchCount)
+
+	parquetReader := parquet.NewReader(readerAt)
+	defer parquetReader.Close()
+	schema := parquetReader.Schema()
+	keyColumn, tsColumn, offsetColumn := -1, -1, -1
+	if leaf, found := schema.Lookup(SW_COLUMN_NAME_KEY); found {
+		keyColumn = leaf.ColumnIndex
+	}
+	if leaf, found := schema.Lookup(SW_COLUMN_NAME_TS); found {
+		tsColumn = leaf.ColumnIndex
+	}
+	if leaf, found := schema.Lookup(SW_COLUMN_NAME_OFFSET); found {
+		offsetColumn = leaf.ColumnIndex
+	}
+	if keyColumn < 0 || tsColumn < 0 {
+		return fmt.Errorf("missing %s or %s column", SW_COLUMN_NAME_KEY, SW_COLUMN_NAME_TS)
+	}
+
+	rows := make([]parquet.Row, 128)
+	for {
+		rowCount, readErr := parquetReader.ReadRows(rows)
+		for i := 0; i < rowCount; i++ {
+			var key []byte
+			var pos messagePosition
+			for _, value := range rows[i] {
+				switch value.Column() {
+				case keyColumn:
+					key = value.ByteArray()
+				case tsColumn:
+					pos.tsNs = value.Int64()
+				case offsetColumn:
+					pos.offset = value.Int64()
+				}
+			}
+			if err := fn(schema, rows[i], key, pos); err != nil {
+				return err
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return nil
+			}
+			return readErr
+		}
+		if rowCount == 0 {
+			return nil
+		}
+	}
+}
+
+// LogKeyCompactionStats logs the result of a compaction run.
+func LogKeyCompactionStats(t topic.Topic, stats *KeyCompactionStats, err error) {
+	if err != nil {
+		glog.Errorf("compact topic %s by key: %v", t, err)
+	}
+	if stats != nil && stats.MessagesDropped > 0 {
+		glog.V(0).Infof("compacted topic %s by key: dropped %d of %d messages, rewrote %d of %d files",
+			t, stats.MessagesDropped, stats.MessagesScanned, stats.FilesRewritten, stats.FilesScanned)
+	}
+}
@@ -0,0 +1,62 @@
+package logstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyCompactorKeepsLatestPerKey(t *testing.T) {
+	now := time.Now()
+	c := newKeyCompactor(&KeyCompactionOption{DeleteRetention: time.Hour}, now)
+
+	ts := func(ago time.Duration) int64 { return now.Add(-ago).UnixNano() }
+	messages := []struct {
+		key       string
+		pos       messagePosition
+		tombstone bool
+		keep      bool
+	}{
+		{"a", messagePosition{ts(5 * time.Hour), 1}, false, false},
+		{"b", messagePosition{ts(4 * time.Hour), 2}, false, false},
+		{"", messagePosition{ts(4 * time.Hour), 3}, false, true},
+		{"a", messagePosition{ts(3 * time.Hour), 4}, false, true},
+		{"b", messagePosition{ts(2 * time.Hour), 5}, true, false}, // tombstone older than the delete retention
+		{"c", messagePosition{ts(2 * time.Hour), 6}, false, false},
+		{"c", messagePosition{ts(time.Minute), 7}, true, true}, // recent tombstone
+	}
+	for _, m := range messages {
+		c.observe([]byte(m.key), m.pos, m.tombstone)
+	}
+	for _, m := range messages {
+		if got := c.keep([]byte(m.key), m.pos); got != m.keep {
+			t.Errorf("key %q offset %d: keep %v, expected %v", m.key, m.pos.offset, got, m.keep)
+		}
+	}
+}
+
+func TestKeyCompactorRetention(t *testing.T) {
+	now := time.Now()
+	c := newKeyCompactor(&KeyCompactionOption{DeleteRetention: time.Hour, Retention: 2 * time.Hour}, now)
+
+	old := messagePosition{tsNs: now.Add(-3 * time.Hour).UnixNano(), offset: 1}
+	recent := messagePosition{tsNs: now.Add(-time.Hour).UnixNano(), offset: 2}
+	c.observe([]byte("a"), old, false)
+	c.observe(nil, recent, false)
+
+	if c.keep([]byte("a"), old) {
+		t.Errorf("message older than the retention should be dropped")
+	}
+	if !c.keep(nil, recent) {
+		t.Errorf("recent message without key should be kept")
+	}
+}
+
+func TestMessagePositionWithoutOffset(t *testing.T) {
+	p := messagePosition{tsNs: 100}
+	if !p.isSame(messagePosition{tsNs: 100, offset: 7}) {
+		t.Errorf("rows without offset should match by timestamp")
+	}
+	if !(messagePosition{tsNs: 200}).isAfter(p) {
+		t.Errorf("later timestamp should be after")
+	}
+}
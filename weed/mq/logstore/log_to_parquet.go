@@ -405,20 +405,12 @@ func writeLogFilesToParquet(filerClient filer_pb.FilerClient, partitionDir strin
 }
 
 func saveParquetFileToPartitionDir(filerClient filer_pb.FilerClient, sourceFile *os.File, partitionDir, parquetFileName string, preference *operation.StoragePreference, startTsNs, stopTsNs int64, sourceLogFiles []string, earliestBufferStart int64, minOffset, maxOffset int64, hasOffsets bool) error {
-	uploader, err := operation.NewUploader()
-	if err != nil {
-		return fmt.Errorf("new uploader: %w", err)
-	}
-
 	// get file size
 	fileInfo, err := sourceFile.Stat()
 	if err != nil {
 		return fmt.Errorf("stat source file: %w", err)
 	}
 
-	// upload file in chunks
-	chunkSize := int64(4 * 1024 * 1024)
-	chunkCount := (fileInfo.Size() + chunkSize - 1) / chunkSize
 	entry := &filer_pb.Entry{
 		Name: parquetFileName,
 		Attributes: &filer_pb.FuseAttributes{
@@ -461,6 +453,33 @@ func saveParquetFileToPartitionDir(filerClient filer_pb.FilerClient, sourceFile
 		entry.Extended[mq.ExtendedAttrBufferStart] = bufferStartBytes
 	}
 
+	if entry.Chunks, err = uploadParquetFile(filerClient, sourceFile, fileInfo.Size(), partitionDir, parquetFileName, preference); err != nil {
+		return err
+	}
+
+	// write the entry to partitionDir
+	if err := filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: partitionDir,
+			Entry:     entry,
+		})
+	}); err != nil {
+		return fmt.Errorf("create entry: %w", err)
+	}
+
+	return nil
+}
+
+// uploadParquetFile uploads a local parquet file in chunks, and returns the chunks.
+func uploadParquetFile(filerClient filer_pb.FilerClient, sourceFile *os.File, fileSize int64, partitionDir, parquetFileName string, preference *operation.StoragePreference) (chunks []*filer_pb.FileChunk, err error) {
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return nil, fmt.Errorf("new uploader: %w", err)
+	}
+
+	// upload file in chunks
+	chunkSize := int64(4 * 1024 * 1024)
+	chunkCount := (fileSize + chunkSize - 1) / chunkSize
 	for i := int64(0); i < chunkCount; i++ {
 		fileId, uploadResult, err, _ := uploader.UploadWithRetry(
 			filerClient,
@@ -485,25 +504,14 @@ func saveParquetFileToPartitionDir(filerClient filer_pb.FilerClient, sourceFile
 			io.NewSectionReader(sourceFile, i*chunkSize, chunkSize),
 		)
 		if err != nil {
-			return fmt.Errorf("upload chunk %d: %v", i, err)
+			return nil, fmt.Errorf("upload chunk %d: %v", i, err)
 		}
 		if uploadResult.Error != "" {
-			return fmt.Errorf("upload result: %v", uploadResult.Error)
+			return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
 		}
-		entry.Chunks = append(entry.Chunks, uploadResult.ToPbFileChunk(fileId, i*chunkSize, time.Now().UnixNano()))
-	}
-
-	// write the entry to partitionDir
-	if err := filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
-		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
-			Directory: partitionDir,
-			Entry:     entry,
-		})
-	}); err != nil {
-		return fmt.Errorf("create entry: %w", err)
+		chunks = append(chunks, uploadResult.ToPbFileChunk(fileId, i*chunkSize, time.Now().UnixNano()))
 	}
-
-	return nil
+	return chunks, nil
 }
 
 func iterateLogEntries(filerClient filer_pb.FilerClient, logFile *filer_pb.Entry, eachLogEntryFn func(entry *filer_pb.LogEntry) error) error {
@@ -0,0 +1,103 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"google.golang.org/grpc"
+)
+
+var ErrBadUsernameOrPassword = errors.New("bad user name or password")
+
+// Authenticator checks the user name and password of a connecting client,
+// and what the client is allowed to do.
+type Authenticator interface {
+	Authenticate(ctx context.Context, username string, password []byte) (*Identity, error)
+}
+
+// Identity is an authenticated client. A nil identity is allowed to do everything.
+type Identity struct {
+	Name    string
+	actions []string
+}
+
+// CanPublish checks whether the identity is allowed to publish into the MQ namespace.
+func (i *Identity) CanPublish(namespace string) bool {
+	return i.isAllowed(s3_constants.ACTION_WRITE, namespace)
+}
+
+// CanSubscribe checks whether the identity is allowed to subscribe to the MQ namespace.
+func (i *Identity) CanSubscribe(namespace string) bool {
+	return i.isAllowed(s3_constants.ACTION_READ, namespace)
+}
+
+// isAllowed follows the S3 identity actions: "Admin", "Write", or "Write:<namespace>".
+func (i *Identity) isAllowed(action, namespace string) bool {
+	if i == nil {
+		return true
+	}
+	for _, a := range i.actions {
+		switch a {
+		case s3_constants.ACTION_ADMIN, action, action + ":" + namespace, s3_constants.ACTION_ADMIN + ":" + namespace:
+			return true
+		}
+	}
+	return false
+}
+
+// CredentialAuthenticator uses the user name as the access key, and the password as the secret key
+// of the identities managed by "weed/credential", the same ones as the S3 API.
+type CredentialAuthenticator struct {
+	credentialManager *credential.CredentialManager
+}
+
+func NewCredentialAuthenticator(credentialManager *credential.CredentialManager) *CredentialAuthenticator {
+	return &CredentialAuthenticator{credentialManager: credentialManager}
+}
+
+// SetFilerAddressFunc points the credential stores kept on the filer to the filers of the gateway.
+func (a *CredentialAuthenticator) SetFilerAddressFunc(getFiler func() pb.ServerAddress, grpcDialOption grpc.DialOption) {
+	if store, ok := a.credentialManager.GetStore().(interface {
+		SetFilerAddressFunc(func() pb.ServerAddress, grpc.DialOption)
+	}); ok {
+		store.SetFilerAddressFunc(getFiler, grpcDialOption)
+	}
+}
+
+func (a *CredentialAuthenticator) Authenticate(ctx context.Context, username string, password []byte) (*Identity, error) {
+	if username == "" {
+		return nil, ErrBadUsernameOrPassword
+	}
+	identity, err := a.credentialManager.GetUserByAccessKey(ctx, username)
+	if errors.Is(err, credential.ErrAccessKeyNotFound) || errors.Is(err, credential.ErrUserNotFound) {
+		return nil, ErrBadUsernameOrPassword
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup access key: %w", err)
+	}
+	if identity.Disabled {
+		return nil, ErrBadUsernameOrPassword
+	}
+	if cred := findCredential(identity, username); cred == nil || subtle.ConstantTimeCompare([]byte(cred.SecretKey), password) != 1 {
+		return nil, ErrBadUsernameOrPassword
+	}
+	return &Identity{
+		Name:    identity.Name,
+		actions: identity.Actions,
+	}, nil
+}
+
+func findCredential(identity *iam_pb.Identity, accessKey string) *iam_pb.Credential {
+	for _, cred := range identity.Credentials {
+		if cred.AccessKey == accessKey && cred.Status != "Inactive" {
+			return cred
+		}
+	}
+	return nil
+}
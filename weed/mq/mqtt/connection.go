@@ -0,0 +1,531 @@
+package mqtt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+)
+
+const (
+	connectTimeout        = 10 * time.Second
+	topicAliasMaximum     = 64
+	defaultReceiveMaximum = 65535
+)
+
+var errConnectionClosed = errors.New("mqtt connection closed")
+
+// connection serves one MQTT client connection.
+type connection struct {
+	server   *Server
+	conn     net.Conn
+	reader   *bufio.Reader
+	version  byte
+	clientId string
+	identity *Identity
+
+	writeLock sync.Mutex
+
+	will             *Message
+	isDisconnected   bool // the client sent DISCONNECT
+	willOnDisconnect bool // the MQTT 5.0 client asked for the will on DISCONNECT
+	sessionExpiry    uint32
+	hasStoredState   bool
+	session          *session
+
+	// topic aliases sent by the client, only with MQTT 5.0
+	topicAliases map[uint16]string
+
+	// QoS 1 messages sent to the client and waiting for PUBACK
+	inflight     map[uint16]chan struct{}
+	inflightLock sync.Mutex
+	nextPacketId uint16
+	sendQuota    chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{} // closed when the connection is closed
+	finished  chan struct{} // closed when the session state is saved after closing
+}
+
+func newConnection(server *Server, conn net.Conn) *connection {
+	return &connection{
+		server:       server,
+		conn:         conn,
+		reader:       bufio.NewReader(conn),
+		topicAliases: make(map[uint16]string),
+		inflight:     make(map[uint16]chan struct{}),
+		done:         make(chan struct{}),
+		finished:     make(chan struct{}),
+	}
+}
+
+func (c *connection) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+func (c *connection) writePacket(packet Packet) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return WritePacket(c.conn, packet, c.version)
+}
+
+// disconnect tells an MQTT 5.0 client the reason of closing the connection.
+func (c *connection) disconnect(reasonCode byte) {
+	if c.version >= Version5 {
+		_ = c.writePacket(&DisconnectPacket{ReasonCode: reasonCode})
+	}
+	c.close()
+}
+
+func (c *connection) serve() {
+	defer close(c.finished)
+	defer c.close()
+
+	c.conn.SetReadDeadline(time.Now().Add(connectTimeout))
+	packet, err := ReadPacket(c.reader, Version311, c.server.opts.MaxPacketSize)
+	if err != nil {
+		glog.V(1).Infof("mqtt read connect from %s: %v", c.conn.RemoteAddr(), err)
+		return
+	}
+	connect, ok := packet.(*ConnectPacket)
+	if !ok {
+		glog.V(1).Infof("mqtt %s sent packet type %d before connect", c.conn.RemoteAddr(), packet.Type())
+		return
+	}
+	if !c.handleConnect(connect) {
+		return
+	}
+
+	stats.MqMqttConnectionsGauge.Inc()
+	defer stats.MqMqttConnectionsGauge.Dec()
+	defer c.server.unregisterConnection(c)
+	defer c.onClose()
+
+	keepAlive := time.Duration(connect.KeepAlive) * time.Second
+	for {
+		if keepAlive > 0 {
+			c.conn.SetReadDeadline(time.Now().Add(keepAlive * 3 / 2))
+		} else {
+			c.conn.SetReadDeadline(time.Time{})
+		}
+		packet, err := ReadPacket(c.reader, c.version, c.server.opts.MaxPacketSize)
+		if err != nil {
+			select {
+			case <-c.done:
+			default:
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					glog.V(1).Infof("mqtt client %s keep alive timeout", c.clientId)
+					c.disconnect(ReasonKeepAliveTimeout)
+				} else if !errors.Is(err, io.EOF) {
+					glog.V(1).Infof("mqtt client %s read: %v", c.clientId, err)
+					c.disconnect(ReasonMalformedPacket)
+				}
+			}
+			return
+		}
+		if err = c.handlePacket(packet); err != nil {
+			glog.V(1).Infof("mqtt client %s: %v", c.clientId, err)
+			return
+		}
+		if c.isDisconnected {
+			return
+		}
+	}
+}
+
+// handleConnect sets up the session, and returns false if the connection is refused.
+func (c *connection) handleConnect(p *ConnectPacket) bool {
+	c.version = p.ProtocolLevel
+	if c.version != Version31 && c.version != Version311 && c.version != Version5 {
+		c.version = Version311
+		_ = c.writePacket(&ConnackPacket{ReasonCode: ReasonUnsupportedProtocolVersion})
+		return false
+	}
+
+	connack := &ConnackPacket{}
+	if c.version >= Version5 {
+		connack.Properties = &Properties{}
+	}
+	c.clientId = p.ClientId
+	if c.clientId == "" {
+		if !p.CleanStart && c.version < Version5 {
+			_ = c.writePacket(&ConnackPacket{ReasonCode: ReasonClientIdNotValid})
+			return false
+		}
+		c.clientId = "auto-" + uuid.NewString()
+		if c.version >= Version5 {
+			connack.Properties.SetString(PropAssignedClientId, c.clientId)
+		}
+	}
+
+	if authenticator := c.server.opts.Authenticator; authenticator != nil {
+		identity, err := authenticator.Authenticate(c.server.ctx, p.Username, p.Password)
+		if err != nil {
+			glog.V(0).Infof("mqtt client %s from %s: %v", c.clientId, c.conn.RemoteAddr(), err)
+			reasonCode := ReasonBadUsernameOrPassword
+			if !errors.Is(err, ErrBadUsernameOrPassword) {
+				reasonCode = ReasonServerUnavailable
+			}
+			_ = c.writePacket(&ConnackPacket{ReasonCode: reasonCode})
+			return false
+		}
+		c.identity = identity
+	}
+
+	if p.Will != nil {
+		if err := ValidateTopicName(p.Will.Topic); err != nil || p.Will.QoS > 1 {
+			reasonCode := ReasonTopicNameInvalid
+			if err == nil {
+				reasonCode = ReasonQoSNotSupported
+			}
+			_ = c.writePacket(&ConnackPacket{ReasonCode: reasonCode})
+			return false
+		}
+		c.will = p.Will
+	}
+
+	if c.version >= Version5 {
+		c.sessionExpiry, _ = p.Properties.Int(PropSessionExpiry)
+		receiveMaximum := uint32(defaultReceiveMaximum)
+		if v, found := p.Properties.Int(PropReceiveMaximum); found && v > 0 {
+			receiveMaximum = v
+		}
+		c.sendQuota = make(chan struct{}, receiveMaximum)
+	} else {
+		if !p.CleanStart {
+			c.sessionExpiry = SessionNeverExpires
+		}
+		c.sendQuota = make(chan struct{}, defaultReceiveMaximum)
+	}
+
+	// only one connection at a time owns the session of a client id
+	if previous := c.server.registerConnection(c); previous != nil {
+		glog.V(0).Infof("mqtt client %s reconnected from %s, closing the previous connection", c.clientId, c.conn.RemoteAddr())
+		previous.disconnect(ReasonSessionTakenOver)
+		<-previous.finished
+	}
+
+	var subscriptions []Subscription
+	if p.CleanStart {
+		if err := c.server.store.DeleteSession(c.clientId); err != nil {
+			glog.Errorf("mqtt client %s: %v", c.clientId, err)
+		}
+	} else {
+		state, err := c.server.store.LoadSession(c.clientId)
+		if err != nil {
+			glog.Errorf("mqtt client %s: %v", c.clientId, err)
+			c.server.unregisterConnection(c)
+			_ = c.writePacket(&ConnackPacket{ReasonCode: ReasonServerUnavailable})
+			return false
+		}
+		if state != nil {
+			c.hasStoredState = true
+			connack.SessionPresent = true
+			subscriptions = state.Subscriptions
+		}
+	}
+	if c.sessionExpiry > 0 {
+		if err := c.saveSession(subscriptions, 0); err != nil {
+			glog.Errorf("mqtt client %s: %v", c.clientId, err)
+		}
+		c.hasStoredState = true
+	}
+
+	if c.version >= Version5 {
+		connack.Properties.SetInt(PropMaximumQoS, 1)
+		connack.Properties.SetInt(PropRetainAvailable, 1)
+		connack.Properties.SetInt(PropSharedSubAvailable, 0)
+		connack.Properties.SetInt(PropTopicAliasMaximum, topicAliasMaximum)
+		if c.server.opts.MaxPacketSize > 0 {
+			connack.Properties.SetInt(PropMaximumPacketSize, uint32(c.server.opts.MaxPacketSize))
+		}
+		if p.KeepAlive > maxKeepAlive {
+			p.KeepAlive = maxKeepAlive
+			connack.Properties.SetInt(PropServerKeepAlive, maxKeepAlive)
+		}
+	}
+	if err := c.writePacket(connack); err != nil {
+		c.server.unregisterConnection(c)
+		return false
+	}
+
+	c.session = newSession(c, subscriptions, c.sessionExpiry > 0)
+	glog.V(1).Infof("mqtt client %s connected from %s, version %d, session present %v", c.clientId, c.conn.RemoteAddr(), c.version, connack.SessionPresent)
+	return true
+}
+
+func (c *connection) saveSession(subscriptions []Subscription, disconnectedAt int64) error {
+	return c.server.store.SaveSession(&SessionState{
+		ClientId:       c.clientId,
+		Subscriptions:  subscriptions,
+		ExpiryInterval: c.sessionExpiry,
+		DisconnectedAt: disconnectedAt,
+	})
+}
+
+// onClose publishes the will of an abnormally closed connection, and keeps or removes the session.
+func (c *connection) onClose() {
+	c.close()
+	if c.will != nil && (!c.isDisconnected || c.willOnDisconnect) {
+		if err := c.publishMessage(c.will); err != nil {
+			glog.Errorf("mqtt client %s publish will: %v", c.clientId, err)
+		}
+	}
+	subscriptions := c.session.stop()
+	if c.sessionExpiry > 0 {
+		if err := c.saveSession(subscriptions, time.Now().Unix()); err != nil {
+			glog.Errorf("mqtt client %s: %v", c.clientId, err)
+		}
+	} else if c.hasStoredState {
+		if err := c.server.store.DeleteSession(c.clientId); err != nil {
+			glog.Errorf("mqtt client %s: %v", c.clientId, err)
+		}
+	}
+	glog.V(1).Infof("mqtt client %s disconnected", c.clientId)
+}
+
+func (c *connection) handlePacket(packet Packet) error {
+	switch p := packet.(type) {
+	case *PublishPacket:
+		return c.handlePublish(p)
+	case *PubackPacket:
+		c.inflightLock.Lock()
+		if acked, found := c.inflight[p.PacketId]; found {
+			delete(c.inflight, p.PacketId)
+			close(acked)
+		}
+		c.inflightLock.Unlock()
+		return nil
+	case *SubscribePacket:
+		return c.handleSubscribe(p)
+	case *UnsubscribePacket:
+		return c.handleUnsubscribe(p)
+	case *PingreqPacket:
+		return c.writePacket(&PingrespPacket{})
+	case *DisconnectPacket:
+		if expiry, found := p.Properties.Int(PropSessionExpiry); found {
+			if c.sessionExpiry == 0 && expiry > 0 {
+				c.disconnect(ReasonProtocolError)
+				return fmt.Errorf("session expiry changed from 0 on disconnect")
+			}
+			c.sessionExpiry = expiry
+		}
+		c.isDisconnected = true
+		c.willOnDisconnect = p.ReasonCode == ReasonDisconnectWithWill
+		return nil
+	case *ConnectPacket:
+		c.disconnect(ReasonProtocolError)
+		return fmt.Errorf("second connect packet")
+	default:
+		c.disconnect(ReasonProtocolError)
+		return fmt.Errorf("unexpected packet type %d", packet.Type())
+	}
+}
+
+func (c *connection) handlePublish(p *PublishPacket) error {
+	if p.QoS > 1 {
+		c.disconnect(ReasonQoSNotSupported)
+		return fmt.Errorf("publish with qos %d is not supported", p.QoS)
+	}
+	if alias, found := p.Properties.Int(PropTopicAlias); found {
+		if alias == 0 || alias > topicAliasMaximum {
+			c.disconnect(ReasonProtocolError)
+			return fmt.Errorf("invalid topic alias %d", alias)
+		}
+		if p.Topic == "" {
+			p.Topic = c.topicAliases[uint16(alias)]
+		} else {
+			c.topicAliases[uint16(alias)] = p.Topic
+		}
+	}
+	if err := ValidateTopicName(p.Topic); err != nil {
+		c.disconnect(ReasonTopicNameInvalid)
+		return err
+	}
+
+	if !c.identity.CanPublish(c.server.mapper.MapTopic(p.Topic).Namespace) {
+		glog.V(0).Infof("mqtt client %s is not allowed to publish to %s", c.clientId, p.Topic)
+		// MQTT 3.1.1 clients can not be told, so the message is acknowledged and dropped
+		return c.ackPublish(p, ReasonNotAuthorized)
+	}
+
+	if err := c.publishMessage(&p.Message); err != nil {
+		glog.Errorf("mqtt client %s publish to %s: %v", c.clientId, p.Topic, err)
+		if c.version < Version5 {
+			// without a reason code, closing the connection lets the client publish again
+			c.close()
+			return err
+		}
+		return c.ackPublish(p, ReasonUnspecifiedError)
+	}
+	stats.MqMqttMessagesCounter.WithLabelValues("in", strconv.Itoa(int(p.QoS))).Inc()
+	return c.ackPublish(p, ReasonSuccess)
+}
+
+func (c *connection) ackPublish(p *PublishPacket, reasonCode byte) error {
+	if p.QoS == 0 {
+		return nil
+	}
+	return c.writePacket(&PubackPacket{
+		PacketId:   p.PacketId,
+		ReasonCode: reasonCode,
+	})
+}
+
+// publishMessage stores the message into MQ, and keeps it if it is retained.
+func (c *connection) publishMessage(m *Message) error {
+	stored := &StoredMessage{
+		Message: Message{
+			Topic:   m.Topic,
+			Payload: m.Payload,
+			QoS:     m.QoS,
+			Retain:  m.Retain,
+		},
+		ClientId: c.clientId,
+	}
+	if contentType := m.Properties.String(PropContentType); contentType != "" {
+		stored.Properties = (&Properties{}).SetString(PropContentType, contentType)
+	}
+	if m.Retain {
+		if err := c.server.store.SaveRetained(stored); err != nil {
+			return fmt.Errorf("save retained message: %w", err)
+		}
+	}
+	return c.server.publish(stored)
+}
+
+func (c *connection) handleSubscribe(p *SubscribePacket) error {
+	var subscriptionId uint32
+	if p.Properties != nil && len(p.Properties.SubscriptionIds) > 0 {
+		subscriptionId = p.Properties.SubscriptionIds[0]
+	}
+	suback := &SubackPacket{PacketId: p.PacketId}
+	var granted []Subscription
+	var isNew []bool
+	for _, sub := range p.Subscriptions {
+		if strings.HasPrefix(sub.Filter, "$share/") {
+			suback.ReasonCodes = append(suback.ReasonCodes, ReasonSharedSubNotSupported)
+			continue
+		}
+		if err := ValidateTopicFilter(sub.Filter); err != nil {
+			suback.ReasonCodes = append(suback.ReasonCodes, ReasonTopicFilterInvalid)
+			continue
+		}
+		if !c.canSubscribe(sub.Filter) {
+			suback.ReasonCodes = append(suback.ReasonCodes, ReasonNotAuthorized)
+			continue
+		}
+		sub.QoS = min(sub.QoS, 1)
+		sub.SubscriptionId = subscriptionId
+		isNew = append(isNew, c.session.subscribe(sub))
+		granted = append(granted, sub)
+		suback.ReasonCodes = append(suback.ReasonCodes, sub.QoS)
+	}
+	if len(granted) > 0 {
+		c.persistSubscriptions()
+	}
+	if err := c.writePacket(suback); err != nil {
+		return err
+	}
+
+	for i, sub := range granted {
+		if sub.RetainHandling == 2 || sub.RetainHandling == 1 && !isNew[i] {
+			continue
+		}
+		c.session.sendRetained(sub)
+	}
+	return nil
+}
+
+func (c *connection) canSubscribe(filter string) bool {
+	topics, isDefaultNamespace := c.server.mapper.MapFilter(filter)
+	if isDefaultNamespace && !c.identity.CanSubscribe(c.server.mapper.DefaultNamespace()) {
+		return false
+	}
+	for _, t := range topics {
+		if !c.identity.CanSubscribe(t.Namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *connection) handleUnsubscribe(p *UnsubscribePacket) error {
+	unsuback := &UnsubackPacket{PacketId: p.PacketId}
+	for _, filter := range p.Filters {
+		if c.session.unsubscribe(filter) {
+			unsuback.ReasonCodes = append(unsuback.ReasonCodes, ReasonSuccess)
+		} else {
+			unsuback.ReasonCodes = append(unsuback.ReasonCodes, ReasonNoSubscriptionExisted)
+		}
+	}
+	c.persistSubscriptions()
+	return c.writePacket(unsuback)
+}
+
+func (c *connection) persistSubscriptions() {
+	if c.sessionExpiry == 0 {
+		return
+	}
+	if err := c.saveSession(c.session.getSubscriptions(), 0); err != nil {
+		glog.Errorf("mqtt client %s: %v", c.clientId, err)
+	}
+}
+
+// deliver sends the message to the client. A QoS 1 message is delivered
+// once the client acknowledges it, and errConnectionClosed is returned if the connection closes before.
+func (c *connection) deliver(p *PublishPacket) error {
+	if p.QoS == 0 {
+		if err := c.writePacket(p); err != nil {
+			return errConnectionClosed
+		}
+		stats.MqMqttMessagesCounter.WithLabelValues("out", "0").Inc()
+		return nil
+	}
+
+	select {
+	case c.sendQuota <- struct{}{}:
+	case <-c.done:
+		return errConnectionClosed
+	}
+	defer func() { <-c.sendQuota }()
+
+	acked := make(chan struct{})
+	c.inflightLock.Lock()
+	for {
+		c.nextPacketId++
+		if c.nextPacketId == 0 {
+			continue
+		}
+		if _, found := c.inflight[c.nextPacketId]; !found {
+			break
+		}
+	}
+	p.PacketId = c.nextPacketId
+	c.inflight[p.PacketId] = acked
+	c.inflightLock.Unlock()
+
+	if err := c.writePacket(p); err != nil {
+		return errConnectionClosed
+	}
+	select {
+	case <-acked:
+		stats.MqMqttMessagesCounter.WithLabelValues("out", "1").Inc()
+		return nil
+	case <-c.done:
+		return errConnectionClosed
+	}
+}
@@ -0,0 +1,855 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MQTT control packet types
+const (
+	TypeConnect     byte = 1
+	TypeConnack     byte = 2
+	TypePublish     byte = 3
+	TypePuback      byte = 4
+	TypePubrec      byte = 5
+	TypePubrel      byte = 6
+	TypePubcomp     byte = 7
+	TypeSubscribe   byte = 8
+	TypeSuback      byte = 9
+	TypeUnsubscribe byte = 10
+	TypeUnsuback    byte = 11
+	TypePingreq     byte = 12
+	TypePingresp    byte = 13
+	TypeDisconnect  byte = 14
+	TypeAuth        byte = 15
+)
+
+// Protocol levels
+const (
+	Version31  byte = 3
+	Version311 byte = 4
+	Version5   byte = 5
+)
+
+// MQTT 5.0 reason codes. They are converted to the return codes of MQTT 3.1.1 when needed.
+const (
+	ReasonSuccess                    byte = 0x00
+	ReasonGrantedQoS1                byte = 0x01
+	ReasonDisconnectWithWill         byte = 0x04
+	ReasonNoSubscriptionExisted      byte = 0x11
+	ReasonUnspecifiedError           byte = 0x80
+	ReasonMalformedPacket            byte = 0x81
+	ReasonProtocolError              byte = 0x82
+	ReasonImplementationSpecific     byte = 0x83
+	ReasonUnsupportedProtocolVersion byte = 0x84
+	ReasonClientIdNotValid           byte = 0x85
+	ReasonBadUsernameOrPassword      byte = 0x86
+	ReasonNotAuthorized              byte = 0x87
+	ReasonServerUnavailable          byte = 0x88
+	ReasonKeepAliveTimeout           byte = 0x8D
+	ReasonSessionTakenOver           byte = 0x8E
+	ReasonTopicFilterInvalid         byte = 0x8F
+	ReasonTopicNameInvalid           byte = 0x90
+	ReasonPacketTooLarge             byte = 0x95
+	ReasonQoSNotSupported            byte = 0x9B
+	ReasonSharedSubNotSupported      byte = 0x9E
+)
+
+// MQTT 5.0 property identifiers
+const (
+	PropPayloadFormat        byte = 0x01
+	PropMessageExpiry        byte = 0x02
+	PropContentType          byte = 0x03
+	PropResponseTopic        byte = 0x08
+	PropCorrelationData      byte = 0x09
+	PropSubscriptionId       byte = 0x0B
+	PropSessionExpiry        byte = 0x11
+	PropAssignedClientId     byte = 0x12
+	PropServerKeepAlive      byte = 0x13
+	PropAuthMethod           byte = 0x15
+	PropAuthData             byte = 0x16
+	PropRequestProblemInfo   byte = 0x17
+	PropWillDelay            byte = 0x18
+	PropRequestResponseInfo  byte = 0x19
+	PropResponseInfo         byte = 0x1A
+	PropServerReference      byte = 0x1C
+	PropReasonString         byte = 0x1F
+	PropReceiveMaximum       byte = 0x21
+	PropTopicAliasMaximum    byte = 0x22
+	PropTopicAlias           byte = 0x23
+	PropMaximumQoS           byte = 0x24
+	PropRetainAvailable      byte = 0x25
+	PropUserProperty         byte = 0x26
+	PropMaximumPacketSize    byte = 0x27
+	PropWildcardSubAvailable byte = 0x28
+	PropSubIdAvailable       byte = 0x29
+	PropSharedSubAvailable   byte = 0x2A
+)
+
+var ErrMalformedPacket = errors.New("malformed mqtt packet")
+
+type Packet interface {
+	Type() byte
+}
+
+// Properties are the MQTT 5.0 properties of a packet, by their value type.
+type Properties struct {
+	Ints            map[byte]uint32 // byte, two byte and four byte integers
+	Strings         map[byte]string
+	Binary          map[byte][]byte
+	UserProperties  [][2]string
+	SubscriptionIds []uint32
+}
+
+func (p *Properties) Int(id byte) (uint32, bool) {
+	if p == nil {
+		return 0, false
+	}
+	v, found := p.Ints[id]
+	return v, found
+}
+
+func (p *Properties) String(id byte) string {
+	if p == nil {
+		return ""
+	}
+	return p.Strings[id]
+}
+
+func (p *Properties) SetInt(id byte, v uint32) *Properties {
+	if p.Ints == nil {
+		p.Ints = make(map[byte]uint32)
+	}
+	p.Ints[id] = v
+	return p
+}
+
+func (p *Properties) SetString(id byte, v string) *Properties {
+	if p.Strings == nil {
+		p.Strings = make(map[byte]string)
+	}
+	p.Strings[id] = v
+	return p
+}
+
+type Message struct {
+	Topic      string
+	Payload    []byte
+	QoS        byte
+	Retain     bool
+	Properties *Properties
+}
+
+type ConnectPacket struct {
+	ProtocolName  string
+	ProtocolLevel byte
+	CleanStart    bool
+	KeepAlive     uint16
+	Properties    *Properties
+	ClientId      string
+	Will          *Message
+	Username      string
+	HasUsername   bool
+	Password      []byte
+	HasPassword   bool
+}
+
+type ConnackPacket struct {
+	SessionPresent bool
+	ReasonCode     byte
+	Properties     *Properties
+}
+
+type PublishPacket struct {
+	Message
+	PacketId uint16
+	Dup      bool
+}
+
+// PubackPacket acknowledges a QoS 1 publish.
+type PubackPacket struct {
+	PacketId   uint16
+	ReasonCode byte
+	Properties *Properties
+}
+
+type Subscription struct {
+	Filter            string
+	QoS               byte
+	NoLocal           bool
+	RetainAsPublished bool
+	RetainHandling    byte
+	SubscriptionId    uint32 // from the properties of the SUBSCRIBE packet
+}
+
+type SubscribePacket struct {
+	PacketId      uint16
+	Properties    *Properties
+	Subscriptions []Subscription
+}
+
+type SubackPacket struct {
+	PacketId    uint16
+	Properties  *Properties
+	ReasonCodes []byte
+}
+
+type UnsubscribePacket struct {
+	PacketId   uint16
+	Properties *Properties
+	Filters    []string
+}
+
+type UnsubackPacket struct {
+	PacketId    uint16
+	Properties  *Properties
+	ReasonCodes []byte
+}
+
+type PingreqPacket struct{}
+
+type PingrespPacket struct{}
+
+type DisconnectPacket struct {
+	ReasonCode byte
+	Properties *Properties
+}
+
+func (p *ConnectPacket) Type() byte     { return TypeConnect }
+func (p *ConnackPacket) Type() byte     { return TypeConnack }
+func (p *PublishPacket) Type() byte     { return TypePublish }
+func (p *PubackPacket) Type() byte      { return TypePuback }
+func (p *SubscribePacket) Type() byte   { return TypeSubscribe }
+func (p *SubackPacket) Type() byte      { return TypeSuback }
+func (p *UnsubscribePacket) Type() byte { return TypeUnsubscribe }
+func (p *UnsubackPacket) Type() byte    { return TypeUnsuback }
+func (p *PingreqPacket) Type() byte     { return TypePingreq }
+func (p *PingrespPacket) Type() byte    { return TypePingresp }
+func (p *DisconnectPacket) Type() byte  { return TypeDisconnect }
+
+// ReadPacket reads one packet. The protocol version is the one of the connection,
+// and is not used for the CONNECT packet, which carries its own version.
+func ReadPacket(r *bufio.Reader, version byte, maxPacketSize int) (Packet, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	remainingLength, err := readVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if maxPacketSize > 0 && remainingLength > maxPacketSize {
+		return nil, fmt.Errorf("packet size %d exceeds %d", remainingLength, maxPacketSize)
+	}
+	body := make([]byte, remainingLength)
+	if _, err = io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	d := &decoder{buf: body}
+	packetType, flags := header>>4, header&0x0F
+
+	var packet Packet
+	switch packetType {
+	case TypeConnect:
+		packet, err = decodeConnect(d)
+	case TypePublish:
+		packet, err = decodePublish(d, flags, version)
+	case TypePuback:
+		packet, err = decodePuback(d, version)
+	case TypeSubscribe:
+		packet, err = decodeSubscribe(d, version)
+	case TypeUnsubscribe:
+		packet, err = decodeUnsubscribe(d, version)
+	case TypePingreq:
+		packet = &PingreqPacket{}
+	case TypeDisconnect:
+		packet, err = decodeDisconnect(d, version)
+	case TypeConnack, TypeSuback, TypeUnsuback, TypePingresp:
+		// only sent by the server, but decoded for tests and tools
+		packet, err = decodeServerPacket(d, packetType, version)
+	default:
+		return nil, fmt.Errorf("unsupported packet type %d", packetType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return packet, nil
+}
+
+// WritePacket encodes the packet for the protocol version of the connection.
+func WritePacket(w io.Writer, packet Packet, version byte) error {
+	e := &encoder{}
+	var flags byte
+	switch p := packet.(type) {
+	case *ConnectPacket:
+		e.writeString(p.ProtocolName)
+		e.writeByte(p.ProtocolLevel)
+		var connectFlags byte
+		if p.CleanStart {
+			connectFlags |= 0x02
+		}
+		if p.Will != nil {
+			connectFlags |= 0x04 | p.Will.QoS<<3
+			if p.Will.Retain {
+				connectFlags |= 0x20
+			}
+		}
+		if p.HasPassword {
+			connectFlags |= 0x40
+		}
+		if p.HasUsername {
+			connectFlags |= 0x80
+		}
+		e.writeByte(connectFlags)
+		e.writeUint16(p.KeepAlive)
+		if p.ProtocolLevel >= Version5 {
+			e.writeProperties(p.Properties)
+		}
+		e.writeString(p.ClientId)
+		if p.Will != nil {
+			if p.ProtocolLevel >= Version5 {
+				e.writeProperties(p.Will.Properties)
+			}
+			e.writeString(p.Will.Topic)
+			e.writeBinary(p.Will.Payload)
+		}
+		if p.HasUsername {
+			e.writeString(p.Username)
+		}
+		if p.HasPassword {
+			e.writeBinary(p.Password)
+		}
+	case *ConnackPacket:
+		e.writeBool(p.SessionPresent)
+		if version >= Version5 {
+			e.writeByte(p.ReasonCode)
+			e.writeProperties(p.Properties)
+		} else {
+			e.writeByte(connackReturnCode(p.ReasonCode))
+		}
+	case *PublishPacket:
+		flags = p.QoS << 1
+		if p.Dup {
+			flags |= 0x08
+		}
+		if p.Retain {
+			flags |= 0x01
+		}
+		e.writeString(p.Topic)
+		if p.QoS > 0 {
+			e.writeUint16(p.PacketId)
+		}
+		if version >= Version5 {
+			e.writeProperties(p.Properties)
+		}
+		e.buf = append(e.buf, p.Payload...)
+	case *PubackPacket:
+		e.writeUint16(p.PacketId)
+		if version >= Version5 && (p.ReasonCode != ReasonSuccess || p.Properties != nil) {
+			e.writeByte(p.ReasonCode)
+			e.writeProperties(p.Properties)
+		}
+	case *SubscribePacket:
+		flags = 0x02
+		e.writeUint16(p.PacketId)
+		if version >= Version5 {
+			e.writeProperties(p.Properties)
+		}
+		for _, s := range p.Subscriptions {
+			e.writeString(s.Filter)
+			options := s.QoS
+			if s.NoLocal {
+				options |= 0x04
+			}
+			if s.RetainAsPublished {
+				options |= 0x08
+			}
+			options |= s.RetainHandling << 4
+			e.writeByte(options)
+		}
+	case *SubackPacket:
+		e.writeUint16(p.PacketId)
+		if version >= Version5 {
+			e.writeProperties(p.Properties)
+			e.buf = append(e.buf, p.ReasonCodes...)
+		} else {
+			for _, code := range p.ReasonCodes {
+				if code >= 0x80 {
+					code = 0x80
+				}
+				e.writeByte(code)
+			}
+		}
+	case *UnsubscribePacket:
+		flags = 0x02
+		e.writeUint16(p.PacketId)
+		if version >= Version5 {
+			e.writeProperties(p.Properties)
+		}
+		for _, filter := range p.Filters {
+			e.writeString(filter)
+		}
+	case *UnsubackPacket:
+		e.writeUint16(p.PacketId)
+		if version >= Version5 {
+			e.writeProperties(p.Properties)
+			e.buf = append(e.buf, p.ReasonCodes...)
+		}
+	case *PingreqPacket, *PingrespPacket:
+	case *DisconnectPacket:
+		if version >= Version5 {
+			e.writeByte(p.ReasonCode)
+			e.writeProperties(p.Properties)
+		}
+	default:
+		return fmt.Errorf("encoding packet type %d is not supported", packet.Type())
+	}
+
+	header := []byte{packet.Type()<<4 | flags}
+	header = appendVarInt(header, len(e.buf))
+	if _, err := w.Write(append(header, e.buf...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// connackReturnCode converts a reason code into the CONNACK return code of MQTT 3.1.1.
+func connackReturnCode(reasonCode byte) byte {
+	switch reasonCode {
+	case ReasonSuccess:
+		return 0
+	case ReasonUnsupportedProtocolVersion:
+		return 1
+	case ReasonClientIdNotValid:
+		return 2
+	case ReasonBadUsernameOrPassword:
+		return 4
+	case ReasonNotAuthorized:
+		return 5
+	default:
+		return 3 // server unavailable
+	}
+}
+
+func decodeConnect(d *decoder) (*ConnectPacket, error) {
+	p := &ConnectPacket{}
+	p.ProtocolName = d.readString()
+	p.ProtocolLevel = d.readByte()
+	flags := d.readByte()
+	p.KeepAlive = d.readUint16()
+	if d.err != nil {
+		return nil, d.err
+	}
+	if p.ProtocolName != "MQTT" && p.ProtocolName != "MQIsdp" {
+		return nil, fmt.Errorf("unknown protocol name %q", p.ProtocolName)
+	}
+	if flags&0x01 != 0 {
+		return nil, ErrMalformedPacket
+	}
+	p.CleanStart = flags&0x02 != 0
+	if p.ProtocolLevel >= Version5 {
+		p.Properties = d.readProperties()
+	}
+	p.ClientId = d.readString()
+	if flags&0x04 != 0 {
+		will := &Message{
+			QoS:    (flags >> 3) & 0x03,
+			Retain: flags&0x20 != 0,
+		}
+		if p.ProtocolLevel >= Version5 {
+			will.Properties = d.readProperties()
+		}
+		will.Topic = d.readString()
+		will.Payload = d.readBinary()
+		p.Will = will
+	}
+	if flags&0x80 != 0 {
+		p.HasUsername = true
+		p.Username = d.readString()
+	}
+	if flags&0x40 != 0 {
+		p.HasPassword = true
+		p.Password = d.readBinary()
+	}
+	return p, d.err
+}
+
+func decodePublish(d *decoder, flags byte, version byte) (*PublishPacket, error) {
+	p := &PublishPacket{}
+	p.QoS = (flags >> 1) & 0x03
+	p.Dup = flags&0x08 != 0
+	p.Retain = flags&0x01 != 0
+	if p.QoS == 3 {
+		return nil, ErrMalformedPacket
+	}
+	p.Topic = d.readString()
+	if p.QoS > 0 {
+		p.PacketId = d.readUint16()
+	}
+	if version >= Version5 {
+		p.Properties = d.readProperties()
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	p.Payload = d.buf[d.pos:]
+	return p, nil
+}
+
+func decodePuback(d *decoder, version byte) (*PubackPacket, error) {
+	p := &PubackPacket{}
+	p.PacketId = d.readUint16()
+	if version >= Version5 && d.remaining() > 0 {
+		p.ReasonCode = d.readByte()
+		if d.remaining() > 0 {
+			p.Properties = d.readProperties()
+		}
+	}
+	return p, d.err
+}
+
+func decodeSubscribe(d *decoder, version byte) (*SubscribePacket, error) {
+	p := &SubscribePacket{}
+	p.PacketId = d.readUint16()
+	if version >= Version5 {
+		p.Properties = d.readProperties()
+	}
+	for d.err == nil && d.remaining() > 0 {
+		s := Subscription{Filter: d.readString()}
+		options := d.readByte()
+		s.QoS = options & 0x03
+		if version >= Version5 {
+			s.NoLocal = options&0x04 != 0
+			s.RetainAsPublished = options&0x08 != 0
+			s.RetainHandling = (options >> 4) & 0x03
+		}
+		p.Subscriptions = append(p.Subscriptions, s)
+	}
+	if d.err == nil && len(p.Subscriptions) == 0 {
+		return nil, ErrMalformedPacket
+	}
+	return p, d.err
+}
+
+func decodeUnsubscribe(d *decoder, version byte) (*UnsubscribePacket, error) {
+	p := &UnsubscribePacket{}
+	p.PacketId = d.readUint16()
+	if version >= Version5 {
+		p.Properties = d.readProperties()
+	}
+	for d.err == nil && d.remaining() > 0 {
+		p.Filters = append(p.Filters, d.readString())
+	}
+	if d.err == nil && len(p.Filters) == 0 {
+		return nil, ErrMalformedPacket
+	}
+	return p, d.err
+}
+
+func decodeDisconnect(d *decoder, version byte) (*DisconnectPacket, error) {
+	p := &DisconnectPacket{}
+	if version >= Version5 && d.remaining() > 0 {
+		p.ReasonCode = d.readByte()
+		if d.remaining() > 0 {
+			p.Properties = d.readProperties()
+		}
+	}
+	return p, d.err
+}
+
+func decodeServerPacket(d *decoder, packetType byte, version byte) (Packet, error) {
+	switch packetType {
+	case TypeConnack:
+		p := &ConnackPacket{SessionPresent: d.readByte()&0x01 != 0}
+		p.ReasonCode = d.readByte()
+		if version >= Version5 {
+			p.Properties = d.readProperties()
+		}
+		return p, d.err
+	case TypeSuback:
+		p := &SubackPacket{PacketId: d.readUint16()}
+		if version >= Version5 {
+			p.Properties = d.readProperties()
+		}
+		if d.err == nil {
+			p.ReasonCodes = d.buf[d.pos:]
+		}
+		return p, d.err
+	case TypeUnsuback:
+		p := &UnsubackPacket{PacketId: d.readUint16()}
+		if version >= Version5 {
+			p.Properties = d.readProperties()
+			if d.err == nil {
+				p.ReasonCodes = d.buf[d.pos:]
+			}
+		}
+		return p, d.err
+	default:
+		return &PingrespPacket{}, nil
+	}
+}
+
+type propertyKind int
+
+const (
+	propByte propertyKind = iota
+	propUint16
+	propUint32
+	propVarInt
+	propString
+	propBinary
+	propStringPair
+)
+
+var propertyKinds = map[byte]propertyKind{
+	PropPayloadFormat:        propByte,
+	PropMessageExpiry:        propUint32,
+	PropContentType:          propString,
+	PropResponseTopic:        propString,
+	PropCorrelationData:      propBinary,
+	PropSubscriptionId:       propVarInt,
+	PropSessionExpiry:        propUint32,
+	PropAssignedClientId:     propString,
+	PropServerKeepAlive:      propUint16,
+	PropAuthMethod:           propString,
+	PropAuthData:             propBinary,
+	PropRequestProblemInfo:   propByte,
+	PropWillDelay:            propUint32,
+	PropRequestResponseInfo:  propByte,
+	PropResponseInfo:         propString,
+	PropServerReference:      propString,
+	PropReasonString:         propString,
+	PropReceiveMaximum:       propUint16,
+	PropTopicAliasMaximum:    propUint16,
+	PropTopicAlias:           propUint16,
+	PropMaximumQoS:           propByte,
+	PropRetainAvailable:      propByte,
+	PropUserProperty:         propStringPair,
+	PropMaximumPacketSize:    propUint32,
+	PropWildcardSubAvailable: propByte,
+	PropSubIdAvailable:       propByte,
+	PropSharedSubAvailable:   propByte,
+}
+
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (d *decoder) remaining() int {
+	return len(d.buf) - d.pos
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || d.pos+n > len(d.buf) {
+		d.err = ErrMalformedPacket
+		return nil
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *decoder) readByte() byte {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *decoder) readUint16() uint16 {
+	if b := d.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (d *decoder) readUint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) readVarInt() int {
+	value, multiplier := 0, 1
+	for i := 0; i < 4; i++ {
+		b := d.readByte()
+		if d.err != nil {
+			return 0
+		}
+		value += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			return value
+		}
+		multiplier *= 128
+	}
+	d.err = ErrMalformedPacket
+	return 0
+}
+
+func (d *decoder) readBinary() []byte {
+	n := int(d.readUint16())
+	b := d.next(n)
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+func (d *decoder) readString() string {
+	return string(d.readBinary())
+}
+
+func (d *decoder) readProperties() *Properties {
+	length := d.readVarInt()
+	end := d.pos + length
+	if d.err != nil || end > len(d.buf) {
+		d.err = ErrMalformedPacket
+		return nil
+	}
+	p := &Properties{}
+	for d.err == nil && d.pos < end {
+		id := d.readByte()
+		kind, found := propertyKinds[id]
+		if !found {
+			d.err = fmt.Errorf("unknown property 0x%02x: %w", id, ErrMalformedPacket)
+			return nil
+		}
+		switch kind {
+		case propByte:
+			p.SetInt(id, uint32(d.readByte()))
+		case propUint16:
+			p.SetInt(id, uint32(d.readUint16()))
+		case propUint32:
+			p.SetInt(id, d.readUint32())
+		case propVarInt:
+			p.SubscriptionIds = append(p.SubscriptionIds, uint32(d.readVarInt()))
+		case propString:
+			p.SetString(id, d.readString())
+		case propBinary:
+			if p.Binary == nil {
+				p.Binary = make(map[byte][]byte)
+			}
+			p.Binary[id] = d.readBinary()
+		case propStringPair:
+			p.UserProperties = append(p.UserProperties, [2]string{d.readString(), d.readString()})
+		}
+	}
+	if d.err == nil && d.pos != end {
+		d.err = ErrMalformedPacket
+	}
+	return p
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) writeByte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *encoder) writeBool(b bool) {
+	if b {
+		e.writeByte(1)
+	} else {
+		e.writeByte(0)
+	}
+}
+
+func (e *encoder) writeUint16(v uint16) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, v)
+}
+
+func (e *encoder) writeBinary(b []byte) {
+	e.writeUint16(uint16(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) writeString(s string) {
+	e.writeBinary([]byte(s))
+}
+
+func (e *encoder) writeProperties(p *Properties) {
+	props := &encoder{}
+	if p != nil {
+		for _, id := range sortedKeys(p.Ints) {
+			props.writeByte(id)
+			switch propertyKinds[id] {
+			case propByte:
+				props.writeByte(byte(p.Ints[id]))
+			case propUint16:
+				props.writeUint16(uint16(p.Ints[id]))
+			default:
+				props.buf = binary.BigEndian.AppendUint32(props.buf, p.Ints[id])
+			}
+		}
+		for _, id := range sortedKeys(p.Strings) {
+			props.writeByte(id)
+			props.writeString(p.Strings[id])
+		}
+		for _, id := range sortedKeys(p.Binary) {
+			props.writeByte(id)
+			props.writeBinary(p.Binary[id])
+		}
+		for _, subscriptionId := range p.SubscriptionIds {
+			props.writeByte(PropSubscriptionId)
+			props.buf = appendVarInt(props.buf, int(subscriptionId))
+		}
+		for _, pair := range p.UserProperties {
+			props.writeByte(PropUserProperty)
+			props.writeString(pair[0])
+			props.writeString(pair[1])
+		}
+	}
+	e.buf = appendVarInt(e.buf, len(props.buf))
+	e.buf = append(e.buf, props.buf...)
+}
+
+func sortedKeys[V any](m map[byte]V) []byte {
+	keys := make([]byte, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func readVarInt(r io.ByteReader) (int, error) {
+	value, multiplier := 0, 1
+	for i := 0; i < 4; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value += int(b&0x7F) * multiplier
+		if b&0x80 == 0 {
+			return value, nil
+		}
+		multiplier *= 128
+	}
+	return 0, ErrMalformedPacket
+}
+
+func appendVarInt(buf []byte, v int) []byte {
+	for {
+		b := byte(v % 128)
+		v /= 128
+		if v > 0 {
+			b |= 0x80
+		}
+		buf = append(buf, b)
+		if v == 0 {
+			return buf
+		}
+	}
+}
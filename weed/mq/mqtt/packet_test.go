@@ -0,0 +1,139 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func roundTrip(t *testing.T, packet Packet, version byte) Packet {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WritePacket(&buf, packet, version); err != nil {
+		t.Fatalf("write %T: %v", packet, err)
+	}
+	decoded, err := ReadPacket(bufio.NewReader(&buf), version, 0)
+	if err != nil {
+		t.Fatalf("read %T: %v", packet, err)
+	}
+	return decoded
+}
+
+func TestConnectRoundTrip(t *testing.T) {
+	for _, version := range []byte{Version311, Version5} {
+		connect := &ConnectPacket{
+			ProtocolName:  "MQTT",
+			ProtocolLevel: version,
+			CleanStart:    true,
+			KeepAlive:     30,
+			ClientId:      "client-1",
+			Will: &Message{
+				Topic:   "status/client-1",
+				Payload: []byte("offline"),
+				QoS:     1,
+				Retain:  true,
+			},
+			Username:    "access",
+			HasUsername: true,
+			Password:    []byte("secret"),
+			HasPassword: true,
+		}
+		if version >= Version5 {
+			connect.Properties = (&Properties{}).SetInt(PropSessionExpiry, 3600)
+			connect.Will.Properties = (&Properties{}).SetString(PropContentType, "text/plain")
+		}
+		decoded := roundTrip(t, connect, version)
+		if !reflect.DeepEqual(decoded, connect) {
+			t.Errorf("version %d: decoded %+v, expected %+v", version, decoded, connect)
+		}
+	}
+}
+
+func TestPublishRoundTrip(t *testing.T) {
+	publish := &PublishPacket{
+		Message: Message{
+			Topic:   "home/kitchen/temperature",
+			Payload: []byte("21.5"),
+			QoS:     1,
+			Retain:  true,
+			Properties: &Properties{
+				Ints:            map[byte]uint32{PropTopicAlias: 3},
+				Strings:         map[byte]string{PropContentType: "text/plain"},
+				UserProperties:  [][2]string{{"unit", "celsius"}},
+				SubscriptionIds: []uint32{7, 300},
+			},
+		},
+		PacketId: 42,
+	}
+	decoded := roundTrip(t, publish, Version5).(*PublishPacket)
+	if !reflect.DeepEqual(decoded, publish) {
+		t.Errorf("decoded %+v, expected %+v", decoded, publish)
+	}
+
+	publish.Properties = nil
+	decoded = roundTrip(t, publish, Version311).(*PublishPacket)
+	if !reflect.DeepEqual(decoded, publish) {
+		t.Errorf("3.1.1 decoded %+v, expected %+v", decoded, publish)
+	}
+}
+
+func TestSubscribeRoundTrip(t *testing.T) {
+	subscribe := &SubscribePacket{
+		PacketId: 7,
+		Subscriptions: []Subscription{
+			{Filter: "home/+/temperature", QoS: 1},
+			{Filter: "alerts/#", QoS: 0, NoLocal: true, RetainAsPublished: true, RetainHandling: 2},
+		},
+	}
+	decoded := roundTrip(t, subscribe, Version5).(*SubscribePacket)
+	if !reflect.DeepEqual(decoded.Subscriptions, subscribe.Subscriptions) {
+		t.Errorf("decoded %+v, expected %+v", decoded.Subscriptions, subscribe.Subscriptions)
+	}
+}
+
+func TestSubackReturnCodes(t *testing.T) {
+	suback := &SubackPacket{
+		PacketId:    7,
+		ReasonCodes: []byte{ReasonGrantedQoS1, ReasonNotAuthorized},
+	}
+	var buf bytes.Buffer
+	if err := WritePacket(&buf, suback, Version311); err != nil {
+		t.Fatal(err)
+	}
+	// fixed header, packet id, and the return codes of MQTT 3.1.1
+	expected := []byte{TypeSuback << 4, 4, 0, 7, 0x01, 0x80}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("encoded %x, expected %x", buf.Bytes(), expected)
+	}
+}
+
+func TestConnackReturnCodes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePacket(&buf, &ConnackPacket{ReasonCode: ReasonBadUsernameOrPassword}, Version311); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{TypeConnack << 4, 2, 0, 4}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("encoded %x, expected %x", buf.Bytes(), expected)
+	}
+}
+
+func TestReadPacketErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"qos 3", []byte{TypePublish<<4 | 0x06, 4, 0, 1, 'a', 0}},
+		{"truncated", []byte{TypePublish << 4, 10, 0, 1}},
+		{"too large", []byte{TypePublish << 4, 0xFF, 0xFF, 0x03}},
+		{"unknown type", []byte{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadPacket(bufio.NewReader(bytes.NewReader(tt.data)), Version311, 1024); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package mqtt
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/schema"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+)
+
+// Fields of the records that MQTT messages are stored as.
+// The payload is kept as bytes, with the MQTT topic and publishing metadata,
+// so that the records can be queried with "weed sql".
+const (
+	RecordFieldTopic       = "mqtt_topic"
+	RecordFieldPayload     = "payload"
+	RecordFieldQoS         = "qos"
+	RecordFieldRetain      = "retain"
+	RecordFieldClientId    = "client_id"
+	RecordFieldContentType = "content_type"
+)
+
+func MessageRecordType() *schema_pb.RecordType {
+	return schema.RecordTypeBegin().
+		WithField(RecordFieldTopic, schema.TypeString).
+		WithField(RecordFieldPayload, schema.TypeBytes).
+		WithField(RecordFieldQoS, schema.TypeInt32).
+		WithField(RecordFieldRetain, schema.TypeBoolean).
+		WithField(RecordFieldClientId, schema.TypeString).
+		WithField(RecordFieldContentType, schema.TypeString).
+		RecordTypeEnd()
+}
+
+// StoredMessage is an MQTT message with the client that published it.
+type StoredMessage struct {
+	Message
+	ClientId string
+}
+
+func (m *StoredMessage) ToRecord() *schema_pb.RecordValue {
+	return schema.RecordBegin().
+		SetString(RecordFieldTopic, m.Topic).
+		SetBytes(RecordFieldPayload, m.Payload).
+		SetInt32(RecordFieldQoS, int32(m.QoS)).
+		SetBool(RecordFieldRetain, m.Retain).
+		SetString(RecordFieldClientId, m.ClientId).
+		SetString(RecordFieldContentType, m.Properties.String(PropContentType)).
+		RecordEnd()
+}
+
+func ParseStoredMessage(record *schema_pb.RecordValue) (*StoredMessage, error) {
+	if record == nil || record.Fields[RecordFieldTopic] == nil {
+		return nil, fmt.Errorf("not an mqtt message record")
+	}
+	fields := record.Fields
+	m := &StoredMessage{
+		Message: Message{
+			Topic:   fields[RecordFieldTopic].GetStringValue(),
+			Payload: fields[RecordFieldPayload].GetBytesValue(),
+			QoS:     byte(fields[RecordFieldQoS].GetInt32Value()),
+			Retain:  fields[RecordFieldRetain].GetBoolValue(),
+		},
+		ClientId: fields[RecordFieldClientId].GetStringValue(),
+	}
+	if contentType := fields[RecordFieldContentType].GetStringValue(); contentType != "" {
+		m.Properties = (&Properties{}).SetString(PropContentType, contentType)
+	}
+	return m, nil
+}
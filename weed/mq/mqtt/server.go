@@ -0,0 +1,354 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/filer_client"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/pub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+	"google.golang.org/grpc"
+)
+
+const (
+	clusterRefreshInterval = 30 * time.Second
+	topicRefreshInterval   = 30 * time.Second
+	maxKeepAlive           = 3600
+)
+
+type Options struct {
+	Listen            string
+	ClientHost        string // the address registered to the masters
+	Masters           string
+	FilerGroup        string
+	Namespace         string // the MQ namespace of MQTT topics without a topic mapping
+	TopicMappings     []TopicMapping
+	DefaultPartitions int32
+	MaxPacketSize     int
+	Authenticator     Authenticator // nil to accept any client
+}
+
+type Server struct {
+	opts           Options
+	grpcDialOption grpc.DialOption
+	masterClient   *wdclient.MasterClient
+	filerClient    *filer_client.FilerClientAccessor
+	store          *Store
+	mapper         *TopicMapper
+	ln             net.Listener
+	wg             sync.WaitGroup
+	ctx            context.Context
+	cancel         context.CancelFunc
+
+	brokers     []string
+	brokersLock sync.RWMutex
+
+	publishers     map[topic.Topic]*pub_client.TopicPublisher
+	publishersLock sync.Mutex
+
+	// connections by client id, to take over the session of a reconnecting client
+	connections     map[string]*connection
+	connectionsLock sync.Mutex
+}
+
+func NewServer(opts Options) (*Server, error) {
+	if opts.Masters == "" {
+		return nil, fmt.Errorf("masters are required")
+	}
+	if opts.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	util.LoadSecurityConfiguration()
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.mq")
+	masterDiscovery := pb.ServerAddresses(opts.Masters).ToServiceDiscovery()
+	clientHost := opts.ClientHost
+	if clientHost == "" {
+		clientHost = opts.Listen
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		opts:           opts,
+		grpcDialOption: grpcDialOption,
+		masterClient:   wdclient.NewMasterClient(grpcDialOption, opts.FilerGroup, "mqtt-gateway", pb.ServerAddress(clientHost), "", "", *masterDiscovery),
+		mapper:         NewTopicMapper(opts.Namespace, opts.TopicMappings),
+		ctx:            ctx,
+		cancel:         cancel,
+		publishers:     make(map[topic.Topic]*pub_client.TopicPublisher),
+		connections:    make(map[string]*connection),
+	}
+	go s.masterClient.KeepConnectedToMaster(ctx)
+
+	filers, err := s.discover(cluster.FilerType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("discover filers: %w", err)
+	}
+	if len(filers) == 0 {
+		cancel()
+		return nil, fmt.Errorf("no filers discovered from masters %s", opts.Masters)
+	}
+	var filerAddresses []pb.ServerAddress
+	for _, filer := range filers {
+		filerAddresses = append(filerAddresses, pb.ServerAddress(filer))
+	}
+	s.filerClient = filer_client.NewFilerClientAccessor(filerAddresses, grpcDialOption)
+	s.store = NewStore(s.filerClient)
+	if setter, ok := opts.Authenticator.(interface {
+		SetFilerAddressFunc(func() pb.ServerAddress, grpc.DialOption)
+	}); ok {
+		setter.SetFilerAddressFunc(s.filerAddress, grpcDialOption)
+	}
+
+	if err = s.refreshBrokers(); err != nil {
+		cancel()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.opts.Listen)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	glog.V(0).Infof("MQTT gateway listening on %s, storing into namespace %s", ln.Addr(), s.opts.Namespace)
+
+	go s.loopRefreshBrokers()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				select {
+				case <-s.ctx.Done():
+				default:
+					glog.Errorf("mqtt accept: %v", err)
+				}
+				return
+			}
+			s.wg.Add(1)
+			go func(c net.Conn) {
+				defer s.wg.Done()
+				newConnection(s, c).serve()
+			}(conn)
+		}
+	}()
+	return nil
+}
+
+func (s *Server) Wait() error {
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Server) Close() error {
+	s.cancel()
+	if s.ln != nil {
+		_ = s.ln.Close()
+	}
+
+	s.connectionsLock.Lock()
+	for _, c := range s.connections {
+		c.close()
+	}
+	s.connectionsLock.Unlock()
+
+	s.publishersLock.Lock()
+	defer s.publishersLock.Unlock()
+	for t, publisher := range s.publishers {
+		if err := publisher.FinishPublish(); err != nil {
+			glog.Warningf("finish publishing to %v: %v", t, err)
+		}
+	}
+	return nil
+}
+
+// Addr returns the bound address of the server listener, or empty if not started.
+func (s *Server) Addr() string {
+	if s.ln == nil {
+		return ""
+	}
+	return s.ln.Addr().String()
+}
+
+func (s *Server) filerAddress() pb.ServerAddress {
+	if filers := s.filerClient.GetFilers(); len(filers) > 0 {
+		return filers[0]
+	}
+	return ""
+}
+
+func (s *Server) discover(clientType string) (addresses []string, err error) {
+	err = s.masterClient.WithClient(false, func(client master_pb.SeaweedClient) error {
+		resp, err := client.ListClusterNodes(context.Background(), &master_pb.ListClusterNodesRequest{
+			ClientType: clientType,
+			FilerGroup: s.opts.FilerGroup,
+			Limit:      1000,
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.ClusterNodes {
+			if node.Address != "" {
+				addresses = append(addresses, node.Address)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+func (s *Server) refreshBrokers() error {
+	brokers, err := s.discover(cluster.BrokerType)
+	if err != nil {
+		return fmt.Errorf("discover brokers: %w", err)
+	}
+	if len(brokers) == 0 {
+		return fmt.Errorf("no brokers discovered from masters %s", s.opts.Masters)
+	}
+	s.brokersLock.Lock()
+	s.brokers = brokers
+	s.brokersLock.Unlock()
+	return nil
+}
+
+func (s *Server) loopRefreshBrokers() {
+	ticker := time.NewTicker(clusterRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.refreshBrokers(); err != nil {
+				glog.Warningf("mqtt gateway: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Server) getBrokers() []string {
+	s.brokersLock.RLock()
+	defer s.brokersLock.RUnlock()
+	return s.brokers
+}
+
+func (s *Server) withBrokerClient(fn func(client mq_pb.SeaweedMessagingClient) error) (err error) {
+	for _, broker := range s.getBrokers() {
+		if err = pb.WithBrokerGrpcClient(false, broker, s.grpcDialOption, fn); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no brokers available")
+	}
+	return err
+}
+
+// listTopics returns the MQ topics of the namespace.
+func (s *Server) listTopics(namespace string) (topics []topic.Topic, err error) {
+	err = s.withBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		resp, err := client.ListTopics(s.ctx, &mq_pb.ListTopicsRequest{})
+		if err != nil {
+			return err
+		}
+		for _, t := range resp.Topics {
+			if t.Namespace == namespace {
+				topics = append(topics, topic.FromPbTopic(t))
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// publish stores the message into the MQ topic mapped from its MQTT topic.
+// The record key is the MQTT topic, so that messages of one MQTT topic stay in order.
+func (s *Server) publish(m *StoredMessage) error {
+	publisher, err := s.getPublisher(s.mapper.MapTopic(m.Topic))
+	if err != nil {
+		return err
+	}
+	return publisher.PublishRecord([]byte(m.Topic), m.ToRecord())
+}
+
+func (s *Server) getPublisher(t topic.Topic) (*pub_client.TopicPublisher, error) {
+	s.publishersLock.Lock()
+	defer s.publishersLock.Unlock()
+	if publisher, found := s.publishers[t]; found {
+		return publisher, nil
+	}
+
+	// keep the partition count of an existing topic, since configuring the topic
+	// with a different partition count would re-assign its partitions
+	partitionCount := s.opts.DefaultPartitions
+	err := s.withBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		conf, err := client.GetTopicConfiguration(s.ctx, &mq_pb.GetTopicConfigurationRequest{
+			Topic: t.ToPbTopic(),
+		})
+		if err != nil {
+			// the topic does not exist yet
+			return nil
+		}
+		if recordType := conf.MessageRecordType; recordType != nil && len(recordType.Fields) > 0 {
+			for _, field := range recordType.Fields {
+				if field.Name == RecordFieldTopic {
+					partitionCount = conf.PartitionCount
+					return nil
+				}
+			}
+			return fmt.Errorf("topic %v has a schema not written by the mqtt gateway", t)
+		}
+		partitionCount = conf.PartitionCount
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := pub_client.NewTopicPublisher(&pub_client.PublisherConfiguration{
+		Topic:          t,
+		PartitionCount: partitionCount,
+		Brokers:        s.getBrokers(),
+		PublisherName:  "mqtt-gateway",
+		RecordType:     MessageRecordType(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create publisher for %v: %w", t, err)
+	}
+	s.publishers[t] = publisher
+	return publisher, nil
+}
+
+// registerConnection makes the connection the owner of the client id,
+// and returns the previous connection of the same client id, which should be closed.
+func (s *Server) registerConnection(c *connection) (previous *connection) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+	previous = s.connections[c.clientId]
+	s.connections[c.clientId] = c
+	return
+}
+
+func (s *Server) unregisterConnection(c *connection) {
+	s.connectionsLock.Lock()
+	defer s.connectionsLock.Unlock()
+	if s.connections[c.clientId] == c {
+		delete(s.connections, c.clientId)
+	}
+}
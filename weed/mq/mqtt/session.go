@@ -0,0 +1,299 @@
+package mqtt
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/sub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	subscriberSlidingWindowSize = 16
+	subscriberMaxPartitionCount = 64
+)
+
+// session delivers the messages matching the subscriptions of a client.
+//
+// Each MQ topic that may hold matching messages is read by one subscriber of the consumer group
+// "mqtt.<client id>", so a message matching several subscriptions is delivered once, with the
+// maximum QoS of the matching subscriptions. The consumer group offsets only move after
+// QoS 1 messages are acknowledged, so a persistent session resumes from the undelivered messages.
+type session struct {
+	conn       *connection
+	persistent bool
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	lock          sync.Mutex
+	subscriptions map[string]Subscription // by topic filter
+	subscribers   map[topic.Topic]context.CancelFunc
+	// topics of the default namespace, listed when a subscription starts with a wildcard
+	namespaceTopics       map[topic.Topic]bool
+	isNamespaceTopicsRead bool
+}
+
+func newSession(conn *connection, subscriptions []Subscription, persistent bool) *session {
+	ctx, cancel := context.WithCancel(conn.server.ctx)
+	s := &session{
+		conn:            conn,
+		persistent:      persistent,
+		ctx:             ctx,
+		cancel:          cancel,
+		subscriptions:   make(map[string]Subscription),
+		subscribers:     make(map[topic.Topic]context.CancelFunc),
+		namespaceTopics: make(map[topic.Topic]bool),
+	}
+	for _, sub := range subscriptions {
+		s.subscriptions[sub.Filter] = sub
+	}
+	s.refreshSubscribers()
+	go s.loopRefreshNamespaceTopics()
+	return s
+}
+
+// stop stops the delivery, and returns the subscriptions to keep for a persistent session.
+func (s *session) stop() []Subscription {
+	s.cancel()
+	return s.getSubscriptions()
+}
+
+// subscribe adds or replaces the subscription of the topic filter, and returns true if it is new.
+func (s *session) subscribe(sub Subscription) (isNew bool) {
+	s.lock.Lock()
+	_, found := s.subscriptions[sub.Filter]
+	s.subscriptions[sub.Filter] = sub
+	s.lock.Unlock()
+	s.refreshSubscribers()
+	return !found
+}
+
+func (s *session) unsubscribe(filter string) (found bool) {
+	s.lock.Lock()
+	_, found = s.subscriptions[filter]
+	delete(s.subscriptions, filter)
+	s.lock.Unlock()
+	if found {
+		s.refreshSubscribers()
+	}
+	return
+}
+
+func (s *session) getSubscriptions() (subscriptions []Subscription) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, sub := range s.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Filter < subscriptions[j].Filter
+	})
+	return
+}
+
+// refreshSubscribers starts the subscribers of the MQ topics that may hold matching messages,
+// and stops the ones that are not needed any more.
+func (s *session) refreshSubscribers() {
+	mapper := s.conn.server.mapper
+	wanted := make(map[topic.Topic]bool)
+	hasWildcardNamespace := false
+
+	s.lock.Lock()
+	for filter := range s.subscriptions {
+		topics, isDefaultNamespace := mapper.MapFilter(filter)
+		for _, t := range topics {
+			wanted[t] = true
+		}
+		hasWildcardNamespace = hasWildcardNamespace || isDefaultNamespace
+	}
+	isNamespaceTopicsRead := s.isNamespaceTopicsRead
+	s.lock.Unlock()
+
+	if hasWildcardNamespace && !isNamespaceTopicsRead {
+		s.readNamespaceTopics()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if hasWildcardNamespace {
+		for t := range s.namespaceTopics {
+			wanted[t] = true
+		}
+	}
+	for t := range wanted {
+		if _, found := s.subscribers[t]; !found {
+			s.subscribers[t] = s.startSubscriber(t)
+		}
+	}
+	for t, cancel := range s.subscribers {
+		if !wanted[t] {
+			cancel()
+			delete(s.subscribers, t)
+		}
+	}
+}
+
+func (s *session) readNamespaceTopics() {
+	namespace := s.conn.server.mapper.DefaultNamespace()
+	topics, err := s.conn.server.listTopics(namespace)
+	if err != nil {
+		glog.Warningf("mqtt client %s list topics in %s: %v", s.conn.clientId, namespace, err)
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.isNamespaceTopicsRead = true
+	for _, t := range topics {
+		s.namespaceTopics[t] = true
+	}
+}
+
+// loopRefreshNamespaceTopics picks up the topics created in the default namespace
+// after a subscription starting with a wildcard.
+func (s *session) loopRefreshNamespaceTopics() {
+	ticker := time.NewTicker(topicRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.lock.Lock()
+			isNamespaceTopicsRead := s.isNamespaceTopicsRead
+			s.lock.Unlock()
+			if isNamespaceTopicsRead {
+				s.readNamespaceTopics()
+				s.refreshSubscribers()
+			}
+		}
+	}
+}
+
+func (s *session) startSubscriber(t topic.Topic) context.CancelFunc {
+	ctx, cancel := context.WithCancel(s.ctx)
+	server := s.conn.server
+
+	// a persistent session resumes from the last acknowledged message.
+	// A topic created after the session started is read from its beginning.
+	offsetType := schema_pb.OffsetType_RESET_TO_LATEST
+	if s.persistent {
+		offsetType = schema_pb.OffsetType_RESUME_OR_LATEST
+		if s.isNamespaceTopicsRead && t.Namespace == server.mapper.DefaultNamespace() && !s.namespaceTopics[t] {
+			offsetType = schema_pb.OffsetType_RESUME_OR_EARLIEST
+		}
+	}
+
+	go func() {
+		// make sure the topic exists, so that the messages published after subscribing are not missed
+		if _, err := server.getPublisher(t); err != nil {
+			glog.Warningf("mqtt client %s subscribe to %v: %v", s.conn.clientId, t, err)
+			return
+		}
+		subscriber := sub_client.NewTopicSubscriber(ctx, server.getBrokers(), &sub_client.SubscriberConfiguration{
+			ClientId:                s.conn.clientId,
+			ConsumerGroup:           "mqtt." + s.conn.clientId,
+			ConsumerGroupInstanceId: s.conn.clientId,
+			GrpcDialOption:          server.grpcDialOption,
+			MaxPartitionCount:       subscriberMaxPartitionCount,
+			SlidingWindowSize:       subscriberSlidingWindowSize,
+		}, &sub_client.ContentConfiguration{
+			Topic:      t,
+			OffsetType: offsetType,
+			OffsetTsNs: time.Now().UnixNano(),
+		}, make(chan sub_client.KeyedTimestamp, 1024))
+		subscriber.SetOnDataMessageWithErrorFn(func(m *mq_pb.SubscribeMessageResponse_Data) error {
+			return s.onMessage(m.Data.Value)
+		})
+		if err := subscriber.Subscribe(); err != nil {
+			glog.V(0).Infof("mqtt client %s subscribe to %v: %v", s.conn.clientId, t, err)
+		}
+	}()
+	return cancel
+}
+
+// onMessage delivers one message read from MQ. Returning an error leaves the message unacknowledged.
+func (s *session) onMessage(value []byte) error {
+	record := &schema_pb.RecordValue{}
+	if err := proto.Unmarshal(value, record); err != nil {
+		return nil
+	}
+	m, err := ParseStoredMessage(record)
+	if err != nil {
+		// not written by the mqtt gateway
+		return nil
+	}
+	p, matched := s.match(m)
+	if !matched {
+		return nil
+	}
+	return s.conn.deliver(p)
+}
+
+// match finds the subscriptions matching the message, and builds the PUBLISH packet to the client.
+func (s *session) match(m *StoredMessage) (p *PublishPacket, matched bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p = &PublishPacket{
+		Message: Message{
+			Topic:   m.Topic,
+			Payload: m.Payload,
+		},
+	}
+	var subscriptionIds []uint32
+	for _, sub := range s.subscriptions {
+		if !MatchTopic(sub.Filter, m.Topic) || sub.NoLocal && m.ClientId == s.conn.clientId {
+			continue
+		}
+		matched = true
+		p.QoS = max(p.QoS, min(sub.QoS, m.QoS))
+		p.Retain = p.Retain || sub.RetainAsPublished && m.Retain
+		if sub.SubscriptionId > 0 {
+			subscriptionIds = append(subscriptionIds, sub.SubscriptionId)
+		}
+	}
+	if matched && s.conn.version >= Version5 {
+		p.Properties = newPublishProperties(m, subscriptionIds)
+	}
+	return
+}
+
+// sendRetained sends the retained messages matching a new subscription.
+func (s *session) sendRetained(sub Subscription) {
+	go func() {
+		err := s.conn.server.store.EachRetained(sub.Filter, func(m *StoredMessage) error {
+			p := &PublishPacket{
+				Message: Message{
+					Topic:   m.Topic,
+					Payload: m.Payload,
+					QoS:     min(sub.QoS, m.QoS),
+					Retain:  true,
+				},
+			}
+			if s.conn.version >= Version5 {
+				var subscriptionIds []uint32
+				if sub.SubscriptionId > 0 {
+					subscriptionIds = append(subscriptionIds, sub.SubscriptionId)
+				}
+				p.Properties = newPublishProperties(m, subscriptionIds)
+			}
+			return s.conn.deliver(p)
+		})
+		if err != nil && err != errConnectionClosed {
+			glog.Warningf("mqtt client %s send retained messages of %s: %v", s.conn.clientId, sub.Filter, err)
+		}
+	}()
+}
+
+func newPublishProperties(m *StoredMessage, subscriptionIds []uint32) *Properties {
+	properties := &Properties{SubscriptionIds: subscriptionIds}
+	if contentType := m.Properties.String(PropContentType); contentType != "" {
+		properties.SetString(PropContentType, contentType)
+	}
+	return properties
+}
@@ -0,0 +1,146 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// The gateway keeps the state shared by all gateway instances on the filer:
+//
+//	/etc/mqtt/sessions/<client id>       persistent sessions with their subscriptions
+//	/etc/mqtt/retained/<escaped topic>   the retained message of each topic
+//
+// The delivery progress of a persistent session is the offset of its MQ consumer group.
+const (
+	StoreDir    = "/etc/mqtt"
+	sessionsDir = StoreDir + "/sessions"
+	retainedDir = StoreDir + "/retained"
+
+	// SessionNeverExpires is the MQTT 5.0 session expiry interval of sessions that never expire.
+	SessionNeverExpires = 0xFFFFFFFF
+)
+
+type FilerClient interface {
+	WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error
+}
+
+// SessionState is the persisted state of a session that outlives its connection.
+type SessionState struct {
+	ClientId       string         `json:"clientId"`
+	Subscriptions  []Subscription `json:"subscriptions"`
+	ExpiryInterval uint32         `json:"expiryInterval"` // seconds after disconnecting
+	DisconnectedAt int64          `json:"disconnectedAt"` // unix seconds, 0 while connected
+}
+
+func (s *SessionState) isExpired(now time.Time) bool {
+	if s.DisconnectedAt == 0 || s.ExpiryInterval == SessionNeverExpires {
+		return false
+	}
+	return now.Unix() > s.DisconnectedAt+int64(s.ExpiryInterval)
+}
+
+type Store struct {
+	filerClient FilerClient
+}
+
+func NewStore(filerClient FilerClient) *Store {
+	return &Store{filerClient: filerClient}
+}
+
+// LoadSession returns nil if the client has no session, or the session has expired.
+func (s *Store) LoadSession(clientId string) (*SessionState, error) {
+	var data []byte
+	err := s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+		data, err = filer.ReadInsideFiler(client, sessionsDir, url.PathEscape(clientId))
+		return err
+	})
+	if errors.Is(err, filer_pb.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read session %s: %w", clientId, err)
+	}
+	state := &SessionState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse session %s: %w", clientId, err)
+	}
+	if state.isExpired(time.Now()) {
+		return nil, s.DeleteSession(clientId)
+	}
+	return state, nil
+}
+
+func (s *Store) SaveSession(state *SessionState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, sessionsDir, url.PathEscape(state.ClientId), data)
+	})
+}
+
+func (s *Store) DeleteSession(clientId string) error {
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		err := filer_pb.DoRemove(context.Background(), client, sessionsDir, url.PathEscape(clientId), true, false, true, false, nil)
+		if err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+			return fmt.Errorf("delete session %s: %w", clientId, err)
+		}
+		return nil
+	})
+}
+
+// SaveRetained keeps the message as the retained message of its topic.
+// A message with an empty payload removes the retained message.
+func (s *Store) SaveRetained(m *StoredMessage) error {
+	if len(m.Payload) == 0 {
+		return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			err := filer_pb.DoRemove(context.Background(), client, retainedDir, url.PathEscape(m.Topic), true, false, true, false, nil)
+			if err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+				return fmt.Errorf("delete retained message of %s: %w", m.Topic, err)
+			}
+			return nil
+		})
+	}
+	data, err := proto.Marshal(m.ToRecord())
+	if err != nil {
+		return err
+	}
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, retainedDir, url.PathEscape(m.Topic), data)
+	})
+}
+
+// EachRetained calls fn with the retained messages of the topics matching the filter.
+func (s *Store) EachRetained(filter string, fn func(m *StoredMessage) error) error {
+	return s.filerClient.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		err := filer_pb.SeaweedList(context.Background(), client, retainedDir, "", func(entry *filer_pb.Entry, isLast bool) error {
+			name, err := url.PathUnescape(entry.Name)
+			if err != nil || entry.IsDirectory || !MatchTopic(filter, name) {
+				return nil
+			}
+			record := &schema_pb.RecordValue{}
+			if err := proto.Unmarshal(entry.Content, record); err != nil {
+				return nil
+			}
+			m, err := ParseStoredMessage(record)
+			if err != nil {
+				return nil
+			}
+			return fn(m)
+		}, "", false, 0)
+		if errors.Is(err, filer_pb.ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,169 @@
+package mqtt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+)
+
+// ValidateTopicName checks the topic name of a published message, which has no wildcards.
+func ValidateTopicName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty topic name")
+	}
+	if strings.ContainsAny(name, "+#\x00") {
+		return fmt.Errorf("invalid topic name %q", name)
+	}
+	return nil
+}
+
+// ValidateTopicFilter checks a subscription topic filter.
+// "+" matches one level, and "#" matches any remaining levels.
+func ValidateTopicFilter(filter string) error {
+	if filter == "" || strings.Contains(filter, "\x00") {
+		return fmt.Errorf("invalid topic filter %q", filter)
+	}
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		if strings.Contains(level, "#") && (level != "#" || i != len(levels)-1) {
+			return fmt.Errorf("invalid multi-level wildcard in topic filter %q", filter)
+		}
+		if strings.Contains(level, "+") && level != "+" {
+			return fmt.Errorf("invalid single-level wildcard in topic filter %q", filter)
+		}
+	}
+	return nil
+}
+
+// MatchTopic checks whether the topic name matches the topic filter.
+// Wildcards at the first level do not match topics starting with "$".
+func MatchTopic(filter, name string) bool {
+	if strings.HasPrefix(name, "$") && (strings.HasPrefix(filter, "+") || strings.HasPrefix(filter, "#")) {
+		return false
+	}
+	filterLevels := strings.Split(filter, "/")
+	nameLevels := strings.Split(name, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(nameLevels) {
+			return false
+		}
+		if level != "+" && level != nameLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(nameLevels)
+}
+
+// filtersOverlap checks whether some topic name could match both filters.
+func filtersOverlap(a, b string) bool {
+	aLevels, bLevels := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; ; i++ {
+		if i >= len(aLevels) || i >= len(bLevels) {
+			return len(aLevels) == len(bLevels)
+		}
+		if aLevels[i] == "#" || bLevels[i] == "#" {
+			return true
+		}
+		if aLevels[i] != "+" && bLevels[i] != "+" && aLevels[i] != bLevels[i] {
+			return false
+		}
+	}
+}
+
+// TopicMapping stores the messages of the MQTT topics matching the filter into the MQ topic.
+type TopicMapping struct {
+	Filter string
+	Topic  topic.Topic
+}
+
+// ParseTopicMappings parses mappings like "sensors/#=iot.sensors,alerts/+=iot.alerts".
+func ParseTopicMappings(s string) (mappings []TopicMapping, err error) {
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		filter, target, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("topic mapping %q is not in the form of <filter>=<namespace>.<topic>", item)
+		}
+		if err = ValidateTopicFilter(filter); err != nil {
+			return nil, err
+		}
+		namespace, name, found := strings.Cut(target, ".")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("topic mapping %q target should be <namespace>.<topic>", item)
+		}
+		mappings = append(mappings, TopicMapping{
+			Filter: filter,
+			Topic:  topic.NewTopic(namespace, name),
+		})
+	}
+	return
+}
+
+// TopicMapper maps MQTT topics to MQ topics. The first matching mapping wins.
+// Without a matching mapping, the message goes to the default namespace,
+// into the MQ topic named after the first level of the MQTT topic.
+type TopicMapper struct {
+	defaultNamespace string
+	mappings         []TopicMapping
+}
+
+func NewTopicMapper(defaultNamespace string, mappings []TopicMapping) *TopicMapper {
+	return &TopicMapper{
+		defaultNamespace: defaultNamespace,
+		mappings:         mappings,
+	}
+}
+
+func (m *TopicMapper) MapTopic(name string) topic.Topic {
+	for _, mapping := range m.mappings {
+		if MatchTopic(mapping.Filter, name) {
+			return mapping.Topic
+		}
+	}
+	firstLevel, _, _ := strings.Cut(name, "/")
+	return topic.NewTopic(m.defaultNamespace, sanitizeTopicName(firstLevel))
+}
+
+// MapFilter returns the MQ topics that may hold messages matching the filter.
+// If the first level of the filter is a wildcard, any topic in the default namespace
+// may hold matching messages, and isDefaultNamespace is true.
+func (m *TopicMapper) MapFilter(filter string) (topics []topic.Topic, isDefaultNamespace bool) {
+	seen := make(map[topic.Topic]bool)
+	for _, mapping := range m.mappings {
+		if filtersOverlap(mapping.Filter, filter) && !seen[mapping.Topic] {
+			seen[mapping.Topic] = true
+			topics = append(topics, mapping.Topic)
+		}
+	}
+	firstLevel, _, _ := strings.Cut(filter, "/")
+	if firstLevel == "+" || firstLevel == "#" {
+		return topics, true
+	}
+	if t := topic.NewTopic(m.defaultNamespace, sanitizeTopicName(firstLevel)); !seen[t] {
+		topics = append(topics, t)
+	}
+	return topics, false
+}
+
+func (m *TopicMapper) DefaultNamespace() string {
+	return m.defaultNamespace
+}
+
+func sanitizeTopicName(level string) string {
+	if level == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, level)
+}
@@ -0,0 +1,89 @@
+package mqtt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		filter, name string
+		expected     bool
+	}{
+		{"a/b/c", "a/b/c", true},
+		{"a/b/c", "a/b", false},
+		{"a/+/c", "a/b/c", true},
+		{"a/+/c", "a/b/d", false},
+		{"a/+", "a/b/c", false},
+		{"a/#", "a", true},
+		{"a/#", "a/b/c", true},
+		{"#", "a/b", true},
+		{"+/+", "a/b", true},
+		{"+", "/", false},
+		{"+/+", "/a", true},
+		{"#", "$SYS/uptime", false},
+		{"+/uptime", "$SYS/uptime", false},
+		{"$SYS/#", "$SYS/uptime", true},
+	}
+	for _, tt := range tests {
+		if actual := MatchTopic(tt.filter, tt.name); actual != tt.expected {
+			t.Errorf("MatchTopic(%q, %q) = %v, expected %v", tt.filter, tt.name, actual, tt.expected)
+		}
+	}
+}
+
+func TestValidateTopicFilter(t *testing.T) {
+	for _, filter := range []string{"a", "a/b", "+", "#", "a/+/b", "a/#", "+/+/#", "/"} {
+		if err := ValidateTopicFilter(filter); err != nil {
+			t.Errorf("filter %q: %v", filter, err)
+		}
+	}
+	for _, filter := range []string{"", "a#", "a/#/b", "a+", "a/b+/c"} {
+		if err := ValidateTopicFilter(filter); err == nil {
+			t.Errorf("filter %q should be invalid", filter)
+		}
+	}
+	if err := ValidateTopicName("a/+"); err == nil {
+		t.Errorf("topic name with a wildcard should be invalid")
+	}
+}
+
+func TestTopicMapper(t *testing.T) {
+	mappings, err := ParseTopicMappings("sensors/#=iot.sensors, alerts/+=iot.alerts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapper := NewTopicMapper("mqtt", mappings)
+
+	for name, expected := range map[string]topic.Topic{
+		"sensors/kitchen/temperature": topic.NewTopic("iot", "sensors"),
+		"alerts/fire":                 topic.NewTopic("iot", "alerts"),
+		"alerts/fire/kitchen":         topic.NewTopic("mqtt", "alerts"),
+		"home/kitchen":                topic.NewTopic("mqtt", "home"),
+		"/leading":                    topic.NewTopic("mqtt", "_"),
+		"my.home/x":                   topic.NewTopic("mqtt", "my_home"),
+	} {
+		if actual := mapper.MapTopic(name); actual != expected {
+			t.Errorf("MapTopic(%q) = %v, expected %v", name, actual, expected)
+		}
+	}
+
+	topics, isDefaultNamespace := mapper.MapFilter("alerts/#")
+	expected := []topic.Topic{topic.NewTopic("iot", "alerts"), topic.NewTopic("mqtt", "alerts")}
+	if !reflect.DeepEqual(topics, expected) || isDefaultNamespace {
+		t.Errorf("MapFilter(alerts/#) = %v %v, expected %v", topics, isDefaultNamespace, expected)
+	}
+	topics, isDefaultNamespace = mapper.MapFilter("+/kitchen/#")
+	expected = []topic.Topic{topic.NewTopic("iot", "sensors")}
+	if !reflect.DeepEqual(topics, expected) || !isDefaultNamespace {
+		t.Errorf("MapFilter(+/kitchen/#) = %v %v, expected %v", topics, isDefaultNamespace, expected)
+	}
+
+	for _, invalid := range []string{"sensors/#", "sensors/#=iot", "a#=iot.x"} {
+		if _, err := ParseTopicMappings(invalid); err == nil {
+			t.Errorf("mapping %q should be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,691 @@
+package sqs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+const (
+	maxBatchEntries           = 10
+	maxReceiveMessages        = 10
+	maxListQueuesResults      = 1000
+	maxVisibilityTimeout      = 43200
+	maxWaitTimeSeconds        = 20
+	maxDeduplicationIdLength  = 128
+	maxMessageGroupIdLength   = 128
+	fifoDelaySecondsForbidden = "The request include parameter that is not valid for this queue type. Reason: DelaySeconds is not supported on a per-message basis for FIFO queues."
+)
+
+var batchEntryIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,80}$`)
+
+// dispatch authorizes and runs an action, returning its result.
+func (s *Server) dispatch(r *http.Request, action string, params []byte) (interface{}, error) {
+	permission, found := actionPermissions[action]
+	if !found {
+		return nil, errInvalidAction(action)
+	}
+
+	var req interface{}
+	switch action {
+	case "CreateQueue":
+		req = &CreateQueueRequest{}
+	case "GetQueueUrl":
+		req = &GetQueueUrlRequest{}
+	case "ListQueues":
+		req = &ListQueuesRequest{}
+	case "DeleteQueue", "PurgeQueue":
+		req = &QueueUrlRequest{}
+	case "GetQueueAttributes":
+		req = &GetQueueAttributesRequest{}
+	case "SetQueueAttributes":
+		req = &SetQueueAttributesRequest{}
+	case "SendMessage":
+		req = &SendMessageRequest{}
+	case "SendMessageBatch":
+		req = &SendMessageBatchRequest{}
+	case "ReceiveMessage":
+		req = &ReceiveMessageRequest{}
+	case "DeleteMessage":
+		req = &DeleteMessageRequest{}
+	case "DeleteMessageBatch":
+		req = &DeleteMessageBatchRequest{}
+	case "ChangeMessageVisibility":
+		req = &ChangeMessageVisibilityRequest{}
+	case "ChangeMessageVisibilityBatch":
+		req = &ChangeMessageVisibilityBatchRequest{}
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, req); err != nil {
+			return nil, errInvalidParameter("Invalid request parameters: %v", err)
+		}
+	}
+
+	switch req := req.(type) {
+	case *CreateQueueRequest:
+		if err := s.authorize(r, permission, req.QueueName); err != nil {
+			return nil, err
+		}
+		return s.createQueue(r, req)
+	case *GetQueueUrlRequest:
+		if err := s.authorize(r, permission, req.QueueName); err != nil {
+			return nil, err
+		}
+		return s.getQueueUrl(r, req)
+	case *ListQueuesRequest:
+		if err := s.authorize(r, permission, ""); err != nil {
+			return nil, err
+		}
+		return s.listQueues(r, req)
+	}
+
+	// the other actions name the queue by its URL
+	var queueUrl string
+	switch req := req.(type) {
+	case *QueueUrlRequest:
+		queueUrl = req.QueueUrl
+	case *GetQueueAttributesRequest:
+		queueUrl = req.QueueUrl
+	case *SetQueueAttributesRequest:
+		queueUrl = req.QueueUrl
+	case *SendMessageRequest:
+		queueUrl = req.QueueUrl
+	case *SendMessageBatchRequest:
+		queueUrl = req.QueueUrl
+	case *ReceiveMessageRequest:
+		queueUrl = req.QueueUrl
+	case *DeleteMessageRequest:
+		queueUrl = req.QueueUrl
+	case *DeleteMessageBatchRequest:
+		queueUrl = req.QueueUrl
+	case *ChangeMessageVisibilityRequest:
+		queueUrl = req.QueueUrl
+	case *ChangeMessageVisibilityBatchRequest:
+		queueUrl = req.QueueUrl
+	}
+	name, err := queueNameFromUrl(queueUrl)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.authorize(r, permission, name); err != nil {
+		return nil, err
+	}
+	if action == "DeleteQueue" {
+		return s.deleteQueue(name)
+	}
+	q, err := s.getQueue(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req := req.(type) {
+	case *QueueUrlRequest:
+		return s.purgeQueue(q)
+	case *GetQueueAttributesRequest:
+		return s.getQueueAttributes(q, req)
+	case *SetQueueAttributesRequest:
+		return s.setQueueAttributes(q, req)
+	case *SendMessageRequest:
+		return s.sendMessage(r, q, req)
+	case *SendMessageBatchRequest:
+		return s.sendMessageBatch(r, q, req)
+	case *ReceiveMessageRequest:
+		return s.receiveMessage(r, q, req)
+	case *DeleteMessageRequest:
+		return nil, q.state.delete(req.ReceiptHandle)
+	case *DeleteMessageBatchRequest:
+		return s.deleteMessageBatch(q, req)
+	case *ChangeMessageVisibilityRequest:
+		return nil, changeVisibility(q, req.ReceiptHandle, req.VisibilityTimeout)
+	case *ChangeMessageVisibilityBatchRequest:
+		return s.changeMessageVisibilityBatch(q, req)
+	}
+	return nil, errInvalidAction(action)
+}
+
+func (s *Server) createQueue(r *http.Request, req *CreateQueueRequest) (*CreateQueueResult, error) {
+	if req.QueueName == "" {
+		return nil, errMissingParameter("QueueName")
+	}
+	if err := validateQueueName(req.QueueName); err != nil {
+		return nil, err
+	}
+	isFifo := strings.HasSuffix(req.QueueName, fifoSuffix)
+	attributes, err := validateAttributes(req.Attributes, isFifo, true)
+	if err != nil {
+		return nil, err
+	}
+	result := &CreateQueueResult{QueueUrl: s.queueUrl(r, req.QueueName)}
+
+	existing, err := s.store.LoadQueue(req.QueueName)
+	if err != nil {
+		return nil, errInternal(err)
+	}
+	if existing != nil {
+		if !existing.sameAttributes(attributes) {
+			return nil, errQueueAlreadyExists(req.QueueName)
+		}
+		return result, nil
+	}
+	if err = s.checkDeadLetterQueue(attributes[AttrRedrivePolicy], isFifo); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	q := &Queue{
+		Name:                  req.QueueName,
+		Topic:                 topicName(req.QueueName, now),
+		Attributes:            attributes,
+		CreatedTimestamp:      now.Unix(),
+		LastModifiedTimestamp: now.Unix(),
+	}
+	if err = s.configureTopic(q, s.opts.DefaultPartitions); err != nil {
+		return nil, errInternal(err)
+	}
+	if err = s.store.SaveQueue(q); err != nil {
+		return nil, errInternal(err)
+	}
+	return result, nil
+}
+
+// checkDeadLetterQueue checks that the dead letter queue of a redrive policy exists, and is of the same type.
+func (s *Server) checkDeadLetterQueue(redrivePolicy string, isFifo bool) error {
+	policy, err := parseRedrivePolicy(redrivePolicy)
+	if err != nil || policy == nil {
+		return err
+	}
+	dlqName := queueNameFromArn(policy.DeadLetterTargetArn)
+	dlq, err := s.store.LoadQueue(dlqName)
+	if err != nil {
+		return errInternal(err)
+	}
+	if dlq == nil {
+		return errInvalidParameter("Value %s for parameter RedrivePolicy is invalid. Reason: Dead letter target does not exist.", redrivePolicy)
+	}
+	if dlq.IsFifo() != isFifo {
+		return errInvalidParameter("Value %s for parameter RedrivePolicy is invalid. Reason: Dead-letter queue must be same type of queue as the source.", redrivePolicy)
+	}
+	return nil
+}
+
+// configureTopic creates or updates the MQ topic of a queue, retaining the messages for the queue retention period.
+// A zero partition count keeps the partitions of an existing topic.
+func (s *Server) configureTopic(q *Queue, partitionCount int32) error {
+	t := topic.NewTopic(s.opts.Namespace, q.Topic)
+	return s.withBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		if partitionCount == 0 {
+			conf, err := client.GetTopicConfiguration(s.ctx, &mq_pb.GetTopicConfigurationRequest{
+				Topic: t.ToPbTopic(),
+			})
+			if err != nil {
+				return err
+			}
+			partitionCount = conf.PartitionCount
+		}
+		_, err := client.ConfigureTopic(s.ctx, &mq_pb.ConfigureTopicRequest{
+			Topic:          t.ToPbTopic(),
+			PartitionCount: partitionCount,
+			Retention: &mq_pb.TopicRetention{
+				RetentionSeconds: int64(q.intAttribute(AttrMessageRetentionPeriod)),
+				Enabled:          true,
+			},
+		})
+		return err
+	})
+}
+
+func (s *Server) getQueueUrl(r *http.Request, req *GetQueueUrlRequest) (*GetQueueUrlResult, error) {
+	if req.QueueName == "" {
+		return nil, errMissingParameter("QueueName")
+	}
+	if err := validateQueueName(req.QueueName); err != nil {
+		return nil, errQueueDoesNotExist()
+	}
+	if _, err := s.getQueue(req.QueueName); err != nil {
+		return nil, err
+	}
+	return &GetQueueUrlResult{QueueUrl: s.queueUrl(r, req.QueueName)}, nil
+}
+
+// listQueues pages by queue name, the next token being the last name returned.
+func (s *Server) listQueues(r *http.Request, req *ListQueuesRequest) (*ListQueuesResult, error) {
+	maxResults := intValue(req.MaxResults, maxListQueuesResults)
+	if maxResults < 1 || maxResults > maxListQueuesResults {
+		return nil, errInvalidParameter("Value %d for parameter MaxResults is invalid. Reason: must be between 1 and %d.", maxResults, maxListQueuesResults)
+	}
+	if req.NextToken != "" && req.MaxResults == nil {
+		return nil, errInvalidParameter("MaxResults is required with NextToken.")
+	}
+	names, err := s.store.ListQueues(req.QueueNamePrefix)
+	if err != nil {
+		return nil, errInternal(err)
+	}
+	result := &ListQueuesResult{}
+	lastName := ""
+	for _, name := range names {
+		if req.NextToken != "" && name <= req.NextToken {
+			continue
+		}
+		if s.authorize(r, actionPermissions["ListQueues"], name) != nil {
+			continue
+		}
+		if len(result.QueueUrls) == maxResults {
+			result.NextToken = lastName
+			break
+		}
+		result.QueueUrls = append(result.QueueUrls, s.queueUrl(r, name))
+		lastName = name
+	}
+	return result, nil
+}
+
+func (s *Server) deleteQueue(name string) (interface{}, error) {
+	q, err := s.store.LoadQueue(name)
+	if err != nil {
+		return nil, errInternal(err)
+	}
+	if q == nil {
+		return nil, errQueueDoesNotExist()
+	}
+	if err = s.store.DeleteQueue(name); err != nil {
+		return nil, errInternal(err)
+	}
+	s.forgetQueue(name)
+	return nil, nil
+}
+
+func (s *Server) getQueueAttributes(q *queueRuntime, req *GetQueueAttributesRequest) (*GetQueueAttributesResult, error) {
+	def := q.definition()
+	all := make(map[string]string)
+	for name := range intAttributes {
+		all[name] = strconv.Itoa(def.intAttribute(name))
+	}
+	if !def.IsFifo() {
+		delete(all, AttrKmsDataKeyReusePeriodSeconds)
+	}
+	for name, value := range def.Attributes {
+		all[name] = value
+	}
+	visible, inflight := q.state.counts(time.Now())
+	all[AttrQueueArn] = s.queueArn(def.Name)
+	all[AttrApproximateNumberOfMessages] = strconv.Itoa(visible)
+	all[AttrApproximateNumberOfMessagesNotVisible] = strconv.Itoa(inflight)
+	all[AttrApproximateNumberOfMessagesDelayed] = "0"
+	all[AttrCreatedTimestamp] = strconv.FormatInt(def.CreatedTimestamp, 10)
+	all[AttrLastModifiedTimestamp] = strconv.FormatInt(def.LastModifiedTimestamp, 10)
+
+	result := &GetQueueAttributesResult{Attributes: make(attributeMap)}
+	for _, name := range req.AttributeNames {
+		if name == "All" {
+			result.Attributes = all
+			break
+		}
+		value, found := all[name]
+		if !found {
+			if _, known := intAttributes[name]; !known && !opaqueAttributes[name] && name != AttrRedrivePolicy &&
+				name != AttrFifoQueue && name != AttrContentBasedDeduplication {
+				return nil, errInvalidAttributeName(name)
+			}
+			continue
+		}
+		result.Attributes[name] = value
+	}
+	return result, nil
+}
+
+func (s *Server) setQueueAttributes(q *queueRuntime, req *SetQueueAttributesRequest) (interface{}, error) {
+	current := q.definition()
+	attributes, err := validateAttributes(req.Attributes, current.IsFifo(), false)
+	if err != nil {
+		return nil, err
+	}
+	if redrivePolicy, found := attributes[AttrRedrivePolicy]; found {
+		if err = s.checkDeadLetterQueue(redrivePolicy, current.IsFifo()); err != nil {
+			return nil, err
+		}
+	}
+
+	updated := *current
+	updated.Attributes = make(map[string]string)
+	for name, value := range current.Attributes {
+		updated.Attributes[name] = value
+	}
+	for name, value := range attributes {
+		if value == "" && name == AttrRedrivePolicy {
+			delete(updated.Attributes, name)
+			continue
+		}
+		updated.Attributes[name] = value
+	}
+	updated.LastModifiedTimestamp = time.Now().Unix()
+
+	if updated.intAttribute(AttrMessageRetentionPeriod) != current.intAttribute(AttrMessageRetentionPeriod) {
+		if err = s.configureTopic(&updated, 0); err != nil {
+			return nil, errInternal(err)
+		}
+	}
+	if err = s.store.SaveQueue(&updated); err != nil {
+		return nil, errInternal(err)
+	}
+	q.setDefinition(&updated)
+	return nil, nil
+}
+
+// purgeQueue deletes the messages sent before now. The messages in the MQ topic are skipped
+// when read, by all gateways, since the purge time is kept in the queue definition.
+func (s *Server) purgeQueue(q *queueRuntime) (interface{}, error) {
+	updated := *q.definition()
+	updated.PurgedTimestamp = time.Now().UnixMilli()
+	if err := s.store.SaveQueue(&updated); err != nil {
+		return nil, errInternal(err)
+	}
+	q.setDefinition(&updated)
+	q.state.purge()
+	return nil, nil
+}
+
+func (s *Server) sendMessage(r *http.Request, q *queueRuntime, req *SendMessageRequest) (*SendMessageResult, error) {
+	return s.send(r, q, req, time.Now())
+}
+
+func (s *Server) sendMessageBatch(r *http.Request, q *queueRuntime, req *SendMessageBatchRequest) (*SendMessageBatchResult, error) {
+	ids := make([]string, len(req.Entries))
+	for i, entry := range req.Entries {
+		ids[i] = entry.Id
+	}
+	if err := validateBatchEntryIds(ids); err != nil {
+		return nil, err
+	}
+	totalSize := 0
+	for _, entry := range req.Entries {
+		totalSize += len(entry.MessageBody)
+	}
+	if maxSize := q.definition().intAttribute(AttrMaximumMessageSize); totalSize > maxSize {
+		return nil, newError("AWS.SimpleQueueService.BatchRequestTooLong", "BatchRequestTooLong", http.StatusBadRequest,
+			"Batch requests cannot be longer than %d bytes.", maxSize)
+	}
+
+	result := &SendMessageBatchResult{
+		Successful: []SendMessageBatchResultEntry{},
+		Failed:     []BatchResultErrorEntry{},
+	}
+	now := time.Now()
+	for _, entry := range req.Entries {
+		sent, err := s.send(r, q, &entry.SendMessageRequest, now)
+		if err != nil {
+			result.Failed = append(result.Failed, batchError(entry.Id, err))
+			continue
+		}
+		result.Successful = append(result.Successful, SendMessageBatchResultEntry{Id: entry.Id, SendMessageResult: *sent})
+	}
+	return result, nil
+}
+
+// send validates and publishes a message. A FIFO message with a deduplication id seen
+// within the deduplication interval is not sent again, and returns the earlier result.
+func (s *Server) send(r *http.Request, q *queueRuntime, req *SendMessageRequest, now time.Time) (*SendMessageResult, error) {
+	def := q.definition()
+	if req.MessageBody == "" {
+		return nil, errMissingParameter("MessageBody")
+	}
+	if maxSize := def.intAttribute(AttrMaximumMessageSize); len(req.MessageBody) > maxSize {
+		return nil, errInvalidParameter("One or more parameters are invalid. Reason: Message must be shorter than %d bytes.", maxSize)
+	}
+	if err := validateMessageAttributes(req.MessageAttributes); err != nil {
+		return nil, err
+	}
+
+	delay := def.intAttribute(AttrDelaySeconds)
+	if req.DelaySeconds != nil {
+		if def.IsFifo() {
+			return nil, errInvalidParameter(fifoDelaySecondsForbidden)
+		}
+		delay = int(*req.DelaySeconds)
+		if r := intAttributes[AttrDelaySeconds]; delay < r.min || delay > r.max {
+			return nil, errInvalidParameter("Value %d for parameter DelaySeconds is invalid. Reason: must be between %d and %d.", delay, r.min, r.max)
+		}
+	}
+
+	stored := &StoredMessage{
+		MessageId:         uuid.NewString(),
+		Body:              req.MessageBody,
+		MessageAttributes: req.MessageAttributes,
+		SentTimestamp:     now.UnixMilli(),
+		SenderId:          s.senderId(r),
+	}
+	result := &SendMessageResult{
+		MessageId:              stored.MessageId,
+		MD5OfMessageBody:       md5Hex([]byte(req.MessageBody)),
+		MD5OfMessageAttributes: md5OfMessageAttributes(req.MessageAttributes),
+	}
+
+	if !def.IsFifo() {
+		if req.MessageGroupId != "" || req.MessageDeduplicationId != "" {
+			return nil, errInvalidParameter("The request include parameter that is not valid for this queue type. Reason: MessageGroupId and MessageDeduplicationId are only valid for FIFO queues.")
+		}
+		if err := q.publish(stored, time.Duration(delay)*time.Second); err != nil {
+			return nil, errInternal(err)
+		}
+		return result, nil
+	}
+
+	if req.MessageGroupId == "" {
+		return nil, errMissingParameter("MessageGroupId")
+	}
+	if len(req.MessageGroupId) > maxMessageGroupIdLength || len(req.MessageDeduplicationId) > maxDeduplicationIdLength {
+		return nil, errInvalidParameter("MessageGroupId and MessageDeduplicationId must be up to 128 characters long.")
+	}
+	deduplicationId := req.MessageDeduplicationId
+	if deduplicationId == "" {
+		if !def.ContentBasedDeduplication() {
+			return nil, errInvalidParameter("The queue should either have ContentBasedDeduplication enabled or MessageDeduplicationId provided explicitly.")
+		}
+		sum := sha256.Sum256([]byte(req.MessageBody))
+		deduplicationId = hex.EncodeToString(sum[:])
+	}
+	stored.MessageGroupId = req.MessageGroupId
+	stored.MessageDeduplicationId = deduplicationId
+	stored.SequenceNumber = q.nextSequenceNumber(now)
+
+	if existing, found := q.state.deduplicate(deduplicationId, &sendResult{messageId: stored.MessageId, sequenceNumber: stored.SequenceNumber}, now); found {
+		result.MessageId, result.SequenceNumber = existing.messageId, existing.sequenceNumber
+		return result, nil
+	}
+	if err := q.publish(stored, time.Duration(delay)*time.Second); err != nil {
+		q.state.forgetDeduplication(deduplicationId)
+		return nil, errInternal(err)
+	}
+	result.SequenceNumber = stored.SequenceNumber
+	return result, nil
+}
+
+// senderId is the access key of a signed request, or the client address.
+func (s *Server) senderId(r *http.Request) string {
+	authorization := r.Header.Get("Authorization")
+	if _, credential, found := strings.Cut(authorization, "Credential="); found {
+		accessKey, _, _ := strings.Cut(credential, "/")
+		return accessKey
+	}
+	host := r.RemoteAddr
+	if i := strings.LastIndex(host, ":"); i > 0 {
+		host = host[:i]
+	}
+	return host
+}
+
+func validateMessageAttributes(attributes map[string]MessageAttributeValue) error {
+	for name, value := range attributes {
+		if name == "" || len(name) > 256 || strings.HasPrefix(strings.ToLower(name), "aws.") || strings.HasPrefix(strings.ToLower(name), "amazon.") {
+			return errInvalidParameter("The message attribute name %q is invalid.", name)
+		}
+		baseType, _, _ := strings.Cut(value.DataType, ".")
+		switch baseType {
+		case "String", "Number":
+			if value.StringValue == "" {
+				return errInvalidParameter("The message attribute %q must contain a non-empty message attribute value for message attribute type %q.", name, value.DataType)
+			}
+		case "Binary":
+			if len(value.BinaryValue) == 0 {
+				return errInvalidParameter("The message attribute %q must contain a non-empty message attribute value for message attribute type %q.", name, value.DataType)
+			}
+		default:
+			return errInvalidParameter("The message attribute %q has an invalid message attribute type %q.", name, value.DataType)
+		}
+	}
+	return nil
+}
+
+func (s *Server) receiveMessage(r *http.Request, q *queueRuntime, req *ReceiveMessageRequest) (*ReceiveMessageResult, error) {
+	def := q.definition()
+	maxMessages := intValue(req.MaxNumberOfMessages, 1)
+	if maxMessages < 1 || maxMessages > maxReceiveMessages {
+		return nil, errInvalidParameter("Value %d for parameter MaxNumberOfMessages is invalid. Reason: must be between 1 and %d.", maxMessages, maxReceiveMessages)
+	}
+	visibilityTimeout := intValue(req.VisibilityTimeout, def.intAttribute(AttrVisibilityTimeout))
+	if visibilityTimeout < 0 || visibilityTimeout > maxVisibilityTimeout {
+		return nil, errInvalidParameter("Value %d for parameter VisibilityTimeout is invalid. Reason: must be between 0 and %d.", visibilityTimeout, maxVisibilityTimeout)
+	}
+	waitTime := intValue(req.WaitTimeSeconds, def.intAttribute(AttrReceiveMessageWaitTimeSeconds))
+	if waitTime < 0 || waitTime > maxWaitTimeSeconds {
+		return nil, errInvalidParameter("Value %d for parameter WaitTimeSeconds is invalid. Reason: must be between 0 and %d.", waitTime, maxWaitTimeSeconds)
+	}
+
+	received, err := q.receive(r.Context(), maxMessages, time.Duration(visibilityTimeout)*time.Second, time.Duration(waitTime)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	systemAttributeNames := append(req.AttributeNames, req.MessageSystemAttributeNames...)
+	result := &ReceiveMessageResult{}
+	for _, m := range received {
+		message := Message{
+			MessageId:     m.MessageId,
+			ReceiptHandle: m.receiptHandle,
+			MD5OfBody:     md5Hex([]byte(m.Body)),
+			Body:          m.Body,
+		}
+		if attributes := filterAttributes(m.systemAttributes(), systemAttributeNames); len(attributes) > 0 {
+			message.Attributes = attributes
+		}
+		if attributes := filterAttributes(m.MessageAttributes, req.MessageAttributeNames); len(attributes) > 0 {
+			message.MessageAttributes = attributes
+			message.MD5OfMessageAttributes = md5OfMessageAttributes(attributes)
+		}
+		result.Messages = append(result.Messages, message)
+	}
+	return result, nil
+}
+
+// filterAttributes returns the attributes matching the names: "All" or ".*" for all attributes,
+// "<prefix>.*" for the attributes with the prefix, or the name itself.
+func filterAttributes[V any](attributes map[string]V, names []string) map[string]V {
+	result := make(map[string]V)
+	for _, name := range names {
+		if name == "All" || name == ".*" {
+			return attributes
+		}
+		if prefix, isPrefix := strings.CutSuffix(name, ".*"); isPrefix {
+			for attributeName, value := range attributes {
+				if strings.HasPrefix(attributeName, prefix+".") {
+					result[attributeName] = value
+				}
+			}
+			continue
+		}
+		if value, found := attributes[name]; found {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func (s *Server) deleteMessageBatch(q *queueRuntime, req *DeleteMessageBatchRequest) (*DeleteMessageBatchResult, error) {
+	ids := make([]string, len(req.Entries))
+	for i, entry := range req.Entries {
+		ids[i] = entry.Id
+	}
+	if err := validateBatchEntryIds(ids); err != nil {
+		return nil, err
+	}
+	result := &DeleteMessageBatchResult{
+		Successful: []BatchResultEntry{},
+		Failed:     []BatchResultErrorEntry{},
+	}
+	for _, entry := range req.Entries {
+		if err := q.state.delete(entry.ReceiptHandle); err != nil {
+			result.Failed = append(result.Failed, batchError(entry.Id, err))
+			continue
+		}
+		result.Successful = append(result.Successful, BatchResultEntry{Id: entry.Id})
+	}
+	return result, nil
+}
+
+func changeVisibility(q *queueRuntime, receiptHandle string, visibilityTimeout *intParam) error {
+	if receiptHandle == "" {
+		return errMissingParameter("ReceiptHandle")
+	}
+	if visibilityTimeout == nil {
+		return errMissingParameter("VisibilityTimeout")
+	}
+	timeout := int(*visibilityTimeout)
+	if timeout < 0 || timeout > maxVisibilityTimeout {
+		return errInvalidParameter("Value %d for parameter VisibilityTimeout is invalid. Reason: must be between 0 and %d.", timeout, maxVisibilityTimeout)
+	}
+	return q.state.changeVisibility(receiptHandle, time.Duration(timeout)*time.Second, time.Now())
+}
+
+func (s *Server) changeMessageVisibilityBatch(q *queueRuntime, req *ChangeMessageVisibilityBatchRequest) (*ChangeMessageVisibilityBatchResult, error) {
+	ids := make([]string, len(req.Entries))
+	for i, entry := range req.Entries {
+		ids[i] = entry.Id
+	}
+	if err := validateBatchEntryIds(ids); err != nil {
+		return nil, err
+	}
+	result := &ChangeMessageVisibilityBatchResult{
+		Successful: []BatchResultEntry{},
+		Failed:     []BatchResultErrorEntry{},
+	}
+	for _, entry := range req.Entries {
+		if err := changeVisibility(q, entry.ReceiptHandle, entry.VisibilityTimeout); err != nil {
+			result.Failed = append(result.Failed, batchError(entry.Id, err))
+			continue
+		}
+		result.Successful = append(result.Successful, BatchResultEntry{Id: entry.Id})
+	}
+	return result, nil
+}
+
+func validateBatchEntryIds(ids []string) error {
+	if len(ids) == 0 {
+		return errEmptyBatch()
+	}
+	if len(ids) > maxBatchEntries {
+		return errTooManyEntries()
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !batchEntryIdPattern.MatchString(id) {
+			return errInvalidBatchEntryId(id)
+		}
+		if seen[id] {
+			return errBatchEntryIdsNotDistinct()
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func batchError(id string, err error) BatchResultErrorEntry {
+	e := toError(err)
+	return BatchResultErrorEntry{Id: id, Code: e.Code, Message: e.Message, SenderFault: e.isSenderFault()}
+}
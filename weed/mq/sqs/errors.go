@@ -0,0 +1,87 @@
+package sqs
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Error is an SQS error, with the code of the query protocol.
+// The JSON protocol reports the type instead, e.g. "com.amazonaws.sqs#QueueDoesNotExist".
+type Error struct {
+	Code       string
+	Type       string
+	Message    string
+	HTTPStatus int
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) isSenderFault() bool {
+	return e.HTTPStatus < http.StatusInternalServerError
+}
+
+func newError(code, errorType string, status int, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Type: errorType, Message: fmt.Sprintf(format, args...), HTTPStatus: status}
+}
+
+func errInvalidParameter(format string, args ...interface{}) *Error {
+	return newError("InvalidParameterValue", "InvalidParameterValue", http.StatusBadRequest, format, args...)
+}
+
+func errMissingParameter(name string) *Error {
+	return newError("MissingParameter", "MissingParameter", http.StatusBadRequest, "The request must contain the parameter %s.", name)
+}
+
+func errInvalidAttributeName(name string) *Error {
+	return newError("InvalidAttributeName", "InvalidAttributeName", http.StatusBadRequest, "Unknown Attribute %s.", name)
+}
+
+func errQueueDoesNotExist() *Error {
+	return newError("AWS.SimpleQueueService.NonExistentQueue", "QueueDoesNotExist", http.StatusBadRequest, "The specified queue does not exist.")
+}
+
+func errQueueAlreadyExists(name string) *Error {
+	return newError("QueueAlreadyExists", "QueueNameExists", http.StatusBadRequest, "A queue already exists with the same name %s and a different value for an attribute.", name)
+}
+
+func errReceiptHandleIsInvalid(handle string) *Error {
+	return newError("ReceiptHandleIsInvalid", "ReceiptHandleIsInvalid", http.StatusBadRequest, "The input receipt handle %q is not a valid receipt handle.", handle)
+}
+
+func errMessageNotInflight() *Error {
+	return newError("AWS.SimpleQueueService.MessageNotInflight", "MessageNotInflight", http.StatusBadRequest, "The message referred to isn't in flight.")
+}
+
+func errEmptyBatch() *Error {
+	return newError("AWS.SimpleQueueService.EmptyBatchRequest", "EmptyBatchRequest", http.StatusBadRequest, "The batch request doesn't contain any entries.")
+}
+
+func errTooManyEntries() *Error {
+	return newError("AWS.SimpleQueueService.TooManyEntriesInBatchRequest", "TooManyEntriesInBatchRequest", http.StatusBadRequest, "The batch request contains more than %d entries.", maxBatchEntries)
+}
+
+func errBatchEntryIdsNotDistinct() *Error {
+	return newError("AWS.SimpleQueueService.BatchEntryIdsNotDistinct", "BatchEntryIdsNotDistinct", http.StatusBadRequest, "Two or more batch entries in the request have the same Id.")
+}
+
+func errInvalidBatchEntryId(id string) *Error {
+	return newError("AWS.SimpleQueueService.InvalidBatchEntryId", "InvalidBatchEntryId", http.StatusBadRequest, "The Id %q of a batch entry is invalid.", id)
+}
+
+func errInvalidAction(action string) *Error {
+	return newError("InvalidAction", "InvalidAction", http.StatusBadRequest, "The action %s is not valid for this endpoint.", action)
+}
+
+func errAccessDenied() *Error {
+	return newError("AccessDenied", "AccessDeniedException", http.StatusForbidden, "Access to the resource is denied.")
+}
+
+func errSignature(message string) *Error {
+	return newError("InvalidClientTokenId", "InvalidClientTokenId", http.StatusForbidden, "%s", message)
+}
+
+func errInternal(err error) *Error {
+	return newError("InternalError", "InternalError", http.StatusInternalServerError, "%v", err)
+}
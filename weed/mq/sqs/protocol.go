@@ -0,0 +1,478 @@
+package sqs
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SQS serves two protocols with the same actions:
+//   - the query protocol, form encoded requests with an "Action" parameter, and XML responses
+//   - the JSON protocol, JSON requests with an "X-Amz-Target: AmazonSQS.<Action>" header, and JSON responses
+const (
+	jsonContentType  = "application/x-amz-json-1.0"
+	jsonTargetPrefix = "AmazonSQS."
+	xmlNamespace     = "http://queue.amazonaws.com/doc/2012-11-05/"
+)
+
+// intParam accepts both a JSON number and a string, since query parameters are strings.
+type intParam int
+
+func (p *intParam) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+	*p = intParam(v)
+	return nil
+}
+
+// intValue returns the value of an optional parameter, or the default if absent.
+func intValue(p *intParam, defaultValue int) int {
+	if p == nil {
+		return defaultValue
+	}
+	return int(*p)
+}
+
+type MessageAttributeValue struct {
+	DataType    string `json:"DataType" xml:"DataType"`
+	StringValue string `json:"StringValue,omitempty" xml:"StringValue,omitempty"`
+	BinaryValue []byte `json:"BinaryValue,omitempty" xml:"BinaryValue,omitempty"`
+}
+
+type CreateQueueRequest struct {
+	QueueName  string
+	Attributes map[string]string
+	Tags       map[string]string `json:"tags"`
+}
+
+type QueueUrlRequest struct {
+	QueueUrl string
+}
+
+type GetQueueUrlRequest struct {
+	QueueName string
+}
+
+type ListQueuesRequest struct {
+	QueueNamePrefix string
+	MaxResults      *intParam
+	NextToken       string
+}
+
+type GetQueueAttributesRequest struct {
+	QueueUrl       string
+	AttributeNames []string
+}
+
+type SetQueueAttributesRequest struct {
+	QueueUrl   string
+	Attributes map[string]string
+}
+
+type SendMessageRequest struct {
+	QueueUrl               string
+	MessageBody            string
+	DelaySeconds           *intParam
+	MessageAttributes      map[string]MessageAttributeValue
+	MessageDeduplicationId string
+	MessageGroupId         string
+}
+
+type SendMessageBatchRequestEntry struct {
+	Id string
+	SendMessageRequest
+}
+
+type SendMessageBatchRequest struct {
+	QueueUrl string
+	Entries  []SendMessageBatchRequestEntry
+}
+
+type ReceiveMessageRequest struct {
+	QueueUrl                    string
+	AttributeNames              []string
+	MessageSystemAttributeNames []string
+	MessageAttributeNames       []string
+	MaxNumberOfMessages         *intParam
+	VisibilityTimeout           *intParam
+	WaitTimeSeconds             *intParam
+}
+
+type DeleteMessageRequest struct {
+	QueueUrl      string
+	ReceiptHandle string
+}
+
+type DeleteMessageBatchRequestEntry struct {
+	Id            string
+	ReceiptHandle string
+}
+
+type DeleteMessageBatchRequest struct {
+	QueueUrl string
+	Entries  []DeleteMessageBatchRequestEntry
+}
+
+type ChangeMessageVisibilityRequest struct {
+	QueueUrl          string
+	ReceiptHandle     string
+	VisibilityTimeout *intParam
+}
+
+type ChangeMessageVisibilityBatchRequestEntry struct {
+	Id                string
+	ReceiptHandle     string
+	VisibilityTimeout *intParam
+}
+
+type ChangeMessageVisibilityBatchRequest struct {
+	QueueUrl string
+	Entries  []ChangeMessageVisibilityBatchRequestEntry
+}
+
+type CreateQueueResult struct {
+	QueueUrl string
+}
+
+type GetQueueUrlResult struct {
+	QueueUrl string
+}
+
+type ListQueuesResult struct {
+	QueueUrls []string `json:"QueueUrls,omitempty" xml:"QueueUrl"`
+	NextToken string   `json:"NextToken,omitempty" xml:"NextToken,omitempty"`
+}
+
+type GetQueueAttributesResult struct {
+	Attributes attributeMap `xml:"Attribute"`
+}
+
+type SendMessageResult struct {
+	MessageId              string
+	MD5OfMessageBody       string
+	MD5OfMessageAttributes string `json:",omitempty" xml:",omitempty"`
+	SequenceNumber         string `json:",omitempty" xml:",omitempty"`
+}
+
+type SendMessageBatchResultEntry struct {
+	Id string
+	SendMessageResult
+}
+
+type BatchResultErrorEntry struct {
+	Id          string
+	Code        string
+	Message     string
+	SenderFault bool
+}
+
+type SendMessageBatchResult struct {
+	Successful []SendMessageBatchResultEntry `xml:"SendMessageBatchResultEntry"`
+	Failed     []BatchResultErrorEntry       `xml:"BatchResultErrorEntry"`
+}
+
+type Message struct {
+	MessageId              string
+	ReceiptHandle          string
+	MD5OfBody              string
+	Body                   string
+	Attributes             attributeMap        `json:",omitempty" xml:"Attribute"`
+	MD5OfMessageAttributes string              `json:",omitempty" xml:",omitempty"`
+	MessageAttributes      messageAttributeMap `json:",omitempty" xml:"MessageAttribute"`
+}
+
+type ReceiveMessageResult struct {
+	Messages []Message `json:"Messages,omitempty" xml:"Message"`
+}
+
+type BatchResultEntry struct {
+	Id string
+}
+
+type DeleteMessageBatchResult struct {
+	Successful []BatchResultEntry      `xml:"DeleteMessageBatchResultEntry"`
+	Failed     []BatchResultErrorEntry `xml:"BatchResultErrorEntry"`
+}
+
+type ChangeMessageVisibilityBatchResult struct {
+	Successful []BatchResultEntry      `xml:"ChangeMessageVisibilityBatchResultEntry"`
+	Failed     []BatchResultErrorEntry `xml:"BatchResultErrorEntry"`
+}
+
+// attributeMap is a JSON object, and a list of <Name> and <Value> elements in XML.
+type attributeMap map[string]string
+
+func (m attributeMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, name := range sortedKeys(m) {
+		entry := struct {
+			Name  string
+			Value string
+		}{name, m[name]}
+		if err := e.EncodeElement(entry, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type messageAttributeMap map[string]MessageAttributeValue
+
+func (m messageAttributeMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, name := range sortedKeys(m) {
+		entry := struct {
+			Name  string
+			Value MessageAttributeValue
+		}{name, m[name]}
+		if err := e.EncodeElement(entry, start); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseRequest returns the action of the request, and its parameters as JSON.
+func parseRequest(r *http.Request, body []byte) (action string, params []byte, isJSON bool, err error) {
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		if !strings.HasPrefix(target, jsonTargetPrefix) {
+			return "", nil, true, errInvalidAction(target)
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			body = []byte("{}")
+		}
+		return strings.TrimPrefix(target, jsonTargetPrefix), body, true, nil
+	}
+	values := r.URL.Query()
+	if len(body) > 0 {
+		form, parseErr := url.ParseQuery(string(body))
+		if parseErr != nil {
+			return "", nil, false, errInvalidParameter("invalid form: %v", parseErr)
+		}
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+	action = values.Get("Action")
+	if action == "" {
+		return "", nil, false, errMissingParameter("Action")
+	}
+	params, err = json.Marshal(queryToParams(values))
+	return action, params, false, err
+}
+
+// queryToParams converts the query parameters to the shape of the JSON protocol, e.g.
+// "Attribute.1.Name=VisibilityTimeout&Attribute.1.Value=60" to {"Attributes":{"VisibilityTimeout":"60"}},
+// and "SendMessageBatchRequestEntry.1.Id=a" to {"Entries":[{"Id":"a"}]}.
+func queryToParams(values url.Values) map[string]interface{} {
+	tree := make(map[string]interface{})
+	for key, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		node := tree
+		segments := strings.Split(key, ".")
+		for i, segment := range segments {
+			if i == len(segments)-1 {
+				if _, isNode := node[segment].(map[string]interface{}); !isNode {
+					node[segment] = v[0]
+				}
+				break
+			}
+			child, isNode := node[segment].(map[string]interface{})
+			if !isNode {
+				child = make(map[string]interface{})
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return normalizeQueryNode(tree)
+}
+
+func normalizeQueryNode(node map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range node {
+		child, isNode := value.(map[string]interface{})
+		if !isNode {
+			result[key] = value
+			continue
+		}
+		items, isList := queryList(child)
+		if !isList {
+			result[key] = normalizeQueryNode(child)
+			continue
+		}
+		switch {
+		case key == "Attribute" || key == "MessageAttribute" || key == "MessageSystemAttribute":
+			m := make(map[string]interface{})
+			for _, item := range items {
+				if entry, ok := item.(map[string]interface{}); ok {
+					if name, ok := entry["Name"].(string); ok {
+						m[name] = entry["Value"]
+					}
+				}
+			}
+			result[key+"s"] = m
+		case key == "Tag":
+			m := make(map[string]interface{})
+			for _, item := range items {
+				if entry, ok := item.(map[string]interface{}); ok {
+					if name, ok := entry["Key"].(string); ok {
+						m[name] = entry["Value"]
+					}
+				}
+			}
+			result["tags"] = m
+		case strings.HasSuffix(key, "RequestEntry"):
+			result["Entries"] = items
+		default:
+			// e.g. AttributeName.1, MessageAttributeName.1
+			result[key+"s"] = items
+		}
+	}
+	return result
+}
+
+// queryList returns the children ordered by index, if the keys are list indexes.
+func queryList(node map[string]interface{}) ([]interface{}, bool) {
+	if len(node) == 0 {
+		return nil, false
+	}
+	indexes := make([]int, 0, len(node))
+	for key := range node {
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, false
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	items := make([]interface{}, 0, len(indexes))
+	for _, index := range indexes {
+		item := node[strconv.Itoa(index)]
+		if m, isNode := item.(map[string]interface{}); isNode {
+			item = normalizeQueryNode(m)
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+func writeResult(w http.ResponseWriter, action, requestId string, isJSON bool, result interface{}) {
+	if isJSON {
+		if result == nil {
+			result = struct{}{}
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			writeError(w, requestId, isJSON, errInternal(err))
+			return
+		}
+		w.Header().Set("Content-Type", jsonContentType)
+		w.Header().Set("x-amzn-RequestId", requestId)
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+		return
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<?xml version="1.0"?><%sResponse xmlns="%s">`, action, xmlNamespace)
+	if result != nil {
+		if err := xml.NewEncoder(&buf).EncodeElement(result, xml.StartElement{Name: xml.Name{Local: action + "Result"}}); err != nil {
+			writeError(w, requestId, isJSON, errInternal(err))
+			return
+		}
+	}
+	fmt.Fprintf(&buf, `<ResponseMetadata><RequestId>%s</RequestId></ResponseMetadata></%sResponse>`, requestId, action)
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("x-amzn-RequestId", requestId)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func writeError(w http.ResponseWriter, requestId string, isJSON bool, e *Error) {
+	fault := "Sender"
+	if !e.isSenderFault() {
+		fault = "Receiver"
+	}
+	w.Header().Set("x-amzn-RequestId", requestId)
+	if isJSON {
+		data, _ := json.Marshal(map[string]string{
+			"__type":  "com.amazonaws.sqs#" + e.Type,
+			"message": e.Message,
+		})
+		w.Header().Set("Content-Type", jsonContentType)
+		// lets clients of the JSON protocol report the error code of the query protocol
+		w.Header().Set("x-amzn-query-error", e.Code+";"+fault)
+		w.WriteHeader(e.HTTPStatus)
+		w.Write(data)
+		return
+	}
+	response := struct {
+		XMLName xml.Name `xml:"ErrorResponse"`
+		Error   struct {
+			Type    string
+			Code    string
+			Message string
+		}
+		RequestId string
+	}{RequestId: requestId}
+	response.Error.Type, response.Error.Code, response.Error.Message = fault, e.Code, e.Message
+	data, _ := xml.Marshal(&response)
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(e.HTTPStatus)
+	w.Write(data)
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// md5OfMessageAttributes is the checksum SQS clients verify: the attributes sorted by name,
+// each encoded as the length prefixed name, data type and value, with a transport type byte.
+func md5OfMessageAttributes(attributes map[string]MessageAttributeValue) string {
+	if len(attributes) == 0 {
+		return ""
+	}
+	h := md5.New()
+	writeLengthPrefixed := func(data []byte) {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+		h.Write(length[:])
+		h.Write(data)
+	}
+	for _, name := range sortedKeys(attributes) {
+		value := attributes[name]
+		writeLengthPrefixed([]byte(name))
+		writeLengthPrefixed([]byte(value.DataType))
+		if strings.HasPrefix(value.DataType, "Binary") {
+			h.Write([]byte{2})
+			writeLengthPrefixed(value.BinaryValue)
+		} else {
+			h.Write([]byte{1})
+			writeLengthPrefixed([]byte(value.StringValue))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,188 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQueryRequest(t *testing.T) {
+	form := url.Values{
+		"Action":                            {"SendMessageBatch"},
+		"QueueUrl":                          {"http://localhost:9324/000000000000/jobs"},
+		"SendMessageBatchRequestEntry.2.Id": {"b"},
+		"SendMessageBatchRequestEntry.2.MessageBody":                          {"second"},
+		"SendMessageBatchRequestEntry.1.Id":                                   {"a"},
+		"SendMessageBatchRequestEntry.1.MessageBody":                          {"first"},
+		"SendMessageBatchRequestEntry.1.DelaySeconds":                         {"5"},
+		"SendMessageBatchRequestEntry.1.MessageAttribute.1.Name":              {"color"},
+		"SendMessageBatchRequestEntry.1.MessageAttribute.1.Value.DataType":    {"String"},
+		"SendMessageBatchRequestEntry.1.MessageAttribute.1.Value.StringValue": {"blue"},
+	}
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	action, params, isJSON, err := parseRequest(r, []byte(form.Encode()))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if action != "SendMessageBatch" || isJSON {
+		t.Fatalf("action %q json %v", action, isJSON)
+	}
+	req := &SendMessageBatchRequest{}
+	if err = json.Unmarshal(params, req); err != nil {
+		t.Fatalf("unmarshal %s: %v", params, err)
+	}
+	if req.QueueUrl != "http://localhost:9324/000000000000/jobs" || len(req.Entries) != 2 {
+		t.Fatalf("request %+v", req)
+	}
+	first, second := req.Entries[0], req.Entries[1]
+	if first.Id != "a" || first.MessageBody != "first" || intValue(first.DelaySeconds, 0) != 5 {
+		t.Errorf("first entry %+v", first)
+	}
+	if got := first.MessageAttributes["color"]; got.DataType != "String" || got.StringValue != "blue" {
+		t.Errorf("first entry attributes %+v", first.MessageAttributes)
+	}
+	if second.Id != "b" || second.MessageBody != "second" || second.DelaySeconds != nil {
+		t.Errorf("second entry %+v", second)
+	}
+}
+
+func TestParseQueryAttributes(t *testing.T) {
+	values := url.Values{
+		"Action":            {"CreateQueue"},
+		"QueueName":         {"jobs.fifo"},
+		"Attribute.1.Name":  {"FifoQueue"},
+		"Attribute.1.Value": {"true"},
+		"Attribute.2.Name":  {"VisibilityTimeout"},
+		"Attribute.2.Value": {"60"},
+		"Tag.1.Key":         {"team"},
+		"Tag.1.Value":       {"search"},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/?"+values.Encode(), nil)
+	_, params, _, err := parseRequest(r, nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	req := &CreateQueueRequest{}
+	if err = json.Unmarshal(params, req); err != nil {
+		t.Fatalf("unmarshal %s: %v", params, err)
+	}
+	want := map[string]string{"FifoQueue": "true", "VisibilityTimeout": "60"}
+	if req.QueueName != "jobs.fifo" || !reflect.DeepEqual(req.Attributes, want) || req.Tags["team"] != "search" {
+		t.Errorf("request %+v", req)
+	}
+
+	values = url.Values{
+		"Action":                 {"ReceiveMessage"},
+		"AttributeName.1":        {"All"},
+		"MessageAttributeName.1": {"color"},
+		"MessageAttributeName.2": {"size.*"},
+		"MaxNumberOfMessages":    {"10"},
+	}
+	_, params, _, err = parseRequest(httptest.NewRequest(http.MethodGet, "/?"+values.Encode(), nil), nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	receive := &ReceiveMessageRequest{}
+	if err = json.Unmarshal(params, receive); err != nil {
+		t.Fatalf("unmarshal %s: %v", params, err)
+	}
+	if !reflect.DeepEqual(receive.AttributeNames, []string{"All"}) ||
+		!reflect.DeepEqual(receive.MessageAttributeNames, []string{"color", "size.*"}) ||
+		intValue(receive.MaxNumberOfMessages, 1) != 10 {
+		t.Errorf("request %+v", receive)
+	}
+}
+
+func TestParseJSONRequest(t *testing.T) {
+	body := `{"QueueUrl":"http://localhost/000000000000/jobs","MaxNumberOfMessages":3,"WaitTimeSeconds":"2"}`
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("X-Amz-Target", "AmazonSQS.ReceiveMessage")
+	action, params, isJSON, err := parseRequest(r, []byte(body))
+	if err != nil || action != "ReceiveMessage" || !isJSON {
+		t.Fatalf("parse: %q %v %v", action, isJSON, err)
+	}
+	req := &ReceiveMessageRequest{}
+	if err = json.Unmarshal(params, req); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if intValue(req.MaxNumberOfMessages, 1) != 3 || intValue(req.WaitTimeSeconds, 0) != 2 {
+		t.Errorf("request %+v", req)
+	}
+
+	r.Header.Set("X-Amz-Target", "DynamoDB_20120810.GetItem")
+	if _, _, _, err = parseRequest(r, []byte(body)); err == nil {
+		t.Errorf("expected an error for a foreign target")
+	}
+}
+
+func TestMd5OfMessageAttributes(t *testing.T) {
+	if md5OfMessageAttributes(nil) != "" {
+		t.Errorf("expected no checksum without attributes")
+	}
+	attributes := map[string]MessageAttributeValue{
+		"b": {DataType: "String", StringValue: "x"},
+		"a": {DataType: "Binary", BinaryValue: []byte{1, 2}},
+	}
+	sum := md5OfMessageAttributes(attributes)
+	if len(sum) != 32 {
+		t.Fatalf("checksum %q", sum)
+	}
+	changed := map[string]MessageAttributeValue{
+		"b": {DataType: "String", StringValue: "y"},
+		"a": {DataType: "Binary", BinaryValue: []byte{1, 2}},
+	}
+	if md5OfMessageAttributes(changed) == sum {
+		t.Errorf("checksum should depend on the values")
+	}
+	if md5Hex([]byte("hello")) != "5d41402abc4b2a76b9719d911017c592" {
+		t.Errorf("md5 of body %s", md5Hex([]byte("hello")))
+	}
+}
+
+func TestWriteXMLResult(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeResult(w, "ReceiveMessage", "req-1", false, &ReceiveMessageResult{Messages: []Message{{
+		MessageId:     "m-1",
+		ReceiptHandle: "h-1",
+		MD5OfBody:     "md5",
+		Body:          "hello",
+		Attributes:    attributeMap{"SentTimestamp": "1", "ApproximateReceiveCount": "2"},
+	}}})
+	body := w.Body.String()
+	for _, want := range []string{
+		`<ReceiveMessageResponse xmlns="` + xmlNamespace + `">`,
+		`<ReceiveMessageResult><Message><MessageId>m-1</MessageId>`,
+		`<Attribute><Name>ApproximateReceiveCount</Name><Value>2</Value></Attribute><Attribute><Name>SentTimestamp</Name>`,
+		`<ResponseMetadata><RequestId>req-1</RequestId></ResponseMetadata></ReceiveMessageResponse>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("response %s\nmissing %s", body, want)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeError(w, "req-1", true, errQueueDoesNotExist())
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status %d", w.Code)
+	}
+	if got := w.Header().Get("x-amzn-query-error"); got != "AWS.SimpleQueueService.NonExistentQueue;Sender" {
+		t.Errorf("query error header %q", got)
+	}
+	if !strings.Contains(w.Body.String(), `"__type":"com.amazonaws.sqs#QueueDoesNotExist"`) {
+		t.Errorf("body %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	writeError(w, "req-2", false, errInternal(errEmptyBatch()))
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), "<Type>Receiver</Type><Code>InternalError</Code>") {
+		t.Errorf("status %d body %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,338 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// The gateway keeps the queue definitions on the filer:
+//
+//	/etc/sqs/queues/<queue name>   the queue attributes, and the MQ topic of its messages
+//
+// The messages are stored in the MQ topic, and the deleted messages are tracked by the offsets
+// of the "sqs" consumer group of the topic.
+const (
+	StoreDir  = "/etc/sqs"
+	queuesDir = StoreDir + "/queues"
+
+	fifoSuffix = ".fifo"
+)
+
+// attributes that can be set, with their defaults
+const (
+	AttrDelaySeconds                  = "DelaySeconds"
+	AttrMaximumMessageSize            = "MaximumMessageSize"
+	AttrMessageRetentionPeriod        = "MessageRetentionPeriod"
+	AttrReceiveMessageWaitTimeSeconds = "ReceiveMessageWaitTimeSeconds"
+	AttrVisibilityTimeout             = "VisibilityTimeout"
+	AttrRedrivePolicy                 = "RedrivePolicy"
+	AttrRedriveAllowPolicy            = "RedriveAllowPolicy"
+	AttrFifoQueue                     = "FifoQueue"
+	AttrContentBasedDeduplication     = "ContentBasedDeduplication"
+	AttrDeduplicationScope            = "DeduplicationScope"
+	AttrFifoThroughputLimit           = "FifoThroughputLimit"
+	AttrPolicy                        = "Policy"
+	AttrKmsMasterKeyId                = "KmsMasterKeyId"
+	AttrKmsDataKeyReusePeriodSeconds  = "KmsDataKeyReusePeriodSeconds"
+	AttrSqsManagedSseEnabled          = "SqsManagedSseEnabled"
+)
+
+// attributes computed on each request
+const (
+	AttrQueueArn                              = "QueueArn"
+	AttrApproximateNumberOfMessages           = "ApproximateNumberOfMessages"
+	AttrApproximateNumberOfMessagesNotVisible = "ApproximateNumberOfMessagesNotVisible"
+	AttrApproximateNumberOfMessagesDelayed    = "ApproximateNumberOfMessagesDelayed"
+	AttrCreatedTimestamp                      = "CreatedTimestamp"
+	AttrLastModifiedTimestamp                 = "LastModifiedTimestamp"
+)
+
+var queueNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,80}$`)
+
+type intRange struct {
+	defaultValue, min, max int
+}
+
+var intAttributes = map[string]intRange{
+	AttrDelaySeconds:                  {0, 0, 900},
+	AttrMaximumMessageSize:            {262144, 1024, 262144},
+	AttrMessageRetentionPeriod:        {345600, 60, 1209600},
+	AttrReceiveMessageWaitTimeSeconds: {0, 0, 20},
+	AttrVisibilityTimeout:             {30, 0, 43200},
+	AttrKmsDataKeyReusePeriodSeconds:  {300, 60, 86400},
+}
+
+// attributes kept as given, without affecting the gateway
+var opaqueAttributes = map[string]bool{
+	AttrRedriveAllowPolicy:   true,
+	AttrPolicy:               true,
+	AttrKmsMasterKeyId:       true,
+	AttrSqsManagedSseEnabled: true,
+	AttrDeduplicationScope:   true,
+	AttrFifoThroughputLimit:  true,
+}
+
+// Queue is the definition of a queue.
+type Queue struct {
+	Name                  string            `json:"name"`
+	Topic                 string            `json:"topic"` // the MQ topic name in the gateway namespace
+	Attributes            map[string]string `json:"attributes"`
+	CreatedTimestamp      int64             `json:"createdTimestamp"` // unix seconds
+	LastModifiedTimestamp int64             `json:"lastModifiedTimestamp"`
+	PurgedTimestamp       int64             `json:"purgedTimestamp,omitempty"` // unix milliseconds, the messages sent until then are deleted
+}
+
+// RedrivePolicy moves a message to the dead letter queue when it has been received maxReceiveCount times.
+type RedrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+	MaxReceiveCount     int    `json:"maxReceiveCount"`
+}
+
+func (q *Queue) IsFifo() bool {
+	return q.Attributes[AttrFifoQueue] == "true"
+}
+
+func (q *Queue) intAttribute(name string) int {
+	if v, err := strconv.Atoi(q.Attributes[name]); err == nil {
+		return v
+	}
+	return intAttributes[name].defaultValue
+}
+
+func (q *Queue) VisibilityTimeout() time.Duration {
+	return time.Duration(q.intAttribute(AttrVisibilityTimeout)) * time.Second
+}
+
+func (q *Queue) ContentBasedDeduplication() bool {
+	return q.Attributes[AttrContentBasedDeduplication] == "true"
+}
+
+// RedrivePolicy returns nil if the queue has no dead letter queue.
+func (q *Queue) RedrivePolicy() *RedrivePolicy {
+	policy, err := parseRedrivePolicy(q.Attributes[AttrRedrivePolicy])
+	if err != nil {
+		return nil
+	}
+	return policy
+}
+
+func parseRedrivePolicy(text string) (*RedrivePolicy, error) {
+	if text == "" {
+		return nil, nil
+	}
+	// maxReceiveCount is a number or a string
+	raw := struct {
+		DeadLetterTargetArn string          `json:"deadLetterTargetArn"`
+		MaxReceiveCount     json.RawMessage `json:"maxReceiveCount"`
+	}{}
+	if err := json.Unmarshal([]byte(text), &raw); err != nil {
+		return nil, errInvalidParameter("Value %s for parameter RedrivePolicy is invalid. Reason: invalid JSON.", text)
+	}
+	var maxReceiveCount intParam
+	if err := maxReceiveCount.UnmarshalJSON(raw.MaxReceiveCount); err != nil || maxReceiveCount < 1 || maxReceiveCount > 1000 {
+		return nil, errInvalidParameter("Value %s for parameter RedrivePolicy is invalid. Reason: maxReceiveCount must be between 1 and 1000.", text)
+	}
+	if queueNameFromArn(raw.DeadLetterTargetArn) == "" {
+		return nil, errInvalidParameter("Value %s for parameter RedrivePolicy is invalid. Reason: invalid deadLetterTargetArn.", text)
+	}
+	return &RedrivePolicy{DeadLetterTargetArn: raw.DeadLetterTargetArn, MaxReceiveCount: int(maxReceiveCount)}, nil
+}
+
+func queueArn(region, accountId, name string) string {
+	return fmt.Sprintf("arn:aws:sqs:%s:%s:%s", region, accountId, name)
+}
+
+// queueNameFromArn returns the queue name of an "arn:aws:sqs:<region>:<account>:<name>".
+func queueNameFromArn(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "sqs" {
+		return ""
+	}
+	return parts[5]
+}
+
+// queueNameFromUrl returns the last path element of a queue URL, "http://host/<account>/<name>".
+func queueNameFromUrl(queueUrl string) (string, error) {
+	if queueUrl == "" {
+		return "", errMissingParameter("QueueUrl")
+	}
+	u, err := url.Parse(queueUrl)
+	if err != nil {
+		return "", errQueueDoesNotExist()
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	name := path[strings.LastIndex(path, "/")+1:]
+	if validateQueueName(name) != nil {
+		return "", errQueueDoesNotExist()
+	}
+	return name, nil
+}
+
+func validateQueueName(name string) error {
+	base := strings.TrimSuffix(name, fifoSuffix)
+	if len(name) > 80 || !queueNamePattern.MatchString(base) {
+		return errInvalidParameter("Can only include alphanumeric characters, hyphens, or underscores. 1 to 80 in length.")
+	}
+	return nil
+}
+
+// topicName maps the queue name to an MQ topic name. The creation time keeps
+// a re-created queue from seeing the messages of a deleted queue with the same name.
+func topicName(name string, created time.Time) string {
+	base := strings.TrimSuffix(name, fifoSuffix)
+	if base != name {
+		base += "_fifo"
+	}
+	return base + "-" + strconv.FormatInt(created.Unix(), 36)
+}
+
+// validateAttributes checks the attributes given when creating or updating a queue,
+// and returns them normalized.
+func validateAttributes(attributes map[string]string, isFifo, creating bool) (map[string]string, error) {
+	result := make(map[string]string)
+	for name, value := range attributes {
+		if r, isInt := intAttributes[name]; isInt {
+			v, err := strconv.Atoi(value)
+			if err != nil || v < r.min || v > r.max {
+				return nil, errInvalidParameter("Invalid value for the parameter %s.", name)
+			}
+			result[name] = strconv.Itoa(v)
+			continue
+		}
+		switch name {
+		case AttrFifoQueue:
+			if !creating {
+				return nil, errInvalidAttributeName(name)
+			}
+			if b, err := strconv.ParseBool(value); err != nil || b != isFifo {
+				return nil, errInvalidParameter("The queue name of a FIFO queue must end with the %s suffix.", fifoSuffix)
+			}
+			if isFifo {
+				result[name] = "true"
+			}
+		case AttrContentBasedDeduplication:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errInvalidParameter("Invalid value for the parameter %s.", name)
+			}
+			if !isFifo {
+				return nil, errInvalidAttributeName(name)
+			}
+			result[name] = strconv.FormatBool(b)
+		case AttrRedrivePolicy:
+			if _, err := parseRedrivePolicy(value); err != nil {
+				return nil, err
+			}
+			result[name] = value
+		default:
+			if !opaqueAttributes[name] {
+				return nil, errInvalidAttributeName(name)
+			}
+			result[name] = value
+		}
+	}
+	if creating && isFifo {
+		result[AttrFifoQueue] = "true"
+	}
+	return result, nil
+}
+
+// sameAttributes reports whether creating a queue with the attributes would change an existing queue.
+func (q *Queue) sameAttributes(attributes map[string]string) bool {
+	for name, value := range attributes {
+		existing, found := q.Attributes[name]
+		if !found {
+			if r, isInt := intAttributes[name]; isInt && value == strconv.Itoa(r.defaultValue) {
+				continue
+			}
+			if value == "false" && name == AttrContentBasedDeduplication {
+				continue
+			}
+		}
+		if existing != value {
+			return false
+		}
+	}
+	return true
+}
+
+type FilerClient interface {
+	WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error
+}
+
+type Store struct {
+	filerClient FilerClient
+}
+
+func NewStore(filerClient FilerClient) *Store {
+	return &Store{filerClient: filerClient}
+}
+
+// LoadQueue returns nil if the queue does not exist.
+func (s *Store) LoadQueue(name string) (*Queue, error) {
+	var data []byte
+	err := s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) (err error) {
+		data, err = filer.ReadInsideFiler(client, queuesDir, name)
+		return err
+	})
+	if errors.Is(err, filer_pb.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read queue %s: %w", name, err)
+	}
+	q := &Queue{}
+	if err = json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("parse queue %s: %w", name, err)
+	}
+	if q.Attributes == nil {
+		q.Attributes = make(map[string]string)
+	}
+	return q, nil
+}
+
+func (s *Store) SaveQueue(q *Queue) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer.SaveInsideFiler(client, queuesDir, q.Name, data)
+	})
+}
+
+func (s *Store) DeleteQueue(name string) error {
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		err := filer_pb.DoRemove(context.Background(), client, queuesDir, name, true, false, true, false, nil)
+		if err != nil && !errors.Is(err, filer_pb.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+// ListQueues returns the queue names with the prefix, in order.
+func (s *Store) ListQueues(prefix string) (names []string, err error) {
+	err = s.filerClient.WithFilerClient(true, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.SeaweedList(context.Background(), client, queuesDir, prefix, func(entry *filer_pb.Entry, isLast bool) error {
+			if !entry.IsDirectory {
+				names = append(names, entry.Name)
+			}
+			return nil
+		}, "", false, 0)
+	})
+	if errors.Is(err, filer_pb.ErrNotFound) {
+		return nil, nil
+	}
+	return
+}
@@ -0,0 +1,297 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/pub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/sub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+)
+
+const (
+	consumerGroup               = "sqs"
+	subscriberMaxPartitionCount = 32
+	// messages read ahead per partition, visible or in flight
+	subscriberSlidingWindowSize = 256
+)
+
+// queueRuntime sends the messages of a queue to its MQ topic, and reads them back for receiving.
+// The gateways receiving from a queue share the partitions of its topic through the consumer group,
+// and a message is acknowledged to MQ when it is deleted.
+type queueRuntime struct {
+	server   *Server
+	state    *queueState
+	ctx      context.Context
+	cancel   context.CancelFunc
+	lock     sync.Mutex
+	def      *Queue
+	loadedAt time.Time
+
+	publisher        *pub_client.TopicPublisher
+	subscriberCancel context.CancelFunc
+	lastReceive      time.Time
+	lastSequence     int64
+}
+
+func newQueueRuntime(server *Server, def *Queue) *queueRuntime {
+	ctx, cancel := context.WithCancel(server.ctx)
+	return &queueRuntime{
+		server:   server,
+		state:    newQueueState(def.IsFifo()),
+		ctx:      ctx,
+		cancel:   cancel,
+		def:      def,
+		loadedAt: time.Now(),
+	}
+}
+
+func (q *queueRuntime) definition() *Queue {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.def
+}
+
+func (q *queueRuntime) setDefinition(def *Queue) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.def, q.loadedAt = def, time.Now()
+}
+
+func (q *queueRuntime) topic() topic.Topic {
+	return topic.NewTopic(q.server.opts.Namespace, q.definition().Topic)
+}
+
+func (q *queueRuntime) close() {
+	q.cancel()
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.publisher != nil {
+		if err := q.publisher.FinishPublish(); err != nil {
+			glog.Warningf("finish publishing to queue %s: %v", q.def.Name, err)
+		}
+		q.publisher = nil
+	}
+}
+
+func (q *queueRuntime) getPublisher() (*pub_client.TopicPublisher, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.publisher != nil {
+		return q.publisher, nil
+	}
+	t := topic.NewTopic(q.server.opts.Namespace, q.def.Topic)
+	var partitionCount int32
+	err := q.server.withBrokerClient(func(client mq_pb.SeaweedMessagingClient) error {
+		conf, err := client.GetTopicConfiguration(q.ctx, &mq_pb.GetTopicConfigurationRequest{
+			Topic: t.ToPbTopic(),
+		})
+		if err != nil {
+			return err
+		}
+		partitionCount = conf.PartitionCount
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("topic %v of queue %s: %w", t, q.def.Name, err)
+	}
+	publisher, err := pub_client.NewTopicPublisher(&pub_client.PublisherConfiguration{
+		Topic:          t,
+		PartitionCount: partitionCount,
+		Brokers:        q.server.getBrokers(),
+		PublisherName:  "sqs-gateway",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create publisher for %v: %w", t, err)
+	}
+	q.publisher = publisher
+	return publisher, nil
+}
+
+// nextSequenceNumber returns increasing sequence numbers for the messages of a FIFO queue.
+func (q *queueRuntime) nextSequenceNumber(now time.Time) string {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.lastSequence = max(q.lastSequence+1, now.UnixNano())
+	return fmt.Sprintf("%020d", q.lastSequence)
+}
+
+// publish stores the message in the MQ topic. The messages of a FIFO message group share
+// the same key, so that they stay in one partition in order.
+func (q *queueRuntime) publish(m *StoredMessage, delay time.Duration) error {
+	publisher, err := q.getPublisher()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	key := []byte(m.MessageId)
+	if m.MessageGroupId != "" {
+		key = []byte(m.MessageGroupId)
+	}
+	if delay > 0 {
+		return publisher.PublishAt(key, data, time.Now().Add(delay))
+	}
+	return publisher.Publish(key, data)
+}
+
+// startSubscriber reads the MQ topic into the queue state, if not reading already.
+func (q *queueRuntime) startSubscriber() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.lastReceive = time.Now()
+	if q.subscriberCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(q.ctx)
+	q.subscriberCancel = cancel
+	t := topic.NewTopic(q.server.opts.Namespace, q.def.Topic)
+	instanceId := q.server.opts.ClientHost
+	if instanceId == "" {
+		instanceId = q.server.opts.Listen
+	}
+
+	go func() {
+		subscriber := sub_client.NewTopicSubscriber(ctx, q.server.getBrokers(), &sub_client.SubscriberConfiguration{
+			ClientId:                instanceId,
+			ConsumerGroup:           consumerGroup,
+			ConsumerGroupInstanceId: instanceId,
+			GrpcDialOption:          q.server.grpcDialOption,
+			MaxPartitionCount:       subscriberMaxPartitionCount,
+			SlidingWindowSize:       subscriberSlidingWindowSize,
+		}, &sub_client.ContentConfiguration{
+			Topic:      t,
+			OffsetType: schema_pb.OffsetType_RESUME_OR_EARLIEST,
+		}, make(chan sub_client.KeyedTimestamp, 1024))
+		subscriber.SetOnDataMessageWithErrorFn(func(m *mq_pb.SubscribeMessageResponse_Data) error {
+			return q.onMessage(ctx, m.Data.Value)
+		})
+		if err := subscriber.Subscribe(); err != nil {
+			glog.V(0).Infof("sqs queue %s subscribe to %v: %v", q.definition().Name, t, err)
+		}
+	}()
+}
+
+// onMessage makes a message read from MQ receivable, and returns when the message is deleted.
+// Returning an error leaves the message unacknowledged, to be read again.
+func (q *queueRuntime) onMessage(ctx context.Context, value []byte) error {
+	stored := &StoredMessage{}
+	if err := json.Unmarshal(value, stored); err != nil || stored.MessageId == "" {
+		// not written by the sqs gateway
+		return nil
+	}
+	if stored.SentTimestamp <= q.definition().PurgedTimestamp {
+		return nil
+	}
+	m := q.state.add(stored)
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopIdleSubscriber stops reading the MQ topic of a queue that is not received from,
+// and has no message in flight. The messages read but not received are read again later.
+func (q *queueRuntime) stopIdleSubscriber(now time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.subscriberCancel == nil || now.Sub(q.lastReceive) < subscriberIdleTimeout {
+		return
+	}
+	if _, inflight := q.state.counts(now); inflight > 0 {
+		return
+	}
+	q.subscriberCancel()
+	q.subscriberCancel = nil
+	q.state.reset()
+	glog.V(1).Infof("sqs queue %s stopped reading after %v idle", q.def.Name, subscriberIdleTimeout)
+}
+
+// receive returns up to maxMessages messages, waiting up to waitTime for messages to become visible.
+func (q *queueRuntime) receive(ctx context.Context, maxMessages int, visibilityTimeout, waitTime time.Duration) ([]*message, error) {
+	q.startSubscriber()
+	deadline := time.Now().Add(waitTime)
+	def := q.definition()
+	maxReceiveCount := 0
+	redrivePolicy := def.RedrivePolicy()
+	if redrivePolicy != nil {
+		maxReceiveCount = redrivePolicy.MaxReceiveCount
+	}
+
+	for {
+		waitChan := q.state.waitChan()
+		now := time.Now()
+		received, deadLetters := q.state.receive(maxMessages, visibilityTimeout, maxReceiveCount, now)
+		if len(deadLetters) > 0 {
+			q.moveToDeadLetterQueue(redrivePolicy, deadLetters)
+		}
+		if len(received) > 0 || !now.Before(deadline) {
+			return received, nil
+		}
+
+		wait := deadline.Sub(now)
+		if next := q.state.nextVisibleAt(); !next.IsZero() && next.Sub(now) < wait {
+			wait = max(next.Sub(now), time.Millisecond)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-waitChan:
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil
+		case <-q.ctx.Done():
+			timer.Stop()
+			return nil, errQueueDoesNotExist()
+		}
+		timer.Stop()
+	}
+}
+
+// moveToDeadLetterQueue sends the messages received too many times to the dead letter queue,
+// keeping their message ids, and deletes them from this queue.
+func (q *queueRuntime) moveToDeadLetterQueue(policy *RedrivePolicy, messages []*message) {
+	dlqName := queueNameFromArn(policy.DeadLetterTargetArn)
+	dlq, err := q.server.getQueue(dlqName)
+	for _, m := range messages {
+		if err == nil {
+			moved := *m.StoredMessage
+			moved.ReceiveCount = m.receiveCount
+			err = dlq.publish(&moved, 0)
+		}
+		if err != nil {
+			glog.Warningf("sqs queue %s move message %s to dead letter queue %s: %v", q.definition().Name, m.MessageId, dlqName, err)
+			q.state.deadLettered(m, false)
+			continue
+		}
+		q.state.deadLettered(m, true)
+		glog.V(1).Infof("sqs queue %s moved message %s to dead letter queue %s after %d receives", q.definition().Name, m.MessageId, dlqName, m.receiveCount)
+	}
+}
+
+// systemAttributes returns the message system attributes of a received message.
+func (m *message) systemAttributes() map[string]string {
+	attributes := map[string]string{
+		"SenderId":                         m.SenderId,
+		"SentTimestamp":                    strconv.FormatInt(m.SentTimestamp, 10),
+		"ApproximateReceiveCount":          strconv.Itoa(m.receiveCount),
+		"ApproximateFirstReceiveTimestamp": strconv.FormatInt(m.firstReceiveTimestamp, 10),
+	}
+	if m.MessageGroupId != "" {
+		attributes["MessageGroupId"] = m.MessageGroupId
+		attributes["MessageDeduplicationId"] = m.MessageDeduplicationId
+		attributes["SequenceNumber"] = m.SequenceNumber
+	}
+	return attributes
+}
@@ -0,0 +1,395 @@
+package sqs
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// deduplicationInterval is how long a FIFO queue remembers the deduplication ids of sent messages
+	deduplicationInterval = 5 * time.Minute
+)
+
+// StoredMessage is a message as stored in the MQ topic of its queue.
+type StoredMessage struct {
+	MessageId              string                           `json:"id"`
+	Body                   string                           `json:"body"`
+	MessageAttributes      map[string]MessageAttributeValue `json:"attributes,omitempty"`
+	SentTimestamp          int64                            `json:"sent"` // unix milliseconds
+	SenderId               string                           `json:"sender,omitempty"`
+	MessageGroupId         string                           `json:"group,omitempty"`
+	MessageDeduplicationId string                           `json:"dedup,omitempty"`
+	SequenceNumber         string                           `json:"seq,omitempty"`
+	// the receive count from the source queue, of a message moved to a dead letter queue
+	ReceiveCount int `json:"receiveCount,omitempty"`
+}
+
+// message is a message read from the MQ topic, until it is deleted.
+type message struct {
+	*StoredMessage
+	receiveCount          int
+	firstReceiveTimestamp int64 // unix milliseconds
+	receiptHandle         string
+	arrival               uint64 // the order the message was read from MQ
+	visibleAt             time.Time
+	inflight              bool
+	deadLettering         bool
+	// closed when the message is deleted, so that its MQ offset can be committed
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (m *message) markDone() {
+	m.doneOnce.Do(func() {
+		close(m.done)
+	})
+}
+
+type messageGroup struct {
+	pending  []*message // in sequence order
+	inflight int
+}
+
+type sendResult struct {
+	messageId      string
+	sequenceNumber string
+	expiresAt      time.Time
+}
+
+// queueState tracks the messages of a queue read by this gateway: the visible ones waiting to be
+// received, and the received ones until they are deleted or their visibility timeout expires.
+// A FIFO queue delivers the messages of each message group in order, one receive at a time.
+type queueState struct {
+	lock     sync.Mutex
+	fifo     bool
+	messages map[string]*message // by message id, visible or in flight
+
+	available []*message // visible messages of a standard queue
+
+	groups     map[string]*messageGroup // message groups of a FIFO queue
+	groupOrder []string                 // FIFO message groups with visible messages, in arrival order
+
+	dedup map[string]*sendResult // FIFO deduplication ids of recently sent messages
+
+	arrivals uint64
+
+	notify chan struct{} // closed when messages become visible
+}
+
+func newQueueState(fifo bool) *queueState {
+	return &queueState{
+		fifo:     fifo,
+		messages: make(map[string]*message),
+		groups:   make(map[string]*messageGroup),
+		dedup:    make(map[string]*sendResult),
+		notify:   make(chan struct{}),
+	}
+}
+
+// add makes a message read from MQ visible. A message read again, e.g. after the subscriber
+// reconnected, returns the tracked message.
+func (s *queueState) add(stored *StoredMessage) *message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if m, found := s.messages[stored.MessageId]; found {
+		return m
+	}
+	m := &message{
+		StoredMessage: stored,
+		receiveCount:  stored.ReceiveCount,
+		done:          make(chan struct{}),
+	}
+	s.arrivals++
+	m.arrival = s.arrivals
+	s.messages[m.MessageId] = m
+	s.makeVisible(m)
+	return m
+}
+
+// makeVisible must be called with the lock held.
+func (s *queueState) makeVisible(m *message) {
+	m.inflight, m.deadLettering = false, false
+	if !s.fifo {
+		s.available = append(s.available, m)
+	} else {
+		group := s.groups[m.MessageGroupId]
+		if group == nil {
+			group = &messageGroup{}
+			s.groups[m.MessageGroupId] = group
+		}
+		if len(group.pending) == 0 {
+			s.groupOrder = append(s.groupOrder, m.MessageGroupId)
+		}
+		group.pending = append(group.pending, m)
+		// a message becoming visible again goes before the later messages of its group
+		sort.Slice(group.pending, func(i, j int) bool {
+			return group.pending[i].arrival < group.pending[j].arrival
+		})
+	}
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// receive returns up to maxMessages visible messages, and makes them invisible until the visibility timeout.
+// The messages received more than maxReceiveCount times are returned separately to be dead lettered.
+func (s *queueState) receive(maxMessages int, visibilityTimeout time.Duration, maxReceiveCount int, now time.Time) (received, deadLetters []*message) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+
+	take := func(m *message) {
+		if maxReceiveCount > 0 && m.receiveCount >= maxReceiveCount {
+			m.inflight, m.deadLettering = true, true
+			m.visibleAt = now.Add(visibilityTimeout)
+			deadLetters = append(deadLetters, m)
+			return
+		}
+		m.receiveCount++
+		if m.firstReceiveTimestamp == 0 {
+			m.firstReceiveTimestamp = now.UnixMilli()
+		}
+		m.receiptHandle = newReceiptHandle(m.MessageId)
+		m.inflight = true
+		m.visibleAt = now.Add(visibilityTimeout)
+		received = append(received, m)
+	}
+
+	if !s.fifo {
+		for len(received) < maxMessages && len(s.available) > 0 {
+			m := s.available[0]
+			s.available = s.available[1:]
+			take(m)
+		}
+		return
+	}
+
+	var remainingGroups []string
+	for _, groupId := range s.groupOrder {
+		group := s.groups[groupId]
+		// a group is locked while any of its messages is in flight
+		if group.inflight > 0 || len(received) >= maxMessages {
+			remainingGroups = append(remainingGroups, groupId)
+			continue
+		}
+		for len(received) < maxMessages && len(group.pending) > 0 {
+			m := group.pending[0]
+			group.pending = group.pending[1:]
+			group.inflight++
+			take(m)
+		}
+		if len(group.pending) > 0 {
+			remainingGroups = append(remainingGroups, groupId)
+		}
+	}
+	s.groupOrder = remainingGroups
+	return
+}
+
+// expire makes the in flight messages past their visibility timeout visible again.
+// It must be called with the lock held.
+func (s *queueState) expire(now time.Time) {
+	var expired []*message
+	for _, m := range s.messages {
+		if m.inflight && !m.deadLettering && !now.Before(m.visibleAt) {
+			expired = append(expired, m)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].arrival < expired[j].arrival
+	})
+	for _, m := range expired {
+		s.release(m)
+	}
+}
+
+// release must be called with the lock held.
+func (s *queueState) release(m *message) {
+	if s.fifo {
+		if group := s.groups[m.MessageGroupId]; group != nil && group.inflight > 0 {
+			group.inflight--
+		}
+	}
+	s.makeVisible(m)
+}
+
+// remove forgets a deleted message, and must be called with the lock held.
+func (s *queueState) remove(m *message) {
+	delete(s.messages, m.MessageId)
+	if s.fifo && m.inflight {
+		if group := s.groups[m.MessageGroupId]; group != nil {
+			if group.inflight > 0 {
+				group.inflight--
+			}
+			if group.inflight == 0 && len(group.pending) == 0 {
+				delete(s.groups, m.MessageGroupId)
+			} else if group.inflight == 0 {
+				// the next message of the group can be received
+				close(s.notify)
+				s.notify = make(chan struct{})
+			}
+		}
+	}
+	m.inflight = false
+	m.markDone()
+}
+
+// delete deletes a received message. A receipt handle of an earlier receive, or of a message
+// already deleted, is accepted without deleting anything, as SQS does.
+func (s *queueState) delete(receiptHandle string) error {
+	messageId, ok := parseReceiptHandle(receiptHandle)
+	if !ok {
+		return errReceiptHandleIsInvalid(receiptHandle)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	m, found := s.messages[messageId]
+	if !found || !m.inflight || m.deadLettering || m.receiptHandle != receiptHandle {
+		return nil
+	}
+	s.remove(m)
+	return nil
+}
+
+// changeVisibility sets the visibility timeout of a received message from now on.
+func (s *queueState) changeVisibility(receiptHandle string, timeout time.Duration, now time.Time) error {
+	messageId, ok := parseReceiptHandle(receiptHandle)
+	if !ok {
+		return errReceiptHandleIsInvalid(receiptHandle)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	m, found := s.messages[messageId]
+	if !found || !m.inflight || m.deadLettering || m.receiptHandle != receiptHandle || !now.Before(m.visibleAt) {
+		return errMessageNotInflight()
+	}
+	if timeout <= 0 {
+		s.release(m)
+		return nil
+	}
+	m.visibleAt = now.Add(timeout)
+	return nil
+}
+
+// deadLettered forgets the messages moved to the dead letter queue, or makes them visible again if moving failed.
+func (s *queueState) deadLettered(m *message, moved bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if moved {
+		s.remove(m)
+	} else {
+		s.release(m)
+	}
+}
+
+// purge deletes the visible messages.
+func (s *queueState) purge() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	count := 0
+	for _, m := range s.messages {
+		if !m.inflight {
+			delete(s.messages, m.MessageId)
+			m.markDone()
+			count++
+		}
+	}
+	s.available = nil
+	for groupId, group := range s.groups {
+		group.pending = nil
+		if group.inflight == 0 {
+			delete(s.groups, groupId)
+		}
+	}
+	s.groupOrder = nil
+	return count
+}
+
+// reset forgets the messages read from MQ, after the subscriber stopped.
+func (s *queueState) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.messages = make(map[string]*message)
+	s.available = nil
+	s.groups = make(map[string]*messageGroup)
+	s.groupOrder = nil
+}
+
+// counts returns the number of visible messages, and of messages in flight.
+func (s *queueState) counts(now time.Time) (visible, inflight int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	for _, m := range s.messages {
+		if m.inflight {
+			inflight++
+		} else {
+			visible++
+		}
+	}
+	return
+}
+
+// waitChan returns a channel closed when messages become visible.
+func (s *queueState) waitChan() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.notify
+}
+
+// nextVisibleAt returns when the earliest in flight message becomes visible, or zero if none.
+func (s *queueState) nextVisibleAt() (next time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, m := range s.messages {
+		if m.inflight && !m.deadLettering && (next.IsZero() || m.visibleAt.Before(next)) {
+			next = m.visibleAt
+		}
+	}
+	return
+}
+
+// deduplicate returns the result of sending a message with the same deduplication id
+// within the deduplication interval, or records the result of this send.
+func (s *queueState) deduplicate(deduplicationId string, result *sendResult, now time.Time) (existing *sendResult, found bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for id, r := range s.dedup {
+		if now.After(r.expiresAt) {
+			delete(s.dedup, id)
+		}
+	}
+	if r, found := s.dedup[deduplicationId]; found {
+		return r, true
+	}
+	result.expiresAt = now.Add(deduplicationInterval)
+	s.dedup[deduplicationId] = result
+	return nil, false
+}
+
+// forgetDeduplication removes the deduplication id of a send that failed.
+func (s *queueState) forgetDeduplication(deduplicationId string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.dedup, deduplicationId)
+}
+
+// newReceiptHandle identifies one receive of a message.
+func newReceiptHandle(messageId string) string {
+	var nonce [12]byte
+	rand.Read(nonce[:])
+	return base64.RawURLEncoding.EncodeToString([]byte(messageId + ":" + hex.EncodeToString(nonce[:])))
+}
+
+func parseReceiptHandle(receiptHandle string) (messageId string, ok bool) {
+	data, err := base64.RawURLEncoding.DecodeString(receiptHandle)
+	if err != nil {
+		return "", false
+	}
+	messageId, _, ok = strings.Cut(string(data), ":")
+	return messageId, ok && messageId != ""
+}
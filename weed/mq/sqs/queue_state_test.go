@@ -0,0 +1,180 @@
+package sqs
+
+import (
+	"testing"
+	"time"
+)
+
+func addMessages(s *queueState, group string, ids ...string) {
+	for _, id := range ids {
+		s.add(&StoredMessage{MessageId: id, Body: id, MessageGroupId: group})
+	}
+}
+
+func messageIds(messages []*message) (ids []string) {
+	for _, m := range messages {
+		ids = append(ids, m.MessageId)
+	}
+	return
+}
+
+func TestVisibilityTimeout(t *testing.T) {
+	s := newQueueState(false)
+	addMessages(s, "", "m1", "m2")
+	now := time.Now()
+
+	received, _ := s.receive(10, 30*time.Second, 0, now)
+	if len(received) != 2 {
+		t.Fatalf("received %v", messageIds(received))
+	}
+	if again, _ := s.receive(10, 30*time.Second, 0, now.Add(10*time.Second)); len(again) != 0 {
+		t.Fatalf("in flight messages received again: %v", messageIds(again))
+	}
+	if visible, inflight := s.counts(now); visible != 0 || inflight != 2 {
+		t.Errorf("counts %d visible %d in flight", visible, inflight)
+	}
+
+	firstHandle := received[0].receiptHandle
+	if err := s.delete(received[1].receiptHandle); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	select {
+	case <-received[1].done:
+	default:
+		t.Errorf("deleted message not done")
+	}
+
+	// m1 becomes visible again after its visibility timeout
+	again, _ := s.receive(10, 30*time.Second, 0, now.Add(31*time.Second))
+	if len(again) != 1 || again[0].MessageId != "m1" || again[0].receiveCount != 2 {
+		t.Fatalf("received again %v", messageIds(again))
+	}
+	// the receipt handle of the earlier receive deletes nothing
+	if err := s.delete(firstHandle); err != nil {
+		t.Fatalf("delete with stale handle: %v", err)
+	}
+	if _, inflight := s.counts(now.Add(31 * time.Second)); inflight != 1 {
+		t.Errorf("stale handle deleted the message")
+	}
+	if err := s.delete("not a handle"); err == nil {
+		t.Errorf("expected an invalid receipt handle")
+	}
+}
+
+func TestChangeVisibility(t *testing.T) {
+	s := newQueueState(false)
+	addMessages(s, "", "m1")
+	now := time.Now()
+	received, _ := s.receive(1, 30*time.Second, 0, now)
+	firstHandle := received[0].receiptHandle
+
+	if err := s.changeVisibility(firstHandle, time.Minute, now.Add(20*time.Second)); err != nil {
+		t.Fatalf("change visibility: %v", err)
+	}
+	if again, _ := s.receive(1, 30*time.Second, 0, now.Add(40*time.Second)); len(again) != 0 {
+		t.Fatalf("received before the extended timeout")
+	}
+	if err := s.changeVisibility(firstHandle, 0, now.Add(40*time.Second)); err != nil {
+		t.Fatalf("change visibility to 0: %v", err)
+	}
+	again, _ := s.receive(1, 30*time.Second, 0, now.Add(40*time.Second))
+	if len(again) != 1 {
+		t.Fatalf("not visible after a zero visibility timeout")
+	}
+	if err := s.changeVisibility(firstHandle, time.Minute, now.Add(40*time.Second)); err == nil {
+		t.Errorf("expected the earlier receipt handle to be rejected")
+	}
+}
+
+func TestFifoGroupOrder(t *testing.T) {
+	s := newQueueState(true)
+	addMessages(s, "g1", "a1", "a2")
+	addMessages(s, "g2", "b1")
+	addMessages(s, "g1", "a3")
+	now := time.Now()
+
+	received, _ := s.receive(2, 30*time.Second, 0, now)
+	if ids := messageIds(received); len(ids) != 2 || ids[0] != "a1" || ids[1] != "a2" {
+		t.Fatalf("first receive %v", ids)
+	}
+	// g1 is locked while its messages are in flight
+	received2, _ := s.receive(10, 30*time.Second, 0, now)
+	if ids := messageIds(received2); len(ids) != 1 || ids[0] != "b1" {
+		t.Fatalf("second receive %v", ids)
+	}
+
+	// a1 expires and goes back before a3, while a2 is still locking the group
+	if err := s.changeVisibility(received[0].receiptHandle, 0, now); err != nil {
+		t.Fatalf("change visibility: %v", err)
+	}
+	if locked, _ := s.receive(10, 30*time.Second, 0, now); len(locked) != 0 {
+		t.Fatalf("group received while a message is in flight: %v", messageIds(locked))
+	}
+	if err := s.delete(received[1].receiptHandle); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	received3, _ := s.receive(10, 30*time.Second, 0, now)
+	if ids := messageIds(received3); len(ids) != 2 || ids[0] != "a1" || ids[1] != "a3" {
+		t.Fatalf("third receive %v", ids)
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	s := newQueueState(false)
+	addMessages(s, "", "m1")
+	now := time.Now()
+	for i := 1; i <= 2; i++ {
+		received, deadLetters := s.receive(1, time.Second, 2, now)
+		if len(received) != 1 || len(deadLetters) != 0 {
+			t.Fatalf("receive %d: %v %v", i, messageIds(received), messageIds(deadLetters))
+		}
+		now = now.Add(2 * time.Second)
+	}
+	received, deadLetters := s.receive(1, time.Second, 2, now)
+	if len(received) != 0 || len(deadLetters) != 1 || deadLetters[0].receiveCount != 2 {
+		t.Fatalf("third receive %v %v", messageIds(received), messageIds(deadLetters))
+	}
+	// dead lettering does not expire like a visibility timeout
+	if again, _ := s.receive(1, time.Second, 2, now.Add(time.Minute)); len(again) != 0 {
+		t.Fatalf("received while dead lettering")
+	}
+	s.deadLettered(deadLetters[0], true)
+	if visible, inflight := s.counts(now); visible != 0 || inflight != 0 {
+		t.Errorf("counts %d %d after moving to the dead letter queue", visible, inflight)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	s := newQueueState(true)
+	now := time.Now()
+	if _, found := s.deduplicate("d1", &sendResult{messageId: "m1"}, now); found {
+		t.Fatalf("new deduplication id found")
+	}
+	existing, found := s.deduplicate("d1", &sendResult{messageId: "m2"}, now.Add(time.Minute))
+	if !found || existing.messageId != "m1" {
+		t.Fatalf("duplicate not found: %+v", existing)
+	}
+	if _, found = s.deduplicate("d1", &sendResult{messageId: "m3"}, now.Add(deduplicationInterval+time.Second)); found {
+		t.Errorf("deduplication id kept after the deduplication interval")
+	}
+	s.forgetDeduplication("d1")
+	if _, found = s.deduplicate("d1", &sendResult{messageId: "m4"}, now); found {
+		t.Errorf("forgotten deduplication id found")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	s := newQueueState(false)
+	addMessages(s, "", "m1", "m2", "m3")
+	now := time.Now()
+	received, _ := s.receive(1, 30*time.Second, 0, now)
+	if purged := s.purge(); purged != 2 {
+		t.Errorf("purged %d", purged)
+	}
+	if visible, inflight := s.counts(now); visible != 0 || inflight != 1 {
+		t.Errorf("counts %d %d after purge", visible, inflight)
+	}
+	if err := s.delete(received[0].receiptHandle); err != nil {
+		t.Errorf("delete after purge: %v", err)
+	}
+}
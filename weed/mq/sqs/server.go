@@ -0,0 +1,387 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/filer_client"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	stats_collect "github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/wdclient"
+	"google.golang.org/grpc"
+)
+
+const (
+	clusterRefreshInterval = 30 * time.Second
+	// how long the queue definitions are cached, so that changes by other gateways are seen
+	queueRefreshInterval = 10 * time.Second
+	// a queue not received from for this long stops reading its MQ topic,
+	// leaving its partitions to the gateways that receive from it
+	subscriberIdleTimeout = 5 * time.Minute
+	maxRequestSize        = 2 << 20
+	DefaultAccountId      = "000000000000"
+)
+
+type Options struct {
+	Listen            string
+	ClientHost        string // the address registered to the masters
+	Masters           string
+	FilerGroup        string
+	Namespace         string // the MQ namespace of the queue topics
+	Region            string // the region of the queue ARNs
+	DefaultPartitions int32
+	// Auth authenticates requests with AWS signatures of the S3 identities, and authorizes
+	// the actions on the queue names as S3 bucket names.
+	Auth bool
+	// AuthConfig is an optional S3 identities file, added to the identities on the filer.
+	AuthConfig string
+}
+
+type Server struct {
+	opts           Options
+	grpcDialOption grpc.DialOption
+	masterClient   *wdclient.MasterClient
+	store          *Store
+	iam            *s3api.IdentityAccessManagement // nil accepts any request
+	httpServer     *http.Server
+	ln             net.Listener
+	ctx            context.Context
+	cancel         context.CancelFunc
+	done           chan error
+
+	brokers     []string
+	brokersLock sync.RWMutex
+
+	queues     map[string]*queueRuntime
+	queuesLock sync.Mutex
+}
+
+func NewServer(opts Options) (*Server, error) {
+	if opts.Masters == "" {
+		return nil, fmt.Errorf("masters are required")
+	}
+	if opts.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if opts.DefaultPartitions <= 0 {
+		opts.DefaultPartitions = 4
+	}
+	util.LoadSecurityConfiguration()
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.mq")
+	masterDiscovery := pb.ServerAddresses(opts.Masters).ToServiceDiscovery()
+	clientHost := opts.ClientHost
+	if clientHost == "" {
+		clientHost = opts.Listen
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		opts:           opts,
+		grpcDialOption: grpcDialOption,
+		masterClient:   wdclient.NewMasterClient(grpcDialOption, opts.FilerGroup, "sqs-gateway", pb.ServerAddress(clientHost), "", "", *masterDiscovery),
+		ctx:            ctx,
+		cancel:         cancel,
+		done:           make(chan error, 1),
+		queues:         make(map[string]*queueRuntime),
+	}
+	go s.masterClient.KeepConnectedToMaster(ctx)
+
+	filers, err := s.discover(cluster.FilerType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("discover filers: %w", err)
+	}
+	if len(filers) == 0 {
+		cancel()
+		return nil, fmt.Errorf("no filers discovered from masters %s", opts.Masters)
+	}
+	var filerAddresses []pb.ServerAddress
+	for _, filer := range filers {
+		filerAddresses = append(filerAddresses, pb.ServerAddress(filer))
+	}
+	s.store = NewStore(filer_client.NewFilerClientAccessor(filerAddresses, grpcDialOption))
+	if opts.Auth {
+		s.iam = s3api.NewIdentityAccessManagement(&s3api.S3ApiServerOption{
+			Filers:         filerAddresses,
+			GrpcDialOption: grpcDialOption,
+			Config:         opts.AuthConfig,
+		})
+	}
+
+	if err = s.refreshBrokers(); err != nil {
+		cancel()
+		return nil, err
+	}
+	s.httpServer = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s, nil
+}
+
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.opts.Listen)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	glog.V(0).Infof("SQS gateway listening on %s, storing into namespace %s", ln.Addr(), s.opts.Namespace)
+
+	go s.loopMaintenance()
+	go func() {
+		err := s.httpServer.Serve(ln)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		s.done <- err
+	}()
+	return nil
+}
+
+// Wait blocks until the server is closed.
+func (s *Server) Wait() error {
+	return <-s.done
+}
+
+func (s *Server) Close() error {
+	s.cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.httpServer.Shutdown(ctx)
+
+	s.queuesLock.Lock()
+	defer s.queuesLock.Unlock()
+	for name, q := range s.queues {
+		q.close()
+		delete(s.queues, name)
+	}
+	return err
+}
+
+// Addr returns the bound address of the server listener, or empty if not started.
+func (s *Server) Addr() string {
+	if s.ln == nil {
+		return ""
+	}
+	return s.ln.Addr().String()
+}
+
+func (s *Server) discover(clientType string) (addresses []string, err error) {
+	err = s.masterClient.WithClient(false, func(client master_pb.SeaweedClient) error {
+		resp, err := client.ListClusterNodes(context.Background(), &master_pb.ListClusterNodesRequest{
+			ClientType: clientType,
+			FilerGroup: s.opts.FilerGroup,
+			Limit:      1000,
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.ClusterNodes {
+			if node.Address != "" {
+				addresses = append(addresses, node.Address)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+func (s *Server) refreshBrokers() error {
+	brokers, err := s.discover(cluster.BrokerType)
+	if err != nil {
+		return fmt.Errorf("discover brokers: %w", err)
+	}
+	if len(brokers) == 0 {
+		return fmt.Errorf("no brokers discovered from masters %s", s.opts.Masters)
+	}
+	s.brokersLock.Lock()
+	s.brokers = brokers
+	s.brokersLock.Unlock()
+	return nil
+}
+
+func (s *Server) getBrokers() []string {
+	s.brokersLock.RLock()
+	defer s.brokersLock.RUnlock()
+	return s.brokers
+}
+
+func (s *Server) withBrokerClient(fn func(client mq_pb.SeaweedMessagingClient) error) (err error) {
+	for _, broker := range s.getBrokers() {
+		if err = pb.WithBrokerGrpcClient(false, broker, s.grpcDialOption, fn); err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = fmt.Errorf("no brokers available")
+	}
+	return err
+}
+
+// loopMaintenance refreshes the brokers, and stops reading the queues not received from.
+func (s *Server) loopMaintenance() {
+	ticker := time.NewTicker(clusterRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.refreshBrokers(); err != nil {
+				glog.Warningf("sqs gateway: %v", err)
+			}
+			s.queuesLock.Lock()
+			for _, q := range s.queues {
+				q.stopIdleSubscriber(time.Now())
+			}
+			s.queuesLock.Unlock()
+		}
+	}
+}
+
+// getQueue returns the runtime of an existing queue.
+func (s *Server) getQueue(name string) (*queueRuntime, error) {
+	s.queuesLock.Lock()
+	defer s.queuesLock.Unlock()
+	q, found := s.queues[name]
+	if found && time.Since(q.loadedAt) < queueRefreshInterval {
+		return q, nil
+	}
+
+	definition, err := s.store.LoadQueue(name)
+	if err != nil {
+		return nil, errInternal(err)
+	}
+	if definition == nil || found && definition.Topic != q.definition().Topic {
+		// deleted, or deleted and created again
+		if found {
+			q.close()
+			delete(s.queues, name)
+		}
+		if definition == nil {
+			return nil, errQueueDoesNotExist()
+		}
+		found = false
+	}
+	if found {
+		q.setDefinition(definition)
+		return q, nil
+	}
+	q = newQueueRuntime(s, definition)
+	s.queues[name] = q
+	return q, nil
+}
+
+// forgetQueue drops the runtime of a deleted queue.
+func (s *Server) forgetQueue(name string) {
+	s.queuesLock.Lock()
+	defer s.queuesLock.Unlock()
+	if q, found := s.queues[name]; found {
+		q.close()
+		delete(s.queues, name)
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestId := uuid.NewString()
+	if r.Method != http.MethodPost && r.Method != http.MethodGet {
+		writeError(w, requestId, false, newError("InvalidAction", "InvalidAction", http.StatusMethodNotAllowed, "The method %s is not supported.", r.Method))
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, requestId, false, errInvalidParameter("read request: %v", err))
+		return
+	}
+	action, params, isJSON, err := parseRequest(r, body)
+	if err != nil {
+		writeError(w, requestId, isJSON, toError(err))
+		return
+	}
+
+	result, err := s.dispatch(r, action, params)
+	status := http.StatusOK
+	if err != nil {
+		e := toError(err)
+		status = e.HTTPStatus
+		if !e.isSenderFault() {
+			glog.Errorf("sqs %s: %v", action, e)
+		} else {
+			glog.V(1).Infof("sqs %s: %v", action, e)
+		}
+		writeError(w, requestId, isJSON, e)
+	} else {
+		writeResult(w, action, requestId, isJSON, result)
+	}
+	stats_collect.MqSqsRequestsCounter.WithLabelValues(action, fmt.Sprint(status)).Inc()
+}
+
+func toError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return errInternal(err)
+}
+
+// authorize checks the signature of the request, and that the identity can do the action on the queue.
+// An empty queue name checks an action on all queues.
+func (s *Server) authorize(r *http.Request, action s3api.Action, queueName string) error {
+	if s.iam == nil || !s.iam.IsAuthEnabled() {
+		return nil
+	}
+	identity, errCode := s.iam.AuthSignatureOnly(r)
+	if errCode != s3err.ErrNone {
+		return errSignature(s3err.GetAPIError(errCode).Description)
+	}
+	if s.iam.VerifyActionPermission(r, identity, action, queueName, "") != s3err.ErrNone {
+		return errAccessDenied()
+	}
+	return nil
+}
+
+func (s *Server) queueUrl(r *http.Request, name string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, r.Host, DefaultAccountId, name)
+}
+
+func (s *Server) queueArn(name string) string {
+	return queueArn(s.opts.Region, DefaultAccountId, name)
+}
+
+// actions and the permissions they need
+var actionPermissions = map[string]s3api.Action{
+	"CreateQueue":                  s3_constants.ACTION_ADMIN,
+	"DeleteQueue":                  s3_constants.ACTION_ADMIN,
+	"SetQueueAttributes":           s3_constants.ACTION_ADMIN,
+	"PurgeQueue":                   s3_constants.ACTION_WRITE,
+	"GetQueueUrl":                  s3_constants.ACTION_READ,
+	"GetQueueAttributes":           s3_constants.ACTION_READ,
+	"ListQueues":                   s3_constants.ACTION_LIST,
+	"SendMessage":                  s3_constants.ACTION_WRITE,
+	"SendMessageBatch":             s3_constants.ACTION_WRITE,
+	"ReceiveMessage":               s3_constants.ACTION_READ,
+	"DeleteMessage":                s3_constants.ACTION_WRITE,
+	"DeleteMessageBatch":           s3_constants.ACTION_WRITE,
+	"ChangeMessageVisibility":      s3_constants.ACTION_WRITE,
+	"ChangeMessageVisibilityBatch": s3_constants.ACTION_WRITE,
+}
@@ -0,0 +1,168 @@
+package sub_coordinator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/schema"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+)
+
+const (
+	DefaultRedeliveryBackoff    = time.Second
+	DefaultMaxRedeliveryBackoff = time.Minute
+)
+
+// RedeliveryPolicy decides when a message that failed processing is redelivered,
+// and when it is given up and sent to the dead letter topic.
+type RedeliveryPolicy struct {
+	MaxDeliveryAttempts int32
+	Backoff             time.Duration
+	MaxBackoff          time.Duration
+	DeadLetterTopic     topic.Topic
+}
+
+// NewRedeliveryPolicy returns nil if the subscription does not bound redelivery.
+func NewRedeliveryPolicy(policy *mq_pb.DeadLetterPolicy, t topic.Topic, consumerGroup string) *RedeliveryPolicy {
+	if policy.GetMaxDeliveryAttempts() <= 0 {
+		return nil
+	}
+	p := &RedeliveryPolicy{
+		MaxDeliveryAttempts: policy.MaxDeliveryAttempts,
+		Backoff:             time.Duration(policy.RedeliveryBackoffMs) * time.Millisecond,
+		MaxBackoff:          time.Duration(policy.MaxRedeliveryBackoffMs) * time.Millisecond,
+		DeadLetterTopic:     DefaultDeadLetterTopic(t, consumerGroup),
+	}
+	if p.Backoff <= 0 {
+		p.Backoff = DefaultRedeliveryBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultMaxRedeliveryBackoff
+	}
+	if p.MaxBackoff < p.Backoff {
+		p.MaxBackoff = p.Backoff
+	}
+	if policy.DeadLetterTopic != nil && policy.DeadLetterTopic.Name != "" {
+		p.DeadLetterTopic = topic.FromPbTopic(policy.DeadLetterTopic)
+		if p.DeadLetterTopic.Namespace == "" {
+			p.DeadLetterTopic.Namespace = t.Namespace
+		}
+	}
+	return p
+}
+
+// DefaultDeadLetterTopic is "<topic>.<consumer_group>.dlq" in the namespace of the topic.
+func DefaultDeadLetterTopic(t topic.Topic, consumerGroup string) topic.Topic {
+	if consumerGroup == "" {
+		return topic.NewTopic(t.Namespace, t.Name+".dlq")
+	}
+	return topic.NewTopic(t.Namespace, t.Name+"."+consumerGroup+".dlq")
+}
+
+// ShouldDeadLetter tells whether a message delivered this many times should not be redelivered.
+func (p *RedeliveryPolicy) ShouldDeadLetter(deliveryAttempts int32) bool {
+	return deliveryAttempts >= p.MaxDeliveryAttempts
+}
+
+// RedeliveryDelay is the wait before the next delivery, doubled after each failed attempt.
+func (p *RedeliveryPolicy) RedeliveryDelay(deliveryAttempts int32) time.Duration {
+	delay := p.Backoff
+	for i := int32(1); i < deliveryAttempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// Fields of the records in a dead letter topic
+const (
+	DeadLetterFieldNamespace        = "source_namespace"
+	DeadLetterFieldTopic            = "source_topic"
+	DeadLetterFieldConsumerGroup    = "consumer_group"
+	DeadLetterFieldPartitionStart   = "partition_start"
+	DeadLetterFieldPartitionStop    = "partition_stop"
+	DeadLetterFieldKey              = "key"
+	DeadLetterFieldValue            = "value"
+	DeadLetterFieldTsNs             = "ts_ns"
+	DeadLetterFieldDeliveryAttempts = "delivery_attempts"
+	DeadLetterFieldError            = "error"
+	DeadLetterFieldFailedAt         = "failed_at"
+)
+
+// DeadLetter is a message that failed processing, with the failure metadata.
+type DeadLetter struct {
+	Topic            topic.Topic
+	ConsumerGroup    string
+	Partition        topic.Partition
+	Key              []byte
+	Value            []byte
+	TsNs             int64
+	DeliveryAttempts int32
+	Error            string
+	FailedAt         time.Time
+}
+
+// DeadLetterRecordType is the schema of the dead letter topics.
+func DeadLetterRecordType() *schema_pb.RecordType {
+	return schema.RecordTypeBegin().
+		WithField(DeadLetterFieldNamespace, schema.TypeString).
+		WithField(DeadLetterFieldTopic, schema.TypeString).
+		WithField(DeadLetterFieldConsumerGroup, schema.TypeString).
+		WithField(DeadLetterFieldPartitionStart, schema.TypeInt32).
+		WithField(DeadLetterFieldPartitionStop, schema.TypeInt32).
+		WithField(DeadLetterFieldKey, schema.TypeBytes).
+		WithField(DeadLetterFieldValue, schema.TypeBytes).
+		WithField(DeadLetterFieldTsNs, schema.TypeInt64).
+		WithField(DeadLetterFieldDeliveryAttempts, schema.TypeInt32).
+		WithField(DeadLetterFieldError, schema.TypeString).
+		WithField(DeadLetterFieldFailedAt, schema.TypeTimestamp).
+		RecordTypeEnd()
+}
+
+// ToRecord converts the dead letter into a record of the dead letter topic.
+func (d *DeadLetter) ToRecord() *schema_pb.RecordValue {
+	record := schema.RecordBegin().
+		SetString(DeadLetterFieldNamespace, d.Topic.Namespace).
+		SetString(DeadLetterFieldTopic, d.Topic.Name).
+		SetString(DeadLetterFieldConsumerGroup, d.ConsumerGroup).
+		SetInt32(DeadLetterFieldPartitionStart, d.Partition.RangeStart).
+		SetInt32(DeadLetterFieldPartitionStop, d.Partition.RangeStop).
+		SetBytes(DeadLetterFieldKey, d.Key).
+		SetBytes(DeadLetterFieldValue, d.Value).
+		SetInt64(DeadLetterFieldTsNs, d.TsNs).
+		SetInt32(DeadLetterFieldDeliveryAttempts, d.DeliveryAttempts).
+		SetString(DeadLetterFieldError, d.Error).
+		RecordEnd()
+	record.Fields[DeadLetterFieldFailedAt] = &schema_pb.Value{Kind: &schema_pb.Value_TimestampValue{TimestampValue: &schema_pb.TimestampValue{
+		TimestampMicros: d.FailedAt.UnixMicro(),
+		IsUtc:           true,
+	}}}
+	return record
+}
+
+// ParseDeadLetter reads a record of a dead letter topic.
+func ParseDeadLetter(record *schema_pb.RecordValue) (*DeadLetter, error) {
+	if record == nil || record.Fields[DeadLetterFieldTopic] == nil {
+		return nil, fmt.Errorf("not a dead letter record")
+	}
+	fields := record.Fields
+	d := &DeadLetter{
+		Topic:            topic.NewTopic(fields[DeadLetterFieldNamespace].GetStringValue(), fields[DeadLetterFieldTopic].GetStringValue()),
+		ConsumerGroup:    fields[DeadLetterFieldConsumerGroup].GetStringValue(),
+		Key:              fields[DeadLetterFieldKey].GetBytesValue(),
+		Value:            fields[DeadLetterFieldValue].GetBytesValue(),
+		TsNs:             fields[DeadLetterFieldTsNs].GetInt64Value(),
+		DeliveryAttempts: fields[DeadLetterFieldDeliveryAttempts].GetInt32Value(),
+		Error:            fields[DeadLetterFieldError].GetStringValue(),
+	}
+	d.Partition.RangeStart = fields[DeadLetterFieldPartitionStart].GetInt32Value()
+	d.Partition.RangeStop = fields[DeadLetterFieldPartitionStop].GetInt32Value()
+	if ts := fields[DeadLetterFieldFailedAt].GetTimestampValue(); ts != nil {
+		d.FailedAt = time.UnixMicro(ts.TimestampMicros)
+	}
+	return d, nil
+}
@@ -0,0 +1,66 @@
+package sub_coordinator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeliveryPolicy(t *testing.T) {
+	orders := topic.NewTopic("shop", "orders")
+
+	assert.Nil(t, NewRedeliveryPolicy(nil, orders, "billing"))
+	assert.Nil(t, NewRedeliveryPolicy(&mq_pb.DeadLetterPolicy{}, orders, "billing"))
+
+	p := NewRedeliveryPolicy(&mq_pb.DeadLetterPolicy{
+		MaxDeliveryAttempts:    3,
+		RedeliveryBackoffMs:    100,
+		MaxRedeliveryBackoffMs: 300,
+	}, orders, "billing")
+	assert.Equal(t, topic.NewTopic("shop", "orders.billing.dlq"), p.DeadLetterTopic)
+	assert.False(t, p.ShouldDeadLetter(2))
+	assert.True(t, p.ShouldDeadLetter(3))
+	assert.Equal(t, 100*time.Millisecond, p.RedeliveryDelay(1))
+	assert.Equal(t, 200*time.Millisecond, p.RedeliveryDelay(2))
+	assert.Equal(t, 300*time.Millisecond, p.RedeliveryDelay(3))
+
+	p = NewRedeliveryPolicy(&mq_pb.DeadLetterPolicy{
+		MaxDeliveryAttempts: 1,
+		DeadLetterTopic:     &schema_pb.Topic{Name: "failed"},
+	}, orders, "billing")
+	assert.Equal(t, topic.NewTopic("shop", "failed"), p.DeadLetterTopic)
+	assert.Equal(t, DefaultRedeliveryBackoff, p.RedeliveryDelay(1))
+}
+
+func TestDeadLetterRecord(t *testing.T) {
+	d := &DeadLetter{
+		Topic:            topic.NewTopic("shop", "orders"),
+		ConsumerGroup:    "billing",
+		Partition:        topic.Partition{RangeStart: 0, RangeStop: 630},
+		Key:              []byte("order-1"),
+		Value:            []byte("payload"),
+		TsNs:             12345,
+		DeliveryAttempts: 3,
+		Error:            "card declined",
+		FailedAt:         time.UnixMicro(time.Now().UnixMicro()),
+	}
+
+	parsed, err := ParseDeadLetter(d.ToRecord())
+	assert.NoError(t, err)
+	assert.Equal(t, d.Topic, parsed.Topic)
+	assert.Equal(t, d.ConsumerGroup, parsed.ConsumerGroup)
+	assert.Equal(t, d.Partition.RangeStop, parsed.Partition.RangeStop)
+	assert.Equal(t, d.Key, parsed.Key)
+	assert.Equal(t, d.Value, parsed.Value)
+	assert.Equal(t, d.TsNs, parsed.TsNs)
+	assert.Equal(t, d.DeliveryAttempts, parsed.DeliveryAttempts)
+	assert.Equal(t, d.Error, parsed.Error)
+	assert.True(t, d.FailedAt.Equal(parsed.FailedAt))
+
+	_, err = ParseDeadLetter(&schema_pb.RecordValue{})
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 
 type InflightMessageTracker struct {
 	messages   map[string]int64
+	attempts   map[string]int32 // delivery attempts of the inflight message of each key
 	mu         sync.Mutex
 	timestamps *RingBuffer
 }
@@ -14,6 +15,7 @@ type InflightMessageTracker struct {
 func NewInflightMessageTracker(capacity int) *InflightMessageTracker {
 	return &InflightMessageTracker{
 		messages:   make(map[string]int64),
+		attempts:   make(map[string]int32),
 		timestamps: NewRingBuffer(capacity),
 	}
 }
@@ -25,9 +27,34 @@ func (imt *InflightMessageTracker) EnflightMessage(key []byte, tsNs int64) {
 	imt.mu.Lock()
 	defer imt.mu.Unlock()
 	imt.messages[string(key)] = tsNs
+	imt.attempts[string(key)] = 1
 	imt.timestamps.EnflightTimestamp(tsNs)
 }
 
+// RedeliverMessage counts one more delivery of an inflight message, and returns the delivery attempt.
+// It returns 0 if the message is no longer inflight.
+func (imt *InflightMessageTracker) RedeliverMessage(key []byte, tsNs int64) int32 {
+	imt.mu.Lock()
+	defer imt.mu.Unlock()
+	timestamp, exists := imt.messages[string(key)]
+	if !exists || timestamp != tsNs {
+		return 0
+	}
+	imt.attempts[string(key)]++
+	return imt.attempts[string(key)]
+}
+
+// DeliveryAttempts returns how many times the inflight message has been delivered, 0 if not inflight.
+func (imt *InflightMessageTracker) DeliveryAttempts(key []byte, tsNs int64) int32 {
+	imt.mu.Lock()
+	defer imt.mu.Unlock()
+	timestamp, exists := imt.messages[string(key)]
+	if !exists || timestamp != tsNs {
+		return 0
+	}
+	return imt.attempts[string(key)]
+}
+
 // IsMessageAcknowledged returns true if the message has been acknowledged.
 // If the message is older than the oldest inflight messages, returns false.
 // returns false if the message is inflight.
@@ -60,6 +87,7 @@ func (imt *InflightMessageTracker) AcknowledgeMessage(key []byte, tsNs int64) bo
 		return false
 	}
 	delete(imt.messages, string(key))
+	delete(imt.attempts, string(key))
 	// Remove the specific timestamp from the ring buffer.
 	imt.timestamps.AckTimestamp(tsNs)
 	return true
@@ -69,6 +97,14 @@ func (imt *InflightMessageTracker) GetOldestAckedTimestamp() int64 {
 	return imt.timestamps.OldestAckedTimestamp()
 }
 
+// InflightTimestamp returns the timestamp of the inflight message with the key.
+func (imt *InflightMessageTracker) InflightTimestamp(key []byte) (tsNs int64, found bool) {
+	imt.mu.Lock()
+	defer imt.mu.Unlock()
+	tsNs, found = imt.messages[string(key)]
+	return
+}
+
 // IsInflight returns true if the message with the key is inflight.
 func (imt *InflightMessageTracker) IsInflight(key []byte) bool {
 	imt.mu.Lock()
@@ -85,6 +121,7 @@ func (imt *InflightMessageTracker) Cleanup() int {
 	count := len(imt.messages)
 	// Clear all in-flight messages
 	imt.messages = make(map[string]int64)
+	imt.attempts = make(map[string]int32)
 	return count
 }
 
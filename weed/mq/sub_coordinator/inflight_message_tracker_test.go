@@ -132,3 +132,19 @@ func TestInflightMessageTracker4(t *testing.T) {
 	assert.Equal(t, int64(3), tracker.GetOldestAckedTimestamp())
 
 }
+
+func TestInflightMessageTrackerDeliveryAttempts(t *testing.T) {
+	tracker := NewInflightMessageTracker(1)
+
+	tracker.EnflightMessage([]byte("1"), int64(1))
+	assert.Equal(t, int32(1), tracker.DeliveryAttempts([]byte("1"), int64(1)))
+	assert.Equal(t, int32(2), tracker.RedeliverMessage([]byte("1"), int64(1)))
+	assert.Equal(t, int32(2), tracker.DeliveryAttempts([]byte("1"), int64(1)))
+
+	// a different message of the same key is not inflight
+	assert.Equal(t, int32(0), tracker.RedeliverMessage([]byte("1"), int64(2)))
+
+	assert.True(t, tracker.AcknowledgeMessage([]byte("1"), int64(1)))
+	assert.Equal(t, int32(0), tracker.DeliveryAttempts([]byte("1"), int64(1)))
+	assert.Equal(t, int32(0), tracker.RedeliverMessage([]byte("1"), int64(1)))
+}
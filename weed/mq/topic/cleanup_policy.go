@@ -0,0 +1,89 @@
+package topic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+// Cleanup policies follow the Kafka "cleanup.policy" topic config.
+// "compact" keeps only the latest message of each key, and "compact,delete"
+// additionally drops messages older than the retention period.
+const (
+	CleanupPolicyDelete  = "delete"
+	CleanupPolicyCompact = "compact"
+
+	DefaultDeleteRetention  = 24 * time.Hour
+	DefaultMinCompactionLag = 10 * time.Minute
+)
+
+// NormalizeCleanupPolicy validates a cleanup policy, and returns it in a canonical form.
+func NormalizeCleanupPolicy(policy string) (string, error) {
+	var policies []string
+	seen := make(map[string]bool)
+	for _, p := range strings.Split(policy, ",") {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" || seen[p] {
+			continue
+		}
+		if p != CleanupPolicyDelete && p != CleanupPolicyCompact {
+			return "", fmt.Errorf("unknown cleanup policy %q", p)
+		}
+		seen[p] = true
+		policies = append(policies, p)
+	}
+	if len(policies) == 0 {
+		return CleanupPolicyDelete, nil
+	}
+	sort.Strings(policies)
+	return strings.Join(policies, ","), nil
+}
+
+// CleanupPolicy returns the cleanup policy of a topic, "delete" if not set.
+func CleanupPolicy(retention *mq_pb.TopicRetention) string {
+	if retention.GetCleanupPolicy() == "" {
+		return CleanupPolicyDelete
+	}
+	return retention.GetCleanupPolicy()
+}
+
+// IsCompacted tells whether the topic keeps only the latest message of each key.
+func IsCompacted(retention *mq_pb.TopicRetention) bool {
+	for _, p := range strings.Split(CleanupPolicy(retention), ",") {
+		if p == CleanupPolicyCompact {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteRetention is how long compaction keeps tombstones, i.e. messages with a key but no value.
+func DeleteRetention(retention *mq_pb.TopicRetention) time.Duration {
+	if retention.GetDeleteRetentionSeconds() > 0 {
+		return time.Duration(retention.GetDeleteRetentionSeconds()) * time.Second
+	}
+	return DefaultDeleteRetention
+}
+
+// MinCompactionLag is the minimum age of log files before they are compacted.
+func MinCompactionLag(retention *mq_pb.TopicRetention) time.Duration {
+	if retention.GetMinCompactionLagSeconds() > 0 {
+		return time.Duration(retention.GetMinCompactionLagSeconds()) * time.Second
+	}
+	return DefaultMinCompactionLag
+}
+
+// CompactionRetention is the age after which compaction drops any message, 0 to keep them.
+// It only applies to topics with the "compact,delete" policy.
+func CompactionRetention(retention *mq_pb.TopicRetention) time.Duration {
+	if !retention.GetEnabled() || retention.GetRetentionSeconds() <= 0 {
+		return 0
+	}
+	if !strings.Contains(CleanupPolicy(retention), CleanupPolicyDelete) {
+		return 0
+	}
+	return time.Duration(retention.GetRetentionSeconds()) * time.Second
+}
@@ -0,0 +1,139 @@
+package topic
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// Delayed messages are held in time bucketed segment files, until they are due:
+//
+//	/topics/<namespace>/<topic>/.delayed/<rangeStart>-<rangeStop>/<bucket>
+//
+// Each segment holds the messages due within one bucket interval, as a sequence of
+// [4 bytes size][mq_pb.DataMessage]. The "cancelled" file of the partition holds the
+// cancelled keys with their cancellation time.
+// The partition generation is not part of the path, so that a new generation of the
+// same partition range continues to deliver the delayed messages.
+const (
+	DelayedDirName        = ".delayed"
+	DelayedCancelFile     = "cancelled"
+	DelayedBucketInterval = time.Minute
+	DelayedBucketFormat   = "2006-01-02-15-04"
+)
+
+func DelayedPartitionDir(t Topic, p Partition) string {
+	return fmt.Sprintf("%s/%s/%04d-%04d", t.Dir(), DelayedDirName, p.RangeStart, p.RangeStop)
+}
+
+// DelayedBucketName is the name of the segment holding the messages due at deliverAtNs.
+func DelayedBucketName(deliverAtNs int64) string {
+	return time.Unix(0, deliverAtNs).UTC().Truncate(DelayedBucketInterval).Format(DelayedBucketFormat)
+}
+
+// ParseDelayedBucketName returns the start time of the bucket.
+func ParseDelayedBucketName(name string) (time.Time, error) {
+	return time.Parse(DelayedBucketFormat, name)
+}
+
+func EncodeDelayedMessage(message *mq_pb.DataMessage) ([]byte, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4, 4+len(data))
+	util.Uint32toBytes(buf, uint32(len(data)))
+	return append(buf, data...), nil
+}
+
+func EachDelayedMessage(buf []byte, fn func(message *mq_pb.DataMessage) error) error {
+	for pos := 0; pos+4 <= len(buf); {
+		size := int(util.BytesToUint32(buf[pos : pos+4]))
+		if pos+4+size > len(buf) {
+			return fmt.Errorf("delayed message [%d,%d) exceeds %d", pos, pos+4+size, len(buf))
+		}
+		message := &mq_pb.DataMessage{}
+		if err := proto.Unmarshal(buf[pos+4:pos+4+size], message); err != nil {
+			return fmt.Errorf("unmarshal delayed message: %w", err)
+		}
+		if err := fn(message); err != nil {
+			return err
+		}
+		pos += 4 + size
+	}
+	return nil
+}
+
+// DelayedQueue orders the loaded delayed messages by their delivery time.
+// It is not thread safe.
+type DelayedQueue struct {
+	items delayedHeap
+}
+
+func (q *DelayedQueue) Len() int {
+	return len(q.items)
+}
+
+func (q *DelayedQueue) Push(message *mq_pb.DataMessage) {
+	heap.Push(&q.items, message)
+}
+
+// NextDeliverAtNs returns the delivery time of the earliest message, or 0 if empty.
+func (q *DelayedQueue) NextDeliverAtNs() int64 {
+	if len(q.items) == 0 {
+		return 0
+	}
+	return q.items[0].DeliverAtNs
+}
+
+// PopDue removes and returns the messages due at or before nowNs, in delivery order.
+func (q *DelayedQueue) PopDue(nowNs int64) (due []*mq_pb.DataMessage) {
+	for len(q.items) > 0 && q.items[0].DeliverAtNs <= nowNs {
+		due = append(due, heap.Pop(&q.items).(*mq_pb.DataMessage))
+	}
+	return
+}
+
+// Cancel removes the messages of the key, and returns them.
+func (q *DelayedQueue) Cancel(key []byte) (cancelled []*mq_pb.DataMessage) {
+	kept := q.items[:0]
+	for _, message := range q.items {
+		if string(message.Key) == string(key) {
+			cancelled = append(cancelled, message)
+		} else {
+			kept = append(kept, message)
+		}
+	}
+	for i := len(kept); i < len(q.items); i++ {
+		q.items[i] = nil
+	}
+	q.items = kept
+	heap.Init(&q.items)
+	return
+}
+
+type delayedHeap []*mq_pb.DataMessage
+
+func (h delayedHeap) Len() int { return len(h) }
+func (h delayedHeap) Less(i, j int) bool {
+	if h[i].DeliverAtNs == h[j].DeliverAtNs {
+		return h[i].TsNs < h[j].TsNs
+	}
+	return h[i].DeliverAtNs < h[j].DeliverAtNs
+}
+func (h delayedHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *delayedHeap) Push(x any) {
+	*h = append(*h, x.(*mq_pb.DataMessage))
+}
+func (h *delayedHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
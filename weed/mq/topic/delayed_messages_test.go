@@ -0,0 +1,72 @@
+package topic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
+)
+
+func TestDelayedBucketName(t *testing.T) {
+	deliverAt := time.Date(2025, 3, 4, 5, 6, 7, 8, time.UTC)
+	name := DelayedBucketName(deliverAt.UnixNano())
+	if name != "2025-03-04-05-06" {
+		t.Fatalf("bucket name %s", name)
+	}
+	bucketStart, err := ParseDelayedBucketName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bucketStart.Equal(deliverAt.Truncate(DelayedBucketInterval)) {
+		t.Errorf("bucket start %v", bucketStart)
+	}
+	if _, err := ParseDelayedBucketName(DelayedCancelFile); err == nil {
+		t.Errorf("cancel file parsed as a bucket")
+	}
+}
+
+func TestDelayedMessageEncoding(t *testing.T) {
+	var buf []byte
+	for _, key := range []string{"a", "b", "c"} {
+		data, err := EncodeDelayedMessage(&mq_pb.DataMessage{Key: []byte(key), Value: []byte("v"), DeliverAtNs: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf = append(buf, data...)
+	}
+	var keys string
+	if err := EachDelayedMessage(buf, func(message *mq_pb.DataMessage) error {
+		keys += string(message.Key)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if keys != "abc" {
+		t.Errorf("decoded keys %q", keys)
+	}
+	if err := EachDelayedMessage(buf[:len(buf)-1], func(message *mq_pb.DataMessage) error { return nil }); err == nil {
+		t.Errorf("expected an error for a truncated segment")
+	}
+}
+
+func TestDelayedQueue(t *testing.T) {
+	q := &DelayedQueue{}
+	q.Push(&mq_pb.DataMessage{Key: []byte("c"), DeliverAtNs: 30})
+	q.Push(&mq_pb.DataMessage{Key: []byte("a"), DeliverAtNs: 10})
+	q.Push(&mq_pb.DataMessage{Key: []byte("b"), DeliverAtNs: 20})
+	q.Push(&mq_pb.DataMessage{Key: []byte("b"), DeliverAtNs: 40})
+
+	if next := q.NextDeliverAtNs(); next != 10 {
+		t.Fatalf("next delivery %d", next)
+	}
+	if cancelled := q.Cancel([]byte("b")); len(cancelled) != 2 {
+		t.Fatalf("cancelled %d messages", len(cancelled))
+	}
+	due := q.PopDue(30)
+	if len(due) != 2 || string(due[0].Key) != "a" || string(due[1].Key) != "c" {
+		t.Fatalf("due messages %v", due)
+	}
+	if q.Len() != 0 || q.NextDeliverAtNs() != 0 {
+		t.Errorf("queue not empty: %d", q.Len())
+	}
+}
@@ -0,0 +1,325 @@
+package nfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// NFSv3 (RFC 1813) constants
+const (
+	nfsProgram  = 100003
+	nfsVersion3 = 3
+
+	nfs3Ok             = 0
+	nfs3ErrPerm        = 1
+	nfs3ErrNoEnt       = 2
+	nfs3ErrIO          = 5
+	nfs3ErrAcces       = 13
+	nfs3ErrExist       = 17
+	nfs3ErrNotDir      = 20
+	nfs3ErrIsDir       = 21
+	nfs3ErrInval       = 22
+	nfs3ErrNoSpc       = 28
+	nfs3ErrRoFs        = 30
+	nfs3ErrNameTooLong = 63
+	nfs3ErrNotEmpty    = 66
+	nfs3ErrStale       = 70
+	nfs3ErrBadHandle   = 10001
+	nfs3ErrNotSync     = 10002
+	nfs3ErrBadCookie   = 10003
+	nfs3ErrNotSupp     = 10004
+	nfs3ErrTooSmall    = 10005
+	nfs3ErrServerFault = 10006
+
+	nf3Reg  = 1
+	nf3Dir  = 2
+	nf3Blk  = 3
+	nf3Chr  = 4
+	nf3Lnk  = 5
+	nf3Sock = 6
+	nf3Fifo = 7
+
+	maxNameLen    = 255
+	maxPathLen    = 4096
+	maxHandleSize = 64
+
+	timeDontChange    = 0
+	timeSetToServer   = 1
+	timeSetToClient   = 2
+	blockSize         = 512
+	preferredReadSize = 1024 * 1024
+	maxReadSize       = 1024 * 1024
+)
+
+// nfsError carries an NFS status through the filer operations
+type nfsError uint32
+
+func (e nfsError) Error() string {
+	return fmt.Sprintf("nfs status %d", uint32(e))
+}
+
+// toNfsStatus maps filer errors to NFS status codes
+func toNfsStatus(err error) uint32 {
+	if err == nil {
+		return nfs3Ok
+	}
+	var ne nfsError
+	if errors.As(err, &ne) {
+		return uint32(ne)
+	}
+	if errors.Is(err, filer_pb.ErrNotFound) || errors.Is(err, os.ErrNotExist) {
+		return nfs3ErrNoEnt
+	}
+	message := err.Error()
+	switch {
+	case strings.Contains(message, filer_pb.ErrNotFound.Error()):
+		return nfs3ErrNoEnt
+	case strings.Contains(message, "non-empty"), strings.Contains(message, "not empty"):
+		return nfs3ErrNotEmpty
+	case strings.Contains(message, "EEXIST"), strings.Contains(message, "already exists"):
+		return nfs3ErrExist
+	case strings.Contains(message, "is a file"), strings.Contains(message, "not a directory"):
+		return nfs3ErrNotDir
+	}
+	return nfs3ErrIO
+}
+
+// fileAttributes is fattr3
+type fileAttributes struct {
+	fileType uint32
+	mode     uint32
+	nlink    uint32
+	uid      uint32
+	gid      uint32
+	size     uint64
+	used     uint64
+	rdev     uint32
+	fileid   uint64
+	atime    time.Time
+	mtime    time.Time
+	ctime    time.Time
+}
+
+func (ns *NfsServer) toFileAttributes(inode uint64, entry *filer_pb.Entry) *fileAttributes {
+	attr := &fileAttributes{
+		fileType: nf3Reg,
+		nlink:    1,
+		fileid:   inode,
+	}
+	var mode os.FileMode
+	var mtime, crtime int64
+	if entry.Attributes != nil {
+		mode = os.FileMode(entry.Attributes.FileMode)
+		attr.uid, attr.gid = ns.option.UidGidMapper.FilerToLocal(entry.Attributes.Uid, entry.Attributes.Gid)
+		attr.rdev = entry.Attributes.Rdev
+		mtime = entry.Attributes.Mtime
+		crtime = entry.Attributes.Crtime
+		if entry.HardLinkCounter > 0 {
+			attr.nlink = uint32(entry.HardLinkCounter)
+		}
+	}
+	switch {
+	case entry.IsDirectory || mode&os.ModeDir != 0:
+		attr.fileType = nf3Dir
+		attr.nlink = 2
+	case mode&os.ModeSymlink != 0:
+		attr.fileType = nf3Lnk
+		attr.size = uint64(len(entry.Attributes.SymlinkTarget))
+	case mode&os.ModeNamedPipe != 0:
+		attr.fileType = nf3Fifo
+	case mode&os.ModeSocket != 0:
+		attr.fileType = nf3Sock
+	case mode&os.ModeCharDevice != 0:
+		attr.fileType = nf3Chr
+	case mode&os.ModeDevice != 0:
+		attr.fileType = nf3Blk
+	default:
+		attr.size = filer.FileSize(entry)
+	}
+	attr.mode = uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		attr.mode |= 0o4000
+	}
+	if mode&os.ModeSetgid != 0 {
+		attr.mode |= 0o2000
+	}
+	if mode&os.ModeSticky != 0 {
+		attr.mode |= 0o1000
+	}
+	attr.used = (attr.size + blockSize - 1) / blockSize * blockSize
+	attr.mtime = time.Unix(mtime, 0)
+	attr.atime = attr.mtime
+	attr.ctime = attr.mtime
+	if crtime > mtime {
+		attr.ctime = time.Unix(crtime, 0)
+	}
+	return attr
+}
+
+func (ns *NfsServer) writeFileAttributes(w *xdrWriter, attr *fileAttributes) {
+	w.uint32(attr.fileType)
+	w.uint32(attr.mode)
+	w.uint32(attr.nlink)
+	w.uint32(attr.uid)
+	w.uint32(attr.gid)
+	w.uint64(attr.size)
+	w.uint64(attr.used)
+	w.uint32(attr.rdev >> 8) // specdata1, major
+	w.uint32(attr.rdev & 0xff)
+	w.uint64(uint64(ns.handles.exportId))
+	w.uint64(attr.fileid)
+	writeNfsTime(w, attr.atime)
+	writeNfsTime(w, attr.mtime)
+	writeNfsTime(w, attr.ctime)
+}
+
+// writePostOpAttr writes post_op_attr, which is optional
+func (ns *NfsServer) writePostOpAttr(w *xdrWriter, attr *fileAttributes) {
+	if attr == nil {
+		w.bool(false)
+		return
+	}
+	w.bool(true)
+	ns.writeFileAttributes(w, attr)
+}
+
+// writeWccData writes wcc_data without the optional pre-operation attributes
+func (ns *NfsServer) writeWccData(w *xdrWriter, after *fileAttributes) {
+	w.bool(false)
+	ns.writePostOpAttr(w, after)
+}
+
+func writeNfsTime(w *xdrWriter, t time.Time) {
+	w.uint32(uint32(t.Unix()))
+	w.uint32(uint32(t.Nanosecond()))
+}
+
+// setAttributes is sattr3
+type setAttributes struct {
+	mode     *uint32
+	uid      *uint32
+	gid      *uint32
+	size     *uint64
+	atime    *time.Time
+	mtime    *time.Time
+	hasValue bool
+}
+
+func readSetAttributes(r *xdrReader) (*setAttributes, error) {
+	s := &setAttributes{}
+	readOptionalUint32 := func() (*uint32, error) {
+		set, err := r.bool()
+		if err != nil || !set {
+			return nil, err
+		}
+		v, err := r.uint32()
+		return &v, err
+	}
+	readTime := func() (*time.Time, error) {
+		how, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		switch how {
+		case timeDontChange:
+			return nil, nil
+		case timeSetToServer:
+			t := time.Now()
+			return &t, nil
+		case timeSetToClient:
+			seconds, err := r.uint32()
+			if err != nil {
+				return nil, err
+			}
+			nseconds, err := r.uint32()
+			if err != nil {
+				return nil, err
+			}
+			t := time.Unix(int64(seconds), int64(nseconds))
+			return &t, nil
+		}
+		return nil, errRpcGarbageArgs
+	}
+
+	var err error
+	if s.mode, err = readOptionalUint32(); err != nil {
+		return nil, err
+	}
+	if s.uid, err = readOptionalUint32(); err != nil {
+		return nil, err
+	}
+	if s.gid, err = readOptionalUint32(); err != nil {
+		return nil, err
+	}
+	if set, err := r.bool(); err != nil {
+		return nil, err
+	} else if set {
+		size, err := r.uint64()
+		if err != nil {
+			return nil, err
+		}
+		s.size = &size
+	}
+	if s.atime, err = readTime(); err != nil {
+		return nil, err
+	}
+	if s.mtime, err = readTime(); err != nil {
+		return nil, err
+	}
+	s.hasValue = s.mode != nil || s.uid != nil || s.gid != nil || s.size != nil || s.atime != nil || s.mtime != nil
+	return s, nil
+}
+
+// apply sets the attributes on the entry, except the size which needs the file data
+func (ns *NfsServer) apply(s *setAttributes, entry *filer_pb.Entry) {
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	if s.mode != nil {
+		entry.Attributes.FileMode = chmod(entry.Attributes.FileMode, *s.mode)
+	}
+	if s.uid != nil || s.gid != nil {
+		uid, gid := ns.option.UidGidMapper.FilerToLocal(entry.Attributes.Uid, entry.Attributes.Gid)
+		if s.uid != nil {
+			uid = *s.uid
+		}
+		if s.gid != nil {
+			gid = *s.gid
+		}
+		entry.Attributes.Uid, entry.Attributes.Gid = ns.option.UidGidMapper.LocalToFiler(uid, gid)
+	}
+	if s.mtime != nil {
+		entry.Attributes.Mtime = s.mtime.Unix()
+	}
+}
+
+// chmod replaces the permission bits of the os.FileMode stored in the entry with the unix mode
+func chmod(fileMode uint32, mode uint32) uint32 {
+	m := os.FileMode(fileMode)
+	m = m&^(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky) | os.FileMode(mode)&os.ModePerm
+	if mode&0o4000 != 0 {
+		m |= os.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		m |= os.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		m |= os.ModeSticky
+	}
+	return uint32(m)
+}
+
+// newFileMode converts the unix mode of sattr3 to os.FileMode
+func newFileMode(s *setAttributes, defaultPerm uint32, typeBits os.FileMode) uint32 {
+	perm := defaultPerm
+	if s != nil && s.mode != nil {
+		perm = *s.mode
+	}
+	return chmod(uint32(typeBits), perm)
+}
@@ -0,0 +1,205 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	// cookies 1 and 2 are "." and "..", the directory entries follow
+	firstEntryCookie = 3
+
+	dirListingTtl = 2 * time.Minute
+
+	// encoded sizes used to fit the entries into the requested reply size
+	postOpAttrSize    = 4 + 84
+	postOpHandleSize  = 4 + 4 + handleSize
+	readDirHeaderSize = 4 + postOpAttrSize + 8 + 4 + 4
+)
+
+// dirListing is a snapshot of a directory, taken when a client starts listing with cookie 0.
+// Later calls continue from the snapshot with the same cookie verifier,
+// so entries removed or added while listing do not shift the cookies.
+type dirListing struct {
+	entries   []*filer_pb.Entry
+	createdAt time.Time
+}
+
+type dirListingKey struct {
+	inode    uint64
+	verifier uint64
+}
+
+type dirListingCache struct {
+	sync.Mutex
+	listings map[dirListingKey]*dirListing
+}
+
+func newDirListingCache() *dirListingCache {
+	return &dirListingCache{
+		listings: make(map[dirListingKey]*dirListing),
+	}
+}
+
+func (c *dirListingCache) get(inode, verifier uint64) *dirListing {
+	c.Lock()
+	defer c.Unlock()
+	return c.listings[dirListingKey{inode, verifier}]
+}
+
+func (c *dirListingCache) put(inode uint64, listing *dirListing) (verifier uint64) {
+	c.Lock()
+	defer c.Unlock()
+	for key, l := range c.listings {
+		if time.Since(l.createdAt) > dirListingTtl {
+			delete(c.listings, key)
+		}
+	}
+	verifier = uint64(listing.createdAt.UnixNano())
+	for c.listings[dirListingKey{inode, verifier}] != nil {
+		verifier++
+	}
+	c.listings[dirListingKey{inode, verifier}] = listing
+	return verifier
+}
+
+func (ns *NfsServer) listDirectory(dirPath util.FullPath) (*dirListing, error) {
+	listing := &dirListing{createdAt: time.Now()}
+	err := filer_pb.ReadDirAllEntries(context.Background(), ns, dirPath, "", func(entry *filer_pb.Entry, isLast bool) error {
+		listing.entries = append(listing.entries, entry)
+		return nil
+	})
+	return listing, err
+}
+
+type readDirEntry struct {
+	name  string
+	inode uint64
+	attr  *fileAttributes
+}
+
+// nfsReadDir serves READDIR, and READDIRPLUS which also returns the attributes and handles of the entries
+func (ns *NfsServer) nfsReadDir(call *rpcCall, w *xdrWriter, plus bool) error {
+	dirInode, dirPath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	cookie, err := call.args.uint64()
+	if err != nil {
+		return err
+	}
+	verifierBytes, err := call.args.fixedOpaque(8)
+	if err != nil {
+		return err
+	}
+	verifier := binary.BigEndian.Uint64(verifierBytes)
+	dirCount, err := call.args.uint32()
+	if err != nil {
+		return err
+	}
+	maxCount := dirCount
+	if plus {
+		if maxCount, err = call.args.uint32(); err != nil {
+			return err
+		}
+	}
+
+	var dirAttr *fileAttributes
+	if status == nfs3Ok {
+		dirAttr, _, status = ns.getAttributes(dirInode, dirPath)
+	}
+	if status == nfs3Ok && dirAttr.fileType != nf3Dir {
+		status = nfs3ErrNotDir
+	}
+
+	var listing *dirListing
+	if status == nfs3Ok {
+		if cookie == 0 {
+			if listing, err = ns.listDirectory(dirPath); err != nil {
+				glog.V(0).Infof("nfs: list %s: %v", dirPath, err)
+				status = toNfsStatus(err)
+			} else {
+				verifier = ns.dirListings.put(dirInode, listing)
+			}
+		} else if listing = ns.dirListings.get(dirInode, verifier); listing == nil {
+			status = nfs3ErrBadCookie
+		}
+	}
+	if status != nfs3Ok {
+		w.uint32(status)
+		ns.writePostOpAttr(w, dirAttr)
+		return nil
+	}
+
+	var entries []readDirEntry
+	var cookies []uint64
+	entriesSize, dirSize := readDirHeaderSize, 0
+	eof := true
+	for next := cookie + 1; next < uint64(len(listing.entries))+firstEntryCookie; next++ {
+		var e readDirEntry
+		switch next {
+		case 1:
+			e = readDirEntry{name: ".", inode: dirInode, attr: dirAttr}
+		case 2:
+			var parentStatus uint32
+			e.name = ".."
+			if e.inode, e.attr, parentStatus = ns.lookupParent(dirPath); parentStatus != nfs3Ok {
+				e.inode, e.attr = dirInode, dirAttr
+			}
+		default:
+			entry := listing.entries[next-firstEntryCookie]
+			e.name = entry.Name
+			e.inode = ns.handles.track(dirPath.Child(entry.Name), entry)
+			if plus {
+				if f := ns.findOpenFile(e.inode); f != nil {
+					if dirtyEntry := f.dirtyEntry(); dirtyEntry != nil {
+						entry = dirtyEntry
+					}
+				}
+				e.attr = ns.toFileAttributes(e.inode, entry)
+			}
+		}
+
+		size := 4 + 8 + 4 + (len(e.name)+3)&^3 + 8
+		dirSize += size
+		if plus {
+			size += postOpAttrSize + postOpHandleSize
+		}
+		if entriesSize+size > int(maxCount) || dirSize > int(dirCount) {
+			eof = false
+			break
+		}
+		entriesSize += size
+		entries = append(entries, e)
+		cookies = append(cookies, next)
+	}
+	if len(entries) == 0 && !eof {
+		w.uint32(nfs3ErrTooSmall)
+		ns.writePostOpAttr(w, dirAttr)
+		return nil
+	}
+
+	w.uint32(nfs3Ok)
+	ns.writePostOpAttr(w, dirAttr)
+	w.uint64(verifier)
+	for i, e := range entries {
+		w.bool(true)
+		w.uint64(e.inode)
+		w.string(e.name)
+		w.uint64(cookies[i])
+		if plus {
+			ns.writePostOpAttr(w, e.attr)
+			w.bool(true)
+			w.opaque(ns.handles.toHandle(e.inode))
+		}
+	}
+	w.bool(false)
+	w.bool(eof)
+	return nil
+}
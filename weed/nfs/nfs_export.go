@@ -0,0 +1,79 @@
+package nfs
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ExportAcl decides which client networks may mount the export, and whether read-write or read-only.
+type ExportAcl struct {
+	rules []exportRule
+}
+
+type exportRule struct {
+	network  *net.IPNet
+	readOnly bool
+}
+
+// NewExportAcl parses a comma separated list of "<cidr or ip>[:ro|:rw]" rules.
+// The most specific matching network decides the access. An empty list allows every client read-write.
+func NewExportAcl(rulesStr string) (*ExportAcl, error) {
+	acl := &ExportAcl{}
+	for _, ruleStr := range strings.Split(rulesStr, ",") {
+		ruleStr = strings.TrimSpace(ruleStr)
+		if ruleStr == "" {
+			continue
+		}
+		rule := exportRule{}
+		if idx := strings.LastIndex(ruleStr, ":"); idx >= 0 {
+			switch ruleStr[idx+1:] {
+			case "ro":
+				rule.readOnly = true
+				ruleStr = ruleStr[:idx]
+			case "rw":
+				ruleStr = ruleStr[:idx]
+			}
+		}
+		if !strings.Contains(ruleStr, "/") {
+			ip := net.ParseIP(ruleStr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid client network %q", ruleStr)
+			}
+			if ip.To4() != nil {
+				ruleStr += "/32"
+			} else {
+				ruleStr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(ruleStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client network %q: %v", ruleStr, err)
+		}
+		rule.network = network
+		acl.rules = append(acl.rules, rule)
+	}
+	return acl, nil
+}
+
+// Check returns whether the client is allowed, and whether its access is read-only.
+func (acl *ExportAcl) Check(ip net.IP) (allowed bool, readOnly bool) {
+	if len(acl.rules) == 0 {
+		return true, false
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	bestPrefix := -1
+	for _, rule := range acl.rules {
+		if !rule.network.Contains(ip) {
+			continue
+		}
+		prefix, _ := rule.network.Mask.Size()
+		if prefix > bestPrefix {
+			bestPrefix = prefix
+			readOnly = rule.readOnly
+		}
+	}
+	return bestPrefix >= 0, readOnly
+}
@@ -0,0 +1,50 @@
+package nfs
+
+import (
+	"net"
+	"testing"
+)
+
+func TestExportAcl(t *testing.T) {
+	acl, err := NewExportAcl("10.0.0.0/8, 10.1.0.0/16:ro, 192.168.1.5, fd00::/8:ro")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		ip       string
+		allowed  bool
+		readOnly bool
+	}{
+		{"10.2.3.4", true, false},
+		{"10.1.2.3", true, true}, // the more specific network wins
+		{"192.168.1.5", true, false},
+		{"192.168.1.6", false, false},
+		{"fd00::1", true, true},
+		{"::ffff:10.2.3.4", true, false},
+	}
+	for _, tt := range tests {
+		allowed, readOnly := acl.Check(net.ParseIP(tt.ip))
+		if allowed != tt.allowed || readOnly != tt.readOnly {
+			t.Errorf("%s: got allowed=%v readOnly=%v, want %v %v", tt.ip, allowed, readOnly, tt.allowed, tt.readOnly)
+		}
+	}
+}
+
+func TestExportAclDefaultAllowsAll(t *testing.T) {
+	acl, err := NewExportAcl("")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if allowed, readOnly := acl.Check(net.ParseIP("1.2.3.4")); !allowed || readOnly {
+		t.Fatalf("expected read-write access, got allowed=%v readOnly=%v", allowed, readOnly)
+	}
+}
+
+func TestExportAclInvalid(t *testing.T) {
+	for _, rules := range []string{"10.0.0.0/33", "not-an-ip", "10.0.0.1:rx"} {
+		if _, err := NewExportAcl(rules); err == nil {
+			t.Errorf("expected error for %q", rules)
+		}
+	}
+}
@@ -0,0 +1,580 @@
+package nfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/page_writer"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxWriteSize = 1024 * 1024
+
+	stableUnstable = 0
+	stableDataSync = 1
+	stableFileSync = 2
+
+	// how long the entry of a file without buffered writes is trusted
+	entryCacheTtl = time.Second
+	// how long a file without buffered writes is kept open
+	openFileIdleTimeout = time.Minute
+)
+
+// openFile keeps the state of a file being read or written.
+// NFS is stateless, so the gateway keeps files open on READ and WRITE,
+// buffers UNSTABLE writes in the chunked upload pipeline of weed mount,
+// and saves the entry on COMMIT, on stable writes, or after the write-behind delay.
+type openFile struct {
+	sync.Mutex
+	ns             *NfsServer
+	inode          uint64
+	fullpath       util.FullPath
+	entry          *filer_pb.Entry
+	entryLoadedAt  time.Time
+	lastAccess     time.Time
+	firstDirtyAt   time.Time
+	dirty          bool
+	closed         bool
+	lastWriteStop  int64
+	uploadPipeline *page_writer.UploadPipeline
+
+	// chunksLock guards the entry chunks, which the upload pipeline appends to from its uploaders
+	chunksLock sync.Mutex
+	reader     *filer.ChunkReadAt
+	uploadErr  error
+}
+
+func (ns *NfsServer) findOpenFile(inode uint64) *openFile {
+	ns.openFilesLock.Lock()
+	defer ns.openFilesLock.Unlock()
+	return ns.openFiles[inode]
+}
+
+// lockOpenFile returns the locked open file of the inode, opening it if needed
+func (ns *NfsServer) lockOpenFile(inode uint64, fullpath util.FullPath) *openFile {
+	for {
+		ns.openFilesLock.Lock()
+		f, found := ns.openFiles[inode]
+		if !found {
+			f = &openFile{
+				ns:       ns,
+				inode:    inode,
+				fullpath: fullpath,
+			}
+			ns.openFiles[inode] = f
+		}
+		ns.openFilesLock.Unlock()
+
+		f.Lock()
+		if !f.closed {
+			f.fullpath = fullpath
+			f.lastAccess = time.Now()
+			return f
+		}
+		// closed after being looked up, try again
+		f.Unlock()
+	}
+}
+
+// dropOpenFile discards the file state, including writes not yet flushed
+func (ns *NfsServer) dropOpenFile(inode uint64) {
+	ns.openFilesLock.Lock()
+	f, found := ns.openFiles[inode]
+	delete(ns.openFiles, inode)
+	ns.openFilesLock.Unlock()
+	if found {
+		f.Lock()
+		f.destroy()
+		f.Unlock()
+	}
+}
+
+// dirtyEntry returns a copy of the entry if it has writes not yet flushed to the filer
+func (f *openFile) dirtyEntry() *filer_pb.Entry {
+	f.Lock()
+	defer f.Unlock()
+	if !f.dirty {
+		return nil
+	}
+	f.chunksLock.Lock()
+	defer f.chunksLock.Unlock()
+	return proto.Clone(f.entry).(*filer_pb.Entry)
+}
+
+// loadEntry reads the entry from the filer unless it has buffered writes or is fresh enough
+func (f *openFile) loadEntry(force bool) error {
+	if f.entry != nil && (f.dirty || (!force && time.Since(f.entryLoadedAt) < entryCacheTtl)) {
+		return nil
+	}
+	entry, err := f.ns.lookupEntry(f.fullpath)
+	if err != nil {
+		return err
+	}
+	if entry.IsDirectory {
+		return nfsError(nfs3ErrIsDir)
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	f.chunksLock.Lock()
+	f.entry = entry
+	f.reader = nil
+	f.chunksLock.Unlock()
+	f.entryLoadedAt = time.Now()
+	return nil
+}
+
+func (f *openFile) readAt(buf []byte, offset int64) (n int, eof bool, err error) {
+	if err = f.loadEntry(false); err != nil {
+		return
+	}
+
+	fileSize := int64(filer.FileSize(f.entry))
+	if offset >= fileSize {
+		return 0, true, nil
+	}
+	if int64(len(buf)) > fileSize-offset {
+		buf = buf[:fileSize-offset]
+	}
+
+	var tsNs int64
+	if len(f.entry.Content) > 0 {
+		if offset < int64(len(f.entry.Content)) {
+			n = copy(buf, f.entry.Content[offset:])
+		}
+	} else {
+		f.chunksLock.Lock()
+		if f.reader == nil {
+			visibleIntervals, _ := filer.NonOverlappingVisibleIntervals(context.Background(), filer.LookupFn(f.ns), f.entry.GetChunks(), 0, fileSize)
+			chunkViews := filer.ViewFromVisibleIntervals(visibleIntervals, 0, fileSize)
+			f.reader = filer.NewChunkReaderAtFromClient(context.Background(), f.ns.readerCache, chunkViews, fileSize, filer.DefaultPrefetchCount)
+		}
+		reader := f.reader
+		f.chunksLock.Unlock()
+
+		n, tsNs, err = reader.ReadAtWithTime(context.Background(), buf, offset)
+		if err == io.EOF {
+			err = nil
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	// overlay the buffered writes, one pipeline chunk at a time
+	if f.uploadPipeline != nil {
+		for start := offset; start < offset+int64(len(buf)); {
+			stop := (start/f.uploadPipeline.ChunkSize + 1) * f.uploadPipeline.ChunkSize
+			if stop > offset+int64(len(buf)) {
+				stop = offset + int64(len(buf))
+			}
+			maxStop := f.uploadPipeline.MaybeReadDataAt(buf[start-offset:stop-offset], start, tsNs)
+			if int(maxStop-offset) > n {
+				n = int(maxStop - offset)
+			}
+			start = stop
+		}
+	}
+	if n < len(buf) {
+		// sparse regions read as zeros
+		clear(buf[n:])
+		n = len(buf)
+	}
+
+	return n, offset+int64(n) >= fileSize, nil
+}
+
+func (f *openFile) writeAt(data []byte, offset int64) error {
+	if err := f.loadEntry(false); err != nil {
+		return err
+	}
+	if f.uploadPipeline == nil {
+		f.uploadPipeline = page_writer.NewUploadPipeline(f.ns.concurrentWriters, f.ns.option.ChunkSizeLimit,
+			f.saveChunkedFileIntervalToStorage, f.ns.option.ConcurrentWriters, f.ns.swapFileDir)
+	}
+
+	tsNs := time.Now().UnixNano()
+	if len(f.entry.Content) > 0 {
+		// move the inline content into the pipeline, so it is saved as a chunk together with the new data
+		content := f.entry.Content
+		f.entry.Content = nil
+		if _, err := f.uploadPipeline.SaveDataAt(content, 0, true, tsNs); err != nil {
+			return err
+		}
+	}
+	if _, err := f.uploadPipeline.SaveDataAt(data, offset, offset == f.lastWriteStop, tsNs); err != nil {
+		return err
+	}
+	f.lastWriteStop = offset + int64(len(data))
+
+	if !f.dirty {
+		f.dirty = true
+		f.firstDirtyAt = time.Now()
+	}
+	if stop := uint64(f.lastWriteStop); stop > f.entry.Attributes.FileSize {
+		f.entry.Attributes.FileSize = stop
+	}
+	f.entry.Attributes.Mtime = time.Now().Unix()
+	return nil
+}
+
+// flushLocked uploads the buffered writes and saves the entry
+func (f *openFile) flushLocked() error {
+	if !f.dirty {
+		return nil
+	}
+	f.uploadPipeline.FlushAll()
+
+	f.chunksLock.Lock()
+	uploadErr := f.uploadErr
+	f.uploadErr = nil
+	f.chunksLock.Unlock()
+	if uploadErr != nil {
+		return fmt.Errorf("flush %s: %v", f.fullpath, uploadErr)
+	}
+
+	f.chunksLock.Lock()
+	manifestedChunks, manifestErr := filer.MaybeManifestize(f.saveDataAsChunk, f.entry.GetChunks())
+	if manifestErr != nil {
+		// not good, but should be ok
+		glog.V(0).Infof("file %s close MaybeManifestize: %v", f.fullpath, manifestErr)
+	} else {
+		f.entry.Chunks = manifestedChunks
+	}
+	entry := proto.Clone(f.entry).(*filer_pb.Entry)
+	f.chunksLock.Unlock()
+
+	if err := f.ns.saveEntry(f.fullpath, entry); err != nil {
+		return err
+	}
+	f.dirty = false
+	f.entryLoadedAt = time.Now()
+	return nil
+}
+
+func (f *openFile) flush() error {
+	f.Lock()
+	defer f.Unlock()
+	return f.flushLocked()
+}
+
+func (f *openFile) destroy() {
+	f.closed = true
+	if f.uploadPipeline != nil {
+		f.uploadPipeline.Shutdown()
+		f.uploadPipeline = nil
+	}
+}
+
+func (f *openFile) saveChunkedFileIntervalToStorage(reader io.Reader, offset int64, size int64, modifiedTsNs int64, cleanupFn func()) {
+
+	defer cleanupFn()
+
+	chunk, err := f.saveDataAsChunk(reader, f.fullpath.Name(), offset, modifiedTsNs)
+	f.chunksLock.Lock()
+	defer f.chunksLock.Unlock()
+	if err != nil {
+		glog.V(0).Infof("%v saveToStorage [%d,%d): %v", f.fullpath, offset, offset+size, err)
+		f.uploadErr = err
+		return
+	}
+	f.entry.Chunks = append(f.entry.GetChunks(), chunk)
+	f.reader = nil
+	glog.V(3).Infof("%v saveToStorage %s [%d,%d)", f.fullpath, chunk.FileId, offset, offset+size)
+
+}
+
+func (f *openFile) saveDataAsChunk(reader io.Reader, filename string, offset int64, tsNs int64) (chunk *filer_pb.FileChunk, err error) {
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		return
+	}
+
+	option := f.ns.option
+	fileId, uploadResult, err, _ := uploader.UploadWithRetry(
+		f.ns,
+		&filer_pb.AssignVolumeRequest{
+			Count:       1,
+			Replication: option.Replication,
+			Collection:  option.Collection,
+			TtlSec:      option.TtlSec,
+			DiskType:    option.DiskType,
+			DataCenter:  option.DataCenter,
+			Path:        string(f.fullpath),
+		},
+		&operation.UploadOption{
+			Filename:          filename,
+			Cipher:            option.Cipher,
+			IsInputCompressed: false,
+			MimeType:          "",
+			PairMap:           nil,
+		},
+		func(host, fileId string) string {
+			fileUrl := fmt.Sprintf("http://%s/%s", host, fileId)
+			if option.VolumeServerAccess == "filerProxy" {
+				fileUrl = fmt.Sprintf("http://%s/?proxyChunkId=%s", option.Filer.ToHttpAddress(), fileId)
+			}
+			return fileUrl
+		},
+		reader,
+	)
+
+	if err != nil {
+		glog.V(0).Infof("upload data %v: %v", filename, err)
+		return nil, fmt.Errorf("upload data: %w", err)
+	}
+	if uploadResult.Error != "" {
+		glog.V(0).Infof("upload failure %v: %v", filename, err)
+		return nil, fmt.Errorf("upload result: %v", uploadResult.Error)
+	}
+
+	return uploadResult.ToPbFileChunk(fileId, offset, tsNs), nil
+}
+
+// truncateLocked changes the file size, dropping the chunks beyond it
+func (f *openFile) truncateLocked(size uint64) {
+	f.chunksLock.Lock()
+	defer f.chunksLock.Unlock()
+	if size < filer.FileSize(f.entry) {
+		if len(f.entry.Content) > 0 {
+			if size < uint64(len(f.entry.Content)) {
+				f.entry.Content = f.entry.Content[:size]
+			}
+		}
+		var chunks []*filer_pb.FileChunk
+		for _, chunk := range f.entry.GetChunks() {
+			int64Size := int64(chunk.Size)
+			if chunk.Offset+int64Size > int64(size) {
+				// this chunk is truncated
+				int64Size = int64(size) - chunk.Offset
+				if int64Size <= 0 {
+					continue
+				}
+				chunk.Size = uint64(int64Size)
+			}
+			chunks = append(chunks, chunk)
+		}
+		f.entry.Chunks = chunks
+	}
+	f.entry.Attributes.FileSize = size
+	f.entry.Attributes.Mtime = time.Now().Unix()
+	f.reader = nil
+}
+
+// setEntryAttributes applies SETATTR, flushing buffered writes first
+func (ns *NfsServer) setEntryAttributes(inode uint64, fullpath util.FullPath, s *setAttributes) error {
+	entry, err := ns.lookupEntry(fullpath)
+	if err != nil {
+		return err
+	}
+	if entry.IsDirectory || (s.size == nil && ns.findOpenFile(inode) == nil) {
+		if s.size != nil && entry.IsDirectory {
+			return nfsError(nfs3ErrIsDir)
+		}
+		ns.apply(s, entry)
+		return ns.saveEntry(fullpath, entry)
+	}
+
+	f := ns.lockOpenFile(inode, fullpath)
+	defer f.Unlock()
+	if err := f.flushLocked(); err != nil {
+		return err
+	}
+	if err := f.loadEntry(true); err != nil {
+		return err
+	}
+	if s.size != nil {
+		f.truncateLocked(*s.size)
+	}
+	ns.apply(s, f.entry)
+	f.chunksLock.Lock()
+	entry = proto.Clone(f.entry).(*filer_pb.Entry)
+	f.chunksLock.Unlock()
+	return ns.saveEntry(fullpath, entry)
+}
+
+func (ns *NfsServer) nfsRead(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	offset, err := call.args.uint64()
+	if err != nil {
+		return err
+	}
+	count, err := call.args.uint32()
+	if err != nil {
+		return err
+	}
+	if count > maxReadSize {
+		count = maxReadSize
+	}
+
+	var data []byte
+	var eof bool
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		f := ns.lockOpenFile(inode, fullpath)
+		buf := make([]byte, count)
+		n, readEof, readErr := f.readAt(buf, int64(offset))
+		if readErr != nil {
+			glog.V(0).Infof("nfs: read %s [%d,%d): %v", fullpath, offset, offset+uint64(count), readErr)
+			status = toNfsStatus(readErr)
+		} else {
+			data, eof = buf[:n], readEof
+			attr = ns.toFileAttributes(inode, f.entry)
+		}
+		f.Unlock()
+	}
+
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status == nfs3Ok {
+		w.uint32(uint32(len(data)))
+		w.bool(eof)
+		w.opaque(data)
+	}
+	return nil
+}
+
+func (ns *NfsServer) nfsWrite(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	offset, err := call.args.uint64()
+	if err != nil {
+		return err
+	}
+	if _, err = call.args.uint32(); err != nil { // count, same as the data length
+		return err
+	}
+	stable, err := call.args.uint32()
+	if err != nil {
+		return err
+	}
+	data, err := call.args.opaque(maxWriteSize)
+	if err != nil {
+		return err
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	var attr *fileAttributes
+	committed := uint32(stableUnstable)
+	if status == nfs3Ok {
+		f := ns.lockOpenFile(inode, fullpath)
+		err := f.loadEntry(false)
+		if err == nil && !mayWrite(ns.toFileAttributes(inode, f.entry), &call.cred) {
+			err = nfsError(nfs3ErrAcces)
+		}
+		if err == nil {
+			err = f.writeAt(data, int64(offset))
+		}
+		if err == nil && stable != stableUnstable {
+			err = f.flushLocked()
+			committed = stableFileSync
+		}
+		if err != nil {
+			glog.V(0).Infof("nfs: write %s [%d,%d): %v", fullpath, offset, offset+uint64(len(data)), err)
+			status = toNfsStatus(err)
+		} else {
+			f.chunksLock.Lock()
+			attr = ns.toFileAttributes(inode, f.entry)
+			f.chunksLock.Unlock()
+		}
+		f.Unlock()
+	}
+
+	w.uint32(status)
+	ns.writeWccData(w, attr)
+	if status == nfs3Ok {
+		w.uint32(uint32(len(data)))
+		w.uint32(committed)
+		w.fixedOpaque(ns.writeVerifier)
+	}
+	return nil
+}
+
+func (ns *NfsServer) nfsCommit(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	if _, err = call.args.uint64(); err != nil { // offset
+		return err
+	}
+	if _, err = call.args.uint32(); err != nil { // count
+		return err
+	}
+
+	if status == nfs3Ok {
+		if f := ns.findOpenFile(inode); f != nil {
+			if err := f.flush(); err != nil {
+				glog.V(0).Infof("nfs: commit %s: %v", fullpath, err)
+				status = toNfsStatus(err)
+			}
+		}
+	}
+
+	w.uint32(status)
+	ns.writeWccData(w, ns.postOpAttributes(inode, fullpath))
+	if status == nfs3Ok {
+		w.fixedOpaque(ns.writeVerifier)
+	}
+	return nil
+}
+
+// loopFlushOpenFiles saves buffered writes after the write-behind delay, closes idle files,
+// and persists the handles issued since the last round
+func (ns *NfsServer) loopFlushOpenFiles() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ns.stopCh:
+			return
+		case <-ticker.C:
+			ns.flushOpenFiles(false)
+			ns.handles.persist()
+		}
+	}
+}
+
+func (ns *NfsServer) flushOpenFiles(all bool) {
+	ns.openFilesLock.Lock()
+	files := make([]*openFile, 0, len(ns.openFiles))
+	for _, f := range ns.openFiles {
+		files = append(files, f)
+	}
+	ns.openFilesLock.Unlock()
+
+	now := time.Now()
+	for _, f := range files {
+		f.Lock()
+		if f.dirty && (all || now.Sub(f.firstDirtyAt) >= ns.option.WriteBehindDelay) {
+			if err := f.flushLocked(); err != nil {
+				glog.Errorf("nfs: flush %s: %v", f.fullpath, err)
+			}
+		}
+		if !f.dirty && now.Sub(f.lastAccess) >= openFileIdleTimeout {
+			ns.openFilesLock.Lock()
+			if ns.openFiles[f.inode] == f {
+				delete(ns.openFiles, f.inode)
+			}
+			ns.openFilesLock.Unlock()
+			f.destroy()
+		}
+		f.Unlock()
+	}
+}
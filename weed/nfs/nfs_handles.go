@@ -0,0 +1,276 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	rootInode     = 1
+	handleVersion = 1
+	handleSize    = 16
+)
+
+// handleMap maps NFS file handles to filer paths.
+//
+// Like the inode mapping of weed mount, a handle carries the inode of the entry,
+// which is the inode persisted in the entry attributes, or derived from the path and creation time.
+// The inode to path mapping is cached in memory, and persisted in the filer key-value store,
+// so handles issued before the gateway restarts are resolved again.
+// Only handles of entries removed or moved away meanwhile get NFS3ERR_STALE.
+type handleMap struct {
+	sync.RWMutex
+	exportId   uint32
+	inode2path map[uint64]util.FullPath
+	path2inode map[util.FullPath]uint64
+	store      handleStore
+	// changed mappings not yet persisted, an empty path for a forgotten inode
+	dirty map[uint64]util.FullPath
+}
+
+// handleStore persists the inode to path mapping of an export
+type handleStore interface {
+	loadPath(exportId uint32, inode uint64) (util.FullPath, bool, error)
+	savePath(exportId uint32, inode uint64, path util.FullPath) error
+}
+
+func newHandleMap(root util.FullPath, store handleStore) *handleMap {
+	m := &handleMap{
+		exportId:   uint32(util.HashStringToLong(string(root))),
+		inode2path: make(map[uint64]util.FullPath),
+		path2inode: make(map[util.FullPath]uint64),
+		store:      store,
+		dirty:      make(map[uint64]util.FullPath),
+	}
+	m.inode2path[rootInode] = root
+	m.path2inode[root] = rootInode
+	return m
+}
+
+// entryInode returns the inode used for the entry at the path
+func entryInode(path util.FullPath, entry *filer_pb.Entry) uint64 {
+	var crtime int64
+	if entry != nil && entry.Attributes != nil {
+		if entry.Attributes.Inode > rootInode {
+			return entry.Attributes.Inode
+		}
+		crtime = entry.Attributes.Crtime
+	}
+	inode := path.AsInode(crtime)
+	if inode <= rootInode {
+		inode += rootInode + 1
+	}
+	return inode
+}
+
+// track records the path and returns its inode
+func (m *handleMap) track(path util.FullPath, entry *filer_pb.Entry) uint64 {
+	m.Lock()
+	defer m.Unlock()
+	if inode, found := m.path2inode[path]; found && inode == rootInode {
+		return inode
+	}
+	inode := entryInode(path, entry)
+	if oldInode, found := m.path2inode[path]; found && oldInode != inode {
+		// the path now points to a different entry
+		m.forget(oldInode)
+	}
+	if oldPath, found := m.inode2path[inode]; found && oldPath != path {
+		// the entry has been moved
+		delete(m.path2inode, oldPath)
+	}
+	m.set(inode, path)
+	return inode
+}
+
+func (m *handleMap) set(inode uint64, path util.FullPath) {
+	if oldPath, found := m.inode2path[inode]; !found || oldPath != path {
+		m.dirty[inode] = path
+	}
+	m.inode2path[inode] = path
+	m.path2inode[path] = inode
+}
+
+func (m *handleMap) forget(inode uint64) {
+	if _, found := m.inode2path[inode]; found {
+		m.dirty[inode] = ""
+	}
+	delete(m.inode2path, inode)
+}
+
+// getPath resolves the inode, from the persisted mapping if it is not cached
+func (m *handleMap) getPath(inode uint64) (util.FullPath, bool) {
+	m.RLock()
+	path, found := m.inode2path[inode]
+	_, forgotten := m.dirty[inode]
+	m.RUnlock()
+	if found || forgotten || m.store == nil {
+		return path, found
+	}
+
+	path, found, err := m.store.loadPath(m.exportId, inode)
+	if err != nil {
+		glog.V(0).Infof("nfs: load handle %d: %v", inode, err)
+		return "", false
+	}
+	if !found {
+		return "", false
+	}
+	m.Lock()
+	defer m.Unlock()
+	if cached, found := m.inode2path[inode]; found {
+		return cached, true
+	}
+	if _, forgotten := m.dirty[inode]; forgotten {
+		return "", false
+	}
+	if _, taken := m.path2inode[path]; !taken {
+		m.path2inode[path] = inode
+	}
+	m.inode2path[inode] = path
+	return path, true
+}
+
+func (m *handleMap) getInode(path util.FullPath) (uint64, bool) {
+	m.RLock()
+	defer m.RUnlock()
+	inode, found := m.path2inode[path]
+	return inode, found
+}
+
+// removePath forgets the path, and for directories every path under it
+func (m *handleMap) removePath(path util.FullPath) {
+	m.Lock()
+	defer m.Unlock()
+	m.forEachUnder(path, func(p util.FullPath, inode uint64) {
+		delete(m.path2inode, p)
+		m.forget(inode)
+	})
+}
+
+// movePath keeps the handles of the moved entry and its descendants valid
+func (m *handleMap) movePath(oldPath, newPath util.FullPath) {
+	m.Lock()
+	defer m.Unlock()
+	if inode, found := m.path2inode[newPath]; found {
+		// the target is replaced
+		delete(m.path2inode, newPath)
+		m.forget(inode)
+	}
+	moved := make(map[util.FullPath]uint64)
+	m.forEachUnder(oldPath, func(p util.FullPath, inode uint64) {
+		delete(m.path2inode, p)
+		moved[newPath+p[len(oldPath):]] = inode
+	})
+	for p, inode := range moved {
+		m.set(inode, p)
+	}
+}
+
+// persist saves the changed mappings, keeping the ones failed to save for the next time
+func (m *handleMap) persist() {
+	if m.store == nil {
+		return
+	}
+	m.Lock()
+	dirty := m.dirty
+	m.dirty = make(map[uint64]util.FullPath)
+	m.Unlock()
+
+	var errs []error
+	for inode, path := range dirty {
+		if err := m.store.savePath(m.exportId, inode, path); err != nil {
+			errs = append(errs, err)
+			m.Lock()
+			if _, changed := m.dirty[inode]; !changed {
+				m.dirty[inode] = path
+			}
+			m.Unlock()
+		}
+	}
+	if len(errs) > 0 {
+		glog.V(0).Infof("nfs: persist %d of %d handles: %v", len(errs), len(dirty), errors.Join(errs...))
+	}
+}
+
+func (m *handleMap) forEachUnder(path util.FullPath, fn func(p util.FullPath, inode uint64)) {
+	prefix := string(path) + "/"
+	for p, inode := range m.path2inode {
+		if inode == rootInode {
+			continue
+		}
+		if p == path || strings.HasPrefix(string(p), prefix) {
+			fn(p, inode)
+		}
+	}
+}
+
+// toHandle encodes the handle as version, export id and inode
+func (m *handleMap) toHandle(inode uint64) []byte {
+	handle := make([]byte, handleSize)
+	handle[0] = handleVersion
+	binary.BigEndian.PutUint32(handle[4:8], m.exportId)
+	binary.BigEndian.PutUint64(handle[8:16], inode)
+	return handle
+}
+
+// fromHandle decodes the inode, returning false for handles not issued by this export
+func (m *handleMap) fromHandle(handle []byte) (uint64, bool) {
+	if len(handle) != handleSize || handle[0] != handleVersion {
+		return 0, false
+	}
+	if binary.BigEndian.Uint32(handle[4:8]) != m.exportId {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(handle[8:16]), true
+}
+
+// filerHandleStore persists the handles in the filer key-value store
+type filerHandleStore struct {
+	filerClient filer_pb.FilerClient
+}
+
+func handleKey(exportId uint32, inode uint64) []byte {
+	key := []byte("nfs.handle.")
+	key = binary.BigEndian.AppendUint32(key, exportId)
+	return binary.BigEndian.AppendUint64(key, inode)
+}
+
+func (s *filerHandleStore) loadPath(exportId uint32, inode uint64) (path util.FullPath, found bool, err error) {
+	err = s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: handleKey(exportId, inode)})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		path, found = util.FullPath(resp.Value), len(resp.Value) > 0
+		return nil
+	})
+	return
+}
+
+// savePath saves the path of the inode, or deletes the inode for an empty path
+func (s *filerHandleStore) savePath(exportId uint32, inode uint64, path util.FullPath) error {
+	return s.filerClient.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{
+			Key:   handleKey(exportId, inode),
+			Value: []byte(path),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return errors.New(resp.Error)
+		}
+		return nil
+	})
+}
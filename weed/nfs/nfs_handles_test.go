@@ -0,0 +1,123 @@
+package nfs
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestHandleRoundTrip(t *testing.T) {
+	m := newHandleMap("/export", nil)
+
+	inode := m.track("/export/a.txt", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Crtime: 100}})
+	decoded, ok := m.fromHandle(m.toHandle(inode))
+	if !ok || decoded != inode {
+		t.Fatalf("decoded %d ok=%v, want %d", decoded, ok, inode)
+	}
+	if p, found := m.getPath(inode); !found || p != "/export/a.txt" {
+		t.Fatalf("getPath: %s %v", p, found)
+	}
+
+	// handles of another export are rejected
+	other := newHandleMap("/other", nil)
+	if _, ok := other.fromHandle(m.toHandle(inode)); ok {
+		t.Fatalf("expected handle of another export to be rejected")
+	}
+	if _, ok := m.fromHandle([]byte{1, 2, 3}); ok {
+		t.Fatalf("expected malformed handle to be rejected")
+	}
+}
+
+func TestHandleStableAcrossRestart(t *testing.T) {
+	entry := &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Crtime: 100}}
+	withInode := &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 12345}}
+
+	first, second := newHandleMap("/export", nil), newHandleMap("/export", nil)
+	if first.track("/export/a", entry) != second.track("/export/a", entry) {
+		t.Fatalf("expected the same inode for the same entry")
+	}
+	if inode := first.track("/export/b", withInode); inode != 12345 {
+		t.Fatalf("expected the persisted inode, got %d", inode)
+	}
+	if inode := first.track("/export", entry); inode != rootInode {
+		t.Fatalf("expected the root inode, got %d", inode)
+	}
+}
+
+func TestHandleMoveAndRemove(t *testing.T) {
+	m := newHandleMap("/", nil)
+	dir := m.track("/d", &filer_pb.Entry{IsDirectory: true, Attributes: &filer_pb.FuseAttributes{Inode: 10}})
+	file := m.track("/d/f", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 11}})
+	sibling := m.track("/dx", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 12}})
+
+	m.movePath("/d", "/e")
+	for inode, want := range map[uint64]util.FullPath{dir: "/e", file: "/e/f", sibling: "/dx"} {
+		if p, found := m.getPath(inode); !found || p != want {
+			t.Errorf("inode %d: got %s %v, want %s", inode, p, found, want)
+		}
+	}
+	if _, found := m.getInode("/d/f"); found {
+		t.Errorf("old path should be forgotten")
+	}
+
+	m.removePath("/e")
+	if _, found := m.getPath(file); found {
+		t.Errorf("removed descendants should be forgotten")
+	}
+	if _, found := m.getPath(sibling); !found {
+		t.Errorf("sibling with a common prefix should be kept")
+	}
+	if _, found := m.getPath(rootInode); !found {
+		t.Errorf("root should be kept")
+	}
+}
+
+type memoryHandleStore map[string]util.FullPath
+
+func (s memoryHandleStore) loadPath(exportId uint32, inode uint64) (util.FullPath, bool, error) {
+	path, found := s[string(handleKey(exportId, inode))]
+	return path, found, nil
+}
+
+func (s memoryHandleStore) savePath(exportId uint32, inode uint64, path util.FullPath) error {
+	if path == "" {
+		delete(s, string(handleKey(exportId, inode)))
+	} else {
+		s[string(handleKey(exportId, inode))] = path
+	}
+	return nil
+}
+
+func TestHandlePersistedAcrossRestart(t *testing.T) {
+	store := make(memoryHandleStore)
+	before := newHandleMap("/export", store)
+	kept := before.track("/export/d/kept", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 20}})
+	moved := before.track("/export/d/moved", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 21}})
+	removed := before.track("/export/d/removed", &filer_pb.Entry{Attributes: &filer_pb.FuseAttributes{Inode: 22}})
+	before.persist()
+	before.movePath("/export/d/moved", "/export/e")
+	before.removePath("/export/d/removed")
+	if _, found := before.getPath(removed); found {
+		t.Errorf("removed handle resolved before it is persisted")
+	}
+	before.persist()
+
+	handle := before.toHandle(kept)
+	after := newHandleMap("/export", store)
+	inode, ok := after.fromHandle(handle)
+	if !ok {
+		t.Fatalf("handle rejected after restart")
+	}
+	for inode, want := range map[uint64]util.FullPath{inode: "/export/d/kept", moved: "/export/e"} {
+		if p, found := after.getPath(inode); !found || p != want {
+			t.Errorf("inode %d after restart: got %s %v, want %s", inode, p, found, want)
+		}
+	}
+	if p, found := after.getPath(removed); found {
+		t.Errorf("removed inode resolved to %s after restart", p)
+	}
+	if inode, found := after.getInode("/export/d/kept"); !found || inode != kept {
+		t.Errorf("loaded handle is not cached: %d %v", inode, found)
+	}
+}
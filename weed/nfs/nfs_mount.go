@@ -0,0 +1,126 @@
+package nfs
+
+import (
+	"path"
+	"sort"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// MOUNT v3 protocol, RFC 1813 appendix I
+const (
+	mountProgram  = 100005
+	mountVersion3 = 3
+
+	mountProcNull    = 0
+	mountProcMnt     = 1
+	mountProcDump    = 2
+	mountProcUmnt    = 3
+	mountProcUmntAll = 4
+	mountProcExport  = 5
+
+	mnt3Ok          = 0
+	mnt3ErrNoEnt    = 2
+	mnt3ErrAcces    = 13
+	mnt3ErrNotDir   = 20
+	mnt3ErrInval    = 22
+	mnt3ErrServerFt = 10006
+)
+
+func (ns *NfsServer) dispatchMount(call *rpcCall, w *xdrWriter) uint32 {
+	switch call.proc {
+	case mountProcNull:
+		return rpcAcceptSuccess
+	case mountProcMnt:
+		return ns.mountMnt(call, w)
+	case mountProcDump:
+		ns.mountDump(w)
+		return rpcAcceptSuccess
+	case mountProcUmnt:
+		dirPath, err := call.args.string(maxPathLen)
+		if err != nil {
+			return rpcAcceptGarbageArgs
+		}
+		ns.mountsLock.Lock()
+		delete(ns.mounts, call.remoteAddr.String())
+		ns.mountsLock.Unlock()
+		glog.V(0).Infof("nfs: %v unmount %s", call.remoteAddr, dirPath)
+		return rpcAcceptSuccess
+	case mountProcUmntAll:
+		ns.mountsLock.Lock()
+		delete(ns.mounts, call.remoteAddr.String())
+		ns.mountsLock.Unlock()
+		return rpcAcceptSuccess
+	case mountProcExport:
+		// exports: one export, available to the groups allowed by the ACL
+		w.bool(true)
+		w.string(string(ns.root))
+		w.bool(false)
+		w.bool(false)
+		return rpcAcceptSuccess
+	}
+	return rpcAcceptProcUnavail
+}
+
+// mountMnt resolves the export path, or a directory under it, to a file handle
+func (ns *NfsServer) mountMnt(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath, err := call.args.string(maxPathLen)
+	if err != nil {
+		return rpcAcceptGarbageArgs
+	}
+	fullpath := util.FullPath(path.Clean("/" + dirPath))
+	if fullpath != ns.root && !fullpath.IsUnder(ns.root) {
+		glog.V(0).Infof("nfs: %v mount %s: not under export %s", call.remoteAddr, dirPath, ns.root)
+		w.uint32(mnt3ErrAcces)
+		return rpcAcceptSuccess
+	}
+
+	inode := uint64(rootInode)
+	if fullpath != ns.root {
+		entry, err := ns.lookupEntry(fullpath)
+		if err != nil {
+			glog.V(0).Infof("nfs: %v mount %s: %v", call.remoteAddr, dirPath, err)
+			if toNfsStatus(err) == nfs3ErrNoEnt {
+				w.uint32(mnt3ErrNoEnt)
+			} else {
+				w.uint32(mnt3ErrServerFt)
+			}
+			return rpcAcceptSuccess
+		}
+		if !entry.IsDirectory {
+			w.uint32(mnt3ErrNotDir)
+			return rpcAcceptSuccess
+		}
+		inode = ns.handles.track(fullpath, entry)
+	}
+
+	ns.mountsLock.Lock()
+	ns.mounts[call.remoteAddr.String()] = string(fullpath)
+	ns.mountsLock.Unlock()
+	glog.V(0).Infof("nfs: %v mount %s", call.remoteAddr, fullpath)
+
+	w.uint32(mnt3Ok)
+	w.opaque(ns.handles.toHandle(inode))
+	// auth flavors
+	w.uint32(2)
+	w.uint32(rpcAuthUnix)
+	w.uint32(rpcAuthNone)
+	return rpcAcceptSuccess
+}
+
+func (ns *NfsServer) mountDump(w *xdrWriter) {
+	ns.mountsLock.Lock()
+	defer ns.mountsLock.Unlock()
+	var clients []string
+	for client := range ns.mounts {
+		clients = append(clients, client)
+	}
+	sort.Strings(clients)
+	for _, client := range clients {
+		w.bool(true)
+		w.string(client)
+		w.string(ns.mounts[client])
+	}
+	w.bool(false)
+}
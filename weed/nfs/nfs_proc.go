@@ -0,0 +1,904 @@
+package nfs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// NFSv3 procedures, RFC 1813 section 3
+const (
+	nfsProcNull        = 0
+	nfsProcGetAttr     = 1
+	nfsProcSetAttr     = 2
+	nfsProcLookup      = 3
+	nfsProcAccess      = 4
+	nfsProcReadLink    = 5
+	nfsProcRead        = 6
+	nfsProcWrite       = 7
+	nfsProcCreate      = 8
+	nfsProcMkdir       = 9
+	nfsProcSymlink     = 10
+	nfsProcMknod       = 11
+	nfsProcRemove      = 12
+	nfsProcRmdir       = 13
+	nfsProcRename      = 14
+	nfsProcLink        = 15
+	nfsProcReadDir     = 16
+	nfsProcReadDirPlus = 17
+	nfsProcFsStat      = 18
+	nfsProcFsInfo      = 19
+	nfsProcPathConf    = 20
+	nfsProcCommit      = 21
+
+	access3Read    = 0x0001
+	access3Lookup  = 0x0002
+	access3Modify  = 0x0004
+	access3Extend  = 0x0008
+	access3Delete  = 0x0010
+	access3Execute = 0x0020
+
+	createUnchecked = 0
+	createGuarded   = 1
+	createExclusive = 2
+
+	fsf3Link        = 0x0001
+	fsf3Symlink     = 0x0002
+	fsf3Homogeneous = 0x0008
+	fsf3CanSetTime  = 0x0010
+
+	// the create verifier of EXCLUSIVE CREATE, kept to recognize retransmissions
+	createVerifierKey = "x-seaweedfs-nfs-create-verifier"
+)
+
+func (ns *NfsServer) dispatchNfs(call *rpcCall, w *xdrWriter) uint32 {
+	var err error
+	switch call.proc {
+	case nfsProcNull:
+		return rpcAcceptSuccess
+	case nfsProcGetAttr:
+		err = ns.nfsGetAttr(call, w)
+	case nfsProcSetAttr:
+		err = ns.nfsSetAttr(call, w)
+	case nfsProcLookup:
+		err = ns.nfsLookup(call, w)
+	case nfsProcAccess:
+		err = ns.nfsAccess(call, w)
+	case nfsProcReadLink:
+		err = ns.nfsReadLink(call, w)
+	case nfsProcRead:
+		err = ns.nfsRead(call, w)
+	case nfsProcWrite:
+		err = ns.nfsWrite(call, w)
+	case nfsProcCreate:
+		err = ns.nfsCreate(call, w)
+	case nfsProcMkdir:
+		err = ns.nfsMkdir(call, w)
+	case nfsProcSymlink:
+		err = ns.nfsSymlink(call, w)
+	case nfsProcMknod:
+		// special files are not supported
+		w.uint32(nfs3ErrNotSupp)
+		ns.writeWccData(w, nil)
+	case nfsProcRemove:
+		err = ns.nfsRemove(call, w, false)
+	case nfsProcRmdir:
+		err = ns.nfsRemove(call, w, true)
+	case nfsProcRename:
+		err = ns.nfsRename(call, w)
+	case nfsProcLink:
+		// hard links are not supported
+		w.uint32(nfs3ErrNotSupp)
+		ns.writePostOpAttr(w, nil)
+		ns.writeWccData(w, nil)
+	case nfsProcReadDir:
+		err = ns.nfsReadDir(call, w, false)
+	case nfsProcReadDirPlus:
+		err = ns.nfsReadDir(call, w, true)
+	case nfsProcFsStat:
+		err = ns.nfsFsStat(call, w)
+	case nfsProcFsInfo:
+		err = ns.nfsFsInfo(call, w)
+	case nfsProcPathConf:
+		err = ns.nfsPathConf(call, w)
+	case nfsProcCommit:
+		err = ns.nfsCommit(call, w)
+	default:
+		return rpcAcceptProcUnavail
+	}
+	if err != nil {
+		glog.V(1).Infof("nfs: procedure %d from %v: %v", call.proc, call.remoteAddr, err)
+		return rpcAcceptGarbageArgs
+	}
+	return rpcAcceptSuccess
+}
+
+// readHandle decodes a file handle argument. A non-OK status is returned for handles
+// not issued by this export, or for inodes removed or moved away.
+func (ns *NfsServer) readHandle(r *xdrReader) (inode uint64, fullpath util.FullPath, status uint32, err error) {
+	handle, err := r.opaque(maxHandleSize)
+	if err != nil {
+		return
+	}
+	inode, ok := ns.handles.fromHandle(handle)
+	if !ok {
+		return 0, "", nfs3ErrBadHandle, nil
+	}
+	fullpath, found := ns.handles.getPath(inode)
+	if !found {
+		return 0, "", nfs3ErrStale, nil
+	}
+	return inode, fullpath, nfs3Ok, nil
+}
+
+// readDirOpArgs decodes diropargs3
+func (ns *NfsServer) readDirOpArgs(r *xdrReader) (dirInode uint64, dirPath util.FullPath, name string, status uint32, err error) {
+	if dirInode, dirPath, status, err = ns.readHandle(r); err != nil {
+		return
+	}
+	if name, err = r.string(maxPathLen); err != nil {
+		return
+	}
+	if status == nfs3Ok {
+		status = checkName(name)
+	}
+	return
+}
+
+func checkName(name string) uint32 {
+	if len(name) > maxNameLen {
+		return nfs3ErrNameTooLong
+	}
+	if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+		return nfs3ErrInval
+	}
+	return nfs3Ok
+}
+
+// getAttributes returns the attributes of a tracked inode
+func (ns *NfsServer) getAttributes(inode uint64, fullpath util.FullPath) (*fileAttributes, *filer_pb.Entry, uint32) {
+	entry, err := ns.getEntry(inode, fullpath)
+	if err != nil {
+		status := toNfsStatus(err)
+		if status == nfs3ErrNoEnt {
+			// the entry behind the handle is gone
+			ns.handles.removePath(fullpath)
+			status = nfs3ErrStale
+		}
+		return nil, nil, status
+	}
+	return ns.toFileAttributes(inode, entry), entry, nfs3Ok
+}
+
+// postOpAttributes returns the attributes for post_op_attr, or nil if not available
+func (ns *NfsServer) postOpAttributes(inode uint64, fullpath util.FullPath) *fileAttributes {
+	if fullpath == "" {
+		return nil
+	}
+	attr, _, _ := ns.getAttributes(inode, fullpath)
+	return attr
+}
+
+func (ns *NfsServer) nfsGetAttr(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		attr, _, status = ns.getAttributes(inode, fullpath)
+	}
+	w.uint32(status)
+	if status == nfs3Ok {
+		ns.writeFileAttributes(w, attr)
+	}
+	return nil
+}
+
+func (ns *NfsServer) nfsSetAttr(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	s, err := readSetAttributes(call.args)
+	if err != nil {
+		return err
+	}
+	var guardCtime *time.Time
+	if check, err := call.args.bool(); err != nil {
+		return err
+	} else if check {
+		seconds, err := call.args.uint32()
+		if err != nil {
+			return err
+		}
+		if _, err = call.args.uint32(); err != nil {
+			return err
+		}
+		t := time.Unix(int64(seconds), 0)
+		guardCtime = &t
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		var attr *fileAttributes
+		if attr, _, status = ns.getAttributes(inode, fullpath); status == nfs3Ok && guardCtime != nil && attr.ctime.Unix() != guardCtime.Unix() {
+			status = nfs3ErrNotSync
+		}
+		if status == nfs3Ok {
+			status = checkSetAttributes(attr, s, &call.cred)
+		}
+	}
+	if status == nfs3Ok && s.hasValue {
+		if err := ns.setEntryAttributes(inode, fullpath, s); err != nil {
+			glog.V(1).Infof("nfs: setattr %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+	w.uint32(status)
+	ns.writeWccData(w, ns.postOpAttributes(inode, fullpath))
+	return nil
+}
+
+func (ns *NfsServer) nfsLookup(call *rpcCall, w *xdrWriter) error {
+	dirInode, dirPath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	name, err := call.args.string(maxPathLen)
+	if err != nil {
+		return err
+	}
+
+	var inode uint64
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		switch name {
+		case ".":
+			inode = dirInode
+			attr, _, status = ns.getAttributes(dirInode, dirPath)
+		case "..":
+			inode, attr, status = ns.lookupParent(dirPath)
+		default:
+			if status = checkName(name); status == nfs3Ok {
+				inode, attr, status = ns.lookupChild(dirPath, name)
+			}
+		}
+	}
+
+	w.uint32(status)
+	if status == nfs3Ok {
+		w.opaque(ns.handles.toHandle(inode))
+		ns.writePostOpAttr(w, attr)
+	}
+	ns.writePostOpAttr(w, ns.postOpAttributes(dirInode, dirPath))
+	return nil
+}
+
+func (ns *NfsServer) lookupChild(dirPath util.FullPath, name string) (uint64, *fileAttributes, uint32) {
+	fullpath := dirPath.Child(name)
+	entry, err := ns.lookupEntry(fullpath)
+	if err != nil {
+		return 0, nil, toNfsStatus(err)
+	}
+	inode := ns.handles.track(fullpath, entry)
+	if f := ns.findOpenFile(inode); f != nil {
+		if dirtyEntry := f.dirtyEntry(); dirtyEntry != nil {
+			entry = dirtyEntry
+		}
+	}
+	return inode, ns.toFileAttributes(inode, entry), nfs3Ok
+}
+
+// lookupParent resolves "..", which stays at the export root
+func (ns *NfsServer) lookupParent(dirPath util.FullPath) (uint64, *fileAttributes, uint32) {
+	if dirPath == ns.root || !dirPath.IsUnder(ns.root) {
+		attr, _, status := ns.getAttributes(rootInode, ns.root)
+		return rootInode, attr, status
+	}
+	parentDir, _ := dirPath.DirAndName()
+	parentPath := util.FullPath(parentDir)
+	if parentPath == ns.root {
+		attr, _, status := ns.getAttributes(rootInode, ns.root)
+		return rootInode, attr, status
+	}
+	grandParent, parentName := parentPath.DirAndName()
+	return ns.lookupChild(util.FullPath(grandParent), parentName)
+}
+
+func (ns *NfsServer) nfsAccess(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	requested, err := call.args.uint32()
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		attr, _, status = ns.getAttributes(inode, fullpath)
+	}
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status == nfs3Ok {
+		w.uint32(requested & allowedAccess(attr, &call.cred, call.conn.readOnly))
+	}
+	return nil
+}
+
+// allowedAccess evaluates the permission bits against the caller credential
+func allowedAccess(attr *fileAttributes, cred *rpcCredential, readOnly bool) uint32 {
+	var perm uint32
+	switch {
+	case cred.uid == 0:
+		perm = 7
+	case cred.uid == attr.uid:
+		perm = attr.mode >> 6 & 7
+	case cred.gid == attr.gid || containsGid(cred.gids, attr.gid):
+		perm = attr.mode >> 3 & 7
+	default:
+		perm = attr.mode & 7
+	}
+
+	var access uint32
+	if perm&4 != 0 {
+		access |= access3Read
+	}
+	if perm&2 != 0 && !readOnly {
+		access |= access3Modify | access3Extend
+		if attr.fileType == nf3Dir {
+			access |= access3Delete
+		}
+	}
+	if perm&1 != 0 || (cred.uid == 0 && attr.mode&0o111 != 0) {
+		if attr.fileType == nf3Dir {
+			access |= access3Lookup
+		} else {
+			access |= access3Execute
+		}
+	}
+	if cred.uid == 0 && attr.fileType == nf3Dir {
+		access |= access3Lookup
+	}
+	return access
+}
+
+// mayWrite checks the caller may write the file. Like the kernel NFS server, the owner may write
+// whatever the mode, e.g. to a file it has just created with mode 0444.
+func mayWrite(attr *fileAttributes, cred *rpcCredential) bool {
+	return cred.uid == attr.uid || allowedAccess(attr, cred, false)&access3Modify != 0
+}
+
+// checkSetAttributes follows the POSIX rules of chmod, chown, truncate and utimes
+func checkSetAttributes(attr *fileAttributes, s *setAttributes, cred *rpcCredential) uint32 {
+	if cred.uid == 0 {
+		return nfs3Ok
+	}
+	owner := cred.uid == attr.uid
+	if s.mode != nil && !owner {
+		return nfs3ErrPerm
+	}
+	if s.uid != nil && *s.uid != attr.uid {
+		return nfs3ErrPerm
+	}
+	if s.gid != nil && *s.gid != attr.gid && !(owner && (cred.gid == *s.gid || containsGid(cred.gids, *s.gid))) {
+		return nfs3ErrPerm
+	}
+	if (s.size != nil || s.atime != nil || s.mtime != nil) && !mayWrite(attr, cred) {
+		return nfs3ErrAcces
+	}
+	return nfs3Ok
+}
+
+// checkDirModify checks the caller may add and remove entries of the directory
+func (ns *NfsServer) checkDirModify(cred *rpcCredential, dirInode uint64, dirPath util.FullPath) (*fileAttributes, uint32) {
+	attr, _, status := ns.getAttributes(dirInode, dirPath)
+	if status != nfs3Ok {
+		return nil, status
+	}
+	if access := allowedAccess(attr, cred, false); access&access3Modify == 0 || access&access3Lookup == 0 {
+		return attr, nfs3ErrAcces
+	}
+	return attr, nfs3Ok
+}
+
+// checkRemove checks the caller may remove the entry from the directory.
+// In a sticky directory, only the owner of the entry or of the directory may remove it.
+func (ns *NfsServer) checkRemove(cred *rpcCredential, dirInode uint64, dirPath util.FullPath, name string) uint32 {
+	dirAttr, status := ns.checkDirModify(cred, dirInode, dirPath)
+	if status != nfs3Ok || dirAttr.mode&0o1000 == 0 || cred.uid == 0 || cred.uid == dirAttr.uid {
+		return status
+	}
+	entry, err := ns.lookupEntry(dirPath.Child(name))
+	if err != nil {
+		return toNfsStatus(err)
+	}
+	if ns.toFileAttributes(0, entry).uid != cred.uid {
+		return nfs3ErrAcces
+	}
+	return nfs3Ok
+}
+
+func containsGid(gids []uint32, gid uint32) bool {
+	for _, g := range gids {
+		if g == gid {
+			return true
+		}
+	}
+	return false
+}
+
+func (ns *NfsServer) nfsReadLink(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		attr, entry, status = ns.getAttributes(inode, fullpath)
+	}
+	if status == nfs3Ok && attr.fileType != nf3Lnk {
+		status = nfs3ErrInval
+	}
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status == nfs3Ok {
+		w.string(entry.Attributes.SymlinkTarget)
+	}
+	return nil
+}
+
+func (ns *NfsServer) nfsCreate(call *rpcCall, w *xdrWriter) error {
+	dirInode, dirPath, name, status, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+	how, err := call.args.uint32()
+	if err != nil {
+		return err
+	}
+	var s *setAttributes
+	var verifier []byte
+	switch how {
+	case createUnchecked, createGuarded:
+		if s, err = readSetAttributes(call.args); err != nil {
+			return err
+		}
+	case createExclusive:
+		if verifier, err = call.args.fixedOpaque(8); err != nil {
+			return err
+		}
+	default:
+		return errRpcGarbageArgs
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		_, status = ns.checkDirModify(&call.cred, dirInode, dirPath)
+	}
+	var inode uint64
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		inode, attr, status = ns.createFile(call, dirPath, name, how, s, verifier)
+	}
+	ns.writeCreateResult(w, status, inode, attr, dirInode, dirPath)
+	return nil
+}
+
+func (ns *NfsServer) createFile(call *rpcCall, dirPath util.FullPath, name string, how uint32, s *setAttributes, verifier []byte) (uint64, *fileAttributes, uint32) {
+	fullpath := dirPath.Child(name)
+
+	existing, err := ns.lookupEntry(fullpath)
+	if err != nil && toNfsStatus(err) != nfs3ErrNoEnt {
+		return 0, nil, toNfsStatus(err)
+	}
+	if existing != nil {
+		switch how {
+		case createGuarded:
+			return 0, nil, nfs3ErrExist
+		case createExclusive:
+			if existing.Extended == nil || string(existing.Extended[createVerifierKey]) != string(verifier) {
+				return 0, nil, nfs3ErrExist
+			}
+			// a retransmission of a completed exclusive create
+			inode := ns.handles.track(fullpath, existing)
+			return inode, ns.toFileAttributes(inode, existing), nfs3Ok
+		}
+		if existing.IsDirectory {
+			return 0, nil, nfs3ErrIsDir
+		}
+		inode := ns.handles.track(fullpath, existing)
+		if s != nil && s.hasValue {
+			if status := checkSetAttributes(ns.toFileAttributes(inode, existing), s, &call.cred); status != nfs3Ok {
+				return 0, nil, status
+			}
+			if err := ns.setEntryAttributes(inode, fullpath, s); err != nil {
+				return 0, nil, toNfsStatus(err)
+			}
+		}
+		attr, _, status := ns.getAttributes(inode, fullpath)
+		return inode, attr, status
+	}
+
+	entry := ns.newEntry(call, fullpath, s, 0o644, 0)
+	if verifier != nil {
+		entry.Extended = map[string][]byte{createVerifierKey: verifier}
+	}
+	if err := ns.createEntry(dirPath, entry, how != createUnchecked); err != nil {
+		return 0, nil, toNfsStatus(err)
+	}
+	inode := ns.handles.track(fullpath, entry)
+	return inode, ns.toFileAttributes(inode, entry), nfs3Ok
+}
+
+// newEntry builds the entry of a new file, directory or symlink, owned by the caller
+func (ns *NfsServer) newEntry(call *rpcCall, fullpath util.FullPath, s *setAttributes, defaultPerm uint32, typeBits os.FileMode) *filer_pb.Entry {
+	now := time.Now()
+	uid, gid := call.cred.uid, call.cred.gid
+	if s != nil && s.uid != nil {
+		uid = *s.uid
+	}
+	if s != nil && s.gid != nil {
+		gid = *s.gid
+	}
+	uid, gid = ns.option.UidGidMapper.LocalToFiler(uid, gid)
+	mtime := now
+	if s != nil && s.mtime != nil {
+		mtime = *s.mtime
+	}
+	return &filer_pb.Entry{
+		Name:        fullpath.Name(),
+		IsDirectory: typeBits&os.ModeDir != 0,
+		Attributes: &filer_pb.FuseAttributes{
+			Mtime:    mtime.Unix(),
+			Crtime:   now.Unix(),
+			FileMode: newFileMode(s, defaultPerm, typeBits),
+			Uid:      uid,
+			Gid:      gid,
+			TtlSec:   ns.option.TtlSec,
+			Inode:    fullpath.AsInode(now.UnixNano()),
+		},
+	}
+}
+
+func (ns *NfsServer) createEntry(dirPath util.FullPath, entry *filer_pb.Entry, exclusive bool) error {
+	return ns.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.CreateEntryRequest{
+			Directory:  string(dirPath),
+			Entry:      entry,
+			OExcl:      exclusive,
+			Signatures: []int32{ns.signature},
+		}
+		glog.V(1).Infof("nfs create: %v", request)
+		if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
+			return fmt.Errorf("create %s: %w", dirPath.Child(entry.Name), err)
+		}
+		return nil
+	})
+}
+
+func (ns *NfsServer) saveEntry(fullpath util.FullPath, entry *filer_pb.Entry) error {
+	dir, _ := fullpath.DirAndName()
+	return ns.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{ns.signature},
+		}
+		glog.V(1).Infof("nfs save entry: %v", request)
+		return filer_pb.UpdateEntry(context.Background(), client, request)
+	})
+}
+
+// writeCreateResult writes the result of CREATE, MKDIR and SYMLINK
+func (ns *NfsServer) writeCreateResult(w *xdrWriter, status uint32, inode uint64, attr *fileAttributes, dirInode uint64, dirPath util.FullPath) {
+	w.uint32(status)
+	if status == nfs3Ok {
+		w.bool(true)
+		w.opaque(ns.handles.toHandle(inode))
+		ns.writePostOpAttr(w, attr)
+	}
+	ns.writeWccData(w, ns.postOpAttributes(dirInode, dirPath))
+}
+
+func (ns *NfsServer) nfsMkdir(call *rpcCall, w *xdrWriter) error {
+	dirInode, dirPath, name, status, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+	s, err := readSetAttributes(call.args)
+	if err != nil {
+		return err
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		_, status = ns.checkDirModify(&call.cred, dirInode, dirPath)
+	}
+	var inode uint64
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		fullpath := dirPath.Child(name)
+		entry := ns.newEntry(call, fullpath, s, 0o755, os.ModeDir)
+		if err := ns.createEntry(dirPath, entry, true); err != nil {
+			status = toNfsStatus(err)
+		} else {
+			inode = ns.handles.track(fullpath, entry)
+			attr = ns.toFileAttributes(inode, entry)
+		}
+	}
+	ns.writeCreateResult(w, status, inode, attr, dirInode, dirPath)
+	return nil
+}
+
+func (ns *NfsServer) nfsSymlink(call *rpcCall, w *xdrWriter) error {
+	dirInode, dirPath, name, status, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+	s, err := readSetAttributes(call.args)
+	if err != nil {
+		return err
+	}
+	target, err := call.args.string(maxPathLen)
+	if err != nil {
+		return err
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		_, status = ns.checkDirModify(&call.cred, dirInode, dirPath)
+	}
+	var inode uint64
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		fullpath := dirPath.Child(name)
+		entry := ns.newEntry(call, fullpath, s, 0o777, os.ModeSymlink)
+		entry.Attributes.SymlinkTarget = target
+		if err := ns.createEntry(dirPath, entry, true); err != nil {
+			status = toNfsStatus(err)
+		} else {
+			inode = ns.handles.track(fullpath, entry)
+			attr = ns.toFileAttributes(inode, entry)
+		}
+	}
+	ns.writeCreateResult(w, status, inode, attr, dirInode, dirPath)
+	return nil
+}
+
+func (ns *NfsServer) nfsRemove(call *rpcCall, w *xdrWriter, isDirectory bool) error {
+	dirInode, dirPath, name, status, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		status = ns.checkRemove(&call.cred, dirInode, dirPath, name)
+	}
+	if status == nfs3Ok {
+		status = ns.removeEntry(dirPath, name, isDirectory)
+	}
+	w.uint32(status)
+	ns.writeWccData(w, ns.postOpAttributes(dirInode, dirPath))
+	return nil
+}
+
+func (ns *NfsServer) removeEntry(dirPath util.FullPath, name string, isDirectory bool) uint32 {
+	fullpath := dirPath.Child(name)
+	entry, err := ns.lookupEntry(fullpath)
+	if err != nil {
+		return toNfsStatus(err)
+	}
+	if isDirectory && !entry.IsDirectory {
+		return nfs3ErrNotDir
+	}
+	if !isDirectory && entry.IsDirectory {
+		return nfs3ErrIsDir
+	}
+	if inode, found := ns.handles.getInode(fullpath); found {
+		ns.dropOpenFile(inode)
+	}
+	err = filer_pb.Remove(context.Background(), ns, string(dirPath), name, true, false, false, false, []int32{ns.signature})
+	if err != nil {
+		glog.V(1).Infof("nfs remove %s: %v", fullpath, err)
+		return toNfsStatus(err)
+	}
+	ns.handles.removePath(fullpath)
+	return nfs3Ok
+}
+
+func (ns *NfsServer) nfsRename(call *rpcCall, w *xdrWriter) error {
+	fromDirInode, fromDirPath, fromName, status, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+	toDirInode, toDirPath, toName, toStatus, err := ns.readDirOpArgs(call.args)
+	if err != nil {
+		return err
+	}
+	if status == nfs3Ok {
+		status = toStatus
+	}
+
+	if status == nfs3Ok && call.conn.readOnly {
+		status = nfs3ErrRoFs
+	}
+	if status == nfs3Ok {
+		status = ns.checkRemove(&call.cred, fromDirInode, fromDirPath, fromName)
+	}
+	if status == nfs3Ok {
+		// an existing target is replaced, so it must be removable too
+		if targetStatus := ns.checkRemove(&call.cred, toDirInode, toDirPath, toName); targetStatus != nfs3ErrNoEnt {
+			status = targetStatus
+		}
+	}
+	if status == nfs3Ok {
+		status = ns.renameEntry(fromDirPath, fromName, toDirPath, toName)
+	}
+	w.uint32(status)
+	ns.writeWccData(w, ns.postOpAttributes(fromDirInode, fromDirPath))
+	ns.writeWccData(w, ns.postOpAttributes(toDirInode, toDirPath))
+	return nil
+}
+
+// renameEntry follows the POSIX rename semantics: an existing target is replaced
+// if it is a file, or an empty directory when the source is also a directory.
+func (ns *NfsServer) renameEntry(fromDirPath util.FullPath, fromName string, toDirPath util.FullPath, toName string) uint32 {
+	oldPath, newPath := fromDirPath.Child(fromName), toDirPath.Child(toName)
+	if oldPath == newPath {
+		return nfs3Ok
+	}
+	if newPath.IsUnder(oldPath) {
+		return nfs3ErrInval
+	}
+	source, err := ns.lookupEntry(oldPath)
+	if err != nil {
+		return toNfsStatus(err)
+	}
+	if target, err := ns.lookupEntry(newPath); err == nil {
+		switch {
+		case source.IsDirectory && !target.IsDirectory:
+			return nfs3ErrNotDir
+		case !source.IsDirectory && target.IsDirectory:
+			return nfs3ErrIsDir
+		}
+		if status := ns.removeEntry(toDirPath, toName, target.IsDirectory); status != nfs3Ok {
+			return status
+		}
+	} else if status := toNfsStatus(err); status != nfs3ErrNoEnt {
+		return status
+	}
+
+	if inode, found := ns.handles.getInode(oldPath); found {
+		// the buffered writes are saved to the old path before it is moved
+		if f := ns.findOpenFile(inode); f != nil {
+			if err := f.flush(); err != nil {
+				return toNfsStatus(err)
+			}
+			ns.dropOpenFile(inode)
+		}
+	}
+
+	err = ns.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: string(fromDirPath),
+			OldName:      fromName,
+			NewDirectory: string(toDirPath),
+			NewName:      toName,
+			Signatures:   []int32{ns.signature},
+		}
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("rename %s => %s: %w", oldPath, newPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		glog.V(1).Infof("nfs: %v", err)
+		return toNfsStatus(err)
+	}
+	ns.handles.movePath(oldPath, newPath)
+	return nfs3Ok
+}
+
+func (ns *NfsServer) nfsFsStat(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		attr, _, status = ns.getAttributes(inode, fullpath)
+	}
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status != nfs3Ok {
+		return nil
+	}
+	totalSize, usedSize, fileCount := ns.statistics()
+	freeSize := uint64(0)
+	if totalSize > usedSize {
+		freeSize = totalSize - usedSize
+	}
+	const maxFiles = 1 << 48
+	freeFiles := uint64(maxFiles)
+	if fileCount < maxFiles {
+		freeFiles = maxFiles - fileCount
+	}
+	w.uint64(totalSize)
+	w.uint64(freeSize)
+	w.uint64(freeSize)
+	w.uint64(maxFiles)
+	w.uint64(freeFiles)
+	w.uint64(freeFiles)
+	w.uint32(0) // invarsec, the file system may change at any time
+	return nil
+}
+
+func (ns *NfsServer) nfsFsInfo(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		attr, _, status = ns.getAttributes(inode, fullpath)
+	}
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status != nfs3Ok {
+		return nil
+	}
+	w.uint32(maxReadSize)       // rtmax
+	w.uint32(preferredReadSize) // rtpref
+	w.uint32(4096)              // rtmult
+	w.uint32(maxWriteSize)      // wtmax
+	w.uint32(maxWriteSize)      // wtpref
+	w.uint32(4096)              // wtmult
+	w.uint32(64 * 1024)         // dtpref
+	w.uint64(1<<63 - 1)         // maxfilesize
+	writeNfsTime(w, time.Unix(1, 0))
+	w.uint32(fsf3Symlink | fsf3Homogeneous | fsf3CanSetTime)
+	return nil
+}
+
+func (ns *NfsServer) nfsPathConf(call *rpcCall, w *xdrWriter) error {
+	inode, fullpath, status, err := ns.readHandle(call.args)
+	if err != nil {
+		return err
+	}
+	var attr *fileAttributes
+	if status == nfs3Ok {
+		attr, _, status = ns.getAttributes(inode, fullpath)
+	}
+	w.uint32(status)
+	ns.writePostOpAttr(w, attr)
+	if status != nfs3Ok {
+		return nil
+	}
+	w.uint32(1)          // linkmax
+	w.uint32(maxNameLen) // name_max
+	w.bool(true)         // no_trunc
+	w.bool(true)         // chown_restricted
+	w.bool(false)        // case_insensitive
+	w.bool(true)         // case_preserving
+	return nil
+}
@@ -0,0 +1,47 @@
+package nfs
+
+import "testing"
+
+func TestCheckSetAttributes(t *testing.T) {
+	file := &fileAttributes{fileType: nf3Reg, mode: 0o640, uid: 1000, gid: 100}
+	mode, size := uint32(0o600), uint64(0)
+	otherUid, otherGid, memberGid := uint32(2000), uint32(200), uint32(300)
+
+	owner := &rpcCredential{uid: 1000, gid: 100, gids: []uint32{memberGid}}
+	group := &rpcCredential{uid: 1001, gid: 100}
+	other := &rpcCredential{uid: 1002, gid: 999}
+	root := &rpcCredential{}
+
+	tests := []struct {
+		name string
+		cred *rpcCredential
+		s    *setAttributes
+		want uint32
+	}{
+		{"owner chmod", owner, &setAttributes{mode: &mode}, nfs3Ok},
+		{"group chmod", group, &setAttributes{mode: &mode}, nfs3ErrPerm},
+		{"owner chown", owner, &setAttributes{uid: &otherUid}, nfs3ErrPerm},
+		{"root chown", root, &setAttributes{uid: &otherUid}, nfs3Ok},
+		{"owner chgrp to a member group", owner, &setAttributes{gid: &memberGid}, nfs3Ok},
+		{"owner chgrp to another group", owner, &setAttributes{gid: &otherGid}, nfs3ErrPerm},
+		{"group truncate without write permission", group, &setAttributes{size: &size}, nfs3ErrAcces},
+		{"other truncate", other, &setAttributes{size: &size}, nfs3ErrAcces},
+		{"owner truncate", owner, &setAttributes{size: &size}, nfs3Ok},
+	}
+	for _, tt := range tests {
+		if got := checkSetAttributes(file, tt.s, tt.cred); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	readOnly := &fileAttributes{fileType: nf3Reg, mode: 0o444, uid: 1000, gid: 100}
+	if !mayWrite(readOnly, owner) {
+		t.Errorf("the owner may write its read only file")
+	}
+	if mayWrite(readOnly, group) {
+		t.Errorf("the group may not write a read only file")
+	}
+	if !mayWrite(&fileAttributes{fileType: nf3Reg, mode: 0o664, uid: 1000, gid: 100}, group) {
+		t.Errorf("the group may write a group writable file")
+	}
+}
@@ -0,0 +1,235 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/mount/meta_cache"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"github.com/seaweedfs/seaweedfs/weed/util/chunk_cache"
+	"github.com/seaweedfs/seaweedfs/weed/util/version"
+)
+
+type Option struct {
+	Filer              pb.ServerAddress
+	FilerRootPath      string
+	GrpcDialOption     grpc.DialOption
+	Collection         string
+	Replication        string
+	DiskType           string
+	DataCenter         string
+	TtlSec             int32
+	Cipher             bool
+	VolumeServerAccess string // how to access volume servers
+	CacheDir           string
+	CacheSizeMB        int64
+	ChunkSizeLimit     int64
+	ConcurrentWriters  int
+	WriteBehindDelay   time.Duration
+	UidGidMapper       *meta_cache.UidGidMapper
+	ExportAcl          *ExportAcl
+}
+
+// NfsServer is an NFSv3 gateway backed by a filer.
+// The MOUNT and NFS programs are served on the same TCP port.
+type NfsServer struct {
+	option            *Option
+	root              util.FullPath
+	handles           *handleMap
+	chunkCache        *chunk_cache.TieredChunkCache
+	readerCache       *filer.ReaderCache
+	concurrentWriters *util.LimitedConcurrentExecutor
+	swapFileDir       string
+	signature         int32
+	writeVerifier     []byte
+	openFilesLock     sync.Mutex
+	openFiles         map[uint64]*openFile
+	dirListings       *dirListingCache
+	mountsLock        sync.Mutex
+	mounts            map[string]string // client address -> mounted path
+	stats             fsStatsCache
+	stopCh            chan struct{}
+}
+
+func NewNfsServer(option *Option) (*NfsServer, error) {
+
+	root := util.FullPath(path.Clean(option.FilerRootPath))
+	if root == "" || root == "." {
+		root = "/"
+	}
+
+	cacheUniqueId := util.Md5String([]byte("nfs" + string(option.Filer) + string(root) + version.Version()))[0:8]
+	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
+	if err := os.MkdirAll(path.Join(cacheDir, "swap"), os.FileMode(0755)); err != nil {
+		return nil, fmt.Errorf("create cache dir %s: %v", cacheDir, err)
+	}
+
+	if option.ExportAcl == nil {
+		option.ExportAcl = &ExportAcl{}
+	}
+	if option.UidGidMapper == nil {
+		option.UidGidMapper, _ = meta_cache.NewUidGidMapper("", "")
+	}
+
+	ns := &NfsServer{
+		option:            option,
+		root:              root,
+		chunkCache:        chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024),
+		concurrentWriters: util.NewLimitedConcurrentExecutor(option.ConcurrentWriters),
+		swapFileDir:       path.Join(cacheDir, "swap"),
+		signature:         util.RandomInt32(),
+		writeVerifier:     binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixNano())),
+		openFiles:         make(map[uint64]*openFile),
+		dirListings:       newDirListingCache(),
+		mounts:            make(map[string]string),
+		stopCh:            make(chan struct{}),
+	}
+	ns.readerCache = filer.NewReaderCache(32, ns.chunkCache, filer.LookupFn(ns))
+	ns.handles = newHandleMap(root, &filerHandleStore{filerClient: ns})
+
+	go ns.loopFlushOpenFiles()
+
+	return ns, nil
+}
+
+var _ = filer_pb.FilerClient(&NfsServer{})
+
+func (ns *NfsServer) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+
+	return pb.WithGrpcClient(streamingMode, ns.signature, func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(client)
+	}, ns.option.Filer.ToGrpcAddress(), false, ns.option.GrpcDialOption)
+
+}
+
+func (ns *NfsServer) AdjustedUrl(location *filer_pb.Location) string {
+	if ns.option.VolumeServerAccess == "publicUrl" {
+		return location.PublicUrl
+	}
+	return location.Url
+}
+
+func (ns *NfsServer) GetDataCenter() string {
+	return ns.option.DataCenter
+}
+
+// Serve accepts NFS clients until the listener is closed
+func (ns *NfsServer) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		var ip net.IP
+		if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+			ip = tcpAddr.IP
+		}
+		allowed, readOnly := ns.option.ExportAcl.Check(ip)
+		if !allowed {
+			glog.V(0).Infof("nfs: reject client %v", conn.RemoteAddr())
+			conn.Close()
+			continue
+		}
+		glog.V(1).Infof("nfs: accept client %v read-only:%v", conn.RemoteAddr(), readOnly)
+		go serveRpcConn(conn, readOnly, ns.dispatch)
+	}
+}
+
+// Shutdown flushes all buffered writes and persists the handles
+func (ns *NfsServer) Shutdown() {
+	close(ns.stopCh)
+	ns.flushOpenFiles(true)
+	ns.handles.persist()
+}
+
+func (ns *NfsServer) dispatch(call *rpcCall, w *xdrWriter) uint32 {
+	switch call.prog {
+	case mountProgram:
+		if call.vers != mountVersion3 {
+			w.uint32(mountVersion3)
+			w.uint32(mountVersion3)
+			return rpcAcceptProgMismatch
+		}
+		return ns.dispatchMount(call, w)
+	case nfsProgram:
+		if call.vers != nfsVersion3 {
+			w.uint32(nfsVersion3)
+			w.uint32(nfsVersion3)
+			return rpcAcceptProgMismatch
+		}
+		return ns.dispatchNfs(call, w)
+	}
+	return rpcAcceptProgUnavail
+}
+
+func (ns *NfsServer) lookupEntry(fullpath util.FullPath) (*filer_pb.Entry, error) {
+	if fullpath == "/" {
+		return &filer_pb.Entry{
+			Name:        "/",
+			IsDirectory: true,
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(os.ModeDir | 0777),
+				Mtime:    time.Now().Unix(),
+			},
+		}, nil
+	}
+	return filer_pb.GetEntry(context.Background(), ns, fullpath)
+}
+
+// getEntry returns the entry of the inode, including writes not yet flushed to the filer
+func (ns *NfsServer) getEntry(inode uint64, fullpath util.FullPath) (*filer_pb.Entry, error) {
+	if f := ns.findOpenFile(inode); f != nil {
+		if entry := f.dirtyEntry(); entry != nil {
+			return entry, nil
+		}
+	}
+	return ns.lookupEntry(fullpath)
+}
+
+type fsStatsCache struct {
+	sync.Mutex
+	filer_pb.StatisticsResponse
+	lastChecked int64 // unix time in seconds
+}
+
+func (ns *NfsServer) statistics() (totalSize, usedSize, fileCount uint64) {
+	ns.stats.Lock()
+	defer ns.stats.Unlock()
+
+	if ns.stats.lastChecked < time.Now().Unix()-20 {
+		err := ns.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			request := &filer_pb.StatisticsRequest{
+				Collection:  ns.option.Collection,
+				Replication: ns.option.Replication,
+				Ttl:         fmt.Sprintf("%ds", ns.option.TtlSec),
+				DiskType:    ns.option.DiskType,
+			}
+			resp, err := client.Statistics(context.Background(), request)
+			if err != nil {
+				return err
+			}
+			ns.stats.TotalSize = resp.TotalSize
+			ns.stats.UsedSize = resp.UsedSize
+			ns.stats.FileCount = resp.FileCount
+			ns.stats.lastChecked = time.Now().Unix()
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("filer Statistics: %v", err)
+		}
+	}
+	return ns.stats.TotalSize, ns.stats.UsedSize, ns.stats.FileCount
+}
@@ -0,0 +1,268 @@
+package nfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// ONC RPC v2 (RFC 5531) over TCP with record marking.
+
+const (
+	rpcVersion = 2
+
+	rpcMsgCall  = 0
+	rpcMsgReply = 1
+
+	rpcReplyAccepted = 0
+	rpcReplyDenied   = 1
+
+	rpcAcceptSuccess      = 0
+	rpcAcceptProgUnavail  = 1
+	rpcAcceptProgMismatch = 2
+	rpcAcceptProcUnavail  = 3
+	rpcAcceptGarbageArgs  = 4
+	rpcAcceptSystemErr    = 5
+
+	rpcRejectMismatch  = 0
+	rpcRejectAuthError = 1
+
+	rpcAuthNone = 0
+	rpcAuthUnix = 1
+
+	rpcAuthStatBadCred = 1
+	rpcAuthStatTooWeak = 5
+
+	rpcLastFragment = 0x80000000
+	// large enough for a maximum sized WRITE plus its headers
+	rpcMaxRecordSize = 4 * 1024 * 1024
+	// record mark, xid, message type, reply status, verifier flavor and length, accept status
+	rpcAcceptedReplyHeaderSize = 4 + 6*4
+
+	maxAuthBodySize      = 400
+	maxMachineNameSize   = 255
+	maxAuxiliaryGroups   = 16
+	nobodyId             = 65534
+	maxConcurrentPerConn = 16
+)
+
+var errRpcGarbageArgs = errors.New("garbage arguments")
+
+// rpcCredential is the caller identity carried by AUTH_UNIX
+type rpcCredential struct {
+	flavor  uint32
+	machine string
+	uid     uint32
+	gid     uint32
+	gids    []uint32
+}
+
+type rpcCall struct {
+	xid        uint32
+	prog       uint32
+	vers       uint32
+	proc       uint32
+	cred       rpcCredential
+	args       *xdrReader
+	conn       *rpcConn
+	remoteAddr net.Addr
+}
+
+// rpcHandler handles one call, appending the procedure results to w.
+// It returns the RPC accept status.
+type rpcHandler func(call *rpcCall, w *xdrWriter) uint32
+
+type rpcConn struct {
+	conn      net.Conn
+	handler   rpcHandler
+	writeLock sync.Mutex
+	// readOnly is decided by the export ACL when the connection is accepted
+	readOnly bool
+}
+
+func serveRpcConn(conn net.Conn, readOnly bool, handler rpcHandler) {
+	c := &rpcConn{
+		conn:     conn,
+		handler:  handler,
+		readOnly: readOnly,
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReaderSize(conn, 64*1024)
+	limiter := make(chan struct{}, maxConcurrentPerConn)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		record, err := readRpcRecord(reader)
+		if err != nil {
+			if err != io.EOF {
+				glog.V(1).Infof("nfs: read from %v: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			if err := c.handleRecord(record); err != nil {
+				glog.V(1).Infof("nfs: call from %v: %v", conn.RemoteAddr(), err)
+				conn.Close()
+			}
+		}()
+	}
+}
+
+// readRpcRecord reads all fragments of one record
+func readRpcRecord(reader io.Reader) ([]byte, error) {
+	var record []byte
+	var header [4]byte
+	for {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return nil, err
+		}
+		mark := binary.BigEndian.Uint32(header[:])
+		size := int(mark &^ rpcLastFragment)
+		if len(record)+size > rpcMaxRecordSize {
+			return nil, fmt.Errorf("rpc record size %d exceeds %d", len(record)+size, rpcMaxRecordSize)
+		}
+		start := len(record)
+		record = append(record, make([]byte, size)...)
+		if _, err := io.ReadFull(reader, record[start:]); err != nil {
+			return nil, err
+		}
+		if mark&rpcLastFragment != 0 {
+			return record, nil
+		}
+	}
+}
+
+func (c *rpcConn) handleRecord(record []byte) error {
+	r := newXdrReader(record)
+	xid, err := r.uint32()
+	if err != nil {
+		return err
+	}
+	msgType, err := r.uint32()
+	if err != nil {
+		return err
+	}
+	if msgType != rpcMsgCall {
+		return fmt.Errorf("unexpected rpc message type %d", msgType)
+	}
+
+	call := &rpcCall{xid: xid, conn: c, remoteAddr: c.conn.RemoteAddr()}
+	var version uint32
+	for _, field := range []*uint32{&version, &call.prog, &call.vers, &call.proc} {
+		if *field, err = r.uint32(); err != nil {
+			return err
+		}
+	}
+	if version != rpcVersion {
+		w := newXdrWriter(32)
+		writeRpcDeniedHeader(w, xid, rpcRejectMismatch)
+		w.uint32(rpcVersion)
+		w.uint32(rpcVersion)
+		return c.writeRecord(w)
+	}
+
+	if call.cred, err = readRpcCredential(r); err != nil {
+		w := newXdrWriter(32)
+		writeRpcDeniedHeader(w, xid, rpcRejectAuthError)
+		w.uint32(rpcAuthStatBadCred)
+		return c.writeRecord(w)
+	}
+	// the verifier is not used by AUTH_NONE or AUTH_UNIX
+	if _, err = r.uint32(); err != nil {
+		return err
+	}
+	if _, err = r.opaque(maxAuthBodySize); err != nil {
+		return err
+	}
+	if call.cred.flavor != rpcAuthNone && call.cred.flavor != rpcAuthUnix && call.proc != 0 {
+		w := newXdrWriter(32)
+		writeRpcDeniedHeader(w, xid, rpcRejectAuthError)
+		w.uint32(rpcAuthStatTooWeak)
+		return c.writeRecord(w)
+	}
+	call.args = r
+
+	w := newXdrWriter(256)
+	w.fixedOpaque(make([]byte, rpcAcceptedReplyHeaderSize))
+	acceptStatus := c.handler(call, w)
+	if acceptStatus != rpcAcceptSuccess && acceptStatus != rpcAcceptProgMismatch {
+		// drop any partially written results
+		w.buf = w.buf[:rpcAcceptedReplyHeaderSize]
+	}
+	header := w.buf[4:rpcAcceptedReplyHeaderSize]
+	for i, v := range []uint32{xid, rpcMsgReply, rpcReplyAccepted, rpcAuthNone, 0, acceptStatus} {
+		binary.BigEndian.PutUint32(header[i*4:], v)
+	}
+	return c.writeRecord(w)
+}
+
+func writeRpcDeniedHeader(w *xdrWriter, xid uint32, rejectStatus uint32) {
+	w.uint32(0) // record mark placeholder
+	w.uint32(xid)
+	w.uint32(rpcMsgReply)
+	w.uint32(rpcReplyDenied)
+	w.uint32(rejectStatus)
+}
+
+// writeRecord sends the reply as a single fragment. The first 4 bytes of w are reserved for the record mark.
+func (c *rpcConn) writeRecord(w *xdrWriter) error {
+	buf := w.Bytes()
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-4)|rpcLastFragment)
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+func readRpcCredential(r *xdrReader) (cred rpcCredential, err error) {
+	cred.uid, cred.gid = nobodyId, nobodyId
+	if cred.flavor, err = r.uint32(); err != nil {
+		return
+	}
+	body, err := r.opaque(maxAuthBodySize)
+	if err != nil || cred.flavor != rpcAuthUnix {
+		return
+	}
+	br := newXdrReader(body)
+	if _, err = br.uint32(); err != nil { // stamp
+		return
+	}
+	if cred.machine, err = br.string(maxMachineNameSize); err != nil {
+		return
+	}
+	if cred.uid, err = br.uint32(); err != nil {
+		return
+	}
+	if cred.gid, err = br.uint32(); err != nil {
+		return
+	}
+	count, err := br.uint32()
+	if err != nil {
+		return
+	}
+	if count > maxAuxiliaryGroups {
+		return cred, fmt.Errorf("too many auxiliary groups: %d", count)
+	}
+	for i := uint32(0); i < count; i++ {
+		var gid uint32
+		if gid, err = br.uint32(); err != nil {
+			return
+		}
+		cred.gids = append(cred.gids, gid)
+	}
+	return
+}
@@ -0,0 +1,124 @@
+package nfs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// XDR (RFC 4506) encoding used by ONC RPC, MOUNT and NFSv3.
+// All quantities are big endian and padded to 4 byte boundaries.
+
+var errXdrShortBuffer = errors.New("xdr: short buffer")
+
+type xdrReader struct {
+	buf []byte
+	pos int
+}
+
+func newXdrReader(buf []byte) *xdrReader {
+	return &xdrReader{buf: buf}
+}
+
+func (r *xdrReader) remaining() int {
+	return len(r.buf) - r.pos
+}
+
+func (r *xdrReader) uint32() (uint32, error) {
+	if r.remaining() < 4 {
+		return 0, errXdrShortBuffer
+	}
+	v := binary.BigEndian.Uint32(r.buf[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *xdrReader) uint64() (uint64, error) {
+	if r.remaining() < 8 {
+		return 0, errXdrShortBuffer
+	}
+	v := binary.BigEndian.Uint64(r.buf[r.pos:])
+	r.pos += 8
+	return v, nil
+}
+
+func (r *xdrReader) bool() (bool, error) {
+	v, err := r.uint32()
+	return v != 0, err
+}
+
+// fixedOpaque reads n bytes plus padding, without copying
+func (r *xdrReader) fixedOpaque(n int) ([]byte, error) {
+	padded := (n + 3) &^ 3
+	if n < 0 || r.remaining() < padded {
+		return nil, errXdrShortBuffer
+	}
+	v := r.buf[r.pos : r.pos+n]
+	r.pos += padded
+	return v, nil
+}
+
+// opaque reads a variable length opaque, limited to maxSize bytes
+func (r *xdrReader) opaque(maxSize int) ([]byte, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if int64(n) > int64(maxSize) {
+		return nil, fmt.Errorf("xdr: opaque length %d exceeds %d", n, maxSize)
+	}
+	return r.fixedOpaque(int(n))
+}
+
+func (r *xdrReader) string(maxSize int) (string, error) {
+	v, err := r.opaque(maxSize)
+	return string(v), err
+}
+
+type xdrWriter struct {
+	buf []byte
+}
+
+func newXdrWriter(capacity int) *xdrWriter {
+	return &xdrWriter{buf: make([]byte, 0, capacity)}
+}
+
+func (w *xdrWriter) Bytes() []byte {
+	return w.buf
+}
+
+func (w *xdrWriter) Len() int {
+	return len(w.buf)
+}
+
+func (w *xdrWriter) uint32(v uint32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, v)
+}
+
+func (w *xdrWriter) uint64(v uint64) {
+	w.buf = binary.BigEndian.AppendUint64(w.buf, v)
+}
+
+func (w *xdrWriter) bool(v bool) {
+	if v {
+		w.uint32(1)
+	} else {
+		w.uint32(0)
+	}
+}
+
+func (w *xdrWriter) fixedOpaque(v []byte) {
+	w.buf = append(w.buf, v...)
+	if pad := (4 - len(v)%4) % 4; pad > 0 {
+		w.buf = append(w.buf, make([]byte, pad)...)
+	}
+}
+
+func (w *xdrWriter) opaque(v []byte) {
+	w.uint32(uint32(len(v)))
+	w.fixedOpaque(v)
+}
+
+func (w *xdrWriter) string(v string) {
+	w.opaque([]byte(v))
+}
@@ -0,0 +1,59 @@
+package nfs
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestXdrRoundTrip(t *testing.T) {
+	w := newXdrWriter(64)
+	w.uint32(7)
+	w.uint64(1 << 40)
+	w.bool(true)
+	w.string("abcde")
+	w.opaque([]byte{1, 2, 3, 4})
+	w.fixedOpaque([]byte{9, 9})
+
+	// strings and opaques are padded to 4 bytes
+	if w.Len() != 4+8+4+(4+8)+(4+4)+4 {
+		t.Fatalf("unexpected encoded length %d", w.Len())
+	}
+
+	r := newXdrReader(w.Bytes())
+	if v, err := r.uint32(); err != nil || v != 7 {
+		t.Fatalf("uint32: %v %v", v, err)
+	}
+	if v, err := r.uint64(); err != nil || v != 1<<40 {
+		t.Fatalf("uint64: %v %v", v, err)
+	}
+	if v, err := r.bool(); err != nil || !v {
+		t.Fatalf("bool: %v %v", v, err)
+	}
+	if v, err := r.string(16); err != nil || v != "abcde" {
+		t.Fatalf("string: %q %v", v, err)
+	}
+	if v, err := r.opaque(16); err != nil || !bytes.Equal(v, []byte{1, 2, 3, 4}) {
+		t.Fatalf("opaque: %v %v", v, err)
+	}
+	if v, err := r.fixedOpaque(2); err != nil || !bytes.Equal(v, []byte{9, 9}) {
+		t.Fatalf("fixedOpaque: %v %v", v, err)
+	}
+	if r.remaining() != 0 {
+		t.Fatalf("expected all consumed, %d left", r.remaining())
+	}
+}
+
+func TestXdrReaderLimits(t *testing.T) {
+	w := newXdrWriter(16)
+	w.string("too long")
+
+	if _, err := newXdrReader(w.Bytes()).string(4); err == nil {
+		t.Fatalf("expected error for string longer than the limit")
+	}
+	if _, err := newXdrReader(w.Bytes()[:6]).string(16); err == nil {
+		t.Fatalf("expected error for truncated string")
+	}
+	if _, err := newXdrReader([]byte{0, 0}).uint32(); err == nil {
+		t.Fatalf("expected error for short buffer")
+	}
+}
@@ -24,8 +24,12 @@ const (
 type ConfigureRequest struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	CollectionCapacity int64                  `protobuf:"varint,1,opt,name=collection_capacity,json=collectionCapacity,proto3" json:"collection_capacity,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// when set, change the consistency mode of this directory instead of the quota
+	// the path is relative to the mount directory
+	ConsistencyPath string `protobuf:"bytes,2,opt,name=consistency_path,json=consistencyPath,proto3" json:"consistency_path,omitempty"`
+	ConsistencyMode string `protobuf:"bytes,3,opt,name=consistency_mode,json=consistencyMode,proto3" json:"consistency_mode,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ConfigureRequest) Reset() {
@@ -65,10 +69,25 @@ func (x *ConfigureRequest) GetCollectionCapacity() int64 {
 	return 0
 }
 
+func (x *ConfigureRequest) GetConsistencyPath() string {
+	if x != nil {
+		return x.ConsistencyPath
+	}
+	return ""
+}
+
+func (x *ConfigureRequest) GetConsistencyMode() string {
+	if x != nil {
+		return x.ConsistencyMode
+	}
+	return ""
+}
+
 type ConfigureResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsistencyRules []*ConsistencyRule     `protobuf:"bytes,1,rep,name=consistency_rules,json=consistencyRules,proto3" json:"consistency_rules,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ConfigureResponse) Reset() {
@@ -101,14 +120,79 @@ func (*ConfigureResponse) Descriptor() ([]byte, []int) {
 	return file_mount_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *ConfigureResponse) GetConsistencyRules() []*ConsistencyRule {
+	if x != nil {
+		return x.ConsistencyRules
+	}
+	return nil
+}
+
+type ConsistencyRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsistencyRule) Reset() {
+	*x = ConsistencyRule{}
+	mi := &file_mount_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsistencyRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsistencyRule) ProtoMessage() {}
+
+func (x *ConsistencyRule) ProtoReflect() protoreflect.Message {
+	mi := &file_mount_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsistencyRule.ProtoReflect.Descriptor instead.
+func (*ConsistencyRule) Descriptor() ([]byte, []int) {
+	return file_mount_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConsistencyRule) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ConsistencyRule) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
 var File_mount_proto protoreflect.FileDescriptor
 
 const file_mount_proto_rawDesc = "" +
 	"\n" +
-	"\vmount.proto\x12\fmessaging_pb\"C\n" +
+	"\vmount.proto\x12\fmessaging_pb\"\x99\x01\n" +
 	"\x10ConfigureRequest\x12/\n" +
-	"\x13collection_capacity\x18\x01 \x01(\x03R\x12collectionCapacity\"\x13\n" +
-	"\x11ConfigureResponse2^\n" +
+	"\x13collection_capacity\x18\x01 \x01(\x03R\x12collectionCapacity\x12)\n" +
+	"\x10consistency_path\x18\x02 \x01(\tR\x0fconsistencyPath\x12)\n" +
+	"\x10consistency_mode\x18\x03 \x01(\tR\x0fconsistencyMode\"_\n" +
+	"\x11ConfigureResponse\x12J\n" +
+	"\x11consistency_rules\x18\x01 \x03(\v2\x1d.messaging_pb.ConsistencyRuleR\x10consistencyRules\"9\n" +
+	"\x0fConsistencyRule\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode2^\n" +
 	"\fSeaweedMount\x12N\n" +
 	"\tConfigure\x12\x1e.messaging_pb.ConfigureRequest\x1a\x1f.messaging_pb.ConfigureResponse\"\x00BO\n" +
 	"\x10seaweedfs.clientB\n" +
@@ -126,19 +210,21 @@ func file_mount_proto_rawDescGZIP() []byte {
 	return file_mount_proto_rawDescData
 }
 
-var file_mount_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_mount_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_mount_proto_goTypes = []any{
 	(*ConfigureRequest)(nil),  // 0: messaging_pb.ConfigureRequest
 	(*ConfigureResponse)(nil), // 1: messaging_pb.ConfigureResponse
+	(*ConsistencyRule)(nil),   // 2: messaging_pb.ConsistencyRule
 }
 var file_mount_proto_depIdxs = []int32{
-	0, // 0: messaging_pb.SeaweedMount.Configure:input_type -> messaging_pb.ConfigureRequest
-	1, // 1: messaging_pb.SeaweedMount.Configure:output_type -> messaging_pb.ConfigureResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: messaging_pb.ConfigureResponse.consistency_rules:type_name -> messaging_pb.ConsistencyRule
+	0, // 1: messaging_pb.SeaweedMount.Configure:input_type -> messaging_pb.ConfigureRequest
+	1, // 2: messaging_pb.SeaweedMount.Configure:output_type -> messaging_pb.ConfigureResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_mount_proto_init() }
@@ -152,7 +238,7 @@ func file_mount_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mount_proto_rawDesc), len(file_mount_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -7,6 +7,7 @@
 package mq_agent_pb
 
 import (
+	mq_pb "github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
 	schema_pb "github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -237,6 +238,7 @@ type PublishRecordRequest struct {
 	SessionId     int64                  `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // session_id is required for the first record
 	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	Value         *schema_pb.RecordValue `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	DeliverAtNs   int64                  `protobuf:"varint,4,opt,name=deliver_at_ns,json=deliverAtNs,proto3" json:"deliver_at_ns,omitempty"` // if in the future, the record is delivered to subscribers at this time
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -292,6 +294,13 @@ func (x *PublishRecordRequest) GetValue() *schema_pb.RecordValue {
 	return nil
 }
 
+func (x *PublishRecordRequest) GetDeliverAtNs() int64 {
+	if x != nil {
+		return x.DeliverAtNs
+	}
+	return 0
+}
+
 type PublishRecordResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AckSequence   int64                  `protobuf:"varint,1,opt,name=ack_sequence,json=ackSequence,proto3" json:"ack_sequence,omitempty"`
@@ -366,6 +375,8 @@ type SubscribeRecordRequest struct {
 	Init          *SubscribeRecordRequest_InitSubscribeRecordRequest `protobuf:"bytes,1,opt,name=init,proto3" json:"init,omitempty"`
 	AckSequence   int64                                              `protobuf:"varint,2,opt,name=ack_sequence,json=ackSequence,proto3" json:"ack_sequence,omitempty"`
 	AckKey        []byte                                             `protobuf:"bytes,3,opt,name=ack_key,json=ackKey,proto3" json:"ack_key,omitempty"`
+	IsNack        bool                                               `protobuf:"varint,4,opt,name=is_nack,json=isNack,proto3" json:"is_nack,omitempty"`
+	NackError     string                                             `protobuf:"bytes,5,opt,name=nack_error,json=nackError,proto3" json:"nack_error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -421,17 +432,32 @@ func (x *SubscribeRecordRequest) GetAckKey() []byte {
 	return nil
 }
 
+func (x *SubscribeRecordRequest) GetIsNack() bool {
+	if x != nil {
+		return x.IsNack
+	}
+	return false
+}
+
+func (x *SubscribeRecordRequest) GetNackError() string {
+	if x != nil {
+		return x.NackError
+	}
+	return ""
+}
+
 type SubscribeRecordResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value         *schema_pb.RecordValue `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	TsNs          int64                  `protobuf:"varint,4,opt,name=ts_ns,json=tsNs,proto3" json:"ts_ns,omitempty"`
-	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
-	IsEndOfStream bool                   `protobuf:"varint,6,opt,name=is_end_of_stream,json=isEndOfStream,proto3" json:"is_end_of_stream,omitempty"`
-	IsEndOfTopic  bool                   `protobuf:"varint,7,opt,name=is_end_of_topic,json=isEndOfTopic,proto3" json:"is_end_of_topic,omitempty"`
-	Offset        int64                  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"` // Sequential offset within partition
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Key             []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value           *schema_pb.RecordValue `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	TsNs            int64                  `protobuf:"varint,4,opt,name=ts_ns,json=tsNs,proto3" json:"ts_ns,omitempty"`
+	Error           string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	IsEndOfStream   bool                   `protobuf:"varint,6,opt,name=is_end_of_stream,json=isEndOfStream,proto3" json:"is_end_of_stream,omitempty"`
+	IsEndOfTopic    bool                   `protobuf:"varint,7,opt,name=is_end_of_topic,json=isEndOfTopic,proto3" json:"is_end_of_topic,omitempty"`
+	Offset          int64                  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"` // Sequential offset within partition
+	DeliveryAttempt int32                  `protobuf:"varint,9,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *SubscribeRecordResponse) Reset() {
@@ -513,6 +539,13 @@ func (x *SubscribeRecordResponse) GetOffset() int64 {
 	return 0
 }
 
+func (x *SubscribeRecordResponse) GetDeliveryAttempt() int32 {
+	if x != nil {
+		return x.DeliveryAttempt
+	}
+	return 0
+}
+
 type SubscribeRecordRequest_InitSubscribeRecordRequest struct {
 	state                   protoimpl.MessageState       `protogen:"open.v1"`
 	ConsumerGroup           string                       `protobuf:"bytes,1,opt,name=consumer_group,json=consumerGroup,proto3" json:"consumer_group,omitempty"`
@@ -524,8 +557,10 @@ type SubscribeRecordRequest_InitSubscribeRecordRequest struct {
 	Filter                  string                       `protobuf:"bytes,10,opt,name=filter,proto3" json:"filter,omitempty"`
 	MaxSubscribedPartitions int32                        `protobuf:"varint,11,opt,name=max_subscribed_partitions,json=maxSubscribedPartitions,proto3" json:"max_subscribed_partitions,omitempty"`
 	SlidingWindowSize       int32                        `protobuf:"varint,12,opt,name=sliding_window_size,json=slidingWindowSize,proto3" json:"sliding_window_size,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	// with a dead letter policy, each record must be acked or nacked by the client
+	DeadLetterPolicy *mq_pb.DeadLetterPolicy `protobuf:"bytes,13,opt,name=dead_letter_policy,json=deadLetterPolicy,proto3" json:"dead_letter_policy,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *SubscribeRecordRequest_InitSubscribeRecordRequest) Reset() {
@@ -621,11 +656,18 @@ func (x *SubscribeRecordRequest_InitSubscribeRecordRequest) GetSlidingWindowSize
 	return 0
 }
 
+func (x *SubscribeRecordRequest_InitSubscribeRecordRequest) GetDeadLetterPolicy() *mq_pb.DeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetterPolicy
+	}
+	return nil
+}
+
 var File_mq_agent_proto protoreflect.FileDescriptor
 
 const file_mq_agent_proto_rawDesc = "" +
 	"\n" +
-	"\x0emq_agent.proto\x12\fmessaging_pb\x1a\x0fmq_schema.proto\"\xcc\x01\n" +
+	"\x0emq_agent.proto\x12\fmessaging_pb\x1a\x0fmq_schema.proto\x1a\x0fmq_broker.proto\"\xcc\x01\n" +
 	"\x1aStartPublishSessionRequest\x12&\n" +
 	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\x12'\n" +
 	"\x0fpartition_count\x18\x02 \x01(\x05R\x0epartitionCount\x126\n" +
@@ -640,23 +682,27 @@ const file_mq_agent_proto_rawDesc = "" +
 	"\n" +
 	"session_id\x18\x01 \x01(\x03R\tsessionId\"3\n" +
 	"\x1bClosePublishSessionResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error\"u\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\x99\x01\n" +
 	"\x14PublishRecordRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\x03R\tsessionId\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\fR\x03key\x12,\n" +
-	"\x05value\x18\x03 \x01(\v2\x16.schema_pb.RecordValueR\x05value\"\x92\x01\n" +
+	"\x05value\x18\x03 \x01(\v2\x16.schema_pb.RecordValueR\x05value\x12\"\n" +
+	"\rdeliver_at_ns\x18\x04 \x01(\x03R\vdeliverAtNs\"\x92\x01\n" +
 	"\x15PublishRecordResponse\x12!\n" +
 	"\fack_sequence\x18\x01 \x01(\x03R\vackSequence\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1f\n" +
 	"\vbase_offset\x18\x03 \x01(\x03R\n" +
 	"baseOffset\x12\x1f\n" +
 	"\vlast_offset\x18\x04 \x01(\x03R\n" +
-	"lastOffset\"\xfb\x04\n" +
+	"lastOffset\"\x81\x06\n" +
 	"\x16SubscribeRecordRequest\x12S\n" +
 	"\x04init\x18\x01 \x01(\v2?.messaging_pb.SubscribeRecordRequest.InitSubscribeRecordRequestR\x04init\x12!\n" +
 	"\fack_sequence\x18\x02 \x01(\x03R\vackSequence\x12\x17\n" +
-	"\aack_key\x18\x03 \x01(\fR\x06ackKey\x1a\xcf\x03\n" +
+	"\aack_key\x18\x03 \x01(\fR\x06ackKey\x12\x17\n" +
+	"\ais_nack\x18\x04 \x01(\bR\x06isNack\x12\x1d\n" +
+	"\n" +
+	"nack_error\x18\x05 \x01(\tR\tnackError\x1a\x9d\x04\n" +
 	"\x1aInitSubscribeRecordRequest\x12%\n" +
 	"\x0econsumer_group\x18\x01 \x01(\tR\rconsumerGroup\x12;\n" +
 	"\x1aconsumer_group_instance_id\x18\x02 \x01(\tR\x17consumerGroupInstanceId\x12&\n" +
@@ -669,7 +715,8 @@ const file_mq_agent_proto_rawDesc = "" +
 	"\x06filter\x18\n" +
 	" \x01(\tR\x06filter\x12:\n" +
 	"\x19max_subscribed_partitions\x18\v \x01(\x05R\x17maxSubscribedPartitions\x12.\n" +
-	"\x13sliding_window_size\x18\f \x01(\x05R\x11slidingWindowSize\"\xec\x01\n" +
+	"\x13sliding_window_size\x18\f \x01(\x05R\x11slidingWindowSize\x12L\n" +
+	"\x12dead_letter_policy\x18\r \x01(\v2\x1e.messaging_pb.DeadLetterPolicyR\x10deadLetterPolicy\"\x97\x02\n" +
 	"\x17SubscribeRecordResponse\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\fR\x03key\x12,\n" +
 	"\x05value\x18\x03 \x01(\v2\x16.schema_pb.RecordValueR\x05value\x12\x13\n" +
@@ -677,7 +724,8 @@ const file_mq_agent_proto_rawDesc = "" +
 	"\x05error\x18\x05 \x01(\tR\x05error\x12'\n" +
 	"\x10is_end_of_stream\x18\x06 \x01(\bR\risEndOfStream\x12%\n" +
 	"\x0fis_end_of_topic\x18\a \x01(\bR\fisEndOfTopic\x12\x16\n" +
-	"\x06offset\x18\b \x01(\x03R\x06offset2\xb9\x03\n" +
+	"\x06offset\x18\b \x01(\x03R\x06offset\x12)\n" +
+	"\x10delivery_attempt\x18\t \x01(\x05R\x0fdeliveryAttempt2\xb9\x03\n" +
 	"\x15SeaweedMessagingAgent\x12l\n" +
 	"\x13StartPublishSession\x12(.messaging_pb.StartPublishSessionRequest\x1a).messaging_pb.StartPublishSessionResponse\"\x00\x12l\n" +
 	"\x13ClosePublishSession\x12(.messaging_pb.ClosePublishSessionRequest\x1a).messaging_pb.ClosePublishSessionResponse\"\x00\x12^\n" +
@@ -713,6 +761,7 @@ var file_mq_agent_proto_goTypes = []any{
 	(*schema_pb.RecordValue)(nil),                             // 11: schema_pb.RecordValue
 	(*schema_pb.PartitionOffset)(nil),                         // 12: schema_pb.PartitionOffset
 	(schema_pb.OffsetType)(0),                                 // 13: schema_pb.OffsetType
+	(*mq_pb.DeadLetterPolicy)(nil),                            // 14: messaging_pb.DeadLetterPolicy
 }
 var file_mq_agent_proto_depIdxs = []int32{
 	9,  // 0: messaging_pb.StartPublishSessionRequest.topic:type_name -> schema_pb.Topic
@@ -723,19 +772,20 @@ var file_mq_agent_proto_depIdxs = []int32{
 	9,  // 5: messaging_pb.SubscribeRecordRequest.InitSubscribeRecordRequest.topic:type_name -> schema_pb.Topic
 	12, // 6: messaging_pb.SubscribeRecordRequest.InitSubscribeRecordRequest.partition_offsets:type_name -> schema_pb.PartitionOffset
 	13, // 7: messaging_pb.SubscribeRecordRequest.InitSubscribeRecordRequest.offset_type:type_name -> schema_pb.OffsetType
-	0,  // 8: messaging_pb.SeaweedMessagingAgent.StartPublishSession:input_type -> messaging_pb.StartPublishSessionRequest
-	2,  // 9: messaging_pb.SeaweedMessagingAgent.ClosePublishSession:input_type -> messaging_pb.ClosePublishSessionRequest
-	4,  // 10: messaging_pb.SeaweedMessagingAgent.PublishRecord:input_type -> messaging_pb.PublishRecordRequest
-	6,  // 11: messaging_pb.SeaweedMessagingAgent.SubscribeRecord:input_type -> messaging_pb.SubscribeRecordRequest
-	1,  // 12: messaging_pb.SeaweedMessagingAgent.StartPublishSession:output_type -> messaging_pb.StartPublishSessionResponse
-	3,  // 13: messaging_pb.SeaweedMessagingAgent.ClosePublishSession:output_type -> messaging_pb.ClosePublishSessionResponse
-	5,  // 14: messaging_pb.SeaweedMessagingAgent.PublishRecord:output_type -> messaging_pb.PublishRecordResponse
-	7,  // 15: messaging_pb.SeaweedMessagingAgent.SubscribeRecord:output_type -> messaging_pb.SubscribeRecordResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	14, // 8: messaging_pb.SubscribeRecordRequest.InitSubscribeRecordRequest.dead_letter_policy:type_name -> messaging_pb.DeadLetterPolicy
+	0,  // 9: messaging_pb.SeaweedMessagingAgent.StartPublishSession:input_type -> messaging_pb.StartPublishSessionRequest
+	2,  // 10: messaging_pb.SeaweedMessagingAgent.ClosePublishSession:input_type -> messaging_pb.ClosePublishSessionRequest
+	4,  // 11: messaging_pb.SeaweedMessagingAgent.PublishRecord:input_type -> messaging_pb.PublishRecordRequest
+	6,  // 12: messaging_pb.SeaweedMessagingAgent.SubscribeRecord:input_type -> messaging_pb.SubscribeRecordRequest
+	1,  // 13: messaging_pb.SeaweedMessagingAgent.StartPublishSession:output_type -> messaging_pb.StartPublishSessionResponse
+	3,  // 14: messaging_pb.SeaweedMessagingAgent.ClosePublishSession:output_type -> messaging_pb.ClosePublishSessionResponse
+	5,  // 15: messaging_pb.SeaweedMessagingAgent.PublishRecord:output_type -> messaging_pb.PublishRecordResponse
+	7,  // 16: messaging_pb.SeaweedMessagingAgent.SubscribeRecord:output_type -> messaging_pb.SubscribeRecordResponse
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_mq_agent_proto_init() }
@@ -432,11 +432,14 @@ func (*BalanceTopicsResponse) Descriptor() ([]byte, []int) {
 
 // ////////////////////////////////////////////////
 type TopicRetention struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	RetentionSeconds int64                  `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"` // retention duration in seconds
-	Enabled          bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`                                           // whether retention is enabled
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	RetentionSeconds        int64                  `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`                          // retention duration in seconds
+	Enabled                 bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`                                                                    // whether retention is enabled
+	CleanupPolicy           string                 `protobuf:"bytes,3,opt,name=cleanup_policy,json=cleanupPolicy,proto3" json:"cleanup_policy,omitempty"`                                    // "delete", "compact", or "compact,delete"; empty means "delete"
+	DeleteRetentionSeconds  int64                  `protobuf:"varint,4,opt,name=delete_retention_seconds,json=deleteRetentionSeconds,proto3" json:"delete_retention_seconds,omitempty"`      // how long compaction keeps tombstones, 0 uses the default
+	MinCompactionLagSeconds int64                  `protobuf:"varint,5,opt,name=min_compaction_lag_seconds,json=minCompactionLagSeconds,proto3" json:"min_compaction_lag_seconds,omitempty"` // messages younger than this are not compacted, 0 uses the default
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *TopicRetention) Reset() {
@@ -483,6 +486,27 @@ func (x *TopicRetention) GetEnabled() bool {
 	return false
 }
 
+func (x *TopicRetention) GetCleanupPolicy() string {
+	if x != nil {
+		return x.CleanupPolicy
+	}
+	return ""
+}
+
+func (x *TopicRetention) GetDeleteRetentionSeconds() int64 {
+	if x != nil {
+		return x.DeleteRetentionSeconds
+	}
+	return 0
+}
+
+func (x *TopicRetention) GetMinCompactionLagSeconds() int64 {
+	if x != nil {
+		return x.MinCompactionLagSeconds
+	}
+	return 0
+}
+
 type ConfigureTopicRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Topic             *schema_pb.Topic       `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
@@ -1861,13 +1885,15 @@ func (x *ControlMessage) GetPublisherName() string {
 }
 
 type DataMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	TsNs          int64                  `protobuf:"varint,3,opt,name=ts_ns,json=tsNs,proto3" json:"ts_ns,omitempty"`
-	Ctrl          *ControlMessage        `protobuf:"bytes,4,opt,name=ctrl,proto3" json:"ctrl,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Key             []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value           []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	TsNs            int64                  `protobuf:"varint,3,opt,name=ts_ns,json=tsNs,proto3" json:"ts_ns,omitempty"`
+	Ctrl            *ControlMessage        `protobuf:"bytes,4,opt,name=ctrl,proto3" json:"ctrl,omitempty"`
+	DeliveryAttempt int32                  `protobuf:"varint,5,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"` // set by the broker when delivering to a subscriber, starting from 1
+	DeliverAtNs     int64                  `protobuf:"varint,6,opt,name=deliver_at_ns,json=deliverAtNs,proto3" json:"deliver_at_ns,omitempty"`           // if in the future, the broker holds the message until this time
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *DataMessage) Reset() {
@@ -1928,6 +1954,20 @@ func (x *DataMessage) GetCtrl() *ControlMessage {
 	return nil
 }
 
+func (x *DataMessage) GetDeliveryAttempt() int32 {
+	if x != nil {
+		return x.DeliveryAttempt
+	}
+	return 0
+}
+
+func (x *DataMessage) GetDeliverAtNs() int64 {
+	if x != nil {
+		return x.DeliverAtNs
+	}
+	return 0
+}
+
 type PublishMessageRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Message:
@@ -2342,6 +2382,76 @@ func (*SubscribeMessageRequest_Ack) isSubscribeMessageRequest_Message() {}
 
 func (*SubscribeMessageRequest_Seek) isSubscribeMessageRequest_Message() {}
 
+// DeadLetterPolicy bounds the redelivery of messages that fail processing.
+// After max_delivery_attempts, the message is published to the dead letter topic.
+type DeadLetterPolicy struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	MaxDeliveryAttempts    int32                  `protobuf:"varint,1,opt,name=max_delivery_attempts,json=maxDeliveryAttempts,proto3" json:"max_delivery_attempts,omitempty"` // 0 disables redelivery and dead lettering
+	RedeliveryBackoffMs    int64                  `protobuf:"varint,2,opt,name=redelivery_backoff_ms,json=redeliveryBackoffMs,proto3" json:"redelivery_backoff_ms,omitempty"` // doubled on each redelivery
+	MaxRedeliveryBackoffMs int64                  `protobuf:"varint,3,opt,name=max_redelivery_backoff_ms,json=maxRedeliveryBackoffMs,proto3" json:"max_redelivery_backoff_ms,omitempty"`
+	DeadLetterTopic        *schema_pb.Topic       `protobuf:"bytes,4,opt,name=dead_letter_topic,json=deadLetterTopic,proto3" json:"dead_letter_topic,omitempty"` // defaults to "<topic>.<consumer_group>.dlq"
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DeadLetterPolicy) Reset() {
+	*x = DeadLetterPolicy{}
+	mi := &file_mq_broker_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterPolicy) ProtoMessage() {}
+
+func (x *DeadLetterPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_mq_broker_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterPolicy.ProtoReflect.Descriptor instead.
+func (*DeadLetterPolicy) Descriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeadLetterPolicy) GetMaxDeliveryAttempts() int32 {
+	if x != nil {
+		return x.MaxDeliveryAttempts
+	}
+	return 0
+}
+
+func (x *DeadLetterPolicy) GetRedeliveryBackoffMs() int64 {
+	if x != nil {
+		return x.RedeliveryBackoffMs
+	}
+	return 0
+}
+
+func (x *DeadLetterPolicy) GetMaxRedeliveryBackoffMs() int64 {
+	if x != nil {
+		return x.MaxRedeliveryBackoffMs
+	}
+	return 0
+}
+
+func (x *DeadLetterPolicy) GetDeadLetterTopic() *schema_pb.Topic {
+	if x != nil {
+		return x.DeadLetterTopic
+	}
+	return nil
+}
+
 type SubscribeMessageResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Message:
@@ -2355,7 +2465,7 @@ type SubscribeMessageResponse struct {
 
 func (x *SubscribeMessageResponse) Reset() {
 	*x = SubscribeMessageResponse{}
-	mi := &file_mq_broker_proto_msgTypes[37]
+	mi := &file_mq_broker_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2367,7 +2477,7 @@ func (x *SubscribeMessageResponse) String() string {
 func (*SubscribeMessageResponse) ProtoMessage() {}
 
 func (x *SubscribeMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[37]
+	mi := &file_mq_broker_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2380,7 +2490,7 @@ func (x *SubscribeMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeMessageResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{37}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SubscribeMessageResponse) GetMessage() isSubscribeMessageResponse_Message {
@@ -2438,7 +2548,7 @@ type SubscribeFollowMeRequest struct {
 
 func (x *SubscribeFollowMeRequest) Reset() {
 	*x = SubscribeFollowMeRequest{}
-	mi := &file_mq_broker_proto_msgTypes[38]
+	mi := &file_mq_broker_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2450,7 +2560,7 @@ func (x *SubscribeFollowMeRequest) String() string {
 func (*SubscribeFollowMeRequest) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[38]
+	mi := &file_mq_broker_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2463,7 +2573,7 @@ func (x *SubscribeFollowMeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeFollowMeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{38}
+	return file_mq_broker_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SubscribeFollowMeRequest) GetMessage() isSubscribeFollowMeRequest_Message {
@@ -2531,7 +2641,7 @@ type SubscribeFollowMeResponse struct {
 
 func (x *SubscribeFollowMeResponse) Reset() {
 	*x = SubscribeFollowMeResponse{}
-	mi := &file_mq_broker_proto_msgTypes[39]
+	mi := &file_mq_broker_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2543,7 +2653,7 @@ func (x *SubscribeFollowMeResponse) String() string {
 func (*SubscribeFollowMeResponse) ProtoMessage() {}
 
 func (x *SubscribeFollowMeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[39]
+	mi := &file_mq_broker_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2556,7 +2666,7 @@ func (x *SubscribeFollowMeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeFollowMeResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{39}
+	return file_mq_broker_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *SubscribeFollowMeResponse) GetAckTsNs() int64 {
@@ -2592,7 +2702,7 @@ type FetchMessageRequest struct {
 
 func (x *FetchMessageRequest) Reset() {
 	*x = FetchMessageRequest{}
-	mi := &file_mq_broker_proto_msgTypes[40]
+	mi := &file_mq_broker_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2604,7 +2714,7 @@ func (x *FetchMessageRequest) String() string {
 func (*FetchMessageRequest) ProtoMessage() {}
 
 func (x *FetchMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[40]
+	mi := &file_mq_broker_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2617,7 +2727,7 @@ func (x *FetchMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchMessageRequest.ProtoReflect.Descriptor instead.
 func (*FetchMessageRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{40}
+	return file_mq_broker_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *FetchMessageRequest) GetTopic() *schema_pb.Topic {
@@ -2703,7 +2813,7 @@ type FetchMessageResponse struct {
 
 func (x *FetchMessageResponse) Reset() {
 	*x = FetchMessageResponse{}
-	mi := &file_mq_broker_proto_msgTypes[41]
+	mi := &file_mq_broker_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2715,7 +2825,7 @@ func (x *FetchMessageResponse) String() string {
 func (*FetchMessageResponse) ProtoMessage() {}
 
 func (x *FetchMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[41]
+	mi := &file_mq_broker_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2728,7 +2838,7 @@ func (x *FetchMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FetchMessageResponse.ProtoReflect.Descriptor instead.
 func (*FetchMessageResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{41}
+	return file_mq_broker_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *FetchMessageResponse) GetMessages() []*DataMessage {
@@ -2790,7 +2900,7 @@ type ClosePublishersRequest struct {
 
 func (x *ClosePublishersRequest) Reset() {
 	*x = ClosePublishersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[42]
+	mi := &file_mq_broker_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2802,7 +2912,7 @@ func (x *ClosePublishersRequest) String() string {
 func (*ClosePublishersRequest) ProtoMessage() {}
 
 func (x *ClosePublishersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[42]
+	mi := &file_mq_broker_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2815,7 +2925,7 @@ func (x *ClosePublishersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePublishersRequest.ProtoReflect.Descriptor instead.
 func (*ClosePublishersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{42}
+	return file_mq_broker_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ClosePublishersRequest) GetTopic() *schema_pb.Topic {
@@ -2840,7 +2950,7 @@ type ClosePublishersResponse struct {
 
 func (x *ClosePublishersResponse) Reset() {
 	*x = ClosePublishersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[43]
+	mi := &file_mq_broker_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2852,7 +2962,7 @@ func (x *ClosePublishersResponse) String() string {
 func (*ClosePublishersResponse) ProtoMessage() {}
 
 func (x *ClosePublishersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[43]
+	mi := &file_mq_broker_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2865,7 +2975,7 @@ func (x *ClosePublishersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClosePublishersResponse.ProtoReflect.Descriptor instead.
 func (*ClosePublishersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{43}
+	return file_mq_broker_proto_rawDescGZIP(), []int{44}
 }
 
 type CloseSubscribersRequest struct {
@@ -2878,7 +2988,7 @@ type CloseSubscribersRequest struct {
 
 func (x *CloseSubscribersRequest) Reset() {
 	*x = CloseSubscribersRequest{}
-	mi := &file_mq_broker_proto_msgTypes[44]
+	mi := &file_mq_broker_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2890,7 +3000,7 @@ func (x *CloseSubscribersRequest) String() string {
 func (*CloseSubscribersRequest) ProtoMessage() {}
 
 func (x *CloseSubscribersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[44]
+	mi := &file_mq_broker_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2903,7 +3013,7 @@ func (x *CloseSubscribersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseSubscribersRequest.ProtoReflect.Descriptor instead.
 func (*CloseSubscribersRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{44}
+	return file_mq_broker_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *CloseSubscribersRequest) GetTopic() *schema_pb.Topic {
@@ -2928,7 +3038,7 @@ type CloseSubscribersResponse struct {
 
 func (x *CloseSubscribersResponse) Reset() {
 	*x = CloseSubscribersResponse{}
-	mi := &file_mq_broker_proto_msgTypes[45]
+	mi := &file_mq_broker_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2940,7 +3050,7 @@ func (x *CloseSubscribersResponse) String() string {
 func (*CloseSubscribersResponse) ProtoMessage() {}
 
 func (x *CloseSubscribersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[45]
+	mi := &file_mq_broker_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2953,7 +3063,7 @@ func (x *CloseSubscribersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseSubscribersResponse.ProtoReflect.Descriptor instead.
 func (*CloseSubscribersResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{45}
+	return file_mq_broker_proto_rawDescGZIP(), []int{46}
 }
 
 type GetUnflushedMessagesRequest struct {
@@ -2967,7 +3077,7 @@ type GetUnflushedMessagesRequest struct {
 
 func (x *GetUnflushedMessagesRequest) Reset() {
 	*x = GetUnflushedMessagesRequest{}
-	mi := &file_mq_broker_proto_msgTypes[46]
+	mi := &file_mq_broker_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2979,7 +3089,7 @@ func (x *GetUnflushedMessagesRequest) String() string {
 func (*GetUnflushedMessagesRequest) ProtoMessage() {}
 
 func (x *GetUnflushedMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[46]
+	mi := &file_mq_broker_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2992,7 +3102,7 @@ func (x *GetUnflushedMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUnflushedMessagesRequest.ProtoReflect.Descriptor instead.
 func (*GetUnflushedMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{46}
+	return file_mq_broker_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *GetUnflushedMessagesRequest) GetTopic() *schema_pb.Topic {
@@ -3027,7 +3137,7 @@ type GetUnflushedMessagesResponse struct {
 
 func (x *GetUnflushedMessagesResponse) Reset() {
 	*x = GetUnflushedMessagesResponse{}
-	mi := &file_mq_broker_proto_msgTypes[47]
+	mi := &file_mq_broker_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3039,7 +3149,7 @@ func (x *GetUnflushedMessagesResponse) String() string {
 func (*GetUnflushedMessagesResponse) ProtoMessage() {}
 
 func (x *GetUnflushedMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[47]
+	mi := &file_mq_broker_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3052,7 +3162,7 @@ func (x *GetUnflushedMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUnflushedMessagesResponse.ProtoReflect.Descriptor instead.
 func (*GetUnflushedMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{47}
+	return file_mq_broker_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *GetUnflushedMessagesResponse) GetMessage() *filer_pb.LogEntry {
@@ -3086,7 +3196,7 @@ type GetPartitionRangeInfoRequest struct {
 
 func (x *GetPartitionRangeInfoRequest) Reset() {
 	*x = GetPartitionRangeInfoRequest{}
-	mi := &file_mq_broker_proto_msgTypes[48]
+	mi := &file_mq_broker_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3098,7 +3208,7 @@ func (x *GetPartitionRangeInfoRequest) String() string {
 func (*GetPartitionRangeInfoRequest) ProtoMessage() {}
 
 func (x *GetPartitionRangeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[48]
+	mi := &file_mq_broker_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3111,7 +3221,7 @@ func (x *GetPartitionRangeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPartitionRangeInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetPartitionRangeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{48}
+	return file_mq_broker_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *GetPartitionRangeInfoRequest) GetTopic() *schema_pb.Topic {
@@ -3144,7 +3254,7 @@ type GetPartitionRangeInfoResponse struct {
 
 func (x *GetPartitionRangeInfoResponse) Reset() {
 	*x = GetPartitionRangeInfoResponse{}
-	mi := &file_mq_broker_proto_msgTypes[49]
+	mi := &file_mq_broker_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3156,7 +3266,7 @@ func (x *GetPartitionRangeInfoResponse) String() string {
 func (*GetPartitionRangeInfoResponse) ProtoMessage() {}
 
 func (x *GetPartitionRangeInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[49]
+	mi := &file_mq_broker_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3169,7 +3279,7 @@ func (x *GetPartitionRangeInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPartitionRangeInfoResponse.ProtoReflect.Descriptor instead.
 func (*GetPartitionRangeInfoResponse) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{49}
+	return file_mq_broker_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *GetPartitionRangeInfoResponse) GetOffsetRange() *OffsetRangeInfo {
@@ -3218,7 +3328,7 @@ type OffsetRangeInfo struct {
 
 func (x *OffsetRangeInfo) Reset() {
 	*x = OffsetRangeInfo{}
-	mi := &file_mq_broker_proto_msgTypes[50]
+	mi := &file_mq_broker_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3230,7 +3340,7 @@ func (x *OffsetRangeInfo) String() string {
 func (*OffsetRangeInfo) ProtoMessage() {}
 
 func (x *OffsetRangeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[50]
+	mi := &file_mq_broker_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3243,7 +3353,7 @@ func (x *OffsetRangeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OffsetRangeInfo.ProtoReflect.Descriptor instead.
 func (*OffsetRangeInfo) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{50}
+	return file_mq_broker_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *OffsetRangeInfo) GetEarliestOffset() int64 {
@@ -3277,7 +3387,7 @@ type TimestampRangeInfo struct {
 
 func (x *TimestampRangeInfo) Reset() {
 	*x = TimestampRangeInfo{}
-	mi := &file_mq_broker_proto_msgTypes[51]
+	mi := &file_mq_broker_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3289,7 +3399,7 @@ func (x *TimestampRangeInfo) String() string {
 func (*TimestampRangeInfo) ProtoMessage() {}
 
 func (x *TimestampRangeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[51]
+	mi := &file_mq_broker_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3302,7 +3412,7 @@ func (x *TimestampRangeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimestampRangeInfo.ProtoReflect.Descriptor instead.
 func (*TimestampRangeInfo) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{51}
+	return file_mq_broker_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *TimestampRangeInfo) GetEarliestTimestampNs() int64 {
@@ -3319,6 +3429,110 @@ func (x *TimestampRangeInfo) GetLatestTimestampNs() int64 {
 	return 0
 }
 
+type CancelDelayedMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Topic         *schema_pb.Topic       `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelDelayedMessageRequest) Reset() {
+	*x = CancelDelayedMessageRequest{}
+	mi := &file_mq_broker_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDelayedMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDelayedMessageRequest) ProtoMessage() {}
+
+func (x *CancelDelayedMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mq_broker_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDelayedMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelDelayedMessageRequest) Descriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CancelDelayedMessageRequest) GetTopic() *schema_pb.Topic {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *CancelDelayedMessageRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type CancelDelayedMessageResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CancelledCount int64                  `protobuf:"varint,1,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"`
+	Error          string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelDelayedMessageResponse) Reset() {
+	*x = CancelDelayedMessageResponse{}
+	mi := &file_mq_broker_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDelayedMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDelayedMessageResponse) ProtoMessage() {}
+
+func (x *CancelDelayedMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mq_broker_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDelayedMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelDelayedMessageResponse) Descriptor() ([]byte, []int) {
+	return file_mq_broker_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CancelDelayedMessageResponse) GetCancelledCount() int64 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+func (x *CancelDelayedMessageResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type PublisherToPubBalancerRequest_InitMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Broker        string                 `protobuf:"bytes,1,opt,name=broker,proto3" json:"broker,omitempty"`
@@ -3328,7 +3542,7 @@ type PublisherToPubBalancerRequest_InitMessage struct {
 
 func (x *PublisherToPubBalancerRequest_InitMessage) Reset() {
 	*x = PublisherToPubBalancerRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[53]
+	mi := &file_mq_broker_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3340,7 +3554,7 @@ func (x *PublisherToPubBalancerRequest_InitMessage) String() string {
 func (*PublisherToPubBalancerRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublisherToPubBalancerRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[53]
+	mi := &file_mq_broker_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3384,7 +3598,7 @@ type SubscriberToSubCoordinatorRequest_InitMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_InitMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[54]
+	mi := &file_mq_broker_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3396,7 +3610,7 @@ func (x *SubscriberToSubCoordinatorRequest_InitMessage) String() string {
 func (*SubscriberToSubCoordinatorRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[54]
+	mi := &file_mq_broker_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3456,7 +3670,7 @@ type SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage{}
-	mi := &file_mq_broker_proto_msgTypes[55]
+	mi := &file_mq_broker_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3468,7 +3682,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) String() stri
 func (*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[55]
+	mi := &file_mq_broker_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3500,7 +3714,7 @@ type SubscriberToSubCoordinatorRequest_AckAssignmentMessage struct {
 
 func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) Reset() {
 	*x = SubscriberToSubCoordinatorRequest_AckAssignmentMessage{}
-	mi := &file_mq_broker_proto_msgTypes[56]
+	mi := &file_mq_broker_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3512,7 +3726,7 @@ func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) String() string
 func (*SubscriberToSubCoordinatorRequest_AckAssignmentMessage) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorRequest_AckAssignmentMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[56]
+	mi := &file_mq_broker_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3544,7 +3758,7 @@ type SubscriberToSubCoordinatorResponse_Assignment struct {
 
 func (x *SubscriberToSubCoordinatorResponse_Assignment) Reset() {
 	*x = SubscriberToSubCoordinatorResponse_Assignment{}
-	mi := &file_mq_broker_proto_msgTypes[57]
+	mi := &file_mq_broker_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3556,7 +3770,7 @@ func (x *SubscriberToSubCoordinatorResponse_Assignment) String() string {
 func (*SubscriberToSubCoordinatorResponse_Assignment) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorResponse_Assignment) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[57]
+	mi := &file_mq_broker_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3588,7 +3802,7 @@ type SubscriberToSubCoordinatorResponse_UnAssignment struct {
 
 func (x *SubscriberToSubCoordinatorResponse_UnAssignment) Reset() {
 	*x = SubscriberToSubCoordinatorResponse_UnAssignment{}
-	mi := &file_mq_broker_proto_msgTypes[58]
+	mi := &file_mq_broker_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3600,7 +3814,7 @@ func (x *SubscriberToSubCoordinatorResponse_UnAssignment) String() string {
 func (*SubscriberToSubCoordinatorResponse_UnAssignment) ProtoMessage() {}
 
 func (x *SubscriberToSubCoordinatorResponse_UnAssignment) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[58]
+	mi := &file_mq_broker_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3636,7 +3850,7 @@ type PublishMessageRequest_InitMessage struct {
 
 func (x *PublishMessageRequest_InitMessage) Reset() {
 	*x = PublishMessageRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[59]
+	mi := &file_mq_broker_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3648,7 +3862,7 @@ func (x *PublishMessageRequest_InitMessage) String() string {
 func (*PublishMessageRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublishMessageRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[59]
+	mi := &file_mq_broker_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3709,7 +3923,7 @@ type PublishFollowMeRequest_InitMessage struct {
 
 func (x *PublishFollowMeRequest_InitMessage) Reset() {
 	*x = PublishFollowMeRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[60]
+	mi := &file_mq_broker_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3721,7 +3935,7 @@ func (x *PublishFollowMeRequest_InitMessage) String() string {
 func (*PublishFollowMeRequest_InitMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[60]
+	mi := &file_mq_broker_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3760,7 +3974,7 @@ type PublishFollowMeRequest_FlushMessage struct {
 
 func (x *PublishFollowMeRequest_FlushMessage) Reset() {
 	*x = PublishFollowMeRequest_FlushMessage{}
-	mi := &file_mq_broker_proto_msgTypes[61]
+	mi := &file_mq_broker_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3772,7 +3986,7 @@ func (x *PublishFollowMeRequest_FlushMessage) String() string {
 func (*PublishFollowMeRequest_FlushMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_FlushMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[61]
+	mi := &file_mq_broker_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3803,7 +4017,7 @@ type PublishFollowMeRequest_CloseMessage struct {
 
 func (x *PublishFollowMeRequest_CloseMessage) Reset() {
 	*x = PublishFollowMeRequest_CloseMessage{}
-	mi := &file_mq_broker_proto_msgTypes[62]
+	mi := &file_mq_broker_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3815,7 +4029,7 @@ func (x *PublishFollowMeRequest_CloseMessage) String() string {
 func (*PublishFollowMeRequest_CloseMessage) ProtoMessage() {}
 
 func (x *PublishFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[62]
+	mi := &file_mq_broker_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3842,13 +4056,14 @@ type SubscribeMessageRequest_InitMessage struct {
 	Filter            string                     `protobuf:"bytes,10,opt,name=filter,proto3" json:"filter,omitempty"`
 	FollowerBroker    string                     `protobuf:"bytes,11,opt,name=follower_broker,json=followerBroker,proto3" json:"follower_broker,omitempty"`
 	SlidingWindowSize int32                      `protobuf:"varint,12,opt,name=sliding_window_size,json=slidingWindowSize,proto3" json:"sliding_window_size,omitempty"`
+	DeadLetterPolicy  *DeadLetterPolicy          `protobuf:"bytes,13,opt,name=dead_letter_policy,json=deadLetterPolicy,proto3" json:"dead_letter_policy,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *SubscribeMessageRequest_InitMessage) Reset() {
 	*x = SubscribeMessageRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[63]
+	mi := &file_mq_broker_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3860,7 +4075,7 @@ func (x *SubscribeMessageRequest_InitMessage) String() string {
 func (*SubscribeMessageRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[63]
+	mi := &file_mq_broker_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3939,17 +4154,26 @@ func (x *SubscribeMessageRequest_InitMessage) GetSlidingWindowSize() int32 {
 	return 0
 }
 
+func (x *SubscribeMessageRequest_InitMessage) GetDeadLetterPolicy() *DeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetterPolicy
+	}
+	return nil
+}
+
 type SubscribeMessageRequest_AckMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TsNs          int64                  `protobuf:"varint,1,opt,name=ts_ns,json=tsNs,proto3" json:"ts_ns,omitempty"` // Timestamp in nanoseconds for acknowledgment tracking
 	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	IsNack        bool                   `protobuf:"varint,3,opt,name=is_nack,json=isNack,proto3" json:"is_nack,omitempty"` // the message failed processing, and should be redelivered
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                  // the processing failure of a nack
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SubscribeMessageRequest_AckMessage) Reset() {
 	*x = SubscribeMessageRequest_AckMessage{}
-	mi := &file_mq_broker_proto_msgTypes[64]
+	mi := &file_mq_broker_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3961,7 +4185,7 @@ func (x *SubscribeMessageRequest_AckMessage) String() string {
 func (*SubscribeMessageRequest_AckMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest_AckMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[64]
+	mi := &file_mq_broker_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3991,6 +4215,20 @@ func (x *SubscribeMessageRequest_AckMessage) GetKey() []byte {
 	return nil
 }
 
+func (x *SubscribeMessageRequest_AckMessage) GetIsNack() bool {
+	if x != nil {
+		return x.IsNack
+	}
+	return false
+}
+
+func (x *SubscribeMessageRequest_AckMessage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type SubscribeMessageRequest_SeekMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`                                                     // New offset to seek to
@@ -4001,7 +4239,7 @@ type SubscribeMessageRequest_SeekMessage struct {
 
 func (x *SubscribeMessageRequest_SeekMessage) Reset() {
 	*x = SubscribeMessageRequest_SeekMessage{}
-	mi := &file_mq_broker_proto_msgTypes[65]
+	mi := &file_mq_broker_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4013,7 +4251,7 @@ func (x *SubscribeMessageRequest_SeekMessage) String() string {
 func (*SubscribeMessageRequest_SeekMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageRequest_SeekMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[65]
+	mi := &file_mq_broker_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4054,7 +4292,7 @@ type SubscribeMessageResponse_SubscribeCtrlMessage struct {
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) Reset() {
 	*x = SubscribeMessageResponse_SubscribeCtrlMessage{}
-	mi := &file_mq_broker_proto_msgTypes[66]
+	mi := &file_mq_broker_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4066,7 +4304,7 @@ func (x *SubscribeMessageResponse_SubscribeCtrlMessage) String() string {
 func (*SubscribeMessageResponse_SubscribeCtrlMessage) ProtoMessage() {}
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[66]
+	mi := &file_mq_broker_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4079,7 +4317,7 @@ func (x *SubscribeMessageResponse_SubscribeCtrlMessage) ProtoReflect() protorefl
 
 // Deprecated: Use SubscribeMessageResponse_SubscribeCtrlMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeMessageResponse_SubscribeCtrlMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{37, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{38, 0}
 }
 
 func (x *SubscribeMessageResponse_SubscribeCtrlMessage) GetError() string {
@@ -4114,7 +4352,7 @@ type SubscribeFollowMeRequest_InitMessage struct {
 
 func (x *SubscribeFollowMeRequest_InitMessage) Reset() {
 	*x = SubscribeFollowMeRequest_InitMessage{}
-	mi := &file_mq_broker_proto_msgTypes[67]
+	mi := &file_mq_broker_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4126,7 +4364,7 @@ func (x *SubscribeFollowMeRequest_InitMessage) String() string {
 func (*SubscribeFollowMeRequest_InitMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[67]
+	mi := &file_mq_broker_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4139,7 +4377,7 @@ func (x *SubscribeFollowMeRequest_InitMessage) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use SubscribeFollowMeRequest_InitMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_InitMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{38, 0}
+	return file_mq_broker_proto_rawDescGZIP(), []int{39, 0}
 }
 
 func (x *SubscribeFollowMeRequest_InitMessage) GetTopic() *schema_pb.Topic {
@@ -4172,7 +4410,7 @@ type SubscribeFollowMeRequest_AckMessage struct {
 
 func (x *SubscribeFollowMeRequest_AckMessage) Reset() {
 	*x = SubscribeFollowMeRequest_AckMessage{}
-	mi := &file_mq_broker_proto_msgTypes[68]
+	mi := &file_mq_broker_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4184,7 +4422,7 @@ func (x *SubscribeFollowMeRequest_AckMessage) String() string {
 func (*SubscribeFollowMeRequest_AckMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_AckMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[68]
+	mi := &file_mq_broker_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4197,7 +4435,7 @@ func (x *SubscribeFollowMeRequest_AckMessage) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use SubscribeFollowMeRequest_AckMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_AckMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{38, 1}
+	return file_mq_broker_proto_rawDescGZIP(), []int{39, 1}
 }
 
 func (x *SubscribeFollowMeRequest_AckMessage) GetTsNs() int64 {
@@ -4215,7 +4453,7 @@ type SubscribeFollowMeRequest_CloseMessage struct {
 
 func (x *SubscribeFollowMeRequest_CloseMessage) Reset() {
 	*x = SubscribeFollowMeRequest_CloseMessage{}
-	mi := &file_mq_broker_proto_msgTypes[69]
+	mi := &file_mq_broker_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4227,7 +4465,7 @@ func (x *SubscribeFollowMeRequest_CloseMessage) String() string {
 func (*SubscribeFollowMeRequest_CloseMessage) ProtoMessage() {}
 
 func (x *SubscribeFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_mq_broker_proto_msgTypes[69]
+	mi := &file_mq_broker_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4240,7 +4478,7 @@ func (x *SubscribeFollowMeRequest_CloseMessage) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use SubscribeFollowMeRequest_CloseMessage.ProtoReflect.Descriptor instead.
 func (*SubscribeFollowMeRequest_CloseMessage) Descriptor() ([]byte, []int) {
-	return file_mq_broker_proto_rawDescGZIP(), []int{38, 2}
+	return file_mq_broker_proto_rawDescGZIP(), []int{39, 2}
 }
 
 var File_mq_broker_proto protoreflect.FileDescriptor
@@ -4274,10 +4512,13 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\amessage\" \n" +
 	"\x1ePublisherToPubBalancerResponse\"\x16\n" +
 	"\x14BalanceTopicsRequest\"\x17\n" +
-	"\x15BalanceTopicsResponse\"W\n" +
+	"\x15BalanceTopicsResponse\"\xf5\x01\n" +
 	"\x0eTopicRetention\x12+\n" +
 	"\x11retention_seconds\x18\x01 \x01(\x03R\x10retentionSeconds\x12\x18\n" +
-	"\aenabled\x18\x02 \x01(\bR\aenabled\"\xb1\x02\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12%\n" +
+	"\x0ecleanup_policy\x18\x03 \x01(\tR\rcleanupPolicy\x128\n" +
+	"\x18delete_retention_seconds\x18\x04 \x01(\x03R\x16deleteRetentionSeconds\x12;\n" +
+	"\x1amin_compaction_lag_seconds\x18\x05 \x01(\x03R\x17minCompactionLagSeconds\"\xb1\x02\n" +
 	"\x15ConfigureTopicRequest\x12&\n" +
 	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\x12'\n" +
 	"\x0fpartition_count\x18\x02 \x01(\x05R\x0epartitionCount\x12:\n" +
@@ -4390,12 +4631,14 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\amessage\"R\n" +
 	"\x0eControlMessage\x12\x19\n" +
 	"\bis_close\x18\x01 \x01(\bR\aisClose\x12%\n" +
-	"\x0epublisher_name\x18\x02 \x01(\tR\rpublisherName\"|\n" +
+	"\x0epublisher_name\x18\x02 \x01(\tR\rpublisherName\"\xcb\x01\n" +
 	"\vDataMessage\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\fR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05value\x12\x13\n" +
 	"\x05ts_ns\x18\x03 \x01(\x03R\x04tsNs\x120\n" +
-	"\x04ctrl\x18\x04 \x01(\v2\x1c.messaging_pb.ControlMessageR\x04ctrl\"\xf9\x02\n" +
+	"\x04ctrl\x18\x04 \x01(\v2\x1c.messaging_pb.ControlMessageR\x04ctrl\x12)\n" +
+	"\x10delivery_attempt\x18\x05 \x01(\x05R\x0fdeliveryAttempt\x12\"\n" +
+	"\rdeliver_at_ns\x18\x06 \x01(\x03R\vdeliverAtNs\"\xf9\x02\n" +
 	"\x15PublishMessageRequest\x12E\n" +
 	"\x04init\x18\x01 \x01(\v2/.messaging_pb.PublishMessageRequest.InitMessageH\x00R\x04init\x12/\n" +
 	"\x04data\x18\x02 \x01(\v2\x19.messaging_pb.DataMessageH\x00R\x04data\x1a\xdc\x01\n" +
@@ -4426,11 +4669,11 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\fCloseMessageB\t\n" +
 	"\amessage\"5\n" +
 	"\x17PublishFollowMeResponse\x12\x1a\n" +
-	"\tack_ts_ns\x18\x01 \x01(\x03R\aackTsNs\"\x9d\x06\n" +
+	"\tack_ts_ns\x18\x01 \x01(\x03R\aackTsNs\"\x9a\a\n" +
 	"\x17SubscribeMessageRequest\x12G\n" +
 	"\x04init\x18\x01 \x01(\v21.messaging_pb.SubscribeMessageRequest.InitMessageH\x00R\x04init\x12D\n" +
 	"\x03ack\x18\x02 \x01(\v20.messaging_pb.SubscribeMessageRequest.AckMessageH\x00R\x03ack\x12G\n" +
-	"\x04seek\x18\x03 \x01(\v21.messaging_pb.SubscribeMessageRequest.SeekMessageH\x00R\x04seek\x1a\x8a\x03\n" +
+	"\x04seek\x18\x03 \x01(\v21.messaging_pb.SubscribeMessageRequest.SeekMessageH\x00R\x04seek\x1a\xd8\x03\n" +
 	"\vInitMessage\x12%\n" +
 	"\x0econsumer_group\x18\x01 \x01(\tR\rconsumerGroup\x12\x1f\n" +
 	"\vconsumer_id\x18\x02 \x01(\tR\n" +
@@ -4443,16 +4686,24 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\x06filter\x18\n" +
 	" \x01(\tR\x06filter\x12'\n" +
 	"\x0ffollower_broker\x18\v \x01(\tR\x0efollowerBroker\x12.\n" +
-	"\x13sliding_window_size\x18\f \x01(\x05R\x11slidingWindowSize\x1a3\n" +
+	"\x13sliding_window_size\x18\f \x01(\x05R\x11slidingWindowSize\x12L\n" +
+	"\x12dead_letter_policy\x18\r \x01(\v2\x1e.messaging_pb.DeadLetterPolicyR\x10deadLetterPolicy\x1ab\n" +
 	"\n" +
 	"AckMessage\x12\x13\n" +
 	"\x05ts_ns\x18\x01 \x01(\x03R\x04tsNs\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\fR\x03key\x1a]\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12\x17\n" +
+	"\ais_nack\x18\x03 \x01(\bR\x06isNack\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x1a]\n" +
 	"\vSeekMessage\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x126\n" +
 	"\voffset_type\x18\x02 \x01(\x0e2\x15.schema_pb.OffsetTypeR\n" +
 	"offsetTypeB\t\n" +
-	"\amessage\"\xa7\x02\n" +
+	"\amessage\"\xf3\x01\n" +
+	"\x10DeadLetterPolicy\x122\n" +
+	"\x15max_delivery_attempts\x18\x01 \x01(\x05R\x13maxDeliveryAttempts\x122\n" +
+	"\x15redelivery_backoff_ms\x18\x02 \x01(\x03R\x13redeliveryBackoffMs\x129\n" +
+	"\x19max_redelivery_backoff_ms\x18\x03 \x01(\x03R\x16maxRedeliveryBackoffMs\x12<\n" +
+	"\x11dead_letter_topic\x18\x04 \x01(\v2\x10.schema_pb.TopicR\x0fdeadLetterTopic\"\xa7\x02\n" +
 	"\x18SubscribeMessageResponse\x12Q\n" +
 	"\x04ctrl\x18\x01 \x01(\v2;.messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessageH\x00R\x04ctrl\x12/\n" +
 	"\x04data\x18\x02 \x01(\v2\x19.messaging_pb.DataMessageH\x00R\x04data\x1a|\n" +
@@ -4531,7 +4782,13 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\x0fhigh_water_mark\x18\x03 \x01(\x03R\rhighWaterMark\"x\n" +
 	"\x12TimestampRangeInfo\x122\n" +
 	"\x15earliest_timestamp_ns\x18\x01 \x01(\x03R\x13earliestTimestampNs\x12.\n" +
-	"\x13latest_timestamp_ns\x18\x02 \x01(\x03R\x11latestTimestampNs2\xad\x11\n" +
+	"\x13latest_timestamp_ns\x18\x02 \x01(\x03R\x11latestTimestampNs\"W\n" +
+	"\x1bCancelDelayedMessageRequest\x12&\n" +
+	"\x05topic\x18\x01 \x01(\v2\x10.schema_pb.TopicR\x05topic\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\"]\n" +
+	"\x1cCancelDelayedMessageResponse\x12'\n" +
+	"\x0fcancelled_count\x18\x01 \x01(\x03R\x0ecancelledCount\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\x9e\x12\n" +
 	"\x10SeaweedMessaging\x12c\n" +
 	"\x10FindBrokerLeader\x12%.messaging_pb.FindBrokerLeaderRequest\x1a&.messaging_pb.FindBrokerLeaderResponse\"\x00\x12y\n" +
 	"\x16PublisherToPubBalancer\x12+.messaging_pb.PublisherToPubBalancerRequest\x1a,.messaging_pb.PublisherToPubBalancerResponse\"\x00(\x010\x01\x12Z\n" +
@@ -4554,7 +4811,8 @@ const file_mq_broker_proto_rawDesc = "" +
 	"\x11SubscribeFollowMe\x12&.messaging_pb.SubscribeFollowMeRequest\x1a'.messaging_pb.SubscribeFollowMeResponse\"\x00(\x01\x12W\n" +
 	"\fFetchMessage\x12!.messaging_pb.FetchMessageRequest\x1a\".messaging_pb.FetchMessageResponse\"\x00\x12q\n" +
 	"\x14GetUnflushedMessages\x12).messaging_pb.GetUnflushedMessagesRequest\x1a*.messaging_pb.GetUnflushedMessagesResponse\"\x000\x01\x12r\n" +
-	"\x15GetPartitionRangeInfo\x12*.messaging_pb.GetPartitionRangeInfoRequest\x1a+.messaging_pb.GetPartitionRangeInfoResponse\"\x00BO\n" +
+	"\x15GetPartitionRangeInfo\x12*.messaging_pb.GetPartitionRangeInfoRequest\x1a+.messaging_pb.GetPartitionRangeInfoResponse\"\x00\x12o\n" +
+	"\x14CancelDelayedMessage\x12).messaging_pb.CancelDelayedMessageRequest\x1a*.messaging_pb.CancelDelayedMessageResponse\"\x00BO\n" +
 	"\fseaweedfs.mqB\x11MessageQueueProtoZ,github.com/seaweedfs/seaweedfs/weed/pb/mq_pbb\x06proto3"
 
 var (
@@ -4569,7 +4827,7 @@ func file_mq_broker_proto_rawDescGZIP() []byte {
 	return file_mq_broker_proto_rawDescData
 }
 
-var file_mq_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_mq_broker_proto_msgTypes = make([]protoimpl.MessageInfo, 73)
 var file_mq_broker_proto_goTypes = []any{
 	(*FindBrokerLeaderRequest)(nil),                                  // 0: messaging_pb.FindBrokerLeaderRequest
 	(*FindBrokerLeaderResponse)(nil),                                 // 1: messaging_pb.FindBrokerLeaderResponse
@@ -4608,172 +4866,180 @@ var file_mq_broker_proto_goTypes = []any{
 	(*PublishFollowMeRequest)(nil),                                   // 34: messaging_pb.PublishFollowMeRequest
 	(*PublishFollowMeResponse)(nil),                                  // 35: messaging_pb.PublishFollowMeResponse
 	(*SubscribeMessageRequest)(nil),                                  // 36: messaging_pb.SubscribeMessageRequest
-	(*SubscribeMessageResponse)(nil),                                 // 37: messaging_pb.SubscribeMessageResponse
-	(*SubscribeFollowMeRequest)(nil),                                 // 38: messaging_pb.SubscribeFollowMeRequest
-	(*SubscribeFollowMeResponse)(nil),                                // 39: messaging_pb.SubscribeFollowMeResponse
-	(*FetchMessageRequest)(nil),                                      // 40: messaging_pb.FetchMessageRequest
-	(*FetchMessageResponse)(nil),                                     // 41: messaging_pb.FetchMessageResponse
-	(*ClosePublishersRequest)(nil),                                   // 42: messaging_pb.ClosePublishersRequest
-	(*ClosePublishersResponse)(nil),                                  // 43: messaging_pb.ClosePublishersResponse
-	(*CloseSubscribersRequest)(nil),                                  // 44: messaging_pb.CloseSubscribersRequest
-	(*CloseSubscribersResponse)(nil),                                 // 45: messaging_pb.CloseSubscribersResponse
-	(*GetUnflushedMessagesRequest)(nil),                              // 46: messaging_pb.GetUnflushedMessagesRequest
-	(*GetUnflushedMessagesResponse)(nil),                             // 47: messaging_pb.GetUnflushedMessagesResponse
-	(*GetPartitionRangeInfoRequest)(nil),                             // 48: messaging_pb.GetPartitionRangeInfoRequest
-	(*GetPartitionRangeInfoResponse)(nil),                            // 49: messaging_pb.GetPartitionRangeInfoResponse
-	(*OffsetRangeInfo)(nil),                                          // 50: messaging_pb.OffsetRangeInfo
-	(*TimestampRangeInfo)(nil),                                       // 51: messaging_pb.TimestampRangeInfo
-	nil,                                                              // 52: messaging_pb.BrokerStats.StatsEntry
-	(*PublisherToPubBalancerRequest_InitMessage)(nil),                // 53: messaging_pb.PublisherToPubBalancerRequest.InitMessage
-	(*SubscriberToSubCoordinatorRequest_InitMessage)(nil),            // 54: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
-	(*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage)(nil), // 55: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
-	(*SubscriberToSubCoordinatorRequest_AckAssignmentMessage)(nil),   // 56: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
-	(*SubscriberToSubCoordinatorResponse_Assignment)(nil),            // 57: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
-	(*SubscriberToSubCoordinatorResponse_UnAssignment)(nil),          // 58: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
-	(*PublishMessageRequest_InitMessage)(nil),                        // 59: messaging_pb.PublishMessageRequest.InitMessage
-	(*PublishFollowMeRequest_InitMessage)(nil),                       // 60: messaging_pb.PublishFollowMeRequest.InitMessage
-	(*PublishFollowMeRequest_FlushMessage)(nil),                      // 61: messaging_pb.PublishFollowMeRequest.FlushMessage
-	(*PublishFollowMeRequest_CloseMessage)(nil),                      // 62: messaging_pb.PublishFollowMeRequest.CloseMessage
-	(*SubscribeMessageRequest_InitMessage)(nil),                      // 63: messaging_pb.SubscribeMessageRequest.InitMessage
-	(*SubscribeMessageRequest_AckMessage)(nil),                       // 64: messaging_pb.SubscribeMessageRequest.AckMessage
-	(*SubscribeMessageRequest_SeekMessage)(nil),                      // 65: messaging_pb.SubscribeMessageRequest.SeekMessage
-	(*SubscribeMessageResponse_SubscribeCtrlMessage)(nil),            // 66: messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
-	(*SubscribeFollowMeRequest_InitMessage)(nil),                     // 67: messaging_pb.SubscribeFollowMeRequest.InitMessage
-	(*SubscribeFollowMeRequest_AckMessage)(nil),                      // 68: messaging_pb.SubscribeFollowMeRequest.AckMessage
-	(*SubscribeFollowMeRequest_CloseMessage)(nil),                    // 69: messaging_pb.SubscribeFollowMeRequest.CloseMessage
-	(*schema_pb.Topic)(nil),                                          // 70: schema_pb.Topic
-	(*schema_pb.Partition)(nil),                                      // 71: schema_pb.Partition
-	(*schema_pb.RecordType)(nil),                                     // 72: schema_pb.RecordType
-	(*filer_pb.LogEntry)(nil),                                        // 73: filer_pb.LogEntry
-	(*schema_pb.PartitionOffset)(nil),                                // 74: schema_pb.PartitionOffset
-	(schema_pb.OffsetType)(0),                                        // 75: schema_pb.OffsetType
+	(*DeadLetterPolicy)(nil),                                         // 37: messaging_pb.DeadLetterPolicy
+	(*SubscribeMessageResponse)(nil),                                 // 38: messaging_pb.SubscribeMessageResponse
+	(*SubscribeFollowMeRequest)(nil),                                 // 39: messaging_pb.SubscribeFollowMeRequest
+	(*SubscribeFollowMeResponse)(nil),                                // 40: messaging_pb.SubscribeFollowMeResponse
+	(*FetchMessageRequest)(nil),                                      // 41: messaging_pb.FetchMessageRequest
+	(*FetchMessageResponse)(nil),                                     // 42: messaging_pb.FetchMessageResponse
+	(*ClosePublishersRequest)(nil),                                   // 43: messaging_pb.ClosePublishersRequest
+	(*ClosePublishersResponse)(nil),                                  // 44: messaging_pb.ClosePublishersResponse
+	(*CloseSubscribersRequest)(nil),                                  // 45: messaging_pb.CloseSubscribersRequest
+	(*CloseSubscribersResponse)(nil),                                 // 46: messaging_pb.CloseSubscribersResponse
+	(*GetUnflushedMessagesRequest)(nil),                              // 47: messaging_pb.GetUnflushedMessagesRequest
+	(*GetUnflushedMessagesResponse)(nil),                             // 48: messaging_pb.GetUnflushedMessagesResponse
+	(*GetPartitionRangeInfoRequest)(nil),                             // 49: messaging_pb.GetPartitionRangeInfoRequest
+	(*GetPartitionRangeInfoResponse)(nil),                            // 50: messaging_pb.GetPartitionRangeInfoResponse
+	(*OffsetRangeInfo)(nil),                                          // 51: messaging_pb.OffsetRangeInfo
+	(*TimestampRangeInfo)(nil),                                       // 52: messaging_pb.TimestampRangeInfo
+	(*CancelDelayedMessageRequest)(nil),                              // 53: messaging_pb.CancelDelayedMessageRequest
+	(*CancelDelayedMessageResponse)(nil),                             // 54: messaging_pb.CancelDelayedMessageResponse
+	nil,                                                              // 55: messaging_pb.BrokerStats.StatsEntry
+	(*PublisherToPubBalancerRequest_InitMessage)(nil),                // 56: messaging_pb.PublisherToPubBalancerRequest.InitMessage
+	(*SubscriberToSubCoordinatorRequest_InitMessage)(nil),            // 57: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
+	(*SubscriberToSubCoordinatorRequest_AckUnAssignmentMessage)(nil), // 58: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
+	(*SubscriberToSubCoordinatorRequest_AckAssignmentMessage)(nil),   // 59: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
+	(*SubscriberToSubCoordinatorResponse_Assignment)(nil),            // 60: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
+	(*SubscriberToSubCoordinatorResponse_UnAssignment)(nil),          // 61: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
+	(*PublishMessageRequest_InitMessage)(nil),                        // 62: messaging_pb.PublishMessageRequest.InitMessage
+	(*PublishFollowMeRequest_InitMessage)(nil),                       // 63: messaging_pb.PublishFollowMeRequest.InitMessage
+	(*PublishFollowMeRequest_FlushMessage)(nil),                      // 64: messaging_pb.PublishFollowMeRequest.FlushMessage
+	(*PublishFollowMeRequest_CloseMessage)(nil),                      // 65: messaging_pb.PublishFollowMeRequest.CloseMessage
+	(*SubscribeMessageRequest_InitMessage)(nil),                      // 66: messaging_pb.SubscribeMessageRequest.InitMessage
+	(*SubscribeMessageRequest_AckMessage)(nil),                       // 67: messaging_pb.SubscribeMessageRequest.AckMessage
+	(*SubscribeMessageRequest_SeekMessage)(nil),                      // 68: messaging_pb.SubscribeMessageRequest.SeekMessage
+	(*SubscribeMessageResponse_SubscribeCtrlMessage)(nil),            // 69: messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
+	(*SubscribeFollowMeRequest_InitMessage)(nil),                     // 70: messaging_pb.SubscribeFollowMeRequest.InitMessage
+	(*SubscribeFollowMeRequest_AckMessage)(nil),                      // 71: messaging_pb.SubscribeFollowMeRequest.AckMessage
+	(*SubscribeFollowMeRequest_CloseMessage)(nil),                    // 72: messaging_pb.SubscribeFollowMeRequest.CloseMessage
+	(*schema_pb.Topic)(nil),                                          // 73: schema_pb.Topic
+	(*schema_pb.Partition)(nil),                                      // 74: schema_pb.Partition
+	(*schema_pb.RecordType)(nil),                                     // 75: schema_pb.RecordType
+	(*filer_pb.LogEntry)(nil),                                        // 76: filer_pb.LogEntry
+	(*schema_pb.PartitionOffset)(nil),                                // 77: schema_pb.PartitionOffset
+	(schema_pb.OffsetType)(0),                                        // 78: schema_pb.OffsetType
 }
 var file_mq_broker_proto_depIdxs = []int32{
-	52, // 0: messaging_pb.BrokerStats.stats:type_name -> messaging_pb.BrokerStats.StatsEntry
-	70, // 1: messaging_pb.TopicPartitionStats.topic:type_name -> schema_pb.Topic
-	71, // 2: messaging_pb.TopicPartitionStats.partition:type_name -> schema_pb.Partition
-	53, // 3: messaging_pb.PublisherToPubBalancerRequest.init:type_name -> messaging_pb.PublisherToPubBalancerRequest.InitMessage
-	2,  // 4: messaging_pb.PublisherToPubBalancerRequest.stats:type_name -> messaging_pb.BrokerStats
-	70, // 5: messaging_pb.ConfigureTopicRequest.topic:type_name -> schema_pb.Topic
-	8,  // 6: messaging_pb.ConfigureTopicRequest.retention:type_name -> messaging_pb.TopicRetention
-	72, // 7: messaging_pb.ConfigureTopicRequest.message_record_type:type_name -> schema_pb.RecordType
-	17, // 8: messaging_pb.ConfigureTopicResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	8,  // 9: messaging_pb.ConfigureTopicResponse.retention:type_name -> messaging_pb.TopicRetention
-	72, // 10: messaging_pb.ConfigureTopicResponse.message_record_type:type_name -> schema_pb.RecordType
-	70, // 11: messaging_pb.ListTopicsResponse.topics:type_name -> schema_pb.Topic
-	70, // 12: messaging_pb.TopicExistsRequest.topic:type_name -> schema_pb.Topic
-	70, // 13: messaging_pb.LookupTopicBrokersRequest.topic:type_name -> schema_pb.Topic
-	70, // 14: messaging_pb.LookupTopicBrokersResponse.topic:type_name -> schema_pb.Topic
-	17, // 15: messaging_pb.LookupTopicBrokersResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	71, // 16: messaging_pb.BrokerPartitionAssignment.partition:type_name -> schema_pb.Partition
-	70, // 17: messaging_pb.GetTopicConfigurationRequest.topic:type_name -> schema_pb.Topic
-	70, // 18: messaging_pb.GetTopicConfigurationResponse.topic:type_name -> schema_pb.Topic
-	17, // 19: messaging_pb.GetTopicConfigurationResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	8,  // 20: messaging_pb.GetTopicConfigurationResponse.retention:type_name -> messaging_pb.TopicRetention
-	72, // 21: messaging_pb.GetTopicConfigurationResponse.message_record_type:type_name -> schema_pb.RecordType
-	70, // 22: messaging_pb.GetTopicPublishersRequest.topic:type_name -> schema_pb.Topic
-	24, // 23: messaging_pb.GetTopicPublishersResponse.publishers:type_name -> messaging_pb.TopicPublisher
-	70, // 24: messaging_pb.GetTopicSubscribersRequest.topic:type_name -> schema_pb.Topic
-	25, // 25: messaging_pb.GetTopicSubscribersResponse.subscribers:type_name -> messaging_pb.TopicSubscriber
-	71, // 26: messaging_pb.TopicPublisher.partition:type_name -> schema_pb.Partition
-	71, // 27: messaging_pb.TopicSubscriber.partition:type_name -> schema_pb.Partition
-	70, // 28: messaging_pb.AssignTopicPartitionsRequest.topic:type_name -> schema_pb.Topic
-	17, // 29: messaging_pb.AssignTopicPartitionsRequest.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
-	54, // 30: messaging_pb.SubscriberToSubCoordinatorRequest.init:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
-	56, // 31: messaging_pb.SubscriberToSubCoordinatorRequest.ack_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
-	55, // 32: messaging_pb.SubscriberToSubCoordinatorRequest.ack_un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
-	57, // 33: messaging_pb.SubscriberToSubCoordinatorResponse.assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
-	58, // 34: messaging_pb.SubscriberToSubCoordinatorResponse.un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
-	30, // 35: messaging_pb.DataMessage.ctrl:type_name -> messaging_pb.ControlMessage
-	59, // 36: messaging_pb.PublishMessageRequest.init:type_name -> messaging_pb.PublishMessageRequest.InitMessage
-	31, // 37: messaging_pb.PublishMessageRequest.data:type_name -> messaging_pb.DataMessage
-	60, // 38: messaging_pb.PublishFollowMeRequest.init:type_name -> messaging_pb.PublishFollowMeRequest.InitMessage
-	31, // 39: messaging_pb.PublishFollowMeRequest.data:type_name -> messaging_pb.DataMessage
-	61, // 40: messaging_pb.PublishFollowMeRequest.flush:type_name -> messaging_pb.PublishFollowMeRequest.FlushMessage
-	62, // 41: messaging_pb.PublishFollowMeRequest.close:type_name -> messaging_pb.PublishFollowMeRequest.CloseMessage
-	63, // 42: messaging_pb.SubscribeMessageRequest.init:type_name -> messaging_pb.SubscribeMessageRequest.InitMessage
-	64, // 43: messaging_pb.SubscribeMessageRequest.ack:type_name -> messaging_pb.SubscribeMessageRequest.AckMessage
-	65, // 44: messaging_pb.SubscribeMessageRequest.seek:type_name -> messaging_pb.SubscribeMessageRequest.SeekMessage
-	66, // 45: messaging_pb.SubscribeMessageResponse.ctrl:type_name -> messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
-	31, // 46: messaging_pb.SubscribeMessageResponse.data:type_name -> messaging_pb.DataMessage
-	67, // 47: messaging_pb.SubscribeFollowMeRequest.init:type_name -> messaging_pb.SubscribeFollowMeRequest.InitMessage
-	68, // 48: messaging_pb.SubscribeFollowMeRequest.ack:type_name -> messaging_pb.SubscribeFollowMeRequest.AckMessage
-	69, // 49: messaging_pb.SubscribeFollowMeRequest.close:type_name -> messaging_pb.SubscribeFollowMeRequest.CloseMessage
-	70, // 50: messaging_pb.FetchMessageRequest.topic:type_name -> schema_pb.Topic
-	71, // 51: messaging_pb.FetchMessageRequest.partition:type_name -> schema_pb.Partition
-	31, // 52: messaging_pb.FetchMessageResponse.messages:type_name -> messaging_pb.DataMessage
-	70, // 53: messaging_pb.ClosePublishersRequest.topic:type_name -> schema_pb.Topic
-	70, // 54: messaging_pb.CloseSubscribersRequest.topic:type_name -> schema_pb.Topic
-	70, // 55: messaging_pb.GetUnflushedMessagesRequest.topic:type_name -> schema_pb.Topic
-	71, // 56: messaging_pb.GetUnflushedMessagesRequest.partition:type_name -> schema_pb.Partition
-	73, // 57: messaging_pb.GetUnflushedMessagesResponse.message:type_name -> filer_pb.LogEntry
-	70, // 58: messaging_pb.GetPartitionRangeInfoRequest.topic:type_name -> schema_pb.Topic
-	71, // 59: messaging_pb.GetPartitionRangeInfoRequest.partition:type_name -> schema_pb.Partition
-	50, // 60: messaging_pb.GetPartitionRangeInfoResponse.offset_range:type_name -> messaging_pb.OffsetRangeInfo
-	51, // 61: messaging_pb.GetPartitionRangeInfoResponse.timestamp_range:type_name -> messaging_pb.TimestampRangeInfo
-	3,  // 62: messaging_pb.BrokerStats.StatsEntry.value:type_name -> messaging_pb.TopicPartitionStats
-	70, // 63: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	71, // 64: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage.partition:type_name -> schema_pb.Partition
-	71, // 65: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage.partition:type_name -> schema_pb.Partition
-	17, // 66: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment.partition_assignment:type_name -> messaging_pb.BrokerPartitionAssignment
-	71, // 67: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment.partition:type_name -> schema_pb.Partition
-	70, // 68: messaging_pb.PublishMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	71, // 69: messaging_pb.PublishMessageRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	70, // 70: messaging_pb.PublishFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	71, // 71: messaging_pb.PublishFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	70, // 72: messaging_pb.SubscribeMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	74, // 73: messaging_pb.SubscribeMessageRequest.InitMessage.partition_offset:type_name -> schema_pb.PartitionOffset
-	75, // 74: messaging_pb.SubscribeMessageRequest.InitMessage.offset_type:type_name -> schema_pb.OffsetType
-	75, // 75: messaging_pb.SubscribeMessageRequest.SeekMessage.offset_type:type_name -> schema_pb.OffsetType
-	70, // 76: messaging_pb.SubscribeFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
-	71, // 77: messaging_pb.SubscribeFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
-	0,  // 78: messaging_pb.SeaweedMessaging.FindBrokerLeader:input_type -> messaging_pb.FindBrokerLeaderRequest
-	4,  // 79: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:input_type -> messaging_pb.PublisherToPubBalancerRequest
-	6,  // 80: messaging_pb.SeaweedMessaging.BalanceTopics:input_type -> messaging_pb.BalanceTopicsRequest
-	11, // 81: messaging_pb.SeaweedMessaging.ListTopics:input_type -> messaging_pb.ListTopicsRequest
-	13, // 82: messaging_pb.SeaweedMessaging.TopicExists:input_type -> messaging_pb.TopicExistsRequest
-	9,  // 83: messaging_pb.SeaweedMessaging.ConfigureTopic:input_type -> messaging_pb.ConfigureTopicRequest
-	15, // 84: messaging_pb.SeaweedMessaging.LookupTopicBrokers:input_type -> messaging_pb.LookupTopicBrokersRequest
-	18, // 85: messaging_pb.SeaweedMessaging.GetTopicConfiguration:input_type -> messaging_pb.GetTopicConfigurationRequest
-	20, // 86: messaging_pb.SeaweedMessaging.GetTopicPublishers:input_type -> messaging_pb.GetTopicPublishersRequest
-	22, // 87: messaging_pb.SeaweedMessaging.GetTopicSubscribers:input_type -> messaging_pb.GetTopicSubscribersRequest
-	26, // 88: messaging_pb.SeaweedMessaging.AssignTopicPartitions:input_type -> messaging_pb.AssignTopicPartitionsRequest
-	42, // 89: messaging_pb.SeaweedMessaging.ClosePublishers:input_type -> messaging_pb.ClosePublishersRequest
-	44, // 90: messaging_pb.SeaweedMessaging.CloseSubscribers:input_type -> messaging_pb.CloseSubscribersRequest
-	28, // 91: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:input_type -> messaging_pb.SubscriberToSubCoordinatorRequest
-	32, // 92: messaging_pb.SeaweedMessaging.PublishMessage:input_type -> messaging_pb.PublishMessageRequest
-	36, // 93: messaging_pb.SeaweedMessaging.SubscribeMessage:input_type -> messaging_pb.SubscribeMessageRequest
-	34, // 94: messaging_pb.SeaweedMessaging.PublishFollowMe:input_type -> messaging_pb.PublishFollowMeRequest
-	38, // 95: messaging_pb.SeaweedMessaging.SubscribeFollowMe:input_type -> messaging_pb.SubscribeFollowMeRequest
-	40, // 96: messaging_pb.SeaweedMessaging.FetchMessage:input_type -> messaging_pb.FetchMessageRequest
-	46, // 97: messaging_pb.SeaweedMessaging.GetUnflushedMessages:input_type -> messaging_pb.GetUnflushedMessagesRequest
-	48, // 98: messaging_pb.SeaweedMessaging.GetPartitionRangeInfo:input_type -> messaging_pb.GetPartitionRangeInfoRequest
-	1,  // 99: messaging_pb.SeaweedMessaging.FindBrokerLeader:output_type -> messaging_pb.FindBrokerLeaderResponse
-	5,  // 100: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:output_type -> messaging_pb.PublisherToPubBalancerResponse
-	7,  // 101: messaging_pb.SeaweedMessaging.BalanceTopics:output_type -> messaging_pb.BalanceTopicsResponse
-	12, // 102: messaging_pb.SeaweedMessaging.ListTopics:output_type -> messaging_pb.ListTopicsResponse
-	14, // 103: messaging_pb.SeaweedMessaging.TopicExists:output_type -> messaging_pb.TopicExistsResponse
-	10, // 104: messaging_pb.SeaweedMessaging.ConfigureTopic:output_type -> messaging_pb.ConfigureTopicResponse
-	16, // 105: messaging_pb.SeaweedMessaging.LookupTopicBrokers:output_type -> messaging_pb.LookupTopicBrokersResponse
-	19, // 106: messaging_pb.SeaweedMessaging.GetTopicConfiguration:output_type -> messaging_pb.GetTopicConfigurationResponse
-	21, // 107: messaging_pb.SeaweedMessaging.GetTopicPublishers:output_type -> messaging_pb.GetTopicPublishersResponse
-	23, // 108: messaging_pb.SeaweedMessaging.GetTopicSubscribers:output_type -> messaging_pb.GetTopicSubscribersResponse
-	27, // 109: messaging_pb.SeaweedMessaging.AssignTopicPartitions:output_type -> messaging_pb.AssignTopicPartitionsResponse
-	43, // 110: messaging_pb.SeaweedMessaging.ClosePublishers:output_type -> messaging_pb.ClosePublishersResponse
-	45, // 111: messaging_pb.SeaweedMessaging.CloseSubscribers:output_type -> messaging_pb.CloseSubscribersResponse
-	29, // 112: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:output_type -> messaging_pb.SubscriberToSubCoordinatorResponse
-	33, // 113: messaging_pb.SeaweedMessaging.PublishMessage:output_type -> messaging_pb.PublishMessageResponse
-	37, // 114: messaging_pb.SeaweedMessaging.SubscribeMessage:output_type -> messaging_pb.SubscribeMessageResponse
-	35, // 115: messaging_pb.SeaweedMessaging.PublishFollowMe:output_type -> messaging_pb.PublishFollowMeResponse
-	39, // 116: messaging_pb.SeaweedMessaging.SubscribeFollowMe:output_type -> messaging_pb.SubscribeFollowMeResponse
-	41, // 117: messaging_pb.SeaweedMessaging.FetchMessage:output_type -> messaging_pb.FetchMessageResponse
-	47, // 118: messaging_pb.SeaweedMessaging.GetUnflushedMessages:output_type -> messaging_pb.GetUnflushedMessagesResponse
-	49, // 119: messaging_pb.SeaweedMessaging.GetPartitionRangeInfo:output_type -> messaging_pb.GetPartitionRangeInfoResponse
-	99, // [99:120] is the sub-list for method output_type
-	78, // [78:99] is the sub-list for method input_type
-	78, // [78:78] is the sub-list for extension type_name
-	78, // [78:78] is the sub-list for extension extendee
-	0,  // [0:78] is the sub-list for field type_name
+	55,  // 0: messaging_pb.BrokerStats.stats:type_name -> messaging_pb.BrokerStats.StatsEntry
+	73,  // 1: messaging_pb.TopicPartitionStats.topic:type_name -> schema_pb.Topic
+	74,  // 2: messaging_pb.TopicPartitionStats.partition:type_name -> schema_pb.Partition
+	56,  // 3: messaging_pb.PublisherToPubBalancerRequest.init:type_name -> messaging_pb.PublisherToPubBalancerRequest.InitMessage
+	2,   // 4: messaging_pb.PublisherToPubBalancerRequest.stats:type_name -> messaging_pb.BrokerStats
+	73,  // 5: messaging_pb.ConfigureTopicRequest.topic:type_name -> schema_pb.Topic
+	8,   // 6: messaging_pb.ConfigureTopicRequest.retention:type_name -> messaging_pb.TopicRetention
+	75,  // 7: messaging_pb.ConfigureTopicRequest.message_record_type:type_name -> schema_pb.RecordType
+	17,  // 8: messaging_pb.ConfigureTopicResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	8,   // 9: messaging_pb.ConfigureTopicResponse.retention:type_name -> messaging_pb.TopicRetention
+	75,  // 10: messaging_pb.ConfigureTopicResponse.message_record_type:type_name -> schema_pb.RecordType
+	73,  // 11: messaging_pb.ListTopicsResponse.topics:type_name -> schema_pb.Topic
+	73,  // 12: messaging_pb.TopicExistsRequest.topic:type_name -> schema_pb.Topic
+	73,  // 13: messaging_pb.LookupTopicBrokersRequest.topic:type_name -> schema_pb.Topic
+	73,  // 14: messaging_pb.LookupTopicBrokersResponse.topic:type_name -> schema_pb.Topic
+	17,  // 15: messaging_pb.LookupTopicBrokersResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	74,  // 16: messaging_pb.BrokerPartitionAssignment.partition:type_name -> schema_pb.Partition
+	73,  // 17: messaging_pb.GetTopicConfigurationRequest.topic:type_name -> schema_pb.Topic
+	73,  // 18: messaging_pb.GetTopicConfigurationResponse.topic:type_name -> schema_pb.Topic
+	17,  // 19: messaging_pb.GetTopicConfigurationResponse.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	8,   // 20: messaging_pb.GetTopicConfigurationResponse.retention:type_name -> messaging_pb.TopicRetention
+	75,  // 21: messaging_pb.GetTopicConfigurationResponse.message_record_type:type_name -> schema_pb.RecordType
+	73,  // 22: messaging_pb.GetTopicPublishersRequest.topic:type_name -> schema_pb.Topic
+	24,  // 23: messaging_pb.GetTopicPublishersResponse.publishers:type_name -> messaging_pb.TopicPublisher
+	73,  // 24: messaging_pb.GetTopicSubscribersRequest.topic:type_name -> schema_pb.Topic
+	25,  // 25: messaging_pb.GetTopicSubscribersResponse.subscribers:type_name -> messaging_pb.TopicSubscriber
+	74,  // 26: messaging_pb.TopicPublisher.partition:type_name -> schema_pb.Partition
+	74,  // 27: messaging_pb.TopicSubscriber.partition:type_name -> schema_pb.Partition
+	73,  // 28: messaging_pb.AssignTopicPartitionsRequest.topic:type_name -> schema_pb.Topic
+	17,  // 29: messaging_pb.AssignTopicPartitionsRequest.broker_partition_assignments:type_name -> messaging_pb.BrokerPartitionAssignment
+	57,  // 30: messaging_pb.SubscriberToSubCoordinatorRequest.init:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage
+	59,  // 31: messaging_pb.SubscriberToSubCoordinatorRequest.ack_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage
+	58,  // 32: messaging_pb.SubscriberToSubCoordinatorRequest.ack_un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage
+	60,  // 33: messaging_pb.SubscriberToSubCoordinatorResponse.assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.Assignment
+	61,  // 34: messaging_pb.SubscriberToSubCoordinatorResponse.un_assignment:type_name -> messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment
+	30,  // 35: messaging_pb.DataMessage.ctrl:type_name -> messaging_pb.ControlMessage
+	62,  // 36: messaging_pb.PublishMessageRequest.init:type_name -> messaging_pb.PublishMessageRequest.InitMessage
+	31,  // 37: messaging_pb.PublishMessageRequest.data:type_name -> messaging_pb.DataMessage
+	63,  // 38: messaging_pb.PublishFollowMeRequest.init:type_name -> messaging_pb.PublishFollowMeRequest.InitMessage
+	31,  // 39: messaging_pb.PublishFollowMeRequest.data:type_name -> messaging_pb.DataMessage
+	64,  // 40: messaging_pb.PublishFollowMeRequest.flush:type_name -> messaging_pb.PublishFollowMeRequest.FlushMessage
+	65,  // 41: messaging_pb.PublishFollowMeRequest.close:type_name -> messaging_pb.PublishFollowMeRequest.CloseMessage
+	66,  // 42: messaging_pb.SubscribeMessageRequest.init:type_name -> messaging_pb.SubscribeMessageRequest.InitMessage
+	67,  // 43: messaging_pb.SubscribeMessageRequest.ack:type_name -> messaging_pb.SubscribeMessageRequest.AckMessage
+	68,  // 44: messaging_pb.SubscribeMessageRequest.seek:type_name -> messaging_pb.SubscribeMessageRequest.SeekMessage
+	73,  // 45: messaging_pb.DeadLetterPolicy.dead_letter_topic:type_name -> schema_pb.Topic
+	69,  // 46: messaging_pb.SubscribeMessageResponse.ctrl:type_name -> messaging_pb.SubscribeMessageResponse.SubscribeCtrlMessage
+	31,  // 47: messaging_pb.SubscribeMessageResponse.data:type_name -> messaging_pb.DataMessage
+	70,  // 48: messaging_pb.SubscribeFollowMeRequest.init:type_name -> messaging_pb.SubscribeFollowMeRequest.InitMessage
+	71,  // 49: messaging_pb.SubscribeFollowMeRequest.ack:type_name -> messaging_pb.SubscribeFollowMeRequest.AckMessage
+	72,  // 50: messaging_pb.SubscribeFollowMeRequest.close:type_name -> messaging_pb.SubscribeFollowMeRequest.CloseMessage
+	73,  // 51: messaging_pb.FetchMessageRequest.topic:type_name -> schema_pb.Topic
+	74,  // 52: messaging_pb.FetchMessageRequest.partition:type_name -> schema_pb.Partition
+	31,  // 53: messaging_pb.FetchMessageResponse.messages:type_name -> messaging_pb.DataMessage
+	73,  // 54: messaging_pb.ClosePublishersRequest.topic:type_name -> schema_pb.Topic
+	73,  // 55: messaging_pb.CloseSubscribersRequest.topic:type_name -> schema_pb.Topic
+	73,  // 56: messaging_pb.GetUnflushedMessagesRequest.topic:type_name -> schema_pb.Topic
+	74,  // 57: messaging_pb.GetUnflushedMessagesRequest.partition:type_name -> schema_pb.Partition
+	76,  // 58: messaging_pb.GetUnflushedMessagesResponse.message:type_name -> filer_pb.LogEntry
+	73,  // 59: messaging_pb.GetPartitionRangeInfoRequest.topic:type_name -> schema_pb.Topic
+	74,  // 60: messaging_pb.GetPartitionRangeInfoRequest.partition:type_name -> schema_pb.Partition
+	51,  // 61: messaging_pb.GetPartitionRangeInfoResponse.offset_range:type_name -> messaging_pb.OffsetRangeInfo
+	52,  // 62: messaging_pb.GetPartitionRangeInfoResponse.timestamp_range:type_name -> messaging_pb.TimestampRangeInfo
+	73,  // 63: messaging_pb.CancelDelayedMessageRequest.topic:type_name -> schema_pb.Topic
+	3,   // 64: messaging_pb.BrokerStats.StatsEntry.value:type_name -> messaging_pb.TopicPartitionStats
+	73,  // 65: messaging_pb.SubscriberToSubCoordinatorRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	74,  // 66: messaging_pb.SubscriberToSubCoordinatorRequest.AckUnAssignmentMessage.partition:type_name -> schema_pb.Partition
+	74,  // 67: messaging_pb.SubscriberToSubCoordinatorRequest.AckAssignmentMessage.partition:type_name -> schema_pb.Partition
+	17,  // 68: messaging_pb.SubscriberToSubCoordinatorResponse.Assignment.partition_assignment:type_name -> messaging_pb.BrokerPartitionAssignment
+	74,  // 69: messaging_pb.SubscriberToSubCoordinatorResponse.UnAssignment.partition:type_name -> schema_pb.Partition
+	73,  // 70: messaging_pb.PublishMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	74,  // 71: messaging_pb.PublishMessageRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	73,  // 72: messaging_pb.PublishFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	74,  // 73: messaging_pb.PublishFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	73,  // 74: messaging_pb.SubscribeMessageRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	77,  // 75: messaging_pb.SubscribeMessageRequest.InitMessage.partition_offset:type_name -> schema_pb.PartitionOffset
+	78,  // 76: messaging_pb.SubscribeMessageRequest.InitMessage.offset_type:type_name -> schema_pb.OffsetType
+	37,  // 77: messaging_pb.SubscribeMessageRequest.InitMessage.dead_letter_policy:type_name -> messaging_pb.DeadLetterPolicy
+	78,  // 78: messaging_pb.SubscribeMessageRequest.SeekMessage.offset_type:type_name -> schema_pb.OffsetType
+	73,  // 79: messaging_pb.SubscribeFollowMeRequest.InitMessage.topic:type_name -> schema_pb.Topic
+	74,  // 80: messaging_pb.SubscribeFollowMeRequest.InitMessage.partition:type_name -> schema_pb.Partition
+	0,   // 81: messaging_pb.SeaweedMessaging.FindBrokerLeader:input_type -> messaging_pb.FindBrokerLeaderRequest
+	4,   // 82: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:input_type -> messaging_pb.PublisherToPubBalancerRequest
+	6,   // 83: messaging_pb.SeaweedMessaging.BalanceTopics:input_type -> messaging_pb.BalanceTopicsRequest
+	11,  // 84: messaging_pb.SeaweedMessaging.ListTopics:input_type -> messaging_pb.ListTopicsRequest
+	13,  // 85: messaging_pb.SeaweedMessaging.TopicExists:input_type -> messaging_pb.TopicExistsRequest
+	9,   // 86: messaging_pb.SeaweedMessaging.ConfigureTopic:input_type -> messaging_pb.ConfigureTopicRequest
+	15,  // 87: messaging_pb.SeaweedMessaging.LookupTopicBrokers:input_type -> messaging_pb.LookupTopicBrokersRequest
+	18,  // 88: messaging_pb.SeaweedMessaging.GetTopicConfiguration:input_type -> messaging_pb.GetTopicConfigurationRequest
+	20,  // 89: messaging_pb.SeaweedMessaging.GetTopicPublishers:input_type -> messaging_pb.GetTopicPublishersRequest
+	22,  // 90: messaging_pb.SeaweedMessaging.GetTopicSubscribers:input_type -> messaging_pb.GetTopicSubscribersRequest
+	26,  // 91: messaging_pb.SeaweedMessaging.AssignTopicPartitions:input_type -> messaging_pb.AssignTopicPartitionsRequest
+	43,  // 92: messaging_pb.SeaweedMessaging.ClosePublishers:input_type -> messaging_pb.ClosePublishersRequest
+	45,  // 93: messaging_pb.SeaweedMessaging.CloseSubscribers:input_type -> messaging_pb.CloseSubscribersRequest
+	28,  // 94: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:input_type -> messaging_pb.SubscriberToSubCoordinatorRequest
+	32,  // 95: messaging_pb.SeaweedMessaging.PublishMessage:input_type -> messaging_pb.PublishMessageRequest
+	36,  // 96: messaging_pb.SeaweedMessaging.SubscribeMessage:input_type -> messaging_pb.SubscribeMessageRequest
+	34,  // 97: messaging_pb.SeaweedMessaging.PublishFollowMe:input_type -> messaging_pb.PublishFollowMeRequest
+	39,  // 98: messaging_pb.SeaweedMessaging.SubscribeFollowMe:input_type -> messaging_pb.SubscribeFollowMeRequest
+	41,  // 99: messaging_pb.SeaweedMessaging.FetchMessage:input_type -> messaging_pb.FetchMessageRequest
+	47,  // 100: messaging_pb.SeaweedMessaging.GetUnflushedMessages:input_type -> messaging_pb.GetUnflushedMessagesRequest
+	49,  // 101: messaging_pb.SeaweedMessaging.GetPartitionRangeInfo:input_type -> messaging_pb.GetPartitionRangeInfoRequest
+	53,  // 102: messaging_pb.SeaweedMessaging.CancelDelayedMessage:input_type -> messaging_pb.CancelDelayedMessageRequest
+	1,   // 103: messaging_pb.SeaweedMessaging.FindBrokerLeader:output_type -> messaging_pb.FindBrokerLeaderResponse
+	5,   // 104: messaging_pb.SeaweedMessaging.PublisherToPubBalancer:output_type -> messaging_pb.PublisherToPubBalancerResponse
+	7,   // 105: messaging_pb.SeaweedMessaging.BalanceTopics:output_type -> messaging_pb.BalanceTopicsResponse
+	12,  // 106: messaging_pb.SeaweedMessaging.ListTopics:output_type -> messaging_pb.ListTopicsResponse
+	14,  // 107: messaging_pb.SeaweedMessaging.TopicExists:output_type -> messaging_pb.TopicExistsResponse
+	10,  // 108: messaging_pb.SeaweedMessaging.ConfigureTopic:output_type -> messaging_pb.ConfigureTopicResponse
+	16,  // 109: messaging_pb.SeaweedMessaging.LookupTopicBrokers:output_type -> messaging_pb.LookupTopicBrokersResponse
+	19,  // 110: messaging_pb.SeaweedMessaging.GetTopicConfiguration:output_type -> messaging_pb.GetTopicConfigurationResponse
+	21,  // 111: messaging_pb.SeaweedMessaging.GetTopicPublishers:output_type -> messaging_pb.GetTopicPublishersResponse
+	23,  // 112: messaging_pb.SeaweedMessaging.GetTopicSubscribers:output_type -> messaging_pb.GetTopicSubscribersResponse
+	27,  // 113: messaging_pb.SeaweedMessaging.AssignTopicPartitions:output_type -> messaging_pb.AssignTopicPartitionsResponse
+	44,  // 114: messaging_pb.SeaweedMessaging.ClosePublishers:output_type -> messaging_pb.ClosePublishersResponse
+	46,  // 115: messaging_pb.SeaweedMessaging.CloseSubscribers:output_type -> messaging_pb.CloseSubscribersResponse
+	29,  // 116: messaging_pb.SeaweedMessaging.SubscriberToSubCoordinator:output_type -> messaging_pb.SubscriberToSubCoordinatorResponse
+	33,  // 117: messaging_pb.SeaweedMessaging.PublishMessage:output_type -> messaging_pb.PublishMessageResponse
+	38,  // 118: messaging_pb.SeaweedMessaging.SubscribeMessage:output_type -> messaging_pb.SubscribeMessageResponse
+	35,  // 119: messaging_pb.SeaweedMessaging.PublishFollowMe:output_type -> messaging_pb.PublishFollowMeResponse
+	40,  // 120: messaging_pb.SeaweedMessaging.SubscribeFollowMe:output_type -> messaging_pb.SubscribeFollowMeResponse
+	42,  // 121: messaging_pb.SeaweedMessaging.FetchMessage:output_type -> messaging_pb.FetchMessageResponse
+	48,  // 122: messaging_pb.SeaweedMessaging.GetUnflushedMessages:output_type -> messaging_pb.GetUnflushedMessagesResponse
+	50,  // 123: messaging_pb.SeaweedMessaging.GetPartitionRangeInfo:output_type -> messaging_pb.GetPartitionRangeInfoResponse
+	54,  // 124: messaging_pb.SeaweedMessaging.CancelDelayedMessage:output_type -> messaging_pb.CancelDelayedMessageResponse
+	103, // [103:125] is the sub-list for method output_type
+	81,  // [81:103] is the sub-list for method input_type
+	81,  // [81:81] is the sub-list for extension type_name
+	81,  // [81:81] is the sub-list for extension extendee
+	0,   // [0:81] is the sub-list for field type_name
 }
 
 func init() { file_mq_broker_proto_init() }
@@ -4809,11 +5075,11 @@ func file_mq_broker_proto_init() {
 		(*SubscribeMessageRequest_Ack)(nil),
 		(*SubscribeMessageRequest_Seek)(nil),
 	}
-	file_mq_broker_proto_msgTypes[37].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[38].OneofWrappers = []any{
 		(*SubscribeMessageResponse_Ctrl)(nil),
 		(*SubscribeMessageResponse_Data)(nil),
 	}
-	file_mq_broker_proto_msgTypes[38].OneofWrappers = []any{
+	file_mq_broker_proto_msgTypes[39].OneofWrappers = []any{
 		(*SubscribeFollowMeRequest_Init)(nil),
 		(*SubscribeFollowMeRequest_Ack)(nil),
 		(*SubscribeFollowMeRequest_Close)(nil),
@@ -4824,7 +5090,7 @@ func file_mq_broker_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mq_broker_proto_rawDesc), len(file_mq_broker_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   70,
+			NumMessages:   73,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
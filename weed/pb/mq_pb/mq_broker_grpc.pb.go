@@ -40,6 +40,7 @@ const (
 	SeaweedMessaging_FetchMessage_FullMethodName               = "/messaging_pb.SeaweedMessaging/FetchMessage"
 	SeaweedMessaging_GetUnflushedMessages_FullMethodName       = "/messaging_pb.SeaweedMessaging/GetUnflushedMessages"
 	SeaweedMessaging_GetPartitionRangeInfo_FullMethodName      = "/messaging_pb.SeaweedMessaging/GetPartitionRangeInfo"
+	SeaweedMessaging_CancelDelayedMessage_FullMethodName       = "/messaging_pb.SeaweedMessaging/CancelDelayedMessage"
 )
 
 // SeaweedMessagingClient is the client API for SeaweedMessaging service.
@@ -79,6 +80,8 @@ type SeaweedMessagingClient interface {
 	GetUnflushedMessages(ctx context.Context, in *GetUnflushedMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetUnflushedMessagesResponse], error)
 	// Get comprehensive partition range information (offsets, timestamps, and other fields)
 	GetPartitionRangeInfo(ctx context.Context, in *GetPartitionRangeInfoRequest, opts ...grpc.CallOption) (*GetPartitionRangeInfoResponse, error)
+	// Cancel the delayed messages of a key that are not delivered yet
+	CancelDelayedMessage(ctx context.Context, in *CancelDelayedMessageRequest, opts ...grpc.CallOption) (*CancelDelayedMessageResponse, error)
 }
 
 type seaweedMessagingClient struct {
@@ -326,6 +329,16 @@ func (c *seaweedMessagingClient) GetPartitionRangeInfo(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *seaweedMessagingClient) CancelDelayedMessage(ctx context.Context, in *CancelDelayedMessageRequest, opts ...grpc.CallOption) (*CancelDelayedMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelDelayedMessageResponse)
+	err := c.cc.Invoke(ctx, SeaweedMessaging_CancelDelayedMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SeaweedMessagingServer is the server API for SeaweedMessaging service.
 // All implementations must embed UnimplementedSeaweedMessagingServer
 // for forward compatibility.
@@ -363,6 +376,8 @@ type SeaweedMessagingServer interface {
 	GetUnflushedMessages(*GetUnflushedMessagesRequest, grpc.ServerStreamingServer[GetUnflushedMessagesResponse]) error
 	// Get comprehensive partition range information (offsets, timestamps, and other fields)
 	GetPartitionRangeInfo(context.Context, *GetPartitionRangeInfoRequest) (*GetPartitionRangeInfoResponse, error)
+	// Cancel the delayed messages of a key that are not delivered yet
+	CancelDelayedMessage(context.Context, *CancelDelayedMessageRequest) (*CancelDelayedMessageResponse, error)
 	mustEmbedUnimplementedSeaweedMessagingServer()
 }
 
@@ -436,6 +451,9 @@ func (UnimplementedSeaweedMessagingServer) GetUnflushedMessages(*GetUnflushedMes
 func (UnimplementedSeaweedMessagingServer) GetPartitionRangeInfo(context.Context, *GetPartitionRangeInfoRequest) (*GetPartitionRangeInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPartitionRangeInfo not implemented")
 }
+func (UnimplementedSeaweedMessagingServer) CancelDelayedMessage(context.Context, *CancelDelayedMessageRequest) (*CancelDelayedMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelDelayedMessage not implemented")
+}
 func (UnimplementedSeaweedMessagingServer) mustEmbedUnimplementedSeaweedMessagingServer() {}
 func (UnimplementedSeaweedMessagingServer) testEmbeddedByValue()                          {}
 
@@ -762,6 +780,24 @@ func _SeaweedMessaging_GetPartitionRangeInfo_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedMessaging_CancelDelayedMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelDelayedMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedMessagingServer).CancelDelayedMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SeaweedMessaging_CancelDelayedMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedMessagingServer).CancelDelayedMessage(ctx, req.(*CancelDelayedMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SeaweedMessaging_ServiceDesc is the grpc.ServiceDesc for SeaweedMessaging service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -825,6 +861,10 @@ var SeaweedMessaging_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPartitionRangeInfo",
 			Handler:    _SeaweedMessaging_GetPartitionRangeInfo_Handler,
 		},
+		{
+			MethodName: "CancelDelayedMessage",
+			Handler:    _SeaweedMessaging_CancelDelayedMessage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
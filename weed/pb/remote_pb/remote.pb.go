@@ -69,6 +69,7 @@ type RemoteConf struct {
 	ContaboSecretKey                string                 `protobuf:"bytes,69,opt,name=contabo_secret_key,json=contaboSecretKey,proto3" json:"contabo_secret_key,omitempty"`
 	ContaboEndpoint                 string                 `protobuf:"bytes,70,opt,name=contabo_endpoint,json=contaboEndpoint,proto3" json:"contabo_endpoint,omitempty"`
 	ContaboRegion                   string                 `protobuf:"bytes,71,opt,name=contabo_region,json=contaboRegion,proto3" json:"contabo_region,omitempty"`
+	SeaweedfsFilers                 string                 `protobuf:"bytes,75,opt,name=seaweedfs_filers,json=seaweedfsFilers,proto3" json:"seaweedfs_filers,omitempty"`
 	unknownFields                   protoimpl.UnknownFields
 	sizeCache                       protoimpl.SizeCache
 }
@@ -404,6 +405,13 @@ func (x *RemoteConf) GetContaboRegion() string {
 	return ""
 }
 
+func (x *RemoteConf) GetSeaweedfsFilers() string {
+	if x != nil {
+		return x.SeaweedfsFilers
+	}
+	return ""
+}
+
 type RemoteStorageMapping struct {
 	state                    protoimpl.MessageState            `protogen:"open.v1"`
 	Mappings                 map[string]*RemoteStorageLocation `protobuf:"bytes,1,rep,name=mappings,proto3" json:"mappings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -520,7 +528,7 @@ var File_remote_proto protoreflect.FileDescriptor
 
 const file_remote_proto_rawDesc = "" +
 	"\n" +
-	"\fremote.proto\x12\tremote_pb\"\x9b\x0e\n" +
+	"\fremote.proto\x12\tremote_pb\"\xc6\x0e\n" +
 	"\n" +
 	"RemoteConf\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
@@ -567,7 +575,8 @@ const file_remote_proto_rawDesc = "" +
 	"\x12contabo_access_key\x18D \x01(\tR\x10contaboAccessKey\x12,\n" +
 	"\x12contabo_secret_key\x18E \x01(\tR\x10contaboSecretKey\x12)\n" +
 	"\x10contabo_endpoint\x18F \x01(\tR\x0fcontaboEndpoint\x12%\n" +
-	"\x0econtabo_region\x18G \x01(\tR\rcontaboRegion\"\xff\x01\n" +
+	"\x0econtabo_region\x18G \x01(\tR\rcontaboRegion\x12)\n" +
+	"\x10seaweedfs_filers\x18K \x01(\tR\x0fseaweedfsFilers\"\xff\x01\n" +
 	"\x14RemoteStorageMapping\x12I\n" +
 	"\bmappings\x18\x01 \x03(\v2-.remote_pb.RemoteStorageMapping.MappingsEntryR\bmappings\x12=\n" +
 	"\x1bprimary_bucket_storage_name\x18\x02 \x01(\tR\x18primaryBucketStorageName\x1a]\n" +
@@ -19,57 +19,68 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VolumeServer_BatchDelete_FullMethodName                 = "/volume_server_pb.VolumeServer/BatchDelete"
-	VolumeServer_VacuumVolumeCheck_FullMethodName           = "/volume_server_pb.VolumeServer/VacuumVolumeCheck"
-	VolumeServer_VacuumVolumeCompact_FullMethodName         = "/volume_server_pb.VolumeServer/VacuumVolumeCompact"
-	VolumeServer_VacuumVolumeCommit_FullMethodName          = "/volume_server_pb.VolumeServer/VacuumVolumeCommit"
-	VolumeServer_VacuumVolumeCleanup_FullMethodName         = "/volume_server_pb.VolumeServer/VacuumVolumeCleanup"
-	VolumeServer_DeleteCollection_FullMethodName            = "/volume_server_pb.VolumeServer/DeleteCollection"
-	VolumeServer_AllocateVolume_FullMethodName              = "/volume_server_pb.VolumeServer/AllocateVolume"
-	VolumeServer_VolumeSyncStatus_FullMethodName            = "/volume_server_pb.VolumeServer/VolumeSyncStatus"
-	VolumeServer_VolumeIncrementalCopy_FullMethodName       = "/volume_server_pb.VolumeServer/VolumeIncrementalCopy"
-	VolumeServer_VolumeMount_FullMethodName                 = "/volume_server_pb.VolumeServer/VolumeMount"
-	VolumeServer_VolumeUnmount_FullMethodName               = "/volume_server_pb.VolumeServer/VolumeUnmount"
-	VolumeServer_VolumeDelete_FullMethodName                = "/volume_server_pb.VolumeServer/VolumeDelete"
-	VolumeServer_VolumeMarkReadonly_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeMarkReadonly"
-	VolumeServer_VolumeMarkWritable_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeMarkWritable"
-	VolumeServer_VolumeConfigure_FullMethodName             = "/volume_server_pb.VolumeServer/VolumeConfigure"
-	VolumeServer_VolumeStatus_FullMethodName                = "/volume_server_pb.VolumeServer/VolumeStatus"
-	VolumeServer_VolumeCopy_FullMethodName                  = "/volume_server_pb.VolumeServer/VolumeCopy"
-	VolumeServer_ReadVolumeFileStatus_FullMethodName        = "/volume_server_pb.VolumeServer/ReadVolumeFileStatus"
-	VolumeServer_CopyFile_FullMethodName                    = "/volume_server_pb.VolumeServer/CopyFile"
-	VolumeServer_ReceiveFile_FullMethodName                 = "/volume_server_pb.VolumeServer/ReceiveFile"
-	VolumeServer_ReadNeedleBlob_FullMethodName              = "/volume_server_pb.VolumeServer/ReadNeedleBlob"
-	VolumeServer_ReadNeedleMeta_FullMethodName              = "/volume_server_pb.VolumeServer/ReadNeedleMeta"
-	VolumeServer_WriteNeedleBlob_FullMethodName             = "/volume_server_pb.VolumeServer/WriteNeedleBlob"
-	VolumeServer_ReadAllNeedles_FullMethodName              = "/volume_server_pb.VolumeServer/ReadAllNeedles"
-	VolumeServer_VolumeTailSender_FullMethodName            = "/volume_server_pb.VolumeServer/VolumeTailSender"
-	VolumeServer_VolumeTailReceiver_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeTailReceiver"
-	VolumeServer_VolumeEcShardsGenerate_FullMethodName      = "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate"
-	VolumeServer_VolumeEcShardsRebuild_FullMethodName       = "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild"
-	VolumeServer_VolumeEcShardsCopy_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeEcShardsCopy"
-	VolumeServer_VolumeEcShardsDelete_FullMethodName        = "/volume_server_pb.VolumeServer/VolumeEcShardsDelete"
-	VolumeServer_VolumeEcShardsMount_FullMethodName         = "/volume_server_pb.VolumeServer/VolumeEcShardsMount"
-	VolumeServer_VolumeEcShardsUnmount_FullMethodName       = "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount"
-	VolumeServer_VolumeEcShardRead_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeEcShardRead"
-	VolumeServer_VolumeEcBlobDelete_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeEcBlobDelete"
-	VolumeServer_VolumeEcShardsToVolume_FullMethodName      = "/volume_server_pb.VolumeServer/VolumeEcShardsToVolume"
-	VolumeServer_VolumeEcShardsInfo_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeEcShardsInfo"
-	VolumeServer_VolumeTierMoveDatToRemote_FullMethodName   = "/volume_server_pb.VolumeServer/VolumeTierMoveDatToRemote"
-	VolumeServer_VolumeTierMoveDatFromRemote_FullMethodName = "/volume_server_pb.VolumeServer/VolumeTierMoveDatFromRemote"
-	VolumeServer_VolumeServerStatus_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeServerStatus"
-	VolumeServer_VolumeServerLeave_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeServerLeave"
-	VolumeServer_FetchAndWriteNeedle_FullMethodName         = "/volume_server_pb.VolumeServer/FetchAndWriteNeedle"
-	VolumeServer_Query_FullMethodName                       = "/volume_server_pb.VolumeServer/Query"
-	VolumeServer_VolumeNeedleStatus_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeNeedleStatus"
-	VolumeServer_Ping_FullMethodName                        = "/volume_server_pb.VolumeServer/Ping"
+	VolumeServer_BatchDelete_FullMethodName                  = "/volume_server_pb.VolumeServer/BatchDelete"
+	VolumeServer_VacuumVolumeCheck_FullMethodName            = "/volume_server_pb.VolumeServer/VacuumVolumeCheck"
+	VolumeServer_VacuumVolumeCompact_FullMethodName          = "/volume_server_pb.VolumeServer/VacuumVolumeCompact"
+	VolumeServer_VacuumVolumeCommit_FullMethodName           = "/volume_server_pb.VolumeServer/VacuumVolumeCommit"
+	VolumeServer_VacuumVolumeCleanup_FullMethodName          = "/volume_server_pb.VolumeServer/VacuumVolumeCleanup"
+	VolumeServer_DeleteCollection_FullMethodName             = "/volume_server_pb.VolumeServer/DeleteCollection"
+	VolumeServer_AllocateVolume_FullMethodName               = "/volume_server_pb.VolumeServer/AllocateVolume"
+	VolumeServer_VolumeSyncStatus_FullMethodName             = "/volume_server_pb.VolumeServer/VolumeSyncStatus"
+	VolumeServer_VolumeIncrementalCopy_FullMethodName        = "/volume_server_pb.VolumeServer/VolumeIncrementalCopy"
+	VolumeServer_VolumeReplicaHints_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeReplicaHints"
+	VolumeServer_VolumeMount_FullMethodName                  = "/volume_server_pb.VolumeServer/VolumeMount"
+	VolumeServer_VolumeUnmount_FullMethodName                = "/volume_server_pb.VolumeServer/VolumeUnmount"
+	VolumeServer_VolumeDelete_FullMethodName                 = "/volume_server_pb.VolumeServer/VolumeDelete"
+	VolumeServer_VolumeMarkReadonly_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeMarkReadonly"
+	VolumeServer_VolumeMarkWritable_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeMarkWritable"
+	VolumeServer_VolumeConfigure_FullMethodName              = "/volume_server_pb.VolumeServer/VolumeConfigure"
+	VolumeServer_VolumeConvertOffset_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeConvertOffset"
+	VolumeServer_VolumeWormVerify_FullMethodName             = "/volume_server_pb.VolumeServer/VolumeWormVerify"
+	VolumeServer_VolumeStatus_FullMethodName                 = "/volume_server_pb.VolumeServer/VolumeStatus"
+	VolumeServer_VolumeCopy_FullMethodName                   = "/volume_server_pb.VolumeServer/VolumeCopy"
+	VolumeServer_ReadVolumeFileStatus_FullMethodName         = "/volume_server_pb.VolumeServer/ReadVolumeFileStatus"
+	VolumeServer_CopyFile_FullMethodName                     = "/volume_server_pb.VolumeServer/CopyFile"
+	VolumeServer_ReceiveFile_FullMethodName                  = "/volume_server_pb.VolumeServer/ReceiveFile"
+	VolumeServer_ReadNeedleBlob_FullMethodName               = "/volume_server_pb.VolumeServer/ReadNeedleBlob"
+	VolumeServer_ReadNeedleMeta_FullMethodName               = "/volume_server_pb.VolumeServer/ReadNeedleMeta"
+	VolumeServer_WriteNeedleBlob_FullMethodName              = "/volume_server_pb.VolumeServer/WriteNeedleBlob"
+	VolumeServer_ReplicateNeedles_FullMethodName             = "/volume_server_pb.VolumeServer/ReplicateNeedles"
+	VolumeServer_ReadAllNeedles_FullMethodName               = "/volume_server_pb.VolumeServer/ReadAllNeedles"
+	VolumeServer_VolumeTailSender_FullMethodName             = "/volume_server_pb.VolumeServer/VolumeTailSender"
+	VolumeServer_VolumeTailReceiver_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeTailReceiver"
+	VolumeServer_VolumeEcShardsGenerate_FullMethodName       = "/volume_server_pb.VolumeServer/VolumeEcShardsGenerate"
+	VolumeServer_VolumeEcShardsRebuild_FullMethodName        = "/volume_server_pb.VolumeServer/VolumeEcShardsRebuild"
+	VolumeServer_VolumeEcShardsCopy_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeEcShardsCopy"
+	VolumeServer_VolumeEcShardsDelete_FullMethodName         = "/volume_server_pb.VolumeServer/VolumeEcShardsDelete"
+	VolumeServer_VolumeEcShardsMount_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeEcShardsMount"
+	VolumeServer_VolumeEcShardsUnmount_FullMethodName        = "/volume_server_pb.VolumeServer/VolumeEcShardsUnmount"
+	VolumeServer_VolumeEcShardRead_FullMethodName            = "/volume_server_pb.VolumeServer/VolumeEcShardRead"
+	VolumeServer_VolumeEcBlobDelete_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeEcBlobDelete"
+	VolumeServer_VolumeEcShardsToVolume_FullMethodName       = "/volume_server_pb.VolumeServer/VolumeEcShardsToVolume"
+	VolumeServer_VolumeEcShardsInfo_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeEcShardsInfo"
+	VolumeServer_VolumeEcShardsVacuumPrepare_FullMethodName  = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumPrepare"
+	VolumeServer_VolumeEcShardsVacuumGenerate_FullMethodName = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumGenerate"
+	VolumeServer_VolumeEcShardsVacuumCommit_FullMethodName   = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumCommit"
+	VolumeServer_VolumeEcShardsVacuumCleanup_FullMethodName  = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumCleanup"
+	VolumeServer_VolumeEcShardsVacuumRollback_FullMethodName = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumRollback"
+	VolumeServer_VolumeEcShardsVacuumFinalize_FullMethodName = "/volume_server_pb.VolumeServer/VolumeEcShardsVacuumFinalize"
+	VolumeServer_VolumeTierMoveDatToRemote_FullMethodName    = "/volume_server_pb.VolumeServer/VolumeTierMoveDatToRemote"
+	VolumeServer_VolumeTierMoveDatFromRemote_FullMethodName  = "/volume_server_pb.VolumeServer/VolumeTierMoveDatFromRemote"
+	VolumeServer_VolumeServerStatus_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeServerStatus"
+	VolumeServer_VolumeServerLeave_FullMethodName            = "/volume_server_pb.VolumeServer/VolumeServerLeave"
+	VolumeServer_VolumeServerIoStats_FullMethodName          = "/volume_server_pb.VolumeServer/VolumeServerIoStats"
+	VolumeServer_FetchAndWriteNeedle_FullMethodName          = "/volume_server_pb.VolumeServer/FetchAndWriteNeedle"
+	VolumeServer_Query_FullMethodName                        = "/volume_server_pb.VolumeServer/Query"
+	VolumeServer_VolumeNeedleStatus_FullMethodName           = "/volume_server_pb.VolumeServer/VolumeNeedleStatus"
+	VolumeServer_Ping_FullMethodName                         = "/volume_server_pb.VolumeServer/Ping"
 )
 
 // VolumeServerClient is the client API for VolumeServer service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VolumeServerClient interface {
-	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
+	//Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
 	BatchDelete(ctx context.Context, in *BatchDeleteRequest, opts ...grpc.CallOption) (*BatchDeleteResponse, error)
 	VacuumVolumeCheck(ctx context.Context, in *VacuumVolumeCheckRequest, opts ...grpc.CallOption) (*VacuumVolumeCheckResponse, error)
 	VacuumVolumeCompact(ctx context.Context, in *VacuumVolumeCompactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VacuumVolumeCompactResponse], error)
@@ -79,12 +90,15 @@ type VolumeServerClient interface {
 	AllocateVolume(ctx context.Context, in *AllocateVolumeRequest, opts ...grpc.CallOption) (*AllocateVolumeResponse, error)
 	VolumeSyncStatus(ctx context.Context, in *VolumeSyncStatusRequest, opts ...grpc.CallOption) (*VolumeSyncStatusResponse, error)
 	VolumeIncrementalCopy(ctx context.Context, in *VolumeIncrementalCopyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeIncrementalCopyResponse], error)
+	VolumeReplicaHints(ctx context.Context, in *VolumeReplicaHintsRequest, opts ...grpc.CallOption) (*VolumeReplicaHintsResponse, error)
 	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
 	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
 	VolumeDelete(ctx context.Context, in *VolumeDeleteRequest, opts ...grpc.CallOption) (*VolumeDeleteResponse, error)
 	VolumeMarkReadonly(ctx context.Context, in *VolumeMarkReadonlyRequest, opts ...grpc.CallOption) (*VolumeMarkReadonlyResponse, error)
 	VolumeMarkWritable(ctx context.Context, in *VolumeMarkWritableRequest, opts ...grpc.CallOption) (*VolumeMarkWritableResponse, error)
 	VolumeConfigure(ctx context.Context, in *VolumeConfigureRequest, opts ...grpc.CallOption) (*VolumeConfigureResponse, error)
+	VolumeConvertOffset(ctx context.Context, in *VolumeConvertOffsetRequest, opts ...grpc.CallOption) (*VolumeConvertOffsetResponse, error)
+	VolumeWormVerify(ctx context.Context, in *VolumeWormVerifyRequest, opts ...grpc.CallOption) (*VolumeWormVerifyResponse, error)
 	VolumeStatus(ctx context.Context, in *VolumeStatusRequest, opts ...grpc.CallOption) (*VolumeStatusResponse, error)
 	// copy the .idx .dat files, and mount this volume
 	VolumeCopy(ctx context.Context, in *VolumeCopyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeCopyResponse], error)
@@ -94,6 +108,7 @@ type VolumeServerClient interface {
 	ReadNeedleBlob(ctx context.Context, in *ReadNeedleBlobRequest, opts ...grpc.CallOption) (*ReadNeedleBlobResponse, error)
 	ReadNeedleMeta(ctx context.Context, in *ReadNeedleMetaRequest, opts ...grpc.CallOption) (*ReadNeedleMetaResponse, error)
 	WriteNeedleBlob(ctx context.Context, in *WriteNeedleBlobRequest, opts ...grpc.CallOption) (*WriteNeedleBlobResponse, error)
+	ReplicateNeedles(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReplicateNeedlesRequest, ReplicateNeedlesResponse], error)
 	ReadAllNeedles(ctx context.Context, in *ReadAllNeedlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadAllNeedlesResponse], error)
 	VolumeTailSender(ctx context.Context, in *VolumeTailSenderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTailSenderResponse], error)
 	VolumeTailReceiver(ctx context.Context, in *VolumeTailReceiverRequest, opts ...grpc.CallOption) (*VolumeTailReceiverResponse, error)
@@ -108,11 +123,18 @@ type VolumeServerClient interface {
 	VolumeEcBlobDelete(ctx context.Context, in *VolumeEcBlobDeleteRequest, opts ...grpc.CallOption) (*VolumeEcBlobDeleteResponse, error)
 	VolumeEcShardsToVolume(ctx context.Context, in *VolumeEcShardsToVolumeRequest, opts ...grpc.CallOption) (*VolumeEcShardsToVolumeResponse, error)
 	VolumeEcShardsInfo(ctx context.Context, in *VolumeEcShardsInfoRequest, opts ...grpc.CallOption) (*VolumeEcShardsInfoResponse, error)
+	VolumeEcShardsVacuumPrepare(ctx context.Context, in *VolumeEcShardsVacuumPrepareRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumPrepareResponse, error)
+	VolumeEcShardsVacuumGenerate(ctx context.Context, in *VolumeEcShardsVacuumGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumGenerateResponse, error)
+	VolumeEcShardsVacuumCommit(ctx context.Context, in *VolumeEcShardsVacuumCommitRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumCommitResponse, error)
+	VolumeEcShardsVacuumCleanup(ctx context.Context, in *VolumeEcShardsVacuumCleanupRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumCleanupResponse, error)
+	VolumeEcShardsVacuumRollback(ctx context.Context, in *VolumeEcShardsVacuumRollbackRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumRollbackResponse, error)
+	VolumeEcShardsVacuumFinalize(ctx context.Context, in *VolumeEcShardsVacuumFinalizeRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumFinalizeResponse, error)
 	// tiered storage
 	VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTierMoveDatToRemoteResponse], error)
 	VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTierMoveDatFromRemoteResponse], error)
 	VolumeServerStatus(ctx context.Context, in *VolumeServerStatusRequest, opts ...grpc.CallOption) (*VolumeServerStatusResponse, error)
 	VolumeServerLeave(ctx context.Context, in *VolumeServerLeaveRequest, opts ...grpc.CallOption) (*VolumeServerLeaveResponse, error)
+	VolumeServerIoStats(ctx context.Context, in *VolumeServerIoStatsRequest, opts ...grpc.CallOption) (*VolumeServerIoStatsResponse, error)
 	// remote storage
 	FetchAndWriteNeedle(ctx context.Context, in *FetchAndWriteNeedleRequest, opts ...grpc.CallOption) (*FetchAndWriteNeedleResponse, error)
 	// <experimental> query
@@ -237,6 +259,16 @@ func (c *volumeServerClient) VolumeIncrementalCopy(ctx context.Context, in *Volu
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VolumeServer_VolumeIncrementalCopyClient = grpc.ServerStreamingClient[VolumeIncrementalCopyResponse]
 
+func (c *volumeServerClient) VolumeReplicaHints(ctx context.Context, in *VolumeReplicaHintsRequest, opts ...grpc.CallOption) (*VolumeReplicaHintsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeReplicaHintsResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeReplicaHints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *volumeServerClient) VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(VolumeMountResponse)
@@ -297,6 +329,26 @@ func (c *volumeServerClient) VolumeConfigure(ctx context.Context, in *VolumeConf
 	return out, nil
 }
 
+func (c *volumeServerClient) VolumeConvertOffset(ctx context.Context, in *VolumeConvertOffsetRequest, opts ...grpc.CallOption) (*VolumeConvertOffsetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeConvertOffsetResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeConvertOffset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeWormVerify(ctx context.Context, in *VolumeWormVerifyRequest, opts ...grpc.CallOption) (*VolumeWormVerifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeWormVerifyResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeWormVerify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *volumeServerClient) VolumeStatus(ctx context.Context, in *VolumeStatusRequest, opts ...grpc.CallOption) (*VolumeStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(VolumeStatusResponse)
@@ -398,9 +450,22 @@ func (c *volumeServerClient) WriteNeedleBlob(ctx context.Context, in *WriteNeedl
 	return out, nil
 }
 
+func (c *volumeServerClient) ReplicateNeedles(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReplicateNeedlesRequest, ReplicateNeedlesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[5], VolumeServer_ReplicateNeedles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplicateNeedlesRequest, ReplicateNeedlesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VolumeServer_ReplicateNeedlesClient = grpc.BidiStreamingClient[ReplicateNeedlesRequest, ReplicateNeedlesResponse]
+
 func (c *volumeServerClient) ReadAllNeedles(ctx context.Context, in *ReadAllNeedlesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReadAllNeedlesResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[5], VolumeServer_ReadAllNeedles_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[6], VolumeServer_ReadAllNeedles_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +484,7 @@ type VolumeServer_ReadAllNeedlesClient = grpc.ServerStreamingClient[ReadAllNeedl
 
 func (c *volumeServerClient) VolumeTailSender(ctx context.Context, in *VolumeTailSenderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTailSenderResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[6], VolumeServer_VolumeTailSender_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[7], VolumeServer_VolumeTailSender_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -508,7 +573,7 @@ func (c *volumeServerClient) VolumeEcShardsUnmount(ctx context.Context, in *Volu
 
 func (c *volumeServerClient) VolumeEcShardRead(ctx context.Context, in *VolumeEcShardReadRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeEcShardReadResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[7], VolumeServer_VolumeEcShardRead_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[8], VolumeServer_VolumeEcShardRead_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -555,9 +620,69 @@ func (c *volumeServerClient) VolumeEcShardsInfo(ctx context.Context, in *VolumeE
 	return out, nil
 }
 
+func (c *volumeServerClient) VolumeEcShardsVacuumPrepare(ctx context.Context, in *VolumeEcShardsVacuumPrepareRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumPrepareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumPrepareResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumPrepare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsVacuumGenerate(ctx context.Context, in *VolumeEcShardsVacuumGenerateRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumGenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumGenerateResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumGenerate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsVacuumCommit(ctx context.Context, in *VolumeEcShardsVacuumCommitRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumCommitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumCommitResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumCommit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsVacuumCleanup(ctx context.Context, in *VolumeEcShardsVacuumCleanupRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumCleanupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumCleanupResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumCleanup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsVacuumRollback(ctx context.Context, in *VolumeEcShardsVacuumRollbackRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumRollbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumRollbackResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumRollback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *volumeServerClient) VolumeEcShardsVacuumFinalize(ctx context.Context, in *VolumeEcShardsVacuumFinalizeRequest, opts ...grpc.CallOption) (*VolumeEcShardsVacuumFinalizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeEcShardsVacuumFinalizeResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeEcShardsVacuumFinalize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *volumeServerClient) VolumeTierMoveDatToRemote(ctx context.Context, in *VolumeTierMoveDatToRemoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTierMoveDatToRemoteResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[8], VolumeServer_VolumeTierMoveDatToRemote_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[9], VolumeServer_VolumeTierMoveDatToRemote_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -576,7 +701,7 @@ type VolumeServer_VolumeTierMoveDatToRemoteClient = grpc.ServerStreamingClient[V
 
 func (c *volumeServerClient) VolumeTierMoveDatFromRemote(ctx context.Context, in *VolumeTierMoveDatFromRemoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VolumeTierMoveDatFromRemoteResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[9], VolumeServer_VolumeTierMoveDatFromRemote_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[10], VolumeServer_VolumeTierMoveDatFromRemote_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -613,6 +738,16 @@ func (c *volumeServerClient) VolumeServerLeave(ctx context.Context, in *VolumeSe
 	return out, nil
 }
 
+func (c *volumeServerClient) VolumeServerIoStats(ctx context.Context, in *VolumeServerIoStatsRequest, opts ...grpc.CallOption) (*VolumeServerIoStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolumeServerIoStatsResponse)
+	err := c.cc.Invoke(ctx, VolumeServer_VolumeServerIoStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *volumeServerClient) FetchAndWriteNeedle(ctx context.Context, in *FetchAndWriteNeedleRequest, opts ...grpc.CallOption) (*FetchAndWriteNeedleResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FetchAndWriteNeedleResponse)
@@ -625,7 +760,7 @@ func (c *volumeServerClient) FetchAndWriteNeedle(ctx context.Context, in *FetchA
 
 func (c *volumeServerClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[QueriedStripe], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[10], VolumeServer_Query_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &VolumeServer_ServiceDesc.Streams[11], VolumeServer_Query_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -666,7 +801,7 @@ func (c *volumeServerClient) Ping(ctx context.Context, in *PingRequest, opts ...
 // All implementations must embed UnimplementedVolumeServerServer
 // for forward compatibility.
 type VolumeServerServer interface {
-	// Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
+	//Experts only: takes multiple fid parameters. This function does not propagate deletes to replicas.
 	BatchDelete(context.Context, *BatchDeleteRequest) (*BatchDeleteResponse, error)
 	VacuumVolumeCheck(context.Context, *VacuumVolumeCheckRequest) (*VacuumVolumeCheckResponse, error)
 	VacuumVolumeCompact(*VacuumVolumeCompactRequest, grpc.ServerStreamingServer[VacuumVolumeCompactResponse]) error
@@ -676,12 +811,15 @@ type VolumeServerServer interface {
 	AllocateVolume(context.Context, *AllocateVolumeRequest) (*AllocateVolumeResponse, error)
 	VolumeSyncStatus(context.Context, *VolumeSyncStatusRequest) (*VolumeSyncStatusResponse, error)
 	VolumeIncrementalCopy(*VolumeIncrementalCopyRequest, grpc.ServerStreamingServer[VolumeIncrementalCopyResponse]) error
+	VolumeReplicaHints(context.Context, *VolumeReplicaHintsRequest) (*VolumeReplicaHintsResponse, error)
 	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
 	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
 	VolumeDelete(context.Context, *VolumeDeleteRequest) (*VolumeDeleteResponse, error)
 	VolumeMarkReadonly(context.Context, *VolumeMarkReadonlyRequest) (*VolumeMarkReadonlyResponse, error)
 	VolumeMarkWritable(context.Context, *VolumeMarkWritableRequest) (*VolumeMarkWritableResponse, error)
 	VolumeConfigure(context.Context, *VolumeConfigureRequest) (*VolumeConfigureResponse, error)
+	VolumeConvertOffset(context.Context, *VolumeConvertOffsetRequest) (*VolumeConvertOffsetResponse, error)
+	VolumeWormVerify(context.Context, *VolumeWormVerifyRequest) (*VolumeWormVerifyResponse, error)
 	VolumeStatus(context.Context, *VolumeStatusRequest) (*VolumeStatusResponse, error)
 	// copy the .idx .dat files, and mount this volume
 	VolumeCopy(*VolumeCopyRequest, grpc.ServerStreamingServer[VolumeCopyResponse]) error
@@ -691,6 +829,7 @@ type VolumeServerServer interface {
 	ReadNeedleBlob(context.Context, *ReadNeedleBlobRequest) (*ReadNeedleBlobResponse, error)
 	ReadNeedleMeta(context.Context, *ReadNeedleMetaRequest) (*ReadNeedleMetaResponse, error)
 	WriteNeedleBlob(context.Context, *WriteNeedleBlobRequest) (*WriteNeedleBlobResponse, error)
+	ReplicateNeedles(grpc.BidiStreamingServer[ReplicateNeedlesRequest, ReplicateNeedlesResponse]) error
 	ReadAllNeedles(*ReadAllNeedlesRequest, grpc.ServerStreamingServer[ReadAllNeedlesResponse]) error
 	VolumeTailSender(*VolumeTailSenderRequest, grpc.ServerStreamingServer[VolumeTailSenderResponse]) error
 	VolumeTailReceiver(context.Context, *VolumeTailReceiverRequest) (*VolumeTailReceiverResponse, error)
@@ -705,11 +844,18 @@ type VolumeServerServer interface {
 	VolumeEcBlobDelete(context.Context, *VolumeEcBlobDeleteRequest) (*VolumeEcBlobDeleteResponse, error)
 	VolumeEcShardsToVolume(context.Context, *VolumeEcShardsToVolumeRequest) (*VolumeEcShardsToVolumeResponse, error)
 	VolumeEcShardsInfo(context.Context, *VolumeEcShardsInfoRequest) (*VolumeEcShardsInfoResponse, error)
+	VolumeEcShardsVacuumPrepare(context.Context, *VolumeEcShardsVacuumPrepareRequest) (*VolumeEcShardsVacuumPrepareResponse, error)
+	VolumeEcShardsVacuumGenerate(context.Context, *VolumeEcShardsVacuumGenerateRequest) (*VolumeEcShardsVacuumGenerateResponse, error)
+	VolumeEcShardsVacuumCommit(context.Context, *VolumeEcShardsVacuumCommitRequest) (*VolumeEcShardsVacuumCommitResponse, error)
+	VolumeEcShardsVacuumCleanup(context.Context, *VolumeEcShardsVacuumCleanupRequest) (*VolumeEcShardsVacuumCleanupResponse, error)
+	VolumeEcShardsVacuumRollback(context.Context, *VolumeEcShardsVacuumRollbackRequest) (*VolumeEcShardsVacuumRollbackResponse, error)
+	VolumeEcShardsVacuumFinalize(context.Context, *VolumeEcShardsVacuumFinalizeRequest) (*VolumeEcShardsVacuumFinalizeResponse, error)
 	// tiered storage
 	VolumeTierMoveDatToRemote(*VolumeTierMoveDatToRemoteRequest, grpc.ServerStreamingServer[VolumeTierMoveDatToRemoteResponse]) error
 	VolumeTierMoveDatFromRemote(*VolumeTierMoveDatFromRemoteRequest, grpc.ServerStreamingServer[VolumeTierMoveDatFromRemoteResponse]) error
 	VolumeServerStatus(context.Context, *VolumeServerStatusRequest) (*VolumeServerStatusResponse, error)
 	VolumeServerLeave(context.Context, *VolumeServerLeaveRequest) (*VolumeServerLeaveResponse, error)
+	VolumeServerIoStats(context.Context, *VolumeServerIoStatsRequest) (*VolumeServerIoStatsResponse, error)
 	// remote storage
 	FetchAndWriteNeedle(context.Context, *FetchAndWriteNeedleRequest) (*FetchAndWriteNeedleResponse, error)
 	// <experimental> query
@@ -753,6 +899,9 @@ func (UnimplementedVolumeServerServer) VolumeSyncStatus(context.Context, *Volume
 func (UnimplementedVolumeServerServer) VolumeIncrementalCopy(*VolumeIncrementalCopyRequest, grpc.ServerStreamingServer[VolumeIncrementalCopyResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method VolumeIncrementalCopy not implemented")
 }
+func (UnimplementedVolumeServerServer) VolumeReplicaHints(context.Context, *VolumeReplicaHintsRequest) (*VolumeReplicaHintsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeReplicaHints not implemented")
+}
 func (UnimplementedVolumeServerServer) VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeMount not implemented")
 }
@@ -771,6 +920,12 @@ func (UnimplementedVolumeServerServer) VolumeMarkWritable(context.Context, *Volu
 func (UnimplementedVolumeServerServer) VolumeConfigure(context.Context, *VolumeConfigureRequest) (*VolumeConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeConfigure not implemented")
 }
+func (UnimplementedVolumeServerServer) VolumeConvertOffset(context.Context, *VolumeConvertOffsetRequest) (*VolumeConvertOffsetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeConvertOffset not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeWormVerify(context.Context, *VolumeWormVerifyRequest) (*VolumeWormVerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeWormVerify not implemented")
+}
 func (UnimplementedVolumeServerServer) VolumeStatus(context.Context, *VolumeStatusRequest) (*VolumeStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeStatus not implemented")
 }
@@ -795,6 +950,9 @@ func (UnimplementedVolumeServerServer) ReadNeedleMeta(context.Context, *ReadNeed
 func (UnimplementedVolumeServerServer) WriteNeedleBlob(context.Context, *WriteNeedleBlobRequest) (*WriteNeedleBlobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteNeedleBlob not implemented")
 }
+func (UnimplementedVolumeServerServer) ReplicateNeedles(grpc.BidiStreamingServer[ReplicateNeedlesRequest, ReplicateNeedlesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReplicateNeedles not implemented")
+}
 func (UnimplementedVolumeServerServer) ReadAllNeedles(*ReadAllNeedlesRequest, grpc.ServerStreamingServer[ReadAllNeedlesResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ReadAllNeedles not implemented")
 }
@@ -834,6 +992,24 @@ func (UnimplementedVolumeServerServer) VolumeEcShardsToVolume(context.Context, *
 func (UnimplementedVolumeServerServer) VolumeEcShardsInfo(context.Context, *VolumeEcShardsInfoRequest) (*VolumeEcShardsInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsInfo not implemented")
 }
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumPrepare(context.Context, *VolumeEcShardsVacuumPrepareRequest) (*VolumeEcShardsVacuumPrepareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumPrepare not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumGenerate(context.Context, *VolumeEcShardsVacuumGenerateRequest) (*VolumeEcShardsVacuumGenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumGenerate not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumCommit(context.Context, *VolumeEcShardsVacuumCommitRequest) (*VolumeEcShardsVacuumCommitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumCommit not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumCleanup(context.Context, *VolumeEcShardsVacuumCleanupRequest) (*VolumeEcShardsVacuumCleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumCleanup not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumRollback(context.Context, *VolumeEcShardsVacuumRollbackRequest) (*VolumeEcShardsVacuumRollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumRollback not implemented")
+}
+func (UnimplementedVolumeServerServer) VolumeEcShardsVacuumFinalize(context.Context, *VolumeEcShardsVacuumFinalizeRequest) (*VolumeEcShardsVacuumFinalizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeEcShardsVacuumFinalize not implemented")
+}
 func (UnimplementedVolumeServerServer) VolumeTierMoveDatToRemote(*VolumeTierMoveDatToRemoteRequest, grpc.ServerStreamingServer[VolumeTierMoveDatToRemoteResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method VolumeTierMoveDatToRemote not implemented")
 }
@@ -846,6 +1022,9 @@ func (UnimplementedVolumeServerServer) VolumeServerStatus(context.Context, *Volu
 func (UnimplementedVolumeServerServer) VolumeServerLeave(context.Context, *VolumeServerLeaveRequest) (*VolumeServerLeaveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VolumeServerLeave not implemented")
 }
+func (UnimplementedVolumeServerServer) VolumeServerIoStats(context.Context, *VolumeServerIoStatsRequest) (*VolumeServerIoStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeServerIoStats not implemented")
+}
 func (UnimplementedVolumeServerServer) FetchAndWriteNeedle(context.Context, *FetchAndWriteNeedleRequest) (*FetchAndWriteNeedleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchAndWriteNeedle not implemented")
 }
@@ -1027,6 +1206,24 @@ func _VolumeServer_VolumeIncrementalCopy_Handler(srv interface{}, stream grpc.Se
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type VolumeServer_VolumeIncrementalCopyServer = grpc.ServerStreamingServer[VolumeIncrementalCopyResponse]
 
+func _VolumeServer_VolumeReplicaHints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeReplicaHintsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeReplicaHints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeReplicaHints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeReplicaHints(ctx, req.(*VolumeReplicaHintsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeServer_VolumeMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VolumeMountRequest)
 	if err := dec(in); err != nil {
@@ -1135,6 +1332,42 @@ func _VolumeServer_VolumeConfigure_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeServer_VolumeConvertOffset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeConvertOffsetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeConvertOffset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeConvertOffset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeConvertOffset(ctx, req.(*VolumeConvertOffsetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeWormVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeWormVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeWormVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeWormVerify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeWormVerify(ctx, req.(*VolumeWormVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeServer_VolumeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VolumeStatusRequest)
 	if err := dec(in); err != nil {
@@ -1254,6 +1487,13 @@ func _VolumeServer_WriteNeedleBlob_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeServer_ReplicateNeedles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VolumeServerServer).ReplicateNeedles(&grpc.GenericServerStream[ReplicateNeedlesRequest, ReplicateNeedlesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VolumeServer_ReplicateNeedlesServer = grpc.BidiStreamingServer[ReplicateNeedlesRequest, ReplicateNeedlesResponse]
+
 func _VolumeServer_ReadAllNeedles_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ReadAllNeedlesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1467,6 +1707,114 @@ func _VolumeServer_VolumeEcShardsInfo_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeServer_VolumeEcShardsVacuumPrepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumPrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumPrepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumPrepare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumPrepare(ctx, req.(*VolumeEcShardsVacuumPrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsVacuumGenerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumGenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumGenerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumGenerate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumGenerate(ctx, req.(*VolumeEcShardsVacuumGenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsVacuumCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumCommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumCommit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumCommit(ctx, req.(*VolumeEcShardsVacuumCommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsVacuumCleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumCleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumCleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumCleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumCleanup(ctx, req.(*VolumeEcShardsVacuumCleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsVacuumRollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumRollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumRollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumRollback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumRollback(ctx, req.(*VolumeEcShardsVacuumRollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VolumeServer_VolumeEcShardsVacuumFinalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeEcShardsVacuumFinalizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumFinalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeEcShardsVacuumFinalize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeEcShardsVacuumFinalize(ctx, req.(*VolumeEcShardsVacuumFinalizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeServer_VolumeTierMoveDatToRemote_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(VolumeTierMoveDatToRemoteRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1525,6 +1873,24 @@ func _VolumeServer_VolumeServerLeave_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeServer_VolumeServerIoStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeServerIoStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeServerServer).VolumeServerIoStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VolumeServer_VolumeServerIoStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeServerServer).VolumeServerIoStats(ctx, req.(*VolumeServerIoStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VolumeServer_FetchAndWriteNeedle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FetchAndWriteNeedleRequest)
 	if err := dec(in); err != nil {
@@ -1625,6 +1991,10 @@ var VolumeServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeSyncStatus",
 			Handler:    _VolumeServer_VolumeSyncStatus_Handler,
 		},
+		{
+			MethodName: "VolumeReplicaHints",
+			Handler:    _VolumeServer_VolumeReplicaHints_Handler,
+		},
 		{
 			MethodName: "VolumeMount",
 			Handler:    _VolumeServer_VolumeMount_Handler,
@@ -1649,6 +2019,14 @@ var VolumeServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeConfigure",
 			Handler:    _VolumeServer_VolumeConfigure_Handler,
 		},
+		{
+			MethodName: "VolumeConvertOffset",
+			Handler:    _VolumeServer_VolumeConvertOffset_Handler,
+		},
+		{
+			MethodName: "VolumeWormVerify",
+			Handler:    _VolumeServer_VolumeWormVerify_Handler,
+		},
 		{
 			MethodName: "VolumeStatus",
 			Handler:    _VolumeServer_VolumeStatus_Handler,
@@ -1709,6 +2087,30 @@ var VolumeServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeEcShardsInfo",
 			Handler:    _VolumeServer_VolumeEcShardsInfo_Handler,
 		},
+		{
+			MethodName: "VolumeEcShardsVacuumPrepare",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumPrepare_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsVacuumGenerate",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumGenerate_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsVacuumCommit",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumCommit_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsVacuumCleanup",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumCleanup_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsVacuumRollback",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumRollback_Handler,
+		},
+		{
+			MethodName: "VolumeEcShardsVacuumFinalize",
+			Handler:    _VolumeServer_VolumeEcShardsVacuumFinalize_Handler,
+		},
 		{
 			MethodName: "VolumeServerStatus",
 			Handler:    _VolumeServer_VolumeServerStatus_Handler,
@@ -1717,6 +2119,10 @@ var VolumeServer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VolumeServerLeave",
 			Handler:    _VolumeServer_VolumeServerLeave_Handler,
 		},
+		{
+			MethodName: "VolumeServerIoStats",
+			Handler:    _VolumeServer_VolumeServerIoStats_Handler,
+		},
 		{
 			MethodName: "FetchAndWriteNeedle",
 			Handler:    _VolumeServer_FetchAndWriteNeedle_Handler,
@@ -1756,6 +2162,12 @@ var VolumeServer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _VolumeServer_ReceiveFile_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReplicateNeedles",
+			Handler:       _VolumeServer_ReplicateNeedles_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "ReadAllNeedles",
 			Handler:       _VolumeServer_ReadAllNeedles_Handler,
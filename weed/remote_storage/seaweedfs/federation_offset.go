@@ -0,0 +1,62 @@
+package seaweedfs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+const (
+	peerOffsetKeyPrefix  = "federation.peer."
+	localOffsetKeyPrefix = "federation.local."
+)
+
+// PeerOffsetKey is the filer kv key of the peer metadata change applied to a federated directory
+func PeerOffsetKey(dir string) []byte {
+	return []byte(peerOffsetKeyPrefix + dir)
+}
+
+// LocalOffsetKey is the filer kv key of the local metadata change forwarded from a federated directory
+func LocalOffsetKey(dir string) []byte {
+	return []byte(localOffsetKeyPrefix + dir)
+}
+
+func EncodeOffset(offsetTsNs int64) []byte {
+	valueBuf := make([]byte, 8)
+	util.Uint64toBytes(valueBuf, uint64(offsetTsNs))
+	return valueBuf
+}
+
+func DecodeOffset(value []byte) int64 {
+	if len(value) < 8 {
+		return 0
+	}
+	return int64(util.BytesToUint64(value))
+}
+
+func GetOffset(client filer_pb.SeaweedFilerClient, key []byte) (offsetTsNs int64, err error) {
+	resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: key})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Error) != 0 {
+		return 0, errors.New(resp.Error)
+	}
+	return DecodeOffset(resp.Value), nil
+}
+
+func SetOffset(client filer_pb.SeaweedFilerClient, key []byte, offsetTsNs int64) error {
+	resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{
+		Key:   key,
+		Value: EncodeOffset(offsetTsNs),
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Error) != 0 {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package seaweedfs
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/remote_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/grpc"
+)
+
+// FederationSignature marks the metadata changes made by the federation,
+// so the followers on both clusters can skip the changes they made themselves.
+var FederationSignature = int32(util.HashStringToLong("seaweedfs.federation"))
+
+const uploadChunkSize = 4 * 1024 * 1024
+
+func init() {
+	remote_storage.RemoteStorageClientMakers["seaweedfs"] = new(seaweedfsRemoteStorageMaker)
+}
+
+type seaweedfsRemoteStorageMaker struct{}
+
+func (s seaweedfsRemoteStorageMaker) HasBucket() bool {
+	return false
+}
+
+func (s seaweedfsRemoteStorageMaker) Make(conf *remote_pb.RemoteConf) (remote_storage.RemoteStorageClient, error) {
+	filers := pb.ServerAddresses(conf.SeaweedfsFilers).ToAddresses()
+	if len(filers) == 0 {
+		return nil, fmt.Errorf("seaweedfs remote storage %s: no peer filer address", conf.Name)
+	}
+	return &seaweedfsRemoteStorageClient{
+		conf:           conf,
+		filers:         filers,
+		grpcDialOption: security.LoadClientTLS(util.GetViper(), "grpc.client"),
+	}, nil
+}
+
+// seaweedfsRemoteStorageClient treats a directory on another SeaweedFS cluster as a remote storage.
+// The metadata goes through the native filer gRPC of the peer cluster,
+// and the file content is read from and written to the peer volume servers directly.
+type seaweedfsRemoteStorageClient struct {
+	conf           *remote_pb.RemoteConf
+	filers         []pb.ServerAddress
+	grpcDialOption grpc.DialOption
+}
+
+var _ = remote_storage.RemoteStorageClient(&seaweedfsRemoteStorageClient{})
+var _ = filer_pb.FilerClient(&seaweedfsRemoteStorageClient{})
+
+func (s *seaweedfsRemoteStorageClient) WithFilerClient(streamingMode bool, fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithOneOfGrpcFilerClients(streamingMode, s.filers, s.grpcDialOption, fn)
+}
+
+func (s *seaweedfsRemoteStorageClient) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (s *seaweedfsRemoteStorageClient) GetDataCenter() string {
+	return ""
+}
+
+// Filers returns the peer filer addresses
+func (s *seaweedfsRemoteStorageClient) Filers() []pb.ServerAddress {
+	return s.filers
+}
+
+// ToRemoteEntry describes an entry on the peer cluster as a remote entry of the local cluster
+func ToRemoteEntry(storageName string, entry *filer_pb.Entry) *filer_pb.RemoteEntry {
+	return &filer_pb.RemoteEntry{
+		StorageName: storageName,
+		RemoteMtime: entry.GetAttributes().GetMtime(),
+		RemoteSize:  int64(filer.FileSize(entry)),
+		RemoteETag:  filer.ETag(entry),
+	}
+}
+
+func (s *seaweedfsRemoteStorageClient) Traverse(loc *remote_pb.RemoteStorageLocation, visitFn remote_storage.VisitFunc) (err error) {
+	return filer_pb.TraverseBfs(context.Background(), s, util.FullPath(loc.Path), func(parentPath util.FullPath, entry *filer_pb.Entry) error {
+		return visitFn(string(parentPath), entry.Name, entry.IsDirectory, ToRemoteEntry(s.conf.Name, entry))
+	})
+}
+
+func (s *seaweedfsRemoteStorageClient) lookup(loc *remote_pb.RemoteStorageLocation) (entry *filer_pb.Entry, err error) {
+	entry, err = filer_pb.GetEntry(context.Background(), s, util.FullPath(loc.Path))
+	if err == nil && entry == nil {
+		err = filer_pb.ErrNotFound
+	}
+	return
+}
+
+func (s *seaweedfsRemoteStorageClient) ReadFile(loc *remote_pb.RemoteStorageLocation, offset int64, size int64) (data []byte, err error) {
+	entry, err := s.lookup(loc)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s%s: %w", s.conf.Name, loc.Path, err)
+	}
+
+	if len(entry.Content) > 0 {
+		if offset >= int64(len(entry.Content)) {
+			return nil, io.EOF
+		}
+		return entry.Content[offset:min(offset+size, int64(len(entry.Content)))], nil
+	}
+
+	reader := filer.NewChunkStreamReader(s, entry.GetChunks())
+	defer reader.Close()
+
+	data = make([]byte, size)
+	n, err := reader.ReadAt(data, offset)
+	if err != nil && !(err == io.EOF && n > 0) {
+		return nil, fmt.Errorf("read %s%s [%d,%d): %w", s.conf.Name, loc.Path, offset, offset+size, err)
+	}
+	return data[:n], nil
+}
+
+func (s *seaweedfsRemoteStorageClient) WriteDirectory(loc *remote_pb.RemoteStorageLocation, entry *filer_pb.Entry) (err error) {
+	dir, name := util.FullPath(loc.Path).DirAndName()
+	return s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory: dir,
+			Entry: &filer_pb.Entry{
+				Name:        name,
+				IsDirectory: true,
+				Attributes:  entry.Attributes,
+				Extended:    entry.Extended,
+			},
+			Signatures: []int32{FederationSignature},
+		})
+	})
+}
+
+func (s *seaweedfsRemoteStorageClient) RemoveDirectory(loc *remote_pb.RemoteStorageLocation) (err error) {
+	return s.remove(loc, true)
+}
+
+func (s *seaweedfsRemoteStorageClient) WriteFile(loc *remote_pb.RemoteStorageLocation, entry *filer_pb.Entry, reader io.Reader) (remoteEntry *filer_pb.RemoteEntry, err error) {
+	dir, name := util.FullPath(loc.Path).DirAndName()
+
+	assignFunc := func(ctx context.Context, count int) (*operation.VolumeAssignRequest, *operation.AssignResult, error) {
+		var assignResult *filer_pb.AssignVolumeResponse
+		err := s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.AssignVolume(ctx, &filer_pb.AssignVolumeRequest{
+				Count: int32(count),
+				Path:  loc.Path,
+			})
+			if err != nil {
+				return fmt.Errorf("assign volume: %w", err)
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("assign volume: %v", resp.Error)
+			}
+			assignResult = resp
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &operation.AssignResult{
+			Fid:       assignResult.FileId,
+			Url:       assignResult.Location.Url,
+			PublicUrl: assignResult.Location.PublicUrl,
+			Count:     uint64(count),
+			Auth:      security.EncodedJwt(assignResult.Auth),
+		}, nil
+	}
+
+	var mimeType string
+	if entry.Attributes != nil {
+		mimeType = entry.Attributes.Mime
+	}
+	uploadResult, err := operation.UploadReaderInChunks(context.Background(), reader, &operation.ChunkedUploadOption{
+		ChunkSize:  uploadChunkSize,
+		MimeType:   mimeType,
+		AssignFunc: assignFunc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upload to %s%s: %w", s.conf.Name, loc.Path, err)
+	}
+
+	attributes := &filer_pb.FuseAttributes{}
+	if entry.Attributes != nil {
+		attributes.Mtime = entry.Attributes.Mtime
+		attributes.Crtime = entry.Attributes.Crtime
+		attributes.FileMode = entry.Attributes.FileMode
+		attributes.Uid = entry.Attributes.Uid
+		attributes.Gid = entry.Attributes.Gid
+		attributes.Mime = entry.Attributes.Mime
+	}
+	attributes.FileSize = uint64(uploadResult.TotalSize)
+	attributes.Md5 = uploadResult.Md5Hash.Sum(nil)
+
+	remoteFileEntry := &filer_pb.Entry{
+		Name:       name,
+		Attributes: attributes,
+		Chunks:     uploadResult.FileChunks,
+		Extended:   entry.Extended,
+	}
+	if err = s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(context.Background(), client, &filer_pb.CreateEntryRequest{
+			Directory:  dir,
+			Entry:      remoteFileEntry,
+			Signatures: []int32{FederationSignature},
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("create %s%s: %w", s.conf.Name, loc.Path, err)
+	}
+
+	return ToRemoteEntry(s.conf.Name, remoteFileEntry), nil
+}
+
+func (s *seaweedfsRemoteStorageClient) UpdateFileMetadata(loc *remote_pb.RemoteStorageLocation, oldEntry *filer_pb.Entry, newEntry *filer_pb.Entry) (err error) {
+	dir, _ := util.FullPath(loc.Path).DirAndName()
+	existing, err := s.lookup(loc)
+	if err != nil {
+		return fmt.Errorf("lookup %s%s: %w", s.conf.Name, loc.Path, err)
+	}
+	mergeEntryMetadata(existing, newEntry)
+	return s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(context.Background(), client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      existing,
+			Signatures: []int32{FederationSignature},
+		})
+	})
+}
+
+// mergeEntryMetadata copies the metadata of the new entry onto the peer entry, keeping the peer content
+func mergeEntryMetadata(existing *filer_pb.Entry, newEntry *filer_pb.Entry) {
+	if newEntry.Attributes != nil {
+		if existing.Attributes == nil {
+			existing.Attributes = &filer_pb.FuseAttributes{}
+		}
+		existing.Attributes.FileMode = newEntry.Attributes.FileMode
+		existing.Attributes.Uid = newEntry.Attributes.Uid
+		existing.Attributes.Gid = newEntry.Attributes.Gid
+		existing.Attributes.Mime = newEntry.Attributes.Mime
+		existing.Attributes.Mtime = newEntry.Attributes.Mtime
+	}
+	existing.Extended = newEntry.Extended
+}
+
+func (s *seaweedfsRemoteStorageClient) DeleteFile(loc *remote_pb.RemoteStorageLocation) (err error) {
+	return s.remove(loc, false)
+}
+
+func (s *seaweedfsRemoteStorageClient) remove(loc *remote_pb.RemoteStorageLocation, isRecursive bool) error {
+	dir, name := util.FullPath(loc.Path).DirAndName()
+	return s.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.DoRemove(context.Background(), client, dir, name, true, isRecursive, true, false, []int32{FederationSignature})
+	})
+}
+
+func (s *seaweedfsRemoteStorageClient) ListBuckets() (buckets []*remote_storage.Bucket, err error) {
+	return nil, nil
+}
+
+func (s *seaweedfsRemoteStorageClient) CreateBucket(name string) (err error) {
+	return fmt.Errorf("seaweedfs remote storage %s does not have buckets", s.conf.Name)
+}
+
+func (s *seaweedfsRemoteStorageClient) DeleteBucket(name string) (err error) {
+	return fmt.Errorf("seaweedfs remote storage %s does not have buckets", s.conf.Name)
+}
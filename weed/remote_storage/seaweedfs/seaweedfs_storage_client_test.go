@@ -0,0 +1,83 @@
+package seaweedfs
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/remote_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage"
+)
+
+func TestParseFederatedLocation(t *testing.T) {
+	loc, err := remote_storage.ParseRemoteLocation("seaweedfs", "east/data/logs/")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if loc.Name != "east" || loc.Bucket != "" || loc.Path != "/data/logs" {
+		t.Errorf("unexpected location %+v", loc)
+	}
+	if formatted := remote_storage.FormatLocation(loc); formatted != "east/data/logs" {
+		t.Errorf("format: %s", formatted)
+	}
+
+	loc, _ = remote_storage.ParseRemoteLocation("seaweedfs", "east")
+	if loc.Path != "/" {
+		t.Errorf("root path: %+v", loc)
+	}
+}
+
+func TestMakeRequiresFilers(t *testing.T) {
+	maker := remote_storage.RemoteStorageClientMakers["seaweedfs"]
+	if _, err := maker.Make(&remote_pb.RemoteConf{Name: "east", Type: "seaweedfs"}); err == nil {
+		t.Errorf("expected error without peer filers")
+	}
+	client, err := maker.Make(&remote_pb.RemoteConf{Name: "east", Type: "seaweedfs", SeaweedfsFilers: "east-filer1:8888,east-filer2:8888"})
+	if err != nil {
+		t.Fatalf("make: %v", err)
+	}
+	if filers := client.(*seaweedfsRemoteStorageClient).Filers(); len(filers) != 2 || filers[1] != "east-filer2:8888" {
+		t.Errorf("unexpected filers %v", filers)
+	}
+}
+
+func TestToRemoteEntry(t *testing.T) {
+	entry := &filer_pb.Entry{
+		Name: "a.txt",
+		Attributes: &filer_pb.FuseAttributes{
+			Mtime:    1700000000,
+			FileSize: 11,
+			Md5:      []byte{0xab, 0xcd},
+		},
+	}
+	remoteEntry := ToRemoteEntry("east", entry)
+	if remoteEntry.StorageName != "east" || remoteEntry.RemoteMtime != 1700000000 || remoteEntry.RemoteSize != 11 || remoteEntry.RemoteETag != "abcd" {
+		t.Errorf("unexpected remote entry %+v", remoteEntry)
+	}
+}
+
+func TestOffsetEncoding(t *testing.T) {
+	if DecodeOffset(nil) != 0 {
+		t.Errorf("empty offset should be 0")
+	}
+	if DecodeOffset(EncodeOffset(1234567890123)) != 1234567890123 {
+		t.Errorf("offset round trip")
+	}
+	if string(PeerOffsetKey("/east")) == string(LocalOffsetKey("/east")) {
+		t.Errorf("peer and local offsets should use different keys")
+	}
+}
+
+func TestMergeEntryMetadataWithoutAttributes(t *testing.T) {
+	existing := &filer_pb.Entry{Name: "a.txt"}
+	mergeEntryMetadata(existing, &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{FileMode: 0644, Uid: 7, Mtime: 1700000000},
+		Extended:   map[string][]byte{"k": []byte("v")},
+	})
+	if existing.Attributes == nil || existing.Attributes.FileMode != 0644 || existing.Attributes.Uid != 7 || existing.Attributes.Mtime != 1700000000 {
+		t.Errorf("unexpected attributes %+v", existing.Attributes)
+	}
+	if string(existing.Extended["k"]) != "v" {
+		t.Errorf("unexpected extended %v", existing.Extended)
+	}
+}
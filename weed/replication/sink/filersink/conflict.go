@@ -0,0 +1,359 @@
+package filersink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// ConflictPolicy decides which version survives when both sides of an
+// active-active filer.sync changed the same file concurrently.
+type ConflictPolicy string
+
+const (
+	// ConflictNewestWins keeps the version with the later mtime.
+	ConflictNewestWins ConflictPolicy = "newest-wins"
+	// ConflictKeepBoth keeps the newest version at the path, and saves the
+	// other one next to it as "<name>.conflict-<signature>-<mtime>".
+	ConflictKeepBoth ConflictPolicy = "keep-both"
+	// ConflictPreferA always keeps the version of filer A.
+	ConflictPreferA ConflictPolicy = "prefer-a"
+	// ConflictPreferB always keeps the version of filer B.
+	ConflictPreferB ConflictPolicy = "prefer-b"
+)
+
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(strings.TrimSpace(s)); policy {
+	case ConflictNewestWins, ConflictKeepBoth, ConflictPreferA, ConflictPreferB:
+		return policy, nil
+	case "":
+		return ConflictNewestWins, nil
+	}
+	return "", fmt.Errorf("unknown conflict policy %q, expecting %s, %s, %s or %s", s, ConflictNewestWins, ConflictKeepBoth, ConflictPreferA, ConflictPreferB)
+}
+
+type pathConflictPolicy struct {
+	prefix string
+	policy ConflictPolicy
+}
+
+// ConflictPolicies holds the default policy and the per path overrides.
+// Paths are relative to the synchronized directory, and the longest matching prefix wins.
+type ConflictPolicies struct {
+	defaultPolicy ConflictPolicy
+	paths         []pathConflictPolicy
+}
+
+// ParseConflictPolicies parses the default policy and a comma separated list of <path>=<policy>.
+func ParseConflictPolicies(defaultPolicy string, pathPolicies string) (*ConflictPolicies, error) {
+	policy, err := ParseConflictPolicy(defaultPolicy)
+	if err != nil {
+		return nil, err
+	}
+	policies := &ConflictPolicies{defaultPolicy: policy}
+	for _, item := range util.StringSplit(pathPolicies, ",") {
+		path, value, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid conflict policy %q, expecting <path>=<policy>", item)
+		}
+		p, err := ParseConflictPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		prefix := "/" + strings.Trim(strings.TrimSpace(path), "/")
+		policies.paths = append(policies.paths, pathConflictPolicy{prefix: prefix, policy: p})
+	}
+	sort.Slice(policies.paths, func(i, j int) bool {
+		return len(policies.paths[i].prefix) > len(policies.paths[j].prefix)
+	})
+	return policies, nil
+}
+
+func (p *ConflictPolicies) policyFor(relativePath string) ConflictPolicy {
+	for _, pp := range p.paths {
+		if pp.prefix == "/" || relativePath == pp.prefix || strings.HasPrefix(relativePath, pp.prefix+"/") {
+			return pp.policy
+		}
+	}
+	return p.defaultPolicy
+}
+
+// ConflictRecord is one resolved conflict, written as a JSON line to the conflict log.
+type ConflictRecord struct {
+	Time          time.Time `json:"time"`
+	Path          string    `json:"path"`
+	Type          string    `json:"type"`
+	Policy        string    `json:"policy"`
+	Resolution    string    `json:"resolution"`
+	SourceFiler   string    `json:"sourceFiler"`
+	TargetFiler   string    `json:"targetFiler"`
+	SourceVersion string    `json:"sourceVersion,omitempty"`
+	TargetVersion string    `json:"targetVersion,omitempty"`
+	ConflictCopy  string    `json:"conflictCopy,omitempty"`
+}
+
+// ConflictLog appends conflict records to a file. It is shared by both sync directions.
+type ConflictLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewConflictLog(fileName string) (*ConflictLog, error) {
+	if fileName == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open conflict log %s: %v", fileName, err)
+	}
+	return &ConflictLog{file: f}, nil
+}
+
+func (l *ConflictLog) write(record *ConflictRecord) {
+	if l == nil {
+		return
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err = l.file.Write(append(data, '\n')); err != nil {
+		glog.Warningf("write conflict log: %v", err)
+	}
+}
+
+const (
+	conflictResolutionSource   = "source"
+	conflictResolutionTarget   = "target"
+	conflictResolutionKeepBoth = "keep-both"
+)
+
+// ConflictResolver detects concurrent changes for one direction of filer.sync.
+type ConflictResolver struct {
+	policies        *ConflictPolicies
+	log             *ConflictLog
+	sourceFiler     string
+	targetFiler     string
+	sourceSignature int32
+	targetSignature int32
+	sourceIsA       bool
+}
+
+func NewConflictResolver(policies *ConflictPolicies, log *ConflictLog, sourceFiler, targetFiler string, sourceSignature, targetSignature int32, sourceIsA bool) *ConflictResolver {
+	return &ConflictResolver{
+		policies:        policies,
+		log:             log,
+		sourceFiler:     sourceFiler,
+		targetFiler:     targetFiler,
+		sourceSignature: sourceSignature,
+		targetSignature: targetSignature,
+		sourceIsA:       sourceIsA,
+	}
+}
+
+// sourceWins decides between two concurrently modified versions of a file.
+func (r *ConflictResolver) sourceWins(policy ConflictPolicy, sourceEntry, targetEntry *filer_pb.Entry) bool {
+	switch policy {
+	case ConflictPreferA:
+		return r.sourceIsA
+	case ConflictPreferB:
+		return !r.sourceIsA
+	}
+	sourceMtime, targetMtime := sourceEntry.GetAttributes().GetMtime(), targetEntry.GetAttributes().GetMtime()
+	if sourceMtime != targetMtime {
+		return sourceMtime > targetMtime
+	}
+	// both directions must agree on the winner
+	return r.sourceSignature > r.targetSignature
+}
+
+// deleterWins decides between a deletion and a concurrent modification.
+// Only the prefer-a and prefer-b policies let a deletion discard a modification.
+func (r *ConflictResolver) deleterWins(policy ConflictPolicy, sourceDeleted bool) bool {
+	switch policy {
+	case ConflictPreferA:
+		return r.sourceIsA == sourceDeleted
+	case ConflictPreferB:
+		return r.sourceIsA != sourceDeleted
+	}
+	return false
+}
+
+func (r *ConflictResolver) conflictName(name string, entry *filer_pb.Entry, signature int32) string {
+	return fmt.Sprintf("%s.conflict-%d-%d", name, signature, entry.GetAttributes().GetMtime())
+}
+
+func (r *ConflictResolver) record(key, eventType string, policy ConflictPolicy, resolution string, sourceVV, targetVV VersionVector, conflictCopy string) {
+	glog.V(0).Infof("conflict %s %s: policy %s, kept %s %s", eventType, key, policy, resolution, conflictCopy)
+	stats.FilerSyncConflictCounter.WithLabelValues(r.sourceFiler, r.targetFiler, string(policy), resolution).Inc()
+	record := &ConflictRecord{
+		Time:         time.Now(),
+		Path:         key,
+		Type:         eventType,
+		Policy:       string(policy),
+		Resolution:   resolution,
+		SourceFiler:  r.sourceFiler,
+		TargetFiler:  r.targetFiler,
+		ConflictCopy: conflictCopy,
+	}
+	if sourceVV != nil {
+		record.SourceVersion = string(sourceVV.encode())
+	}
+	if targetVV != nil {
+		record.TargetVersion = string(targetVV.encode())
+	}
+	r.log.write(record)
+}
+
+func (fs *FilerSink) relativePath(key string) string {
+	return "/" + strings.Trim(strings.TrimPrefix(key, fs.dir), "/")
+}
+
+// resolveConcurrentWrite compares the versions of a file on the source and the target.
+// It returns whether the source version should be written, and the version vector to record with it.
+// If the target version wins, it is marked as having seen the source version.
+// With the keep-both policy, the losing version is kept as a conflict copy, and movedAside
+// reports that the target version has been moved away from key.
+func (fs *FilerSink) resolveConcurrentWrite(client filer_pb.SeaweedFilerClient, key, eventType string, oldEntry, sourceEntry, targetEntry *filer_pb.Entry, signatures []int32) (merged VersionVector, apply bool, movedAside bool, err error) {
+	r := fs.conflictResolver
+	sourceVV := entryVersionVector(sourceEntry, r.sourceSignature)
+	targetVV := entryVersionVector(targetEntry, r.targetSignature)
+	merged = sourceVV.merge(targetVV)
+
+	if oldEntry != nil && filer.ETag(oldEntry) == filer.ETag(targetEntry) {
+		// the target still has the version that the source changed
+		return merged, true, false, nil
+	}
+	switch sourceVV.compare(targetVV) {
+	case vectorEqual, vectorAfter:
+		return merged, true, false, nil
+	case vectorBefore:
+		glog.V(2).Infof("skip stale %s %s", eventType, key)
+		return nil, false, false, nil
+	}
+
+	policy := r.policies.policyFor(fs.relativePath(key))
+	dir, name := util.FullPath(key).DirAndName()
+	ctx := context.Background()
+
+	if r.sourceWins(policy, sourceEntry, targetEntry) {
+		resolution, conflictCopy := conflictResolutionSource, ""
+		if policy == ConflictKeepBoth {
+			// the other direction creates the same copy on the source filer
+			resolution, conflictCopy = conflictResolutionKeepBoth, r.conflictName(name, targetEntry, r.targetSignature)
+			if _, err = client.AtomicRenameEntry(ctx, &filer_pb.AtomicRenameEntryRequest{
+				OldDirectory: dir,
+				OldName:      name,
+				NewDirectory: dir,
+				NewName:      conflictCopy,
+				Signatures:   signatures,
+			}); err != nil {
+				return nil, false, false, fmt.Errorf("keep conflict copy %s/%s: %v", dir, conflictCopy, err)
+			}
+			movedAside = true
+		}
+		r.record(key, eventType, policy, resolution, sourceVV, targetVV, conflictCopy)
+		return merged, true, movedAside, nil
+	}
+
+	resolution, conflictCopy := conflictResolutionTarget, ""
+	if policy == ConflictKeepBoth {
+		resolution, conflictCopy = conflictResolutionKeepBoth, r.conflictName(name, sourceEntry, r.sourceSignature)
+		copyEntry := proto.Clone(sourceEntry).(*filer_pb.Entry)
+		copyEntry.Name = conflictCopy
+		if err = fs.createEntry(client, util.Join(dir, conflictCopy), copyEntry, sourceVV, signatures); err != nil {
+			return nil, false, false, err
+		}
+	}
+
+	// record that the target has seen the source version, so later changes do not conflict again
+	stampVersionVector(targetEntry, merged)
+	if _, err = client.UpdateEntry(ctx, &filer_pb.UpdateEntryRequest{
+		Directory:          dir,
+		Entry:              targetEntry,
+		IsFromOtherCluster: true,
+		Signatures:         signatures,
+	}); err != nil {
+		return nil, false, false, fmt.Errorf("update version of %s: %v", key, err)
+	}
+	r.record(key, eventType, policy, resolution, sourceVV, targetVV, conflictCopy)
+	return nil, false, false, nil
+}
+
+// keepDeletionOverUpdate is called when an updated file no longer exists on the target.
+// If the target had the file before, it was deleted concurrently with the update,
+// and the update is dropped only if the policy prefers the deleting target.
+func (fs *FilerSink) keepDeletionOverUpdate(key string, sourceEntry *filer_pb.Entry) bool {
+	r := fs.conflictResolver
+	sourceVV := entryVersionVector(sourceEntry, r.sourceSignature)
+	if _, seen := sourceVV[r.targetSignature]; !seen {
+		return false
+	}
+	policy := r.policies.policyFor(fs.relativePath(key))
+	if r.deleterWins(policy, false) {
+		r.record(key, "delete", policy, conflictResolutionTarget, sourceVV, nil, "")
+		return true
+	}
+	r.record(key, "delete", policy, conflictResolutionSource, sourceVV, nil, "")
+	return false
+}
+
+// DeleteEntryVersion deletes the file at key unless the target has modified it
+// concurrently and the conflict policy keeps the modification.
+func (fs *FilerSink) DeleteEntryVersion(key string, oldEntry *filer_pb.Entry, deleteIncludeChunks bool, signatures []int32) error {
+	if fs.conflictResolver == nil || oldEntry == nil || oldEntry.IsDirectory {
+		return fs.DeleteEntry(key, oldEntry.GetIsDirectory(), deleteIncludeChunks, signatures)
+	}
+
+	dir, name := util.FullPath(key).DirAndName()
+	var targetEntry *filer_pb.Entry
+	err := fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, lookupErr := filer_pb.LookupEntry(context.Background(), client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if lookupErr == filer_pb.ErrNotFound {
+			return nil
+		}
+		if lookupErr != nil {
+			return lookupErr
+		}
+		targetEntry = resp.Entry
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("lookup %s: %v", key, err)
+	}
+	if targetEntry == nil || targetEntry.IsDirectory || filer.ETag(oldEntry) == filer.ETag(targetEntry) {
+		return fs.DeleteEntry(key, oldEntry.IsDirectory, deleteIncludeChunks, signatures)
+	}
+
+	r := fs.conflictResolver
+	sourceVV := entryVersionVector(oldEntry, r.sourceSignature)
+	targetVV := entryVersionVector(targetEntry, r.targetSignature)
+	if order := sourceVV.compare(targetVV); order == vectorEqual || order == vectorAfter {
+		return fs.DeleteEntry(key, oldEntry.IsDirectory, deleteIncludeChunks, signatures)
+	}
+
+	policy := r.policies.policyFor(fs.relativePath(key))
+	if r.deleterWins(policy, true) {
+		r.record(key, "delete", policy, conflictResolutionSource, sourceVV, targetVV, "")
+		return fs.DeleteEntry(key, oldEntry.IsDirectory, deleteIncludeChunks, signatures)
+	}
+	r.record(key, "delete", policy, conflictResolutionTarget, sourceVV, targetVV, "")
+	return nil
+}
@@ -35,6 +35,7 @@ type FilerSink struct {
 	isIncremental     bool
 	executor          *util.LimitedConcurrentExecutor
 	signature         int32
+	conflictResolver  *ConflictResolver
 }
 
 func init() {
@@ -73,6 +74,11 @@ func (fs *FilerSink) SetSourceFiler(s *source.FilerSource) {
 	fs.filerSource = s
 }
 
+// SetConflictResolver enables conflict detection for active-active synchronization.
+func (fs *FilerSink) SetConflictResolver(r *ConflictResolver) {
+	fs.conflictResolver = r
+}
+
 func (fs *FilerSink) DoInitialize(address, grpcAddress string, dir string,
 	replication string, collection string, ttlSec int, diskType string, grpcDialOption grpc.DialOption, writeChunkByFiler bool) (err error) {
 	fs.address = address
@@ -110,6 +116,11 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures [
 
 		dir, name := util.FullPath(key).DirAndName()
 
+		var versionVector VersionVector
+		if fs.conflictResolver != nil && !entry.IsDirectory {
+			versionVector = entryVersionVector(entry, fs.conflictResolver.sourceSignature)
+		}
+
 		// look up existing entry
 		lookupRequest := &filer_pb.LookupDirectoryEntryRequest{
 			Directory: dir,
@@ -121,45 +132,61 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry, signatures [
 				glog.V(3).Infof("already replicated %s", key)
 				return nil
 			}
-			if resp.Entry.Attributes != nil && resp.Entry.Attributes.Mtime >= entry.Attributes.Mtime {
+			if versionVector != nil && !resp.Entry.IsDirectory {
+				merged, apply, _, err := fs.resolveConcurrentWrite(client, key, "create", nil, entry, resp.Entry, signatures)
+				if err != nil || !apply {
+					return err
+				}
+				versionVector = merged
+			} else if resp.Entry.Attributes != nil && resp.Entry.Attributes.Mtime >= entry.Attributes.Mtime {
 				glog.V(3).Infof("skip overwriting %s", key)
 				return nil
 			}
 		}
 
-		replicatedChunks, err := fs.replicateChunks(entry.GetChunks(), key)
-
-		if err != nil {
-			// only warning here since the source chunk may have been deleted already
-			glog.Warningf("replicate entry chunks %s: %v", key, err)
-			return nil
-		}
+		return fs.createEntry(client, key, entry, versionVector, signatures)
+	})
+}
 
-		// glog.V(4).Infof("replicated %s %+v ===> %+v", key, entry.GetChunks(), replicatedChunks)
+func (fs *FilerSink) createEntry(client filer_pb.SeaweedFilerClient, key string, entry *filer_pb.Entry, versionVector VersionVector, signatures []int32) error {
 
-		request := &filer_pb.CreateEntryRequest{
-			Directory: dir,
-			Entry: &filer_pb.Entry{
-				Name:        name,
-				IsDirectory: entry.IsDirectory,
-				Attributes:  entry.Attributes,
-				Extended:    entry.Extended,
-				Chunks:      replicatedChunks,
-				Content:     entry.Content,
-				RemoteEntry: entry.RemoteEntry,
-			},
-			IsFromOtherCluster: true,
-			Signatures:         signatures,
-		}
+	dir, name := util.FullPath(key).DirAndName()
 
-		glog.V(3).Infof("create: %v", request)
-		if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
-			glog.V(0).Infof("create entry %s: %v", key, err)
-			return fmt.Errorf("create entry %s: %v", key, err)
-		}
+	replicatedChunks, err := fs.replicateChunks(entry.GetChunks(), key)
 
+	if err != nil {
+		// only warning here since the source chunk may have been deleted already
+		glog.Warningf("replicate entry chunks %s: %v", key, err)
 		return nil
-	})
+	}
+
+	// glog.V(4).Infof("replicated %s %+v ===> %+v", key, entry.GetChunks(), replicatedChunks)
+
+	request := &filer_pb.CreateEntryRequest{
+		Directory: dir,
+		Entry: &filer_pb.Entry{
+			Name:        name,
+			IsDirectory: entry.IsDirectory,
+			Attributes:  entry.Attributes,
+			Extended:    entry.Extended,
+			Chunks:      replicatedChunks,
+			Content:     entry.Content,
+			RemoteEntry: entry.RemoteEntry,
+		},
+		IsFromOtherCluster: true,
+		Signatures:         signatures,
+	}
+	if versionVector != nil {
+		stampVersionVector(request.Entry, versionVector)
+	}
+
+	glog.V(3).Infof("create: %v", request)
+	if err := filer_pb.CreateEntry(context.Background(), client, request); err != nil {
+		glog.V(0).Infof("create entry %s: %v", key, err)
+		return fmt.Errorf("create entry %s: %v", key, err)
+	}
+
+	return nil
 }
 
 func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParentPath string, newEntry *filer_pb.Entry, deleteIncludeChunks bool, signatures []int32) (foundExistingEntry bool, err error) {
@@ -187,13 +214,37 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParent
 		return nil
 	})
 
+	// conflicts are only detected for files updated in place
+	detectConflict := fs.conflictResolver != nil && !newEntry.IsDirectory &&
+		newParentPath == dir && oldEntry.GetName() == newEntry.Name
+
 	if err != nil {
+		if detectConflict && err == filer_pb.ErrNotFound && fs.keepDeletionOverUpdate(key, newEntry) {
+			return true, nil
+		}
 		return false, fmt.Errorf("lookup %s: %v", key, err)
 	}
 
 	glog.V(4).Infof("oldEntry %+v, newEntry %+v, existingEntry: %+v", oldEntry, newEntry, existingEntry)
 
-	if existingEntry.Attributes.Mtime > newEntry.Attributes.Mtime {
+	var versionVector VersionVector
+	if detectConflict && !existingEntry.IsDirectory {
+		var apply, movedAside bool
+		err = fs.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+			var resolveErr error
+			versionVector, apply, movedAside, resolveErr = fs.resolveConcurrentWrite(client, key, "update", oldEntry, newEntry, existingEntry, signatures)
+			if resolveErr != nil || !apply || !movedAside {
+				return resolveErr
+			}
+			// the existing version has been kept as a conflict copy
+			return fs.createEntry(client, key, newEntry, versionVector, signatures)
+		})
+		if err != nil || !apply || movedAside {
+			return true, err
+		}
+	}
+
+	if versionVector == nil && existingEntry.Attributes.Mtime > newEntry.Attributes.Mtime {
 		// skip if already changed
 		// this usually happens when the messages are not ordered
 		glog.V(2).Infof("late updates %s", key)
@@ -223,6 +274,9 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry *filer_pb.Entry, newParent
 		existingEntry.HardLinkCounter = newEntry.HardLinkCounter
 		existingEntry.Content = newEntry.Content
 		existingEntry.RemoteEntry = newEntry.RemoteEntry
+		if versionVector != nil {
+			stampVersionVector(existingEntry, versionVector)
+		}
 	}
 
 	// save updated meta data
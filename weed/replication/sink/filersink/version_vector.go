@@ -0,0 +1,148 @@
+package filersink
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	// ExtSyncVersionVectorKey records, per filer signature, the latest clock of
+	// the changes that an entry has incorporated.
+	ExtSyncVersionVectorKey = "Seaweed-Sync-Version-Vector"
+	// ExtSyncFingerprintKey records the content and mtime of the entry as written
+	// by filer.sync, to detect local changes made after the last sync write.
+	ExtSyncFingerprintKey = "Seaweed-Sync-Fingerprint"
+)
+
+// VersionVector maps a filer signature to a hybrid logical clock in nanoseconds.
+type VersionVector map[int32]int64
+
+type vectorOrder int
+
+const (
+	vectorEqual vectorOrder = iota
+	vectorBefore
+	vectorAfter
+	vectorConcurrent
+)
+
+func decodeVersionVector(data []byte) VersionVector {
+	vv := make(VersionVector)
+	for _, part := range strings.Split(string(data), ",") {
+		sig, clock, found := strings.Cut(part, ":")
+		if !found {
+			continue
+		}
+		s, err := strconv.ParseInt(sig, 10, 32)
+		if err != nil {
+			continue
+		}
+		c, err := strconv.ParseInt(clock, 10, 64)
+		if err != nil {
+			continue
+		}
+		vv[int32(s)] = c
+	}
+	return vv
+}
+
+func (vv VersionVector) encode() []byte {
+	sigs := make([]int32, 0, len(vv))
+	for sig := range vv {
+		sigs = append(sigs, sig)
+	}
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i] < sigs[j] })
+	parts := make([]string, 0, len(sigs))
+	for _, sig := range sigs {
+		parts = append(parts, fmt.Sprintf("%d:%d", sig, vv[sig]))
+	}
+	return []byte(strings.Join(parts, ","))
+}
+
+// advance moves the clock of sig forward to at least clock, and always past
+// its previous value so that every local change is ordered after the last one.
+func (vv VersionVector) advance(sig int32, clock int64) {
+	if next := vv[sig] + 1; next > clock {
+		clock = next
+	}
+	vv[sig] = clock
+}
+
+func (vv VersionVector) merge(other VersionVector) VersionVector {
+	merged := make(VersionVector, len(vv)+len(other))
+	for sig, clock := range vv {
+		merged[sig] = clock
+	}
+	for sig, clock := range other {
+		if clock > merged[sig] {
+			merged[sig] = clock
+		}
+	}
+	return merged
+}
+
+// compare reports how vv is ordered relative to other.
+func (vv VersionVector) compare(other VersionVector) vectorOrder {
+	var less, greater bool
+	for sig, clock := range vv {
+		if clock > other[sig] {
+			greater = true
+		} else if clock < other[sig] {
+			less = true
+		}
+	}
+	for sig, clock := range other {
+		if _, found := vv[sig]; !found && clock > 0 {
+			less = true
+		}
+	}
+	switch {
+	case less && greater:
+		return vectorConcurrent
+	case less:
+		return vectorBefore
+	case greater:
+		return vectorAfter
+	}
+	return vectorEqual
+}
+
+func syncFingerprint(entry *filer_pb.Entry) string {
+	var mtime int64
+	if entry.Attributes != nil {
+		mtime = entry.Attributes.Mtime
+	}
+	return fmt.Sprintf("%s@%d", filer.ETag(entry), mtime)
+}
+
+// entryVersionVector returns the version vector of entry as stored on the filer
+// with the given signature. If the entry was changed locally since filer.sync
+// last wrote it, the change is counted as a new version of that filer.
+func entryVersionVector(entry *filer_pb.Entry, signature int32) VersionVector {
+	vv := decodeVersionVector(entry.Extended[ExtSyncVersionVectorKey])
+	if string(entry.Extended[ExtSyncFingerprintKey]) != syncFingerprint(entry) {
+		var mtimeNs int64
+		if entry.Attributes != nil {
+			mtimeNs = entry.Attributes.Mtime * 1e9
+		}
+		vv.advance(signature, mtimeNs)
+	}
+	return vv
+}
+
+// stampVersionVector records vv and the current fingerprint on entry, so the
+// entry is recognized as unchanged until it is modified again.
+func stampVersionVector(entry *filer_pb.Entry, vv VersionVector) {
+	extended := make(map[string][]byte, len(entry.Extended)+2)
+	for k, v := range entry.Extended {
+		extended[k] = v
+	}
+	extended[ExtSyncVersionVectorKey] = vv.encode()
+	entry.Extended = extended
+	entry.Extended[ExtSyncFingerprintKey] = []byte(syncFingerprint(entry))
+}
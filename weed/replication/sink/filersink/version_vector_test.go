@@ -0,0 +1,75 @@
+package filersink
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestVersionVectorCompare(t *testing.T) {
+	tests := []struct {
+		a, b VersionVector
+		want vectorOrder
+	}{
+		{VersionVector{1: 5}, VersionVector{1: 5}, vectorEqual},
+		{VersionVector{1: 5}, VersionVector{1: 6}, vectorBefore},
+		{VersionVector{1: 6, 2: 1}, VersionVector{1: 6}, vectorAfter},
+		{VersionVector{1: 6}, VersionVector{2: 1}, vectorConcurrent},
+		{VersionVector{1: 6, 2: 1}, VersionVector{1: 5, 2: 2}, vectorConcurrent},
+	}
+	for _, tt := range tests {
+		if got := tt.a.compare(tt.b); got != tt.want {
+			t.Errorf("%v compare %v = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	vv := decodeVersionVector(VersionVector{-3: 10, 7: 20}.encode())
+	if len(vv) != 2 || vv[-3] != 10 || vv[7] != 20 {
+		t.Errorf("unexpected decoded version vector %v", vv)
+	}
+}
+
+func TestEntryVersionVector(t *testing.T) {
+	entry := &filer_pb.Entry{
+		Name:       "a.txt",
+		Attributes: &filer_pb.FuseAttributes{Mtime: 100, Md5: []byte{1, 2, 3}},
+	}
+
+	// a file never written by filer.sync is a local version
+	vv := entryVersionVector(entry, 1)
+	if vv.compare(VersionVector{1: 100e9}) != vectorEqual {
+		t.Fatalf("unexpected version vector %v", vv)
+	}
+
+	// a stamped file keeps its version until changed locally
+	stampVersionVector(entry, VersionVector{1: 100e9, 2: 50e9})
+	if vv = entryVersionVector(entry, 1); vv.compare(VersionVector{1: 100e9, 2: 50e9}) != vectorEqual {
+		t.Fatalf("unexpected version vector %v after stamping", vv)
+	}
+
+	// a local change within the same second still advances the clock
+	entry.Attributes.Md5 = []byte{4, 5, 6}
+	if vv = entryVersionVector(entry, 1); vv.compare(VersionVector{1: 100e9, 2: 50e9}) != vectorAfter {
+		t.Fatalf("unexpected version vector %v after local change", vv)
+	}
+}
+
+func TestConflictPolicies(t *testing.T) {
+	policies, err := ParseConflictPolicies("newest-wins", "/docs=keep-both,/docs/shared=prefer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]ConflictPolicy{
+		"/a.txt":              ConflictNewestWins,
+		"/docs/a.txt":         ConflictKeepBoth,
+		"/docs2/a.txt":        ConflictNewestWins,
+		"/docs/shared/x/a.md": ConflictPreferA,
+	} {
+		if got := policies.policyFor(path); got != want {
+			t.Errorf("policy for %s = %s, want %s", path, got, want)
+		}
+	}
+	if _, err = ParseConflictPolicies("oldest-wins", ""); err == nil {
+		t.Errorf("expected error for unknown policy")
+	}
+}
@@ -17,6 +17,12 @@ type ReplicationSink interface {
 	IsIncremental() bool
 }
 
+// VersionedDeleteSink is implemented by sinks that check the deleted version
+// against concurrent changes on the sink side before deleting.
+type VersionedDeleteSink interface {
+	DeleteEntryVersion(key string, oldEntry *filer_pb.Entry, deleteIncludeChunks bool, signatures []int32) error
+}
+
 var (
 	Sinks []ReplicationSink
 )
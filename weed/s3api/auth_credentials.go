@@ -15,6 +15,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	iamlib "github.com/seaweedfs/seaweedfs/weed/iam"
 	"github.com/seaweedfs/seaweedfs/weed/kms"
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
@@ -67,6 +68,9 @@ type IdentityAccessManagement struct {
 	stopChan     chan struct{}
 	shutdownOnce sync.Once
 
+	// accessKeyLastUsed batches access key usage for GetAccessKeyLastUsed
+	accessKeyLastUsed *accessKeyLastUsedTracker
+
 	// useStaticConfig indicates if the configuration was loaded from a static file
 	useStaticConfig bool
 
@@ -169,6 +173,8 @@ func NewIdentityAccessManagementWithStore(option *S3ApiServerOption, explicitSto
 
 	iam.credentialManager = credentialManager
 	iam.stopChan = make(chan struct{})
+	iam.accessKeyLastUsed = newAccessKeyLastUsedTracker()
+	go iam.loopFlushAccessKeyLastUsed()
 
 	// First, try to load configurations from file or filer
 	startConfigFile := option.Config
@@ -418,6 +424,22 @@ func (iam *IdentityAccessManagement) loadS3ApiConfiguration(config *iam_pb.S3Api
 	return iam.ReplaceS3ApiConfiguration(config)
 }
 
+// effectivePolicyNames returns the managed policies attached to an identity,
+// including those inherited from the IAM groups it belongs to.
+func effectivePolicyNames(config *iam_pb.S3ApiConfiguration, ident *iam_pb.Identity) []string {
+	groupPolicyNames := iamlib.GroupPolicyNames(config, ident.Name)
+	if len(groupPolicyNames) == 0 {
+		return ident.PolicyNames
+	}
+	policyNames := append([]string{}, ident.PolicyNames...)
+	for _, policyName := range groupPolicyNames {
+		if !slices.Contains(policyNames, policyName) {
+			policyNames = append(policyNames, policyName)
+		}
+	}
+	return policyNames
+}
+
 // ReplaceS3ApiConfiguration completely replaces the current configuration (used when no static config)
 func (iam *IdentityAccessManagement) ReplaceS3ApiConfiguration(config *iam_pb.S3ApiConfiguration) error {
 	var identities []*Identity
@@ -474,7 +496,7 @@ func (iam *IdentityAccessManagement) ReplaceS3ApiConfiguration(config *iam_pb.S3
 			Actions:      nil,
 			PrincipalArn: generatePrincipalArn(ident.Name),
 			Disabled:     ident.Disabled, // false (default) = enabled, true = disabled
-			PolicyNames:  ident.PolicyNames,
+			PolicyNames:  effectivePolicyNames(config, ident),
 		}
 		switch {
 		case ident.Name == AccountAnonymous.Id:
@@ -658,7 +680,7 @@ func (iam *IdentityAccessManagement) MergeS3ApiConfiguration(config *iam_pb.S3Ap
 			Actions:      nil,
 			PrincipalArn: generatePrincipalArn(ident.Name),
 			Disabled:     ident.Disabled,
-			PolicyNames:  ident.PolicyNames,
+			PolicyNames:  effectivePolicyNames(config, ident),
 		}
 
 		switch {
@@ -876,6 +898,11 @@ func (iam *IdentityAccessManagement) isEnabled() bool {
 	return iam.isAuthEnabled || iam.iamIntegration != nil
 }
 
+// IsAuthEnabled reports whether requests must be signed, for the services reusing the S3 identities.
+func (iam *IdentityAccessManagement) IsAuthEnabled() bool {
+	return iam.isEnabled()
+}
+
 func (iam *IdentityAccessManagement) updateAuthenticationState(identitiesCount int) bool {
 	if !iam.isAuthEnabled && identitiesCount > 0 {
 		iam.isAuthEnabled = true
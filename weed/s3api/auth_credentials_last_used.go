@@ -0,0 +1,91 @@
+package s3api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+)
+
+// accessKeyLastUsedFlushInterval bounds how often access key usage is written
+// to the credential store. AWS reports last-used information with similar
+// coarse granularity, so recording every request is unnecessary.
+const accessKeyLastUsedFlushInterval = 5 * time.Minute
+
+type accessKeyUsage struct {
+	userName string
+	lastUsed int64
+	service  string
+	region   string
+}
+
+// accessKeyLastUsedTracker collects the most recent use of each access key in
+// memory and periodically persists it via the credential manager.
+type accessKeyLastUsedTracker struct {
+	mu      sync.Mutex
+	pending map[string]accessKeyUsage
+	flushed map[string]int64
+}
+
+func newAccessKeyLastUsedTracker() *accessKeyLastUsedTracker {
+	return &accessKeyLastUsedTracker{
+		pending: make(map[string]accessKeyUsage),
+		flushed: make(map[string]int64),
+	}
+}
+
+func (t *accessKeyLastUsedTracker) record(userName, accessKey, service, region string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if flushedAt, found := t.flushed[accessKey]; found && now.Unix()-flushedAt < int64(accessKeyLastUsedFlushInterval/time.Second) {
+		return
+	}
+	t.pending[accessKey] = accessKeyUsage{userName: userName, lastUsed: now.Unix(), service: service, region: region}
+}
+
+func (t *accessKeyLastUsedTracker) drain() map[string]accessKeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pending := t.pending
+	t.pending = make(map[string]accessKeyUsage)
+	for accessKey, usage := range pending {
+		t.flushed[accessKey] = usage.lastUsed
+	}
+	return pending
+}
+
+// recordAccessKeyUsage notes a successful request signed by a long-term access key.
+// Static and session credentials are not tracked since they are not in the credential store.
+func (iam *IdentityAccessManagement) recordAccessKeyUsage(identity *Identity, authInfo *v4AuthInfo) {
+	if iam.accessKeyLastUsed == nil || identity == nil || identity.IsStatic || authInfo == nil {
+		return
+	}
+	service := authInfo.Service
+	if service == "" {
+		service = "s3"
+	}
+	iam.accessKeyLastUsed.record(identity.Name, authInfo.AccessKey, service, authInfo.Region, time.Now())
+}
+
+func (iam *IdentityAccessManagement) flushAccessKeyLastUsed() {
+	for accessKey, usage := range iam.accessKeyLastUsed.drain() {
+		if err := iam.credentialManager.UpdateAccessKeyLastUsed(context.Background(), usage.userName, accessKey, usage.lastUsed, usage.service, usage.region); err != nil {
+			glog.V(2).Infof("failed to record last use of access key %s: %v", accessKey, err)
+		}
+	}
+}
+
+func (iam *IdentityAccessManagement) loopFlushAccessKeyLastUsed() {
+	ticker := time.NewTicker(accessKeyLastUsedFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			iam.flushAccessKeyLastUsed()
+		case <-iam.stopChan:
+			return
+		}
+	}
+}
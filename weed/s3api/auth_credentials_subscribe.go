@@ -88,8 +88,10 @@ func (s3a *S3ApiServer) onIamConfigChange(dir string, oldEntry *filer_pb.Entry,
 	// Watch /etc/seaweedfs/identities and /etc/seaweedfs/policies
 	isIdentityDir := strings.HasPrefix(dir, "/etc/seaweedfs/identities")
 	isPolicyDir := strings.HasPrefix(dir, "/etc/seaweedfs/policies")
+	// IAM groups and roles are stored one file per entity under /etc/iam
+	isGroupOrRoleDir := strings.HasPrefix(dir, filer.IamConfigDirectory+"/groups") || strings.HasPrefix(dir, filer.IamConfigDirectory+"/iam_roles")
 
-	if isIdentityDir || isPolicyDir {
+	if isIdentityDir || isPolicyDir || isGroupOrRoleDir {
 		// For multiple-file mode, any change in these directories should trigger a full reload
 		// from the credential manager (which handles the details of loading from multiple files).
 		glog.V(1).Infof("IAM change detected in %s, reloading configuration", dir)
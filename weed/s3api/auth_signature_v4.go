@@ -213,6 +213,13 @@ func (iam *IdentityAccessManagement) verifyV4Signature(r *http.Request, shouldCh
 	if sessionToken == "" {
 		sessionToken = r.URL.Query().Get("X-Amz-Security-Token")
 	}
+	if sessionToken == "" {
+		defer func() {
+			if errCode == s3err.ErrNone {
+				iam.recordAccessKeyUsage(identity, authInfo)
+			}
+		}()
+	}
 	if sessionToken != "" {
 		// Validate STS session token
 		identity, cred, errCode = iam.validateSTSSessionToken(r, sessionToken, authInfo.AccessKey)
@@ -85,6 +85,11 @@ func (s3a *S3ApiServer) createMultipartUpload(r *http.Request, input *s3.CreateM
 		if input.ContentType != nil {
 			entry.Attributes.Mime = *input.ContentType
 		}
+		// Remember the flexible checksum settings, used to checksum parts and combine them on completion
+		if input.ChecksumAlgorithm != nil {
+			entry.Extended[s3_constants.SeaweedFSChecksumAlgorithm] = []byte(*input.ChecksumAlgorithm)
+			entry.Extended[s3_constants.SeaweedFSChecksumType] = []byte(r.Header.Get(s3_constants.AmzChecksumType))
+		}
 
 		// Prepare and apply encryption configuration within directory creation
 		// This ensures encryption resources are only allocated if directory creation succeeds
@@ -129,6 +134,8 @@ type CompleteMultipartUploadResult struct {
 	Bucket   *string  `xml:"Bucket,omitempty"`
 	Key      *string  `xml:"Key,omitempty"`
 	ETag     *string  `xml:"ETag,omitempty"`
+	checksumElements
+	ChecksumType string `xml:"ChecksumType,omitempty"`
 	// VersionId is NOT included in XML body - it should only be in x-amz-version-id HTTP header
 
 	// Store the VersionId internally for setting HTTP header, but don't marshal to XML
@@ -174,6 +181,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 	}
 	completedPartNumbers := []int{}
 	completedPartMap := make(map[int][]string)
+	completedPartChecksums := make(map[int]checksumElements)
 
 	maxPartNo := 1
 
@@ -182,6 +190,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 			completedPartNumbers = append(completedPartNumbers, part.PartNumber)
 		}
 		completedPartMap[part.PartNumber] = append(completedPartMap[part.PartNumber], part.ETag)
+		completedPartChecksums[part.PartNumber] = part.checksumElements
 		maxPartNo = maxInt(maxPartNo, part.PartNumber)
 	}
 	sort.Ints(completedPartNumbers)
@@ -290,9 +299,14 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 		StartChunk int    `json:"start"`
 		EndChunk   int    `json:"end"` // exclusive
 		ETag       string `json:"etag"`
+		Checksum   string `json:"checksum,omitempty"` // part checksum, same algorithm as the object checksum
 	}
 	var partBoundaries []PartBoundary
 
+	// Track part checksums to combine them into the object checksum
+	var partChecksums []*objectChecksum
+	var partSizes []int64
+
 	for _, partNumber := range completedPartNumbers {
 		partEntriesByNumber, ok := partEntries[partNumber]
 		if !ok {
@@ -313,8 +327,18 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 				continue
 			}
 
+			// Verify the part checksum sent with CompleteMultipartUpload, if any
+			partChecksum := getEntryChecksum(entry)
+			if partChecksum != nil {
+				if expected := completedPartChecksums[partNumber].value(partChecksum.Algorithm); expected != "" && expected != partChecksum.Value {
+					glog.Errorf("completeMultipartUpload %s checksum mismatch: stored %s, expected %s", entry.Name, partChecksum.Value, expected)
+					return nil, s3err.ErrInvalidPart
+				}
+			}
+
 			// Record the start chunk index for this part
 			partStartChunk := len(finalParts)
+			partStartOffset := offset
 
 			// Calculate the part's ETag (for GetObject with PartNumber)
 			partETag := filer.ETag(entry)
@@ -344,17 +368,41 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 
 			// Record the part boundary
 			partEndChunk := len(finalParts)
-			partBoundaries = append(partBoundaries, PartBoundary{
+			partBoundary := PartBoundary{
 				PartNumber: partNumber,
 				StartChunk: partStartChunk,
 				EndChunk:   partEndChunk,
 				ETag:       partETag,
-			})
+			}
+			if partChecksum != nil {
+				partBoundary.Checksum = partChecksum.Value
+			}
+			partBoundaries = append(partBoundaries, partBoundary)
+			partChecksums = append(partChecksums, partChecksum)
+			partSizes = append(partSizes, offset-partStartOffset)
 
 			found = true
 		}
 	}
 
+	// Combine the part checksums into the object checksum
+	multipartChecksum, checksumErr := computeMultipartChecksum(partChecksums, partSizes, string(pentry.Extended[s3_constants.SeaweedFSChecksumType]))
+	if checksumErr != nil {
+		glog.Warningf("completeMultipartUpload %s %s: %v", *input.Bucket, *input.UploadId, checksumErr)
+		multipartChecksum = nil
+	}
+	if uploadAlgorithm, found := checksumAlgorithmFromName(string(pentry.Extended[s3_constants.SeaweedFSChecksumAlgorithm])); found {
+		if multipartChecksum == nil || multipartChecksum.Algorithm != uploadAlgorithm {
+			glog.Warningf("completeMultipartUpload %s %s: parts are missing %s checksums", *input.Bucket, *input.UploadId, uploadAlgorithm.Name())
+			multipartChecksum = nil
+		}
+	}
+	if multipartChecksum == nil {
+		for i := range partBoundaries {
+			partBoundaries[i].Checksum = ""
+		}
+	}
+
 	entryName, dirName := s3a.getEntryNameAndDir(input)
 
 	// Check if versioning is configured for this bucket BEFORE creating any files
@@ -402,6 +450,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 				firstPartEntry := partEntries[completedPartNumbers[0]][0]
 				copySSEHeadersFromFirstPart(versionEntry, firstPartEntry, "versioned")
 			}
+			setEntryChecksum(versionEntry, multipartChecksum)
 			if pentry.Attributes.Mime != "" {
 				versionEntry.Attributes.Mime = pentry.Attributes.Mime
 			} else if mime != "" {
@@ -482,6 +531,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 				firstPartEntry := partEntries[completedPartNumbers[0]][0]
 				copySSEHeadersFromFirstPart(entry, firstPartEntry, "suspended versioning")
 			}
+			setEntryChecksum(entry, multipartChecksum)
 			if pentry.Attributes.Mime != "" {
 				entry.Attributes.Mime = pentry.Attributes.Mime
 			} else if mime != "" {
@@ -535,6 +585,7 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 				firstPartEntry := partEntries[completedPartNumbers[0]][0]
 				copySSEHeadersFromFirstPart(entry, firstPartEntry, "non-versioned")
 			}
+			setEntryChecksum(entry, multipartChecksum)
 			if pentry.Attributes.Mime != "" {
 				entry.Attributes.Mime = pentry.Attributes.Mime
 			} else if mime != "" {
@@ -561,6 +612,11 @@ func (s3a *S3ApiServer) completeMultipartUpload(r *http.Request, input *s3.Compl
 		}
 	}
 
+	if multipartChecksum != nil {
+		output.checksumElements = newChecksumElements(multipartChecksum)
+		output.ChecksumType = multipartChecksum.Type
+	}
+
 	for _, deleteEntry := range deleteEntries {
 		//delete unused part data
 		if err = s3a.rm(uploadDirectory, deleteEntry.Name, true, true); err != nil {
@@ -687,15 +743,24 @@ type ListPartsResult struct {
 	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListPartsResult"`
 
 	// copied from s3.ListPartsOutput, the Parts is not converting to <Part></Part>
-	Bucket               *string    `type:"string"`
-	IsTruncated          *bool      `type:"boolean"`
-	Key                  *string    `min:"1" type:"string"`
-	MaxParts             *int64     `type:"integer"`
-	NextPartNumberMarker *int64     `type:"integer"`
-	PartNumberMarker     *int64     `type:"integer"`
-	Part                 []*s3.Part `locationName:"Part" type:"list" flattened:"true"`
-	StorageClass         *string    `type:"string" enum:"StorageClass"`
-	UploadId             *string    `type:"string"`
+	Bucket               *string          `type:"string"`
+	IsTruncated          *bool            `type:"boolean"`
+	Key                  *string          `min:"1" type:"string"`
+	MaxParts             *int64           `type:"integer"`
+	NextPartNumberMarker *int64           `type:"integer"`
+	PartNumberMarker     *int64           `type:"integer"`
+	Part                 []*ListPartsPart `locationName:"Part" type:"list" flattened:"true"`
+	StorageClass         *string          `type:"string" enum:"StorageClass"`
+	UploadId             *string          `type:"string"`
+}
+
+// ListPartsPart is s3.Part with all flexible checksums, s3.Part lacks CRC64NVME
+type ListPartsPart struct {
+	checksumElements
+	ETag         *string    `type:"string"`
+	LastModified *time.Time `type:"timestamp"`
+	PartNumber   *int64     `type:"integer"`
+	Size         *int64     `type:"long"`
 }
 
 func (s3a *S3ApiServer) listObjectParts(input *s3.ListPartsInput) (output *ListPartsResult, code s3err.ErrorCode) {
@@ -731,11 +796,13 @@ func (s3a *S3ApiServer) listObjectParts(input *s3.ListPartsInput) (output *ListP
 				continue
 			}
 			partETag := filer.ETag(entry)
-			part := &s3.Part{
-				PartNumber:   aws.Int64(int64(partNumber)),
-				LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0).UTC()),
-				Size:         aws.Int64(int64(filer.FileSize(entry))),
-				ETag:         aws.String("\"" + partETag + "\""),
+			// the listed entries carry the extended attributes, no need to look up each part
+			part := &ListPartsPart{
+				checksumElements: newChecksumElements(getEntryChecksum(entry)),
+				PartNumber:       aws.Int64(int64(partNumber)),
+				LastModified:     aws.Time(time.Unix(entry.Attributes.Mtime, 0).UTC()),
+				Size:             aws.Int64(int64(filer.FileSize(entry))),
+				ETag:             aws.String("\"" + partETag + "\""),
 			}
 			output.Part = append(output.Part, part)
 			glog.V(3).Infof("listObjectParts: Added part %d, size=%d, etag=%s",
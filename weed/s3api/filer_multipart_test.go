@@ -32,15 +32,21 @@ func TestInitiateMultipartUploadResult(t *testing.T) {
 func TestListPartsResult(t *testing.T) {
 
 	expected := `<?xml version="1.0" encoding="UTF-8"?>
-<ListPartsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Part><ETag>&#34;12345678&#34;</ETag><LastModified>1970-01-01T00:00:00Z</LastModified><PartNumber>1</PartNumber><Size>123</Size></Part></ListPartsResult>`
+<ListPartsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Part><ETag>&#34;12345678&#34;</ETag><LastModified>1970-01-01T00:00:00Z</LastModified><PartNumber>1</PartNumber><Size>123</Size></Part><Part><ChecksumCRC64NVME>AAAAAAAAAAA=</ChecksumCRC64NVME><ETag>&#34;9abcdef0&#34;</ETag><PartNumber>2</PartNumber><Size>45</Size></Part></ListPartsResult>`
 	response := &ListPartsResult{
-		Part: []*s3.Part{
+		Part: []*ListPartsPart{
 			{
 				PartNumber:   aws.Int64(int64(1)),
 				LastModified: aws.Time(time.Unix(0, 0).UTC()),
 				Size:         aws.Int64(int64(123)),
 				ETag:         aws.String("\"12345678\""),
 			},
+			{
+				checksumElements: newChecksumElements(&objectChecksum{Algorithm: ChecksumAlgorithmCRC64NVMe, Value: "AAAAAAAAAAA="}),
+				PartNumber:       aws.Int64(int64(2)),
+				Size:             aws.Int64(int64(45)),
+				ETag:             aws.String("\"9abcdef0\""),
+			},
 		},
 	}
 
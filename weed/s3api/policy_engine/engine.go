@@ -482,6 +482,7 @@ func IsReadAction(action string) bool {
 		"s3:GetBucketObjectLockConfiguration",
 		"s3:GetObjectRetention",
 		"s3:GetObjectLegalHold",
+		"s3:GetObjectAttributes",
 	}
 
 	for _, readAction := range readActions {
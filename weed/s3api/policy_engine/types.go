@@ -461,6 +461,7 @@ var S3Actions = map[string]string{
 	"PutObjectRetention":               "s3:PutObjectRetention",
 	"GetObjectLegalHold":               "s3:GetObjectLegalHold",
 	"PutObjectLegalHold":               "s3:PutObjectLegalHold",
+	"GetObjectAttributes":              "s3:GetObjectAttributes",
 	"GetBucketObjectLockConfiguration": "s3:GetBucketObjectLockConfiguration",
 	"PutBucketObjectLockConfiguration": "s3:PutBucketObjectLockConfiguration",
 	"BypassGovernanceRetention":        "s3:BypassGovernanceRetention",
@@ -198,6 +198,10 @@ func resolveFromQueryParameters(query url.Values, method string, hasObject bool)
 
 	// Object retention and legal hold operations (object-level only)
 	if hasObject {
+		if query.Has("attributes") && method == http.MethodGet {
+			return s3_constants.S3_ACTION_GET_OBJECT_ATTRIBUTES
+		}
+
 		if query.Has("retention") {
 			switch method {
 			case http.MethodGet:
@@ -51,6 +51,17 @@ const (
 	SeaweedFSExpiresS3               = "X-Seaweedfs-Expires-S3"
 	AmzMpPartsCount                  = "x-amz-mp-parts-count"
 
+	// S3 flexible checksum headers
+	AmzChecksumAlgorithm    = "X-Amz-Checksum-Algorithm"
+	AmzSdkChecksumAlgorithm = "X-Amz-Sdk-Checksum-Algorithm"
+	AmzChecksumType         = "X-Amz-Checksum-Type"
+	AmzChecksumMode         = "X-Amz-Checksum-Mode"
+
+	// S3 GetObjectAttributes headers
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
 	// S3 ACL headers
 	AmzCannedAcl      = "X-Amz-Acl"
 	AmzAclFullControl = "X-Amz-Grant-Full-Control"
@@ -119,6 +130,17 @@ const (
 	SeaweedFSSSES3Encryption = "x-seaweedfs-sse-s3-encryption" // Encryption type for multipart upload SSE-S3 inheritance
 	SeaweedFSSSES3BaseIV     = "x-seaweedfs-sse-s3-base-iv"    // Base IV for multipart upload SSE-S3 (for IV offset calculation)
 	SeaweedFSSSES3KeyData    = "x-seaweedfs-sse-s3-key-data"   // Encrypted key data for multipart upload SSE-S3 inheritance
+
+	// Flexible checksum metadata keys
+	SeaweedFSChecksumAlgorithm = "x-seaweedfs-checksum-algorithm" // Algorithm name, e.g. CRC32C
+	SeaweedFSChecksumValue     = "x-seaweedfs-checksum-value"     // Base64 checksum, with a "-N" suffix for composite checksums
+	SeaweedFSChecksumType      = "x-seaweedfs-checksum-type"      // FULL_OBJECT or COMPOSITE
+)
+
+// S3 checksum types
+const (
+	ChecksumTypeFullObject = "FULL_OBJECT"
+	ChecksumTypeComposite  = "COMPOSITE"
 )
 
 // SeaweedFS internal headers for filer communication
@@ -0,0 +1,156 @@
+package s3api
+
+// This file bridges roles and managed policies created through the IAM API
+// (stored by the credential manager) into the STS role and policy stores, so
+// that AssumeRole* calls and session authorization can use them.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	iamlib "github.com/seaweedfs/seaweedfs/weed/iam"
+	"github.com/seaweedfs/seaweedfs/weed/iam/integration"
+	"github.com/seaweedfs/seaweedfs/weed/iam/policy"
+	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
+)
+
+// credentialRoleStore resolves roles from the credential manager first and
+// falls back to the roles defined in the advanced IAM configuration.
+type credentialRoleStore struct {
+	credentialManager *credential.CredentialManager
+	fallback          integration.RoleStore
+}
+
+func newCredentialRoleStore(credentialManager *credential.CredentialManager, fallback integration.RoleStore) *credentialRoleStore {
+	return &credentialRoleStore{credentialManager: credentialManager, fallback: fallback}
+}
+
+// StoreRole stores roles defined in the IAM configuration file in the fallback store.
+func (s *credentialRoleStore) StoreRole(ctx context.Context, filerAddress string, roleName string, role *integration.RoleDefinition) error {
+	return s.fallback.StoreRole(ctx, filerAddress, roleName, role)
+}
+
+func (s *credentialRoleStore) GetRole(ctx context.Context, filerAddress string, roleName string) (*integration.RoleDefinition, error) {
+	role, err := s.credentialManager.GetRole(ctx, roleName)
+	if err == nil && role != nil {
+		return roleToRoleDefinition(role)
+	}
+	if err != nil && !errors.Is(err, credential.ErrRoleNotFound) {
+		return nil, err
+	}
+	return s.fallback.GetRole(ctx, filerAddress, roleName)
+}
+
+func (s *credentialRoleStore) ListRoles(ctx context.Context, filerAddress string) ([]string, error) {
+	roleNames, err := s.fallback.ListRoles(ctx, filerAddress)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.credentialManager.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+	return roleNames, nil
+}
+
+func (s *credentialRoleStore) DeleteRole(ctx context.Context, filerAddress string, roleName string) error {
+	return s.fallback.DeleteRole(ctx, filerAddress, roleName)
+}
+
+func roleToRoleDefinition(role *iam_pb.Role) (*integration.RoleDefinition, error) {
+	roleDef := &integration.RoleDefinition{
+		RoleName:         role.Name,
+		RoleArn:          iamlib.RoleArn(role.Name),
+		AttachedPolicies: role.PolicyNames,
+		Description:      role.Description,
+	}
+	if role.AssumeRolePolicyDocument != "" {
+		var trustPolicy policy.PolicyDocument
+		if err := json.Unmarshal([]byte(role.AssumeRolePolicyDocument), &trustPolicy); err != nil {
+			return nil, fmt.Errorf("invalid trust policy of role %s: %w", role.Name, err)
+		}
+		roleDef.TrustPolicy = &trustPolicy
+	}
+	return roleDef, nil
+}
+
+// credentialPolicyStore resolves policies from the advanced IAM configuration
+// first and falls back to managed policies stored by the credential manager.
+type credentialPolicyStore struct {
+	credentialManager *credential.CredentialManager
+	fallback          policy.PolicyStore
+}
+
+func newCredentialPolicyStore(credentialManager *credential.CredentialManager, fallback policy.PolicyStore) *credentialPolicyStore {
+	return &credentialPolicyStore{credentialManager: credentialManager, fallback: fallback}
+}
+
+func (s *credentialPolicyStore) StorePolicy(ctx context.Context, filerAddress string, name string, policyDocument *policy.PolicyDocument) error {
+	return s.fallback.StorePolicy(ctx, filerAddress, name, policyDocument)
+}
+
+func (s *credentialPolicyStore) GetPolicy(ctx context.Context, filerAddress string, name string) (*policy.PolicyDocument, error) {
+	policyDocument, fallbackErr := s.fallback.GetPolicy(ctx, filerAddress, name)
+	if fallbackErr == nil && policyDocument != nil {
+		return policyDocument, nil
+	}
+	managed, err := s.credentialManager.GetPolicy(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if managed == nil {
+		if fallbackErr != nil {
+			return nil, fallbackErr
+		}
+		return nil, fmt.Errorf("policy %s not found", name)
+	}
+	// Both documents follow the AWS policy grammar, so convert through JSON
+	data, err := json.Marshal(managed)
+	if err != nil {
+		return nil, err
+	}
+	var converted policy.PolicyDocument
+	if err := json.Unmarshal(data, &converted); err != nil {
+		return nil, fmt.Errorf("failed to convert managed policy %s: %w", name, err)
+	}
+	return &converted, nil
+}
+
+func (s *credentialPolicyStore) DeletePolicy(ctx context.Context, filerAddress string, name string) error {
+	return s.fallback.DeletePolicy(ctx, filerAddress, name)
+}
+
+func (s *credentialPolicyStore) ListPolicies(ctx context.Context, filerAddress string) ([]string, error) {
+	policyNames, err := s.fallback.ListPolicies(ctx, filerAddress)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := s.credentialManager.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for name := range managed {
+		policyNames = append(policyNames, name)
+	}
+	return policyNames, nil
+}
+
+// useCredentialStoresForIAM makes roles and managed policies created through
+// the IAM API visible to the STS service of iamManager.
+func useCredentialStoresForIAM(iamManager *integration.IAMManager, credentialManager *credential.CredentialManager) {
+	if credentialManager == nil {
+		return
+	}
+	if roleStore := iamManager.GetRoleStore(); roleStore != nil {
+		iamManager.SetRoleStore(newCredentialRoleStore(credentialManager, roleStore))
+	}
+	if policyEngine := iamManager.GetPolicyEngine(); policyEngine != nil && policyEngine.GetStore() != nil {
+		policyEngine.SetStore(newCredentialPolicyStore(credentialManager, policyEngine.GetStore()))
+	}
+}
@@ -158,7 +158,7 @@ func (e *EmbeddedIamApi) writeIamErrorResponse(w http.ResponseWriter, r *http.Re
 	switch errCode {
 	case iam.ErrCodeNoSuchEntityException:
 		s3err.WriteXMLResponse(w, r, http.StatusNotFound, errorResp)
-	case iam.ErrCodeEntityAlreadyExistsException:
+	case iam.ErrCodeEntityAlreadyExistsException, iam.ErrCodeDeleteConflictException:
 		s3err.WriteXMLResponse(w, r, http.StatusConflict, errorResp)
 	case iam.ErrCodeMalformedPolicyDocumentException, iam.ErrCodeInvalidInputException:
 		s3err.WriteXMLResponse(w, r, http.StatusBadRequest, errorResp)
@@ -228,9 +228,12 @@ func (e *EmbeddedIamApi) ListAccessKeys(s3cfg *iam_pb.S3ApiConfiguration, values
 			identName := ident.Name
 			accessKey := cred.AccessKey
 			statusCopy := status
-			resp.ListAccessKeysResult.AccessKeyMetadata = append(resp.ListAccessKeysResult.AccessKeyMetadata,
-				&iam.AccessKeyMetadata{UserName: &identName, AccessKeyId: &accessKey, Status: &statusCopy},
-			)
+			metadata := &iam.AccessKeyMetadata{UserName: &identName, AccessKeyId: &accessKey, Status: &statusCopy}
+			if cred.CreateDate != 0 {
+				createDate := time.Unix(cred.CreateDate, 0).UTC()
+				metadata.CreateDate = &createDate
+			}
+			resp.ListAccessKeysResult.AccessKeyMetadata = append(resp.ListAccessKeysResult.AccessKeyMetadata, metadata)
 		}
 	}
 	return resp
@@ -331,11 +334,13 @@ func (e *EmbeddedIamApi) CreateAccessKey(s3cfg *iam_pb.S3ApiConfiguration, value
 	resp.CreateAccessKeyResult.AccessKey.SecretAccessKey = &secretAccessKey
 	resp.CreateAccessKeyResult.AccessKey.UserName = &userName
 	resp.CreateAccessKeyResult.AccessKey.Status = &status
+	createDate := time.Now().UTC()
+	resp.CreateAccessKeyResult.AccessKey.CreateDate = &createDate
 
 	for _, ident := range s3cfg.Identities {
 		if userName == ident.Name {
 			ident.Credentials = append(ident.Credentials,
-				&iam_pb.Credential{AccessKey: accessKeyId, SecretKey: secretAccessKey, Status: iamAccessKeyStatusActive})
+				&iam_pb.Credential{AccessKey: accessKeyId, SecretKey: secretAccessKey, Status: iamAccessKeyStatusActive, CreateDate: createDate.Unix()})
 			return resp, nil
 		}
 	}
@@ -371,11 +376,9 @@ func (e *EmbeddedIamApi) GetPolicyDocument(policy *string) (policy_engine.Policy
 	return policyDocument, nil
 }
 
-// CreatePolicy validates and creates a new IAM managed policy.
-// NOTE: Currently this only validates the policy document and returns policy metadata.
-// The policy is not persisted to a managed policy store. To apply permissions to a user,
-// use PutUserPolicy which stores the policy inline on the user's identity.
-// TODO: Implement managed policy storage for full AWS IAM compatibility (ListPolicies, GetPolicy, AttachUserPolicy).
+// CreatePolicy validates a new IAM managed policy without persisting it.
+// It is used when no credential manager is configured; otherwise CreatePolicy
+// stores the policy and its first version via iamlib.ExecuteManagedAction.
 func (e *EmbeddedIamApi) CreatePolicy(s3cfg *iam_pb.S3ApiConfiguration, values url.Values) (iamCreatePolicyResponse, *iamError) {
 	var resp iamCreatePolicyResponse
 	policyName := values.Get("PolicyName")
@@ -1060,6 +1063,9 @@ func (e *EmbeddedIamApi) ExecuteAction(values url.Values, skipPersist bool) (int
 		case "ListUsers", "ListAccessKeys", "GetUser", "GetUserPolicy", "ListServiceAccounts", "GetServiceAccount":
 			// Allowed read-only actions
 		default:
+			if iamlib.IsReadOnlyManagedAction(action) {
+				break
+			}
 			return nil, &iamError{Code: s3err.GetAPIError(s3err.ErrAccessDenied).Code, Error: fmt.Errorf("IAM write operations are disabled on this server")}
 		}
 	}
@@ -1114,13 +1120,24 @@ func (e *EmbeddedIamApi) ExecuteAction(values url.Values, skipPersist bool) (int
 	case "DeleteAccessKey":
 		response = e.DeleteAccessKey(s3cfg, values)
 	case "CreatePolicy":
-		response, iamErr = e.CreatePolicy(s3cfg, values)
+		if e.credentialManager != nil {
+			response, changed, iamErr = iamlib.ExecuteManagedAction(context.Background(), e.credentialManager, s3cfg, values)
+		} else {
+			response, iamErr = e.CreatePolicy(s3cfg, values)
+		}
 		if iamErr != nil {
 			glog.Errorf("CreatePolicy: %+v", iamErr.Error)
 			return nil, iamErr
 		}
 	case "DeletePolicy":
-		// Managed policies are not stored separately, so deletion is a no-op.
+		if e.credentialManager != nil {
+			response, changed, iamErr = iamlib.ExecuteManagedAction(context.Background(), e.credentialManager, s3cfg, values)
+			if iamErr != nil {
+				return nil, iamErr
+			}
+			break
+		}
+		// Without a credential manager managed policies are not stored, so deletion is a no-op.
 		// Returns success for AWS compatibility.
 		response = struct{}{}
 		changed = false
@@ -1178,6 +1195,17 @@ func (e *EmbeddedIamApi) ExecuteAction(values url.Values, skipPersist bool) (int
 			return nil, iamErr
 		}
 	default:
+		if iamlib.IsManagedAction(action) {
+			var store iamlib.ManagedPolicyStore
+			if e.credentialManager != nil {
+				store = e.credentialManager
+			}
+			response, changed, iamErr = iamlib.ExecuteManagedAction(context.Background(), store, s3cfg, values)
+			if iamErr != nil {
+				return nil, iamErr
+			}
+			break
+		}
 		return nil, &iamError{Code: s3err.GetAPIError(s3err.ErrNotImplemented).Code, Error: errors.New(s3err.GetAPIError(s3err.ErrNotImplemented).Description)}
 	}
 	if changed {
@@ -16,9 +16,12 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/gorilla/mux"
+	"github.com/seaweedfs/seaweedfs/weed/credential"
+	iamlib "github.com/seaweedfs/seaweedfs/weed/iam"
 	"github.com/seaweedfs/seaweedfs/weed/pb/iam_pb"
 	. "github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 )
@@ -1716,6 +1719,47 @@ func TestEmbeddedIamExecuteAction(t *testing.T) {
 	assert.Equal(t, "ExecuteActionUser", api.mockConfig.Identities[0].Name)
 }
 
+// TestEmbeddedIamManagedActions tests group, managed policy and policy attachment actions via ExecuteAction
+func TestEmbeddedIamManagedActions(t *testing.T) {
+	api := NewEmbeddedIamApiForTest()
+	credentialManager, err := credential.NewCredentialManager(credential.StoreTypeMemory, util.GetViper(), "")
+	assert.NoError(t, err)
+	api.credentialManager = credentialManager
+	api.mockConfig = &iam_pb.S3ApiConfiguration{Identities: []*iam_pb.Identity{{Name: "alice"}}}
+
+	execute := func(kv ...string) (interface{}, *iamError) {
+		vals := url.Values{}
+		for i := 0; i < len(kv); i += 2 {
+			vals.Set(kv[i], kv[i+1])
+		}
+		return api.ExecuteAction(vals, false)
+	}
+
+	_, iamErr := execute("Action", "CreatePolicy", "PolicyName", "read", "PolicyDocument", `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:Get*"],"Resource":["arn:aws:s3:::bucket/*"]}]}`)
+	assert.Nil(t, iamErr)
+	_, iamErr = execute("Action", "CreateGroup", "GroupName", "readers")
+	assert.Nil(t, iamErr)
+	_, iamErr = execute("Action", "AddUserToGroup", "GroupName", "readers", "UserName", "alice")
+	assert.Nil(t, iamErr)
+	_, iamErr = execute("Action", "AttachGroupPolicy", "GroupName", "readers", "PolicyArn", "arn:aws:iam:::policy/read")
+	assert.Nil(t, iamErr)
+	_, iamErr = execute("Action", "AttachGroupPolicy", "GroupName", "readers", "PolicyArn", "arn:aws:iam:::policy/missing")
+	assert.NotNil(t, iamErr)
+	assert.Equal(t, iam.ErrCodeNoSuchEntityException, iamErr.Code)
+
+	assert.Len(t, api.mockConfig.Groups, 1)
+	assert.Equal(t, []string{"alice"}, api.mockConfig.Groups[0].Members)
+	assert.Equal(t, []string{"read"}, effectivePolicyNames(api.mockConfig, api.mockConfig.Identities[0]))
+
+	// Read-only servers still serve managed read actions
+	api.readOnly = true
+	resp, iamErr := execute("Action", "ListGroupsForUser", "UserName", "alice")
+	assert.Nil(t, iamErr)
+	assert.Len(t, resp.(iamlib.ListGroupsForUserResponse).ListGroupsForUserResult.Groups, 1)
+	_, iamErr = execute("Action", "DeleteGroup", "GroupName", "readers")
+	assert.NotNil(t, iamErr)
+}
+
 // TestEmbeddedIamReadOnly tests that write operations are blocked when readOnly is true
 func TestEmbeddedIamReadOnly(t *testing.T) {
 	api := NewEmbeddedIamApiForTest()
@@ -0,0 +1,491 @@
+package s3api
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+// S3 flexible checksums
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/checking-object-integrity.html
+//
+// Objects keep the checksum computed during upload in entry.Extended so it can be
+// returned on GET/HEAD (with x-amz-checksum-mode: ENABLED) and by GetObjectAttributes.
+// Multipart uploads keep per-part checksums in the part boundaries and combine them
+// into a COMPOSITE (checksum of checksums) or FULL_OBJECT (CRC combination) value.
+
+// supportedChecksumAlgorithms lists the algorithms in the order they are probed in request headers
+var supportedChecksumAlgorithms = []ChecksumAlgorithm{
+	ChecksumAlgorithmCRC32,
+	ChecksumAlgorithmCRC32C,
+	ChecksumAlgorithmCRC64NVMe,
+	ChecksumAlgorithmSHA1,
+	ChecksumAlgorithmSHA256,
+}
+
+// Name returns the algorithm name used by x-amz-checksum-algorithm, e.g. "CRC32C".
+func (ca ChecksumAlgorithm) Name() string {
+	switch ca {
+	case ChecksumAlgorithmCRC32:
+		return "CRC32"
+	case ChecksumAlgorithmCRC32C:
+		return "CRC32C"
+	case ChecksumAlgorithmCRC64NVMe:
+		return "CRC64NVME"
+	case ChecksumAlgorithmSHA1:
+		return "SHA1"
+	case ChecksumAlgorithmSHA256:
+		return "SHA256"
+	}
+	return ""
+}
+
+// isCRC reports whether the algorithm supports FULL_OBJECT checksums for multipart uploads.
+func (ca ChecksumAlgorithm) isCRC() bool {
+	return ca == ChecksumAlgorithmCRC32 || ca == ChecksumAlgorithmCRC32C || ca == ChecksumAlgorithmCRC64NVMe
+}
+
+// checksumAlgorithmFromName parses an algorithm name such as "crc32c" or "SHA256".
+func checksumAlgorithmFromName(name string) (ChecksumAlgorithm, bool) {
+	for _, ca := range supportedChecksumAlgorithms {
+		if strings.EqualFold(name, ca.Name()) {
+			return ca, true
+		}
+	}
+	return ChecksumAlgorithmNone, false
+}
+
+// objectChecksum is the flexible checksum recorded for an object or a part.
+type objectChecksum struct {
+	Algorithm ChecksumAlgorithm
+	Value     string // base64 encoded, with a "-N" suffix for composite multipart checksums
+	Type      string // s3_constants.ChecksumTypeFullObject or s3_constants.ChecksumTypeComposite
+}
+
+// getRequestChecksumAlgorithm determines the checksum algorithm requested by an upload,
+// and the expected base64 value if the client sent it as a header.
+// Values sent in a streaming trailer are verified by s3ChunkedReader, so no expected value is returned for them.
+func getRequestChecksumAlgorithm(r *http.Request) (algorithm ChecksumAlgorithm, expected string, errCode s3err.ErrorCode) {
+	if trailer := r.Header.Get("x-amz-trailer"); trailer != "" {
+		if ca, err := extractChecksumAlgorithm(trailer); err == nil && ca != ChecksumAlgorithmNone {
+			return ca, "", s3err.ErrNone
+		}
+	}
+
+	for _, ca := range supportedChecksumAlgorithms {
+		value := r.Header.Get(ca.String())
+		if value == "" {
+			continue
+		}
+		if algorithm != ChecksumAlgorithmNone {
+			glog.V(3).Infof("multiple checksum headers in request: %s and %s", algorithm.String(), ca.String())
+			return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+		}
+		algorithm, expected = ca, value
+	}
+	if algorithm != ChecksumAlgorithmNone {
+		return algorithm, expected, s3err.ErrNone
+	}
+
+	name := r.Header.Get(s3_constants.AmzSdkChecksumAlgorithm)
+	if name == "" {
+		name = r.Header.Get(s3_constants.AmzChecksumAlgorithm)
+	}
+	if name == "" {
+		return ChecksumAlgorithmNone, "", s3err.ErrNone
+	}
+	ca, found := checksumAlgorithmFromName(name)
+	if !found {
+		glog.V(3).Infof("unsupported checksum algorithm %q", name)
+		return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+	}
+	return ca, "", s3err.ErrNone
+}
+
+// checksumReader computes a flexible checksum of the data read through it,
+// and fails at EOF if an expected value was provided and does not match.
+type checksumReader struct {
+	reader    io.Reader
+	algorithm ChecksumAlgorithm
+	hasher    hash.Hash
+	expected  string
+}
+
+func newChecksumReader(reader io.Reader, algorithm ChecksumAlgorithm, expected string) *checksumReader {
+	return &checksumReader{
+		reader:    reader,
+		algorithm: algorithm,
+		hasher:    getCheckSumWriter(algorithm),
+		expected:  expected,
+	}
+}
+
+func (cr *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = cr.reader.Read(p)
+	if n > 0 {
+		cr.hasher.Write(p[:n])
+	}
+	if err == io.EOF && cr.expected != "" {
+		if actual := cr.value(); actual != cr.expected {
+			glog.V(3).Infof("%s '%s' does not match provided checksum '%s'", cr.algorithm.String(), actual, cr.expected)
+			return n, errors.New(s3err.ErrMsgPayloadChecksumMismatch)
+		}
+	}
+	return n, err
+}
+
+func (cr *checksumReader) value() string {
+	return base64.StdEncoding.EncodeToString(cr.hasher.Sum(nil))
+}
+
+// checksum returns the full object checksum of all data read so far.
+func (cr *checksumReader) checksum() *objectChecksum {
+	return &objectChecksum{
+		Algorithm: cr.algorithm,
+		Value:     cr.value(),
+		Type:      s3_constants.ChecksumTypeFullObject,
+	}
+}
+
+// setEntryChecksum records the checksum in the entry's extended attributes.
+// A nil checksum removes any previously recorded one.
+func setEntryChecksum(entry *filer_pb.Entry, checksum *objectChecksum) {
+	if checksum == nil || checksum.Value == "" {
+		if entry.Extended != nil {
+			delete(entry.Extended, s3_constants.SeaweedFSChecksumAlgorithm)
+			delete(entry.Extended, s3_constants.SeaweedFSChecksumValue)
+			delete(entry.Extended, s3_constants.SeaweedFSChecksumType)
+		}
+		return
+	}
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	entry.Extended[s3_constants.SeaweedFSChecksumAlgorithm] = []byte(checksum.Algorithm.Name())
+	entry.Extended[s3_constants.SeaweedFSChecksumValue] = []byte(checksum.Value)
+	entry.Extended[s3_constants.SeaweedFSChecksumType] = []byte(checksum.Type)
+}
+
+// getEntryChecksum returns the checksum recorded for the entry, or nil if there is none.
+func getEntryChecksum(entry *filer_pb.Entry) *objectChecksum {
+	if entry == nil || entry.Extended == nil {
+		return nil
+	}
+	value := string(entry.Extended[s3_constants.SeaweedFSChecksumValue])
+	if value == "" {
+		return nil
+	}
+	algorithm, found := checksumAlgorithmFromName(string(entry.Extended[s3_constants.SeaweedFSChecksumAlgorithm]))
+	if !found {
+		return nil
+	}
+	checksumType := string(entry.Extended[s3_constants.SeaweedFSChecksumType])
+	if checksumType == "" {
+		checksumType = s3_constants.ChecksumTypeFullObject
+	}
+	return &objectChecksum{Algorithm: algorithm, Value: value, Type: checksumType}
+}
+
+// setChecksumResponseHeaders writes x-amz-checksum-<algorithm> and x-amz-checksum-type.
+func setChecksumResponseHeaders(w http.ResponseWriter, checksum *objectChecksum) {
+	if checksum == nil {
+		return
+	}
+	w.Header().Set(checksum.Algorithm.String(), checksum.Value)
+	w.Header().Set(s3_constants.AmzChecksumType, checksum.Type)
+}
+
+// checksumElements holds the per-algorithm checksum elements shared by S3 XML requests and responses.
+type checksumElements struct {
+	ChecksumCRC32     string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C    string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumCRC64NVME string `xml:"ChecksumCRC64NVME,omitempty"`
+	ChecksumSHA1      string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256    string `xml:"ChecksumSHA256,omitempty"`
+}
+
+func newChecksumElements(checksum *objectChecksum) checksumElements {
+	var elements checksumElements
+	if checksum == nil {
+		return elements
+	}
+	switch checksum.Algorithm {
+	case ChecksumAlgorithmCRC32:
+		elements.ChecksumCRC32 = checksum.Value
+	case ChecksumAlgorithmCRC32C:
+		elements.ChecksumCRC32C = checksum.Value
+	case ChecksumAlgorithmCRC64NVMe:
+		elements.ChecksumCRC64NVME = checksum.Value
+	case ChecksumAlgorithmSHA1:
+		elements.ChecksumSHA1 = checksum.Value
+	case ChecksumAlgorithmSHA256:
+		elements.ChecksumSHA256 = checksum.Value
+	}
+	return elements
+}
+
+// value returns the checksum element for the algorithm, or "" if it is absent.
+func (elements checksumElements) value(algorithm ChecksumAlgorithm) string {
+	switch algorithm {
+	case ChecksumAlgorithmCRC32:
+		return elements.ChecksumCRC32
+	case ChecksumAlgorithmCRC32C:
+		return elements.ChecksumCRC32C
+	case ChecksumAlgorithmCRC64NVMe:
+		return elements.ChecksumCRC64NVME
+	case ChecksumAlgorithmSHA1:
+		return elements.ChecksumSHA1
+	case ChecksumAlgorithmSHA256:
+		return elements.ChecksumSHA256
+	}
+	return ""
+}
+
+// getMultipartChecksumSettings reads and validates x-amz-checksum-algorithm and
+// x-amz-checksum-type from a CreateMultipartUpload request.
+func getMultipartChecksumSettings(r *http.Request) (algorithm ChecksumAlgorithm, checksumType string, errCode s3err.ErrorCode) {
+	checksumType = strings.ToUpper(r.Header.Get(s3_constants.AmzChecksumType))
+	name := r.Header.Get(s3_constants.AmzChecksumAlgorithm)
+	if name == "" {
+		if checksumType != "" {
+			return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+		}
+		return ChecksumAlgorithmNone, "", s3err.ErrNone
+	}
+	algorithm, found := checksumAlgorithmFromName(name)
+	if !found {
+		return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+	}
+
+	switch checksumType {
+	case "":
+		// CRC64NVME only supports full object checksums, everything else defaults to composite
+		if algorithm == ChecksumAlgorithmCRC64NVMe {
+			checksumType = s3_constants.ChecksumTypeFullObject
+		} else {
+			checksumType = s3_constants.ChecksumTypeComposite
+		}
+	case s3_constants.ChecksumTypeFullObject:
+		if !algorithm.isCRC() {
+			return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+		}
+	case s3_constants.ChecksumTypeComposite:
+		if algorithm == ChecksumAlgorithmCRC64NVMe {
+			return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+		}
+	default:
+		return ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest
+	}
+	return algorithm, checksumType, s3err.ErrNone
+}
+
+// computeMultipartChecksum combines the part checksums into the object checksum.
+// checksumType is the type requested when the upload was created; if empty it is derived from the algorithm.
+// It returns nil if any part lacks a checksum or the parts use different algorithms.
+func computeMultipartChecksum(parts []*objectChecksum, partSizes []int64, checksumType string) (*objectChecksum, error) {
+	if len(parts) == 0 || len(parts) != len(partSizes) {
+		return nil, nil
+	}
+	algorithm := ChecksumAlgorithmNone
+	for _, part := range parts {
+		if part == nil || part.Value == "" {
+			return nil, nil
+		}
+		if algorithm != ChecksumAlgorithmNone && part.Algorithm != algorithm {
+			return nil, nil
+		}
+		algorithm = part.Algorithm
+	}
+	if checksumType == "" {
+		checksumType = s3_constants.ChecksumTypeComposite
+		if algorithm == ChecksumAlgorithmCRC64NVMe {
+			checksumType = s3_constants.ChecksumTypeFullObject
+		}
+	}
+
+	if checksumType == s3_constants.ChecksumTypeFullObject {
+		if !algorithm.isCRC() {
+			return nil, fmt.Errorf("full object checksum is not supported for %s", algorithm.Name())
+		}
+		var combined uint64
+		for i, part := range parts {
+			crc, err := decodeCRC(part.Value, algorithm)
+			if err != nil {
+				return nil, fmt.Errorf("part %d: %w", i+1, err)
+			}
+			if i == 0 {
+				combined = crc
+			} else {
+				combined = crcCombine(algorithm, combined, crc, partSizes[i])
+			}
+		}
+		return &objectChecksum{
+			Algorithm: algorithm,
+			Value:     base64.StdEncoding.EncodeToString(encodeCRC(combined, algorithm)),
+			Type:      s3_constants.ChecksumTypeFullObject,
+		}, nil
+	}
+
+	hasher := getCheckSumWriter(algorithm)
+	for i, part := range parts {
+		raw, err := base64.StdEncoding.DecodeString(part.Value)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: decode checksum: %w", i+1, err)
+		}
+		hasher.Write(raw)
+	}
+	return &objectChecksum{
+		Algorithm: algorithm,
+		Value:     fmt.Sprintf("%s-%d", base64.StdEncoding.EncodeToString(hasher.Sum(nil)), len(parts)),
+		Type:      s3_constants.ChecksumTypeComposite,
+	}, nil
+}
+
+// reflected polynomials of the supported CRC algorithms
+const (
+	crc32IEEEPolynomial       = 0xedb88320
+	crc32CastagnoliPolynomial = 0x82f63b78
+	crc64NVMePolynomial       = 0x9a6c9329ac4bc9b5
+)
+
+func crcParameters(algorithm ChecksumAlgorithm) (poly uint64, width int) {
+	switch algorithm {
+	case ChecksumAlgorithmCRC32:
+		return crc32IEEEPolynomial, 32
+	case ChecksumAlgorithmCRC32C:
+		return crc32CastagnoliPolynomial, 32
+	case ChecksumAlgorithmCRC64NVMe:
+		return crc64NVMePolynomial, 64
+	}
+	return 0, 0
+}
+
+func decodeCRC(value string, algorithm ChecksumAlgorithm) (uint64, error) {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return 0, fmt.Errorf("decode checksum: %w", err)
+	}
+	_, width := crcParameters(algorithm)
+	switch {
+	case width == 32 && len(raw) == 4:
+		return uint64(binary.BigEndian.Uint32(raw)), nil
+	case width == 64 && len(raw) == 8:
+		return binary.BigEndian.Uint64(raw), nil
+	}
+	return 0, fmt.Errorf("invalid %s checksum length %d", algorithm.Name(), len(raw))
+}
+
+func encodeCRC(crc uint64, algorithm ChecksumAlgorithm) []byte {
+	if _, width := crcParameters(algorithm); width == 32 {
+		return binary.BigEndian.AppendUint32(nil, uint32(crc))
+	}
+	return binary.BigEndian.AppendUint64(nil, crc)
+}
+
+// crcCombine returns the CRC of the concatenation of two byte sequences,
+// given crc1 of the first, crc2 of the second, and len2 the length of the second.
+// This is the zlib crc32_combine algorithm generalized to reflected CRCs of any width.
+func crcCombine(algorithm ChecksumAlgorithm, crc1, crc2 uint64, len2 int64) uint64 {
+	if len2 <= 0 {
+		return crc1
+	}
+	poly, width := crcParameters(algorithm)
+
+	even := make([]uint64, width) // even-power-of-two zeros operator
+	odd := make([]uint64, width)  // odd-power-of-two zeros operator
+
+	// put operator for one zero bit in odd
+	odd[0] = poly
+	row := uint64(1)
+	for n := 1; n < width; n++ {
+		odd[n] = row
+		row <<= 1
+	}
+
+	gf2MatrixSquare(even, odd) // operator for two zero bits
+	gf2MatrixSquare(odd, even) // operator for four zero bits
+
+	// apply len2 zeros to crc1 (first square will put the operator for one zero byte in even)
+	for {
+		gf2MatrixSquare(even, odd)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(even, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+
+		gf2MatrixSquare(odd, even)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(odd, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+	}
+	return crc1 ^ crc2
+}
+
+func gf2MatrixTimes(mat []uint64, vec uint64) uint64 {
+	var sum uint64
+	for i := 0; vec != 0; i++ {
+		if vec&1 != 0 {
+			sum ^= mat[i]
+		}
+		vec >>= 1
+	}
+	return sum
+}
+
+func gf2MatrixSquare(square, mat []uint64) {
+	for n := range mat {
+		square[n] = gf2MatrixTimes(mat, mat[n])
+	}
+}
+
+// getChecksumForRead returns the checksum covering the data returned by a GET/HEAD:
+// the part checksum for reads with partNumber, nothing for other ranged reads, and the object checksum otherwise.
+func (s3a *S3ApiServer) getChecksumForRead(r *http.Request, entry *filer_pb.Entry) *objectChecksum {
+	checksum := getEntryChecksum(entry)
+	if checksum == nil {
+		return nil
+	}
+
+	partNumberStr := r.URL.Query().Get("partNumber")
+	if partNumberStr == "" {
+		partNumberStr = r.URL.Query().Get("PartNumber")
+	}
+	if partNumberStr != "" {
+		partNumber, err := strconv.Atoi(partNumberStr)
+		if err != nil {
+			return nil
+		}
+		_, partInfo := s3a.getMultipartInfo(entry, partNumber)
+		if partInfo == nil || partInfo.Checksum == "" {
+			return nil
+		}
+		return &objectChecksum{
+			Algorithm: checksum.Algorithm,
+			Value:     partInfo.Checksum,
+			Type:      s3_constants.ChecksumTypeFullObject,
+		}
+	}
+
+	if r.Header.Get("Range") != "" {
+		return nil
+	}
+	return checksum
+}
@@ -0,0 +1,244 @@
+package s3api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+func checksumOf(algorithm ChecksumAlgorithm, data []byte) string {
+	hasher := getCheckSumWriter(algorithm)
+	hasher.Write(data)
+	return base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+}
+
+func TestGetRequestChecksumAlgorithm(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		algorithm ChecksumAlgorithm
+		expected  string
+		errCode   s3err.ErrorCode
+	}{
+		{"none", nil, ChecksumAlgorithmNone, "", s3err.ErrNone},
+		{"trailer", map[string]string{"x-amz-trailer": "x-amz-checksum-crc32c"}, ChecksumAlgorithmCRC32C, "", s3err.ErrNone},
+		{"header value", map[string]string{"x-amz-checksum-sha256": "abc="}, ChecksumAlgorithmSHA256, "abc=", s3err.ErrNone},
+		{"sdk algorithm", map[string]string{"x-amz-sdk-checksum-algorithm": "crc64nvme"}, ChecksumAlgorithmCRC64NVMe, "", s3err.ErrNone},
+		{"algorithm", map[string]string{"x-amz-checksum-algorithm": "SHA1"}, ChecksumAlgorithmSHA1, "", s3err.ErrNone},
+		{"unsupported algorithm", map[string]string{"x-amz-checksum-algorithm": "MD5"}, ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest},
+		{"multiple values", map[string]string{"x-amz-checksum-crc32": "a", "x-amz-checksum-sha1": "b"}, ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/bucket/object", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			algorithm, expected, errCode := getRequestChecksumAlgorithm(r)
+			if algorithm != tt.algorithm || expected != tt.expected || errCode != tt.errCode {
+				t.Errorf("got (%v, %q, %v), want (%v, %q, %v)", algorithm, expected, errCode, tt.algorithm, tt.expected, tt.errCode)
+			}
+		})
+	}
+}
+
+func TestChecksumReader(t *testing.T) {
+	data := []byte("hello flexible checksums")
+
+	for _, algorithm := range supportedChecksumAlgorithms {
+		reader := newChecksumReader(bytes.NewReader(data), algorithm, checksumOf(algorithm, data))
+		if _, err := io.ReadAll(reader); err != nil {
+			t.Fatalf("%s: unexpected error: %v", algorithm.Name(), err)
+		}
+		if got := reader.checksum().Value; got != checksumOf(algorithm, data) {
+			t.Errorf("%s: checksum %s, want %s", algorithm.Name(), got, checksumOf(algorithm, data))
+		}
+	}
+
+	reader := newChecksumReader(bytes.NewReader(data), ChecksumAlgorithmCRC32, checksumOf(ChecksumAlgorithmCRC32, []byte("other")))
+	if _, err := io.ReadAll(reader); err == nil || err.Error() != s3err.ErrMsgPayloadChecksumMismatch {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+}
+
+func TestEntryChecksumRoundTrip(t *testing.T) {
+	entry := &filer_pb.Entry{}
+	if getEntryChecksum(entry) != nil {
+		t.Fatal("expected no checksum")
+	}
+
+	checksum := &objectChecksum{Algorithm: ChecksumAlgorithmCRC32C, Value: "AAAAAA==", Type: s3_constants.ChecksumTypeFullObject}
+	setEntryChecksum(entry, checksum)
+	if got := getEntryChecksum(entry); got == nil || *got != *checksum {
+		t.Fatalf("got %+v, want %+v", got, checksum)
+	}
+
+	setEntryChecksum(entry, nil)
+	if got := getEntryChecksum(entry); got != nil {
+		t.Fatalf("expected checksum to be removed, got %+v", got)
+	}
+}
+
+func TestCrcCombine(t *testing.T) {
+	first := []byte(strings.Repeat("seaweedfs", 1000))
+	second := []byte(strings.Repeat("checksum", 777))
+	whole := append(append([]byte{}, first...), second...)
+
+	for _, algorithm := range []ChecksumAlgorithm{ChecksumAlgorithmCRC32, ChecksumAlgorithmCRC32C, ChecksumAlgorithmCRC64NVMe} {
+		crc1, _ := decodeCRC(checksumOf(algorithm, first), algorithm)
+		crc2, _ := decodeCRC(checksumOf(algorithm, second), algorithm)
+		combined := base64.StdEncoding.EncodeToString(encodeCRC(crcCombine(algorithm, crc1, crc2, int64(len(second))), algorithm))
+		if want := checksumOf(algorithm, whole); combined != want {
+			t.Errorf("%s: combined %s, want %s", algorithm.Name(), combined, want)
+		}
+	}
+}
+
+func TestComputeMultipartChecksum(t *testing.T) {
+	parts := [][]byte{[]byte(strings.Repeat("a", 5000)), []byte(strings.Repeat("b", 3000)), []byte("c")}
+
+	partChecksums := func(algorithm ChecksumAlgorithm) ([]*objectChecksum, []int64) {
+		var checksums []*objectChecksum
+		var sizes []int64
+		for _, part := range parts {
+			checksums = append(checksums, &objectChecksum{Algorithm: algorithm, Value: checksumOf(algorithm, part), Type: s3_constants.ChecksumTypeFullObject})
+			sizes = append(sizes, int64(len(part)))
+		}
+		return checksums, sizes
+	}
+
+	t.Run("composite", func(t *testing.T) {
+		checksums, sizes := partChecksums(ChecksumAlgorithmSHA256)
+		got, err := computeMultipartChecksum(checksums, sizes, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw []byte
+		for _, c := range checksums {
+			decoded, _ := base64.StdEncoding.DecodeString(c.Value)
+			raw = append(raw, decoded...)
+		}
+		want := checksumOf(ChecksumAlgorithmSHA256, raw) + "-3"
+		if got.Value != want || got.Type != s3_constants.ChecksumTypeComposite {
+			t.Errorf("got %+v, want %s COMPOSITE", got, want)
+		}
+	})
+
+	t.Run("full object", func(t *testing.T) {
+		whole := bytes.Join(parts, nil)
+		for _, algorithm := range []ChecksumAlgorithm{ChecksumAlgorithmCRC32, ChecksumAlgorithmCRC64NVMe} {
+			checksums, sizes := partChecksums(algorithm)
+			got, err := computeMultipartChecksum(checksums, sizes, s3_constants.ChecksumTypeFullObject)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := checksumOf(algorithm, whole); got.Value != want || got.Type != s3_constants.ChecksumTypeFullObject {
+				t.Errorf("%s: got %+v, want %s FULL_OBJECT", algorithm.Name(), got, want)
+			}
+		}
+	})
+
+	t.Run("missing part checksum", func(t *testing.T) {
+		checksums, sizes := partChecksums(ChecksumAlgorithmCRC32)
+		checksums[1] = nil
+		if got, err := computeMultipartChecksum(checksums, sizes, ""); got != nil || err != nil {
+			t.Errorf("expected no checksum, got %+v, %v", got, err)
+		}
+	})
+
+	t.Run("full object sha", func(t *testing.T) {
+		checksums, sizes := partChecksums(ChecksumAlgorithmSHA1)
+		if _, err := computeMultipartChecksum(checksums, sizes, s3_constants.ChecksumTypeFullObject); err == nil {
+			t.Error("expected error for FULL_OBJECT SHA1 checksum")
+		}
+	})
+}
+
+func TestGetMultipartChecksumSettings(t *testing.T) {
+	tests := []struct {
+		algorithm    string
+		checksumType string
+		want         ChecksumAlgorithm
+		wantType     string
+		errCode      s3err.ErrorCode
+	}{
+		{"", "", ChecksumAlgorithmNone, "", s3err.ErrNone},
+		{"CRC32", "", ChecksumAlgorithmCRC32, s3_constants.ChecksumTypeComposite, s3err.ErrNone},
+		{"CRC32C", "FULL_OBJECT", ChecksumAlgorithmCRC32C, s3_constants.ChecksumTypeFullObject, s3err.ErrNone},
+		{"CRC64NVME", "", ChecksumAlgorithmCRC64NVMe, s3_constants.ChecksumTypeFullObject, s3err.ErrNone},
+		{"CRC64NVME", "COMPOSITE", ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest},
+		{"SHA256", "FULL_OBJECT", ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest},
+		{"", "COMPOSITE", ChecksumAlgorithmNone, "", s3err.ErrInvalidRequest},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/bucket/object?uploads", nil)
+		if tt.algorithm != "" {
+			r.Header.Set(s3_constants.AmzChecksumAlgorithm, tt.algorithm)
+		}
+		if tt.checksumType != "" {
+			r.Header.Set(s3_constants.AmzChecksumType, tt.checksumType)
+		}
+		algorithm, checksumType, errCode := getMultipartChecksumSettings(r)
+		if algorithm != tt.want || checksumType != tt.wantType || errCode != tt.errCode {
+			t.Errorf("%s/%s: got (%v, %q, %v), want (%v, %q, %v)", tt.algorithm, tt.checksumType, algorithm, checksumType, errCode, tt.want, tt.wantType, tt.errCode)
+		}
+	}
+}
+
+func TestGetObjectAttributesParts(t *testing.T) {
+	entry := &filer_pb.Entry{
+		Name:       "object",
+		Attributes: &filer_pb.FuseAttributes{FileSize: 13},
+		Chunks: []*filer_pb.FileChunk{
+			{Offset: 0, Size: 5},
+			{Offset: 5, Size: 5},
+			{Offset: 10, Size: 3},
+		},
+		Extended: map[string][]byte{
+			s3_constants.SeaweedFSMultipartPartsCount:     []byte("2"),
+			s3_constants.SeaweedFSMultipartPartBoundaries: []byte(`[{"part":1,"start":0,"end":2,"etag":"a","checksum":"AAAAAQ=="},{"part":2,"start":2,"end":3,"etag":"b","checksum":"AAAAAg=="}]`),
+		},
+	}
+	setEntryChecksum(entry, &objectChecksum{Algorithm: ChecksumAlgorithmCRC32, Value: "AAAAAw==-2", Type: s3_constants.ChecksumTypeComposite})
+
+	s3a := &S3ApiServer{}
+	attributes, errCode := parseObjectAttributes([]string{"ETag, Checksum", "ObjectParts,ObjectSize"})
+	if errCode != s3err.ErrNone {
+		t.Fatalf("parseObjectAttributes: %v", errCode)
+	}
+	response := s3a.buildObjectAttributes(entry, attributes, 1, 0)
+
+	if response.ObjectSize == nil || *response.ObjectSize != 13 {
+		t.Errorf("unexpected object size %v", response.ObjectSize)
+	}
+	if response.Checksum == nil || response.Checksum.ChecksumCRC32 != "AAAAAw==-2" || response.Checksum.ChecksumType != s3_constants.ChecksumTypeComposite {
+		t.Errorf("unexpected checksum %+v", response.Checksum)
+	}
+	parts := response.ObjectParts
+	if parts == nil || parts.PartsCount != 2 || len(parts.Parts) != 1 || !parts.IsTruncated || parts.NextPartNumberMarker != 1 {
+		t.Fatalf("unexpected parts %+v", parts)
+	}
+	if parts.Parts[0].Size != 10 || parts.Parts[0].ChecksumCRC32 != "AAAAAQ==" {
+		t.Errorf("unexpected part %+v", parts.Parts[0])
+	}
+
+	encoded, err := xml.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(encoded, []byte("<Part><ChecksumCRC32>AAAAAQ==</ChecksumCRC32><PartNumber>1</PartNumber><Size>10</Size></Part>")) {
+		t.Errorf("unexpected xml %s", encoded)
+	}
+
+	if _, errCode := parseObjectAttributes([]string{"ETag,Owner"}); errCode != s3err.ErrInvalidObjectAttributes {
+		t.Errorf("expected invalid attribute error, got %v", errCode)
+	}
+}
@@ -927,6 +927,20 @@ func (s3a *S3ApiServer) streamFromVolumeServers(w http.ResponseWriter, r *http.R
 	// Get file size
 	totalSize := int64(filer.FileSize(entry))
 
+	// Transform images, except for range requests
+	if r.Header.Get("Range") == "" && !entry.IsInRemoteOnly() {
+		transform, err := s3a.parseImageTransform(r, bucket, object)
+		if err != nil {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+			return newStreamErrorWithResponse(err)
+		}
+		if transform != nil {
+			if handled, err := s3a.writeImageRendition(w, r, entry, transform, totalSize); handled {
+				return err
+			}
+		}
+	}
+
 	// Parse Range header if present
 	tRangeParse := time.Now()
 	offset, size, isRangeRequest, rangeErr := s3a.parseAndValidateRange(w, r, entry, totalSize, bucket, object)
@@ -2032,6 +2046,11 @@ func (s3a *S3ApiServer) setResponseHeaders(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	// Return the flexible checksum only when requested with x-amz-checksum-mode: ENABLED
+	if r != nil && strings.EqualFold(r.Header.Get(s3_constants.AmzChecksumMode), "ENABLED") {
+		setChecksumResponseHeaders(w, s3a.getChecksumForRead(r, entry))
+	}
+
 	// Apply S3 passthrough headers from query parameters
 	// AWS S3 supports overriding response headers via query parameters like:
 	// ?response-cache-control=no-cache&response-content-type=application/json
@@ -3294,6 +3313,7 @@ type PartBoundaryInfo struct {
 	StartChunk int    `json:"start"`
 	EndChunk   int    `json:"end"` // exclusive
 	ETag       string `json:"etag"`
+	Checksum   string `json:"checksum,omitempty"` // part checksum, same algorithm as the object checksum
 }
 
 // rc is a helper type that wraps a Reader and Closer for proper resource cleanup
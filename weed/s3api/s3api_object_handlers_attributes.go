@@ -0,0 +1,242 @@
+package s3api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+	stats_collect "github.com/seaweedfs/seaweedfs/weed/stats"
+)
+
+// object attributes that can be requested with x-amz-object-attributes
+const (
+	objectAttributeETag         = "ETag"
+	objectAttributeChecksum     = "Checksum"
+	objectAttributeObjectParts  = "ObjectParts"
+	objectAttributeStorageClass = "StorageClass"
+	objectAttributeObjectSize   = "ObjectSize"
+
+	defaultObjectAttributesMaxParts = 1000
+)
+
+// GetObjectAttributesResponse is the response of GetObjectAttributes
+type GetObjectAttributesResponse struct {
+	XMLName      xml.Name                  `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse"`
+	ETag         string                    `xml:"ETag,omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:"Checksum,omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:"ObjectParts,omitempty"`
+	StorageClass string                    `xml:"StorageClass,omitempty"`
+	ObjectSize   *int64                    `xml:"ObjectSize,omitempty"`
+}
+
+// ObjectAttributesChecksum is the object checksum returned by GetObjectAttributes
+type ObjectAttributesChecksum struct {
+	checksumElements
+	ChecksumType string `xml:"ChecksumType,omitempty"`
+}
+
+// ObjectAttributesParts lists the parts of a multipart object returned by GetObjectAttributes
+type ObjectAttributesParts struct {
+	IsTruncated          bool                   `xml:"IsTruncated"`
+	MaxParts             int                    `xml:"MaxParts"`
+	NextPartNumberMarker int                    `xml:"NextPartNumberMarker"`
+	PartNumberMarker     int                    `xml:"PartNumberMarker"`
+	Parts                []ObjectAttributesPart `xml:"Part"`
+	PartsCount           int                    `xml:"PartsCount"`
+}
+
+// ObjectAttributesPart is one part of a multipart object
+type ObjectAttributesPart struct {
+	checksumElements
+	PartNumber int   `xml:"PartNumber"`
+	Size       int64 `xml:"Size"`
+}
+
+// GetObjectAttributesHandler Get object attributes
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+func (s3a *S3ApiServer) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	bucket, object := s3_constants.GetBucketAndObject(r)
+	glog.V(3).Infof("GetObjectAttributesHandler %s %s", bucket, object)
+
+	attributes, errCode := parseObjectAttributes(r.Header.Values(s3_constants.AmzObjectAttributes))
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+
+	maxParts := defaultObjectAttributesMaxParts
+	if value := r.Header.Get(s3_constants.AmzMaxParts); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidMaxParts)
+			return
+		}
+		if parsed < maxParts {
+			maxParts = parsed
+		}
+	}
+	partNumberMarker := 0
+	if value := r.Header.Get(s3_constants.AmzPartNumberMarker); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidPartNumberMarker)
+			return
+		}
+		partNumberMarker = parsed
+	}
+
+	versionId := r.URL.Query().Get("versionId")
+	entry, err := s3a.getObjectEntry(bucket, object, versionId)
+	if err != nil {
+		glog.V(3).Infof("GetObjectAttributesHandler: %v", err)
+		if errors.Is(err, ErrObjectNotFound) {
+			s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+			return
+		}
+		s3err.WriteErrorResponse(w, r, s3err.ErrInternalError)
+		return
+	}
+	if entry.IsDirectory {
+		s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+		return
+	}
+
+	if entry.Extended != nil {
+		if deleteMarker, exists := entry.Extended[s3_constants.ExtDeleteMarkerKey]; exists && string(deleteMarker) == "true" {
+			w.Header().Set("x-amz-delete-marker", "true")
+			if versionId != "" {
+				w.Header().Set("x-amz-version-id", versionId)
+				s3err.WriteErrorResponse(w, r, s3err.ErrMethodNotAllowed)
+				return
+			}
+			s3err.WriteErrorResponse(w, r, s3err.ErrNoSuchKey)
+			return
+		}
+		if entryVersionId, exists := entry.Extended[s3_constants.ExtVersionIdKey]; exists && len(entryVersionId) > 0 {
+			w.Header().Set("x-amz-version-id", string(entryVersionId))
+		}
+	}
+
+	response := s3a.buildObjectAttributes(entry, attributes, maxParts, partNumberMarker)
+
+	if entry.Attributes != nil {
+		w.Header().Set("Last-Modified", time.Unix(entry.Attributes.Mtime, 0).UTC().Format(http.TimeFormat))
+	}
+
+	stats_collect.RecordBucketActiveTime(bucket)
+
+	writeSuccessResponseXML(w, r, response)
+}
+
+// parseObjectAttributes parses the comma separated x-amz-object-attributes header values.
+func parseObjectAttributes(values []string) (map[string]bool, s3err.ErrorCode) {
+	attributes := make(map[string]bool)
+	for _, value := range values {
+		for _, attribute := range strings.Split(value, ",") {
+			attribute = strings.TrimSpace(attribute)
+			if attribute == "" {
+				continue
+			}
+			switch attribute {
+			case objectAttributeETag, objectAttributeChecksum, objectAttributeObjectParts,
+				objectAttributeStorageClass, objectAttributeObjectSize:
+				attributes[attribute] = true
+			default:
+				return nil, s3err.ErrInvalidObjectAttributes
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		return nil, s3err.ErrInvalidObjectAttributes
+	}
+	return attributes, s3err.ErrNone
+}
+
+// buildObjectAttributes fills in the requested attributes of the entry
+func (s3a *S3ApiServer) buildObjectAttributes(entry *filer_pb.Entry, attributes map[string]bool, maxParts, partNumberMarker int) *GetObjectAttributesResponse {
+	response := &GetObjectAttributesResponse{}
+	checksum := getEntryChecksum(entry)
+
+	if attributes[objectAttributeETag] {
+		response.ETag = strings.Trim(s3a.getObjectETag(entry), `"`)
+	}
+	if attributes[objectAttributeChecksum] && checksum != nil {
+		response.Checksum = &ObjectAttributesChecksum{
+			checksumElements: newChecksumElements(checksum),
+			ChecksumType:     checksum.Type,
+		}
+	}
+	if attributes[objectAttributeStorageClass] {
+		response.StorageClass = s3a.getStorageClassFromExtended(entry.Extended)
+	}
+	if attributes[objectAttributeObjectSize] {
+		size := int64(filer.FileSize(entry))
+		response.ObjectSize = &size
+	}
+	if attributes[objectAttributeObjectParts] {
+		response.ObjectParts = getObjectAttributesParts(entry, checksum, maxParts, partNumberMarker)
+	}
+
+	return response
+}
+
+// getObjectAttributesParts lists the parts of a multipart object, or returns nil for other objects
+func getObjectAttributesParts(entry *filer_pb.Entry, checksum *objectChecksum, maxParts, partNumberMarker int) *ObjectAttributesParts {
+	if entry.Extended == nil {
+		return nil
+	}
+	partsCountBytes, isMultipart := entry.Extended[s3_constants.SeaweedFSMultipartPartsCount]
+	if !isMultipart {
+		return nil
+	}
+	partsCount, _ := strconv.Atoi(string(partsCountBytes))
+
+	objectParts := &ObjectAttributesParts{
+		MaxParts:         maxParts,
+		PartNumberMarker: partNumberMarker,
+		PartsCount:       partsCount,
+	}
+
+	var boundaries []PartBoundaryInfo
+	if boundariesJSON, exists := entry.Extended[s3_constants.SeaweedFSMultipartPartBoundaries]; exists {
+		if err := json.Unmarshal(boundariesJSON, &boundaries); err != nil {
+			glog.Warningf("getObjectAttributesParts %s: invalid part boundaries: %v", entry.Name, err)
+			return objectParts
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].PartNumber < boundaries[j].PartNumber
+	})
+
+	chunks := entry.GetChunks()
+	for _, boundary := range boundaries {
+		if boundary.PartNumber <= partNumberMarker {
+			continue
+		}
+		if len(objectParts.Parts) >= maxParts {
+			objectParts.IsTruncated = true
+			break
+		}
+		part := ObjectAttributesPart{PartNumber: boundary.PartNumber}
+		for i := boundary.StartChunk; i < boundary.EndChunk && i < len(chunks); i++ {
+			part.Size += int64(chunks[i].Size)
+		}
+		if checksum != nil && boundary.Checksum != "" {
+			part.checksumElements = newChecksumElements(&objectChecksum{Algorithm: checksum.Algorithm, Value: boundary.Checksum})
+		}
+		objectParts.Parts = append(objectParts.Parts, part)
+		objectParts.NextPartNumberMarker = boundary.PartNumber
+	}
+
+	return objectParts
+}
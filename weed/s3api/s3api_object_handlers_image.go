@@ -0,0 +1,77 @@
+package s3api
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/images"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/s3api/s3err"
+)
+
+// parseImageTransform returns the signed image transformation of the GET request, or nil if there is none.
+// The transformation is signed on the "/bucket/object" path.
+func (s3a *S3ApiServer) parseImageTransform(r *http.Request, bucket, object string) (*images.Transform, error) {
+	query := r.URL.Query()
+	transform, err := images.ParseTransform(query)
+	if err != nil || transform == nil {
+		return nil, err
+	}
+	if err = transform.VerifySignature(s3a.imageTransformKey, "/"+bucket+"/"+object, query.Get(images.TransformSignatureParam)); err != nil {
+		return nil, err
+	}
+	return transform, nil
+}
+
+// writeImageRendition writes the transformed image of the object.
+// It returns false without writing anything if the object should be served as is.
+func (s3a *S3ApiServer) writeImageRendition(w http.ResponseWriter, r *http.Request, entry *filer_pb.Entry, transform *images.Transform, totalSize int64) (handled bool, err error) {
+	var mimeType string
+	if entry.Attributes != nil {
+		mimeType = entry.Attributes.Mime
+	}
+	ext := filepath.Ext(entry.Name)
+	if !images.IsTransformableImage(ext, mimeType) {
+		return false, nil
+	}
+
+	accept := r.Header.Get("Accept")
+	data, contentType, err := filer.ReadImageRendition(r.Context(), s3a.filerClient, filer.JwtForVolumeServer,
+		entry.Content, entry.GetChunks(), totalSize, ext, transform, s3a.imageTransformKey, accept)
+	if err != nil {
+		if errors.Is(err, images.ErrUnsupportedFormat) && transform.Format != "" {
+			s3err.WriteErrorResponse(w, r, s3err.ErrInvalidRequest)
+			return true, newStreamErrorWithResponse(err)
+		}
+		// serve the original image
+		glog.V(1).Infof("transform image %s: %v", entry.Name, err)
+		return false, nil
+	}
+
+	s3a.setResponseHeaders(w, r, entry, totalSize)
+	h := fnv.New32a()
+	h.Write([]byte(transform.Negotiate(accept).Key()))
+	w.Header().Set("ETag", fmt.Sprintf("\"%s-%x\"", filer.ETag(entry), h.Sum32()))
+	if transform.Format == images.FormatAuto {
+		w.Header().Add("Vary", "Accept")
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	written, err := w.Write(data)
+	if written > 0 {
+		BucketTrafficSent(int64(written), r)
+	}
+	if err != nil {
+		return true, newStreamErrorWithResponse(err)
+	}
+	return true, nil
+}
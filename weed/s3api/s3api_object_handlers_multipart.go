@@ -102,6 +102,18 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 	if contentType != "" {
 		createMultipartUploadInput.ContentType = &contentType
 	}
+
+	// Validate the requested flexible checksum; parts are combined into this checksum on completion
+	checksumAlgorithm, checksumType, errCode := getMultipartChecksumSettings(r)
+	if errCode != s3err.ErrNone {
+		s3err.WriteErrorResponse(w, r, errCode)
+		return
+	}
+	if checksumAlgorithm != ChecksumAlgorithmNone {
+		createMultipartUploadInput.ChecksumAlgorithm = aws.String(checksumAlgorithm.Name())
+		r.Header.Set(s3_constants.AmzChecksumType, checksumType)
+	}
+
 	response, errCode := s3a.createMultipartUpload(r, createMultipartUploadInput)
 
 	glog.V(3).Info("NewMultipartUploadHandler", string(s3err.EncodeXMLResponse(response)), errCode)
@@ -111,6 +123,11 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	if checksumAlgorithm != ChecksumAlgorithmNone {
+		w.Header().Set(s3_constants.AmzChecksumAlgorithm, checksumAlgorithm.Name())
+		w.Header().Set(s3_constants.AmzChecksumType, checksumType)
+	}
+
 	writeSuccessResponseXML(w, r, response)
 
 }
@@ -422,6 +439,16 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	// Parts of an upload created with a checksum algorithm are always checksummed,
+	// so the object checksum can be combined from them on completion
+	if checksumAlgorithm, _, _ := getRequestChecksumAlgorithm(r); checksumAlgorithm == ChecksumAlgorithmNone {
+		if uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(bucket), uploadID); err == nil && uploadEntry.Extended != nil {
+			if algorithmName, exists := uploadEntry.Extended[s3_constants.SeaweedFSChecksumAlgorithm]; exists {
+				r.Header.Set(s3_constants.AmzSdkChecksumAlgorithm, string(algorithmName))
+			}
+		}
+	}
+
 	filePath := s3a.genPartUploadPath(bucket, uploadID, partID)
 
 	if partID == 1 && r.Header.Get("Content-Type") == "" {
@@ -446,6 +473,7 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 
 	// Set SSE response headers for multipart uploads
 	s3a.setSSEResponseHeaders(w, r, sseMetadata)
+	setChecksumResponseHeaders(w, sseMetadata.Checksum)
 
 	writeSuccessResponseEmpty(w, r)
 
@@ -531,6 +559,7 @@ type CompleteMultipartUpload struct {
 type CompletedPart struct {
 	ETag       string
 	PartNumber int
+	checksumElements
 }
 
 // handleSSES3MultipartHeaders handles SSE-S3 multipart upload header setup to reduce nesting complexity
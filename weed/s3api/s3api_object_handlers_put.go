@@ -64,11 +64,12 @@ type BucketDefaultEncryptionResult struct {
 	SSEKMSKey  *SSEKMSKey
 }
 
-// SSEResponseMetadata holds encryption metadata needed for HTTP response headers
+// SSEResponseMetadata holds encryption and checksum metadata needed for HTTP response headers
 type SSEResponseMetadata struct {
 	SSEType          string
 	KMSKeyID         string
 	BucketKeyEnabled bool
+	Checksum         *objectChecksum // flexible checksum recorded for the upload, nil if none was requested
 }
 
 func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request) {
@@ -233,6 +234,7 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 
 			// Set SSE response headers for versioned objects
 			s3a.setSSEResponseHeaders(w, r, sseMetadata)
+			setChecksumResponseHeaders(w, sseMetadata.Checksum)
 
 		case s3_constants.VersioningSuspended:
 			// Handle suspended versioning - overwrite with "null" version ID but preserve existing versions
@@ -251,6 +253,7 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 
 			// Set SSE response headers for suspended versioning
 			s3a.setSSEResponseHeaders(w, r, sseMetadata)
+			setChecksumResponseHeaders(w, sseMetadata.Checksum)
 		default:
 			// Handle regular PUT (never configured versioning)
 			filePath := s3a.toFilerPath(bucket, object)
@@ -270,6 +273,7 @@ func (s3a *S3ApiServer) PutObjectHandler(w http.ResponseWriter, r *http.Request)
 
 			// Set SSE response headers based on encryption type used
 			s3a.setSSEResponseHeaders(w, r, sseMetadata)
+			setChecksumResponseHeaders(w, sseMetadata.Checksum)
 		}
 	}
 	stats_collect.RecordBucketActiveTime(bucket)
@@ -283,6 +287,17 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, filePath string, dataReader
 	// This eliminates the filer proxy overhead for PUT operations
 	// Note: filePath is now passed directly instead of URL (no parsing needed)
 
+	// Compute the requested flexible checksum over the plaintext, before any encryption is applied
+	checksumAlgorithm, expectedChecksum, checksumErrCode := getRequestChecksumAlgorithm(r)
+	if checksumErrCode != s3err.ErrNone {
+		return "", checksumErrCode, SSEResponseMetadata{}
+	}
+	var dataChecksum *checksumReader
+	if checksumAlgorithm != ChecksumAlgorithmNone {
+		dataChecksum = newChecksumReader(dataReader, checksumAlgorithm, expectedChecksum)
+		dataReader = dataChecksum
+	}
+
 	// For SSE, encrypt with offset=0 for all parts
 	// Each part is encrypted independently, then decrypted using metadata during GET
 	partOffset := int64(0)
@@ -558,6 +573,13 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, filePath string, dataReader
 		entry.Extended[s3_constants.SeaweedFSExpiresS3] = []byte("true")
 	}
 
+	// Store the flexible checksum so it can be returned on GET/HEAD and GetObjectAttributes
+	var uploadChecksum *objectChecksum
+	if dataChecksum != nil {
+		uploadChecksum = dataChecksum.checksum()
+		setEntryChecksum(entry, uploadChecksum)
+	}
+
 	// Copy user metadata and standard headers
 	for k, v := range r.Header {
 		if len(v) > 0 && len(v[0]) > 0 {
@@ -675,7 +697,8 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, filePath string, dataReader
 
 	// Build SSE response metadata with encryption details
 	responseMetadata := SSEResponseMetadata{
-		SSEType: sseType,
+		SSEType:  sseType,
+		Checksum: uploadChecksum,
 	}
 
 	// For SSE-KMS, include key ID and bucket-key-enabled flag from stored metadata
@@ -0,0 +1,25 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+// StoreMigration controls the online migration of the default filer store.
+func (fs *FilerServer) StoreMigration(ctx context.Context, req *filer_pb.StoreMigrationRequest) (*filer_pb.StoreMigrationResponse, error) {
+
+	migration := fs.filer.StoreMigration
+	if migration == nil {
+		return &filer_pb.StoreMigrationResponse{
+			Error: "no migration target store is configured, add a \"<storeName>.migration\" section to filer.toml",
+		}, nil
+	}
+
+	status, err := migration.Do(req.Action)
+	resp := &filer_pb.StoreMigrationResponse{Status: status}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
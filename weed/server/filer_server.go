@@ -57,6 +57,9 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/security"
 )
 
+// the renditions of transformed images kept in memory
+const imageRenditionCacheSize = 64 * 1024 * 1024
+
 type FilerOption struct {
 	Masters                   *pb.ServerDiscovery
 	FilerGroup                string
@@ -104,6 +107,10 @@ type FilerServer struct {
 	volumeGuard    *security.Guard
 	grpcDialOption grpc.DialOption
 
+	// signs the image transformations, as the volume servers
+	imageTransformKey   []byte
+	imageRenditionCache *filer.ImageRenditionCache
+
 	// metrics read from the master
 	metricsAddress     string
 	metricsIntervalSec int
@@ -154,6 +161,8 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 		knownListeners:        make(map[int32]int32),
 		inFlightDataLimitCond: sync.NewCond(new(sync.Mutex)),
 		CredentialManager:     option.CredentialManager,
+		imageTransformKey:     []byte(v.GetString("image.transform.key")),
+		imageRenditionCache:   filer.NewImageRenditionCache(imageRenditionCacheSize),
 	}
 	fs.listenersCond = sync.NewCond(&fs.listenersLock)
 
@@ -203,6 +212,9 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	// replaced by https://github.com/seaweedfs/seaweedfs/wiki/Path-Specific-Configuration
 	// fs.filer.FsyncBuckets = v.GetStringSlice("filer.options.buckets_fsync")
 	isFresh := fs.filer.LoadConfiguration(v)
+	if fs.filer.StoreMigration != nil {
+		fs.filer.StoreMigration.Run(string(option.Host), fs.filer.DirBucketsPath)
+	}
 
 	notification.LoadConfiguration(v, "notification.")
 
@@ -248,6 +260,7 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	fs.filer.LoadFilerConf()
 
 	fs.filer.LoadRemoteStorageConfAndMapping()
+	go fs.loopFollowFederation()
 
 	grace.OnReload(fs.Reload)
 	grace.OnInterrupt(func() {
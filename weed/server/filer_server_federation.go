@@ -0,0 +1,450 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/cluster"
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/remote_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage/seaweedfs"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// A federated directory mounts a directory of another SeaweedFS cluster, as a remote storage of type "seaweedfs".
+// One filer of the cluster, holding the federation lock, follows each federated directory:
+//   - the metadata changes on the peer cluster are applied to the local directory as remote-only entries,
+//     so the next read fetches the new content from the peer cluster and caches it locally.
+//   - the local changes are forwarded to the peer cluster.
+//
+// The changes made by the federation carry seaweedfs.FederationSignature, and are not sent back.
+const (
+	federationLockName      = "filer.federation"
+	federationCheckInterval = 10 * time.Second
+	federationRetryInterval = 1737 * time.Millisecond
+	federationOffsetSaving  = 3 * time.Second
+)
+
+type federationFollower struct {
+	dir    util.FullPath
+	conf   *remote_pb.RemoteConf
+	loc    *remote_pb.RemoteStorageLocation
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (fs *FilerServer) loopFollowFederation() {
+	lockClient := cluster.NewLockClient(fs.grpcDialOption, fs.option.Host)
+	lock := lockClient.StartLongLivedLock(federationLockName, string(fs.option.Host), func(newLockOwner string) {
+		glog.V(0).Infof("federated directories are followed by filer %s", newLockOwner)
+	}, 0)
+	defer lock.Stop()
+
+	followers := make(map[util.FullPath]*federationFollower)
+	stopFollower := func(dir util.FullPath) {
+		follower := followers[dir]
+		follower.cancel()
+		follower.wg.Wait()
+		delete(followers, dir)
+		glog.V(0).Infof("stop following federated directory %s", dir)
+	}
+
+	for {
+		var mounts map[util.FullPath]*federationFollower
+		if lock.IsLocked() {
+			mounts = fs.listFederatedDirectories()
+		}
+		for dir, follower := range followers {
+			if mount, found := mounts[dir]; !found || !proto.Equal(mount.conf, follower.conf) || !proto.Equal(mount.loc, follower.loc) {
+				stopFollower(dir)
+			}
+		}
+		for dir, mount := range mounts {
+			if _, found := followers[dir]; !found {
+				followers[dir] = mount
+				fs.startFederationFollower(mount)
+			}
+		}
+		time.Sleep(federationCheckInterval)
+	}
+}
+
+// listFederatedDirectories finds the mounted directories whose remote storage is another SeaweedFS cluster
+func (fs *FilerServer) listFederatedDirectories() map[util.FullPath]*federationFollower {
+	mounts := make(map[util.FullPath]*federationFollower)
+	entry, err := fs.filer.FindEntry(context.Background(), util.NewFullPath(filer.DirectoryEtcRemote, filer.REMOTE_STORAGE_MOUNT_FILE))
+	if err != nil {
+		if err != filer_pb.ErrNotFound {
+			glog.Warningf("read remote storage mount mapping: %v", err)
+		}
+		return mounts
+	}
+	mappings, err := filer.UnmarshalRemoteStorageMappings(entry.Content)
+	if err != nil {
+		return mounts
+	}
+	for dir, loc := range mappings.Mappings {
+		_, conf, found := fs.filer.RemoteStorage.GetRemoteStorageClient(loc.Name)
+		if !found || conf.Type != "seaweedfs" {
+			continue
+		}
+		mounts[util.FullPath(dir)] = &federationFollower{
+			dir:  util.FullPath(dir),
+			conf: conf,
+			loc:  loc,
+		}
+	}
+	return mounts
+}
+
+func (fs *FilerServer) startFederationFollower(follower *federationFollower) {
+	glog.V(0).Infof("follow federated directory %s => %s", follower.dir, remote_storage.FormatLocation(follower.loc))
+	ctx, cancel := context.WithCancel(context.Background())
+	follower.cancel = cancel
+	follower.wg.Add(2)
+	go func() {
+		defer follower.wg.Done()
+		fs.keepFollowing(ctx, "peer "+string(follower.dir), func() error {
+			return fs.followPeerChanges(ctx, follower)
+		})
+	}()
+	go func() {
+		defer follower.wg.Done()
+		fs.keepFollowing(ctx, "local "+string(follower.dir), func() error {
+			return fs.forwardLocalChanges(ctx, follower)
+		})
+	}()
+}
+
+func (fs *FilerServer) keepFollowing(ctx context.Context, name string, fn func() error) {
+	for {
+		err := fn()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			glog.Errorf("federation follow %s: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(federationRetryInterval):
+		}
+	}
+}
+
+// subscribeFederationMetadata follows the metadata changes until the context is cancelled,
+// skipping the changes made by the federation, and saves the processed offset periodically.
+func (fs *FilerServer) subscribeFederationMetadata(ctx context.Context, filers []pb.ServerAddress, clientName string, pathPrefix string, offsetKey []byte, startTsNs int64, processEventFn pb.ProcessMetadataFunc) error {
+	lastSavedTime := time.Now()
+	lastTsNs := startTsNs
+	saveOffset := func() {
+		if lastTsNs <= startTsNs {
+			return
+		}
+		if err := fs.filer.Store.KvPut(context.Background(), offsetKey, seaweedfs.EncodeOffset(lastTsNs)); err != nil {
+			glog.Warningf("save %s offset: %v", clientName, err)
+		}
+		startTsNs = lastTsNs
+	}
+	defer saveOffset()
+
+	return pb.WithOneOfGrpcFilerClients(true, filers, fs.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		stream, err := client.SubscribeMetadata(ctx, &filer_pb.SubscribeMetadataRequest{
+			ClientName: clientName,
+			PathPrefix: pathPrefix,
+			SinceNs:    lastTsNs,
+			Signature:  seaweedfs.FederationSignature,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe: %w", err)
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err = processEventFn(resp); err != nil {
+				return fmt.Errorf("process %s/%s: %w", resp.Directory, resp.EventNotification.GetOldEntry().GetName()+resp.EventNotification.GetNewEntry().GetName(), err)
+			}
+			lastTsNs = resp.TsNs
+			if lastSavedTime.Add(federationOffsetSaving).Before(time.Now()) {
+				lastSavedTime = time.Now()
+				saveOffset()
+			}
+		}
+	})
+}
+
+func (fs *FilerServer) readFederationOffset(key []byte, defaultTsNs int64) int64 {
+	value, err := fs.filer.Store.KvGet(context.Background(), key)
+	if err != nil {
+		return defaultTsNs
+	}
+	if offsetTsNs := seaweedfs.DecodeOffset(value); offsetTsNs > 0 {
+		return offsetTsNs
+	}
+	return defaultTsNs
+}
+
+// readPeerTime returns the current time of the peer cluster, to start following its changes
+func (fs *FilerServer) readPeerTime(ctx context.Context, peerFilers []pb.ServerAddress) (peerTsNs int64, err error) {
+	err = pb.WithOneOfGrpcFilerClients(false, peerFilers, fs.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		resp, pingErr := client.Ping(ctx, &filer_pb.PingRequest{})
+		if pingErr != nil {
+			return pingErr
+		}
+		peerTsNs = resp.StartTimeNs
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("read peer time: %w", err)
+	}
+	return peerTsNs, nil
+}
+
+// followPeerChanges applies the metadata changes of the peer directory to the local directory
+func (fs *FilerServer) followPeerChanges(ctx context.Context, follower *federationFollower) error {
+	peerFilers := pb.ServerAddresses(follower.conf.SeaweedfsFilers).ToAddresses()
+
+	prefix := follower.loc.Path
+	if !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	offsetKey := seaweedfs.PeerOffsetKey(string(follower.dir))
+	startTsNs := fs.readFederationOffset(offsetKey, 0)
+	if startTsNs == 0 {
+		// the peer event timestamps come from the peer clock, which can be skewed from the local clock
+		peerTsNs, err := fs.readPeerTime(ctx, peerFilers)
+		if err != nil {
+			return err
+		}
+		if err = fs.filer.Store.KvPut(ctx, offsetKey, seaweedfs.EncodeOffset(peerTsNs)); err != nil {
+			return fmt.Errorf("save peer offset: %w", err)
+		}
+		startTsNs = peerTsNs
+	}
+
+	return fs.subscribeFederationMetadata(ctx, peerFilers, "filer.federation", prefix, offsetKey, startTsNs, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		message := resp.EventNotification
+		if message.OldEntry != nil {
+			oldPath := util.NewFullPath(resp.Directory, message.OldEntry.Name)
+			isRenamed := message.NewEntry != nil && (message.NewParentPath != resp.Directory || message.NewEntry.Name != message.OldEntry.Name)
+			if (message.NewEntry == nil || isRenamed) && strings.HasPrefix(string(oldPath), prefix) {
+				localPath := filer.MapRemoteStorageLocationPathToFullPath(follower.dir, follower.loc, string(oldPath))
+				if err := fs.deleteFederatedEntry(ctx, localPath); err != nil {
+					return err
+				}
+			}
+		}
+		if message.NewEntry != nil {
+			newPath := util.NewFullPath(message.NewParentPath, message.NewEntry.Name)
+			if strings.HasPrefix(string(newPath), prefix) {
+				localPath := filer.MapRemoteStorageLocationPathToFullPath(follower.dir, follower.loc, string(newPath))
+				if err := fs.saveFederatedEntry(ctx, localPath, follower.conf.Name, message.NewEntry); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (fs *FilerServer) deleteFederatedEntry(ctx context.Context, localPath util.FullPath) error {
+	dir, name := localPath.DirAndName()
+	glog.V(2).Infof("federation delete %s", localPath)
+	resp, err := fs.DeleteEntry(ctx, &filer_pb.DeleteEntryRequest{
+		Directory:            dir,
+		Name:                 name,
+		IsDeleteData:         true,
+		IsRecursive:          true,
+		IgnoreRecursiveError: true,
+		Signatures:           []int32{seaweedfs.FederationSignature},
+	})
+	if err == nil && resp.Error != "" {
+		err = fmt.Errorf("%s", resp.Error)
+	}
+	return err
+}
+
+// saveFederatedEntry creates or updates the local entry of a peer entry.
+// A file is saved as remote-only, and its content is fetched from the peer cluster on the next read.
+func (fs *FilerServer) saveFederatedEntry(ctx context.Context, localPath util.FullPath, storageName string, peerEntry *filer_pb.Entry) error {
+	existing, err := fs.filer.FindEntry(ctx, localPath)
+	if err != nil && err != filer_pb.ErrNotFound {
+		return err
+	}
+
+	dir, name := localPath.DirAndName()
+	entry := &filer_pb.Entry{
+		Name:        name,
+		IsDirectory: peerEntry.IsDirectory,
+		Attributes:  &filer_pb.FuseAttributes{},
+		Extended:    peerEntry.Extended,
+	}
+	if peerEntry.Attributes != nil {
+		entry.Attributes.Mtime = peerEntry.Attributes.Mtime
+		entry.Attributes.Crtime = peerEntry.Attributes.Crtime
+		entry.Attributes.FileMode = peerEntry.Attributes.FileMode
+		entry.Attributes.Uid = peerEntry.Attributes.Uid
+		entry.Attributes.Gid = peerEntry.Attributes.Gid
+		entry.Attributes.Mime = peerEntry.Attributes.Mime
+		entry.Attributes.SymlinkTarget = peerEntry.Attributes.SymlinkTarget
+	}
+
+	remoteEntry := seaweedfs.ToRemoteEntry(storageName, peerEntry)
+	if peerEntry.IsDirectory {
+		if existing != nil && existing.IsDirectory() {
+			return nil
+		}
+		entry.RemoteEntry = remoteEntry
+	} else {
+		if existing != nil && existing.Remote != nil &&
+			existing.Remote.RemoteETag == remoteEntry.RemoteETag &&
+			existing.Remote.RemoteMtime == remoteEntry.RemoteMtime &&
+			existing.Remote.RemoteSize == remoteEntry.RemoteSize {
+			// the same version, cached or not
+			return nil
+		}
+		if existing != nil && existing.Remote == nil && existing.Attr.Mtime.Unix() > remoteEntry.RemoteMtime {
+			// a newer local change, which is being forwarded to the peer cluster
+			return nil
+		}
+		entry.Attributes.FileSize = uint64(remoteEntry.RemoteSize)
+		entry.RemoteEntry = remoteEntry
+	}
+
+	glog.V(2).Infof("federation save %s", localPath)
+	resp, err := fs.CreateEntry(ctx, &filer_pb.CreateEntryRequest{
+		Directory:  dir,
+		Entry:      entry,
+		Signatures: []int32{seaweedfs.FederationSignature},
+	})
+	if err == nil && resp.Error != "" {
+		err = fmt.Errorf("%s", resp.Error)
+	}
+	return err
+}
+
+// forwardLocalChanges writes the local changes of the federated directory to the peer cluster
+func (fs *FilerServer) forwardLocalChanges(ctx context.Context, follower *federationFollower) error {
+	client, err := remote_storage.GetRemoteStorage(follower.conf)
+	if err != nil {
+		return err
+	}
+
+	prefix := string(follower.dir) + "/"
+	offsetKey := seaweedfs.LocalOffsetKey(string(follower.dir))
+	defaultTsNs := time.Now().UnixNano()
+	if dirEntry, findErr := fs.filer.FindEntry(ctx, follower.dir); findErr == nil {
+		defaultTsNs = dirEntry.Crtime.UnixNano()
+	}
+	startTsNs := fs.readFederationOffset(offsetKey, defaultTsNs)
+
+	toPeer := func(dir, name string) *remote_pb.RemoteStorageLocation {
+		return filer.MapFullPathToRemoteStorageLocation(follower.dir, follower.loc, util.NewFullPath(dir, name))
+	}
+
+	return fs.subscribeFederationMetadata(ctx, []pb.ServerAddress{fs.option.Host}, "filer.federation.forward", prefix, offsetKey, startTsNs, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		message := resp.EventNotification
+		if filer_pb.IsEmpty(resp) {
+			return nil
+		}
+		if filer_pb.IsDelete(resp) {
+			dest := toPeer(resp.Directory, message.OldEntry.Name)
+			glog.V(2).Infof("federation forward delete %s", remote_storage.FormatLocation(dest))
+			if message.OldEntry.IsDirectory {
+				return client.RemoveDirectory(dest)
+			}
+			return client.DeleteFile(dest)
+		}
+
+		dest := toPeer(message.NewParentPath, message.NewEntry.Name)
+		if filer_pb.IsRename(resp) {
+			oldDest := toPeer(resp.Directory, message.OldEntry.Name)
+			glog.V(2).Infof("federation forward rename %s => %s", remote_storage.FormatLocation(oldDest), remote_storage.FormatLocation(dest))
+			if message.OldEntry.IsDirectory {
+				if err := client.RemoveDirectory(oldDest); err != nil {
+					return err
+				}
+			} else if err := client.DeleteFile(oldDest); err != nil {
+				return err
+			}
+		}
+
+		if message.NewEntry.IsDirectory {
+			if message.NewEntry.RemoteEntry != nil {
+				// pulled from the peer cluster
+				return nil
+			}
+			return client.WriteDirectory(dest, message.NewEntry)
+		}
+		if !shouldForwardToPeer(message.NewEntry) {
+			return nil
+		}
+		if message.OldEntry != nil && !filer_pb.IsRename(resp) && filer.IsSameData(message.OldEntry, message.NewEntry) {
+			glog.V(2).Infof("federation forward metadata %s", remote_storage.FormatLocation(dest))
+			return client.UpdateFileMetadata(dest, message.OldEntry, message.NewEntry)
+		}
+
+		glog.V(2).Infof("federation forward %s", remote_storage.FormatLocation(dest))
+		remoteEntry, err := client.WriteFile(dest, message.NewEntry, fs.newLocalFileReader(ctx, message.NewEntry))
+		if err != nil {
+			return err
+		}
+		return fs.markFederatedEntrySynced(ctx, util.NewFullPath(message.NewParentPath, message.NewEntry.Name), message.NewEntry, remoteEntry)
+	})
+}
+
+// shouldForwardToPeer skips the entries fetched from the peer cluster and not changed locally since then
+func shouldForwardToPeer(entry *filer_pb.Entry) bool {
+	if entry.RemoteEntry == nil {
+		return true
+	}
+	return entry.RemoteEntry.RemoteMtime < entry.Attributes.Mtime
+}
+
+func (fs *FilerServer) newLocalFileReader(ctx context.Context, entry *filer_pb.Entry) io.Reader {
+	if len(entry.Content) > 0 {
+		return bytes.NewReader(entry.Content)
+	}
+	return filer.NewChunkStreamReaderFromFiler(ctx, fs.filer.MasterClient, entry.GetChunks())
+}
+
+// markFederatedEntrySynced records the peer version of the forwarded file,
+// unless the file has changed again since the forwarded version.
+func (fs *FilerServer) markFederatedEntrySynced(ctx context.Context, fullpath util.FullPath, forwarded *filer_pb.Entry, remoteEntry *filer_pb.RemoteEntry) error {
+	current, err := fs.filer.FindEntry(ctx, fullpath)
+	if err == filer_pb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	currentEntry := current.ToProtoEntry()
+	if !filer.IsSameData(currentEntry, forwarded) {
+		return nil
+	}
+	remoteEntry.LastLocalSyncTsNs = time.Now().UnixNano()
+	currentEntry.RemoteEntry = remoteEntry
+	dir, _ := fullpath.DirAndName()
+	_, err = fs.UpdateEntry(ctx, &filer_pb.UpdateEntryRequest{
+		Directory:  dir,
+		Entry:      currentEntry,
+		Signatures: []int32{seaweedfs.FederationSignature},
+	})
+	return err
+}
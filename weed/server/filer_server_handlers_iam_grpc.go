@@ -3,6 +3,7 @@ package weed_server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/seaweedfs/seaweedfs/weed/credential"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -478,3 +479,280 @@ func (s *IamGrpcServer) GetServiceAccountByAccessKey(ctx context.Context, req *i
 		ServiceAccount: sa,
 	}, nil
 }
+
+//////////////////////////////////////////////////
+// Group Management
+
+func (s *IamGrpcServer) CreateGroup(ctx context.Context, req *iam_pb.CreateGroupRequest) (*iam_pb.CreateGroupResponse, error) {
+	if req == nil || req.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group is required")
+	}
+	if err := credential.ValidateGroupName(req.Group.Name); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	glog.V(4).Infof("CreateGroup: %s", req.Group.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.CreateGroup(ctx, req.Group); err != nil {
+		if errors.Is(err, credential.ErrGroupAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "group %s already exists", req.Group.Name)
+		}
+		glog.Errorf("Failed to create group %s: %v", req.Group.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to create group: %v", err)
+	}
+
+	return &iam_pb.CreateGroupResponse{}, nil
+}
+
+func (s *IamGrpcServer) UpdateGroup(ctx context.Context, req *iam_pb.UpdateGroupRequest) (*iam_pb.UpdateGroupResponse, error) {
+	if req == nil || req.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group is required")
+	}
+	glog.V(4).Infof("UpdateGroup: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.UpdateGroup(ctx, req.Name, req.Group); err != nil {
+		if errors.Is(err, credential.ErrGroupNotFound) {
+			return nil, status.Errorf(codes.NotFound, "group %s not found", req.Name)
+		}
+		glog.Errorf("Failed to update group %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to update group: %v", err)
+	}
+
+	return &iam_pb.UpdateGroupResponse{}, nil
+}
+
+func (s *IamGrpcServer) DeleteGroup(ctx context.Context, req *iam_pb.DeleteGroupRequest) (*iam_pb.DeleteGroupResponse, error) {
+	glog.V(4).Infof("DeleteGroup: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.DeleteGroup(ctx, req.Name); err != nil {
+		if errors.Is(err, credential.ErrGroupNotFound) {
+			return nil, status.Errorf(codes.NotFound, "group %s not found", req.Name)
+		}
+		glog.Errorf("Failed to delete group %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to delete group: %v", err)
+	}
+
+	return &iam_pb.DeleteGroupResponse{}, nil
+}
+
+func (s *IamGrpcServer) GetGroup(ctx context.Context, req *iam_pb.GetGroupRequest) (*iam_pb.GetGroupResponse, error) {
+	glog.V(4).Infof("GetGroup: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	group, err := s.credentialManager.GetGroup(ctx, req.Name)
+	if err != nil {
+		if errors.Is(err, credential.ErrGroupNotFound) {
+			return nil, status.Errorf(codes.NotFound, "group %s not found", req.Name)
+		}
+		glog.Errorf("Failed to get group %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to get group: %v", err)
+	}
+
+	return &iam_pb.GetGroupResponse{
+		Group: group,
+	}, nil
+}
+
+func (s *IamGrpcServer) ListGroups(ctx context.Context, req *iam_pb.ListGroupsRequest) (*iam_pb.ListGroupsResponse, error) {
+	glog.V(4).Infof("ListGroups")
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	groups, err := s.credentialManager.ListGroups(ctx)
+	if err != nil {
+		glog.Errorf("Failed to list groups: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list groups: %v", err)
+	}
+
+	return &iam_pb.ListGroupsResponse{
+		Groups: groups,
+	}, nil
+}
+
+//////////////////////////////////////////////////
+// Role Management
+
+func (s *IamGrpcServer) CreateRole(ctx context.Context, req *iam_pb.CreateRoleRequest) (*iam_pb.CreateRoleResponse, error) {
+	if req == nil || req.Role == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	if err := credential.ValidateRoleName(req.Role.Name); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	glog.V(4).Infof("CreateRole: %s", req.Role.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.CreateRole(ctx, req.Role); err != nil {
+		if errors.Is(err, credential.ErrRoleAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "role %s already exists", req.Role.Name)
+		}
+		glog.Errorf("Failed to create role %s: %v", req.Role.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to create role: %v", err)
+	}
+
+	return &iam_pb.CreateRoleResponse{}, nil
+}
+
+func (s *IamGrpcServer) UpdateRole(ctx context.Context, req *iam_pb.UpdateRoleRequest) (*iam_pb.UpdateRoleResponse, error) {
+	if req == nil || req.Role == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "role is required")
+	}
+	glog.V(4).Infof("UpdateRole: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.UpdateRole(ctx, req.Name, req.Role); err != nil {
+		if errors.Is(err, credential.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role %s not found", req.Name)
+		}
+		glog.Errorf("Failed to update role %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to update role: %v", err)
+	}
+
+	return &iam_pb.UpdateRoleResponse{}, nil
+}
+
+func (s *IamGrpcServer) DeleteRole(ctx context.Context, req *iam_pb.DeleteRoleRequest) (*iam_pb.DeleteRoleResponse, error) {
+	glog.V(4).Infof("DeleteRole: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.DeleteRole(ctx, req.Name); err != nil {
+		if errors.Is(err, credential.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role %s not found", req.Name)
+		}
+		glog.Errorf("Failed to delete role %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to delete role: %v", err)
+	}
+
+	return &iam_pb.DeleteRoleResponse{}, nil
+}
+
+func (s *IamGrpcServer) GetRole(ctx context.Context, req *iam_pb.GetRoleRequest) (*iam_pb.GetRoleResponse, error) {
+	glog.V(4).Infof("GetRole: %s", req.Name)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	role, err := s.credentialManager.GetRole(ctx, req.Name)
+	if err != nil {
+		if errors.Is(err, credential.ErrRoleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "role %s not found", req.Name)
+		}
+		glog.Errorf("Failed to get role %s: %v", req.Name, err)
+		return nil, status.Errorf(codes.Internal, "failed to get role: %v", err)
+	}
+
+	return &iam_pb.GetRoleResponse{
+		Role: role,
+	}, nil
+}
+
+func (s *IamGrpcServer) ListRoles(ctx context.Context, req *iam_pb.ListRolesRequest) (*iam_pb.ListRolesResponse, error) {
+	glog.V(4).Infof("ListRoles")
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	roles, err := s.credentialManager.ListRoles(ctx)
+	if err != nil {
+		glog.Errorf("Failed to list roles: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list roles: %v", err)
+	}
+
+	return &iam_pb.ListRolesResponse{
+		Roles: roles,
+	}, nil
+}
+
+//////////////////////////////////////////////////
+// Managed Policy Versions
+
+func (s *IamGrpcServer) GetPolicyVersions(ctx context.Context, req *iam_pb.GetPolicyVersionsRequest) (*iam_pb.GetPolicyVersionsResponse, error) {
+	glog.V(4).Infof("GetPolicyVersions: %s", req.PolicyName)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	versions, err := s.credentialManager.GetPolicyVersions(ctx, req.PolicyName)
+	if err != nil {
+		glog.Errorf("Failed to get versions of policy %s: %v", req.PolicyName, err)
+		return nil, status.Errorf(codes.Internal, "failed to get policy versions: %v", err)
+	}
+
+	return &iam_pb.GetPolicyVersionsResponse{
+		PolicyVersions: versions,
+	}, nil
+}
+
+func (s *IamGrpcServer) PutPolicyVersions(ctx context.Context, req *iam_pb.PutPolicyVersionsRequest) (*iam_pb.PutPolicyVersionsResponse, error) {
+	if req == nil || req.PolicyVersions == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "policy versions are required")
+	}
+	if err := credential.ValidatePolicyName(req.PolicyVersions.PolicyName); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	glog.V(4).Infof("PutPolicyVersions: %s", req.PolicyVersions.PolicyName)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	if err := s.credentialManager.PutPolicyVersions(ctx, req.PolicyVersions); err != nil {
+		glog.Errorf("Failed to put versions of policy %s: %v", req.PolicyVersions.PolicyName, err)
+		return nil, status.Errorf(codes.Internal, "failed to put policy versions: %v", err)
+	}
+
+	return &iam_pb.PutPolicyVersionsResponse{}, nil
+}
+
+//////////////////////////////////////////////////
+// Access Key Usage
+
+func (s *IamGrpcServer) UpdateAccessKeyLastUsed(ctx context.Context, req *iam_pb.UpdateAccessKeyLastUsedRequest) (*iam_pb.UpdateAccessKeyLastUsedResponse, error) {
+	glog.V(4).Infof("UpdateAccessKeyLastUsed: %s for user: %s", req.AccessKey, req.Username)
+
+	if s.credentialManager == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "credential manager is not configured")
+	}
+
+	err := s.credentialManager.UpdateAccessKeyLastUsed(ctx, req.Username, req.AccessKey, req.LastUsedDate, req.Service, req.Region)
+	if err != nil {
+		if errors.Is(err, credential.ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", req.Username)
+		}
+		if errors.Is(err, credential.ErrAccessKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "access key %s not found", req.AccessKey)
+		}
+		glog.Errorf("Failed to update last used of access key %s: %v", req.AccessKey, err)
+		return nil, status.Errorf(codes.Internal, "failed to update access key last used: %v", err)
+	}
+
+	return &iam_pb.UpdateAccessKeyLastUsedResponse{}, nil
+}
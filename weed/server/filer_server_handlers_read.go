@@ -2,7 +2,9 @@ package weed_server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"math"
 	"mime"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/filer"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/images"
 	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
 	"github.com/seaweedfs/seaweedfs/weed/s3api/s3_constants"
 	"github.com/seaweedfs/seaweedfs/weed/security"
@@ -138,6 +141,15 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	transform, err := images.ParseTransform(query)
+	if err == nil && transform != nil {
+		err = transform.VerifySignature(fs.imageTransformKey, r.URL.Path, query.Get(images.TransformSignatureParam))
+	}
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
 	if checkPreconditions(w, r, entry) {
 		return
 	}
@@ -187,6 +199,12 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 	// For SSE objects, we need the original unencrypted size for proper range validation
 	totalSize := int64(entry.FileSize)
 
+	// a HEAD request gets the headers of the rendition, including its size
+	if transform != nil && !entry.IsInRemoteOnly() && images.IsTransformableImage(filepath.Ext(filename), mimeType) {
+		fs.writeImageRendition(w, r, entry, transform, etag)
+		return
+	}
+
 	if r.Method == http.MethodHead {
 		w.Header().Set("Content-Length", strconv.FormatInt(totalSize, 10))
 		return
@@ -242,6 +260,71 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// writeImageRendition writes the transformed image, or the original image if it can not be transformed.
+func (fs *FilerServer) writeImageRendition(w http.ResponseWriter, r *http.Request, entry *filer.Entry, transform *images.Transform, etag string) {
+	ctx := r.Context()
+	accept := r.Header.Get("Accept")
+	if transform.Format == images.FormatAuto {
+		w.Header().Add("Vary", "Accept")
+	}
+
+	negotiated := transform.Negotiate(accept)
+	h := fnv.New32a()
+	h.Write([]byte(negotiated.Key()))
+	renditionEtag := fmt.Sprintf("%s-%x", etag, h.Sum32())
+	if r.Header.Get("If-None-Match") == "\""+renditionEtag+"\"" {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	cacheKey := filer.ImageRenditionKey(string(entry.FullPath), etag, negotiated)
+	data, contentType, found := fs.imageRenditionCache.Get(cacheKey)
+	var err error
+	if !found {
+		data, contentType, err = filer.ReadImageRendition(ctx, fs.filer.MasterClient, fs.maybeGetVolumeReadJwtAuthorizationToken,
+			entry.Content, entry.GetChunks(), int64(entry.FileSize), filepath.Ext(entry.Name()), transform, fs.imageTransformKey, accept)
+		if err == nil {
+			fs.imageRenditionCache.Set(cacheKey, data, contentType)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, images.ErrUnsupportedFormat) && transform.Format != "" {
+			writeJsonError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		glog.V(1).InfofCtx(ctx, "transform image %s: %v", entry.FullPath, err)
+		// serve the original image
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.FormatUint(entry.FileSize, 10))
+			return
+		}
+		ProcessRangeRequest(r, w, int64(entry.FileSize), w.Header().Get("Content-Type"), func(offset int64, size int64) (filer.DoStreamContent, error) {
+			if offset+size <= int64(len(entry.Content)) {
+				return func(writer io.Writer) error {
+					_, err := writer.Write(entry.Content[offset : offset+size])
+					return err
+				}, nil
+			}
+			return filer.PrepareStreamContentWithThrottler(ctx, fs.filer.MasterClient, fs.maybeGetVolumeReadJwtAuthorizationToken, entry.GetChunks(), offset, size, fs.option.DownloadMaxBytesPs)
+		})
+		return
+	}
+
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	SetEtag(w, renditionEtag)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err = w.Write(data); err != nil {
+		stats.FilerHandlerCounter.WithLabelValues(stats.ErrorWriteEntry).Inc()
+		glog.V(2).InfofCtx(ctx, "write image rendition %s: %v", entry.FullPath, err)
+	}
+}
+
 func (fs *FilerServer) maybeGetVolumeReadJwtAuthorizationToken(fileId string) string {
 	return string(security.GenJwtForVolumeServer(fs.volumeGuard.ReadSigningKey, fs.volumeGuard.ReadExpiresAfterSec, fileId))
 }
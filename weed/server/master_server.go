@@ -9,6 +9,7 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/sequence"
 	"github.com/seaweedfs/seaweedfs/weed/shell"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/topology"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
@@ -116,6 +118,33 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers map[string]pb.Se
 	topology.VolumeGrowStrategy.Copy3Count = v.GetUint32("master.volume_growth.copy_3")
 	topology.VolumeGrowStrategy.CopyOtherCount = v.GetUint32("master.volume_growth.copy_other")
 	topology.VolumeGrowStrategy.Threshold = v.GetFloat64("master.volume_growth.threshold")
+	collectionVolumeSizeLimits := make(map[string]uint64)
+	for collection, limitMB := range v.GetStringMapString("master.volume_size_limit") {
+		if !isCollectionConfigKey(collection) {
+			glog.Fatalf("master.volume_size_limit.%s does not match a collection name", collection)
+		}
+		sizeLimitMB, parseErr := strconv.ParseUint(limitMB, 10, 64)
+		if parseErr != nil || sizeLimitMB == 0 {
+			glog.Fatalf("invalid master.volume_size_limit.%s: %s", collection, limitMB)
+		}
+		if sizeLimitMB*1024*1024 > types.OffsetWidth5.MaxVolumeSize() {
+			glog.Fatalf("master.volume_size_limit.%s %dMB exceeds the maximum volume size %dMB", collection, sizeLimitMB, types.OffsetWidth5.MaxVolumeSize()/1024/1024)
+		}
+		collectionVolumeSizeLimits[collection] = sizeLimitMB * 1024 * 1024
+		glog.V(0).Infof("Volume Size Limit of collection %s is %d MB", collection, sizeLimitMB)
+	}
+	collectionWormRetentions := make(map[string]time.Duration)
+	for collection, retention := range v.GetStringMapString("master.worm") {
+		if !isCollectionConfigKey(collection) {
+			glog.Fatalf("master.worm.%s does not match a collection name", collection)
+		}
+		wormRetention, parseErr := time.ParseDuration(retention)
+		if parseErr != nil || wormRetention < time.Second {
+			glog.Fatalf("invalid master.worm.%s: %s", collection, retention)
+		}
+		collectionWormRetentions[collection] = wormRetention
+		glog.V(0).Infof("Collection %s is write-once, retained for %v after the last write", collection, wormRetention)
+	}
 	whiteList := util.StringSplit(v.GetString("guard.white_list"), ",")
 
 	var preallocateSize int64
@@ -142,6 +171,8 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers map[string]pb.Se
 		glog.Fatalf("create sequencer failed.")
 	}
 	ms.Topo = topology.NewTopology("topo", seq, uint64(ms.option.VolumeSizeLimitMB)*1024*1024, 5, replicationAsMin)
+	ms.Topo.SetCollectionVolumeSizeLimits(collectionVolumeSizeLimits)
+	ms.Topo.SetCollectionWormRetentions(collectionWormRetentions)
 	ms.vg = topology.NewDefaultVolumeGrowth()
 	glog.V(0).Infoln("Volume Size Limit is", ms.option.VolumeSizeLimitMB, "MB")
 
@@ -478,3 +509,11 @@ func (ms *MasterServer) Reload() {
 		util.StringSplit(v.GetString("guard.white_list"), ",")...),
 	)
 }
+
+// the collection names in master.toml are lower cased by the configuration loading,
+// and the topology matches them case-insensitively
+var collectionConfigKeyPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+func isCollectionConfigKey(key string) bool {
+	return collectionConfigKeyPattern.MatchString(key)
+}
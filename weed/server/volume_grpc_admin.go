@@ -53,6 +53,8 @@ func (vs *VolumeServer) AllocateVolume(ctx context.Context, req *volume_server_p
 		req.MemoryMapMaxSizeMb,
 		types.ToDiskType(req.DiskType),
 		vs.ldbTimout,
+		req.BytesOffset,
+		req.WormRetentionSeconds,
 	)
 
 	if err != nil {
@@ -153,6 +155,45 @@ func (vs *VolumeServer) VolumeConfigure(ctx context.Context, req *volume_server_
 
 }
 
+func (vs *VolumeServer) VolumeConvertOffset(ctx context.Context, req *volume_server_pb.VolumeConvertOffsetRequest) (*volume_server_pb.VolumeConvertOffsetResponse, error) {
+
+	offsetWidth, err := types.ParseOffsetWidth(req.BytesOffset)
+	if err != nil {
+		return nil, fmt.Errorf("volume convert offset %v: %v", req, err)
+	}
+
+	previous, err := vs.store.ConvertVolumeOffsetWidth(needle.VolumeId(req.VolumeId), offsetWidth)
+	if err != nil {
+		glog.Errorf("volume convert offset %v: %v", req, err)
+		return nil, fmt.Errorf("volume convert offset %v: %v", req, err)
+	}
+
+	return &volume_server_pb.VolumeConvertOffsetResponse{
+		PreviousBytesOffset: uint32(previous),
+	}, nil
+
+}
+
+func (vs *VolumeServer) VolumeWormVerify(ctx context.Context, req *volume_server_pb.VolumeWormVerifyRequest) (*volume_server_pb.VolumeWormVerifyResponse, error) {
+
+	v := vs.store.GetVolume(needle.VolumeId(req.VolumeId))
+	if v == nil {
+		return nil, fmt.Errorf("volume %d not found", req.VolumeId)
+	}
+
+	count, head, err := v.VerifyWormChain()
+	if err != nil {
+		glog.Errorf("volume worm verify %d: %v", req.VolumeId, err)
+		return nil, fmt.Errorf("volume %d worm verify after %d needles: %v", req.VolumeId, count, err)
+	}
+
+	return &volume_server_pb.VolumeWormVerifyResponse{
+		NeedleCount: uint64(count),
+		ChainHead:   head,
+	}, nil
+
+}
+
 func (vs *VolumeServer) VolumeMarkReadonly(ctx context.Context, req *volume_server_pb.VolumeMarkReadonlyRequest) (*volume_server_pb.VolumeMarkReadonlyResponse, error) {
 
 	resp := &volume_server_pb.VolumeMarkReadonlyResponse{}
@@ -285,6 +326,17 @@ func (vs *VolumeServer) VolumeServerLeave(ctx context.Context, req *volume_serve
 
 }
 
+func (vs *VolumeServer) VolumeServerIoStats(ctx context.Context, req *volume_server_pb.VolumeServerIoStatsRequest) (*volume_server_pb.VolumeServerIoStatsResponse, error) {
+
+	resp := &volume_server_pb.VolumeServerIoStatsResponse{
+		VolumeIoStats: vs.store.CollectVolumeIoStats(),
+		TsNs:          time.Now().UnixNano(),
+	}
+
+	return resp, nil
+
+}
+
 func (vs *VolumeServer) VolumeNeedleStatus(ctx context.Context, req *volume_server_pb.VolumeNeedleStatusRequest) (*volume_server_pb.VolumeNeedleStatusResponse, error) {
 
 	resp := &volume_server_pb.VolumeNeedleStatusResponse{}
@@ -19,6 +19,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -148,6 +149,10 @@ func (vs *VolumeServer) VolumeCopy(req *volume_server_pb.VolumeCopyRequest, stre
 		if modifiedTsNs > 0 {
 			os.Chtimes(indexBaseFileName+".idx", time.Unix(0, modifiedTsNs), time.Unix(0, modifiedTsNs))
 		}
+		// the copied .idx file replaces the one the pebble index entries were built from
+		if err = storage.DropPebbleNeedleMap(location.IdxDirectory, needle.VolumeId(req.VolumeId)); err != nil {
+			return err
+		}
 
 		if modifiedTsNs, err = vs.doCopyFileWithThrottler(client, false, req.Collection, req.VolumeId, volFileInfoResp.CompactionRevision, 1024*1024, dataBaseFileName, ".vif", false, true, nil, throttler); err != nil {
 			return err
@@ -328,6 +333,9 @@ func (vs *VolumeServer) ReadVolumeFileStatus(ctx context.Context, req *volume_se
 	resp.DiskType = string(v.DiskType())
 	resp.VolumeInfo = v.GetVolumeInfo()
 	resp.Version = uint32(v.Version())
+	if retainedUntil := v.WormRetainedUntil(); !retainedUntil.IsZero() {
+		resp.WormRetainedUntilSeconds = uint64(retainedUntil.Unix())
+	}
 	return resp, nil
 }
 
@@ -337,6 +345,7 @@ func (vs *VolumeServer) ReadVolumeFileStatus(ctx context.Context, req *volume_se
 func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream volume_server_pb.VolumeServer_CopyFileServer) error {
 
 	var fileName string
+	var offsetWidth types.OffsetWidth
 	if !req.IsEcVolume {
 		v := vs.store.GetVolume(needle.VolumeId(req.VolumeId))
 		if v == nil {
@@ -348,12 +357,16 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 		}
 		v.SyncToDisk()
 		fileName = v.FileName(req.Ext)
+		offsetWidth = v.OffsetWidth()
 	} else {
 		// Sync EC volume files to disk before copying to ensure deletions are visible
 		// This fixes issue #7751 where deleted files in encoded volumes were not
 		// properly marked as deleted when decoded.
-		if ecVolume, found := vs.store.FindEcVolume(needle.VolumeId(req.VolumeId)); found {
+		offsetWidth = types.DefaultOffsetWidth
+		ecVolume, found := vs.store.FindEcVolume(needle.VolumeId(req.VolumeId))
+		if found {
 			ecVolume.Sync()
+			offsetWidth = ecVolume.OffsetWidth()
 		}
 
 		baseFileName := erasure_coding.EcShardBaseFileName(req.Collection, int(req.VolumeId)) + req.Ext
@@ -366,6 +379,11 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 			if util.FileExists(tName) {
 				fileName = tName
 			}
+			if vifFileName := util.Join(location.Directory, erasure_coding.EcShardBaseFileName(req.Collection, int(req.VolumeId))+".vif"); !found && util.FileExists(vifFileName) {
+				if width, widthErr := volume_info.MaybeLoadOffsetWidth(vifFileName); widthErr == nil {
+					offsetWidth = width
+				}
+			}
 		}
 		if fileName == "" {
 			if req.IgnoreSourceFileNotFound {
@@ -417,6 +435,7 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 		err = stream.Send(&volume_server_pb.CopyFileResponse{
 			FileContent:  buffer[:bytesread],
 			ModifiedTsNs: fileModTsNs,
+			BytesOffset:  uint32(offsetWidth),
 		})
 		if err != nil {
 			// println("sending", bytesread, "bytes err", err.Error())
@@ -435,6 +454,7 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 	if fileModTsNs != 0 {
 		err = stream.Send(&volume_server_pb.CopyFileResponse{
 			ModifiedTsNs: fileModTsNs,
+			BytesOffset:  uint32(offsetWidth),
 		})
 		if err != nil {
 			return err
@@ -52,6 +52,10 @@ func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_
 	if v.Collection != req.Collection {
 		return nil, fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
 	}
+	if v.IsWorm() {
+		// ec shards do not enforce write-once, and the original volume can not be deleted
+		return nil, fmt.Errorf("write-once volume %d can not be erasure coded", req.VolumeId)
+	}
 
 	// Create EC context - prefer existing .vif config if present (for regeneration scenarios)
 	ecCtx := erasure_coding.NewDefaultECContext(req.Collection, needle.VolumeId(req.VolumeId))
@@ -88,7 +92,7 @@ func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_
 	}
 
 	// write .ecx file
-	if err := erasure_coding.WriteSortedFileFromIdx(v.IndexFileName(), ".ecx"); err != nil {
+	if err := erasure_coding.WriteSortedFileFromIdxWithWidth(v.IndexFileName(), ".ecx", v.OffsetWidth()); err != nil {
 		return nil, fmt.Errorf("WriteSortedFileFromIdx %s: %v", v.IndexFileName(), err)
 	}
 
@@ -102,6 +106,7 @@ func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_
 	}
 	volumeInfo := &volume_server_pb.VolumeInfo{Version: uint32(v.Version())}
 	volumeInfo.ExpireAtSec = expireAtSec
+	volumeInfo.BytesOffset = uint32(v.OffsetWidth())
 
 	datSize, _, _ := v.FileStat()
 	volumeInfo.DatFileSize = int64(datSize)
@@ -157,8 +162,12 @@ func (vs *VolumeServer) VolumeEcShardsRebuild(ctx context.Context, req *volume_s
 				rebuiltShardIds = generatedShardIds
 			}
 
+			offsetWidth, err := volume_info.MaybeLoadOffsetWidth(dataBaseFileName + ".vif")
+			if err != nil {
+				return nil, fmt.Errorf("MaybeLoadOffsetWidth %s: %v", dataBaseFileName, err)
+			}
 			indexBaseFileName := path.Join(location.IdxDirectory, baseFileName)
-			if err := erasure_coding.RebuildEcxFile(indexBaseFileName); err != nil {
+			if err := erasure_coding.RebuildEcxFile(indexBaseFileName, offsetWidth); err != nil {
 				return nil, fmt.Errorf("RebuildEcxFile %s: %v", dataBaseFileName, err)
 			}
 
@@ -512,7 +521,7 @@ func (vs *VolumeServer) VolumeEcShardsToVolume(ctx context.Context, req *volume_
 
 	// If the EC index contains no live entries, decoding should be a no-op:
 	// just allow the caller to purge EC shards and do not generate an empty normal volume.
-	hasLive, err := erasure_coding.HasLiveNeedles(indexBaseFileName)
+	hasLive, err := erasure_coding.HasLiveNeedles(indexBaseFileName, v.OffsetWidth())
 	if err != nil {
 		return nil, fmt.Errorf("HasLiveNeedles %s: %w", indexBaseFileName, err)
 	}
@@ -521,7 +530,7 @@ func (vs *VolumeServer) VolumeEcShardsToVolume(ctx context.Context, req *volume_
 	}
 
 	// calculate .dat file size
-	datFileSize, err := erasure_coding.FindDatFileSize(dataBaseFileName, indexBaseFileName)
+	datFileSize, err := erasure_coding.FindDatFileSize(dataBaseFileName, indexBaseFileName, v.OffsetWidth())
 	if err != nil {
 		return nil, fmt.Errorf("FindDatFileSize %s: %v", dataBaseFileName, err)
 	}
@@ -532,7 +541,7 @@ func (vs *VolumeServer) VolumeEcShardsToVolume(ctx context.Context, req *volume_
 	}
 
 	// write .idx file from .ecx and .ecj files
-	if err := erasure_coding.WriteIdxFileFromEcIndex(indexBaseFileName); err != nil {
+	if err := erasure_coding.WriteIdxFileFromEcIndex(indexBaseFileName, v.OffsetWidth()); err != nil {
 		return nil, fmt.Errorf("WriteIdxFileFromEcIndex %s: %v", v.IndexBaseFileName(), err)
 	}
 
@@ -0,0 +1,244 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+/*
+
+Steps to vacuum an erasure coded volume
+1. client calls VolumeEcShardsVacuumPrepare on one server holding shards, to snapshot the live needles into the .ecv file
+2. client calls VolumeEcShardsVacuumGenerate on every server holding shards, to generate the .ecNN.vac, .ecx.vac and .vif.vac files
+     each server reads the live data out of the old shards, local or remote
+3. client calls VolumeEcShardsUnmount on every server holding shards, the volume is unavailable until step 5
+4. client calls VolumeEcShardsVacuumCommit on every server holding shards, to swap in the new files
+     the old files are kept as backups, and a commit marker is rolled back on load if the commit is interrupted
+5. if every server committed, client calls VolumeEcShardsVacuumFinalize on every server holding shards, to remove the backups
+     otherwise, client calls VolumeEcShardsVacuumRollback on every server holding shards, to restore the backups
+6. client calls VolumeEcShardsMount on every server holding shards
+On failure before step 3, client calls VolumeEcShardsVacuumCleanup on every server holding shards.
+
+*/
+
+// localEcVolumes returns the ec volumes of the disks holding shards of the volume.
+func (vs *VolumeServer) localEcVolumes(volumeId uint32) (ecVolumes []*erasure_coding.EcVolume) {
+	for _, location := range vs.store.Locations {
+		if ecVolume, found := location.FindEcVolume(needle.VolumeId(volumeId)); found {
+			ecVolumes = append(ecVolumes, ecVolume)
+		}
+	}
+	return
+}
+
+// findEcVacuumIndex returns the index base file name of the local .ecv file of the volume.
+func (vs *VolumeServer) findEcVacuumIndex(collection string, volumeId uint32) (indexBaseFileName string, found bool) {
+	for _, location := range vs.store.Locations {
+		indexBaseFileName = erasure_coding.EcShardFileName(collection, location.IdxDirectory, int(volumeId))
+		if util.FileExists(indexBaseFileName + erasure_coding.EcVacuumIndexExt) {
+			return indexBaseFileName, true
+		}
+	}
+	return "", false
+}
+
+func (vs *VolumeServer) ecVolumeDatReader(ecVolume *erasure_coding.EcVolume) erasure_coding.EcDatReader {
+	return func(buf []byte, offset int64) error {
+		return vs.store.ReadEcVolumeDat(ecVolume, buf, offset)
+	}
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumPrepare(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumPrepareRequest) (*volume_server_pb.VolumeEcShardsVacuumPrepareResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumPrepare: %v", req)
+
+	ecVolumes := vs.localEcVolumes(req.VolumeId)
+	if len(ecVolumes) == 0 {
+		return nil, fmt.Errorf("ec volume %d not found", req.VolumeId)
+	}
+	ecVolume := ecVolumes[0]
+	if ecVolume.Collection != req.Collection {
+		return nil, fmt.Errorf("existing ec collection %v unexpected input %v", ecVolume.Collection, req.Collection)
+	}
+
+	ecVolume.Sync()
+	indexBaseFileName := ecVolume.IndexBaseFileName()
+	if err := erasure_coding.WriteEcVacuumIndex(indexBaseFileName, ecVolume.OffsetWidth()); err != nil {
+		return nil, fmt.Errorf("snapshot ec volume %d index: %v", req.VolumeId, err)
+	}
+	plan, err := erasure_coding.NewEcVacuumPlan(indexBaseFileName, ecVolume.OffsetWidth(), vs.ecVolumeDatReader(ecVolume))
+	if err != nil {
+		return nil, fmt.Errorf("plan ec volume %d vacuum: %v", req.VolumeId, err)
+	}
+
+	datFileSize := ecVolume.DatFileSize()
+	if datFileSize <= 0 {
+		// the shards are padded, so this overestimates the garbage
+		datFileSize = int64(ecVolume.ShardSize()) * int64(ecVolume.ECContext.DataShards)
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumPrepareResponse{
+		DatFileSize:     uint64(datFileSize),
+		NewDatFileSize:  uint64(plan.NewDatFileSize),
+		LiveNeedleCount: uint64(plan.LiveNeedleCount),
+	}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumGenerate(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumGenerateRequest) (*volume_server_pb.VolumeEcShardsVacuumGenerateResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumGenerate: %v", req)
+
+	ecVolumes := vs.localEcVolumes(req.VolumeId)
+	if len(ecVolumes) == 0 {
+		return nil, fmt.Errorf("ec volume %d not found", req.VolumeId)
+	}
+
+	// every server must use the same snapshot of the live needles
+	indexBaseFileName, found := vs.findEcVacuumIndex(req.Collection, req.VolumeId)
+	if req.IndexSourceNode != "" {
+		indexBaseFileName = ecVolumes[0].IndexBaseFileName()
+		err := operation.WithVolumeServerClient(true, pb.ServerAddress(req.IndexSourceNode), vs.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+			_, err := vs.doCopyFile(client, true, req.Collection, req.VolumeId, math.MaxUint32, math.MaxInt64, indexBaseFileName, erasure_coding.EcVacuumIndexExt, false, false, nil)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("copy ec volume %d vacuum index from %s: %v", req.VolumeId, req.IndexSourceNode, err)
+		}
+	} else if !found {
+		return nil, fmt.Errorf("ec volume %d vacuum is not prepared", req.VolumeId)
+	}
+
+	// shards of the volume on other disks are read like the remote ones
+	readOld := vs.ecVolumeDatReader(ecVolumes[0])
+	plan, err := erasure_coding.NewEcVacuumPlan(indexBaseFileName, ecVolumes[0].OffsetWidth(), readOld)
+	if err != nil {
+		return nil, fmt.Errorf("plan ec volume %d vacuum: %v", req.VolumeId, err)
+	}
+
+	requested := make(map[erasure_coding.ShardId]bool)
+	for _, shardId := range req.ShardIds {
+		requested[erasure_coding.ShardId(shardId)] = true
+	}
+	for _, ecVolume := range ecVolumes {
+		var shardIds []erasure_coding.ShardId
+		for _, shardId := range ecVolume.ShardIdList() {
+			if requested[shardId] {
+				shardIds = append(shardIds, shardId)
+				delete(requested, shardId)
+			}
+		}
+		if len(shardIds) == 0 {
+			continue
+		}
+		if err = plan.Generate(ecVolume.DataBaseFileName(), ecVolume.IndexBaseFileName(), shardIds, ecVolume.ECContext, readOld); err != nil {
+			erasure_coding.CleanupEcVacuum(ecVolume.DataBaseFileName(), ecVolume.IndexBaseFileName())
+			return nil, fmt.Errorf("generate ec volume %d vacuumed shards %v: %v", req.VolumeId, shardIds, err)
+		}
+	}
+	for shardId := range requested {
+		return nil, fmt.Errorf("ec shard %d.%d not found", req.VolumeId, shardId)
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumGenerateResponse{
+		NewDatFileSize: uint64(plan.NewDatFileSize),
+	}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumCommit(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumCommitRequest) (*volume_server_pb.VolumeEcShardsVacuumCommitResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumCommit: %v", req)
+
+	if len(vs.localEcVolumes(req.VolumeId)) > 0 {
+		return nil, fmt.Errorf("ec volume %d shards are still mounted", req.VolumeId)
+	}
+
+	// all disks holding the volume must have been vacuumed, or none is committed
+	var dataBaseFileNames, indexBaseFileNames []string
+	for _, location := range vs.store.Locations {
+		dataBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		indexBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.IdxDirectory, int(req.VolumeId))
+		if !util.FileExists(indexBaseFileName + ".ecx") {
+			continue
+		}
+		if !util.FileExists(indexBaseFileName + ".ecx" + erasure_coding.EcVacuumExt) {
+			return nil, fmt.Errorf("ec volume %d in %s is not vacuumed", req.VolumeId, location.Directory)
+		}
+		dataBaseFileNames = append(dataBaseFileNames, dataBaseFileName)
+		indexBaseFileNames = append(indexBaseFileNames, indexBaseFileName)
+	}
+	if len(indexBaseFileNames) == 0 {
+		return nil, fmt.Errorf("ec volume %d not found", req.VolumeId)
+	}
+
+	for i, dataBaseFileName := range dataBaseFileNames {
+		offsetWidth, err := volume_info.MaybeLoadOffsetWidth(dataBaseFileName + ".vif")
+		if err != nil {
+			return nil, fmt.Errorf("commit ec volume %d vacuum %s: %v", req.VolumeId, dataBaseFileName, err)
+		}
+		if err := erasure_coding.CommitEcVacuum(dataBaseFileName, indexBaseFileNames[i], offsetWidth); err != nil {
+			return nil, fmt.Errorf("commit ec volume %d vacuum %s: %v", req.VolumeId, dataBaseFileName, err)
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumCommitResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumCleanup(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumCleanupRequest) (*volume_server_pb.VolumeEcShardsVacuumCleanupResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumCleanup: %v", req)
+
+	for _, location := range vs.store.Locations {
+		dataBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		indexBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.IdxDirectory, int(req.VolumeId))
+		erasure_coding.CleanupEcVacuum(dataBaseFileName, indexBaseFileName)
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumCleanupResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumRollback(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumRollbackRequest) (*volume_server_pb.VolumeEcShardsVacuumRollbackResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumRollback: %v", req)
+
+	if len(vs.localEcVolumes(req.VolumeId)) > 0 {
+		return nil, fmt.Errorf("ec volume %d shards are still mounted", req.VolumeId)
+	}
+
+	for _, location := range vs.store.Locations {
+		dataBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		indexBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.IdxDirectory, int(req.VolumeId))
+		if err := erasure_coding.RollbackEcVacuum(dataBaseFileName, indexBaseFileName); err != nil {
+			return nil, fmt.Errorf("roll back ec volume %d vacuum %s: %v", req.VolumeId, dataBaseFileName, err)
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumRollbackResponse{}, nil
+}
+
+func (vs *VolumeServer) VolumeEcShardsVacuumFinalize(ctx context.Context, req *volume_server_pb.VolumeEcShardsVacuumFinalizeRequest) (*volume_server_pb.VolumeEcShardsVacuumFinalizeResponse, error) {
+
+	glog.V(0).Infof("VolumeEcShardsVacuumFinalize: %v", req)
+
+	for _, location := range vs.store.Locations {
+		dataBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.Directory, int(req.VolumeId))
+		indexBaseFileName := erasure_coding.EcShardFileName(req.Collection, location.IdxDirectory, int(req.VolumeId))
+		if !util.FileExists(indexBaseFileName + erasure_coding.EcVacuumCommitExt) {
+			continue
+		}
+		if err := erasure_coding.FinalizeEcVacuum(dataBaseFileName, indexBaseFileName); err != nil {
+			return nil, fmt.Errorf("finalize ec volume %d vacuum %s: %v", req.VolumeId, dataBaseFileName, err)
+		}
+	}
+
+	return &volume_server_pb.VolumeEcShardsVacuumFinalizeResponse{}, nil
+}
@@ -0,0 +1,97 @@
+package weed_server
+
+import (
+	"io"
+	"sync"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/topology"
+)
+
+// replicated needles queued for each volume, blocking the stream once full
+const replicateNeedlesVolumeQueueSize = 256
+
+// EnableReplicationStreams writes replicas over pipelined gRPC streams instead of http uploads.
+func (vs *VolumeServer) EnableReplicationStreams() {
+	vs.replicationStreams = topology.NewReplicationStreams(vs.grpcDialOption)
+}
+
+// ReplicateNeedles applies the needles written to, or deleted from, the replicas on other volume servers.
+// The needles of each volume are applied in order, and the volumes in parallel.
+func (vs *VolumeServer) ReplicateNeedles(stream volume_server_pb.VolumeServer_ReplicateNeedlesServer) error {
+
+	results := make(chan *volume_server_pb.ReplicatedNeedleResult, replicateNeedlesVolumeQueueSize)
+	sendDone := make(chan error, 1)
+	go func() {
+		var sendErr error
+		for result := range results {
+			if sendErr != nil {
+				// keep draining, so the volume workers can finish
+				continue
+			}
+			resp := &volume_server_pb.ReplicateNeedlesResponse{
+				Results: []*volume_server_pb.ReplicatedNeedleResult{result},
+			}
+			// batch the results already available
+		batching:
+			for {
+				select {
+				case result, ok := <-results:
+					if !ok {
+						break batching
+					}
+					resp.Results = append(resp.Results, result)
+				default:
+					break batching
+				}
+			}
+			sendErr = stream.Send(resp)
+		}
+		sendDone <- sendErr
+	}()
+
+	var wg sync.WaitGroup
+	volumeQueues := make(map[uint32]chan *volume_server_pb.ReplicatedNeedle)
+	var recvErr error
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			recvErr = err
+			break
+		}
+		for _, replicatedNeedle := range req.Needles {
+			queue, found := volumeQueues[replicatedNeedle.VolumeId]
+			if !found {
+				queue = make(chan *volume_server_pb.ReplicatedNeedle, replicateNeedlesVolumeQueueSize)
+				volumeQueues[replicatedNeedle.VolumeId] = queue
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for replicatedNeedle := range queue {
+						result := &volume_server_pb.ReplicatedNeedleResult{Sequence: replicatedNeedle.Sequence}
+						if err := topology.ApplyReplicatedNeedle(vs.store, replicatedNeedle); err != nil {
+							glog.V(0).Infof("apply replicated needle %d,%x: %v", replicatedNeedle.VolumeId, replicatedNeedle.NeedleId, err)
+							result.Error = err.Error()
+						}
+						results <- result
+					}
+				}()
+			}
+			queue <- replicatedNeedle
+		}
+	}
+
+	for _, queue := range volumeQueues {
+		close(queue)
+	}
+	wg.Wait()
+	close(results)
+	if sendErr := <-sendDone; sendErr != nil {
+		return sendErr
+	}
+	if recvErr == io.EOF {
+		return nil
+	}
+	return recvErr
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/storage"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
+	"github.com/seaweedfs/seaweedfs/weed/topology"
 )
 
 type VolumeServer struct {
@@ -53,6 +55,11 @@ type VolumeServer struct {
 	fileSizeLimitBytes       int64
 	isHeartbeating           bool
 	stopChan                 chan bool
+	writeConsistency         *topology.WriteConsistencyConfig
+	replicationStreams       *topology.ReplicationStreams
+	imageTransformKey        []byte
+	renditionCache           *needle_cache.NeedleCache
+	sendFile                 bool
 }
 
 func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
@@ -110,6 +117,7 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 		readBufferSizeMB:              readBufferSizeMB,
 		ldbTimout:                     ldbTimeout,
 		whiteList:                     whiteList,
+		imageTransformKey:             []byte(v.GetString("image.transform.key")),
 	}
 
 	whiteList = append(whiteList, util.StringSplit(v.GetString("guard.white_list"), ",")...)
@@ -160,6 +168,12 @@ func (vs *VolumeServer) LoadNewVolumes() {
 
 func (vs *VolumeServer) Shutdown() {
 	glog.V(0).Infoln("Shutting down volume server...")
+	if vs.replicationStreams != nil {
+		vs.replicationStreams.Close()
+	}
+	if vs.renditionCache != nil {
+		vs.renditionCache.Shutdown()
+	}
 	vs.store.Close()
 	glog.V(0).Infoln("Shut down successfully!")
 }
@@ -171,3 +185,8 @@ func (vs *VolumeServer) Reload() {
 	v := util.GetViper()
 	vs.guard.UpdateWhiteList(append(vs.whiteList, util.StringSplit(v.GetString("guard.white_list"), ",")...))
 }
+
+// EnableReadCache caches needles read from remote tiered volumes and erasure coded volumes on local disks
+func (vs *VolumeServer) EnableReadCache(cacheDirs []string, capacityMBs []int64) error {
+	return vs.store.EnableReadCache(cacheDirs, capacityMBs)
+}
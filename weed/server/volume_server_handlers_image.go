@@ -0,0 +1,149 @@
+package weed_server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/images"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+// EnableImageRenditionCache caches transformed images on local disk, keyed by the needle and the transformation.
+func (vs *VolumeServer) EnableImageRenditionCache(dir string, capacityMB int64) error {
+	renditionCache, err := needle_cache.NewNeedleCache(dir, capacityMB*1024*1024, "renditions")
+	if err != nil {
+		return fmt.Errorf("image rendition cache: %v", err)
+	}
+	vs.renditionCache = renditionCache
+	return nil
+}
+
+// imageTransform returns the signed image transformation of the read request,
+// or nil if there is none or the needle is not an image which can be transformed.
+func (vs *VolumeServer) imageTransform(r *http.Request, ext string, mimeType string) (*images.Transform, error) {
+	if r.Method == http.MethodHead || !images.IsTransformableImage(ext, mimeType) {
+		return nil, nil
+	}
+	query := r.URL.Query()
+	transform, err := images.ParseTransform(query)
+	if err != nil || transform == nil {
+		return nil, err
+	}
+	if err = transform.VerifySignature(vs.imageTransformKey, r.URL.Path, query.Get(images.TransformSignatureParam)); err != nil {
+		return nil, err
+	}
+	return transform, nil
+}
+
+// writeImageRendition writes the transformed image of the needle, from the rendition cache if possible.
+// The needle data is the image, already uncompressed.
+func (vs *VolumeServer) writeImageRendition(w http.ResponseWriter, r *http.Request, volumeId needle.VolumeId, n *needle.Needle, data []byte, transform *images.Transform, filename string, mimeType string) {
+	if transform.Format == images.FormatAuto {
+		w.Header().Add("Vary", "Accept")
+	}
+	transform = transform.Negotiate(r.Header.Get("Accept"))
+
+	key := needle_cache.NeedleKey{
+		VolumeId: uint32(volumeId),
+		NeedleId: n.Id,
+		Size:     n.Size,
+		Variant:  renditionVariant(n, transform),
+	}
+	etag := fmt.Sprintf("%s-%x", n.Etag(), uint32(key.Variant))
+	if inm := r.Header.Get("If-None-Match"); inm == "\""+etag+"\"" {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rendition, contentType, found := vs.getCachedRendition(key)
+	if !found {
+		var err error
+		rendition, contentType, err = images.Transformed(data, transform)
+		if err != nil {
+			if errors.Is(err, images.ErrUnsupportedFormat) && transform.Format != "" {
+				writeJsonError(w, r, http.StatusBadRequest, err)
+				return
+			}
+			// serve the original image, as before the transformations were supported
+			glog.V(1).Infof("transform image %s: %v", r.URL.Path, err)
+			if e := writeResponseContent(filename, mimeType, bytes.NewReader(data), w, r); e != nil {
+				glog.V(2).Infoln("response write error:", e)
+			}
+			return
+		}
+		vs.setCachedRendition(key, n, rendition, contentType)
+	}
+
+	// the file name follows the format
+	if ext := filepath.Ext(filename); ext != "" && contentType != "" {
+		if format := strings.TrimPrefix(contentType, "image/"); !strings.EqualFold(strings.TrimPrefix(ext, "."), format) {
+			filename = strings.TrimSuffix(filename, ext) + "." + format
+		}
+	}
+	SetEtag(w, etag)
+	if e := writeResponseContent(filename, contentType, bytes.NewReader(rendition), w, r); e != nil {
+		glog.V(2).Infoln("response write error:", e)
+	}
+}
+
+// renditionVariant identifies the transformation of the needle content
+func renditionVariant(n *needle.Needle, transform *images.Transform) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.BigEndian.PutUint32(buf[:4], uint32(n.Cookie))
+	binary.BigEndian.PutUint32(buf[4:], uint32(n.Checksum))
+	h.Write(buf[:])
+	h.Write([]byte(transform.Key()))
+	// 0 is the needle itself
+	return h.Sum64() | 1
+}
+
+// the renditions are cached as needles, with the content type as the mime type
+func (vs *VolumeServer) getCachedRendition(key needle_cache.NeedleKey) (rendition []byte, contentType string, found bool) {
+	if vs.renditionCache == nil {
+		return nil, "", false
+	}
+	blob, found := vs.renditionCache.Get(key)
+	if !found {
+		return nil, "", false
+	}
+	if len(blob) < types.NeedleHeaderSize {
+		return nil, "", false
+	}
+	n := new(needle.Needle)
+	n.ParseNeedleHeader(blob)
+	if err := n.ReadBytes(blob, 0, n.Size, needle.GetCurrentVersion()); err != nil {
+		glog.Warningf("read cached rendition of %d,%s: %v", key.VolumeId, key.NeedleId, err)
+		return nil, "", false
+	}
+	return n.Data, string(n.Mime), true
+}
+
+func (vs *VolumeServer) setCachedRendition(key needle_cache.NeedleKey, original *needle.Needle, rendition []byte, contentType string) {
+	if vs.renditionCache == nil || !vs.renditionCache.Fits(int64(len(rendition))) {
+		return
+	}
+	n := &needle.Needle{
+		Id:     original.Id,
+		Cookie: original.Cookie,
+		Data:   rendition,
+		Mime:   []byte(contentType),
+	}
+	n.SetHasMime()
+	n.Checksum = needle.NewCRC(rendition)
+	blob, _, err := n.ToBytes(needle.GetCurrentVersion())
+	if err != nil {
+		glog.Warningf("serialize rendition of %d,%s: %v", key.VolumeId, key.NeedleId, err)
+		return
+	}
+	vs.renditionCache.Set(key, blob)
+}
@@ -179,7 +179,7 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 
 	var count int
 	var memoryCost types.Size
-	readOption.AttemptMetaOnly, readOption.MustMetaOnly = shouldAttemptStreamWrite(hasVolume, ext, r)
+	readOption.AttemptMetaOnly, readOption.MustMetaOnly = shouldAttemptStreamWrite(hasVolume, r)
 	onReadSizeFn := func(size types.Size) {
 		memoryCost = size
 		atomic.AddInt64(&vs.inFlightDownloadDataSize, int64(memoryCost))
@@ -245,7 +245,7 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	if vs.tryHandleChunkedFile(n, filename, ext, w, r) {
+	if vs.tryHandleChunkedFile(volumeId, n, filename, ext, w, r) {
 		return
 	}
 
@@ -263,10 +263,27 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	transform, err := vs.imageTransform(r, ext, mtype)
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if transform != nil && readOption.IsMetaOnly {
+		// the image is needed to transform it
+		if int64(n.DataSize) > images.MaxTransformSourceSize {
+			transform = nil
+		} else {
+			readOption.AttemptMetaOnly, readOption.IsMetaOnly = false, false
+			if _, err = vs.store.ReadVolumeNeedle(volumeId, n, readOption, nil); err != nil {
+				glog.V(0).Infof("read image %s: %v", r.URL.Path, err)
+				InternalError(w)
+				return
+			}
+		}
+	}
+
 	if n.IsCompressed() {
-		_, _, _, shouldResize := shouldResizeImages(ext, r)
-		_, _, _, _, shouldCrop := shouldCropImages(ext, r)
-		if shouldResize || shouldCrop {
+		if transform != nil {
 			if n.Data, err = util.DecompressData(n.Data); err != nil {
 				glog.V(0).Infoln("ungzip error:", err, r.URL.Path)
 			}
@@ -281,36 +298,35 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	if !readOption.IsMetaOnly {
-		rs := conditionallyCropImages(bytes.NewReader(n.Data), ext, r)
-		rs = conditionallyResizeImages(rs, ext, r)
-		if e := writeResponseContent(filename, mtype, rs, w, r); e != nil {
+	if transform != nil {
+		vs.writeImageRendition(w, r, volumeId, n, n.Data, transform, filename, mtype)
+	} else if !readOption.IsMetaOnly {
+		if e := writeResponseContent(filename, mtype, bytes.NewReader(n.Data), w, r); e != nil {
 			glog.V(2).Infoln("response write error:", e)
 		}
 	} else {
+		readOption.SendFile = vs.sendFile && !n.IsCompressed()
 		vs.streamWriteResponseContent(filename, mtype, volumeId, n, w, r, readOption)
 	}
 }
 
-func shouldAttemptStreamWrite(hasLocalVolume bool, ext string, r *http.Request) (shouldAttempt bool, mustMetaOnly bool) {
+// EnableSendFile streams large needles on local disk to plain http connections with sendfile,
+// skipping the user space copy and the needle checksum verification.
+func (vs *VolumeServer) EnableSendFile() {
+	vs.sendFile = true
+}
+
+func shouldAttemptStreamWrite(hasLocalVolume bool, r *http.Request) (shouldAttempt bool, mustMetaOnly bool) {
 	if !hasLocalVolume {
 		return false, false
 	}
-	if len(ext) > 0 {
-		ext = strings.ToLower(ext)
-	}
 	if r.Method == http.MethodHead {
 		return true, true
 	}
-	_, _, _, shouldResize := shouldResizeImages(ext, r)
-	_, _, _, _, shouldCrop := shouldCropImages(ext, r)
-	if shouldResize || shouldCrop {
-		return false, false
-	}
 	return true, false
 }
 
-func (vs *VolumeServer) tryHandleChunkedFile(n *needle.Needle, fileName string, ext string, w http.ResponseWriter, r *http.Request) (processed bool) {
+func (vs *VolumeServer) tryHandleChunkedFile(volumeId needle.VolumeId, n *needle.Needle, fileName string, ext string, w http.ResponseWriter, r *http.Request) (processed bool) {
 	if !n.IsChunkedManifest() || r.URL.Query().Get("cm") == "false" {
 		return false
 	}
@@ -336,79 +352,32 @@ func (vs *VolumeServer) tryHandleChunkedFile(n *needle.Needle, fileName string,
 		}
 	}
 
+	transform, err := vs.imageTransform(r, ext, mType)
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return true
+	}
+
 	w.Header().Set("X-File-Store", "chunked")
 
 	chunkedFileReader := operation.NewChunkedFileReader(chunkManifest.Chunks, vs.GetMaster(context.Background()), vs.grpcDialOption)
 	defer chunkedFileReader.Close()
 
-	rs := conditionallyCropImages(chunkedFileReader, ext, r)
-	rs = conditionallyResizeImages(rs, ext, r)
-
-	if e := writeResponseContent(fileName, mType, rs, w, r); e != nil {
-		glog.V(2).Infoln("response write error:", e)
-	}
-	return true
-}
-
-func conditionallyResizeImages(originalDataReaderSeeker io.ReadSeeker, ext string, r *http.Request) io.ReadSeeker {
-	rs := originalDataReaderSeeker
-	if len(ext) > 0 {
-		ext = strings.ToLower(ext)
-	}
-	width, height, mode, shouldResize := shouldResizeImages(ext, r)
-	if shouldResize {
-		rs, _, _ = images.Resized(ext, originalDataReaderSeeker, width, height, mode)
-	}
-	return rs
-}
-
-func shouldResizeImages(ext string, r *http.Request) (width, height int, mode string, shouldResize bool) {
-	if ext == ".png" || ext == ".jpg" || ext == ".jpeg" || ext == ".gif" || ext == ".webp" {
-		if r.FormValue("width") != "" {
-			width, _ = strconv.Atoi(r.FormValue("width"))
-		}
-		if r.FormValue("height") != "" {
-			height, _ = strconv.Atoi(r.FormValue("height"))
-		}
-	}
-	mode = r.FormValue("mode")
-	shouldResize = width > 0 || height > 0
-	return
-}
-
-func conditionallyCropImages(originalDataReaderSeeker io.ReadSeeker, ext string, r *http.Request) io.ReadSeeker {
-	rs := originalDataReaderSeeker
-	if len(ext) > 0 {
-		ext = strings.ToLower(ext)
-	}
-	x1, y1, x2, y2, shouldCrop := shouldCropImages(ext, r)
-	if shouldCrop {
-		var err error
-		rs, err = images.Cropped(ext, rs, x1, y1, x2, y2)
+	if transform != nil && chunkManifest.Size <= images.MaxTransformSourceSize {
+		data, err := io.ReadAll(chunkedFileReader)
 		if err != nil {
-			glog.Errorf("Cropping images error: %s", err)
+			glog.V(0).Infof("read chunked image (%s) error: %v", r.URL.Path, err)
+			InternalError(w)
+			return true
 		}
+		vs.writeImageRendition(w, r, volumeId, n, data, transform, fileName, mType)
+		return true
 	}
-	return rs
-}
 
-func shouldCropImages(ext string, r *http.Request) (x1, y1, x2, y2 int, shouldCrop bool) {
-	if ext == ".png" || ext == ".jpg" || ext == ".jpeg" || ext == ".gif" {
-		if r.FormValue("crop_x1") != "" {
-			x1, _ = strconv.Atoi(r.FormValue("crop_x1"))
-		}
-		if r.FormValue("crop_y1") != "" {
-			y1, _ = strconv.Atoi(r.FormValue("crop_y1"))
-		}
-		if r.FormValue("crop_x2") != "" {
-			x2, _ = strconv.Atoi(r.FormValue("crop_x2"))
-		}
-		if r.FormValue("crop_y2") != "" {
-			y2, _ = strconv.Atoi(r.FormValue("crop_y2"))
-		}
+	if e := writeResponseContent(fileName, mType, chunkedFileReader, w, r); e != nil {
+		glog.V(2).Infoln("response write error:", e)
 	}
-	shouldCrop = x1 >= 0 && y1 >= 0 && x2 > x1 && y2 > y1
-	return
+	return true
 }
 
 func writeResponseContent(filename, mimeType string, rs io.ReadSeeker, w http.ResponseWriter, r *http.Request) error {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/storage"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/topology"
 	"github.com/seaweedfs/seaweedfs/weed/util/buffer_pool"
@@ -48,8 +49,12 @@ func (vs *VolumeServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 
 	ret := operation.UploadResult{}
 	// use context.WithoutCancel to avoid context cancellation when the client connection is closed
-	isUnchanged, writeError := topology.ReplicatedWrite(context.WithoutCancel(ctx), vs.GetMaster, vs.grpcDialOption, vs.store, volumeId, reqNeedle, r, contentMd5)
+	isUnchanged, writeError := topology.ReplicatedWrite(context.WithoutCancel(ctx), vs.GetMaster, vs.grpcDialOption, vs.store, volumeId, reqNeedle, r, contentMd5, vs.volumeWriteConsistency(volumeId), vs.replicationStreams)
 	if writeError != nil {
+		if errors.Is(writeError, storage.ErrWormOverwrite) {
+			writeJsonError(w, r, http.StatusForbidden, writeError)
+			return
+		}
 		writeJsonError(w, r, http.StatusInternalServerError, writeError)
 		return
 	}
@@ -134,7 +139,7 @@ func (vs *VolumeServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	_, err := topology.ReplicatedDelete(vs.GetMaster, vs.grpcDialOption, vs.store, volumeId, n, r)
+	_, err := topology.ReplicatedDelete(vs.GetMaster, vs.grpcDialOption, vs.store, volumeId, n, r, vs.volumeWriteConsistency(volumeId), vs.replicationStreams)
 
 	writeDeleteResult(err, count, w, r)
 
@@ -145,6 +150,8 @@ func writeDeleteResult(err error, count int64, w http.ResponseWriter, r *http.Re
 		m := make(map[string]int64)
 		m["size"] = count
 		writeJsonQuiet(w, r, http.StatusAccepted, m)
+	} else if errors.Is(err, storage.ErrWormDelete) {
+		writeJsonError(w, r, http.StatusForbidden, fmt.Errorf("Deletion Failed: %w", err))
 	} else {
 		writeJsonError(w, r, http.StatusInternalServerError, fmt.Errorf("Deletion Failed: %w", err))
 	}
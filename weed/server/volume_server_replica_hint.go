@@ -0,0 +1,113 @@
+package weed_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/replica_hint"
+	"github.com/seaweedfs/seaweedfs/weed/topology"
+)
+
+const (
+	replicaHintReplayInterval = 30 * time.Second
+	// older hints are dropped, and the replicas are left to volume.check.disk
+	replicaHintMaxAge = 7 * 24 * time.Hour
+)
+
+// EnableWriteConsistency sets the write consistency of each collection, e.g. "quorum,logs=one",
+// and replays the hints of writes missed by replicas in the background.
+func (vs *VolumeServer) EnableWriteConsistency(writeConsistency string) error {
+	config, err := topology.ParseWriteConsistencyConfig(writeConsistency)
+	if err != nil {
+		return err
+	}
+	if err = vs.store.EnableReplicaHints(); err != nil {
+		return err
+	}
+	vs.writeConsistency = config
+	stats.VolumeServerReplicaHintGauge.Set(float64(vs.store.ReplicaHints().Count()))
+	go vs.loopReplayReplicaHints()
+	return nil
+}
+
+func (vs *VolumeServer) volumeWriteConsistency(volumeId needle.VolumeId) topology.WriteConsistency {
+	collection := ""
+	if v := vs.store.GetVolume(volumeId); v != nil {
+		collection = v.Collection
+	}
+	return vs.writeConsistency.Get(collection)
+}
+
+func (vs *VolumeServer) replayReplicaHints(ctx context.Context, volumeId uint32) (topology.ReplicaHintReplayResult, error) {
+	return topology.ReplayReplicaHints(ctx, vs.GetMaster, vs.grpcDialOption, vs.store, volumeId, replicaHintMaxAge, func(fileId string) security.EncodedJwt {
+		return security.GenJwtForVolumeServer(vs.guard.SigningKey, vs.guard.ExpiresAfterSec, fileId)
+	})
+}
+
+func (vs *VolumeServer) loopReplayReplicaHints() {
+	for {
+		select {
+		case <-vs.stopChan:
+			return
+		case <-time.After(replicaHintReplayInterval):
+		}
+		if vs.store.ReplicaHints().Count() == 0 {
+			continue
+		}
+		result, err := vs.replayReplicaHints(context.Background(), 0)
+		if err != nil {
+			glog.Warningf("replay replica hints: %v", err)
+		}
+		if result.Replayed > 0 || result.Failed > 0 || result.Expired > 0 {
+			glog.V(0).Infof("replica hints replayed %d, failed %d, waiting %d, expired %d",
+				result.Replayed, result.Failed, result.Waiting, result.Expired)
+		}
+	}
+}
+
+func (vs *VolumeServer) VolumeReplicaHints(ctx context.Context, req *volume_server_pb.VolumeReplicaHintsRequest) (*volume_server_pb.VolumeReplicaHintsResponse, error) {
+
+	resp := &volume_server_pb.VolumeReplicaHintsResponse{}
+
+	hintStore := vs.store.ReplicaHints()
+	if hintStore == nil {
+		return resp, nil
+	}
+
+	if req.Replay {
+		result, err := vs.replayReplicaHints(ctx, req.VolumeId)
+		if err != nil {
+			return nil, err
+		}
+		resp.ReplayedCount = uint64(result.Replayed)
+		resp.FailedCount = uint64(result.Failed)
+	}
+
+	// the hints are ordered by volume id
+	var info *volume_server_pb.VolumeReplicaHintsInfo
+	var targets map[string]bool
+	err := hintStore.VisitVolume(req.VolumeId, func(hint *replica_hint.Hint) error {
+		if info == nil || info.VolumeId != hint.VolumeId {
+			info = &volume_server_pb.VolumeReplicaHintsInfo{
+				VolumeId:          hint.VolumeId,
+				OldestCreatedAtNs: hint.CreatedAt.UnixNano(),
+			}
+			targets = make(map[string]bool)
+			resp.VolumeHints = append(resp.VolumeHints, info)
+		}
+		info.HintCount++
+		info.OldestCreatedAtNs = min(info.OldestCreatedAtNs, hint.CreatedAt.UnixNano())
+		if !targets[hint.Target] {
+			targets[hint.Target] = true
+			info.Targets = append(info.Targets, hint.Target)
+		}
+		return nil
+	})
+
+	return resp, err
+}
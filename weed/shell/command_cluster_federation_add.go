@@ -0,0 +1,140 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/remote_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage/seaweedfs"
+)
+
+func init() {
+	Commands = append(Commands, &commandClusterFederationAdd{})
+}
+
+type commandClusterFederationAdd struct {
+}
+
+func (c *commandClusterFederationAdd) Name() string {
+	return "cluster.federation.add"
+}
+
+func (c *commandClusterFederationAdd) Help() string {
+	return `add a peer cluster, or mount a directory of a peer cluster
+
+	# add a peer cluster by its filer addresses
+	cluster.federation.add -name=east -filer=east-filer1:8888,east-filer2:8888
+
+	# mount a directory of the peer cluster to a local directory
+	cluster.federation.add -name=east -remote=/data -dir=/east/data
+
+	# or do both in one step
+	cluster.federation.add -name=east -filer=east-filer1:8888 -remote=/data -dir=/east/data
+
+	The mounted directory is a transparent view of the peer directory:
+	the files are read through from the peer cluster on the first access, and cached locally.
+	One filer of this cluster follows the peer metadata changes to invalidate the cached files,
+	and forwards the local changes under the mounted directory to the peer cluster.
+
+	A directory mounted from a peer cluster should not be mounted again by a third cluster.
+	Changes made by the federation are not forwarded any further.
+
+`
+}
+
+func (c *commandClusterFederationAdd) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandClusterFederationAdd) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	federationAddCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := federationAddCommand.String("name", "", "a short name to identify the peer cluster")
+	filers := federationAddCommand.String("filer", "", "comma separated filer addresses of the peer cluster, <host>:<port>[.<grpcPort>]")
+	remote := federationAddCommand.String("remote", "", "a directory on the peer cluster")
+	dir := federationAddCommand.String("dir", "", "a local directory to mount the peer directory")
+	nonEmpty := federationAddCommand.Bool("nonempty", false, "allows the mounting over a non-empty directory")
+	if err = federationAddCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *name == "" {
+		return fmt.Errorf("missing -name")
+	}
+	if !isAlpha(*name) {
+		return fmt.Errorf("only letters and numbers allowed in name: %v", *name)
+	}
+	if (*dir == "") != (*remote == "") {
+		return fmt.Errorf("-dir and -remote should be set together")
+	}
+
+	var conf *remote_pb.RemoteConf
+	if *filers != "" {
+		if len(pb.ServerAddresses(*filers).ToAddresses()) == 0 {
+			return fmt.Errorf("invalid -filer %s", *filers)
+		}
+		conf = &remote_pb.RemoteConf{
+			Name:            *name,
+			Type:            "seaweedfs",
+			SeaweedfsFilers: *filers,
+		}
+		if err = (&commandRemoteConfigure{}).saveRemoteStorage(commandEnv, writer, conf); err != nil {
+			return fmt.Errorf("save peer %s: %w", *name, err)
+		}
+		fmt.Fprintf(writer, "peer %s: %s\n", *name, *filers)
+	} else {
+		if conf, err = readFederationPeer(commandEnv, *name); err != nil {
+			return err
+		}
+	}
+
+	if *dir == "" {
+		return nil
+	}
+
+	mountDir := strings.TrimSuffix(*dir, "/")
+	remoteLocation, err := remote_storage.ParseRemoteLocation(conf.Type, *name+"/"+strings.TrimPrefix(*remote, "/"))
+	if err != nil {
+		return err
+	}
+
+	// follow the changes on both sides since now, the metadata pulled below is the starting point
+	now := time.Now().UnixNano()
+	if err = commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		if err := seaweedfs.SetOffset(client, seaweedfs.PeerOffsetKey(mountDir), now); err != nil {
+			return err
+		}
+		return seaweedfs.SetOffset(client, seaweedfs.LocalOffsetKey(mountDir), now)
+	}); err != nil {
+		return fmt.Errorf("reset federation offsets of %s: %w", mountDir, err)
+	}
+
+	if err = syncMetadata(commandEnv, writer, mountDir, *nonEmpty, conf, remoteLocation); err != nil {
+		return fmt.Errorf("pull metadata from %s: %w", remote_storage.FormatLocation(remoteLocation), err)
+	}
+
+	if err = filer.InsertMountMapping(commandEnv, mountDir, remoteLocation); err != nil {
+		return fmt.Errorf("save mount mapping: %w", err)
+	}
+	fmt.Fprintf(writer, "mounted %s => %s\n", mountDir, remote_storage.FormatLocation(remoteLocation))
+
+	return nil
+}
+
+func readFederationPeer(commandEnv *CommandEnv, name string) (*remote_pb.RemoteConf, error) {
+	conf, err := filer.ReadRemoteStorageConf(commandEnv.option.GrpcDialOption, commandEnv.option.FilerAddress, name)
+	if err != nil {
+		return nil, fmt.Errorf("find peer %s: %w", name, err)
+	}
+	if conf.Type != "seaweedfs" {
+		return nil, fmt.Errorf("%s is a %s remote storage, not a peer cluster", name, conf.Type)
+	}
+	return conf, nil
+}
@@ -0,0 +1,110 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/remote_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage/seaweedfs"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	Commands = append(Commands, &commandClusterFederationList{})
+}
+
+type commandClusterFederationList struct {
+}
+
+func (c *commandClusterFederationList) Name() string {
+	return "cluster.federation.list"
+}
+
+func (c *commandClusterFederationList) Help() string {
+	return `list the peer clusters and their mounted directories
+
+	cluster.federation.list
+
+	For each mounted directory, it shows the time of the last peer change applied locally,
+	and the time of the last local change forwarded to the peer cluster.
+
+`
+}
+
+func (c *commandClusterFederationList) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandClusterFederationList) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	federationListCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	if err = federationListCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	var peers []*remote_pb.RemoteConf
+	err = filer_pb.ReadDirAllEntries(context.Background(), commandEnv, util.FullPath(filer.DirectoryEtcRemote), "", func(entry *filer_pb.Entry, isLast bool) error {
+		if len(entry.Content) == 0 || !strings.HasSuffix(entry.Name, filer.REMOTE_STORAGE_CONF_SUFFIX) {
+			return nil
+		}
+		conf := &remote_pb.RemoteConf{}
+		if err := proto.Unmarshal(entry.Content, conf); err != nil {
+			return fmt.Errorf("unmarshal %s/%s: %v", filer.DirectoryEtcRemote, entry.Name, err)
+		}
+		if conf.Type == "seaweedfs" {
+			peers = append(peers, conf)
+		}
+		return nil
+	})
+	if err != nil && err != filer_pb.ErrNotFound {
+		return err
+	}
+
+	mappings, err := filer.ReadMountMappings(commandEnv.option.GrpcDialOption, commandEnv.option.FilerAddress)
+	if err != nil {
+		return err
+	}
+	var dirs []string
+	for dir := range mappings.Mappings {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		for _, peer := range peers {
+			fmt.Fprintf(writer, "peer %s: %s\n", peer.Name, peer.SeaweedfsFilers)
+			for _, dir := range dirs {
+				loc := mappings.Mappings[dir]
+				if loc.Name != peer.Name {
+					continue
+				}
+				peerOffset, err := seaweedfs.GetOffset(client, seaweedfs.PeerOffsetKey(dir))
+				if err != nil {
+					return err
+				}
+				localOffset, err := seaweedfs.GetOffset(client, seaweedfs.LocalOffsetKey(dir))
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(writer, "  %s => %s applied:%s forwarded:%s\n", dir, remote_storage.FormatLocation(loc), formatFederationOffset(peerOffset), formatFederationOffset(localOffset))
+			}
+		}
+		return nil
+	})
+}
+
+func formatFederationOffset(offsetTsNs int64) string {
+	if offsetTsNs == 0 {
+		return "-"
+	}
+	return time.Unix(0, offsetTsNs).UTC().Format(time.RFC3339)
+}
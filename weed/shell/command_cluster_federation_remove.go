@@ -0,0 +1,131 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/filer"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/remote_storage/seaweedfs"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandClusterFederationRemove{})
+}
+
+type commandClusterFederationRemove struct {
+}
+
+func (c *commandClusterFederationRemove) Name() string {
+	return "cluster.federation.remove"
+}
+
+func (c *commandClusterFederationRemove) Help() string {
+	return `unmount a directory of a peer cluster, or remove a peer cluster
+
+	# unmount one directory, and purge its locally cached files
+	cluster.federation.remove -dir=/east/data
+
+	# unmount all directories of the peer cluster, and remove the peer
+	cluster.federation.remove -name=east
+
+	The files on the peer cluster are not changed.
+
+`
+}
+
+func (c *commandClusterFederationRemove) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandClusterFederationRemove) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	federationRemoveCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	name := federationRemoveCommand.String("name", "", "the peer cluster to remove")
+	dir := federationRemoveCommand.String("dir", "", "the local directory to unmount")
+	if err = federationRemoveCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if (*name == "") == (*dir == "") {
+		return fmt.Errorf("set either -name or -dir")
+	}
+
+	mappings, err := filer.ReadMountMappings(commandEnv.option.GrpcDialOption, commandEnv.option.FilerAddress)
+	if err != nil {
+		return err
+	}
+
+	if *dir != "" {
+		mountDir := strings.TrimSuffix(*dir, "/")
+		loc, found := mappings.Mappings[mountDir]
+		if !found {
+			return fmt.Errorf("directory %s is not mounted", mountDir)
+		}
+		if _, err = readFederationPeer(commandEnv, loc.Name); err != nil {
+			return err
+		}
+		return c.unmount(commandEnv, writer, mountDir)
+	}
+
+	if _, err = readFederationPeer(commandEnv, *name); err != nil {
+		return err
+	}
+	for mountDir, loc := range mappings.Mappings {
+		if loc.Name != *name {
+			continue
+		}
+		if err = c.unmount(commandEnv, writer, mountDir); err != nil {
+			return err
+		}
+	}
+	return (&commandRemoteConfigure{}).deleteRemoteStorage(commandEnv, writer, *name)
+}
+
+func (c *commandClusterFederationRemove) unmount(commandEnv *CommandEnv, writer io.Writer, mountDir string) error {
+
+	fmt.Fprintf(writer, "unmount %s ...\n", mountDir)
+	if err := filer.DeleteMountMapping(commandEnv, mountDir); err != nil {
+		return fmt.Errorf("delete mount mapping: %w", err)
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		ctx := context.Background()
+		parent, name := util.FullPath(mountDir).DirAndName()
+		lookupResp, lookupErr := filer_pb.LookupEntry(ctx, client, &filer_pb.LookupDirectoryEntryRequest{
+			Directory: parent,
+			Name:      name,
+		})
+		if lookupErr != nil {
+			return fmt.Errorf("lookup %s: %v", mountDir, lookupErr)
+		}
+		oldEntry := lookupResp.Entry
+
+		// the federation signature keeps the follower, if still running, from deleting the peer files
+		fmt.Fprintf(writer, "purge %s ...\n", mountDir)
+		if err := filer_pb.DoRemove(ctx, client, parent, name, true, true, true, false, []int32{seaweedfs.FederationSignature}); err != nil {
+			return fmt.Errorf("delete %s: %v", mountDir, err)
+		}
+		if err := filer_pb.DoMkdir(ctx, client, parent, name, func(entry *filer_pb.Entry) {
+			entry.Attributes = oldEntry.Attributes
+			entry.Extended = oldEntry.Extended
+			entry.Attributes.Crtime = time.Now().Unix()
+			entry.Attributes.Mtime = time.Now().Unix()
+		}); err != nil {
+			return fmt.Errorf("mkdir %s: %v", mountDir, err)
+		}
+
+		// forget the offsets in case the directory is mounted again
+		for _, key := range [][]byte{seaweedfs.PeerOffsetKey(mountDir), seaweedfs.LocalOffsetKey(mountDir)} {
+			if _, err := client.KvPut(ctx, &filer_pb.KvPutRequest{Key: key}); err != nil {
+				return fmt.Errorf("reset federation offset of %s: %v", mountDir, err)
+			}
+		}
+		return nil
+	})
+}
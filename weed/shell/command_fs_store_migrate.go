@@ -0,0 +1,120 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsStoreMigrate{})
+}
+
+type commandFsStoreMigrate struct {
+}
+
+func (c *commandFsStoreMigrate) Name() string {
+	return "fs.store.migrate"
+}
+
+func (c *commandFsStoreMigrate) Help() string {
+	return `migrate filer metadata to another filer store without downtime
+
+	# configure the target store as "<storeName>.migration" in filer.toml, e.g. [postgres2.migration],
+	# and restart the filers. Then:
+
+	fs.store.migrate            # show the migration status
+	fs.store.migrate -start     # write to both stores, copy existing entries, then verify them
+	fs.store.migrate -pause     # pause copying or verifying, writes still go to both stores
+	fs.store.migrate -resume    # continue copying or verifying where it stopped
+	fs.store.migrate -verify    # compare both stores again, and repair differences
+	fs.store.migrate -cutover   # read from the target store, after a verification without mismatches
+
+	After the cutover, writes still go to both stores. To finish the migration, make the target store
+	the default store in filer.toml, remove the migration section, and restart the filers.
+
+	Key values are only copied for hard links. Other key values, e.g. sync offsets, are read from
+	the source store after the cutover until they are written again.
+`
+}
+
+func (c *commandFsStoreMigrate) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandFsStoreMigrate) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+	fsStoreMigrateCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	start := fsStoreMigrateCommand.Bool("start", false, "start the migration")
+	pause := fsStoreMigrateCommand.Bool("pause", false, "pause copying or verifying")
+	resume := fsStoreMigrateCommand.Bool("resume", false, "resume copying or verifying")
+	verify := fsStoreMigrateCommand.Bool("verify", false, "verify both stores again")
+	cutover := fsStoreMigrateCommand.Bool("cutover", false, "read from the target store")
+	if err = fsStoreMigrateCommand.Parse(args); err != nil {
+		return err
+	}
+
+	action := "status"
+	actionCount := 0
+	for name, set := range map[string]bool{"start": *start, "pause": *pause, "resume": *resume, "verify": *verify, "cutover": *cutover} {
+		if set {
+			action = name
+			actionCount++
+		}
+	}
+	if actionCount > 1 {
+		return fmt.Errorf("only one of -start, -pause, -resume, -verify and -cutover can be used")
+	}
+	if action != "status" {
+		if err = commandEnv.confirmIsLocked(args); err != nil {
+			return err
+		}
+	}
+
+	return commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.StoreMigration(context.Background(), &filer_pb.StoreMigrationRequest{Action: action})
+		if err != nil {
+			return err
+		}
+		if resp.Status != nil {
+			printStoreMigrationStatus(writer, resp.Status)
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		return nil
+	})
+}
+
+func printStoreMigrationStatus(writer io.Writer, status *filer_pb.StoreMigrationStatus) {
+	fmt.Fprintf(writer, "migration from %s to %s: %s\n", status.SourceStore, status.TargetStore, status.Phase)
+	if status.PausedPhase != "" {
+		fmt.Fprintf(writer, "  paused while:       %s\n", status.PausedPhase)
+	}
+	readsFrom := status.SourceStore
+	if status.ReadsFromTarget {
+		readsFrom = status.TargetStore
+	}
+	fmt.Fprintf(writer, "  reads from:         %s\n", readsFrom)
+	if status.Owner != "" {
+		fmt.Fprintf(writer, "  running on filer:   %s\n", status.Owner)
+	}
+	if status.StartedAtNs > 0 {
+		fmt.Fprintf(writer, "  started at:         %v\n", time.Unix(0, status.StartedAtNs))
+	}
+	if status.Cursor != "" {
+		fmt.Fprintf(writer, "  cursor:             %s\n", status.Cursor)
+	}
+	fmt.Fprintf(writer, "  backfilled entries: %d\n", status.BackfilledEntries)
+	fmt.Fprintf(writer, "  verify passes:      %d\n", status.VerifyPasses)
+	fmt.Fprintf(writer, "  verified entries:   %d\n", status.VerifiedEntries)
+	fmt.Fprintf(writer, "  mismatched entries: %d\n", status.MismatchedEntries)
+	fmt.Fprintf(writer, "  repaired entries:   %d\n", status.RepairedEntries)
+	fmt.Fprintf(writer, "  dual write errors:  %d (on this filer)\n", status.DualWriteErrors)
+	if status.LastError != "" {
+		fmt.Fprintf(writer, "  last error:         %s\n", status.LastError)
+	}
+}
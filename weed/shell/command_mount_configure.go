@@ -27,11 +27,22 @@ func (c *commandMountConfigure) Name() string {
 func (c *commandMountConfigure) Help() string {
 	return `configure the mount on current server
 
-	mount.configure -dir=<mount_directory>
+	mount.configure -dir=<mount_directory> -quotaMB=1024
+	mount.configure -dir=<mount_directory> -consistency.path=<sub_directory> -consistency=[eventual|cto|strict|inherit]
 
 	This command connects with local mount via unix socket, so it can only run locally.
 	The "mount_directory" value needs to be exactly the same as how mount was started in "weed mount -dir=<mount_directory>"
 
+	The consistency mode decides how the mount sees changes made by other clients:
+	  eventual: trust the local metadata cache, which follows the filer asynchronously.
+	  cto:      close-to-open, revalidate the file with the filer on open.
+	            Changes are visible once the writer closes the file.
+	  strict:   also revalidate every lookup, so new and removed files are seen immediately.
+	  inherit:  remove the setting of the directory, and follow its parent directory.
+	The "sub_directory" is relative to the mount directory, or the full local path under it.
+	The setting is kept in memory, and needs to be applied again after the mount restarts.
+	Avoid -writebackCache for directories with cto or strict consistency.
+
 `
 }
 
@@ -44,6 +55,8 @@ func (c *commandMountConfigure) Do(args []string, commandEnv *CommandEnv, writer
 	mountConfigureCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
 	mountDir := mountConfigureCommand.String("dir", "", "the mount directory same as how \"weed mount -dir=<mount_directory>\" was started")
 	mountQuota := mountConfigureCommand.Int("quotaMB", 0, "the quota in MB")
+	consistencyPath := mountConfigureCommand.String("consistency.path", "", "the directory to change the consistency mode")
+	consistencyMode := mountConfigureCommand.String("consistency", "cto", "[eventual|cto|strict|inherit] consistency mode of the directory")
 	if err = mountConfigureCommand.Parse(args); err != nil {
 		return nil
 	}
@@ -61,6 +74,21 @@ func (c *commandMountConfigure) Do(args []string, commandEnv *CommandEnv, writer
 	defer clientConn.Close()
 
 	client := mount_pb.NewSeaweedMountClient(clientConn)
+
+	if *consistencyPath != "" {
+		resp, configureErr := client.Configure(context.Background(), &mount_pb.ConfigureRequest{
+			ConsistencyPath: *consistencyPath,
+			ConsistencyMode: *consistencyMode,
+		})
+		if configureErr != nil {
+			return configureErr
+		}
+		for _, rule := range resp.ConsistencyRules {
+			fmt.Fprintf(writer, "%s\t%s\n", rule.Path, rule.Mode)
+		}
+		return nil
+	}
+
 	_, err = client.Configure(context.Background(), &mount_pb.ConfigureRequest{
 		CollectionCapacity: int64(*mountQuota) * 1024 * 1024,
 	})
@@ -0,0 +1,161 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/logstore"
+	"github.com/seaweedfs/seaweedfs/weed/mq/pub_balancer"
+	"github.com/seaweedfs/seaweedfs/weed/mq/sub_coordinator"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"github.com/seaweedfs/seaweedfs/weed/util/log_buffer"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	Commands = append(Commands, &commandMqDlqList{})
+}
+
+type commandMqDlqList struct {
+}
+
+func (c *commandMqDlqList) Name() string {
+	return "mq.dlq.list"
+}
+
+func (c *commandMqDlqList) Help() string {
+	return `list the messages in a dead letter topic
+
+	Example:
+		mq.dlq.list -namespace <namespace> -topic <topic_name> -consumerGroup <group>
+		mq.dlq.list -namespace <namespace> -dlqTopic <dead_letter_topic_name> -key <key>
+
+	Messages that failed processing more than the max delivery attempts of a subscription
+	are published to its dead letter topic, by default "<topic>.<consumer_group>.dlq".
+	This lists the dead letters already flushed to the filer, with their failure.
+`
+}
+
+func (c *commandMqDlqList) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandMqDlqList) Do(args []string, commandEnv *CommandEnv, writer io.Writer) error {
+
+	mqCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	namespace := mqCommand.String("namespace", "", "namespace name")
+	topicName := mqCommand.String("topic", "", "topic name")
+	consumerGroup := mqCommand.String("consumerGroup", "", "consumer group name")
+	dlqTopicName := mqCommand.String("dlqTopic", "", "dead letter topic name, instead of the default one of the topic and consumer group")
+	key := mqCommand.String("key", "", "only list dead letters with this key")
+	limit := mqCommand.Int("limit", 100, "max number of dead letters to list")
+	showValue := mqCommand.Bool("showValue", false, "print the message values")
+	if err := mqCommand.Parse(args); err != nil {
+		return err
+	}
+
+	dlqTopic, err := parseDeadLetterTopic(*namespace, *topicName, *consumerGroup, *dlqTopicName)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = eachDeadLetter(commandEnv, dlqTopic, func(d *sub_coordinator.DeadLetter) (bool, error) {
+		if *key != "" && string(d.Key) != *key {
+			return false, nil
+		}
+		count++
+		fmt.Fprintf(writer, "%s %v partition %s group %s key %q ts %d attempts %d: %s\n",
+			d.FailedAt.Format(time.RFC3339), d.Topic, d.Partition, d.ConsumerGroup, string(d.Key), d.TsNs, d.DeliveryAttempts, d.Error)
+		if *showValue {
+			fmt.Fprintf(writer, "    %s\n", formatDeadLetterValue(d.Value))
+		}
+		return count >= *limit, nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(writer, "%d dead letters in %v\n", count, dlqTopic)
+	return nil
+}
+
+func parseDeadLetterTopic(namespace, topicName, consumerGroup, dlqTopicName string) (topic.Topic, error) {
+	if namespace == "" {
+		return topic.Topic{}, fmt.Errorf("namespace is required")
+	}
+	if dlqTopicName != "" {
+		return topic.NewTopic(namespace, dlqTopicName), nil
+	}
+	if topicName == "" {
+		return topic.Topic{}, fmt.Errorf("topic or dlqTopic is required")
+	}
+	return sub_coordinator.DefaultDeadLetterTopic(topic.NewTopic(namespace, topicName), consumerGroup), nil
+}
+
+// eachDeadLetter reads the dead letters of all partitions that are flushed to the filer.
+func eachDeadLetter(commandEnv *CommandEnv, dlqTopic topic.Topic, fn func(d *sub_coordinator.DeadLetter) (isDone bool, err error)) error {
+	partitionDirs, err := dlqTopic.DiscoverPartitions(context.Background(), commandEnv)
+	if err != nil {
+		return fmt.Errorf("find partitions of %v: %w", dlqTopic, err)
+	}
+
+	stopTsNs := time.Now().UnixNano()
+	for _, partitionDir := range partitionDirs {
+		partition, err := parsePartitionDir(partitionDir)
+		if err != nil {
+			return err
+		}
+		readFn := logstore.GenMergedReadFunc(commandEnv, dlqTopic, partition)
+		_, isDone, err := readFn(log_buffer.NewMessagePosition(1, -3), stopTsNs, func(logEntry *filer_pb.LogEntry) (bool, error) {
+			record := &schema_pb.RecordValue{}
+			if err := proto.Unmarshal(logEntry.Data, record); err != nil {
+				return false, nil
+			}
+			d, err := sub_coordinator.ParseDeadLetter(record)
+			if err != nil {
+				return false, nil
+			}
+			return fn(d)
+		})
+		if err != nil {
+			return fmt.Errorf("read %s: %w", partitionDir, err)
+		}
+		if isDone {
+			return nil
+		}
+	}
+	return nil
+}
+
+// parsePartitionDir parses ".../v2006-01-02-15-04-05/0000-0630" into the partition.
+func parsePartitionDir(partitionDir string) (topic.Partition, error) {
+	parts := strings.Split(partitionDir, "/")
+	if len(parts) < 2 {
+		return topic.Partition{}, fmt.Errorf("invalid partition dir %s", partitionDir)
+	}
+	version, err := time.Parse(topic.PartitionGenerationFormat, parts[len(parts)-2])
+	if err != nil {
+		return topic.Partition{}, fmt.Errorf("invalid topic version in %s: %w", partitionDir, err)
+	}
+	start, stop := topic.ParsePartitionBoundary(parts[len(parts)-1])
+	return topic.Partition{
+		RangeStart: start,
+		RangeStop:  stop,
+		RingSize:   pub_balancer.MaxPartitionCount,
+		UnixTimeNs: version.UnixNano(),
+	}, nil
+}
+
+func formatDeadLetterValue(value []byte) string {
+	record := &schema_pb.RecordValue{}
+	if err := proto.Unmarshal(value, record); err == nil && len(record.Fields) > 0 {
+		return record.String()
+	}
+	return fmt.Sprintf("%q", value)
+}
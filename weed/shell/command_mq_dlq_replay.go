@@ -0,0 +1,169 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/mq/client/pub_client"
+	"github.com/seaweedfs/seaweedfs/weed/mq/sub_coordinator"
+	"github.com/seaweedfs/seaweedfs/weed/mq/topic"
+	"github.com/seaweedfs/seaweedfs/weed/pb/filer_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/schema_pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	Commands = append(Commands, &commandMqDlqReplay{})
+}
+
+type commandMqDlqReplay struct {
+}
+
+func (c *commandMqDlqReplay) Name() string {
+	return "mq.dlq.replay"
+}
+
+func (c *commandMqDlqReplay) Help() string {
+	return `publish the messages in a dead letter topic again to their source topics
+
+	Example:
+		mq.dlq.replay -namespace <namespace> -topic <topic_name> -consumerGroup <group> -since 1h -apply
+		mq.dlq.replay -namespace <namespace> -dlqTopic <dead_letter_topic_name> -key <key> -apply
+
+	Without -apply, only the dead letters to replay are listed.
+	Replayed messages are new messages with the original key and value.
+	The dead letter topic is left unchanged; use mq.topic.truncate to clear it.
+`
+}
+
+func (c *commandMqDlqReplay) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandMqDlqReplay) Do(args []string, commandEnv *CommandEnv, writer io.Writer) error {
+
+	mqCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	namespace := mqCommand.String("namespace", "", "namespace name")
+	topicName := mqCommand.String("topic", "", "topic name")
+	consumerGroup := mqCommand.String("consumerGroup", "", "consumer group name")
+	dlqTopicName := mqCommand.String("dlqTopic", "", "dead letter topic name, instead of the default one of the topic and consumer group")
+	key := mqCommand.String("key", "", "only replay dead letters with this key")
+	since := mqCommand.Duration("since", 0, "only replay messages dead lettered within this duration, 0 for all")
+	applyReplay := mqCommand.Bool("apply", false, "publish the messages")
+	if err := mqCommand.Parse(args); err != nil {
+		return err
+	}
+
+	dlqTopic, err := parseDeadLetterTopic(*namespace, *topicName, *consumerGroup, *dlqTopicName)
+	if err != nil {
+		return err
+	}
+
+	var deadLetters []*sub_coordinator.DeadLetter
+	err = eachDeadLetter(commandEnv, dlqTopic, func(d *sub_coordinator.DeadLetter) (bool, error) {
+		if *key != "" && string(d.Key) != *key {
+			return false, nil
+		}
+		if *since > 0 && d.FailedAt.Before(time.Now().Add(-*since)) {
+			return false, nil
+		}
+		deadLetters = append(deadLetters, d)
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !*applyReplay {
+		for _, d := range deadLetters {
+			fmt.Fprintf(writer, "replay key %q to %v, failed %s: %s\n", string(d.Key), d.Topic, d.FailedAt.Format(time.RFC3339), d.Error)
+		}
+		fmt.Fprintf(writer, "%d dead letters to replay from %v. Use -apply to publish them.\n", len(deadLetters), dlqTopic)
+		return nil
+	}
+
+	brokerBalancer, err := findBrokerBalancer(commandEnv)
+	if err != nil {
+		return err
+	}
+
+	publishers := make(map[topic.Topic]*dlqReplayPublisher)
+	defer func() {
+		for _, p := range publishers {
+			p.publisher.Shutdown()
+		}
+	}()
+
+	for _, d := range deadLetters {
+		p, found := publishers[d.Topic]
+		if !found {
+			if p, err = newDlqReplayPublisher(commandEnv, brokerBalancer, d.Topic); err != nil {
+				return err
+			}
+			publishers[d.Topic] = p
+		}
+		if err = p.publish(d); err != nil {
+			return fmt.Errorf("replay key %q to %v: %w", string(d.Key), d.Topic, err)
+		}
+	}
+
+	for t, p := range publishers {
+		if err = p.publisher.FinishPublish(); err != nil {
+			return fmt.Errorf("finish publishing to %v: %w", t, err)
+		}
+	}
+	fmt.Fprintf(writer, "replayed %d dead letters from %v\n", len(deadLetters), dlqTopic)
+	return nil
+}
+
+type dlqReplayPublisher struct {
+	publisher  *pub_client.TopicPublisher
+	withSchema bool
+}
+
+// newDlqReplayPublisher publishes to the source topic with its current configuration,
+// so that the topic schema and partitions are kept.
+func newDlqReplayPublisher(commandEnv *CommandEnv, brokerBalancer string, t topic.Topic) (*dlqReplayPublisher, error) {
+	var partitionCount int32
+	var recordType *schema_pb.RecordType
+	err := commandEnv.WithFilerClient(false, func(client filer_pb.SeaweedFilerClient) error {
+		conf, err := t.ReadConfFile(client)
+		if err != nil {
+			return err
+		}
+		partitionCount = int32(len(conf.BrokerPartitionAssignments))
+		recordType = conf.MessageRecordType
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read topic %v configuration: %w", t, err)
+	}
+
+	publisher, err := pub_client.NewTopicPublisher(&pub_client.PublisherConfiguration{
+		Topic:          t,
+		PartitionCount: partitionCount,
+		Brokers:        []string{brokerBalancer},
+		PublisherName:  "mq.dlq.replay",
+		RecordType:     recordType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create publisher to %v: %w", t, err)
+	}
+	return &dlqReplayPublisher{
+		publisher:  publisher,
+		withSchema: recordType != nil,
+	}, nil
+}
+
+func (p *dlqReplayPublisher) publish(d *sub_coordinator.DeadLetter) error {
+	if !p.withSchema {
+		return p.publisher.Publish(d.Key, d.Value)
+	}
+	record := &schema_pb.RecordValue{}
+	if err := proto.Unmarshal(d.Value, record); err != nil {
+		return fmt.Errorf("unmarshal record: %w", err)
+	}
+	return p.publisher.PublishRecord(d.Key, record)
+}
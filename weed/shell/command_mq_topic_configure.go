@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/mq_pb"
@@ -27,7 +28,13 @@ func (c *commandMqTopicConfigure) Help() string {
 	return `configure a topic with a given name
 
 	Example:
-		mq.topic.configure -namespace <namespace> -topic <topic_name> -partition_count <partition_count>
+		mq.topic.configure -namespace <namespace> -topic <topic_name> -partitionCount <partition_count>
+
+	To keep only the latest message of each key:
+		mq.topic.configure -namespace <namespace> -topic <topic_name> -cleanupPolicy compact -deleteRetention 24h
+
+	The "compact,delete" policy additionally drops messages older than -retention.
+	Messages with a key but an empty value are tombstones, kept for -deleteRetention after compaction.
 `
 }
 
@@ -42,10 +49,25 @@ func (c *commandMqTopicConfigure) Do(args []string, commandEnv *CommandEnv, writ
 	namespace := mqCommand.String("namespace", "", "namespace name")
 	topicName := mqCommand.String("topic", "", "topic name")
 	partitionCount := mqCommand.Int("partitionCount", 6, "partition count")
+	cleanupPolicy := mqCommand.String("cleanupPolicy", "", "delete|compact|compact,delete, empty to keep the current retention")
+	retention := mqCommand.Duration("retention", 0, "drop messages older than this, 0 to keep them")
+	deleteRetention := mqCommand.Duration("deleteRetention", 0, "how long compaction keeps tombstones, 0 for the default")
+	minCompactionLag := mqCommand.Duration("minCompactionLag", 0, "minimum age of messages before compaction, 0 for the default")
 	if err := mqCommand.Parse(args); err != nil {
 		return err
 	}
 
+	var topicRetention *mq_pb.TopicRetention
+	if *cleanupPolicy != "" {
+		topicRetention = &mq_pb.TopicRetention{
+			RetentionSeconds:        int64(*retention / time.Second),
+			Enabled:                 *retention > 0,
+			CleanupPolicy:           *cleanupPolicy,
+			DeleteRetentionSeconds:  int64(*deleteRetention / time.Second),
+			MinCompactionLagSeconds: int64(*minCompactionLag / time.Second),
+		}
+	}
+
 	// find the broker balancer
 	brokerBalancer, err := findBrokerBalancer(commandEnv)
 	if err != nil {
@@ -61,6 +83,7 @@ func (c *commandMqTopicConfigure) Do(args []string, commandEnv *CommandEnv, writ
 				Name:      *topicName,
 			},
 			PartitionCount: int32(*partitionCount),
+			Retention:      topicRetention,
 		})
 		if err != nil {
 			return err
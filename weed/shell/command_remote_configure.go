@@ -43,6 +43,7 @@ func (c *commandRemoteConfigure) Help() string {
 	remote.configure -name=cloud6 -type=wasabi -wasabi.access_key=xxx -wasabi.secret_key=yyy -wasabi.endpoint=s3.us-west-1.wasabisys.com -wasabi.region=us-west-1
 	remote.configure -name=cloud7 -type=storj -storj.access_key=xxx -storj.secret_key=yyy -storj.endpoint=https://gateway.us1.storjshare.io
 	remote.configure -name=cloud8 -type=filebase -filebase.access_key=xxx -filebase.secret_key=yyy -filebase.endpoint=https://s3.filebase.com
+	remote.configure -name=east -type=seaweedfs -seaweedfs.filer=east-filer1:8888,east-filer2:8888
 
 	# delete one configuration
 	remote.configure -delete -name=cloud1
@@ -115,6 +116,8 @@ func (c *commandRemoteConfigure) Do(args []string, commandEnv *CommandEnv, write
 	remoteConfigureCommand.StringVar(&conf.StorjSecretKey, "storj.secret_key", "", "Storj secret key")
 	remoteConfigureCommand.StringVar(&conf.StorjEndpoint, "storj.endpoint", "", "Storj endpoint")
 
+	remoteConfigureCommand.StringVar(&conf.SeaweedfsFilers, "seaweedfs.filer", "", "comma separated filer addresses of another SeaweedFS cluster, see cluster.federation.add")
+
 	if err = remoteConfigureCommand.Parse(args); err != nil {
 		return nil
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
@@ -30,6 +31,11 @@ type commandVolumeBalance struct {
 	commandEnv        *CommandEnv
 	writable          bool
 	applyBalancing    bool
+	byLoad            bool
+	loadThreshold     float64
+	addReplicas       bool
+	volumeLoads       map[volume_load.Key]*volume_load.Load
+	writer            io.Writer
 }
 
 func (c *commandVolumeBalance) Name() string {
@@ -40,6 +46,7 @@ func (c *commandVolumeBalance) Help() string {
 	return `balance all volumes among volume servers
 
 	volume.balance [-collection ALL_COLLECTIONS|EACH_COLLECTION|<collection_name>] [-apply] [-dataCenter=<data_center_name>] [-racks=rack_name_one,rack_name_two] [-nodes=192.168.0.1:8080,192.168.0.2:8080]
+	volume.balance -byLoad [-loadWindow=1m] [-loadThreshold=0.2] [-addReplicas] [-apply]
 
 	The -collection parameter supports:
 	  - ALL_COLLECTIONS: balance across all collections
@@ -76,6 +83,18 @@ func (c *commandVolumeBalance) Help() string {
 		//similar to balanceWritableVolumes
 	}
 
+	With -byLoad, the volumes are balanced by their read and write load instead of their count.
+	The io counters of all volumes are sampled from the volume servers over -loadWindow, then per disk type:
+
+	for (maxServerLoad - minServerLoad) / avgServerLoad > loadThreshold {
+		pick the volume on the busiest server which narrows the load gap the most
+		move it to the least loaded server that stays below the busiest server and keeps the replica placement
+		with -addReplicas, a read heavy read only volume gets one more replica there instead,
+			and the replica placement of all its replicas is raised by one copy
+	}
+
+	Each planned move is printed with the volume load, and the server loads before and after the move.
+
 `
 }
 
@@ -93,6 +112,10 @@ func (c *commandVolumeBalance) Do(args []string, commandEnv *CommandEnv, writer
 	writable := balanceCommand.Bool("writable", false, "only apply the balancing for writable volumes")
 	noLock := balanceCommand.Bool("noLock", false, "do not lock the admin shell at one's own risk")
 	applyBalancing := balanceCommand.Bool("apply", false, "apply the balancing plan.")
+	byLoad := balanceCommand.Bool("byLoad", false, "balance the volumes by their sampled read and write load")
+	loadWindow := balanceCommand.Duration("loadWindow", time.Minute, "the time window to sample the volume load, with -byLoad")
+	loadThreshold := balanceCommand.Float64("loadThreshold", 0.2, "the tolerated spread of the server loads relative to the average load, with -byLoad")
+	addReplicas := balanceCommand.Bool("addReplicas", false, "add replicas of hot read heavy read only volumes instead of moving them, with -byLoad")
 	// TODO: remove this alias
 	applyBalancingAlias := balanceCommand.Bool("force", false, "apply the balancing plan (alias for -apply)")
 	if err = balanceCommand.Parse(args); err != nil {
@@ -101,6 +124,10 @@ func (c *commandVolumeBalance) Do(args []string, commandEnv *CommandEnv, writer
 	handleDeprecatedForceFlag(writer, balanceCommand, applyBalancingAlias, applyBalancing)
 	c.writable = *writable
 	c.applyBalancing = *applyBalancing
+	c.byLoad = *byLoad
+	c.loadThreshold = *loadThreshold
+	c.addReplicas = *addReplicas
+	c.writer = writer
 
 	infoAboutSimulationMode(writer, c.applyBalancing, "-apply")
 
@@ -124,6 +151,12 @@ func (c *commandVolumeBalance) Do(args []string, commandEnv *CommandEnv, writer
 	volumeReplicas, _ := collectVolumeReplicaLocations(topologyInfo)
 	diskTypes := collectVolumeDiskTypes(topologyInfo)
 
+	if c.byLoad {
+		if c.volumeLoads, err = sampleVolumeLoads(commandEnv.option.GrpcDialOption, volumeServers, *loadWindow, writer); err != nil {
+			return err
+		}
+	}
+
 	if *collection == "EACH_COLLECTION" {
 		collections, err := ListCollectionNames(commandEnv, true, false)
 		if err != nil {
@@ -188,6 +221,9 @@ func (c *commandVolumeBalance) balanceVolumeServersByDiskType(diskType types.Dis
 			return true
 		})
 	}
+	if c.byLoad {
+		return c.balanceByLoad(diskType, volumeReplicas, nodes)
+	}
 	if err := balanceSelectedVolume(c.commandEnv, diskType, volumeReplicas, nodes, sortWritableVolumes, c.applyBalancing); err != nil {
 		return err
 	}
@@ -0,0 +1,439 @@
+package shell
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
+)
+
+// a volume is read heavy if at least this share of its operations are reads
+const readHeavyShare = 0.9
+
+// sampleVolumeLoads reads the io counters of the volume servers twice, the sampling window apart
+func sampleVolumeLoads(grpcDialOption grpc.DialOption, nodes []*Node, window time.Duration, writer io.Writer) (map[volume_load.Key]*volume_load.Load, error) {
+	before, err := collectVolumeIoStats(grpcDialOption, nodes)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(writer, "sampling the load of %d volume servers for %v ...\n", len(nodes), window)
+	time.Sleep(window)
+	after, err := collectVolumeIoStats(grpcDialOption, nodes)
+	if err != nil {
+		return nil, err
+	}
+	volumeLoads := volumeLoadsBetween(before, after)
+	printVolumeLoads(writer, nodes, volumeLoads)
+	return volumeLoads, nil
+}
+
+func collectVolumeIoStats(grpcDialOption grpc.DialOption, nodes []*Node) (map[string]*volume_server_pb.VolumeServerIoStatsResponse, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	results := make(map[string]*volume_server_pb.VolumeServerIoStatsResponse)
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *Node) {
+			defer wg.Done()
+			err := operation.WithVolumeServerClient(false, pb.NewServerAddressFromDataNode(n.info), grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+				resp, err := volumeServerClient.VolumeServerIoStats(context.Background(), &volume_server_pb.VolumeServerIoStatsRequest{})
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				results[n.info.Id] = resp
+				mu.Unlock()
+				return nil
+			})
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("collect io stats from %s: %v", n.info.Id, err))
+				mu.Unlock()
+			}
+		}(n)
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
+
+// volumeLoadsBetween computes the load of the volumes found in both samples.
+// Volumes with counters reset in between, e.g. reloaded, have no load.
+func volumeLoadsBetween(before, after map[string]*volume_server_pb.VolumeServerIoStatsResponse) map[volume_load.Key]*volume_load.Load {
+	volumeLoads := make(map[volume_load.Key]*volume_load.Load)
+	for server, afterResp := range after {
+		beforeResp, found := before[server]
+		if !found {
+			continue
+		}
+		previous := make(map[uint32]volume_load.Counters)
+		for _, s := range beforeResp.VolumeIoStats {
+			previous[s.VolumeId] = ioStatsCounters(s, beforeResp.TsNs)
+		}
+		for _, s := range afterResp.VolumeIoStats {
+			p, found := previous[s.VolumeId]
+			if !found {
+				continue
+			}
+			load := ioStatsCounters(s, afterResp.TsNs).LoadSince(p)
+			if load == nil {
+				continue
+			}
+			load.DiskType, load.DiskId = s.DiskType, s.DiskId
+			volumeLoads[volume_load.Key{Server: server, VolumeId: s.VolumeId}] = load
+		}
+	}
+	return volumeLoads
+}
+
+func ioStatsCounters(s *volume_server_pb.VolumeIoStats, tsNs int64) volume_load.Counters {
+	return volume_load.Counters{
+		ReadCount:   s.ReadCount,
+		ReadBytes:   s.ReadBytes,
+		ReadMicros:  s.ReadMicros,
+		WriteCount:  s.WriteCount,
+		WriteBytes:  s.WriteBytes,
+		WriteMicros: s.WriteMicros,
+		SampledAt:   time.Unix(0, tsNs),
+	}
+}
+
+// sumVolumeLoads adds up the rates, and weights the latencies by the operations
+func sumVolumeLoads(loads []*volume_load.Load) *volume_load.Load {
+	total := &volume_load.Load{}
+	var readMicros, writeMicros float64
+	for _, l := range loads {
+		total.ReadOps += l.ReadOps
+		total.WriteOps += l.WriteOps
+		total.ReadBytes += l.ReadBytes
+		total.WriteBytes += l.WriteBytes
+		readMicros += l.ReadOps * float64(l.ReadLatency.Microseconds())
+		writeMicros += l.WriteOps * float64(l.WriteLatency.Microseconds())
+	}
+	if total.ReadOps > 0 {
+		total.ReadLatency = time.Duration(readMicros/total.ReadOps) * time.Microsecond
+	}
+	if total.WriteOps > 0 {
+		total.WriteLatency = time.Duration(writeMicros/total.WriteOps) * time.Microsecond
+	}
+	return total
+}
+
+func printVolumeLoads(writer io.Writer, nodes []*Node, volumeLoads map[volume_load.Key]*volume_load.Load) {
+	for _, n := range nodes {
+		var nodeLoads []*volume_load.Load
+		diskLoads := make(map[string][]*volume_load.Load)
+		for key, load := range volumeLoads {
+			if key.Server != n.info.Id {
+				continue
+			}
+			nodeLoads = append(nodeLoads, load)
+			disk := fmt.Sprintf("%s disk %d", types.ToDiskType(load.DiskType).ReadableString(), load.DiskId)
+			diskLoads[disk] = append(diskLoads[disk], load)
+		}
+		fmt.Fprintf(writer, "  %s %s:%s %s\n", n.info.Id, n.dc, n.rack, sumVolumeLoads(nodeLoads))
+		disks := make([]string, 0, len(diskLoads))
+		for disk := range diskLoads {
+			disks = append(disks, disk)
+		}
+		slices.Sort(disks)
+		for _, disk := range disks {
+			fmt.Fprintf(writer, "    %s: %s\n", disk, sumVolumeLoads(diskLoads[disk]))
+		}
+	}
+}
+
+// loadBalanceMove moves a hot volume replica to a less loaded server, or adds a replica there
+type loadBalanceMove struct {
+	volume           *master_pb.VolumeInformationMessage
+	source, target   *Node
+	load             *volume_load.Load
+	addReplica       bool
+	replicaPlacement *super_block.ReplicaPlacement // the raised replica placement of an added replica
+	replication      string                        // the replica placement before adding a replica
+	replicaServers   []pb.ServerAddress            // the existing replicas of an added replica
+	sourceBefore     float64
+	sourceAfter      float64
+	targetBefore     float64
+	targetAfter      float64
+}
+
+func (m *loadBalanceMove) String() string {
+	action := fmt.Sprintf("move volume %d %s => %s", m.volume.Id, m.source.info.Id, m.target.info.Id)
+	if m.addReplica {
+		action = fmt.Sprintf("add replica of read only volume %d on %s to %s, replication %s => %s",
+			m.volume.Id, m.source.info.Id, m.target.info.Id, m.replication, m.replicaPlacement)
+	}
+	return fmt.Sprintf("%s: volume load %s; %s %.1f => %.1f ops/s, %s %.1f => %.1f ops/s",
+		action, m.load, m.source.info.Id, m.sourceBefore, m.sourceAfter, m.target.info.Id, m.targetBefore, m.targetAfter)
+}
+
+// planLoadBalance repeatedly relieves the busiest server by moving one of its volumes to a less loaded server,
+// until the spread of the server loads is within the threshold of the average load.
+// With addReplicas, read heavy read only volumes get one more replica instead, which spreads their reads.
+func planLoadBalance(diskType types.DiskType, volumeReplicas map[uint32][]*VolumeReplica, nodes []*Node, volumeLoads map[volume_load.Key]*volume_load.Load, threshold float64, addReplicas bool) (moves []*loadBalanceMove) {
+	var diskNodes []*Node
+	nodeLoads := make(map[string]float64)
+	maxMoves := 0
+	for _, n := range nodes {
+		if _, found := n.info.DiskInfos[string(diskType)]; !found {
+			continue
+		}
+		diskNodes = append(diskNodes, n)
+		for vid := range n.selectedVolumes {
+			nodeLoads[n.info.Id] += volumeLoads[volume_load.Key{Server: n.info.Id, VolumeId: vid}].Ops()
+		}
+		maxMoves += len(n.selectedVolumes)
+	}
+	if len(diskNodes) < 2 {
+		return nil
+	}
+
+	for len(moves) < maxMoves {
+		slices.SortFunc(diskNodes, func(a, b *Node) int {
+			return cmp.Compare(nodeLoads[a.info.Id], nodeLoads[b.info.Id])
+		})
+		hotNode, coldNode := diskNodes[len(diskNodes)-1], diskNodes[0]
+		var totalLoad float64
+		for _, n := range diskNodes {
+			totalLoad += nodeLoads[n.info.Id]
+		}
+		avgLoad := totalLoad / float64(len(diskNodes))
+		gap := nodeLoads[hotNode.info.Id] - nodeLoads[coldNode.info.Id]
+		if avgLoad == 0 || gap/avgLoad <= threshold {
+			break
+		}
+
+		// moving a volume narrows the gap to |gap - 2*load|, so the volumes closest to half of the gap go first
+		var candidates []*master_pb.VolumeInformationMessage
+		for vid, v := range hotNode.selectedVolumes {
+			if volumeLoads[volume_load.Key{Server: hotNode.info.Id, VolumeId: vid}].Ops() > 0 && v.RemoteStorageName == "" {
+				candidates = append(candidates, v)
+			}
+		}
+		slices.SortFunc(candidates, func(a, b *master_pb.VolumeInformationMessage) int {
+			la := volumeLoads[volume_load.Key{Server: hotNode.info.Id, VolumeId: a.Id}].Ops()
+			lb := volumeLoads[volume_load.Key{Server: hotNode.info.Id, VolumeId: b.Id}].Ops()
+			return cmp.Compare(math.Abs(gap-2*la), math.Abs(gap-2*lb))
+		})
+
+		var move *loadBalanceMove
+		for _, v := range candidates {
+			for _, targetNode := range diskNodes[:len(diskNodes)-1] {
+				if move = planOneLoadMove(diskType, volumeReplicas, volumeLoads, nodeLoads, v, hotNode, targetNode, addReplicas); move != nil {
+					break
+				}
+			}
+			if move != nil {
+				break
+			}
+		}
+		if move == nil {
+			break
+		}
+		applyLoadMove(move, diskType, volumeReplicas, volumeLoads, nodeLoads)
+		moves = append(moves, move)
+	}
+	return
+}
+
+func planOneLoadMove(diskType types.DiskType, volumeReplicas map[uint32][]*VolumeReplica, volumeLoads map[volume_load.Key]*volume_load.Load, nodeLoads map[string]float64, v *master_pb.VolumeInformationMessage, sourceNode, targetNode *Node, addReplicas bool) *loadBalanceMove {
+	if _, found := targetNode.selectedVolumes[v.Id]; found {
+		return nil
+	}
+	if capacityByFreeVolumeCount(diskType)(targetNode.info) < 1 {
+		return nil
+	}
+	load := volumeLoads[volume_load.Key{Server: sourceNode.info.Id, VolumeId: v.Id}]
+	sourceLoad, targetLoad := nodeLoads[sourceNode.info.Id], nodeLoads[targetNode.info.Id]
+	replicaPlacement, _ := super_block.NewReplicaPlacementFromByte(byte(v.ReplicaPlacement))
+
+	if addReplicas && v.ReadOnly && load.ReadShare() >= readHeavyShare {
+		replicas := volumeReplicas[v.Id]
+		targetLocation := newLocation(targetNode.dc, targetNode.rack, targetNode.info)
+		if raised := raiseReplicaPlacement(replicaPlacement, replicas, targetLocation); raised != nil {
+			// the reads spread evenly over all replicas
+			share := volumeReplicaReads(volumeLoads, replicas) / float64(len(replicas)+1)
+			if share < load.ReadOps && targetLoad+share < sourceLoad {
+				var replicaServers []pb.ServerAddress
+				for _, replica := range replicas {
+					replicaServers = append(replicaServers, pb.NewServerAddressFromDataNode(replica.location.dataNode))
+				}
+				return &loadBalanceMove{
+					volume:           v,
+					source:           sourceNode,
+					target:           targetNode,
+					load:             load,
+					addReplica:       true,
+					replicaPlacement: raised,
+					replication:      replicaPlacement.String(),
+					replicaServers:   replicaServers,
+					sourceBefore:     sourceLoad,
+					sourceAfter:      sourceLoad - load.ReadOps + share,
+					targetBefore:     targetLoad,
+					targetAfter:      targetLoad + share,
+				}
+			}
+		}
+	}
+
+	if targetLoad+load.Ops() >= sourceLoad {
+		return nil
+	}
+	if v.ReplicaPlacement > 0 && !isGoodMove(replicaPlacement, volumeReplicas[v.Id], sourceNode, targetNode) {
+		return nil
+	}
+	return &loadBalanceMove{
+		volume:       v,
+		source:       sourceNode,
+		target:       targetNode,
+		load:         load,
+		sourceBefore: sourceLoad,
+		sourceAfter:  sourceLoad - load.Ops(),
+		targetBefore: targetLoad,
+		targetAfter:  targetLoad + load.Ops(),
+	}
+}
+
+// raiseReplicaPlacement finds a replica placement with one more copy, which allows the new replica on the target location
+func raiseReplicaPlacement(replicaPlacement *super_block.ReplicaPlacement, replicas []*VolumeReplica, targetLocation location) *super_block.ReplicaPlacement {
+	for _, raised := range []super_block.ReplicaPlacement{
+		{SameRackCount: replicaPlacement.SameRackCount + 1, DiffRackCount: replicaPlacement.DiffRackCount, DiffDataCenterCount: replicaPlacement.DiffDataCenterCount},
+		{SameRackCount: replicaPlacement.SameRackCount, DiffRackCount: replicaPlacement.DiffRackCount + 1, DiffDataCenterCount: replicaPlacement.DiffDataCenterCount},
+		{SameRackCount: replicaPlacement.SameRackCount, DiffRackCount: replicaPlacement.DiffRackCount, DiffDataCenterCount: replicaPlacement.DiffDataCenterCount + 1},
+	} {
+		if raised.SameRackCount > 9 || raised.DiffRackCount > 9 || raised.DiffDataCenterCount > 9 {
+			continue
+		}
+		if _, err := super_block.NewReplicaPlacementFromString(raised.String()); err != nil {
+			continue
+		}
+		if satisfyReplicaPlacement(&raised, replicas, targetLocation) {
+			return &raised
+		}
+	}
+	return nil
+}
+
+func volumeReplicaReads(volumeLoads map[volume_load.Key]*volume_load.Load, replicas []*VolumeReplica) (reads float64) {
+	for _, replica := range replicas {
+		if load := volumeLoads[volume_load.Key{Server: replica.location.dataNode.Id, VolumeId: replica.info.Id}]; load != nil {
+			reads += load.ReadOps
+		}
+	}
+	return
+}
+
+// applyLoadMove updates the planning state as if the move is done
+func applyLoadMove(move *loadBalanceMove, diskType types.DiskType, volumeReplicas map[uint32][]*VolumeReplica, volumeLoads map[volume_load.Key]*volume_load.Load, nodeLoads map[string]float64) {
+	v := move.volume
+	sourceKey := volume_load.Key{Server: move.source.info.Id, VolumeId: v.Id}
+	targetKey := volume_load.Key{Server: move.target.info.Id, VolumeId: v.Id}
+
+	if !move.addReplica {
+		adjustAfterMove(v, volumeReplicas, move.source, move.target)
+		volumeLoads[targetKey] = volumeLoads[sourceKey]
+		delete(volumeLoads, sourceKey)
+		nodeLoads[move.source.info.Id] = move.sourceAfter
+		nodeLoads[move.target.info.Id] = move.targetAfter
+		return
+	}
+
+	replicas := volumeReplicas[v.Id]
+	share := volumeReplicaReads(volumeLoads, replicas) / float64(len(replicas)+1)
+	for _, replica := range replicas {
+		key := volume_load.Key{Server: replica.location.dataNode.Id, VolumeId: v.Id}
+		replica.info.ReplicaPlacement = uint32(move.replicaPlacement.Byte())
+		load := volumeLoads[key]
+		if load == nil {
+			load = &volume_load.Load{}
+		}
+		spread := *load
+		if load.ReadOps > 0 {
+			spread.ReadBytes = load.ReadBytes * share / load.ReadOps
+		}
+		spread.ReadOps = share
+		volumeLoads[key] = &spread
+		nodeLoads[key.Server] += share - load.ReadOps
+	}
+	targetLoad := *volumeLoads[sourceKey]
+	volumeLoads[targetKey] = &targetLoad
+	nodeLoads[move.target.info.Id] += targetLoad.Ops()
+
+	loc := newLocation(move.target.dc, move.target.rack, move.target.info)
+	volumeReplicas[v.Id] = append(replicas, &VolumeReplica{location: &loc, info: v})
+	if move.target.selectedVolumes != nil {
+		move.target.selectedVolumes[v.Id] = v
+	}
+	addVolumeCount(move.target.info.DiskInfos[string(diskType)], 1)
+}
+
+func (c *commandVolumeBalance) balanceByLoad(diskType types.DiskType, volumeReplicas map[uint32][]*VolumeReplica, nodes []*Node) error {
+	moves := planLoadBalance(diskType, volumeReplicas, nodes, c.volumeLoads, c.loadThreshold, c.addReplicas)
+	if len(moves) == 0 {
+		fmt.Fprintf(c.writer, "%s load is balanced\n", diskType.ReadableString())
+		return nil
+	}
+	for _, move := range moves {
+		fmt.Fprintf(c.writer, "%s %s\n", diskType.ReadableString(), move)
+		if !c.applyBalancing {
+			continue
+		}
+		if !c.commandEnv.isLocked() {
+			return fmt.Errorf("lock is lost")
+		}
+		if err := c.applyLoadMove(move); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *commandVolumeBalance) applyLoadMove(move *loadBalanceMove) error {
+	grpcDialOption := c.commandEnv.option.GrpcDialOption
+	vid := needle.VolumeId(move.volume.Id)
+	source, target := pb.NewServerAddressFromDataNode(move.source.info), pb.NewServerAddressFromDataNode(move.target.info)
+	if !move.addReplica {
+		return LiveMoveVolume(grpcDialOption, c.writer, vid, source, target, 5*time.Second, move.volume.DiskType, 0, false)
+	}
+
+	if _, err := copyVolume(grpcDialOption, c.writer, vid, source, target, move.volume.DiskType, 0); err != nil {
+		return fmt.Errorf("copy volume %d from %s to %s: %v", vid, source, target, err)
+	}
+	// raise the replication of all replicas, so the new replica is not deleted as over replicated
+	for _, server := range append([]pb.ServerAddress{target}, move.replicaServers...) {
+		err := operation.WithVolumeServerClient(false, server, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			resp, configureErr := volumeServerClient.VolumeConfigure(context.Background(), &volume_server_pb.VolumeConfigureRequest{
+				VolumeId:    uint32(vid),
+				Replication: move.replicaPlacement.String(),
+			})
+			if configureErr != nil {
+				return configureErr
+			}
+			if resp.Error != "" {
+				return errors.New(resp.Error)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("configure volume %d replication %s on %s: %v", vid, move.replicaPlacement, server, err)
+		}
+	}
+	return nil
+}
@@ -3,11 +3,14 @@ package shell
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 )
 
@@ -287,3 +290,96 @@ func TestDeleteEmptySelection(t *testing.T) {
 	})
 
 }
+
+func TestPlanLoadBalance(t *testing.T) {
+	newNode := func(id, rack string, volumes ...*master_pb.VolumeInformationMessage) *Node {
+		return &Node{
+			info: &master_pb.DataNodeInfo{
+				Id: id,
+				DiskInfos: map[string]*master_pb.DiskInfo{
+					"": {MaxVolumeCount: 10, VolumeCount: int64(len(volumes)), VolumeInfos: volumes},
+				},
+			},
+			dc:   "dc1",
+			rack: rack,
+		}
+	}
+	plan := func(addReplicas bool) ([]*loadBalanceMove, map[uint32][]*VolumeReplica) {
+		v1 := &master_pb.VolumeInformationMessage{Id: 1}
+		v2 := &master_pb.VolumeInformationMessage{Id: 2, ReadOnly: true}
+		v3 := &master_pb.VolumeInformationMessage{Id: 3}
+		v4 := &master_pb.VolumeInformationMessage{Id: 4}
+		v5 := &master_pb.VolumeInformationMessage{Id: 5}
+		nodes := []*Node{
+			newNode("dn1", "r1", v1, v2, v3),
+			newNode("dn2", "r1", v4),
+			newNode("dn3", "r2", v5),
+		}
+		volumeReplicas := make(map[uint32][]*VolumeReplica)
+		for _, n := range nodes {
+			n.selectVolumes(func(v *master_pb.VolumeInformationMessage) bool { return true })
+			for _, v := range n.selectedVolumes {
+				loc := newLocation(n.dc, n.rack, n.info)
+				volumeReplicas[v.Id] = append(volumeReplicas[v.Id], &VolumeReplica{location: &loc, info: v})
+			}
+		}
+		volumeLoads := map[volume_load.Key]*volume_load.Load{
+			{Server: "dn1", VolumeId: 1}: {ReadOps: 150, WriteOps: 150},
+			{Server: "dn1", VolumeId: 2}: {ReadOps: 400},
+			{Server: "dn1", VolumeId: 3}: {ReadOps: 10},
+			{Server: "dn2", VolumeId: 4}: {ReadOps: 20},
+			{Server: "dn3", VolumeId: 5}: {ReadOps: 30},
+		}
+		return planLoadBalance(types.HardDriveType, volumeReplicas, nodes, volumeLoads, 0.2, addReplicas), volumeReplicas
+	}
+
+	// the hot read only volume 2 can not move without making the target the busiest server
+	moves, _ := plan(false)
+	assert.Equal(t, 2, len(moves))
+	assert.Equal(t, uint32(1), moves[0].volume.Id)
+	assert.Equal(t, "dn2", moves[0].target.info.Id)
+	assert.Equal(t, 710.0, moves[0].sourceBefore)
+	assert.Equal(t, 410.0, moves[0].sourceAfter)
+	assert.Equal(t, uint32(3), moves[1].volume.Id)
+	assert.Equal(t, "dn3", moves[1].target.info.Id)
+
+	// with -addReplicas, volume 2 gets a replica on the other rack, which takes half of its reads
+	moves, volumeReplicas := plan(true)
+	assert.Equal(t, 4, len(moves))
+	added := moves[2]
+	assert.True(t, added.addReplica)
+	assert.Equal(t, uint32(2), added.volume.Id)
+	assert.Equal(t, "dn3", added.target.info.Id)
+	assert.Equal(t, "000", added.replication)
+	assert.Equal(t, "010", added.replicaPlacement.String())
+	assert.Equal(t, 200.0, added.sourceAfter)
+	assert.Equal(t, 240.0, added.targetAfter)
+	assert.Equal(t, 2, len(volumeReplicas[2]))
+	assert.Equal(t, uint32(10), volumeReplicas[2][0].info.ReplicaPlacement)
+	assert.Equal(t, uint32(4), moves[3].volume.Id)
+	assert.Equal(t, "dn1", moves[3].target.info.Id)
+}
+
+func TestVolumeLoadsBetween(t *testing.T) {
+	before := map[string]*volume_server_pb.VolumeServerIoStatsResponse{
+		"dn1": {TsNs: 0, VolumeIoStats: []*volume_server_pb.VolumeIoStats{
+			{VolumeId: 1, ReadCount: 100, ReadMicros: 1000, WriteCount: 10},
+			{VolumeId: 2, ReadCount: 100},
+		}},
+	}
+	after := map[string]*volume_server_pb.VolumeServerIoStatsResponse{
+		"dn1": {TsNs: int64(10 * time.Second), VolumeIoStats: []*volume_server_pb.VolumeIoStats{
+			{VolumeId: 1, ReadCount: 1100, ReadMicros: 3000, ReadBytes: 10240, WriteCount: 10},
+			{VolumeId: 2, ReadCount: 5},
+			{VolumeId: 3, ReadCount: 5},
+		}},
+	}
+	loads := volumeLoadsBetween(before, after)
+	assert.Equal(t, 1, len(loads), "reset and new volumes have no load")
+	load := loads[volume_load.Key{Server: "dn1", VolumeId: 1}]
+	assert.Equal(t, 100.0, load.ReadOps)
+	assert.Equal(t, 0.0, load.WriteOps)
+	assert.Equal(t, 1024.0, load.ReadBytes)
+	assert.Equal(t, 2*time.Microsecond, load.ReadLatency)
+	assert.Equal(t, 1.0, load.ReadShare())
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/server/constants"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"google.golang.org/grpc"
 )
 
@@ -58,6 +59,9 @@ func (c *commandVolumeCheckDisk) Help() string {
 
 	find all volumes that are replicated
 
+	report the writes missed by replicas and recorded as hints on the volume servers,
+		and replay the hints to the replicas with -apply
+
 	for each writable volume ID, if there are more than 2 replicas, find one pair with the largest 2 in file count
 		for the pair volume A and B
 			append entries in A and not in B to B
@@ -142,6 +146,7 @@ func (c *commandVolumeCheckDisk) Do(args []string, commandEnv *CommandEnv, write
 		}
 	}
 
+	vcd.checkReplicaHints(volumeReplicas, uint32(*volumeId))
 	if err := vcd.checkWritableVolumes(volumeReplicas); err != nil {
 		return err
 	}
@@ -150,6 +155,48 @@ func (c *commandVolumeCheckDisk) Do(args []string, commandEnv *CommandEnv, write
 	return vcd.ewg.Wait()
 }
 
+// checkReplicaHints reports the writes missed by replicas, which are pending on the volume servers to be replayed.
+func (vcd *volumeCheckDisk) checkReplicaHints(volumeReplicas map[uint32][]*VolumeReplica, volumeId uint32) {
+	vcd.write("Pass #0 (replica hints)")
+
+	dataNodes := make(map[string]*master_pb.DataNodeInfo)
+	for _, replicas := range volumeReplicas {
+		for _, replica := range replicas {
+			dataNodes[replica.location.dataNode.Id] = replica.location.dataNode
+		}
+	}
+
+	for _, dataNode := range dataNodes {
+		err := operation.WithVolumeServerClient(false, pb.NewServerAddressFromDataNode(dataNode), vcd.grpcDialOption(), func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			resp, err := volumeServerClient.VolumeReplicaHints(context.Background(), &volume_server_pb.VolumeReplicaHintsRequest{
+				VolumeId: volumeId,
+				Replay:   vcd.applyChanges,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.ReplayedCount > 0 || resp.FailedCount > 0 {
+				vcd.write("replayed %d replica hints on %s, %d failed", resp.ReplayedCount, dataNode.Id, resp.FailedCount)
+			}
+			for _, info := range resp.VolumeHints {
+				targets := make([]string, 0, len(info.Targets))
+				for _, target := range info.Targets {
+					if target == "" {
+						target = "<missing replica>"
+					}
+					targets = append(targets, target)
+				}
+				vcd.write("volume %d on %s has %d pending replica hints, oldest %v ago, for %s",
+					info.VolumeId, dataNode.Id, info.HintCount, vcd.now.Sub(time.Unix(0, info.OldestCreatedAtNs)).Round(time.Second), strings.Join(targets, ", "))
+			}
+			return nil
+		})
+		if err != nil {
+			vcd.write("failed to check replica hints on %s: %v", dataNode.Id, err)
+		}
+	}
+}
+
 // checkWritableVolumes fixes volume replicas which are not read-only.
 func (vcd *volumeCheckDisk) checkWritableVolumes(volumeReplicas map[uint32][]*VolumeReplica) error {
 	vcd.write("Pass #1 (writable volumes)")
@@ -629,17 +676,18 @@ func (vcd *volumeCheckDisk) writeNeedleBlobToTarget(targetVolumeServer pb.Server
 
 func (vcd *volumeCheckDisk) readIndexDatabase(db *needle_map.MemDb, collection string, volumeId uint32, volumeServer pb.ServerAddress) error {
 	var buf bytes.Buffer
-	if err := vcd.copyVolumeIndexFile(collection, volumeId, volumeServer, &buf); err != nil {
+	offsetWidth, err := vcd.copyVolumeIndexFile(collection, volumeId, volumeServer, &buf)
+	if err != nil {
 		return err
 	}
 
 	vcd.writeVerbose("load collection %s volume %d index size %d from %s ...", collection, volumeId, buf.Len(), volumeServer)
-	return db.LoadFilterFromReaderAt(bytes.NewReader(buf.Bytes()), true, false)
+	return db.LoadFilterFromReaderAtWithWidth(bytes.NewReader(buf.Bytes()), offsetWidth, true, false)
 }
 
-func (vcd *volumeCheckDisk) copyVolumeIndexFile(collection string, volumeId uint32, volumeServer pb.ServerAddress, buf *bytes.Buffer) error {
+func (vcd *volumeCheckDisk) copyVolumeIndexFile(collection string, volumeId uint32, volumeServer pb.ServerAddress, buf *bytes.Buffer) (offsetWidth types.OffsetWidth, err error) {
 
-	return operation.WithVolumeServerClient(true, volumeServer, vcd.grpcDialOption(), func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+	err = operation.WithVolumeServerClient(true, volumeServer, vcd.grpcDialOption(), func(volumeServerClient volume_server_pb.VolumeServerClient) error {
 
 		ext := ".idx"
 
@@ -656,7 +704,7 @@ func (vcd *volumeCheckDisk) copyVolumeIndexFile(collection string, volumeId uint
 			return fmt.Errorf("failed to start copying volume %d%s: %v", volumeId, ext, err)
 		}
 
-		err = vcd.writeToBuffer(copyFileClient, buf)
+		offsetWidth, err = vcd.writeToBuffer(copyFileClient, buf)
 		if err != nil {
 			return fmt.Errorf("failed to copy %d%s from %s: %v", volumeId, ext, volumeServer, err)
 		}
@@ -664,18 +712,24 @@ func (vcd *volumeCheckDisk) copyVolumeIndexFile(collection string, volumeId uint
 		return nil
 
 	})
+	return
 }
 
-func (vcd *volumeCheckDisk) writeToBuffer(client volume_server_pb.VolumeServer_CopyFileClient, buf *bytes.Buffer) error {
+// writeToBuffer receives the index file, and returns its offset width
+func (vcd *volumeCheckDisk) writeToBuffer(client volume_server_pb.VolumeServer_CopyFileClient, buf *bytes.Buffer) (types.OffsetWidth, error) {
+	var bytesOffset uint32
 	for {
 		resp, receiveErr := client.Recv()
 		if receiveErr == io.EOF {
 			break
 		}
 		if receiveErr != nil {
-			return fmt.Errorf("receiving: %w", receiveErr)
+			return 0, fmt.Errorf("receiving: %w", receiveErr)
+		}
+		if resp.BytesOffset != 0 {
+			bytesOffset = resp.BytesOffset
 		}
 		buf.Write(resp.FileContent)
 	}
-	return nil
+	return types.ParseOffsetWidth(bytesOffset)
 }
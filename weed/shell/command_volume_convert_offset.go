@@ -0,0 +1,128 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+func init() {
+	Commands = append(Commands, &commandVolumeConvertOffset{})
+}
+
+type commandVolumeConvertOffset struct {
+}
+
+func (c *commandVolumeConvertOffset) Name() string {
+	return "volume.convert.offset"
+}
+
+func (c *commandVolumeConvertOffset) Help() string {
+	return `convert the index offset width of volumes
+
+	volume.convert.offset -volumeId=<volume id> -offsetWidth=5 -apply
+	volume.convert.offset -collectionPattern=<pattern> -offsetWidth=5 -apply
+
+	This command rewrites the index of every replica of the volumes with 4-byte or 5-byte offsets,
+	and records the offset width in the volume .vif file.
+	Volumes with 5-byte offsets can grow up to 8TB, and are served by volume servers built with either width.
+	Each replica is briefly unmounted while its index is rewritten.
+
+	A volume larger than 32GB can not be converted to 4-byte offsets.
+
+`
+}
+
+func (c *commandVolumeConvertOffset) HasTag(CommandTag) bool {
+	return false
+}
+
+func (c *commandVolumeConvertOffset) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	convertCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeId := convertCommand.Uint("volumeId", 0, "the volume id")
+	collectionPattern := convertCommand.String("collectionPattern", "", "match with wildcard characters '*' and '?'")
+	offsetWidth := convertCommand.Uint("offsetWidth", 5, "the index offset width in bytes, 4 or 5")
+	applyChanges := convertCommand.Bool("apply", false, "apply the conversion")
+	if err = convertCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *volumeId == 0 && *collectionPattern == "" {
+		return fmt.Errorf("need -volumeId or -collectionPattern")
+	}
+	if *offsetWidth != uint(types.OffsetWidth4) && *offsetWidth != uint(types.OffsetWidth5) {
+		return fmt.Errorf("unsupported offset width %d", *offsetWidth)
+	}
+
+	if err = commandEnv.confirmIsLocked(args); err != nil {
+		return
+	}
+
+	// collect topology information
+	topologyInfo, _, err := collectTopologyInfo(commandEnv, 0)
+	if err != nil {
+		return err
+	}
+
+	eachDataNode(topologyInfo, func(dc DataCenterId, rack RackId, dn *master_pb.DataNodeInfo) {
+		if err != nil {
+			return
+		}
+		var targetVolumeIds []uint32
+		for _, diskInfo := range dn.DiskInfos {
+			for _, v := range diskInfo.VolumeInfos {
+				if matchVolumeIdOrCollection(v, uint32(*volumeId), *collectionPattern) {
+					targetVolumeIds = append(targetVolumeIds, v.Id)
+				}
+			}
+		}
+		if len(targetVolumeIds) == 0 {
+			return
+		}
+		if !*applyChanges {
+			for _, targetVolumeId := range targetVolumeIds {
+				fmt.Fprintf(writer, "would convert volume %d on %s to %d-byte offsets\n", targetVolumeId, dn.Id, *offsetWidth)
+			}
+			return
+		}
+		err = operation.WithVolumeServerClient(false, pb.NewServerAddressFromDataNode(dn), commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			for _, targetVolumeId := range targetVolumeIds {
+				resp, convertErr := volumeServerClient.VolumeConvertOffset(context.Background(), &volume_server_pb.VolumeConvertOffsetRequest{
+					VolumeId:    targetVolumeId,
+					BytesOffset: uint32(*offsetWidth),
+				})
+				if convertErr != nil {
+					return fmt.Errorf("convert volume %d on %s: %v", targetVolumeId, dn.Id, convertErr)
+				}
+				if resp.PreviousBytesOffset == uint32(*offsetWidth) {
+					fmt.Fprintf(writer, "volume %d on %s already has %d-byte offsets\n", targetVolumeId, dn.Id, *offsetWidth)
+				} else {
+					fmt.Fprintf(writer, "converted volume %d on %s from %d-byte to %d-byte offsets\n", targetVolumeId, dn.Id, resp.PreviousBytesOffset, *offsetWidth)
+				}
+			}
+			return nil
+		})
+	})
+
+	return err
+}
+
+func matchVolumeIdOrCollection(v *master_pb.VolumeInformationMessage, volumeId uint32, collectionPattern string) bool {
+	if volumeId > 0 {
+		return v.Id == volumeId
+	}
+	if collectionPattern == CollectionDefault {
+		return v.Collection == ""
+	}
+	matched, err := filepath.Match(collectionPattern, v.Collection)
+	return err == nil && matched
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage"
+	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
@@ -445,6 +446,7 @@ func (c *commandVolumeFsck) collectOneVolumeFileIds(dataNodeId string, volumeId
 			}
 
 			var buf bytes.Buffer
+			var bytesOffset uint32
 			for {
 				resp, err := copyFileClient.Recv()
 				if errors.Is(err, io.EOF) {
@@ -453,10 +455,17 @@ func (c *commandVolumeFsck) collectOneVolumeFileIds(dataNodeId string, volumeId
 				if err != nil {
 					return err
 				}
+				if resp.BytesOffset != 0 {
+					bytesOffset = resp.BytesOffset
+				}
 				buf.Write(resp.FileContent)
 			}
+			offsetWidth, err := types.ParseOffsetWidth(bytesOffset)
+			if err != nil {
+				return fmt.Errorf("volume %d%s from %s: %v", volumeId, ext, vinfo.server, err)
+			}
 			idxFilename := getVolumeFileIdFile(c.tempFolder, dataNodeId, volumeId)
-			err = writeToFile(buf.Bytes(), idxFilename)
+			err = writeVolumeFileIdFile(buf.Bytes(), offsetWidth, idxFilename)
 			if err != nil {
 				return fmt.Errorf("failed to copy %d%s from %s: %v", volumeId, ext, vinfo.server, err)
 			}
@@ -519,7 +528,7 @@ func (c *commandVolumeFsck) oneVolumeFileIdsCheckOneVolume(dataNodeId string, vo
 		fmt.Fprintf(c.writer, "find missing file chunks in dataNodeId %s volume %d ...\n", dataNodeId, volumeId)
 	}
 
-	db := needle_map.NewMemDb()
+	db := needle_map.NewMemDbWithOffsetWidth(volumeFileIdOffsetWidth)
 	defer db.Close()
 
 	if err = db.LoadFromIdx(getVolumeFileIdFile(c.tempFolder, dataNodeId, volumeId)); err != nil {
@@ -572,7 +581,7 @@ func (c *commandVolumeFsck) httpDelete(path util.FullPath) {
 
 func (c *commandVolumeFsck) oneVolumeFileIdsSubtractFilerFileIds(dataNodeId string, volumeId uint32, vinfo *VInfo, modifyFrom, cutoffFrom uint64) (inUseCount uint64, orphanFileIds []string, orphanDataSize uint64, err error) {
 
-	volumeFileIdDb := needle_map.NewMemDb()
+	volumeFileIdDb := needle_map.NewMemDbWithOffsetWidth(volumeFileIdOffsetWidth)
 	defer volumeFileIdDb.Close()
 
 	if err = volumeFileIdDb.LoadFromIdx(getVolumeFileIdFile(c.tempFolder, dataNodeId, volumeId)); err != nil {
@@ -749,6 +758,25 @@ func getVolumeFileIdFile(tempFolder string, dataNodeid string, vid uint32) strin
 	return filepath.Join(tempFolder, fmt.Sprintf("%s_%d.idx", dataNodeid, vid))
 }
 
+// volumeFileIdOffsetWidth is the offset width of the saved volume index files, which fits volumes of any width
+const volumeFileIdOffsetWidth = types.OffsetWidth5
+
+// writeVolumeFileIdFile saves the volume index with volumeFileIdOffsetWidth
+func writeVolumeFileIdFile(data []byte, offsetWidth types.OffsetWidth, fileName string) error {
+	if offsetWidth != volumeFileIdOffsetWidth {
+		var buf bytes.Buffer
+		err := idx.WalkIndexFileWithWidth(bytes.NewReader(data), offsetWidth, 0, func(key types.NeedleId, offset types.Offset, size types.Size) error {
+			buf.Write(needle_map.ToBytesWithWidth(volumeFileIdOffsetWidth, key, offset, size))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+	return writeToFile(data, fileName)
+}
+
 func getFilerFileIdFile(tempFolder string, vid uint32) string {
 	return filepath.Join(tempFolder, fmt.Sprintf("%d.fid", vid))
 }
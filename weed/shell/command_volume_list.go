@@ -13,6 +13,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 
@@ -191,6 +192,13 @@ func (c *commandVolumeList) writeDataNodeInfo(writer io.Writer, t *master_pb.Dat
 			diskInfoFound = true
 		}
 	}
+	for _, hotNeedle := range t.HotNeedles {
+		if *c.volumeId > 0 && *c.volumeId != uint64(hotNeedle.VolumeId) {
+			continue
+		}
+		fid := needle.NewFileId(needle.VolumeId(hotNeedle.VolumeId), hotNeedle.NeedleId, hotNeedle.Cookie)
+		output(diskInfoFound && verbosityLevel >= 3, writer, "        hot needle %s reads:%d\n", fid, hotNeedle.ReadCount)
+	}
 	output(diskInfoFound && verbosityLevel >= 3, writer, "      DataNode %s %+v \n", t.Id, s)
 	return s
 }
@@ -271,6 +279,10 @@ func writeVolumeInformationMessage(writer io.Writer, t *master_pb.VolumeInformat
 		FileCount:        t.FileCount,
 		DeletedFileCount: t.DeleteCount,
 		DeletedBytes:     t.DeletedByteCount,
+		ReadCount:        t.ReadCount,
+		ReadBytes:        t.ReadBytes,
+		WriteCount:       t.WriteCount,
+		WriteBytes:       t.WriteBytes,
 	}
 }
 
@@ -315,6 +327,10 @@ type statistics struct {
 	FileCount        uint64
 	DeletedFileCount uint64
 	DeletedBytes     uint64
+	ReadCount        uint64
+	ReadBytes        uint64
+	WriteCount       uint64
+	WriteBytes       uint64
 }
 
 func newStatistics() *statistics {
@@ -331,6 +347,10 @@ func (s *statistics) add(t statistics) {
 	s.FileCount += t.FileCount
 	s.DeletedFileCount += t.DeletedFileCount
 	s.DeletedBytes += t.DeletedBytes
+	s.ReadCount += t.ReadCount
+	s.ReadBytes += t.ReadBytes
+	s.WriteCount += t.WriteCount
+	s.WriteBytes += t.WriteBytes
 }
 
 func (s *statistics) isEmpty() bool {
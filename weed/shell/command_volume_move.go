@@ -91,6 +91,11 @@ func (c *commandVolumeMove) Do(args []string, commandEnv *CommandEnv, writer io.
 // LiveMoveVolume moves one volume from one source volume server to one target volume server, with idleTimeout to drain the incoming requests.
 func LiveMoveVolume(grpcDialOption grpc.DialOption, writer io.Writer, volumeId needle.VolumeId, sourceVolumeServer, targetVolumeServer pb.ServerAddress, idleTimeout time.Duration, diskType string, ioBytePerSecond int64, skipTailError bool) (err error) {
 
+	// the source of a retained write-once volume can not be deleted after the copy
+	if err = checkVolumeRetention(grpcDialOption, volumeId, sourceVolumeServer); err != nil {
+		return err
+	}
+
 	log.Printf("copying volume %d from %s to %s", volumeId, sourceVolumeServer, targetVolumeServer)
 	lastAppendAtNs, err := copyVolume(grpcDialOption, writer, volumeId, sourceVolumeServer, targetVolumeServer, diskType, ioBytePerSecond)
 	if err != nil {
@@ -115,6 +120,23 @@ func LiveMoveVolume(grpcDialOption grpc.DialOption, writer io.Writer, volumeId n
 	return nil
 }
 
+// checkVolumeRetention returns an error if the volume is a write-once volume retaining needles
+func checkVolumeRetention(grpcDialOption grpc.DialOption, volumeId needle.VolumeId, volumeServer pb.ServerAddress) error {
+	return operation.WithVolumeServerClient(false, volumeServer, grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+		resp, err := volumeServerClient.ReadVolumeFileStatus(context.Background(), &volume_server_pb.ReadVolumeFileStatusRequest{
+			VolumeId: uint32(volumeId),
+		})
+		if err != nil {
+			return fmt.Errorf("read volume %d status from %s: %v", volumeId, volumeServer, err)
+		}
+		if resp.WormRetainedUntilSeconds > 0 {
+			return fmt.Errorf("write-once volume %d is retained until %v and can not be moved",
+				volumeId, time.Unix(int64(resp.WormRetainedUntilSeconds), 0).Format(time.RFC3339))
+		}
+		return nil
+	})
+}
+
 func copyVolume(grpcDialOption grpc.DialOption, writer io.Writer, volumeId needle.VolumeId, sourceVolumeServer, targetVolumeServer pb.ServerAddress, diskType string, ioBytePerSecond int64) (lastAppendAtNs uint64, err error) {
 
 	// check to see if the volume is already read-only and if its not then we need
@@ -16,6 +16,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/wdclient"
 )
 
@@ -207,6 +208,7 @@ func (rub *replicaUnionBuilder) buildUnionReplica(locations []wdclient.Location,
 
 func (rub *replicaUnionBuilder) readIndexDatabase(db *needle_map.MemDb, server pb.ServerAddress) error {
 	var buf bytes.Buffer
+	var bytesOffset uint32
 
 	err := operation.WithVolumeServerClient(true, server, rub.grpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
 		copyFileClient, err := volumeServerClient.CopyFile(context.Background(), &volume_server_pb.CopyFileRequest{
@@ -230,6 +232,9 @@ func (rub *replicaUnionBuilder) readIndexDatabase(db *needle_map.MemDb, server p
 			if recvErr != nil {
 				return fmt.Errorf("receive: %w", recvErr)
 			}
+			if resp.BytesOffset != 0 {
+				bytesOffset = resp.BytesOffset
+			}
 			buf.Write(resp.FileContent)
 		}
 		return nil
@@ -237,8 +242,12 @@ func (rub *replicaUnionBuilder) readIndexDatabase(db *needle_map.MemDb, server p
 	if err != nil {
 		return err
 	}
+	offsetWidth, err := types.ParseOffsetWidth(bytesOffset)
+	if err != nil {
+		return err
+	}
 
-	return db.LoadFilterFromReaderAt(bytes.NewReader(buf.Bytes()), true, false)
+	return db.LoadFilterFromReaderAtWithWidth(bytes.NewReader(buf.Bytes()), offsetWidth, true, false)
 }
 
 func (rub *replicaUnionBuilder) readNeedleBlob(server pb.ServerAddress, nv needle_map.NeedleValue) ([]byte, error) {
@@ -1,6 +1,9 @@
 package stats
 
-import "net/http"
+import (
+	"io"
+	"net/http"
+)
 
 type StatusRecorder struct {
 	http.ResponseWriter
@@ -19,3 +22,11 @@ func (r *StatusRecorder) WriteHeader(status int) {
 func (r *StatusRecorder) Flush() {
 	r.ResponseWriter.(http.Flusher).Flush()
 }
+
+// ReadFrom keeps the io.ReaderFrom of the wrapped writer, so files can be sent with sendfile
+func (r *StatusRecorder) ReadFrom(src io.Reader) (int64, error) {
+	if readerFrom, ok := r.ResponseWriter.(io.ReaderFrom); ok {
+		return readerFrom.ReadFrom(src)
+	}
+	return io.Copy(r.ResponseWriter, src)
+}
@@ -216,6 +216,14 @@ var (
 			Help:      "The offset of the filer synchronization service.",
 		}, []string{"sourceFiler", "targetFiler", "clientName", "path"})
 
+	FilerSyncConflictCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "filerSync",
+			Name:      "conflict_total",
+			Help:      "Counter of concurrent changes resolved by the filer synchronization service.",
+		}, []string{"sourceFiler", "targetFiler", "policy", "resolution"})
+
 	VolumeServerRequestCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: Namespace,
@@ -346,6 +354,70 @@ var (
 			Help:      "In flight total upload size.",
 		})
 
+	VolumeServerReadCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "read_cache_total",
+			Help:      "Counter of needle read cache lookups and changes, by disk and type of hit, miss, admit or evict.",
+		}, []string{"disk", "type"})
+
+	VolumeServerReadCacheHitRatioGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "read_cache_hit_ratio",
+			Help:      "Hit ratio of the needle read cache since the volume server started.",
+		}, []string{"disk"})
+
+	VolumeServerReadCacheSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "read_cache_size",
+			Help:      "Bytes of needles in the read cache.",
+		}, []string{"disk"})
+
+	VolumeServerReplicaHintCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "replica_hint_total",
+			Help:      "Counter of hints for writes missed by replicas, by type of recorded, replayed, failed or expired.",
+		}, []string{"type"})
+
+	VolumeServerReplicaHintGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "replica_hints",
+			Help:      "Number of hints for writes missed by replicas waiting to be replayed.",
+		})
+
+	VolumeServerCollectionIoCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "collection_io_total",
+			Help:      "Counter of needle reads, writes and deletes by collection.",
+		}, []string{"collection", "type"})
+
+	VolumeServerCollectionIoBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "collection_io_bytes_total",
+			Help:      "Counter of needle bytes read and written by collection.",
+		}, []string{"collection", "type"})
+
+	VolumeServerHotNeedleGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "volumeServer",
+			Name:      "hot_needle_reads",
+			Help:      "Estimated recent reads of the most read needles.",
+		}, []string{"rank", "volume", "needle"})
+
 	S3RequestCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: Namespace,
@@ -458,6 +530,63 @@ var (
 			Name:      "bucket_object_count",
 			Help:      "Current number of objects in each S3 bucket (logical count, deduplicated across replicas).",
 		}, []string{"bucket"})
+
+	MqDelayedMessagesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "mq",
+			Name:      "delayed_messages",
+			Help:      "Number of delayed messages loaded by the broker and waiting to be delivered.",
+		}, []string{"topic", "partition"})
+
+	MqDelayedSegmentsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "mq",
+			Name:      "delayed_segments",
+			Help:      "Number of time bucketed segments holding delayed messages not delivered yet.",
+		}, []string{"topic", "partition"})
+
+	MqDelayedDeliveryLagHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "mq",
+			Name:      "delayed_delivery_lag_seconds",
+			Help:      "Bucketed histogram of how late delayed messages are delivered after their deliver-at time.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20),
+		}, []string{"topic"})
+
+	MqDelayedMessagesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "mq",
+			Name:      "delayed_messages_total",
+			Help:      "Counter of delayed messages by action: scheduled, delivered, or cancelled.",
+		}, []string{"topic", "action"})
+
+	MqMqttConnectionsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "mqtt",
+			Name:      "connections",
+			Help:      "Number of connected MQTT clients.",
+		})
+
+	MqMqttMessagesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "mqtt",
+			Name:      "messages_total",
+			Help:      "Counter of MQTT messages by direction (in or out) and QoS.",
+		}, []string{"direction", "qos"})
+
+	MqSqsRequestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "sqs",
+			Name:      "requests_total",
+			Help:      "Counter of SQS gateway requests by action and HTTP status code.",
+		}, []string{"action", "code"})
 )
 
 func init() {
@@ -483,6 +612,7 @@ func init() {
 	Gather.MustRegister(FilerStoreCounter)
 	Gather.MustRegister(FilerStoreHistogram)
 	Gather.MustRegister(FilerSyncOffsetGauge)
+	Gather.MustRegister(FilerSyncConflictCounter)
 	Gather.MustRegister(FilerServerLastSendTsOfSubscribeGauge)
 	Gather.MustRegister(collectors.NewGoCollector())
 	Gather.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
@@ -503,6 +633,14 @@ func init() {
 	Gather.MustRegister(VolumeServerConcurrentUploadLimit)
 	Gather.MustRegister(VolumeServerInFlightDownloadSize)
 	Gather.MustRegister(VolumeServerInFlightUploadSize)
+	Gather.MustRegister(VolumeServerReadCacheCounter)
+	Gather.MustRegister(VolumeServerReadCacheHitRatioGauge)
+	Gather.MustRegister(VolumeServerReadCacheSizeGauge)
+	Gather.MustRegister(VolumeServerReplicaHintCounter)
+	Gather.MustRegister(VolumeServerReplicaHintGauge)
+	Gather.MustRegister(VolumeServerCollectionIoCounter)
+	Gather.MustRegister(VolumeServerCollectionIoBytesCounter)
+	Gather.MustRegister(VolumeServerHotNeedleGauge)
 
 	Gather.MustRegister(S3RequestCounter)
 	Gather.MustRegister(S3HandlerCounter)
@@ -519,6 +657,14 @@ func init() {
 	Gather.MustRegister(S3BucketPhysicalSizeBytesGauge)
 	Gather.MustRegister(S3BucketObjectCountGauge)
 
+	Gather.MustRegister(MqDelayedMessagesGauge)
+	Gather.MustRegister(MqDelayedSegmentsGauge)
+	Gather.MustRegister(MqDelayedDeliveryLagHistogram)
+	Gather.MustRegister(MqDelayedMessagesCounter)
+	Gather.MustRegister(MqMqttConnectionsGauge)
+	Gather.MustRegister(MqMqttMessagesCounter)
+	Gather.MustRegister(MqSqsRequestsCounter)
+
 	go bucketMetricTTLControl()
 }
 
@@ -581,6 +727,8 @@ func DeleteCollectionMetrics(collection string) {
 	c += VolumeServerDiskSizeGauge.DeletePartialMatch(labels)
 	c += VolumeServerVolumeGauge.DeletePartialMatch(labels)
 	c += VolumeServerReadOnlyVolumeGauge.DeletePartialMatch(labels)
+	c += VolumeServerCollectionIoCounter.DeletePartialMatch(labels)
+	c += VolumeServerCollectionIoBytesCounter.DeletePartialMatch(labels)
 
 	glog.V(0).Infof("delete collection metrics, %s: %d", collection, c)
 }
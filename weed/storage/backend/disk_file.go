@@ -4,6 +4,7 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -16,11 +17,18 @@ var (
 
 const isMac = runtime.GOOS == "darwin"
 
+// maxIdleReaders limits the read only handles kept open for sendfile
+const maxIdleReaders = 16
+
 type DiskFile struct {
 	File         *os.File
 	fullFilePath string
 	fileSize     int64
 	modTime      time.Time
+
+	readersLock   sync.Mutex
+	readers       []*os.File
+	readersClosed bool
 }
 
 func NewDiskFile(f *os.File) *DiskFile {
@@ -87,6 +95,7 @@ func (df *DiskFile) Close() error {
 	if df.File == nil {
 		return nil
 	}
+	df.closeReaders()
 	err := df.Sync()
 	var err1 error
 	if df.File != nil {
@@ -104,6 +113,43 @@ func (df *DiskFile) Close() error {
 	return nil
 }
 
+// AcquireReader returns a read only handle of the file, for sendfile which copies from the file position.
+// The handle serves one reader at a time, and stays open after ReleaseReader for the next one.
+func (df *DiskFile) AcquireReader() (*os.File, error) {
+	df.readersLock.Lock()
+	defer df.readersLock.Unlock()
+	if df.readersClosed {
+		return nil, os.ErrClosed
+	}
+	if n := len(df.readers); n > 0 {
+		f := df.readers[n-1]
+		df.readers = df.readers[:n-1]
+		return f, nil
+	}
+	return os.Open(df.fullFilePath)
+}
+
+// ReleaseReader keeps the handle open for the next reader, or closes it if the file is closed
+func (df *DiskFile) ReleaseReader(f *os.File) {
+	df.readersLock.Lock()
+	defer df.readersLock.Unlock()
+	if df.readersClosed || len(df.readers) >= maxIdleReaders {
+		f.Close()
+		return
+	}
+	df.readers = append(df.readers, f)
+}
+
+func (df *DiskFile) closeReaders() {
+	df.readersLock.Lock()
+	defer df.readersLock.Unlock()
+	for _, f := range df.readers {
+		f.Close()
+	}
+	df.readers = nil
+	df.readersClosed = true
+}
+
 func (df *DiskFile) GetStat() (datSize int64, modTime time.Time, err error) {
 	if df.File == nil {
 		err = os.ErrClosed
@@ -15,6 +15,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
@@ -43,6 +44,9 @@ type DiskLocation struct {
 
 	isDiskSpaceLow bool
 	closeCh        chan struct{}
+
+	// optional cache of needles read from remote tiered volumes and erasure coded volumes
+	readCache *needle_cache.NeedleCache
 }
 
 func GenerateDirUuid(dir string) (dirUuidString string, err error) {
@@ -274,7 +278,7 @@ func (l *DiskLocation) loadExistingVolumesWithId(needleMapKind NeedleMapKind, ld
 func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e error) {
 
 	l.volumesLock.Lock()
-	delVolsMap := l.unmountVolumeByCollection(collection)
+	delVolsMap, retainErr := l.unmountVolumeByCollection(collection)
 	l.volumesLock.Unlock()
 
 	l.ecVolumesLock.Lock()
@@ -282,6 +286,11 @@ func (l *DiskLocation) DeleteCollectionFromDiskLocation(collection string) (e er
 	l.ecVolumesLock.Unlock()
 
 	errChain := make(chan error, 2)
+	if retainErr != nil {
+		// keep the retained volumes mounted, and delete the rest
+		errChain = make(chan error, 3)
+		errChain <- retainErr
+	}
 	var wg sync.WaitGroup
 	wg.Add(2)
 	go func() {
@@ -328,6 +337,7 @@ func (l *DiskLocation) deleteVolumeById(vid needle.VolumeId, onlyEmpty bool) (fo
 	}
 	found = true
 	delete(l.volumes, vid)
+	l.invalidateReadCache(vid)
 	return
 }
 
@@ -365,10 +375,17 @@ func (l *DiskLocation) UnloadVolume(vid needle.VolumeId) error {
 	return nil
 }
 
-func (l *DiskLocation) unmountVolumeByCollection(collectionName string) map[needle.VolumeId]*Volume {
-	deltaVols := make(map[needle.VolumeId]*Volume, 0)
+func (l *DiskLocation) unmountVolumeByCollection(collectionName string) (deltaVols map[needle.VolumeId]*Volume, retainErr error) {
+	deltaVols = make(map[needle.VolumeId]*Volume, 0)
 	for k, v := range l.volumes {
 		if v.Collection == collectionName && !v.isCompacting && !v.isCommitCompacting {
+			v.dataFileAccessLock.RLock()
+			err := v.checkWormRetention()
+			v.dataFileAccessLock.RUnlock()
+			if err != nil {
+				retainErr = err
+				continue
+			}
 			deltaVols[k] = v
 		}
 	}
@@ -376,7 +393,7 @@ func (l *DiskLocation) unmountVolumeByCollection(collectionName string) map[need
 	for k := range deltaVols {
 		delete(l.volumes, k)
 	}
-	return deltaVols
+	return
 }
 
 func (l *DiskLocation) SetVolume(vid needle.VolumeId, volume *Volume) {
@@ -438,6 +455,10 @@ func (l *DiskLocation) Close() {
 	}
 	l.ecVolumesLock.Unlock()
 
+	if l.readCache != nil {
+		l.readCache.Shutdown()
+	}
+
 	close(l.closeCh)
 	return
 }
@@ -40,6 +40,7 @@ func (l *DiskLocation) DestroyEcVolume(vid needle.VolumeId) {
 	if found {
 		ecVolume.Destroy()
 		delete(l.ecVolumes, vid)
+		l.invalidateReadCache(vid)
 	}
 }
 
@@ -163,6 +164,9 @@ func (l *DiskLocation) loadEcShards(shards []string, collection string, vid need
 
 func (l *DiskLocation) loadAllEcShards(onShardLoad func(collection string, vid needle.VolumeId, shardId erasure_coding.ShardId, ecVolume *erasure_coding.EcVolume)) (err error) {
 
+	// an interrupted vacuum commit may have renamed the shards, so recover it before listing them
+	l.recoverEcVacuums()
+
 	dirEntries, err := os.ReadDir(l.Directory)
 	if err != nil {
 		return fmt.Errorf("load all ec shards in dir %s: %v", l.Directory, err)
@@ -239,6 +243,29 @@ func (l *DiskLocation) loadAllEcShards(onShardLoad func(collection string, vid n
 	return nil
 }
 
+// recoverEcVacuums resolves the ec vacuum commit markers left by an interrupted vacuum
+func (l *DiskLocation) recoverEcVacuums() {
+	dirEntries, err := os.ReadDir(l.IdxDirectory)
+	if err != nil {
+		return
+	}
+	for _, fileInfo := range dirEntries {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || path.Ext(name) != erasure_coding.EcVacuumCommitExt {
+			continue
+		}
+		collection, volumeId, err := parseCollectionVolumeId(name[:len(name)-len(erasure_coding.EcVacuumCommitExt)])
+		if err != nil {
+			continue
+		}
+		dataBaseFileName := erasure_coding.EcShardFileName(collection, l.Directory, int(volumeId))
+		indexBaseFileName := erasure_coding.EcShardFileName(collection, l.IdxDirectory, int(volumeId))
+		if err = erasure_coding.RecoverEcVacuum(dataBaseFileName, indexBaseFileName); err != nil {
+			glog.Errorf("recover ec volume %d vacuum in %s: %v", volumeId, l.IdxDirectory, err)
+		}
+	}
+}
+
 func (l *DiskLocation) deleteEcVolumeById(vid needle.VolumeId) (e error) {
 	// Add write lock since we're modifying the ecVolumes map
 	l.ecVolumesLock.Lock()
@@ -250,6 +277,7 @@ func (l *DiskLocation) deleteEcVolumeById(vid needle.VolumeId) (e error) {
 	}
 	ecVolume.Destroy()
 	delete(l.ecVolumes, vid)
+	l.invalidateReadCache(vid)
 	return
 }
 
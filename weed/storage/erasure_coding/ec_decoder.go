@@ -20,8 +20,8 @@ const EcNoLiveEntriesSubstring = "has no live entries"
 
 // HasLiveNeedles returns whether the EC index (.ecx) contains at least one live (non-deleted) entry.
 // This is used by ec.decode to avoid generating an empty normal volume when all entries were deleted.
-func HasLiveNeedles(indexBaseFileName string) (hasLive bool, err error) {
-	err = iterateEcxFile(indexBaseFileName, func(_ types.NeedleId, _ types.Offset, size types.Size) error {
+func HasLiveNeedles(indexBaseFileName string, offsetWidth types.OffsetWidth) (hasLive bool, err error) {
+	err = iterateEcxFile(indexBaseFileName, offsetWidth, func(_ types.NeedleId, _ types.Offset, size types.Size) error {
 		if !size.IsDeleted() {
 			hasLive = true
 			return io.EOF // stop early
@@ -32,7 +32,7 @@ func HasLiveNeedles(indexBaseFileName string) (hasLive bool, err error) {
 }
 
 // write .idx file from .ecx and .ecj files
-func WriteIdxFileFromEcIndex(baseFileName string) (err error) {
+func WriteIdxFileFromEcIndex(baseFileName string, offsetWidth types.OffsetWidth) (err error) {
 
 	ecxFile, openErr := os.OpenFile(baseFileName+".ecx", os.O_RDONLY, 0644)
 	if openErr != nil {
@@ -50,7 +50,7 @@ func WriteIdxFileFromEcIndex(baseFileName string) (err error) {
 
 	err = iterateEcjFile(baseFileName, func(key types.NeedleId) error {
 
-		bytes := needle_map.ToBytes(key, types.Offset{}, types.TombstoneFileSize)
+		bytes := needle_map.ToBytesWithWidth(offsetWidth, key, types.Offset{}, types.TombstoneFileSize)
 		idxFile.Write(bytes)
 
 		return nil
@@ -62,7 +62,7 @@ func WriteIdxFileFromEcIndex(baseFileName string) (err error) {
 // FindDatFileSize calculate .dat file size from max offset entry
 // there may be extra deletions after that entry
 // but they are deletions anyway
-func FindDatFileSize(dataBaseFileName, indexBaseFileName string) (datSize int64, err error) {
+func FindDatFileSize(dataBaseFileName, indexBaseFileName string, offsetWidth types.OffsetWidth) (datSize int64, err error) {
 
 	version, err := readEcVolumeVersion(dataBaseFileName)
 	if err != nil {
@@ -74,7 +74,7 @@ func FindDatFileSize(dataBaseFileName, indexBaseFileName string) (datSize int64,
 	// when all needles are deleted (see issue #7748).
 	datSize = int64(super_block.SuperBlockSize)
 
-	err = iterateEcxFile(indexBaseFileName, func(key types.NeedleId, offset types.Offset, size types.Size) error {
+	err = iterateEcxFile(indexBaseFileName, offsetWidth, func(key types.NeedleId, offset types.Offset, size types.Size) error {
 
 		if size.IsDeleted() {
 			return nil
@@ -110,23 +110,23 @@ func readEcVolumeVersion(baseFileName string) (version needle.Version, err error
 
 }
 
-func iterateEcxFile(baseFileName string, processNeedleFn func(key types.NeedleId, offset types.Offset, size types.Size) error) error {
+func iterateEcxFile(baseFileName string, offsetWidth types.OffsetWidth, processNeedleFn func(key types.NeedleId, offset types.Offset, size types.Size) error) error {
 	ecxFile, openErr := os.OpenFile(baseFileName+".ecx", os.O_RDONLY, 0644)
 	if openErr != nil {
 		return fmt.Errorf("cannot open ec index %s.ecx: %v", baseFileName, openErr)
 	}
 	defer ecxFile.Close()
 
-	buf := make([]byte, types.NeedleMapEntrySize)
+	buf := make([]byte, offsetWidth.EntrySize())
 	for {
 		n, err := ecxFile.Read(buf)
-		if n != types.NeedleMapEntrySize {
+		if n != len(buf) {
 			if err == io.EOF {
 				return nil
 			}
 			return err
 		}
-		key, offset, size := idx.IdxFileEntry(buf)
+		key, offset, size := idx.IdxFileEntryWithWidth(offsetWidth, buf)
 		if processNeedleFn != nil {
 			err = processNeedleFn(key, offset, size)
 		}
@@ -23,7 +23,7 @@ func TestHasLiveNeedles_AllDeletedIsFalse(t *testing.T) {
 		t.Fatalf("write ecx: %v", err)
 	}
 
-	hasLive, err := erasure_coding.HasLiveNeedles(base)
+	hasLive, err := erasure_coding.HasLiveNeedles(base, types.DefaultOffsetWidth)
 	if err != nil {
 		t.Fatalf("HasLiveNeedles: %v", err)
 	}
@@ -45,7 +45,7 @@ func TestHasLiveNeedles_WithLiveEntryIsTrue(t *testing.T) {
 		t.Fatalf("write ecx: %v", err)
 	}
 
-	hasLive, err := erasure_coding.HasLiveNeedles(base)
+	hasLive, err := erasure_coding.HasLiveNeedles(base, types.DefaultOffsetWidth)
 	if err != nil {
 		t.Fatalf("HasLiveNeedles: %v", err)
 	}
@@ -64,7 +64,7 @@ func TestHasLiveNeedles_EmptyFileIsFalse(t *testing.T) {
 		t.Fatalf("write ecx: %v", err)
 	}
 
-	hasLive, err := erasure_coding.HasLiveNeedles(base)
+	hasLive, err := erasure_coding.HasLiveNeedles(base, types.DefaultOffsetWidth)
 	if err != nil {
 		t.Fatalf("HasLiveNeedles: %v", err)
 	}
@@ -99,7 +99,7 @@ func TestWriteIdxFileFromEcIndex_PreservesDeletedNeedles(t *testing.T) {
 	}
 
 	// Generate .idx from .ecx
-	if err := erasure_coding.WriteIdxFileFromEcIndex(base); err != nil {
+	if err := erasure_coding.WriteIdxFileFromEcIndex(base, types.DefaultOffsetWidth); err != nil {
 		t.Fatalf("WriteIdxFileFromEcIndex: %v", err)
 	}
 
@@ -145,7 +145,7 @@ func TestWriteIdxFileFromEcIndex_ProcessesEcjJournal(t *testing.T) {
 	}
 
 	// Generate .idx from .ecx and .ecj
-	if err := erasure_coding.WriteIdxFileFromEcIndex(base); err != nil {
+	if err := erasure_coding.WriteIdxFileFromEcIndex(base, types.DefaultOffsetWidth); err != nil {
 		t.Fatalf("WriteIdxFileFromEcIndex: %v", err)
 	}
 
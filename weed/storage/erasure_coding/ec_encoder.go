@@ -28,8 +28,13 @@ const (
 // WriteSortedFileFromIdx generates .ecx file from existing .idx file
 // all keys are sorted in ascending order
 func WriteSortedFileFromIdx(baseFileName string, ext string) (e error) {
+	return WriteSortedFileFromIdxWithWidth(baseFileName, ext, types.DefaultOffsetWidth)
+}
+
+// WriteSortedFileFromIdxWithWidth writes the sorted index of a volume with the offset width
+func WriteSortedFileFromIdxWithWidth(baseFileName string, ext string, offsetWidth types.OffsetWidth) (e error) {
 
-	nm, err := readNeedleMap(baseFileName)
+	nm, err := readNeedleMap(baseFileName, offsetWidth)
 	if nm != nil {
 		defer nm.Close()
 	}
@@ -44,7 +49,7 @@ func WriteSortedFileFromIdx(baseFileName string, ext string) (e error) {
 	defer ecxFile.Close()
 
 	err = nm.AscendingVisit(func(value needle_map.NeedleValue) error {
-		bytes := value.ToBytes()
+		bytes := value.ToBytesWithWidth(offsetWidth)
 		_, writeErr := ecxFile.Write(bytes)
 		return writeErr
 	})
@@ -330,15 +335,15 @@ func rebuildEcFiles(shardHasData []bool, inputFiles []*os.File, outputFiles []*o
 
 }
 
-func readNeedleMap(baseFileName string) (*needle_map.MemDb, error) {
+func readNeedleMap(baseFileName string, offsetWidth types.OffsetWidth) (*needle_map.MemDb, error) {
 	indexFile, err := os.OpenFile(baseFileName+".idx", os.O_RDONLY, 0644)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read Volume Index %s.idx: %v", baseFileName, err)
 	}
 	defer indexFile.Close()
 
-	cm := needle_map.NewMemDb()
-	err = idx.WalkIndexFile(indexFile, 0, func(key types.NeedleId, offset types.Offset, size types.Size) error {
+	cm := needle_map.NewMemDbWithOffsetWidth(offsetWidth)
+	err = idx.WalkIndexFileWithWidth(indexFile, offsetWidth, 0, func(key types.NeedleId, offset types.Offset, size types.Size) error {
 		if !offset.IsZero() && !size.IsDeleted() {
 			cm.Set(key, offset, size)
 		} else {
@@ -47,7 +47,7 @@ func TestEncodingDecoding(t *testing.T) {
 }
 
 func validateFiles(baseFileName string, ctx *ECContext) error {
-	nm, err := readNeedleMap(baseFileName)
+	nm, err := readNeedleMap(baseFileName, types.DefaultOffsetWidth)
 	if err != nil {
 		return fmt.Errorf("readNeedleMap: %v", err)
 	}
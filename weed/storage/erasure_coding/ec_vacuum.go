@@ -0,0 +1,590 @@
+package erasure_coding
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// Vacuum of erasure coded volumes
+//
+// Deleting from an ec volume only marks the needle in the .ecx file and appends it to the .ecj file,
+// so the deleted bytes stay in the shards. A vacuum rewrites the shards as if the volume had been
+// compacted before it was encoded: the live needles follow the super block in their original order.
+//
+// Each server holding shards of the volume generates its own new shards, reading the live data from
+// the old shards, local or remote. All servers must compute the same layout, so the live needles are
+// snapshot once into the .ecv file on one server, and copied to the others.
+// The new files are written next to the old ones with the .vac suffix, and swapped in on commit.
+// The old files are kept until every server has committed, so a partly failed commit can be rolled back.
+
+const (
+	EcVacuumExt       = ".vac"  // suffix of the files generated by a vacuum, e.g. 1.ec03.vac
+	EcVacuumIndexExt  = ".ecv"  // the live needles when the vacuum started, sorted by needle id
+	EcVacuumCommitExt = ".ecvc" // the commit marker, see CommitEcVacuum
+	EcVacuumBackupExt = ".bak"  // suffix of the files replaced by a vacuum, until it is finalized
+
+	ecVacuumCommitting = "committing" // the files are being swapped
+	ecVacuumCommitted  = "committed"  // the files are swapped, the old files are kept until every server commits
+	ecVacuumFinalizing = "finalizing" // the old files are being removed
+
+	// runs of live data separated by less garbage than this are read together, up to ecVacuumMaxReadSize
+	ecVacuumReadGap     = 64 * 1024
+	ecVacuumMaxReadSize = 4 * 1024 * 1024
+)
+
+// EcDatReader reads a range of the .dat file an ec volume was encoded from.
+type EcDatReader func(buf []byte, offset int64) error
+
+type ecVacuumNeedle struct {
+	key       types.NeedleId
+	size      types.Size
+	oldOffset int64
+	newOffset int64
+}
+
+// ecVacuumRun is a range of the old .dat file copied unchanged to the new .dat file.
+type ecVacuumRun struct {
+	oldOffset int64
+	newOffset int64
+	length    int64
+}
+
+// EcVacuumPlan maps the .dat file of a vacuumed ec volume to the .dat file it was encoded from.
+// It is not safe for concurrent use.
+type EcVacuumPlan struct {
+	NewDatFileSize  int64
+	LiveNeedleCount int
+	superBlock      []byte           // the new super block, with the compaction revision increased
+	needles         []ecVacuumNeedle // sorted by offset
+	runs            []ecVacuumRun    // sorted by offset
+	scratch         []byte
+	offsetWidth     types.OffsetWidth // of the .ecv and .ecx files
+}
+
+// WriteEcVacuumIndex snapshots the live needles of the .ecx and .ecj files into the .ecv file.
+func WriteEcVacuumIndex(indexBaseFileName string, offsetWidth types.OffsetWidth) error {
+	deleted := make(map[types.NeedleId]struct{})
+	if err := iterateEcjFile(indexBaseFileName, func(key types.NeedleId) error {
+		deleted[key] = struct{}{}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("read %s.ecj: %w", indexBaseFileName, err)
+	}
+
+	ecvFile, err := os.OpenFile(indexBaseFileName+EcVacuumIndexExt, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open ecv file: %w", err)
+	}
+	defer ecvFile.Close()
+	writer := bufio.NewWriter(ecvFile)
+
+	err = iterateEcxFile(indexBaseFileName, offsetWidth, func(key types.NeedleId, offset types.Offset, size types.Size) error {
+		if _, found := deleted[key]; found || size.IsDeleted() || offset.IsZero() {
+			return nil
+		}
+		_, writeErr := writer.Write(needle_map.ToBytesWithWidth(offsetWidth, key, offset, size))
+		return writeErr
+	})
+	if err != nil {
+		return fmt.Errorf("snapshot %s.ecx: %w", indexBaseFileName, err)
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	return ecvFile.Sync()
+}
+
+// NewEcVacuumPlan lays out the live needles of the .ecv file after the super block of the volume.
+func NewEcVacuumPlan(indexBaseFileName string, offsetWidth types.OffsetWidth, readOld EcDatReader) (*EcVacuumPlan, error) {
+	header := make([]byte, super_block.SuperBlockSize)
+	if err := readOld(header, 0); err != nil {
+		return nil, fmt.Errorf("read super block: %w", err)
+	}
+	version := needle.Version(header[0])
+	superBlock := make([]byte, super_block.PaddedBlockSize(int(util.BytesToUint16(header[6:8]))))
+	copy(superBlock, header)
+	if len(superBlock) > len(header) {
+		if err := readOld(superBlock, 0); err != nil {
+			return nil, fmt.Errorf("read super block extra: %w", err)
+		}
+	}
+	util.Uint16toBytes(superBlock[4:6], util.BytesToUint16(superBlock[4:6])+1)
+
+	p := &EcVacuumPlan{superBlock: superBlock, offsetWidth: offsetWidth}
+
+	ecvFile, err := os.OpenFile(indexBaseFileName+EcVacuumIndexExt, os.O_RDONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open ec vacuum index %s%s: %v", indexBaseFileName, EcVacuumIndexExt, err)
+	}
+	defer ecvFile.Close()
+	reader := bufio.NewReader(ecvFile)
+	buf := make([]byte, offsetWidth.EntrySize())
+	for {
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("read %s: %w", ecvFile.Name(), err)
+		}
+		key, offset, size := idx.IdxFileEntryWithWidth(offsetWidth, buf)
+		p.needles = append(p.needles, ecVacuumNeedle{key: key, size: size, oldOffset: offset.ToActualOffset()})
+	}
+	slices.SortFunc(p.needles, func(a, b ecVacuumNeedle) int {
+		return cmp.Compare(a.oldOffset, b.oldOffset)
+	})
+
+	newOffset := (int64(len(superBlock)) + types.NeedlePaddingSize - 1) / types.NeedlePaddingSize * types.NeedlePaddingSize
+	for i := range p.needles {
+		n := &p.needles[i]
+		n.newOffset = newOffset
+		actualSize := needle.GetActualSize(n.size, version)
+		if last := len(p.runs) - 1; last >= 0 && p.runs[last].oldOffset+p.runs[last].length == n.oldOffset {
+			p.runs[last].length += actualSize
+		} else {
+			p.runs = append(p.runs, ecVacuumRun{oldOffset: n.oldOffset, newOffset: newOffset, length: actualSize})
+		}
+		newOffset += actualSize
+	}
+	p.NewDatFileSize = newOffset
+	p.LiveNeedleCount = len(p.needles)
+
+	return p, nil
+}
+
+// readNewDat reads a range of the new .dat file, zero filled past its end.
+func (p *EcVacuumPlan) readNewDat(buf []byte, offset int64, readOld EcDatReader) error {
+	clear(buf)
+	if offset < int64(len(p.superBlock)) {
+		copy(buf, p.superBlock[offset:])
+	}
+
+	end := offset + int64(len(buf))
+	i := sort.Search(len(p.runs), func(i int) bool {
+		return p.runs[i].newOffset+p.runs[i].length > offset
+	})
+	for i < len(p.runs) && p.runs[i].newOffset < end {
+		// read the runs close to each other in the old .dat file together
+		j := i + 1
+		for j < len(p.runs) && p.runs[j].newOffset < end &&
+			p.runs[j].oldOffset-(p.runs[j-1].oldOffset+p.runs[j-1].length) <= ecVacuumReadGap &&
+			p.runs[j].oldOffset-p.runs[i].oldOffset <= ecVacuumMaxReadSize {
+			j++
+		}
+		first, last := p.runs[i], p.runs[j-1]
+		from := max(offset, first.newOffset)
+		to := min(end, last.newOffset+last.length)
+		oldFrom := first.oldOffset + from - first.newOffset
+		oldTo := last.oldOffset + to - last.newOffset
+
+		if j == i+1 {
+			if err := readOld(buf[from-offset:to-offset], oldFrom); err != nil {
+				return err
+			}
+		} else {
+			if int64(cap(p.scratch)) < oldTo-oldFrom {
+				p.scratch = make([]byte, oldTo-oldFrom)
+			}
+			scratch := p.scratch[:oldTo-oldFrom]
+			if err := readOld(scratch, oldFrom); err != nil {
+				return err
+			}
+			for _, r := range p.runs[i:j] {
+				runFrom := max(offset, r.newOffset)
+				runTo := min(end, r.newOffset+r.length)
+				oldRunFrom := r.oldOffset + runFrom - r.newOffset - oldFrom
+				copy(buf[runFrom-offset:runTo-offset], scratch[oldRunFrom:])
+			}
+		}
+		i = j
+	}
+	return nil
+}
+
+// Generate writes the new shards with the given ids, the new .ecx file, and the new .vif file.
+func (p *EcVacuumPlan) Generate(dataBaseFileName, indexBaseFileName string, shardIds []ShardId, ctx *ECContext, readOld EcDatReader) error {
+	if err := p.writeShards(dataBaseFileName, shardIds, 256*1024, ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, ctx, readOld); err != nil {
+		return fmt.Errorf("write shards: %w", err)
+	}
+	if err := p.writeEcx(indexBaseFileName + ".ecx" + EcVacuumExt); err != nil {
+		return fmt.Errorf("write ecx: %w", err)
+	}
+
+	volumeInfo, _, _, err := volume_info.MaybeLoadVolumeInfo(dataBaseFileName + ".vif")
+	if err != nil {
+		return fmt.Errorf("load %s.vif: %w", dataBaseFileName, err)
+	}
+	volumeInfo.DatFileSize = p.NewDatFileSize
+	if err := volume_info.SaveVolumeInfo(dataBaseFileName+".vif"+EcVacuumExt, volumeInfo); err != nil {
+		return fmt.Errorf("save vif: %w", err)
+	}
+	return nil
+}
+
+// writeShards encodes the new .dat file the same way as encodeDatFile, but only writes the given shards.
+// The data shards only need their own blocks of the new .dat file, while parity shards need all of them.
+func (p *EcVacuumPlan) writeShards(baseFileName string, shardIds []ShardId, bufferSize int, largeBlockSize int64, smallBlockSize int64, ctx *ECContext, readOld EcDatReader) error {
+	enc, err := ctx.CreateEncoder()
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %w", err)
+	}
+
+	outputs := make([]*os.File, ctx.Total())
+	writers := make([]*bufio.Writer, ctx.Total())
+	defer closeEcFiles(outputs)
+	needParity := false
+	for _, shardId := range shardIds {
+		if int(shardId) >= ctx.Total() {
+			return fmt.Errorf("invalid shard id %d", shardId)
+		}
+		fileName := baseFileName + ctx.ToExt(int(shardId)) + EcVacuumExt
+		if outputs[shardId], err = os.OpenFile(fileName, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return fmt.Errorf("failed to open file %s: %v", fileName, err)
+		}
+		writers[shardId] = bufio.NewWriterSize(outputs[shardId], bufferSize)
+		if int(shardId) >= ctx.DataShards {
+			needParity = true
+		}
+	}
+
+	buffers := make([][]byte, ctx.Total())
+	for i := range buffers {
+		buffers[i] = make([]byte, bufferSize)
+	}
+
+	encodeRow := func(startOffset, blockSize int64) error {
+		if blockSize%int64(bufferSize) != 0 {
+			return fmt.Errorf("unexpected block size %d buffer size %d", blockSize, bufferSize)
+		}
+		for b := int64(0); b < blockSize/int64(bufferSize); b++ {
+			batchOffset := startOffset + b*int64(bufferSize)
+			for i := 0; i < ctx.DataShards; i++ {
+				if needParity || writers[i] != nil {
+					if err := p.readNewDat(buffers[i], batchOffset+blockSize*int64(i), readOld); err != nil {
+						return err
+					}
+				}
+			}
+			if needParity {
+				if err := enc.Encode(buffers); err != nil {
+					return err
+				}
+			}
+			for i, w := range writers {
+				if w != nil {
+					if _, err := w.Write(buffers[i]); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	remainingSize, processedSize := p.NewDatFileSize, int64(0)
+	largeRowSize := largeBlockSize * int64(ctx.DataShards)
+	smallRowSize := smallBlockSize * int64(ctx.DataShards)
+	for remainingSize >= largeRowSize {
+		if err := encodeRow(processedSize, largeBlockSize); err != nil {
+			return fmt.Errorf("failed to encode large chunk data: %w", err)
+		}
+		remainingSize -= largeRowSize
+		processedSize += largeRowSize
+	}
+	for remainingSize > 0 {
+		if err := encodeRow(processedSize, smallBlockSize); err != nil {
+			return fmt.Errorf("failed to encode small chunk data: %w", err)
+		}
+		remainingSize -= smallRowSize
+		processedSize += smallRowSize
+	}
+
+	for i, w := range writers {
+		if w == nil {
+			continue
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if err := outputs[i].Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEcx writes the live needles at their new offsets, sorted by needle id.
+func (p *EcVacuumPlan) writeEcx(fileName string) error {
+	sorted := slices.Clone(p.needles)
+	slices.SortFunc(sorted, func(a, b ecVacuumNeedle) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	ecxFile, err := os.OpenFile(fileName, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer ecxFile.Close()
+	writer := bufio.NewWriter(ecxFile)
+	for _, n := range sorted {
+		if _, err := writer.Write(needle_map.ToBytesWithWidth(p.offsetWidth, n.key, types.ToOffset(n.newOffset), n.size)); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return ecxFile.Sync()
+}
+
+// CommitEcVacuum replaces the shards, the .ecx, .ecj and .vif files with the ones generated by a vacuum.
+// The needles deleted since the .ecv snapshot are deleted again from the new .ecx file.
+// The old files are kept with the .bak suffix, until FinalizeEcVacuum or RollbackEcVacuum.
+// The commit marker lists the swapped files, so an interrupted commit is rolled back on load.
+// The shards must be unmounted.
+func CommitEcVacuum(dataBaseFileName, indexBaseFileName string, offsetWidth types.OffsetWidth) error {
+	if util.FileExists(indexBaseFileName + EcVacuumCommitExt) {
+		return fmt.Errorf("%s%s: previous vacuum is not finalized", indexBaseFileName, EcVacuumCommitExt)
+	}
+	var fileNames []string
+	for i := 0; i < MaxShardCount; i++ {
+		fileName := dataBaseFileName + ToExt(i)
+		if !util.FileExists(fileName) {
+			continue
+		}
+		if !util.FileExists(fileName + EcVacuumExt) {
+			return fmt.Errorf("missing vacuumed shard %s%s", fileName, EcVacuumExt)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	for _, fileName := range []string{indexBaseFileName + ".ecx", dataBaseFileName + ".vif"} {
+		if !util.FileExists(fileName + EcVacuumExt) {
+			return fmt.Errorf("missing vacuumed %s%s", fileName, EcVacuumExt)
+		}
+	}
+
+	if err := reapplyEcDeletions(indexBaseFileName, offsetWidth); err != nil {
+		return fmt.Errorf("reapply deletions: %w", err)
+	}
+	fileNames = append(fileNames, indexBaseFileName+".ecx", indexBaseFileName+".ecj", dataBaseFileName+".vif")
+
+	if err := writeEcVacuumCommit(indexBaseFileName, ecVacuumCommitting, fileNames); err != nil {
+		return fmt.Errorf("write commit marker: %w", err)
+	}
+	for _, fileName := range fileNames {
+		if err := os.Rename(fileName, fileName+EcVacuumBackupExt); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			// no .ecj file is the same as an empty one
+			if err = os.WriteFile(fileName+EcVacuumBackupExt, nil, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fileName := range fileNames {
+		if err := os.Rename(fileName+EcVacuumExt, fileName); err != nil {
+			return err
+		}
+	}
+	if err := writeEcVacuumCommit(indexBaseFileName, ecVacuumCommitted, fileNames); err != nil {
+		return fmt.Errorf("write commit marker: %w", err)
+	}
+	return nil
+}
+
+// RollbackEcVacuum restores the files swapped by CommitEcVacuum, even if the commit was interrupted,
+// and removes the files of the vacuum. The shards must be unmounted.
+func RollbackEcVacuum(dataBaseFileName, indexBaseFileName string) error {
+	state, fileNames, err := readEcVacuumCommit(indexBaseFileName)
+	if err != nil {
+		return err
+	}
+	if state == ecVacuumFinalizing {
+		return fmt.Errorf("%s%s: vacuum is being finalized", indexBaseFileName, EcVacuumCommitExt)
+	}
+	for _, fileName := range fileNames {
+		if !util.FileExists(fileName + EcVacuumBackupExt) {
+			// not swapped yet
+			continue
+		}
+		if err = os.Rename(fileName+EcVacuumBackupExt, fileName); err != nil {
+			return err
+		}
+	}
+	CleanupEcVacuum(dataBaseFileName, indexBaseFileName)
+	return removeEcVacuumCommit(indexBaseFileName)
+}
+
+// FinalizeEcVacuum removes the old files kept by CommitEcVacuum, once every server has committed.
+func FinalizeEcVacuum(dataBaseFileName, indexBaseFileName string) error {
+	state, fileNames, err := readEcVacuumCommit(indexBaseFileName)
+	if err != nil {
+		return err
+	}
+	if state == "" {
+		return fmt.Errorf("%s: vacuum is not committed", indexBaseFileName)
+	}
+	if state == ecVacuumCommitting {
+		return fmt.Errorf("%s: vacuum commit is not complete", indexBaseFileName)
+	}
+	if state == ecVacuumCommitted {
+		// from here on the backups are partly removed, and the vacuum can not be rolled back
+		if err = writeEcVacuumCommit(indexBaseFileName, ecVacuumFinalizing, fileNames); err != nil {
+			return fmt.Errorf("write commit marker: %w", err)
+		}
+	}
+	for _, fileName := range fileNames {
+		if err = os.Remove(fileName + EcVacuumBackupExt); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	os.Remove(indexBaseFileName + EcVacuumIndexExt)
+	return removeEcVacuumCommit(indexBaseFileName)
+}
+
+// RecoverEcVacuum resolves the vacuum commit marker found when loading the volume:
+// an interrupted commit is rolled back, and an interrupted finalize is completed.
+// A complete commit waits for the task to finalize or roll it back.
+func RecoverEcVacuum(dataBaseFileName, indexBaseFileName string) error {
+	state, _, err := readEcVacuumCommit(indexBaseFileName)
+	if err != nil {
+		return err
+	}
+	switch state {
+	case ecVacuumCommitting:
+		glog.Warningf("roll back interrupted ec vacuum commit of %s", indexBaseFileName)
+		return RollbackEcVacuum(dataBaseFileName, indexBaseFileName)
+	case ecVacuumFinalizing:
+		glog.V(0).Infof("finalize interrupted ec vacuum of %s", indexBaseFileName)
+		return FinalizeEcVacuum(dataBaseFileName, indexBaseFileName)
+	case ecVacuumCommitted:
+		glog.V(0).Infof("ec vacuum of %s is committed and waits to be finalized", indexBaseFileName)
+	}
+	return nil
+}
+
+// writeEcVacuumCommit atomically writes the commit marker: the state, then one swapped file per line
+func writeEcVacuumCommit(indexBaseFileName string, state string, fileNames []string) error {
+	markerFileName := indexBaseFileName + EcVacuumCommitExt
+	content := state + "\n" + strings.Join(fileNames, "\n") + "\n"
+	tmpFileName := markerFileName + ".tmp"
+	f, err := os.OpenFile(tmpFileName, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.WriteString(content); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFileName)
+		return err
+	}
+	return os.Rename(tmpFileName, markerFileName)
+}
+
+// readEcVacuumCommit returns an empty state if there is no commit marker
+func readEcVacuumCommit(indexBaseFileName string) (state string, fileNames []string, err error) {
+	content, err := os.ReadFile(indexBaseFileName + EcVacuumCommitExt)
+	if os.IsNotExist(err) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	state, fileNames = lines[0], lines[1:]
+	if state != ecVacuumCommitting && state != ecVacuumCommitted && state != ecVacuumFinalizing {
+		return "", nil, fmt.Errorf("%s%s: unknown state %q", indexBaseFileName, EcVacuumCommitExt, state)
+	}
+	return state, fileNames, nil
+}
+
+func removeEcVacuumCommit(indexBaseFileName string) error {
+	if err := os.Remove(indexBaseFileName + EcVacuumCommitExt); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// reapplyEcDeletions marks the needles deleted in the current .ecx and .ecj files as deleted
+// in the new .ecx file, and journals them in the new .ecj file.
+func reapplyEcDeletions(indexBaseFileName string, offsetWidth types.OffsetWidth) error {
+	var deleted []types.NeedleId
+	if err := iterateEcxFile(indexBaseFileName, offsetWidth, func(key types.NeedleId, offset types.Offset, size types.Size) error {
+		if size.IsDeleted() {
+			deleted = append(deleted, key)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := iterateEcjFile(indexBaseFileName, func(key types.NeedleId) error {
+		deleted = append(deleted, key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	ecxFile, err := os.OpenFile(indexBaseFileName+".ecx"+EcVacuumExt, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer ecxFile.Close()
+	fi, err := ecxFile.Stat()
+	if err != nil {
+		return err
+	}
+	ecjFile, err := os.OpenFile(indexBaseFileName+".ecj"+EcVacuumExt, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer ecjFile.Close()
+
+	b := make([]byte, types.NeedleIdSize)
+	for _, key := range deleted {
+		_, size, err := SearchNeedleFromSortedIndexWithWidth(ecxFile, fi.Size(), offsetWidth, key, MarkNeedleDeletedWithWidth(offsetWidth))
+		if err == NotFoundError || size.IsDeleted() {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		types.NeedleIdToBytes(b, key)
+		if _, err = ecjFile.Write(b); err != nil {
+			return err
+		}
+	}
+	if err = ecxFile.Sync(); err != nil {
+		return err
+	}
+	return ecjFile.Sync()
+}
+
+// CleanupEcVacuum removes the files of an unfinished vacuum.
+func CleanupEcVacuum(dataBaseFileName, indexBaseFileName string) {
+	for i := 0; i < MaxShardCount; i++ {
+		os.Remove(dataBaseFileName + ToExt(i) + EcVacuumExt)
+	}
+	os.Remove(indexBaseFileName + ".ecx" + EcVacuumExt)
+	os.Remove(indexBaseFileName + ".ecj" + EcVacuumExt)
+	os.Remove(indexBaseFileName + EcVacuumIndexExt)
+	os.Remove(dataBaseFileName + ".vif" + EcVacuumExt)
+}
@@ -0,0 +1,349 @@
+package erasure_coding
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func copyTestFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("read %s: %v", src, err)
+	}
+	if err = os.WriteFile(dst, data, 0644); err != nil {
+		t.Fatalf("write %s: %v", dst, err)
+	}
+}
+
+func readEcxEntries(t *testing.T, fileName string) map[types.NeedleId][2]int64 {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("read %s: %v", fileName, err)
+	}
+	entries := make(map[types.NeedleId][2]int64)
+	for i := 0; i+types.NeedleMapEntrySize <= len(data); i += types.NeedleMapEntrySize {
+		key, offset, size := idx.IdxFileEntry(data[i : i+types.NeedleMapEntrySize])
+		entries[key] = [2]int64{offset.ToActualOffset(), int64(size)}
+	}
+	return entries
+}
+
+func TestEcVacuum(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "1")
+	copyTestFile(t, "1.dat", base+".dat")
+	copyTestFile(t, "1.idx", base+".idx")
+	ctx := NewDefaultECContext("", 1)
+
+	if err := generateEcFiles(base, 50, largeBlockSize, smallBlockSize, ctx); err != nil {
+		t.Fatalf("generateEcFiles: %v", err)
+	}
+	if err := WriteSortedFileFromIdx(base, ".ecx"); err != nil {
+		t.Fatalf("WriteSortedFileFromIdx: %v", err)
+	}
+	datFile, err := os.Open(base + ".dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datFile.Close()
+	fi, _ := datFile.Stat()
+	if err = volume_info.SaveVolumeInfo(base+".vif", &volume_server_pb.VolumeInfo{Version: uint32(needle.Version3), DatFileSize: fi.Size()}); err != nil {
+		t.Fatal(err)
+	}
+
+	ev, err := NewEcVolume(types.HardDriveType, dir, dir, "", 1)
+	if err != nil {
+		t.Fatalf("NewEcVolume: %v", err)
+	}
+	if ev.DeletedByteCount() >= uint64(fi.Size())/10 {
+		t.Fatalf("unexpected deleted byte count %d before deleting", ev.DeletedByteCount())
+	}
+
+	// delete every third needle before the vacuum, and one more during it
+	original := readEcxEntries(t, base+".ecx")
+	deleted := make(map[types.NeedleId]bool)
+	var lateDelete types.NeedleId
+	i := 0
+	for key := range original {
+		if i%3 == 0 {
+			deleted[key] = true
+			if err = ev.DeleteNeedleFromEcx(key); err != nil {
+				t.Fatalf("delete %d: %v", key, err)
+			}
+		} else if lateDelete == 0 {
+			lateDelete = key
+		}
+		i++
+	}
+	deletedByteCount := ev.DeletedByteCount()
+	if deletedByteCount == 0 {
+		t.Fatalf("expected deleted bytes after deleting")
+	}
+	ev.Sync()
+
+	readOld := func(buf []byte, offset int64) error {
+		_, err := datFile.ReadAt(buf, offset)
+		return err
+	}
+	if err = WriteEcVacuumIndex(base, types.DefaultOffsetWidth); err != nil {
+		t.Fatalf("WriteEcVacuumIndex: %v", err)
+	}
+	plan, err := NewEcVacuumPlan(base, types.DefaultOffsetWidth, readOld)
+	if err != nil {
+		t.Fatalf("NewEcVacuumPlan: %v", err)
+	}
+	if plan.LiveNeedleCount != len(original)-len(deleted) {
+		t.Fatalf("live needles %d, expected %d", plan.LiveNeedleCount, len(original)-len(deleted))
+	}
+	if reclaimed := fi.Size() - plan.NewDatFileSize; reclaimed < int64(deletedByteCount)*9/10 {
+		t.Fatalf("reclaimed %d bytes, expected about %d", reclaimed, deletedByteCount)
+	}
+
+	// the shards are generated in two parts, as by two servers
+	if err = plan.writeShards(base, []ShardId{0, 3, 11}, 50, largeBlockSize, smallBlockSize, ctx, readOld); err != nil {
+		t.Fatalf("writeShards: %v", err)
+	}
+	var otherShardIds []ShardId
+	for id := 0; id < ctx.Total(); id++ {
+		if id != 0 && id != 3 && id != 11 {
+			otherShardIds = append(otherShardIds, ShardId(id))
+		}
+	}
+	if err = plan.writeShards(base, otherShardIds, 50, largeBlockSize, smallBlockSize, ctx, readOld); err != nil {
+		t.Fatalf("writeShards: %v", err)
+	}
+	if err = plan.writeEcx(base + ".ecx" + EcVacuumExt); err != nil {
+		t.Fatalf("writeEcx: %v", err)
+	}
+	volumeInfo, _, _, _ := volume_info.MaybeLoadVolumeInfo(base + ".vif")
+	volumeInfo.DatFileSize = plan.NewDatFileSize
+	if err = volume_info.SaveVolumeInfo(base+".vif"+EcVacuumExt, volumeInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	// the compacted .dat file keeps the live needles unchanged
+	compacted := make([]byte, plan.NewDatFileSize)
+	if err = plan.readNewDat(compacted, 0, readOld); err != nil {
+		t.Fatalf("readNewDat: %v", err)
+	}
+	if compacted[0] != byte(needle.Version3) || util.BytesToUint16(compacted[4:6]) != 1 {
+		t.Fatalf("unexpected super block %v", compacted[:super_block.SuperBlockSize])
+	}
+	vacuumed := readEcxEntries(t, base+".ecx"+EcVacuumExt)
+	if len(vacuumed) != plan.LiveNeedleCount {
+		t.Fatalf("vacuumed ecx has %d entries, expected %d", len(vacuumed), plan.LiveNeedleCount)
+	}
+	for key, entry := range original {
+		newEntry, found := vacuumed[key]
+		if found == deleted[key] {
+			t.Fatalf("needle %d deleted %v found %v", key, deleted[key], found)
+		}
+		if !found {
+			continue
+		}
+		actualSize := needle.GetActualSize(types.Size(entry[1]), needle.Version3)
+		oldData := make([]byte, actualSize)
+		if _, err = datFile.ReadAt(oldData, entry[0]); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(oldData, compacted[newEntry[0]:newEntry[0]+actualSize]) {
+			t.Fatalf("needle %d moved from %d to %d differs", key, entry[0], newEntry[0])
+		}
+	}
+
+	// the vacuumed shards are the same as encoding the compacted .dat file
+	expectedBase := filepath.Join(dir, "expected")
+	if err = os.WriteFile(expectedBase+".dat", compacted, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = generateEcFiles(expectedBase, 50, largeBlockSize, smallBlockSize, ctx); err != nil {
+		t.Fatalf("generateEcFiles: %v", err)
+	}
+	for id := 0; id < ctx.Total(); id++ {
+		expected, _ := os.ReadFile(expectedBase + ctx.ToExt(id))
+		actual, err := os.ReadFile(base + ctx.ToExt(id) + EcVacuumExt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Fatalf("shard %d differs: %d bytes, expected %d bytes", id, len(actual), len(expected))
+		}
+	}
+
+	// a needle deleted during the vacuum is deleted again on commit
+	if err = ev.DeleteNeedleFromEcx(lateDelete); err != nil {
+		t.Fatal(err)
+	}
+	ev.Close()
+	if err = CommitEcVacuum(base, base, types.DefaultOffsetWidth); err != nil {
+		t.Fatalf("CommitEcVacuum: %v", err)
+	}
+	committed := readEcxEntries(t, base+".ecx")
+	if !types.Size(committed[lateDelete][1]).IsDeleted() {
+		t.Fatalf("needle %d deleted during vacuum is not deleted", lateDelete)
+	}
+	ecj, _ := os.ReadFile(base + ".ecj")
+	if len(ecj) != types.NeedleIdSize || types.BytesToNeedleId(ecj) != lateDelete {
+		t.Fatalf("unexpected ecj %v", ecj)
+	}
+	if !util.FileExists(base+".ec05"+EcVacuumBackupExt) || !util.FileExists(base+EcVacuumCommitExt) {
+		t.Fatalf("old files are not kept until finalized")
+	}
+	if err = FinalizeEcVacuum(base, base); err != nil {
+		t.Fatalf("FinalizeEcVacuum: %v", err)
+	}
+	for _, fileName := range []string{base + ".ec05" + EcVacuumExt, base + ".ecx" + EcVacuumExt, base + EcVacuumIndexExt,
+		base + ".ec05" + EcVacuumBackupExt, base + ".ecx" + EcVacuumBackupExt, base + EcVacuumCommitExt} {
+		if util.FileExists(fileName) {
+			t.Fatalf("%s is not removed", fileName)
+		}
+	}
+
+	ev, err = NewEcVolume(types.HardDriveType, dir, dir, "", 1)
+	if err != nil {
+		t.Fatalf("NewEcVolume after commit: %v", err)
+	}
+	defer ev.Close()
+	if ev.DatFileSize() != plan.NewDatFileSize {
+		t.Fatalf("dat file size %d, expected %d", ev.DatFileSize(), plan.NewDatFileSize)
+	}
+	lateSize := needle.GetActualSize(types.Size(vacuumed[lateDelete][1]), needle.Version3)
+	if ev.DeletedByteCount() != uint64(lateSize) {
+		t.Fatalf("deleted byte count %d after commit, expected %d", ev.DeletedByteCount(), lateSize)
+	}
+}
+
+// writeEcVacuumFixture writes two shards, the index and the .vif file of a volume, with their vacuumed versions
+func writeEcVacuumFixture(t *testing.T, base string, vacuumed bool) {
+	for _, ext := range []string{".ec00", ".ec01", ".ecx", ".vif"} {
+		content := []byte("old" + ext)
+		if ext == ".ecx" {
+			content = nil
+		}
+		if err := os.WriteFile(base+ext, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if !vacuumed {
+			continue
+		}
+		if ext != ".ecx" {
+			content = []byte("new" + ext)
+		}
+		if err := os.WriteFile(base+ext+EcVacuumExt, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkEcVacuumRolledBack(t *testing.T, base string) {
+	for _, ext := range []string{".ec00", ".ec01", ".vif"} {
+		content, err := os.ReadFile(base + ext)
+		if err != nil || string(content) != "old"+ext {
+			t.Fatalf("%s%s is %q, %v", base, ext, content, err)
+		}
+	}
+	for _, fileName := range []string{base + ".ec00" + EcVacuumExt, base + ".ec00" + EcVacuumBackupExt,
+		base + ".ecx" + EcVacuumBackupExt, base + ".ecj" + EcVacuumExt, base + EcVacuumCommitExt} {
+		if util.FileExists(fileName) {
+			t.Fatalf("%s is not removed", fileName)
+		}
+	}
+}
+
+func TestEcVacuumPartialCommitRollback(t *testing.T) {
+	dir := t.TempDir()
+	// one server commits, the other fails for a missing vacuumed shard
+	committed, failed := filepath.Join(dir, "a_1"), filepath.Join(dir, "b_1")
+	writeEcVacuumFixture(t, committed, true)
+	writeEcVacuumFixture(t, failed, true)
+	os.Remove(failed + ".ec01" + EcVacuumExt)
+
+	if err := CommitEcVacuum(committed, committed, types.DefaultOffsetWidth); err != nil {
+		t.Fatalf("CommitEcVacuum: %v", err)
+	}
+	if content, _ := os.ReadFile(committed + ".ec00"); string(content) != "new.ec00" {
+		t.Fatalf("committed shard is %q", content)
+	}
+	if err := CommitEcVacuum(failed, failed, types.DefaultOffsetWidth); err == nil {
+		t.Fatalf("commit with a missing vacuumed shard should fail")
+	}
+	if err := FinalizeEcVacuum(failed, failed); err == nil {
+		t.Fatalf("finalize of an uncommitted vacuum should fail")
+	}
+
+	for _, base := range []string{committed, failed} {
+		if err := RollbackEcVacuum(base, base); err != nil {
+			t.Fatalf("RollbackEcVacuum %s: %v", base, err)
+		}
+		checkEcVacuumRolledBack(t, base)
+	}
+	if content, err := os.ReadFile(committed + ".ecj"); err != nil || len(content) != 0 {
+		t.Fatalf("missing ecj should be restored empty, got %q %v", content, err)
+	}
+}
+
+func TestEcVacuumRecoverInterruptedCommit(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "1")
+	writeEcVacuumFixture(t, base, true)
+	if err := reapplyEcDeletions(base, types.DefaultOffsetWidth); err != nil {
+		t.Fatal(err)
+	}
+
+	// crashed after swapping the first shard only
+	fileNames := []string{base + ".ec00", base + ".ec01", base + ".ecx", base + ".ecj", base + ".vif"}
+	if err := writeEcVacuumCommit(base, ecVacuumCommitting, fileNames); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(base+".ec00", base+".ec00"+EcVacuumBackupExt); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(base+".ec00"+EcVacuumExt, base+".ec00"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(base+".ec01", base+".ec01"+EcVacuumBackupExt); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RecoverEcVacuum(base, base); err != nil {
+		t.Fatalf("RecoverEcVacuum: %v", err)
+	}
+	checkEcVacuumRolledBack(t, base)
+
+	// a complete commit is kept, and an interrupted finalize is completed
+	writeEcVacuumFixture(t, base, true)
+	if err := CommitEcVacuum(base, base, types.DefaultOffsetWidth); err != nil {
+		t.Fatalf("CommitEcVacuum: %v", err)
+	}
+	if err := RecoverEcVacuum(base, base); err != nil {
+		t.Fatalf("RecoverEcVacuum: %v", err)
+	}
+	if !util.FileExists(base + EcVacuumCommitExt) {
+		t.Fatalf("committed vacuum should wait to be finalized")
+	}
+	if err := writeEcVacuumCommit(base, ecVacuumFinalizing, fileNames); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(base + ".ec00" + EcVacuumBackupExt)
+	if err := RollbackEcVacuum(base, base); err == nil {
+		t.Fatalf("rollback of a finalizing vacuum should fail")
+	}
+	if err := RecoverEcVacuum(base, base); err != nil {
+		t.Fatalf("RecoverEcVacuum: %v", err)
+	}
+	if util.FileExists(base+".ec01"+EcVacuumBackupExt) || util.FileExists(base+EcVacuumCommitExt) {
+		t.Fatalf("interrupted finalize is not completed")
+	}
+	if content, _ := os.ReadFile(base + ".ec01"); string(content) != "new.ec01" {
+		t.Fatalf("finalized shard is %q", content)
+	}
+}
@@ -1,11 +1,14 @@
 package erasure_coding
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -14,6 +17,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
 )
@@ -42,6 +46,8 @@ type EcVolume struct {
 	datFileSize               int64
 	ExpireAtSec               uint64     //ec volume destroy time, calculated from the ec volume was created
 	ECContext                 *ECContext // EC encoding parameters
+	liveByteCount             int64      // on disk size of the needles not deleted, accessed atomically
+	offsetWidth               types.OffsetWidth
 }
 
 func NewEcVolume(diskType types.DiskType, dir string, dirIdx string, collection string, vid needle.VolumeId) (ev *EcVolume, err error) {
@@ -69,8 +75,13 @@ func NewEcVolume(diskType types.DiskType, dir string, dirIdx string, collection
 
 	// read volume info
 	ev.Version = needle.Version3
+	ev.offsetWidth = types.DefaultOffsetWidth
 	if volumeInfo, _, found, _ := volume_info.MaybeLoadVolumeInfo(dataBaseFileName + ".vif"); found {
 		ev.Version = needle.Version(volumeInfo.Version)
+		if ev.offsetWidth, err = types.ParseOffsetWidth(volumeInfo.BytesOffset); err != nil {
+			ev.Close()
+			return nil, fmt.Errorf("ec volume %d: %v", vid, err)
+		}
 		ev.datFileSize = volumeInfo.DatFileSize
 		ev.ExpireAtSec = volumeInfo.ExpireAtSec
 
@@ -101,11 +112,33 @@ func NewEcVolume(diskType types.DiskType, dir string, dirIdx string, collection
 		ev.ECContext = NewDefaultECContext(collection, vid)
 	}
 
+	if ev.liveByteCount, err = countLiveBytes(ev.ecxFile, ev.ecxFileSize, ev.offsetWidth, ev.Version); err != nil {
+		ev.Close()
+		return nil, fmt.Errorf("cannot read ec volume index %s.ecx: %v", indexBaseFileName, err)
+	}
+
 	ev.ShardLocations = make(map[ShardId][]pb.ServerAddress)
 
 	return
 }
 
+func countLiveBytes(ecxFile *os.File, ecxFileSize int64, offsetWidth types.OffsetWidth, version needle.Version) (liveByteCount int64, err error) {
+	reader := bufio.NewReader(io.NewSectionReader(ecxFile, 0, ecxFileSize))
+	buf := make([]byte, offsetWidth.EntrySize())
+	for {
+		if _, err = io.ReadFull(reader, buf); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return liveByteCount, nil
+			}
+			return
+		}
+		_, _, size := idx.IdxFileEntryWithWidth(offsetWidth, buf)
+		if !size.IsDeleted() {
+			liveByteCount += needle.GetActualSize(size, version)
+		}
+	}
+}
+
 func (ev *EcVolume) AddEcVolumeShard(ecVolumeShard *EcVolumeShard) bool {
 	for _, s := range ev.Shards {
 		if s.ShardId == ecVolumeShard.ShardId {
@@ -227,6 +260,21 @@ func (ev *EcVolume) Size() (size uint64) {
 	return
 }
 
+// DatFileSize returns the size of the .dat file the volume was encoded from, or 0 if unknown.
+func (ev *EcVolume) DatFileSize() int64 {
+	return ev.datFileSize
+}
+
+// DeletedByteCount estimates the bytes of deleted needles still kept in the shards.
+// It is 0 if the size of the encoded .dat file is unknown.
+func (ev *EcVolume) DeletedByteCount() uint64 {
+	if ev.datFileSize <= 0 {
+		return 0
+	}
+	deleted := ev.datFileSize - super_block.SuperBlockSize - atomic.LoadInt64(&ev.liveByteCount)
+	return uint64(max(deleted, 0))
+}
+
 func (ev *EcVolume) CreatedAt() time.Time {
 	return ev.ecxCreatedAt
 }
@@ -245,11 +293,13 @@ func (ev *EcVolume) ToVolumeEcShardInformationMessage(diskId uint32) (messages [
 		m, ok := ecInfoPerVolume[s.VolumeId]
 		if !ok {
 			m = &master_pb.VolumeEcShardInformationMessage{
-				Id:          uint32(s.VolumeId),
-				Collection:  s.Collection,
-				DiskType:    string(ev.diskType),
-				ExpireAtSec: ev.ExpireAtSec,
-				DiskId:      diskId,
+				Id:               uint32(s.VolumeId),
+				Collection:       s.Collection,
+				DiskType:         string(ev.diskType),
+				ExpireAtSec:      ev.ExpireAtSec,
+				DiskId:           diskId,
+				DatFileSize:      uint64(ev.datFileSize),
+				DeletedByteCount: ev.DeletedByteCount(),
 			}
 			ecInfoPerVolume[s.VolumeId] = m
 		}
@@ -280,6 +330,18 @@ func (ev *EcVolume) LocateEcShardNeedle(needleId types.NeedleId, version needle.
 }
 
 func (ev *EcVolume) LocateEcShardNeedleInterval(version needle.Version, offset int64, size types.Size) (intervals []Interval) {
+	// calculate the locations in the ec shards
+	intervals = LocateData(ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, ev.shardDatSize(), offset, types.Size(needle.GetActualSize(size, version)))
+
+	return
+}
+
+// LocateEcShardDatInterval locates a range of the encoded .dat file in the ec shards.
+func (ev *EcVolume) LocateEcShardDatInterval(offset int64, size types.Size) (intervals []Interval) {
+	return LocateData(ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, ev.shardDatSize(), offset, size)
+}
+
+func (ev *EcVolume) shardDatSize() int64 {
 	shard := ev.Shards[0]
 	// Usually shard will be padded to round of ErasureCodingSmallBlockSize.
 	// So in most cases, if shardSize equals to n * ErasureCodingLargeBlockSize,
@@ -290,31 +352,39 @@ func (ev *EcVolume) LocateEcShardNeedleInterval(version needle.Version, offset i
 		// use datFileSize to calculate the shardSize to match the EC encoding logic.
 		shardSize = ev.datFileSize / int64(ev.ECContext.DataShards)
 	}
-	// calculate the locations in the ec shards
-	intervals = LocateData(ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, shardSize, offset, types.Size(needle.GetActualSize(size, version)))
+	return shardSize
+}
 
-	return
+// OffsetWidth is the width of the offsets in the .ecx and .ecj files
+func (ev *EcVolume) OffsetWidth() types.OffsetWidth {
+	return ev.offsetWidth
 }
 
 func (ev *EcVolume) FindNeedleFromEcx(needleId types.NeedleId) (offset types.Offset, size types.Size, err error) {
-	return SearchNeedleFromSortedIndex(ev.ecxFile, ev.ecxFileSize, needleId, nil)
+	return SearchNeedleFromSortedIndexWithWidth(ev.ecxFile, ev.ecxFileSize, ev.offsetWidth, needleId, nil)
 }
 
 func SearchNeedleFromSortedIndex(ecxFile *os.File, ecxFileSize int64, needleId types.NeedleId, processNeedleFn func(file *os.File, offset int64) error) (offset types.Offset, size types.Size, err error) {
+	return SearchNeedleFromSortedIndexWithWidth(ecxFile, ecxFileSize, types.DefaultOffsetWidth, needleId, processNeedleFn)
+}
+
+// SearchNeedleFromSortedIndexWithWidth searches a sorted index with the offset width
+func SearchNeedleFromSortedIndexWithWidth(ecxFile *os.File, ecxFileSize int64, offsetWidth types.OffsetWidth, needleId types.NeedleId, processNeedleFn func(file *os.File, offset int64) error) (offset types.Offset, size types.Size, err error) {
 	var key types.NeedleId
-	buf := make([]byte, types.NeedleMapEntrySize)
-	l, h := int64(0), ecxFileSize/types.NeedleMapEntrySize
+	entrySize := int64(offsetWidth.EntrySize())
+	buf := make([]byte, entrySize)
+	l, h := int64(0), ecxFileSize/entrySize
 	for l < h {
 		m := (l + h) / 2
-		if n, err := ecxFile.ReadAt(buf, m*types.NeedleMapEntrySize); err != nil {
-			if n != types.NeedleMapEntrySize {
-				return types.Offset{}, types.TombstoneFileSize, fmt.Errorf("ecx file %d read at %d: %v", ecxFileSize, m*types.NeedleMapEntrySize, err)
+		if n, err := ecxFile.ReadAt(buf, m*entrySize); err != nil {
+			if int64(n) != entrySize {
+				return types.Offset{}, types.TombstoneFileSize, fmt.Errorf("ecx file %d read at %d: %v", ecxFileSize, m*entrySize, err)
 			}
 		}
-		key, offset, size = idx.IdxFileEntry(buf)
+		key, offset, size = idx.IdxFileEntryWithWidth(offsetWidth, buf)
 		if key == needleId {
 			if processNeedleFn != nil {
-				err = processNeedleFn(ecxFile, m*types.NeedleMapEntrySize)
+				err = processNeedleFn(ecxFile, m*entrySize)
 			}
 			return
 		}
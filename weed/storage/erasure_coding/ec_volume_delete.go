@@ -4,16 +4,23 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
 var (
-	MarkNeedleDeleted = func(file *os.File, offset int64) error {
+	MarkNeedleDeleted = MarkNeedleDeletedWithWidth(types.DefaultOffsetWidth)
+)
+
+// MarkNeedleDeletedWithWidth marks the needle deleted in a sorted index with the offset width
+func MarkNeedleDeletedWithWidth(offsetWidth types.OffsetWidth) func(file *os.File, offset int64) error {
+	return func(file *os.File, offset int64) error {
 		b := make([]byte, types.SizeSize)
 		types.SizeToBytes(b, types.TombstoneFileSize)
-		n, err := file.WriteAt(b, offset+types.NeedleIdSize+types.OffsetSize)
+		n, err := file.WriteAt(b, offset+types.NeedleIdSize+int64(offsetWidth))
 		if err != nil {
 			return fmt.Errorf("sorted needle write error: %w", err)
 		}
@@ -22,11 +29,11 @@ var (
 		}
 		return nil
 	}
-)
+}
 
 func (ev *EcVolume) DeleteNeedleFromEcx(needleId types.NeedleId) (err error) {
 
-	_, _, err = SearchNeedleFromSortedIndex(ev.ecxFile, ev.ecxFileSize, needleId, MarkNeedleDeleted)
+	_, size, err := SearchNeedleFromSortedIndexWithWidth(ev.ecxFile, ev.ecxFileSize, ev.offsetWidth, needleId, MarkNeedleDeletedWithWidth(ev.offsetWidth))
 
 	if err != nil {
 		if err == NotFoundError {
@@ -34,6 +41,9 @@ func (ev *EcVolume) DeleteNeedleFromEcx(needleId types.NeedleId) (err error) {
 		}
 		return err
 	}
+	if !size.IsDeleted() {
+		atomic.AddInt64(&ev.liveByteCount, -needle.GetActualSize(size, ev.Version))
+	}
 
 	b := make([]byte, types.NeedleIdSize)
 	types.NeedleIdToBytes(b, needleId)
@@ -48,7 +58,7 @@ func (ev *EcVolume) DeleteNeedleFromEcx(needleId types.NeedleId) (err error) {
 	return
 }
 
-func RebuildEcxFile(baseFileName string) error {
+func RebuildEcxFile(baseFileName string, offsetWidth types.OffsetWidth) error {
 
 	if !util.FileExists(baseFileName + ".ecj") {
 		return nil
@@ -81,7 +91,7 @@ func RebuildEcxFile(baseFileName string) error {
 
 		needleId := types.BytesToNeedleId(buf)
 
-		_, _, err = SearchNeedleFromSortedIndex(ecxFile, ecxFileSize, needleId, MarkNeedleDeleted)
+		_, _, err = SearchNeedleFromSortedIndexWithWidth(ecxFile, ecxFileSize, offsetWidth, needleId, MarkNeedleDeletedWithWidth(offsetWidth))
 
 		if err != nil && err != NotFoundError {
 			ecxFile.Close()
@@ -13,6 +13,9 @@ type EcVolumeInfo struct {
 	DiskId      uint32 // ID of the disk this EC volume is on
 	ExpireAtSec uint64 // ec volume destroy time, calculated from the ec volume was created
 	ShardsInfo  *ShardsInfo
+
+	DatFileSize      uint64 // size of the .dat file the volume was encoded from, 0 if unknown
+	DeletedByteCount uint64 // bytes of deleted needles still kept in the shards
 }
 
 func (ecInfo *EcVolumeInfo) Minus(other *EcVolumeInfo) *EcVolumeInfo {
@@ -23,6 +26,9 @@ func (ecInfo *EcVolumeInfo) Minus(other *EcVolumeInfo) *EcVolumeInfo {
 		DiskType:    ecInfo.DiskType,
 		DiskId:      ecInfo.DiskId,
 		ExpireAtSec: ecInfo.ExpireAtSec,
+
+		DatFileSize:      ecInfo.DatFileSize,
+		DeletedByteCount: ecInfo.DeletedByteCount,
 	}
 }
 
@@ -35,5 +41,8 @@ func (evi *EcVolumeInfo) ToVolumeEcShardInformationMessage() (ret *master_pb.Vol
 		DiskType:    evi.DiskType,
 		ExpireAtSec: evi.ExpireAtSec,
 		DiskId:      evi.DiskId,
+
+		DatFileSize:      evi.DatFileSize,
+		DeletedByteCount: evi.DeletedByteCount,
 	}
 }
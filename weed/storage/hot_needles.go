@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"container/heap"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+const (
+	hotNeedleCapacity      = 1024
+	hotNeedleShardCount    = 16
+	hotNeedleSampleRate    = 8
+	HotNeedleReportCount   = 16
+	hotNeedleDecayInterval = time.Minute
+)
+
+type hotNeedleKey struct {
+	volumeId needle.VolumeId
+	needleId NeedleId
+}
+
+type hotNeedleCounter struct {
+	hotNeedleKey
+	cookie Cookie
+	count  uint64
+	index  int // position in the heap
+}
+
+// HotNeedles finds the most read needles with the space-saving heavy hitter algorithm.
+// Only a fixed number of needles are counted. A needle not counted yet replaces the least read one,
+// and inherits its count, so the estimate of a hot needle can only be too high, by at most the evicted count.
+// The counts are halved every hotNeedleDecayInterval, so the needles hot recently are reported.
+// The volumes are spread over shards with their own locks, and only one in sampleRate reads is counted,
+// so the reads of different volumes do not wait for each other.
+// A nil HotNeedles tracks nothing.
+type HotNeedles struct {
+	shards     []*hotNeedleShard
+	sampleRate int
+}
+
+type hotNeedleShard struct {
+	sync.Mutex
+	capacity  int
+	counters  map[hotNeedleKey]*hotNeedleCounter
+	heap      hotNeedleHeap
+	lastDecay time.Time
+}
+
+func NewHotNeedles(capacity, shardCount, sampleRate int) *HotNeedles {
+	h := &HotNeedles{sampleRate: max(sampleRate, 1)}
+	shardCapacity := max(capacity/shardCount, 1)
+	for i := 0; i < shardCount; i++ {
+		h.shards = append(h.shards, &hotNeedleShard{
+			capacity:  shardCapacity,
+			counters:  make(map[hotNeedleKey]*hotNeedleCounter, shardCapacity),
+			lastDecay: time.Now(),
+		})
+	}
+	return h
+}
+
+func (h *HotNeedles) shard(vid needle.VolumeId) *hotNeedleShard {
+	return h.shards[uint32(vid)%uint32(len(h.shards))]
+}
+
+func (h *HotNeedles) Add(vid needle.VolumeId, id NeedleId, cookie Cookie) {
+	if h == nil {
+		return
+	}
+	if h.sampleRate > 1 && rand.IntN(h.sampleRate) != 0 {
+		return
+	}
+	h.shard(vid).add(hotNeedleKey{volumeId: vid, needleId: id}, cookie)
+}
+
+func (s *hotNeedleShard) add(key hotNeedleKey, cookie Cookie) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.decay(time.Now())
+
+	if c, found := s.counters[key]; found {
+		c.count++
+		c.cookie = cookie
+		heap.Fix(&s.heap, c.index)
+		return
+	}
+	if len(s.heap) < s.capacity {
+		c := &hotNeedleCounter{hotNeedleKey: key, cookie: cookie, count: 1}
+		s.counters[key] = c
+		heap.Push(&s.heap, c)
+		return
+	}
+	// replace the least read needle
+	c := s.heap[0]
+	delete(s.counters, c.hotNeedleKey)
+	c.hotNeedleKey, c.cookie = key, cookie
+	c.count++
+	s.counters[key] = c
+	heap.Fix(&s.heap, 0)
+}
+
+// DeleteVolume forgets the needles of a volume no longer on this server
+func (h *HotNeedles) DeleteVolume(vid needle.VolumeId) {
+	if h == nil {
+		return
+	}
+	s := h.shard(vid)
+	s.Lock()
+	defer s.Unlock()
+
+	for key, c := range s.counters {
+		if key.volumeId == vid {
+			delete(s.counters, key)
+			heap.Remove(&s.heap, c.index)
+		}
+	}
+}
+
+// Top returns at most n needles, the most read first, with the read counts scaled up by the sample rate
+func (h *HotNeedles) Top(n int) (hotNeedles []*master_pb.HotNeedle) {
+	if h == nil {
+		return
+	}
+	for _, s := range h.shards {
+		hotNeedles = append(hotNeedles, s.top(n, h.sampleRate)...)
+	}
+	sort.Slice(hotNeedles, func(i, j int) bool {
+		return hotNeedles[i].ReadCount > hotNeedles[j].ReadCount
+	})
+	if len(hotNeedles) > n {
+		hotNeedles = hotNeedles[:n]
+	}
+	return
+}
+
+func (s *hotNeedleShard) top(n int, sampleRate int) (hotNeedles []*master_pb.HotNeedle) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.decay(time.Now())
+
+	counters := make([]*hotNeedleCounter, 0, len(s.heap))
+	for _, c := range s.heap {
+		if c.count > 0 {
+			counters = append(counters, c)
+		}
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].count > counters[j].count
+	})
+	if len(counters) > n {
+		counters = counters[:n]
+	}
+	for _, c := range counters {
+		hotNeedles = append(hotNeedles, &master_pb.HotNeedle{
+			VolumeId:  uint32(c.volumeId),
+			NeedleId:  uint64(c.needleId),
+			Cookie:    uint32(c.cookie),
+			ReadCount: c.count * uint64(sampleRate),
+		})
+	}
+	return
+}
+
+func (s *hotNeedleShard) decay(now time.Time) {
+	halvings := now.Sub(s.lastDecay) / hotNeedleDecayInterval
+	if halvings <= 0 {
+		return
+	}
+	s.lastDecay = s.lastDecay.Add(halvings * hotNeedleDecayInterval)
+	// halving all counts keeps the heap order
+	for _, c := range s.heap {
+		if halvings >= 64 {
+			c.count = 0
+		} else {
+			c.count >>= uint(halvings)
+		}
+	}
+}
+
+type hotNeedleHeap []*hotNeedleCounter
+
+func (h hotNeedleHeap) Len() int { return len(h) }
+
+func (h hotNeedleHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h hotNeedleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *hotNeedleHeap) Push(x any) {
+	c := x.(*hotNeedleCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *hotNeedleHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+func TestHotNeedles(t *testing.T) {
+	h := NewHotNeedles(8, 1, 1)
+
+	// a few hot needles among many cold ones
+	for i := 0; i < 1000; i++ {
+		h.Add(1, NeedleId(1), 0x11)
+		if i%2 == 0 {
+			h.Add(2, NeedleId(2), 0x22)
+		}
+		h.Add(3, NeedleId(1000+i), Cookie(i))
+	}
+
+	top := h.Top(2)
+	if len(top) != 2 {
+		t.Fatalf("top %d needles, expected 2", len(top))
+	}
+	if top[0].VolumeId != 1 || top[0].NeedleId != 1 || top[0].Cookie != 0x11 {
+		t.Fatalf("most read needle %+v", top[0])
+	}
+	if top[1].VolumeId != 2 || top[1].NeedleId != 2 {
+		t.Fatalf("second most read needle %+v", top[1])
+	}
+	if top[0].ReadCount < 1000 {
+		t.Fatalf("read count %d is under estimated", top[0].ReadCount)
+	}
+	shard := h.shards[0]
+	if len(shard.counters) != 8 || len(shard.heap) != 8 {
+		t.Fatalf("tracking %d needles, capacity 8", len(shard.counters))
+	}
+
+	// counts decay over time
+	count := top[0].ReadCount
+	shard.lastDecay = shard.lastDecay.Add(-2 * hotNeedleDecayInterval)
+	top = h.Top(1)
+	if top[0].ReadCount != count/4 {
+		t.Fatalf("decayed read count %d, expected %d", top[0].ReadCount, count/4)
+	}
+	shard.lastDecay = time.Now().Add(-100 * hotNeedleDecayInterval)
+	if top = h.Top(1); len(top) != 0 {
+		t.Fatalf("needles %+v are not decayed", top)
+	}
+
+	// deleted volumes are forgotten
+	h.Add(1, NeedleId(1), 0x11)
+	h.Add(2, NeedleId(2), 0x22)
+	h.DeleteVolume(needle.VolumeId(1))
+	for _, c := range shard.heap {
+		if c.volumeId == 1 {
+			t.Fatalf("needle %d of deleted volume is tracked", c.needleId)
+		}
+	}
+	if top = h.Top(HotNeedleReportCount); len(top) != 1 || top[0].VolumeId != 2 {
+		t.Fatalf("needles after volume deletion %+v", top)
+	}
+
+	var nilHotNeedles *HotNeedles
+	nilHotNeedles.Add(1, NeedleId(1), 0)
+	if top = nilHotNeedles.Top(1); len(top) != 0 {
+		t.Fatalf("nil hot needles %+v", top)
+	}
+}
+
+func TestHotNeedlesShardedSampled(t *testing.T) {
+	h := NewHotNeedles(64, 4, 4)
+
+	for i := 0; i < 4000; i++ {
+		h.Add(5, NeedleId(1), 0x11)
+		h.Add(needle.VolumeId(i%8), NeedleId(1000+i), Cookie(i))
+	}
+
+	// the volumes are spread over the shards
+	for i, shard := range h.shards {
+		for key := range shard.counters {
+			if int(key.volumeId)%len(h.shards) != i {
+				t.Fatalf("volume %d counted in shard %d", key.volumeId, i)
+			}
+		}
+	}
+
+	// the sampled count is scaled up to estimate the reads
+	top := h.Top(1)
+	if len(top) != 1 || top[0].VolumeId != 5 || top[0].NeedleId != 1 {
+		t.Fatalf("most read needle %+v", top)
+	}
+	if top[0].ReadCount < 3000 || top[0].ReadCount > 5000 {
+		t.Fatalf("estimated read count %d, expected about 4000", top[0].ReadCount)
+	}
+}
+
+func TestReportHotNeedleMetrics(t *testing.T) {
+	s := &Store{}
+	s.reportHotNeedleMetrics([]*master_pb.HotNeedle{
+		{VolumeId: 3, NeedleId: 0x10, ReadCount: 90},
+		{VolumeId: 4, NeedleId: 0x20, ReadCount: 50},
+	})
+	if readCount := testutil.ToFloat64(stats.VolumeServerHotNeedleGauge.WithLabelValues("1", "3", "10")); readCount != 90 {
+		t.Fatalf("read count of the hottest needle %v", readCount)
+	}
+
+	// the needles out of the top are not reported any more
+	s.reportHotNeedleMetrics([]*master_pb.HotNeedle{
+		{VolumeId: 4, NeedleId: 0x20, ReadCount: 70},
+	})
+	if series := testutil.CollectAndCount(stats.VolumeServerHotNeedleGauge); series != 1 {
+		t.Fatalf("%d hot needle series, expected 1", series)
+	}
+	if readCount := testutil.ToFloat64(stats.VolumeServerHotNeedleGauge.WithLabelValues("1", "4", "20")); readCount != 70 {
+		t.Fatalf("read count of the hottest needle %v", readCount)
+	}
+	s.reportHotNeedleMetrics(nil)
+}
@@ -6,14 +6,18 @@ import (
 
 // FirstInvalidIndex find the first index the failed lessThanOrEqualToFn function's requirement.
 func FirstInvalidIndex(bytes []byte, lessThanOrEqualToFn func(key types.NeedleId, offset types.Offset, size types.Size) (bool, error)) (int, error) {
-	left, right := 0, len(bytes)/types.NeedleMapEntrySize-1
+	return FirstInvalidIndexWithWidth(bytes, types.DefaultOffsetWidth, lessThanOrEqualToFn)
+}
+
+// FirstInvalidIndexWithWidth searches the index entries of a volume with the offset width.
+func FirstInvalidIndexWithWidth(bytes []byte, width types.OffsetWidth, lessThanOrEqualToFn func(key types.NeedleId, offset types.Offset, size types.Size) (bool, error)) (int, error) {
+	entrySize := width.EntrySize()
+	left, right := 0, len(bytes)/entrySize-1
 	index := right + 1
 	for left <= right {
 		mid := left + (right-left)>>1
-		loc := mid * types.NeedleMapEntrySize
-		key := types.BytesToNeedleId(bytes[loc : loc+types.NeedleIdSize])
-		offset := types.BytesToOffset(bytes[loc+types.NeedleIdSize : loc+types.NeedleIdSize+types.OffsetSize])
-		size := types.BytesToSize(bytes[loc+types.NeedleIdSize+types.OffsetSize : loc+types.NeedleIdSize+types.OffsetSize+types.SizeSize])
+		loc := mid * entrySize
+		key, offset, size := IdxFileEntryWithWidth(width, bytes[loc:loc+entrySize])
 		res, err := lessThanOrEqualToFn(key, offset, size)
 		if err != nil {
 			return -1, err
@@ -10,8 +10,14 @@ import (
 // walks through the index file, calls fn function with each key, offset, size
 // stops with the error returned by the fn function
 func WalkIndexFile(r io.ReaderAt, startFrom uint64, fn func(key types.NeedleId, offset types.Offset, size types.Size) error) error {
-	readerOffset := int64(startFrom * types.NeedleMapEntrySize)
-	bytes := make([]byte, types.NeedleMapEntrySize*RowsToRead)
+	return WalkIndexFileWithWidth(r, types.DefaultOffsetWidth, startFrom, fn)
+}
+
+// WalkIndexFileWithWidth walks through the index file of a volume with the offset width
+func WalkIndexFileWithWidth(r io.ReaderAt, width types.OffsetWidth, startFrom uint64, fn func(key types.NeedleId, offset types.Offset, size types.Size) error) error {
+	entrySize := width.EntrySize()
+	readerOffset := int64(startFrom) * int64(entrySize)
+	bytes := make([]byte, entrySize*RowsToRead)
 	count, e := r.ReadAt(bytes, readerOffset)
 	if count == 0 && e == io.EOF {
 		return nil
@@ -26,8 +32,8 @@ func WalkIndexFile(r io.ReaderAt, startFrom uint64, fn func(key types.NeedleId,
 	)
 
 	for count > 0 && e == nil || e == io.EOF {
-		for i = 0; i+types.NeedleMapEntrySize <= count; i += types.NeedleMapEntrySize {
-			key, offset, size = IdxFileEntry(bytes[i : i+types.NeedleMapEntrySize])
+		for i = 0; i+entrySize <= count; i += entrySize {
+			key, offset, size = IdxFileEntryWithWidth(width, bytes[i:i+entrySize])
 			if e = fn(key, offset, size); e != nil {
 				return e
 			}
@@ -43,9 +49,13 @@ func WalkIndexFile(r io.ReaderAt, startFrom uint64, fn func(key types.NeedleId,
 }
 
 func IdxFileEntry(bytes []byte) (key types.NeedleId, offset types.Offset, size types.Size) {
+	return IdxFileEntryWithWidth(types.DefaultOffsetWidth, bytes)
+}
+
+func IdxFileEntryWithWidth(width types.OffsetWidth, bytes []byte) (key types.NeedleId, offset types.Offset, size types.Size) {
 	key = types.BytesToNeedleId(bytes[:types.NeedleIdSize])
-	offset = types.BytesToOffset(bytes[types.NeedleIdSize : types.NeedleIdSize+types.OffsetSize])
-	size = types.BytesToSize(bytes[types.NeedleIdSize+types.OffsetSize : types.NeedleIdSize+types.OffsetSize+types.SizeSize])
+	offset = width.BytesToOffset(bytes[types.NeedleIdSize : types.NeedleIdSize+int(width)])
+	size = types.BytesToSize(bytes[types.NeedleIdSize+int(width) : types.NeedleIdSize+int(width)+types.SizeSize])
 	return
 }
 
@@ -53,7 +53,8 @@ func ReadNeedleBlob(r backend.BackendStorageFile, offset int64, size Size, versi
 func (n *Needle) ReadBytes(bytes []byte, offset int64, size Size, version Version) (err error) {
 	n.ParseNeedleHeader(bytes)
 	if n.Size != size {
-		if OffsetSize == 4 && offset < int64(MaxPossibleVolumeSize) {
+		if offset < int64(OffsetWidth4.MaxVolumeSize()) {
+			// the offset may have wrapped around in a volume with 4 bytes offsets
 			stats.VolumeServerHandlerCounter.WithLabelValues(stats.ErrorSizeMismatchOffsetSize).Inc()
 			glog.Errorf("entry not found1: offset %d found id %x size %d, expected size %d", offset, n.Id, n.Size, size)
 			return ErrorSizeMismatch
@@ -78,13 +79,18 @@ func (n *Needle) ReadBytes(bytes []byte, offset int64, size Size, version Versio
 
 // ReadData hydrates the needle from the file, with only n.Id is set.
 func (n *Needle) ReadData(r backend.BackendStorageFile, offset int64, size Size, version Version) (err error) {
+	return n.ReadDataWithOffsetWidth(r, offset, size, version, DefaultOffsetWidth)
+}
+
+// ReadDataWithOffsetWidth hydrates the needle from a volume whose index has offsets of the width.
+func (n *Needle) ReadDataWithOffsetWidth(r backend.BackendStorageFile, offset int64, size Size, version Version, offsetWidth OffsetWidth) (err error) {
 	bytes, err := ReadNeedleBlob(r, offset, size, version)
 	if err != nil {
 		return err
 	}
 	err = n.ReadBytes(bytes, offset, size, version)
-	if err == ErrorSizeMismatch && OffsetSize == 4 {
-		offset = offset + int64(MaxPossibleVolumeSize)
+	if err == ErrorSizeMismatch && offsetWidth == OffsetWidth4 {
+		offset = offset + int64(OffsetWidth4.MaxVolumeSize())
 		bytes, err = ReadNeedleBlob(r, offset, size, version)
 		if err != nil {
 			return err
@@ -52,7 +52,7 @@ func (n *Needle) ReadNeedleMeta(r backend.BackendStorageFile, offset int64, size
 	}
 	n.ParseNeedleHeader(bytes)
 	if n.Size != size {
-		if OffsetSize == 4 && offset < int64(MaxPossibleVolumeSize) {
+		if offset < int64(OffsetWidth4.MaxVolumeSize()) {
 			return ErrorSizeMismatch
 		}
 		return fmt.Errorf("size mismatch for entry at offset %d: found id %x size %d, expected size %d", offset, n.Id, n.Size, size)
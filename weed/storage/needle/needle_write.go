@@ -12,14 +12,19 @@ import (
 )
 
 func (n *Needle) Append(w backend.BackendStorageFile, version Version) (offset uint64, size Size, actualSize int64, err error) {
+	return n.AppendWithOffsetWidth(w, version, DefaultOffsetWidth)
+}
+
+// AppendWithOffsetWidth appends the needle to a volume whose index has offsets of the width
+func (n *Needle) AppendWithOffsetWidth(w backend.BackendStorageFile, version Version, offsetWidth OffsetWidth) (offset uint64, size Size, actualSize int64, err error) {
 	end, _, e := w.GetStat()
 	if e != nil {
 		err = fmt.Errorf("Cannot Read Current Volume Position: %w", e)
 		return
 	}
 	offset = uint64(end)
-	if offset >= MaxPossibleVolumeSize && len(n.Data) != 0 {
-		err = fmt.Errorf("Volume Size %d Exceeded %d", offset, MaxPossibleVolumeSize)
+	if maxVolumeSize := offsetWidth.MaxVolumeSize(); offset >= maxVolumeSize && len(n.Data) != 0 {
+		err = fmt.Errorf("Volume Size %d Exceeded %d", offset, maxVolumeSize)
 		return
 	}
 	bytesBuffer := buffer_pool.SyncPoolGetBuffer()
@@ -45,6 +50,15 @@ func (n *Needle) Append(w backend.BackendStorageFile, version Version) (offset u
 	return offset, size, actualSize, err
 }
 
+// ToBytes serializes the needle the same way as it is appended to a volume of the version.
+func (n *Needle) ToBytes(version Version) (needleBlob []byte, size Size, err error) {
+	bytesBuffer := new(bytes.Buffer)
+	if _, _, err = writeNeedleByVersion(version, n, 0, bytesBuffer); err != nil {
+		return nil, 0, err
+	}
+	return bytesBuffer.Bytes(), n.Size, nil
+}
+
 func WriteNeedleBlob(w backend.BackendStorageFile, dataSlice []byte, size Size, appendAtNs uint64, version Version) (offset uint64, err error) {
 
 	if end, _, e := w.GetStat(); e == nil {
@@ -158,3 +158,37 @@ func (m *mockBackendWriter) ReadAt(p []byte, off int64) (n int, err error) {
 	// Not used in this test
 	return 0, nil
 }
+
+func TestToBytes(t *testing.T) {
+	for _, version := range []Version{Version2, Version3} {
+		n := &Needle{
+			Cookie:       types.Cookie(0x1234),
+			Id:           types.NeedleId(42),
+			Data:         []byte("hello replica"),
+			Name:         []byte("hello.txt"),
+			Mime:         []byte("text/plain"),
+			LastModified: 1700000000,
+		}
+		n.SetHasName()
+		n.SetHasMime()
+		n.SetHasLastModifiedDate()
+		n.Checksum = NewCRC(n.Data)
+
+		needleBlob, size, err := n.ToBytes(version)
+		if err != nil {
+			t.Fatalf("%s: ToBytes: %v", versionString(version), err)
+		}
+		if int64(len(needleBlob)) != GetActualSize(size, version) {
+			t.Errorf("%s: blob length %d, expected %d", versionString(version), len(needleBlob), GetActualSize(size, version))
+		}
+
+		parsed := new(Needle)
+		if err = parsed.ReadBytes(needleBlob, 0, size, version); err != nil {
+			t.Fatalf("%s: ReadBytes: %v", versionString(version), err)
+		}
+		if parsed.Id != n.Id || parsed.Cookie != n.Cookie || !bytes.Equal(parsed.Data, n.Data) ||
+			!bytes.Equal(parsed.Name, n.Name) || !bytes.Equal(parsed.Mime, n.Mime) || parsed.LastModified != n.LastModified {
+			t.Errorf("%s: parsed %+v, expected %+v", versionString(version), parsed, n)
+		}
+	}
+}
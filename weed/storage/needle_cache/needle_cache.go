@@ -0,0 +1,288 @@
+package needle_cache
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+// NeedleCache is a read cache of needle blobs on a local fast disk, e.g. NVMe,
+// for volumes where a read is expensive, such as remote tiered volumes and erasure coded volumes.
+//
+// The cache is split into segment files, written in a ring.
+// When the current segment is full, the oldest segment is emptied and reused,
+// so the disk usage never exceeds the capacity.
+// A needle is only admitted on its second miss, so one-time scans do not flush out the hot needles.
+// The index is kept in memory, and the cache starts empty after a restart.
+type NeedleCache struct {
+	sync.RWMutex
+	dir          string
+	label        string
+	segments     []*cacheSegment
+	current      int
+	index        map[NeedleKey]cacheLocation
+	candidates   *admissionFilter
+	maxEntrySize int64
+	cachedBytes  int64
+	hitCount     atomic.Int64
+	missCount    atomic.Int64
+}
+
+// NeedleKey identifies the bytes of a needle blob.
+// Volumes only append, so the same needle at the same offset always has the same content.
+type NeedleKey struct {
+	VolumeId uint32
+	NeedleId types.NeedleId
+	Offset   int64
+	Size     types.Size
+	// Variant identifies content derived from the needle, e.g. an image rendition, or 0 for the needle itself
+	Variant uint64
+}
+
+type cacheLocation struct {
+	segment int
+	offset  int64
+	size    int32
+	crc     uint32
+}
+
+type cacheSegment struct {
+	file   *os.File
+	size   int64
+	limit  int64
+	keys   []NeedleKey
+	bytes  int64
+	closed bool
+}
+
+const (
+	segmentCount = 8
+	// a needle can use up to a quarter of a segment
+	maxEntrySegmentPart = 4
+	// the admission filter remembers about as many keys as the cache can hold with this needle size
+	averageNeedleSize = 64 * 1024
+	minCandidateCount = 1024
+)
+
+// NewNeedleCache creates a cache under dir with the capacity in bytes.
+// The label identifies the cache in the metrics.
+func NewNeedleCache(dir string, capacity int64, label string) (*NeedleCache, error) {
+	if capacity < segmentCount*1024*1024 {
+		return nil, fmt.Errorf("read cache capacity %d is too small, at least %d MB", capacity, segmentCount)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create read cache dir %s: %v", dir, err)
+	}
+
+	segmentSize := capacity / segmentCount
+	c := &NeedleCache{
+		dir:          dir,
+		label:        label,
+		index:        make(map[NeedleKey]cacheLocation),
+		candidates:   newAdmissionFilter(max(minCandidateCount, int(capacity/averageNeedleSize))),
+		maxEntrySize: segmentSize / maxEntrySegmentPart,
+	}
+	for i := 0; i < segmentCount; i++ {
+		fileName := filepath.Join(dir, fmt.Sprintf("read_cache_%d.dat", i))
+		// the index is not persisted, so the previous content is useless
+		f, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			c.Shutdown()
+			return nil, fmt.Errorf("create read cache file %s: %v", fileName, err)
+		}
+		c.segments = append(c.segments, &cacheSegment{file: f, limit: segmentSize})
+	}
+	stats.VolumeServerReadCacheSizeGauge.WithLabelValues(label).Set(0)
+	glog.V(0).Infof("read cache %s: %d MB in %s", label, capacity/1024/1024, dir)
+	return c, nil
+}
+
+// Get returns the cached needle blob
+func (c *NeedleCache) Get(key NeedleKey) ([]byte, bool) {
+	c.RLock()
+	loc, found := c.index[key]
+	var data []byte
+	var err error
+	if found {
+		data = make([]byte, loc.size)
+		_, err = c.segments[loc.segment].file.ReadAt(data, loc.offset)
+	}
+	c.RUnlock()
+
+	if found && err == nil && crc32.ChecksumIEEE(data) != loc.crc {
+		err = fmt.Errorf("crc mismatch")
+	}
+	if found && err != nil {
+		glog.Warningf("read cache %s: read volume %d needle %s: %v", c.label, key.VolumeId, key.NeedleId, err)
+		c.Lock()
+		if current, stillFound := c.index[key]; stillFound && current == loc {
+			delete(c.index, key)
+			c.segments[loc.segment].bytes -= int64(loc.size)
+			c.cachedBytes -= int64(loc.size)
+		}
+		c.Unlock()
+		found = false
+	}
+
+	var hits, misses int64
+	if found {
+		hits, misses = c.hitCount.Add(1), c.missCount.Load()
+		stats.VolumeServerReadCacheCounter.WithLabelValues(c.label, "hit").Inc()
+	} else {
+		hits, misses = c.hitCount.Load(), c.missCount.Add(1)
+		stats.VolumeServerReadCacheCounter.WithLabelValues(c.label, "miss").Inc()
+	}
+	stats.VolumeServerReadCacheHitRatioGauge.WithLabelValues(c.label).Set(float64(hits) / float64(hits+misses))
+
+	if !found {
+		return nil, false
+	}
+	return data, true
+}
+
+// Fits tells whether a needle blob of this size can be cached
+func (c *NeedleCache) Fits(blobSize int64) bool {
+	return blobSize <= c.maxEntrySize
+}
+
+// Set offers a needle blob that was just read after a miss.
+// The first offer only remembers the key. The blob is written on the second offer.
+func (c *NeedleCache) Set(key NeedleKey, data []byte) {
+	if int64(len(data)) > c.maxEntrySize || len(data) == 0 {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if _, found := c.index[key]; found || c.segments[c.current].closed {
+		return
+	}
+	if !c.candidates.admit(key) {
+		return
+	}
+
+	segment := c.segments[c.current]
+	if segment.size+int64(len(data)) > segment.limit {
+		c.current = (c.current + 1) % len(c.segments)
+		segment = c.segments[c.current]
+		c.evictSegment(c.current)
+	}
+
+	if _, err := segment.file.WriteAt(data, segment.size); err != nil {
+		glog.Warningf("read cache %s: write volume %d needle %s: %v", c.label, key.VolumeId, key.NeedleId, err)
+		return
+	}
+	c.index[key] = cacheLocation{
+		segment: c.current,
+		offset:  segment.size,
+		size:    int32(len(data)),
+		crc:     crc32.ChecksumIEEE(data),
+	}
+	segment.size += int64(len(data))
+	segment.bytes += int64(len(data))
+	segment.keys = append(segment.keys, key)
+	c.cachedBytes += int64(len(data))
+
+	stats.VolumeServerReadCacheCounter.WithLabelValues(c.label, "admit").Inc()
+	stats.VolumeServerReadCacheSizeGauge.WithLabelValues(c.label).Set(float64(c.cachedBytes))
+}
+
+// InvalidateVolume drops the cached needles of a deleted volume
+func (c *NeedleCache) InvalidateVolume(volumeId uint32) {
+	c.Lock()
+	defer c.Unlock()
+
+	for key, loc := range c.index {
+		if key.VolumeId == volumeId {
+			delete(c.index, key)
+			c.segments[loc.segment].bytes -= int64(loc.size)
+			c.cachedBytes -= int64(loc.size)
+		}
+	}
+	c.candidates.forgetVolume(volumeId)
+	stats.VolumeServerReadCacheSizeGauge.WithLabelValues(c.label).Set(float64(c.cachedBytes))
+}
+
+func (c *NeedleCache) evictSegment(i int) {
+	segment := c.segments[i]
+	evicted := 0
+	for _, key := range segment.keys {
+		if loc, found := c.index[key]; found && loc.segment == i {
+			delete(c.index, key)
+			evicted++
+		}
+	}
+	c.cachedBytes -= segment.bytes
+	segment.keys = nil
+	segment.size = 0
+	segment.bytes = 0
+	if err := segment.file.Truncate(0); err != nil {
+		glog.Warningf("read cache %s: truncate segment %d: %v", c.label, i, err)
+	}
+	stats.VolumeServerReadCacheCounter.WithLabelValues(c.label, "evict").Add(float64(evicted))
+	stats.VolumeServerReadCacheSizeGauge.WithLabelValues(c.label).Set(float64(c.cachedBytes))
+}
+
+func (c *NeedleCache) Shutdown() {
+	c.Lock()
+	defer c.Unlock()
+	for _, segment := range c.segments {
+		if !segment.closed {
+			segment.file.Close()
+			os.Remove(segment.file.Name())
+			segment.closed = true
+		}
+	}
+	c.index = make(map[NeedleKey]cacheLocation)
+}
+
+// admissionFilter remembers the recently missed keys, in a fixed size ring.
+type admissionFilter struct {
+	keys  map[NeedleKey]int // key -> position in the ring
+	ring  []NeedleKey
+	next  int
+	count int
+}
+
+func newAdmissionFilter(capacity int) *admissionFilter {
+	return &admissionFilter{
+		keys: make(map[NeedleKey]int, capacity),
+		ring: make([]NeedleKey, capacity),
+	}
+}
+
+// admit returns true if the key was seen before, otherwise remembers it
+func (f *admissionFilter) admit(key NeedleKey) bool {
+	if _, found := f.keys[key]; found {
+		delete(f.keys, key)
+		return true
+	}
+	if f.count == len(f.ring) {
+		oldest := f.ring[f.next]
+		if pos, found := f.keys[oldest]; found && pos == f.next {
+			delete(f.keys, oldest)
+		}
+	} else {
+		f.count++
+	}
+	f.ring[f.next] = key
+	f.keys[key] = f.next
+	f.next = (f.next + 1) % len(f.ring)
+	return false
+}
+
+func (f *admissionFilter) forgetVolume(volumeId uint32) {
+	for key := range f.keys {
+		if key.VolumeId == volumeId {
+			delete(f.keys, key)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package needle_cache
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+func newTestCache(t *testing.T) *NeedleCache {
+	c, err := NewNeedleCache(t.TempDir(), segmentCount*1024*1024, "test")
+	if err != nil {
+		t.Fatalf("create cache: %v", err)
+	}
+	t.Cleanup(c.Shutdown)
+	return c
+}
+
+func testKey(vid uint32, id uint64) NeedleKey {
+	return NeedleKey{VolumeId: vid, NeedleId: types.NeedleId(id), Offset: int64(id) * 8, Size: 100}
+}
+
+func TestAdmissionOnSecondMiss(t *testing.T) {
+	c := newTestCache(t)
+	key, data := testKey(1, 1), bytes.Repeat([]byte{7}, 1000)
+
+	if _, found := c.Get(key); found {
+		t.Fatalf("empty cache should miss")
+	}
+	c.Set(key, data)
+	if _, found := c.Get(key); found {
+		t.Fatalf("first miss should not be admitted")
+	}
+	c.Set(key, data)
+	got, found := c.Get(key)
+	if !found || !bytes.Equal(got, data) {
+		t.Fatalf("second miss should be admitted, found=%v", found)
+	}
+	if c.hitCount.Load() != 1 || c.missCount.Load() != 2 {
+		t.Errorf("hits %d misses %d", c.hitCount.Load(), c.missCount.Load())
+	}
+}
+
+func TestEvictionKeepsCapacity(t *testing.T) {
+	c := newTestCache(t)
+	data := bytes.Repeat([]byte{1}, 200*1024)
+
+	// write 4x the capacity
+	for i := uint64(0); i < 4*segmentCount*5; i++ {
+		c.Set(testKey(1, i), data)
+		c.Set(testKey(1, i), data)
+	}
+	var total int64
+	for _, segment := range c.segments {
+		stat, err := segment.file.Stat()
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		total += stat.Size()
+	}
+	if total > segmentCount*1024*1024 {
+		t.Errorf("cache files use %d bytes, over the capacity", total)
+	}
+	if c.cachedBytes != int64(len(c.index))*int64(len(data)) {
+		t.Errorf("cached bytes %d for %d entries", c.cachedBytes, len(c.index))
+	}
+	if _, found := c.Get(testKey(1, 0)); found {
+		t.Errorf("oldest entry should be evicted")
+	}
+	if _, found := c.Get(testKey(1, 4*segmentCount*5-1)); !found {
+		t.Errorf("newest entry should be cached")
+	}
+}
+
+func TestTooLargeNeedleIsNotCached(t *testing.T) {
+	c := newTestCache(t)
+	data := make([]byte, c.maxEntrySize+1)
+	if c.Fits(int64(len(data))) {
+		t.Fatalf("should not fit")
+	}
+	c.Set(testKey(1, 1), data)
+	c.Set(testKey(1, 1), data)
+	if _, found := c.Get(testKey(1, 1)); found {
+		t.Errorf("too large needle should not be cached")
+	}
+}
+
+func TestInvalidateVolume(t *testing.T) {
+	c := newTestCache(t)
+	data := []byte("needle blob")
+	for _, key := range []NeedleKey{testKey(1, 1), testKey(2, 1)} {
+		c.Set(key, data)
+		c.Set(key, data)
+	}
+	c.InvalidateVolume(1)
+	if _, found := c.Get(testKey(1, 1)); found {
+		t.Errorf("volume 1 should be invalidated")
+	}
+	if _, found := c.Get(testKey(2, 1)); !found {
+		t.Errorf("volume 2 should be kept")
+	}
+	if c.cachedBytes != int64(len(data)) {
+		t.Errorf("cached bytes %d", c.cachedBytes)
+	}
+}
+
+func TestCorruptedEntryIsDropped(t *testing.T) {
+	c := newTestCache(t)
+	key, data := testKey(1, 1), []byte("needle blob")
+	c.Set(key, data)
+	c.Set(key, data)
+
+	loc := c.index[key]
+	if _, err := c.segments[loc.segment].file.WriteAt([]byte("X"), loc.offset); err != nil {
+		t.Fatalf("corrupt: %v", err)
+	}
+	if _, found := c.Get(key); found {
+		t.Errorf("corrupted entry should not be returned")
+	}
+	if _, found := c.index[key]; found {
+		t.Errorf("corrupted entry should be dropped")
+	}
+}
+
+func TestShutdownRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewNeedleCache(dir, segmentCount*1024*1024, "test")
+	if err != nil {
+		t.Fatalf("create cache: %v", err)
+	}
+	c.Shutdown()
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected no cache files left, got %d", len(entries))
+	}
+	// setting after shutdown is ignored
+	c.Set(testKey(1, 1), []byte("x"))
+	c.Set(testKey(1, 1), []byte("x"))
+}
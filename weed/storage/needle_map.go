@@ -18,6 +18,7 @@ const (
 	NeedleMapLevelDb                     // small memory footprint, 4MB total, 1 write buffer, 3 block buffer
 	NeedleMapLevelDbMedium               // medium memory footprint, 8MB total, 3 write buffer, 5 block buffer
 	NeedleMapLevelDbLarge                // large memory footprint, 12MB total, 4write buffer, 8 block buffer
+	NeedleMapPebble                      // one pebble db shared by all volumes in the index directory
 )
 
 type NeedleMapper interface {
@@ -42,6 +43,7 @@ type baseNeedleMapper struct {
 	indexFile           *os.File
 	indexFileAccessLock sync.Mutex
 	indexFileOffset     int64
+	offsetWidth         OffsetWidth // of the index file entries
 }
 
 type TempNeedleMapper interface {
@@ -59,7 +61,7 @@ func (nm *baseNeedleMapper) IndexFileSize() uint64 {
 }
 
 func (nm *baseNeedleMapper) appendToIndexFile(key NeedleId, offset Offset, size Size) error {
-	bytes := needle_map.ToBytes(key, offset, size)
+	bytes := needle_map.ToBytesWithWidth(nm.offsetWidth, key, offset, size)
 
 	nm.indexFileAccessLock.Lock()
 	defer nm.indexFileAccessLock.Unlock()
@@ -75,11 +77,12 @@ func (nm *baseNeedleMapper) Sync() error {
 }
 
 func (nm *baseNeedleMapper) ReadIndexEntry(n int64) (key NeedleId, offset Offset, size Size, err error) {
-	bytes := make([]byte, NeedleMapEntrySize)
+	entrySize := nm.offsetWidth.EntrySize()
+	bytes := make([]byte, entrySize)
 	var readCount int
-	if readCount, err = nm.indexFile.ReadAt(bytes, n*NeedleMapEntrySize); err != nil {
+	if readCount, err = nm.indexFile.ReadAt(bytes, n*int64(entrySize)); err != nil {
 		if err == io.EOF {
-			if readCount == NeedleMapEntrySize {
+			if readCount == entrySize {
 				err = nil
 			}
 		}
@@ -87,6 +90,6 @@ func (nm *baseNeedleMapper) ReadIndexEntry(n int64) (key NeedleId, offset Offset
 			return
 		}
 	}
-	key, offset, size = idx.IdxFileEntry(bytes)
+	key, offset, size = idx.IdxFileEntryWithWidth(nm.offsetWidth, bytes)
 	return
 }
@@ -26,7 +26,9 @@ const (
 )
 
 type CompactKey uint16
-type CompactOffset [types.OffsetSize]byte
+
+// CompactOffset holds offsets of any width
+type CompactOffset [types.MaxOffsetSize]byte
 type CompactNeedleValue struct {
 	key    CompactKey
 	offset CompactOffset
@@ -53,12 +55,12 @@ func (ck CompactKey) Key(chunk Chunk) types.NeedleId {
 
 func OffsetToCompact(offset types.Offset) CompactOffset {
 	var co CompactOffset
-	types.OffsetToBytes(co[:], offset)
+	types.OffsetWidth5.OffsetToBytes(co[:], offset)
 	return co
 }
 
 func (co CompactOffset) Offset() types.Offset {
-	return types.BytesToOffset(co[:])
+	return types.OffsetWidth5.BytesToOffset(co[:])
 }
 
 func (cnv CompactNeedleValue) NeedleValue(chunk Chunk) NeedleValue {
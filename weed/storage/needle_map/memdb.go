@@ -16,15 +16,24 @@ import (
 )
 
 // This map uses in memory level db
+// The offsets are kept with the largest width, so index files of any width can be loaded.
 type MemDb struct {
-	db *leveldb.DB
+	db    *leveldb.DB
+	width OffsetWidth // of the index files
 }
 
+const memDbOffsetWidth = OffsetWidth(MaxOffsetSize)
+
 func NewMemDb() *MemDb {
+	return NewMemDbWithOffsetWidth(DefaultOffsetWidth)
+}
+
+// NewMemDbWithOffsetWidth loads and saves index files with the offset width of their volume
+func NewMemDbWithOffsetWidth(width OffsetWidth) *MemDb {
 	opts := &opt.Options{}
 
 	var err error
-	t := &MemDb{}
+	t := &MemDb{width: width}
 	if t.db, err = leveldb.Open(storage.NewMemStorage(), opts); err != nil {
 		glog.V(0).Infof("MemDb fails to open: %v", err)
 		return nil
@@ -35,9 +44,9 @@ func NewMemDb() *MemDb {
 
 func (cm *MemDb) Set(key NeedleId, offset Offset, size Size) error {
 
-	bytes := ToBytes(key, offset, size)
+	bytes := ToBytesWithWidth(memDbOffsetWidth, key, offset, size)
 
-	if err := cm.db.Put(bytes[0:NeedleIdSize], bytes[NeedleIdSize:NeedleIdSize+OffsetSize+SizeSize], nil); err != nil {
+	if err := cm.db.Put(bytes[0:NeedleIdSize], bytes[NeedleIdSize:], nil); err != nil {
 		return fmt.Errorf("failed to write temp leveldb: %w", err)
 	}
 	return nil
@@ -53,11 +62,11 @@ func (cm *MemDb) Get(key NeedleId) (*NeedleValue, bool) {
 	bytes := make([]byte, NeedleIdSize)
 	NeedleIdToBytes(bytes[0:NeedleIdSize], key)
 	data, err := cm.db.Get(bytes, nil)
-	if err != nil || len(data) != OffsetSize+SizeSize {
+	if err != nil || len(data) != int(memDbOffsetWidth)+SizeSize {
 		return nil, false
 	}
-	offset := BytesToOffset(data[0:OffsetSize])
-	size := BytesToSize(data[OffsetSize : OffsetSize+SizeSize])
+	offset := memDbOffsetWidth.BytesToOffset(data[0:memDbOffsetWidth])
+	size := BytesToSize(data[memDbOffsetWidth : int(memDbOffsetWidth)+SizeSize])
 	return &NeedleValue{Key: key, Offset: offset, Size: size}, true
 }
 
@@ -65,8 +74,8 @@ func (cm *MemDb) Get(key NeedleId) (*NeedleValue, bool) {
 func doVisit(iter iterator.Iterator, visit func(NeedleValue) error) (ret error) {
 	key := BytesToNeedleId(iter.Key())
 	data := iter.Value()
-	offset := BytesToOffset(data[0:OffsetSize])
-	size := BytesToSize(data[OffsetSize : OffsetSize+SizeSize])
+	offset := memDbOffsetWidth.BytesToOffset(data[0:memDbOffsetWidth])
+	size := BytesToSize(data[memDbOffsetWidth : int(memDbOffsetWidth)+SizeSize])
 
 	needle := NeedleValue{Key: key, Offset: offset, Size: size}
 	ret = visit(needle)
@@ -126,7 +135,7 @@ func (cm *MemDb) SaveToIdx(idxName string) (ret error) {
 		if value.Offset.IsZero() || value.Size.IsDeleted() {
 			return nil
 		}
-		_, err := idxFile.Write(value.ToBytes())
+		_, err := idxFile.Write(value.ToBytesWithWidth(cm.width))
 		return err
 	})
 
@@ -149,7 +158,12 @@ func (cm *MemDb) LoadFromReaderAt(readerAt io.ReaderAt) (ret error) {
 }
 
 func (cm *MemDb) LoadFilterFromReaderAt(readerAt io.ReaderAt, isFilterOffsetZero bool, isFilterDeleted bool) (ret error) {
-	return idx.WalkIndexFile(readerAt, 0, func(key NeedleId, offset Offset, size Size) error {
+	return cm.LoadFilterFromReaderAtWithWidth(readerAt, cm.width, isFilterOffsetZero, isFilterDeleted)
+}
+
+// LoadFilterFromReaderAtWithWidth loads an index file of another offset width than the MemDb
+func (cm *MemDb) LoadFilterFromReaderAtWithWidth(readerAt io.ReaderAt, width OffsetWidth, isFilterOffsetZero bool, isFilterDeleted bool) (ret error) {
+	return idx.WalkIndexFileWithWidth(readerAt, width, 0, func(key NeedleId, offset Offset, size Size) error {
 		if (isFilterOffsetZero && offset.IsZero()) || (isFilterDeleted && size.IsDeleted()) {
 			return cm.Delete(key)
 		}
@@ -21,10 +21,20 @@ func (nv NeedleValue) ToBytes() []byte {
 	return ToBytes(nv.Key, nv.Offset, nv.Size)
 }
 
+func (nv NeedleValue) ToBytesWithWidth(width OffsetWidth) []byte {
+	return ToBytesWithWidth(width, nv.Key, nv.Offset, nv.Size)
+}
+
+// ToBytes returns the index file entry with the default offset width
 func ToBytes(key NeedleId, offset Offset, size Size) []byte {
-	bytes := make([]byte, NeedleIdSize+OffsetSize+SizeSize)
+	return ToBytesWithWidth(DefaultOffsetWidth, key, offset, size)
+}
+
+// ToBytesWithWidth returns the index file entry of a volume with the offset width
+func ToBytesWithWidth(width OffsetWidth, key NeedleId, offset Offset, size Size) []byte {
+	bytes := make([]byte, width.EntrySize())
 	NeedleIdToBytes(bytes[0:NeedleIdSize], key)
-	OffsetToBytes(bytes[NeedleIdSize:NeedleIdSize+OffsetSize], offset)
-	util.Uint32toBytes(bytes[NeedleIdSize+OffsetSize:NeedleIdSize+OffsetSize+SizeSize], uint32(size))
+	width.OffsetToBytes(bytes[NeedleIdSize:NeedleIdSize+int(width)], offset)
+	util.Uint32toBytes(bytes[NeedleIdSize+int(width):NeedleIdSize+int(width)+SizeSize], uint32(size))
 	return bytes
 }
@@ -39,12 +39,13 @@ type LevelDbNeedleMap struct {
 	recordCount uint64
 }
 
-func NewLevelDbNeedleMap(dbFileName string, indexFile *os.File, opts *opt.Options, ldbTimeout int64) (m *LevelDbNeedleMap, err error) {
+func NewLevelDbNeedleMap(dbFileName string, indexFile *os.File, opts *opt.Options, ldbTimeout int64, offsetWidth OffsetWidth) (m *LevelDbNeedleMap, err error) {
 	m = &LevelDbNeedleMap{dbFileName: dbFileName}
 	m.indexFile = indexFile
+	m.offsetWidth = offsetWidth
 	if !isLevelDbFresh(dbFileName, indexFile) {
 		glog.V(1).Infof("Start to Generate %s from %s", dbFileName, indexFile.Name())
-		generateLevelDbFile(dbFileName, indexFile)
+		generateLevelDbFile(dbFileName, indexFile, offsetWidth)
 		glog.V(1).Infof("Finished Generating %s from %s", dbFileName, indexFile.Name())
 	}
 	if stat, err := indexFile.Stat(); err != nil {
@@ -64,7 +65,7 @@ func NewLevelDbNeedleMap(dbFileName string, indexFile *os.File, opts *opt.Option
 			}
 		}
 		glog.V(0).Infof("Loading %s... , watermark: %d", dbFileName, getWatermark(m.db))
-		m.recordCount = uint64(m.indexFileOffset / NeedleMapEntrySize)
+		m.recordCount = uint64(m.indexFileOffset / int64(offsetWidth.EntrySize()))
 		watermark := (m.recordCount / watermarkBatchSize) * watermarkBatchSize
 		err = setWatermark(m.db, watermark)
 		if err != nil {
@@ -72,7 +73,7 @@ func NewLevelDbNeedleMap(dbFileName string, indexFile *os.File, opts *opt.Option
 			return
 		}
 	}
-	mm, indexLoadError := newNeedleMapMetricFromIndexFile(indexFile)
+	mm, indexLoadError := newNeedleMapMetricFromIndexFile(indexFile, offsetWidth)
 	if indexLoadError != nil {
 		return nil, indexLoadError
 	}
@@ -104,7 +105,7 @@ func isLevelDbFresh(dbFileName string, indexFile *os.File) bool {
 	return dbStat.ModTime().After(indexStat.ModTime())
 }
 
-func generateLevelDbFile(dbFileName string, indexFile *os.File) error {
+func generateLevelDbFile(dbFileName string, indexFile *os.File, offsetWidth OffsetWidth) error {
 	db, err := leveldb.OpenFile(dbFileName, nil)
 	if err != nil {
 		return err
@@ -116,14 +117,15 @@ func generateLevelDbFile(dbFileName string, indexFile *os.File) error {
 		glog.Fatalf("stat file %s: %v", indexFile.Name(), err)
 		return err
 	} else {
-		if watermark*NeedleMapEntrySize > uint64(stat.Size()) {
+		entrySize := uint64(offsetWidth.EntrySize())
+		if watermark*entrySize > uint64(stat.Size()) {
 			glog.Warningf("wrong watermark %d for filesize %d", watermark, stat.Size())
 		}
-		glog.V(0).Infof("generateLevelDbFile %s, watermark %d, num of entries:%d", dbFileName, watermark, (uint64(stat.Size())-watermark*NeedleMapEntrySize)/NeedleMapEntrySize)
+		glog.V(0).Infof("generateLevelDbFile %s, watermark %d, num of entries:%d", dbFileName, watermark, (uint64(stat.Size())-watermark*entrySize)/entrySize)
 	}
-	return idx.WalkIndexFile(indexFile, watermark, func(key NeedleId, offset Offset, size Size) error {
+	return idx.WalkIndexFileWithWidth(indexFile, offsetWidth, watermark, func(key NeedleId, offset Offset, size Size) error {
 		if !offset.IsZero() && !size.IsDeleted() {
-			levelDbWrite(db, key, offset, size, false, 0)
+			levelDbWrite(db, offsetWidth, key, offset, size, false, 0)
 		} else {
 			levelDbDelete(db, key)
 		}
@@ -143,11 +145,11 @@ func (m *LevelDbNeedleMap) Get(key NeedleId) (element *needle_map.NeedleValue, o
 	}
 	NeedleIdToBytes(bytes[0:NeedleIdSize], key)
 	data, err := m.db.Get(bytes, nil)
-	if err != nil || len(data) != OffsetSize+SizeSize {
+	if err != nil || len(data) != int(m.offsetWidth)+SizeSize {
 		return nil, false
 	}
-	offset := BytesToOffset(data[0:OffsetSize])
-	size := BytesToSize(data[OffsetSize : OffsetSize+SizeSize])
+	offset := m.offsetWidth.BytesToOffset(data[0:m.offsetWidth])
+	size := BytesToSize(data[m.offsetWidth : int(m.offsetWidth)+SizeSize])
 	return &needle_map.NeedleValue{Key: key, Offset: offset, Size: size}, true
 }
 
@@ -177,7 +179,7 @@ func (m *LevelDbNeedleMap) Put(key NeedleId, offset Offset, size Size) error {
 		watermark = (m.recordCount / watermarkBatchSize) * watermarkBatchSize
 		glog.V(1).Infof("put cnt:%d for %s,watermark: %d", m.recordCount, m.dbFileName, watermark)
 	}
-	return levelDbWrite(m.db, key, offset, size, watermark == 0, watermark)
+	return levelDbWrite(m.db, m.offsetWidth, key, offset, size, watermark == 0, watermark)
 }
 
 func getWatermark(db *leveldb.DB) uint64 {
@@ -199,11 +201,11 @@ func setWatermark(db *leveldb.DB, watermark uint64) error {
 	return nil
 }
 
-func levelDbWrite(db *leveldb.DB, key NeedleId, offset Offset, size Size, updateWatermark bool, watermark uint64) error {
+func levelDbWrite(db *leveldb.DB, offsetWidth OffsetWidth, key NeedleId, offset Offset, size Size, updateWatermark bool, watermark uint64) error {
 
-	bytes := needle_map.ToBytes(key, offset, size)
+	bytes := needle_map.ToBytesWithWidth(offsetWidth, key, offset, size)
 
-	if err := db.Put(bytes[0:NeedleIdSize], bytes[NeedleIdSize:NeedleIdSize+OffsetSize+SizeSize], nil); err != nil {
+	if err := db.Put(bytes[0:NeedleIdSize], bytes[NeedleIdSize:], nil); err != nil {
 		return fmt.Errorf("failed to write leveldb: %w", err)
 	}
 	// set watermark
@@ -245,7 +247,7 @@ func (m *LevelDbNeedleMap) Delete(key NeedleId, offset Offset) error {
 	} else {
 		watermark = (m.recordCount / watermarkBatchSize) * watermarkBatchSize
 	}
-	return levelDbWrite(m.db, key, oldNeedle.Offset, -oldNeedle.Size, watermark == 0, watermark)
+	return levelDbWrite(m.db, m.offsetWidth, key, oldNeedle.Offset, -oldNeedle.Size, watermark == 0, watermark)
 }
 
 func (m *LevelDbNeedleMap) Close() {
@@ -313,7 +315,7 @@ func (m *LevelDbNeedleMap) UpdateNeedleMap(v *Volume, indexFile *os.File, opts *
 		return e
 	}
 	m.indexFileOffset = stat.Size()
-	m.recordCount = uint64(stat.Size() / NeedleMapEntrySize)
+	m.recordCount = uint64(stat.Size() / int64(m.offsetWidth.EntrySize()))
 
 	//set watermark
 	watermark := (m.recordCount / watermarkBatchSize) * watermarkBatchSize
@@ -356,14 +358,14 @@ func (m *LevelDbNeedleMap) DoOffsetLoading(v *Volume, indexFile *os.File, startF
 		}
 	}
 
-	err = idx.WalkIndexFile(indexFile, startFrom, func(key NeedleId, offset Offset, size Size) (e error) {
+	err = idx.WalkIndexFileWithWidth(indexFile, m.offsetWidth, startFrom, func(key NeedleId, offset Offset, size Size) (e error) {
 		m.mapMetric.FileCounter++
 		bytes := make([]byte, NeedleIdSize)
 		NeedleIdToBytes(bytes[0:NeedleIdSize], key)
 		// fresh loading
 		if startFrom == 0 {
 			m.mapMetric.FileByteCounter += uint64(size)
-			e = levelDbWrite(db, key, offset, size, false, 0)
+			e = levelDbWrite(db, m.offsetWidth, key, offset, size, false, 0)
 			return e
 		}
 		// increment loading
@@ -375,11 +377,11 @@ func (m *LevelDbNeedleMap) DoOffsetLoading(v *Volume, indexFile *os.File, startF
 			}
 			// new needle, unlikely happen
 			m.mapMetric.FileByteCounter += uint64(size)
-			e = levelDbWrite(db, key, offset, size, false, 0)
+			e = levelDbWrite(db, m.offsetWidth, key, offset, size, false, 0)
 		} else {
 			// needle is found
-			oldSize := BytesToSize(data[OffsetSize : OffsetSize+SizeSize])
-			oldOffset := BytesToOffset(data[0:OffsetSize])
+			oldSize := BytesToSize(data[m.offsetWidth : int(m.offsetWidth)+SizeSize])
+			oldOffset := m.offsetWidth.BytesToOffset(data[0:m.offsetWidth])
 			if !offset.IsZero() && !size.IsDeleted() {
 				// updated needle
 				m.mapMetric.FileByteCounter += uint64(size)
@@ -387,7 +389,7 @@ func (m *LevelDbNeedleMap) DoOffsetLoading(v *Volume, indexFile *os.File, startF
 					m.mapMetric.DeletionCounter++
 					m.mapMetric.DeletionByteCounter += uint64(oldSize)
 				}
-				e = levelDbWrite(db, key, offset, size, false, 0)
+				e = levelDbWrite(db, m.offsetWidth, key, offset, size, false, 0)
 			} else {
 				// deleted needle
 				m.mapMetric.DeletionCounter++
@@ -15,11 +15,12 @@ type NeedleMap struct {
 	m needle_map.NeedleValueMap
 }
 
-func NewCompactNeedleMap(file *os.File) *NeedleMap {
+func NewCompactNeedleMap(file *os.File, offsetWidth OffsetWidth) *NeedleMap {
 	nm := &NeedleMap{
 		m: needle_map.NewCompactMap(),
 	}
 	nm.indexFile = file
+	nm.offsetWidth = offsetWidth
 	stat, err := file.Stat()
 	if err != nil {
 		glog.Fatalf("stat file %s: %v", file.Name(), err)
@@ -28,13 +29,13 @@ func NewCompactNeedleMap(file *os.File) *NeedleMap {
 	return nm
 }
 
-func LoadCompactNeedleMap(file *os.File) (*NeedleMap, error) {
-	nm := NewCompactNeedleMap(file)
+func LoadCompactNeedleMap(file *os.File, offsetWidth OffsetWidth) (*NeedleMap, error) {
+	nm := NewCompactNeedleMap(file, offsetWidth)
 	return doLoading(file, nm)
 }
 
 func doLoading(file *os.File, nm *NeedleMap) (*NeedleMap, error) {
-	e := idx.WalkIndexFile(file, 0, func(key NeedleId, offset Offset, size Size) error {
+	e := idx.WalkIndexFileWithWidth(file, nm.offsetWidth, 0, func(key NeedleId, offset Offset, size Size) error {
 		nm.MaybeSetMaxFileKey(key)
 		if !offset.IsZero() && !size.IsDeleted() {
 			nm.FileCounter++
@@ -109,7 +110,7 @@ func (nm *NeedleMap) UpdateNeedleMap(v *Volume, indexFile *os.File, opts *opt.Op
 
 func (nm *NeedleMap) DoOffsetLoading(v *Volume, indexFile *os.File, startFrom uint64) error {
 	glog.V(0).Infof("loading idx from offset %d for file: %s", startFrom, indexFile.Name())
-	e := idx.WalkIndexFile(indexFile, startFrom, func(key NeedleId, offset Offset, size Size) error {
+	e := idx.WalkIndexFileWithWidth(indexFile, nm.offsetWidth, startFrom, func(key NeedleId, offset Offset, size Size) error {
 		nm.MaybeSetMaxFileKey(key)
 		nm.FileCounter++
 		if !offset.IsZero() && !size.IsDeleted() {
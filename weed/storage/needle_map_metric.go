@@ -92,10 +92,10 @@ func (mm *mapMetric) MaybeSetMaxFileKey(key NeedleId) {
 	}
 }
 
-func needleMapMetricFromIndexFile(r *os.File, mm *mapMetric) error {
+func needleMapMetricFromIndexFile(r *os.File, offsetWidth OffsetWidth, mm *mapMetric) error {
 	var bf *boom.BloomFilter
 	buf := make([]byte, NeedleIdSize)
-	err := reverseWalkIndexFile(r, func(entryCount int64) {
+	err := reverseWalkIndexFile(r, offsetWidth, func(entryCount int64) {
 		bf = boom.NewBloomFilter(uint(entryCount), 0.001)
 	}, func(key NeedleId, offset Offset, size Size) error {
 
@@ -124,28 +124,29 @@ func needleMapMetricFromIndexFile(r *os.File, mm *mapMetric) error {
 	return err
 }
 
-func newNeedleMapMetricFromIndexFile(r *os.File) (mm *mapMetric, err error) {
+func newNeedleMapMetricFromIndexFile(r *os.File, offsetWidth OffsetWidth) (mm *mapMetric, err error) {
 	mm = &mapMetric{}
-	err = needleMapMetricFromIndexFile(r, mm)
+	err = needleMapMetricFromIndexFile(r, offsetWidth, mm)
 	return
 }
 
-func reverseWalkIndexFile(r *os.File, initFn func(entryCount int64), fn func(key NeedleId, offset Offset, size Size) error) error {
+func reverseWalkIndexFile(r *os.File, offsetWidth OffsetWidth, initFn func(entryCount int64), fn func(key NeedleId, offset Offset, size Size) error) error {
 	fi, err := r.Stat()
 	if err != nil {
 		return fmt.Errorf("file %s stat error: %v", r.Name(), err)
 	}
 	fileSize := fi.Size()
-	if fileSize%NeedleMapEntrySize != 0 {
+	entrySize := int64(offsetWidth.EntrySize())
+	if fileSize%entrySize != 0 {
 		return fmt.Errorf("unexpected file %s size: %d", r.Name(), fileSize)
 	}
 
-	entryCount := fileSize / NeedleMapEntrySize
+	entryCount := fileSize / entrySize
 	initFn(entryCount)
 
 	batchSize := int64(1024 * 4)
 
-	bytes := make([]byte, NeedleMapEntrySize*batchSize)
+	bytes := make([]byte, entrySize*batchSize)
 	nextBatchSize := entryCount % batchSize
 	if nextBatchSize == 0 {
 		nextBatchSize = batchSize
@@ -153,16 +154,16 @@ func reverseWalkIndexFile(r *os.File, initFn func(entryCount int64), fn func(key
 	remainingCount := entryCount - nextBatchSize
 
 	for remainingCount >= 0 {
-		n, e := r.ReadAt(bytes[:NeedleMapEntrySize*nextBatchSize], NeedleMapEntrySize*remainingCount)
-		// glog.V(0).Infoln("file", r.Name(), "readerOffset", NeedleMapEntrySize*remainingCount, "count", count, "e", e)
-		if e == io.EOF && n == int(NeedleMapEntrySize*nextBatchSize) {
+		n, e := r.ReadAt(bytes[:entrySize*nextBatchSize], entrySize*remainingCount)
+		// glog.V(0).Infoln("file", r.Name(), "readerOffset", entrySize*remainingCount, "count", count, "e", e)
+		if e == io.EOF && n == int(entrySize*nextBatchSize) {
 			e = nil
 		}
 		if e != nil {
 			return e
 		}
 		for i := int(nextBatchSize) - 1; i >= 0; i-- {
-			key, offset, size := idx.IdxFileEntry(bytes[i*NeedleMapEntrySize : i*NeedleMapEntrySize+NeedleMapEntrySize])
+			key, offset, size := idx.IdxFileEntryWithWidth(offsetWidth, bytes[int64(i)*entrySize:int64(i+1)*entrySize])
 			if e = fn(key, offset, size); e != nil {
 				return e
 			}
@@ -12,7 +12,7 @@ import (
 func TestFastLoadingNeedleMapMetrics(t *testing.T) {
 
 	idxFile, _ := os.CreateTemp("", "tmp.idx")
-	nm := NewCompactNeedleMap(idxFile)
+	nm := NewCompactNeedleMap(idxFile, DefaultOffsetWidth)
 
 	for i := 0; i < 10000; i++ {
 		nm.Put(Uint64ToNeedleId(uint64(i+1)), Uint32ToOffset(uint32(0)), Size(1))
@@ -21,7 +21,7 @@ func TestFastLoadingNeedleMapMetrics(t *testing.T) {
 		}
 	}
 
-	mm, _ := newNeedleMapMetricFromIndexFile(idxFile)
+	mm, _ := newNeedleMapMetricFromIndexFile(idxFile, DefaultOffsetWidth)
 
 	glog.V(0).Infof("FileCount expected %d actual %d", nm.FileCount(), mm.FileCount())
 	glog.V(0).Infof("DeletedSize expected %d actual %d", nm.DeletedSize(), mm.DeletedSize())
@@ -0,0 +1,519 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble/v2"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+/*
+All volumes in one index directory share one pebble db, so a server with many volumes
+does not keep one leveldb per volume open.
+
+	'n' + volume id + needle id => offset + size
+	's' + volume id             => volume state
+
+The .idx file is still written first, and stays the source of truth.
+The volume state records how many .idx entries are in the db, and is committed in the same batch
+as the entries, so after a crash only the .idx entries after the watermark are replayed.
+It also records the size, modification time and tail checksum of the .idx file, so the entries
+are rebuilt if the .idx file is replaced, e.g. by a volume copy or "weed fix".
+*/
+
+const (
+	pebbleNeedleMapDirName = "needles.pebble"
+
+	// commit the pending entries every pebbleBatchSize operations
+	pebbleBatchSize = 1024
+
+	pebbleEntryPrefix = 'n'
+	pebbleStatePrefix = 's'
+
+	pebbleVolumeStateSize = 1 + 2 + 8 + 4 + 4 + 8 + 8 + 8 + 8 + 8 + 4
+
+	// the checksum covers the last .idx bytes before the watermark
+	pebbleTailChecksumSize = 4096
+)
+
+type pebbleNeedleDb struct {
+	dir      string
+	db       *pebble.DB
+	refCount int
+}
+
+var (
+	pebbleNeedleDbs     = make(map[string]*pebbleNeedleDb)
+	pebbleNeedleDbsLock sync.Mutex
+)
+
+// acquirePebbleNeedleDb opens the shared db of the index directory if it is not opened yet
+func acquirePebbleNeedleDb(dirIdx string) (*pebbleNeedleDb, error) {
+	dir := filepath.Join(dirIdx, pebbleNeedleMapDirName)
+
+	pebbleNeedleDbsLock.Lock()
+	defer pebbleNeedleDbsLock.Unlock()
+
+	if d, found := pebbleNeedleDbs[dir]; found {
+		d.refCount++
+		return d, nil
+	}
+	glog.V(0).Infof("opening needle map %s", dir)
+	db, err := pebble.Open(dir, &pebble.Options{
+		Logger: pebbleLogger{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %v", dir, err)
+	}
+	d := &pebbleNeedleDb{dir: dir, db: db, refCount: 1}
+	pebbleNeedleDbs[dir] = d
+	return d, nil
+}
+
+// release closes the shared db after the last volume using it is closed
+func (d *pebbleNeedleDb) release() {
+	pebbleNeedleDbsLock.Lock()
+	defer pebbleNeedleDbsLock.Unlock()
+
+	d.refCount--
+	if d.refCount > 0 {
+		return
+	}
+	delete(pebbleNeedleDbs, d.dir)
+	glog.V(0).Infof("closing needle map %s", d.dir)
+	if err := d.db.Close(); err != nil {
+		glog.Warningf("close %s: %v", d.dir, err)
+	}
+}
+
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...interface{}) {
+	glog.V(1).Infof(format, args...)
+}
+
+func (pebbleLogger) Errorf(format string, args ...interface{}) {
+	glog.Errorf(format, args...)
+}
+
+func (pebbleLogger) Fatalf(format string, args ...interface{}) {
+	glog.Fatalf(format, args...)
+}
+
+type PebbleNeedleMap struct {
+	baseNeedleMapper
+	volumeId           needle.VolumeId
+	compactionRevision uint16
+	shared             *pebbleNeedleDb
+	batch              *pebble.Batch
+	accessLock         sync.RWMutex
+	recordCount        uint64 // number of .idx entries applied to the db or the pending batch
+}
+
+func NewPebbleNeedleMap(dirIdx string, volumeId needle.VolumeId, compactionRevision uint16, indexFile *os.File, offsetWidth OffsetWidth) (m *PebbleNeedleMap, err error) {
+	shared, err := acquirePebbleNeedleDb(dirIdx)
+	if err != nil {
+		return nil, err
+	}
+	m = &PebbleNeedleMap{volumeId: volumeId, compactionRevision: compactionRevision, shared: shared}
+	m.indexFile = indexFile
+	m.offsetWidth = offsetWidth
+	if err = m.load(); err != nil {
+		if m.batch != nil {
+			m.batch.Close()
+		}
+		shared.release()
+		return nil, err
+	}
+	return m, nil
+}
+
+// load replays the .idx entries after the watermark, or rebuilds the volume entries if the state is stale
+func (m *PebbleNeedleMap) load() error {
+	stat, err := m.indexFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %v", m.indexFile.Name(), err)
+	}
+	m.indexFileOffset = stat.Size()
+	indexRecordCount := uint64(stat.Size() / int64(m.offsetWidth.EntrySize()))
+
+	state, found, err := m.readState()
+	if err != nil {
+		return err
+	}
+	switch {
+	case !found:
+		glog.V(0).Infof("building needle map of volume %d from %s", m.volumeId, m.indexFile.Name())
+	case state.offsetWidth != m.offsetWidth || state.compactionRevision != m.compactionRevision:
+		glog.V(0).Infof("rebuilding needle map of volume %d: offset width %d revision %d, expected %d revision %d",
+			m.volumeId, state.offsetWidth, state.compactionRevision, m.offsetWidth, m.compactionRevision)
+		found = false
+	case state.recordCount > indexRecordCount:
+		glog.Warningf("rebuilding needle map of volume %d: watermark %d is beyond %d entries in %s",
+			m.volumeId, state.recordCount, indexRecordCount, m.indexFile.Name())
+		found = false
+	case !m.isSameIndexFile(state, stat):
+		glog.Warningf("rebuilding needle map of volume %d: %s is changed", m.volumeId, m.indexFile.Name())
+		found = false
+	}
+	if found {
+		m.recordCount = state.recordCount
+		m.mapMetric = state.mapMetric
+	} else if err = m.clear(); err != nil {
+		return err
+	}
+
+	m.batch = m.shared.db.NewIndexedBatch()
+	glog.V(1).Infof("loading needle map of volume %d from %s, watermark %d of %d entries",
+		m.volumeId, m.indexFile.Name(), m.recordCount, indexRecordCount)
+	err = idx.WalkIndexFileWithWidth(m.indexFile, m.offsetWidth, m.recordCount, func(key NeedleId, offset Offset, size Size) error {
+		if !offset.IsZero() && !size.IsDeleted() {
+			return m.doPut(key, offset, size)
+		}
+		return m.doDelete(key)
+	})
+	if err != nil {
+		return fmt.Errorf("load %s: %v", m.indexFile.Name(), err)
+	}
+	return m.commit()
+}
+
+// isSameIndexFile checks the .idx file is the one the state is committed with, maybe appended after the commit
+func (m *PebbleNeedleMap) isSameIndexFile(state pebbleVolumeState, stat os.FileInfo) bool {
+	if stat.Size() < state.idxSize {
+		return false
+	}
+	if stat.Size() == state.idxSize && stat.ModTime().UnixNano() != state.idxModTime {
+		return false
+	}
+	checksum, err := m.tailChecksum(state.recordCount)
+	if err != nil {
+		glog.Warningf("read tail of %s: %v", m.indexFile.Name(), err)
+		return false
+	}
+	return checksum == state.idxTailChecksum
+}
+
+// tailChecksum is the checksum of the last .idx bytes before the watermark
+func (m *PebbleNeedleMap) tailChecksum(recordCount uint64) (uint32, error) {
+	end := int64(recordCount) * int64(m.offsetWidth.EntrySize())
+	start := max(end-pebbleTailChecksumSize, 0)
+	data := make([]byte, end-start)
+	if _, err := m.indexFile.ReadAt(data, start); err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(data), nil
+}
+
+// clear removes the volume entries and resets the state
+func (m *PebbleNeedleMap) clear() error {
+	m.recordCount = 0
+	m.mapMetric = mapMetric{}
+	state, err := m.stateBytes()
+	if err != nil {
+		return err
+	}
+	batch := m.shared.db.NewBatch()
+	defer batch.Close()
+	start, end := pebbleEntryRange(m.volumeId)
+	if err := batch.DeleteRange(start, end, nil); err != nil {
+		return err
+	}
+	if err := batch.Set(pebbleVolumeKey(pebbleStatePrefix, m.volumeId), state, nil); err != nil {
+		return err
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return fmt.Errorf("clear needle map of volume %d: %v", m.volumeId, err)
+	}
+	return nil
+}
+
+func (m *PebbleNeedleMap) Get(key NeedleId) (element *needle_map.NeedleValue, ok bool) {
+	m.accessLock.RLock()
+	defer m.accessLock.RUnlock()
+	if m.batch == nil {
+		return nil, false
+	}
+	return m.get(key)
+}
+
+func (m *PebbleNeedleMap) get(key NeedleId) (element *needle_map.NeedleValue, ok bool) {
+	data, closer, err := m.batch.Get(m.entryKey(key))
+	if err != nil {
+		if !errors.Is(err, pebble.ErrNotFound) {
+			glog.Errorf("read needle %d of volume %d: %v", key, m.volumeId, err)
+		}
+		return nil, false
+	}
+	defer closer.Close()
+	if len(data) != int(m.offsetWidth)+SizeSize {
+		return nil, false
+	}
+	offset := m.offsetWidth.BytesToOffset(data[0:m.offsetWidth])
+	size := BytesToSize(data[m.offsetWidth : int(m.offsetWidth)+SizeSize])
+	return &needle_map.NeedleValue{Key: key, Offset: offset, Size: size}, true
+}
+
+func (m *PebbleNeedleMap) Put(key NeedleId, offset Offset, size Size) error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if m.batch == nil {
+		return fmt.Errorf("needle map of volume %d is closed", m.volumeId)
+	}
+	// write to index file first
+	if err := m.appendToIndexFile(key, offset, size); err != nil {
+		return fmt.Errorf("cannot write to indexfile %s: %v", m.indexFile.Name(), err)
+	}
+	return m.doPut(key, offset, size)
+}
+
+func (m *PebbleNeedleMap) doPut(key NeedleId, offset Offset, size Size) error {
+	var oldSize Size
+	if oldNeedle, ok := m.get(key); ok {
+		oldSize = oldNeedle.Size
+	}
+	m.logPut(key, oldSize, size)
+	return m.write(key, offset, size)
+}
+
+func (m *PebbleNeedleMap) Delete(key NeedleId, offset Offset) error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if m.batch == nil {
+		return fmt.Errorf("needle map of volume %d is closed", m.volumeId)
+	}
+	oldNeedle, found := m.get(key)
+	if !found || oldNeedle.Size.IsDeleted() {
+		return nil
+	}
+	// write to index file first
+	if err := m.appendToIndexFile(key, offset, TombstoneFileSize); err != nil {
+		return err
+	}
+	return m.doDelete(key)
+}
+
+func (m *PebbleNeedleMap) doDelete(key NeedleId) error {
+	oldNeedle, found := m.get(key)
+	if !found || oldNeedle.Size.IsDeleted() {
+		m.recordCount++
+		return m.maybeCommit()
+	}
+	m.logDelete(oldNeedle.Size)
+	return m.write(key, oldNeedle.Offset, -oldNeedle.Size)
+}
+
+// write adds the entry of one .idx record to the pending batch
+func (m *PebbleNeedleMap) write(key NeedleId, offset Offset, size Size) error {
+	bytes := needle_map.ToBytesWithWidth(m.offsetWidth, key, offset, size)
+	if err := m.batch.Set(m.entryKey(key), bytes[NeedleIdSize:], nil); err != nil {
+		return fmt.Errorf("write needle %d of volume %d: %v", key, m.volumeId, err)
+	}
+	m.recordCount++
+	return m.maybeCommit()
+}
+
+func (m *PebbleNeedleMap) maybeCommit() error {
+	if m.recordCount%pebbleBatchSize != 0 {
+		return nil
+	}
+	return m.commit()
+}
+
+// commit writes the pending entries together with the volume state.
+// The .idx file is replayed after a crash, so the commit does not need to sync.
+func (m *PebbleNeedleMap) commit() error {
+	state, err := m.stateBytes()
+	if err != nil {
+		return err
+	}
+	if err := m.batch.Set(pebbleVolumeKey(pebbleStatePrefix, m.volumeId), state, nil); err != nil {
+		return err
+	}
+	if err := m.batch.Commit(pebble.NoSync); err != nil {
+		return fmt.Errorf("commit needle map of volume %d: %v", m.volumeId, err)
+	}
+	m.batch.Close()
+	m.batch = m.shared.db.NewIndexedBatch()
+	return nil
+}
+
+func (m *PebbleNeedleMap) Sync() error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if m.batch != nil {
+		if err := m.commit(); err != nil {
+			return err
+		}
+	}
+	return m.indexFile.Sync()
+}
+
+func (m *PebbleNeedleMap) Close() {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if m.batch == nil {
+		return
+	}
+	// the state records the identity of the .idx file, so it is committed before closing the file
+	if err := m.commit(); err != nil {
+		glog.Warningf("close needle map of volume %d: %v", m.volumeId, err)
+	}
+	m.closeIndexFile()
+	m.batch.Close()
+	m.batch = nil
+	m.shared.release()
+}
+
+// Destroy removes the volume entries from the shared db, and the .idx file
+func (m *PebbleNeedleMap) Destroy() error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if m.batch == nil {
+		return fmt.Errorf("needle map of volume %d is closed", m.volumeId)
+	}
+	m.closeIndexFile()
+	os.Remove(m.indexFile.Name())
+	m.batch.Close()
+	m.batch = nil
+	defer m.shared.release()
+	return m.shared.dropVolume(m.volumeId)
+}
+
+// dropVolume removes the entries and the state of the volume
+func (d *pebbleNeedleDb) dropVolume(volumeId needle.VolumeId) error {
+	start, end := pebbleEntryRange(volumeId)
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(start, end, nil); err != nil {
+		return err
+	}
+	if err := batch.Delete(pebbleVolumeKey(pebbleStatePrefix, volumeId), nil); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// DropPebbleNeedleMap removes the entries of a volume not loaded, whose files are removed or replaced
+func DropPebbleNeedleMap(dirIdx string, volumeId needle.VolumeId) error {
+	if !util.FolderExists(filepath.Join(dirIdx, pebbleNeedleMapDirName)) {
+		return nil
+	}
+	shared, err := acquirePebbleNeedleDb(dirIdx)
+	if err != nil {
+		return err
+	}
+	defer shared.release()
+	if err = shared.dropVolume(volumeId); err != nil {
+		return fmt.Errorf("drop needle map of volume %d: %v", volumeId, err)
+	}
+	return nil
+}
+
+func (m *PebbleNeedleMap) closeIndexFile() {
+	indexFileName := m.indexFile.Name()
+	if err := m.indexFile.Sync(); err != nil {
+		glog.Warningf("sync file %s failed: %v", indexFileName, err)
+	}
+	if err := m.indexFile.Close(); err != nil {
+		glog.Warningf("close index file %s failed: %v", indexFileName, err)
+	}
+}
+
+type pebbleVolumeState struct {
+	mapMetric
+	offsetWidth        OffsetWidth
+	compactionRevision uint16
+	recordCount        uint64
+	idxSize            int64
+	idxModTime         int64
+	idxTailChecksum    uint32
+}
+
+func (m *PebbleNeedleMap) readState() (state pebbleVolumeState, found bool, err error) {
+	data, closer, err := m.shared.db.Get(pebbleVolumeKey(pebbleStatePrefix, m.volumeId))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, fmt.Errorf("read needle map state of volume %d: %v", m.volumeId, err)
+	}
+	defer closer.Close()
+	if len(data) != pebbleVolumeStateSize {
+		glog.Warningf("needle map state of volume %d has %d bytes", m.volumeId, len(data))
+		return state, false, nil
+	}
+	state.offsetWidth = OffsetWidth(data[0])
+	state.compactionRevision = binary.BigEndian.Uint16(data[1:3])
+	state.recordCount = binary.BigEndian.Uint64(data[3:11])
+	state.FileCounter = binary.BigEndian.Uint32(data[11:15])
+	state.DeletionCounter = binary.BigEndian.Uint32(data[15:19])
+	state.FileByteCounter = binary.BigEndian.Uint64(data[19:27])
+	state.DeletionByteCounter = binary.BigEndian.Uint64(data[27:35])
+	state.MaximumFileKey = binary.BigEndian.Uint64(data[35:43])
+	state.idxSize = int64(binary.BigEndian.Uint64(data[43:51]))
+	state.idxModTime = int64(binary.BigEndian.Uint64(data[51:59]))
+	state.idxTailChecksum = binary.BigEndian.Uint32(data[59:63])
+	return state, true, nil
+}
+
+func (m *PebbleNeedleMap) stateBytes() ([]byte, error) {
+	stat, err := m.indexFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %v", m.indexFile.Name(), err)
+	}
+	checksum, err := m.tailChecksum(m.recordCount)
+	if err != nil {
+		return nil, fmt.Errorf("read tail of %s: %v", m.indexFile.Name(), err)
+	}
+	data := make([]byte, pebbleVolumeStateSize)
+	data[0] = byte(m.offsetWidth)
+	binary.BigEndian.PutUint16(data[1:3], m.compactionRevision)
+	binary.BigEndian.PutUint64(data[3:11], m.recordCount)
+	binary.BigEndian.PutUint32(data[11:15], uint32(m.FileCount()))
+	binary.BigEndian.PutUint32(data[15:19], uint32(m.DeletedCount()))
+	binary.BigEndian.PutUint64(data[19:27], m.ContentSize())
+	binary.BigEndian.PutUint64(data[27:35], m.DeletedSize())
+	binary.BigEndian.PutUint64(data[35:43], uint64(m.MaxFileKey()))
+	binary.BigEndian.PutUint64(data[43:51], uint64(stat.Size()))
+	binary.BigEndian.PutUint64(data[51:59], uint64(stat.ModTime().UnixNano()))
+	binary.BigEndian.PutUint32(data[59:63], checksum)
+	return data, nil
+}
+
+func (m *PebbleNeedleMap) entryKey(key NeedleId) []byte {
+	bytes := make([]byte, 1+4+NeedleIdSize)
+	copy(bytes, pebbleVolumeKey(pebbleEntryPrefix, m.volumeId))
+	NeedleIdToBytes(bytes[1+4:], key)
+	return bytes
+}
+
+// pebbleEntryRange covers all needle ids of the volume
+func pebbleEntryRange(volumeId needle.VolumeId) (start, end []byte) {
+	start = pebbleVolumeKey(pebbleEntryPrefix, volumeId)
+	end = make([]byte, 1+4+NeedleIdSize+1)
+	copy(end, start)
+	for i := 1 + 4; i < 1+4+NeedleIdSize; i++ {
+		end[i] = 0xff
+	}
+	return
+}
+
+func pebbleVolumeKey(prefix byte, volumeId needle.VolumeId) []byte {
+	bytes := make([]byte, 1+4)
+	bytes[0] = prefix
+	binary.BigEndian.PutUint32(bytes[1:], uint32(volumeId))
+	return bytes
+}
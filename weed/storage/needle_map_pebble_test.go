@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestPebbleNeedleMap(t *testing.T) {
+	dir := t.TempDir()
+
+	// a leveldb volume to migrate
+	v3, err := NewVolume(dir, dir, "", 3, NeedleMapLevelDb, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	infos3 := make([]*needleInfo, 100)
+	for i := 1; i <= len(infos3); i++ {
+		doSomeWritesDeletes(i, v3, t, infos3)
+	}
+	v3.Close()
+
+	v1, err := NewVolume(dir, dir, "", 1, NeedleMapPebble, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	v2, err := NewVolume(dir, dir, "", 2, NeedleMapPebble, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	if v1.nm.(*PebbleNeedleMap).shared != v2.nm.(*PebbleNeedleMap).shared {
+		t.Fatalf("volumes in one directory do not share the pebble db")
+	}
+
+	infos1 := make([]*needleInfo, 3000)
+	infos2 := make([]*needleInfo, 3000)
+	for i := 1; i <= len(infos1); i++ {
+		doSomeWritesDeletes(i, v1, t, infos1)
+		doSomeWritesDeletes(i, v2, t, infos2)
+	}
+	checkNeedles(t, v1, infos1)
+	checkNeedles(t, v2, infos2)
+
+	// crash without committing the pending entries
+	nm := v1.nm.(*PebbleNeedleMap)
+	metric := nm.mapMetric
+	if nm.batch.Count() == 0 {
+		t.Fatalf("expected pending entries")
+	}
+	nm.batch.Close()
+	nm.batch = nil
+	nm.closeIndexFile()
+	nm.shared.release()
+	v1.nm = nil
+	v1.Close()
+
+	v1, err = NewVolume(dir, dir, "", 1, NeedleMapPebble, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume reloading: %v", err)
+	}
+	if v1.nm.(*PebbleNeedleMap).mapMetric != metric {
+		t.Fatalf("replayed metric %+v, expected %+v", v1.nm.(*PebbleNeedleMap).mapMetric, metric)
+	}
+	checkNeedles(t, v1, infos1)
+
+	// compacted volumes are rebuilt
+	if err = v1.Compact2(0, 0, nil); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if err = v1.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	if v1.nm.(*PebbleNeedleMap).compactionRevision != 1 {
+		t.Fatalf("compaction revision %d", v1.nm.(*PebbleNeedleMap).compactionRevision)
+	}
+	checkNeedles(t, v1, infos1)
+
+	// destroyed volumes leave no entries behind
+	shared := v1.nm.(*PebbleNeedleMap).shared
+	if err = v2.Destroy(false); err != nil {
+		t.Fatalf("destroy: %v", err)
+	}
+	start, end := pebbleEntryRange(2)
+	iter, err := shared.db.NewIter(nil)
+	if err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+	for iter.SeekGE(start); iter.Valid() && string(iter.Key()) < string(end); iter.Next() {
+		t.Fatalf("entry %x of destroyed volume", iter.Key())
+	}
+	iter.Close()
+
+	// leveldb volumes are migrated
+	v3, err = NewVolume(dir, dir, "", 3, NeedleMapPebble, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume migration: %v", err)
+	}
+	if util.FileExists(v3.FileName(".ldb")) {
+		t.Fatalf("migrated %s is not removed", v3.FileName(".ldb"))
+	}
+	checkNeedles(t, v3, infos3)
+	v3.Close()
+
+	v1.Close()
+	if len(pebbleNeedleDbs) != 0 {
+		t.Fatalf("pebble db is not closed")
+	}
+}
+
+func checkNeedles(t *testing.T, v *Volume, infos []*needleInfo) {
+	for i := 1; i <= len(infos); i++ {
+		if infos[i-1].size == 0 {
+			continue
+		}
+		n := newEmptyNeedle(uint64(i))
+		size, err := v.readNeedle(n, nil, nil)
+		if err != nil {
+			t.Fatalf("read file %d: %v", i, err)
+		}
+		if infos[i-1].size != types.Size(size) || infos[i-1].crc != n.Checksum {
+			t.Fatalf("read file %d mismatch", i)
+		}
+	}
+}
+
+func TestPebbleNeedleMapReplacedIndexFile(t *testing.T) {
+	dir := t.TempDir()
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapPebble, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	infos := make([]*needleInfo, 100)
+	for i := 1; i <= len(infos); i++ {
+		doSomeWritesDeletes(i, v, t, infos)
+	}
+	offsetWidth := v.OffsetWidth()
+	v.Close()
+
+	// an entry not in the .idx file tells whether the entries are rebuilt
+	const strayKey = types.NeedleId(1000000)
+	addStrayEntry := func() {
+		shared, err := acquirePebbleNeedleDb(dir)
+		if err != nil {
+			t.Fatalf("open pebble db: %v", err)
+		}
+		defer shared.release()
+		m := &PebbleNeedleMap{volumeId: 1, shared: shared}
+		value := needle_map.ToBytesWithWidth(offsetWidth, strayKey, types.ToOffset(8), 1)[types.NeedleIdSize:]
+		if err = shared.db.Set(m.entryKey(strayKey), value, nil); err != nil {
+			t.Fatalf("set stray entry: %v", err)
+		}
+	}
+	reload := func() bool {
+		v, err := NewVolume(dir, dir, "", 1, NeedleMapPebble, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+		if err != nil {
+			t.Fatalf("volume reloading: %v", err)
+		}
+		defer v.Close()
+		checkNeedles(t, v, infos)
+		_, found := v.nm.Get(strayKey)
+		return !found
+	}
+
+	addStrayEntry()
+	if reload() {
+		t.Fatalf("needle map of the unchanged .idx file is rebuilt")
+	}
+
+	// the .idx file of the same size is replaced
+	if err = os.Chtimes(v.FileName(".idx"), time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("touch .idx file: %v", err)
+	}
+	if !reload() {
+		t.Fatalf("needle map of the replaced .idx file is not rebuilt")
+	}
+
+	// the entries are dropped with the volume files
+	addStrayEntry()
+	if err = DropPebbleNeedleMap(dir, 1); err != nil {
+		t.Fatalf("drop needle map: %v", err)
+	}
+	if !reload() {
+		t.Fatalf("dropped needle map is not rebuilt")
+	}
+}
@@ -16,13 +16,14 @@ type SortedFileNeedleMap struct {
 	dbFileSize   int64
 }
 
-func NewSortedFileNeedleMap(indexBaseFileName string, indexFile *os.File) (m *SortedFileNeedleMap, err error) {
+func NewSortedFileNeedleMap(indexBaseFileName string, indexFile *os.File, offsetWidth OffsetWidth) (m *SortedFileNeedleMap, err error) {
 	m = &SortedFileNeedleMap{baseFileName: indexBaseFileName}
 	m.indexFile = indexFile
+	m.offsetWidth = offsetWidth
 	fileName := indexBaseFileName + ".sdx"
 	if !isSortedFileFresh(fileName, indexFile) {
 		glog.V(0).Infof("Start to Generate %s from %s", fileName, indexFile.Name())
-		erasure_coding.WriteSortedFileFromIdx(indexBaseFileName, ".sdx")
+		erasure_coding.WriteSortedFileFromIdxWithWidth(indexBaseFileName, ".sdx", offsetWidth)
 		glog.V(0).Infof("Finished Generating %s from %s", fileName, indexFile.Name())
 	}
 	glog.V(1).Infof("Opening %s...", fileName)
@@ -33,7 +34,7 @@ func NewSortedFileNeedleMap(indexBaseFileName string, indexFile *os.File) (m *So
 	dbStat, _ := m.dbFile.Stat()
 	m.dbFileSize = dbStat.Size()
 	glog.V(1).Infof("Loading %s...", indexFile.Name())
-	mm, indexLoadError := newNeedleMapMetricFromIndexFile(indexFile)
+	mm, indexLoadError := newNeedleMapMetricFromIndexFile(indexFile, offsetWidth)
 	if indexLoadError != nil {
 		_ = m.dbFile.Close()
 		return nil, indexLoadError
@@ -60,7 +61,7 @@ func isSortedFileFresh(dbFileName string, indexFile *os.File) bool {
 }
 
 func (m *SortedFileNeedleMap) Get(key NeedleId) (element *needle_map.NeedleValue, ok bool) {
-	offset, size, err := erasure_coding.SearchNeedleFromSortedIndex(m.dbFile, m.dbFileSize, key, nil)
+	offset, size, err := erasure_coding.SearchNeedleFromSortedIndexWithWidth(m.dbFile, m.dbFileSize, m.offsetWidth, key, nil)
 	ok = err == nil
 	return &needle_map.NeedleValue{Key: key, Offset: offset, Size: size}, ok
 
@@ -72,7 +73,7 @@ func (m *SortedFileNeedleMap) Put(key NeedleId, offset Offset, size Size) error
 
 func (m *SortedFileNeedleMap) Delete(key NeedleId, offset Offset) error {
 
-	_, size, err := erasure_coding.SearchNeedleFromSortedIndex(m.dbFile, m.dbFileSize, key, nil)
+	_, size, err := erasure_coding.SearchNeedleFromSortedIndexWithWidth(m.dbFile, m.dbFileSize, m.offsetWidth, key, nil)
 
 	if err != nil {
 		if err == erasure_coding.NotFoundError {
@@ -89,7 +90,7 @@ func (m *SortedFileNeedleMap) Delete(key NeedleId, offset Offset) error {
 	if err := m.appendToIndexFile(key, offset, TombstoneFileSize); err != nil {
 		return err
 	}
-	_, _, err = erasure_coding.SearchNeedleFromSortedIndex(m.dbFile, m.dbFileSize, key, erasure_coding.MarkNeedleDeleted)
+	_, _, err = erasure_coding.SearchNeedleFromSortedIndexWithWidth(m.dbFile, m.dbFileSize, m.offsetWidth, key, erasure_coding.MarkNeedleDeletedWithWidth(m.offsetWidth))
 
 	return err
 }
@@ -0,0 +1,209 @@
+package replica_hint
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+// A Hint records that a needle was not written to, or deleted from, one replica of a volume.
+// The hint only identifies the needle. When replayed, the current local state of the needle
+// is copied to the replica, so a later overwrite or delete of the needle is replayed correctly.
+type Hint struct {
+	VolumeId uint32
+	NeedleId types.NeedleId
+	Cookie   types.Cookie
+	// Target is the url of the replica, or empty if the replica was missing from the volume lookup
+	Target string
+	// CreatedAt is when the replica was first missed
+	CreatedAt time.Time
+	// Generation increases each time the replica misses the needle again, set by HintStore.Add
+	Generation uint64
+}
+
+const hintKeyPrefixSize = 4 + types.NeedleIdSize
+
+// key layout: volume id, needle id, target
+func (h *Hint) key() []byte {
+	key := make([]byte, hintKeyPrefixSize+len(h.Target))
+	binary.BigEndian.PutUint32(key, h.VolumeId)
+	types.NeedleIdToBytes(key[4:hintKeyPrefixSize], h.NeedleId)
+	copy(key[hintKeyPrefixSize:], h.Target)
+	return key
+}
+
+const (
+	hintValueSize      = types.CookieSize + 8 + 8
+	hintValueSizeNoGen = types.CookieSize + 8 // written before the generation was added
+)
+
+// value layout: cookie, created at in unix nanoseconds, generation
+func (h *Hint) value() []byte {
+	value := make([]byte, hintValueSize)
+	types.CookieToBytes(value, h.Cookie)
+	binary.BigEndian.PutUint64(value[types.CookieSize:], uint64(h.CreatedAt.UnixNano()))
+	binary.BigEndian.PutUint64(value[types.CookieSize+8:], h.Generation)
+	return value
+}
+
+func parseHint(key, value []byte) (*Hint, error) {
+	if len(key) < hintKeyPrefixSize || (len(value) != hintValueSize && len(value) != hintValueSizeNoGen) {
+		return nil, fmt.Errorf("invalid hint key %x value %x", key, value)
+	}
+	hint := &Hint{
+		VolumeId:  binary.BigEndian.Uint32(key),
+		NeedleId:  types.BytesToNeedleId(key[4:hintKeyPrefixSize]),
+		Target:    string(key[hintKeyPrefixSize:]),
+		Cookie:    types.BytesToCookie(value),
+		CreatedAt: time.Unix(0, int64(binary.BigEndian.Uint64(value[types.CookieSize:]))),
+	}
+	if len(value) == hintValueSize {
+		hint.Generation = binary.BigEndian.Uint64(value[types.CookieSize+8:])
+	}
+	return hint, nil
+}
+
+// HintStore persists the hints of a volume server in a leveldb database.
+type HintStore struct {
+	dir        string
+	db         *leveldb.DB
+	count      int64
+	generation uint64 // the last generation given to a hint
+	// serializes adding or removing a hint with reading the existing one
+	addLock sync.Mutex
+}
+
+func NewHintStore(dir string) (*HintStore, error) {
+	opts := &opt.Options{
+		BlockCacheCapacity:            4 * 1024 * 1024,
+		WriteBuffer:                   1 * 1024 * 1024,
+		CompactionTableSizeMultiplier: 2,
+	}
+	db, err := leveldb.OpenFile(dir, opts)
+	if err != nil {
+		if errors.IsCorrupted(err) {
+			db, err = leveldb.RecoverFile(dir, opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("open replica hints %s: %v", dir, err)
+		}
+	}
+	hs := &HintStore{dir: dir, db: db}
+
+	iter := db.NewIterator(nil, nil)
+	for iter.Next() {
+		hs.count++
+		if hint, err := parseHint(iter.Key(), iter.Value()); err == nil {
+			hs.generation = max(hs.generation, hint.Generation)
+		}
+	}
+	iter.Release()
+	if hs.count > 0 {
+		glog.V(0).Infof("loaded %d replica hints from %s", hs.count, dir)
+	}
+	return hs, iter.Error()
+}
+
+// Add records a hint with a new generation, keeping the creation time of an existing hint for the same needle and target.
+// A hint being replayed is then not removed, so the newer miss is replayed again.
+func (hs *HintStore) Add(hint *Hint) error {
+	hs.addLock.Lock()
+	defer hs.addLock.Unlock()
+
+	key := hint.key()
+	existing, err := hs.get(key)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		hint.CreatedAt = existing.CreatedAt
+	}
+	hs.generation++
+	hint.Generation = hs.generation
+	if err := hs.db.Put(key, hint.value(), &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+	if existing == nil {
+		atomic.AddInt64(&hs.count, 1)
+	}
+	return nil
+}
+
+// Remove deletes a replayed hint, unless the replica missed the needle again since the hint was read.
+func (hs *HintStore) Remove(hint *Hint) error {
+	hs.addLock.Lock()
+	defer hs.addLock.Unlock()
+
+	key := hint.key()
+	existing, err := hs.get(key)
+	if err != nil || existing == nil || existing.Generation != hint.Generation {
+		return err
+	}
+	if err := hs.db.Delete(key, nil); err != nil {
+		return err
+	}
+	atomic.AddInt64(&hs.count, -1)
+	return nil
+}
+
+func (hs *HintStore) get(key []byte) (*Hint, error) {
+	value, err := hs.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseHint(key, value)
+}
+
+// Count returns the number of pending hints.
+func (hs *HintStore) Count() int64 {
+	return atomic.LoadInt64(&hs.count)
+}
+
+// VisitVolume calls fn for each hint of the volume, or of all volumes if volumeId is 0, ordered by volume id and needle id.
+// The hints are read from a snapshot, so fn can add or remove hints.
+func (hs *HintStore) VisitVolume(volumeId uint32, fn func(hint *Hint) error) error {
+	snapshot, err := hs.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	var slice *leveldb_util.Range
+	if volumeId != 0 {
+		prefix := make([]byte, 4)
+		binary.BigEndian.PutUint32(prefix, volumeId)
+		slice = leveldb_util.BytesPrefix(prefix)
+	}
+	iter := snapshot.NewIterator(slice, nil)
+	defer iter.Release()
+	for iter.Next() {
+		hint, err := parseHint(iter.Key(), iter.Value())
+		if err != nil {
+			glog.Warningf("skip replica hint in %s: %v", hs.dir, err)
+			continue
+		}
+		if err = fn(hint); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+func (hs *HintStore) Close() {
+	if err := hs.db.Close(); err != nil {
+		glog.Warningf("close replica hints %s: %v", hs.dir, err)
+	}
+}
@@ -0,0 +1,125 @@
+package replica_hint
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHintStore(t *testing.T) {
+	dir := t.TempDir()
+	hs, err := NewHintStore(dir)
+	if err != nil {
+		t.Fatalf("NewHintStore: %v", err)
+	}
+
+	createdAt := time.Unix(1700000000, 123)
+	hints := []*Hint{
+		{VolumeId: 3, NeedleId: 7, Cookie: 0x1234, Target: "10.0.0.2:8080", CreatedAt: createdAt},
+		{VolumeId: 3, NeedleId: 7, Cookie: 0x1234, Target: "10.0.0.3:8080", CreatedAt: createdAt},
+		{VolumeId: 3, NeedleId: 9, Cookie: 0x5678, Target: "", CreatedAt: createdAt},
+		{VolumeId: 4, NeedleId: 1, Cookie: 0x9abc, Target: "10.0.0.2:8080", CreatedAt: createdAt},
+	}
+	for _, hint := range hints {
+		if err = hs.Add(hint); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	// a repeated miss keeps the creation time of the original hint
+	if err = hs.Add(&Hint{VolumeId: 3, NeedleId: 7, Cookie: 0x1234, Target: "10.0.0.2:8080", CreatedAt: createdAt.Add(time.Hour)}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if hs.Count() != 4 {
+		t.Fatalf("count %d, expected 4", hs.Count())
+	}
+
+	var visited []*Hint
+	if err = hs.VisitVolume(3, func(hint *Hint) error {
+		visited = append(visited, hint)
+		return nil
+	}); err != nil {
+		t.Fatalf("VisitVolume: %v", err)
+	}
+	if len(visited) != 3 {
+		t.Fatalf("visited %d hints of volume 3, expected 3", len(visited))
+	}
+	for i, hint := range visited {
+		expected := hints[i]
+		if hint.VolumeId != expected.VolumeId || hint.NeedleId != expected.NeedleId || hint.Cookie != expected.Cookie ||
+			hint.Target != expected.Target || !hint.CreatedAt.Equal(expected.CreatedAt) {
+			t.Errorf("hint %d is %+v, expected %+v", i, hint, expected)
+		}
+	}
+
+	// removing while visiting
+	if err = hs.VisitVolume(0, func(hint *Hint) error {
+		if hint.NeedleId == 7 {
+			return hs.Remove(hint)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("VisitVolume: %v", err)
+	}
+	if err = hs.Remove(hints[0]); err != nil {
+		t.Fatalf("Remove again: %v", err)
+	}
+	if hs.Count() != 2 {
+		t.Fatalf("count %d after remove, expected 2", hs.Count())
+	}
+
+	hs.Close()
+	hs, err = NewHintStore(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer hs.Close()
+	if hs.Count() != 2 {
+		t.Fatalf("count %d after reopen, expected 2", hs.Count())
+	}
+}
+
+func TestHintStoreRemoveKeepsNewerMiss(t *testing.T) {
+	hs, err := NewHintStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewHintStore: %v", err)
+	}
+	defer hs.Close()
+
+	createdAt := time.Unix(1700000000, 0)
+	if err = hs.Add(&Hint{VolumeId: 3, NeedleId: 7, Cookie: 0x1234, Target: "10.0.0.2:8080", CreatedAt: createdAt}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	var replaying *Hint
+	if err = hs.VisitVolume(3, func(hint *Hint) error {
+		replaying = hint
+		return nil
+	}); err != nil {
+		t.Fatalf("VisitVolume: %v", err)
+	}
+
+	// the replica misses a newer write of the needle while the hint is replayed
+	newer := &Hint{VolumeId: 3, NeedleId: 7, Cookie: 0x5678, Target: "10.0.0.2:8080", CreatedAt: createdAt.Add(time.Minute)}
+	if err = hs.Add(newer); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if newer.Generation <= replaying.Generation || !newer.CreatedAt.Equal(createdAt) {
+		t.Fatalf("newer hint %+v, replaying %+v", newer, replaying)
+	}
+	if err = hs.Remove(replaying); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if hs.Count() != 1 {
+		t.Fatalf("hint of the newer miss is removed")
+	}
+
+	if err = hs.VisitVolume(3, func(hint *Hint) error {
+		if hint.Cookie != 0x5678 || hint.Generation != newer.Generation {
+			t.Errorf("unexpected hint %+v", hint)
+		}
+		return hs.Remove(hint)
+	}); err != nil {
+		t.Fatalf("VisitVolume: %v", err)
+	}
+	if hs.Count() != 0 {
+		t.Fatalf("count %d after replaying the newer miss, expected 0", hs.Count())
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
@@ -20,6 +22,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/replica_hint"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
 )
@@ -37,7 +40,7 @@ type ReadOption struct {
 	// response
 	IsMetaOnly     bool // read status
 	VolumeRevision uint16
-	IsOutOfRange   bool // whether read over MaxPossibleVolumeSize
+	IsOutOfRange   bool // whether read over the 32GB addressable by 4-byte offsets
 
 	// If HasSlowRead is set to true:
 	//  * read requests and write requests compete for the lock.
@@ -51,6 +54,10 @@ type ReadOption struct {
 	// increasing ReadBufferSize can reduce the number of get locks times and shorten read P99 latency.
 	// but will increase memory usage a bit. Use with hasSlowRead normally.
 	ReadBufferSize int
+
+	// If SendFile is set to true, needle data on local disk is copied to writers supporting io.ReaderFrom,
+	// so plain tcp connections use sendfile. The data is not checked against the needle checksum.
+	SendFile bool
 }
 
 /*
@@ -78,6 +85,10 @@ type Store struct {
 	NewEcShardsChan     chan master_pb.VolumeEcShardInformationMessage
 	DeletedEcShardsChan chan master_pb.VolumeEcShardInformationMessage
 	isStopping          bool
+	replicaHints        *replica_hint.HintStore
+	hotNeedles          *HotNeedles
+	hotNeedleLabelsLock sync.Mutex
+	hotNeedleLabels     [][]string // of the reported metric series
 }
 
 func (s *Store) String() (str string) {
@@ -103,6 +114,7 @@ func NewStore(
 		Id:             id,
 		NeedleMapKind:  needleMapKind,
 		Locations:      make([]*DiskLocation, 0),
+		hotNeedles:     NewHotNeedles(hotNeedleCapacity, hotNeedleShardCount, hotNeedleSampleRate),
 
 		StateUpdateChan:     make(chan *volume_server_pb.VolumeServerState, HEARTBEAT_CHAN_SIZE),
 		NewVolumesChan:      make(chan master_pb.VolumeShortInformationMessage, HEARTBEAT_CHAN_SIZE),
@@ -187,7 +199,7 @@ func (s *Store) SaveState() error {
 	return err
 }
 
-func (s *Store) AddVolume(volumeId needle.VolumeId, collection string, needleMapKind NeedleMapKind, replicaPlacement string, ttlString string, preallocate int64, ver needle.Version, MemoryMapMaxSizeMb uint32, diskType DiskType, ldbTimeout int64) error {
+func (s *Store) AddVolume(volumeId needle.VolumeId, collection string, needleMapKind NeedleMapKind, replicaPlacement string, ttlString string, preallocate int64, ver needle.Version, MemoryMapMaxSizeMb uint32, diskType DiskType, ldbTimeout int64, bytesOffset uint32, wormRetentionSeconds uint64) error {
 	rt, e := super_block.NewReplicaPlacementFromString(replicaPlacement)
 	if e != nil {
 		return e
@@ -196,7 +208,15 @@ func (s *Store) AddVolume(volumeId needle.VolumeId, collection string, needleMap
 	if e != nil {
 		return e
 	}
-	e = s.addVolume(volumeId, collection, needleMapKind, rt, ttl, preallocate, ver, MemoryMapMaxSizeMb, diskType, ldbTimeout)
+	offsetWidth, e := ParseOffsetWidth(bytesOffset)
+	if e != nil {
+		return e
+	}
+	var superBlockExtra *master_pb.SuperBlockExtra
+	if wormRetentionSeconds > 0 {
+		superBlockExtra = newWormSuperBlockExtra(wormRetentionSeconds)
+	}
+	e = s.addVolume(volumeId, collection, needleMapKind, rt, ttl, preallocate, ver, MemoryMapMaxSizeMb, diskType, ldbTimeout, offsetWidth, superBlockExtra)
 	return e
 }
 
@@ -240,7 +260,7 @@ func (s *Store) FindFreeLocation(filterFn func(location *DiskLocation) bool) (re
 	}
 	return ret
 }
-func (s *Store) addVolume(vid needle.VolumeId, collection string, needleMapKind NeedleMapKind, replicaPlacement *super_block.ReplicaPlacement, ttl *needle.TTL, preallocate int64, ver needle.Version, memoryMapMaxSizeMb uint32, diskType DiskType, ldbTimeout int64) error {
+func (s *Store) addVolume(vid needle.VolumeId, collection string, needleMapKind NeedleMapKind, replicaPlacement *super_block.ReplicaPlacement, ttl *needle.TTL, preallocate int64, ver needle.Version, memoryMapMaxSizeMb uint32, diskType DiskType, ldbTimeout int64, offsetWidth OffsetWidth, superBlockExtra *master_pb.SuperBlockExtra) error {
 	if s.findVolume(vid) != nil {
 		return fmt.Errorf("Volume Id %d already exists!", vid)
 	}
@@ -261,9 +281,9 @@ func (s *Store) addVolume(vid needle.VolumeId, collection string, needleMapKind
 	}
 
 	if location != nil {
-		glog.V(0).Infof("In dir %s (disk ID %d) adds volume:%v collection:%s replicaPlacement:%v ttl:%v",
-			location.Directory, diskId, vid, collection, replicaPlacement, ttl)
-		if volume, err := NewVolume(location.Directory, location.IdxDirectory, collection, vid, needleMapKind, replicaPlacement, ttl, preallocate, ver, memoryMapMaxSizeMb, ldbTimeout); err == nil {
+		glog.V(0).Infof("In dir %s (disk ID %d) adds volume:%v collection:%s replicaPlacement:%v ttl:%v offsetWidth:%d extra:%v",
+			location.Directory, diskId, vid, collection, replicaPlacement, ttl, offsetWidth, superBlockExtra)
+		if volume, err := newVolume(location.Directory, location.IdxDirectory, collection, vid, needleMapKind, replicaPlacement, ttl, preallocate, ver, memoryMapMaxSizeMb, ldbTimeout, offsetWidth, superBlockExtra); err == nil {
 			volume.diskId = diskId // Set the disk ID
 			location.SetVolume(vid, volume)
 			glog.V(0).Infof("add volume %d on disk ID %d", vid, diskId)
@@ -504,8 +524,53 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 		HasNoVolumes:    len(volumeMessages) == 0,
 		HasNoEcShards:   len(ecVolumeMessages) == 0,
 		LocationUuids:   uuidList,
+		HotNeedles:      s.collectHotNeedles(),
+	}
+
+}
+
+func (s *Store) CollectVolumeIoStats() (ioStats []*volume_server_pb.VolumeIoStats) {
+	for _, location := range s.Locations {
+		location.volumesLock.RLock()
+		for _, v := range location.volumes {
+			ioStats = append(ioStats, v.IoStats())
+		}
+		location.volumesLock.RUnlock()
 	}
+	return
+}
 
+func (s *Store) collectHotNeedles() (hotNeedles []*master_pb.HotNeedle) {
+	for _, hotNeedle := range s.hotNeedles.Top(HotNeedleReportCount) {
+		if s.findVolume(needle.VolumeId(hotNeedle.VolumeId)) == nil {
+			continue
+		}
+		hotNeedles = append(hotNeedles, hotNeedle)
+	}
+	s.reportHotNeedleMetrics(hotNeedles)
+	return
+}
+
+// reportHotNeedleMetrics keeps one metric series per reported needle,
+// and deletes the series of the needles no longer reported.
+func (s *Store) reportHotNeedleMetrics(hotNeedles []*master_pb.HotNeedle) {
+	s.hotNeedleLabelsLock.Lock()
+	defer s.hotNeedleLabelsLock.Unlock()
+
+	labels := make([][]string, 0, len(hotNeedles))
+	current := make(map[string]bool, len(hotNeedles))
+	for rank, hotNeedle := range hotNeedles {
+		values := []string{strconv.Itoa(rank + 1), strconv.FormatUint(uint64(hotNeedle.VolumeId), 10), NeedleId(hotNeedle.NeedleId).String()}
+		stats.VolumeServerHotNeedleGauge.WithLabelValues(values...).Set(float64(hotNeedle.ReadCount))
+		labels = append(labels, values)
+		current[strings.Join(values, ",")] = true
+	}
+	for _, values := range s.hotNeedleLabels {
+		if !current[strings.Join(values, ",")] {
+			stats.VolumeServerHotNeedleGauge.DeleteLabelValues(values...)
+		}
+	}
+	s.hotNeedleLabels = labels
 }
 
 func (s *Store) deleteExpiredEcVolumes() (ecShards, deleted []*master_pb.VolumeEcShardInformationMessage) {
@@ -562,6 +627,9 @@ func (s *Store) Close() {
 	for _, location := range s.Locations {
 		location.Close()
 	}
+	if s.replicaHints != nil {
+		s.replicaHints.Close()
+	}
 }
 
 func (s *Store) WriteVolumeNeedle(i needle.VolumeId, n *needle.Needle, checkCookie bool, fsync bool) (isUnchanged bool, err error) {
@@ -570,7 +638,11 @@ func (s *Store) WriteVolumeNeedle(i needle.VolumeId, n *needle.Needle, checkCook
 			err = fmt.Errorf("volume %d is read only", i)
 			return
 		}
+		start := time.Now()
 		_, _, isUnchanged, err = v.writeNeedle2(n, checkCookie, fsync && !s.isStopping)
+		if err == nil && !isUnchanged {
+			v.recordWrite(int(n.DataSize), time.Since(start))
+		}
 		return
 	}
 	glog.V(0).Infoln("volume", i, "not found!")
@@ -583,14 +655,30 @@ func (s *Store) DeleteVolumeNeedle(i needle.VolumeId, n *needle.Needle) (Size, e
 		if v.noWriteOrDelete {
 			return 0, fmt.Errorf("volume %d is read only", i)
 		}
-		return v.deleteNeedle2(n)
+		start := time.Now()
+		size, err := v.deleteNeedle2(n)
+		if err == nil && size > 0 {
+			v.recordDelete(time.Since(start))
+		}
+		return size, err
 	}
 	return 0, fmt.Errorf("volume %d not found on %s:%d", i, s.Ip, s.Port)
 }
 
 func (s *Store) ReadVolumeNeedle(i needle.VolumeId, n *needle.Needle, readOption *ReadOption, onReadSizeFn func(size Size)) (int, error) {
 	if v := s.findVolume(i); v != nil {
-		return v.readNeedle(n, readOption, onReadSizeFn)
+		start := time.Now()
+		count, err := v.readNeedle(n, readOption, onReadSizeFn)
+		if err == nil {
+			if readOption != nil && readOption.IsMetaOnly {
+				// the data is counted when it is streamed by ReadVolumeNeedleDataInto
+				v.recordRead(0, time.Since(start))
+			} else {
+				v.recordRead(count, time.Since(start))
+			}
+			s.hotNeedles.Add(i, n.Id, n.Cookie)
+		}
+		return count, err
 	}
 	return 0, fmt.Errorf("volume %d not found", i)
 }
@@ -604,7 +692,11 @@ func (s *Store) ReadVolumeNeedleMetaAt(i needle.VolumeId, n *needle.Needle, offs
 
 func (s *Store) ReadVolumeNeedleDataInto(i needle.VolumeId, n *needle.Needle, readOption *ReadOption, writer io.Writer, offset int64, size int64) error {
 	if v := s.findVolume(i); v != nil {
-		return v.readNeedleDataInto(n, readOption, writer, offset, size)
+		err := v.readNeedleDataInto(n, readOption, writer, offset, size)
+		if err == nil {
+			v.recordReadBytes(int(size))
+		}
+		return err
 	}
 	return fmt.Errorf("volume %d not found", i)
 }
@@ -684,6 +776,7 @@ func (s *Store) UnmountVolume(i needle.VolumeId) error {
 		err := location.UnloadVolume(i)
 		if err == nil {
 			glog.V(0).Infof("UnmountVolume %d", i)
+			s.hotNeedles.DeleteVolume(i)
 			s.DeletedVolumesChan <- message
 			return nil
 		} else if err == ErrVolumeNotFound {
@@ -712,6 +805,7 @@ func (s *Store) DeleteVolume(i needle.VolumeId, onlyEmpty bool) error {
 		err := location.DeleteVolume(i, onlyEmpty)
 		if err == nil {
 			glog.V(0).Infof("DeleteVolume %d", i)
+			s.hotNeedles.DeleteVolume(i)
 			s.DeletedVolumesChan <- message
 			return nil
 		} else if err == ErrVolumeNotFound {
@@ -751,6 +845,37 @@ func (s *Store) ConfigureVolume(i needle.VolumeId, replication string) error {
 	return fmt.Errorf("volume %d not found on disk", i)
 }
 
+// ConvertVolumeOffsetWidth rewrites the volume index with the offset width, remounting the volume
+func (s *Store) ConvertVolumeOffsetWidth(i needle.VolumeId, offsetWidth OffsetWidth) (previous OffsetWidth, err error) {
+	v := s.findVolume(i)
+	if v == nil {
+		return 0, fmt.Errorf("volume %d not found", i)
+	}
+	previous = v.OffsetWidth()
+	if previous == offsetWidth {
+		return previous, nil
+	}
+	if err = v.checkOffsetWidthConversion(offsetWidth); err != nil {
+		return previous, err
+	}
+
+	if err = s.UnmountVolume(i); err != nil {
+		return previous, fmt.Errorf("unmount volume %d: %v", i, err)
+	}
+	convertErr := v.convertOffsetWidth(offsetWidth)
+	if err = s.MountVolume(i); err != nil {
+		if convertErr != nil {
+			return previous, fmt.Errorf("convert volume %d: %v. Also failed to mount: %v", i, convertErr, err)
+		}
+		return previous, fmt.Errorf("mount volume %d: %v", i, err)
+	}
+	if convertErr != nil {
+		return previous, fmt.Errorf("convert volume %d: %v", i, convertErr)
+	}
+	glog.V(0).Infof("volume %d offset width converted from %d to %d", i, previous, offsetWidth)
+	return previous, nil
+}
+
 func (s *Store) SetVolumeSizeLimit(x uint64) {
 	atomic.StoreUint64(&s.volumeSizeLimit, x)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 )
 
@@ -159,6 +160,14 @@ func (s *Store) ReadEcShardNeedle(vid needle.VolumeId, n *needle.Needle, onReadS
 			if len(intervals) > 1 {
 				glog.V(3).Infof("ReadEcShardNeedle needle id %s intervals:%+v", n.String(), intervals)
 			}
+			cacheKey := needle_cache.NeedleKey{VolumeId: uint32(vid), NeedleId: n.Id, Offset: offset.ToActualOffset(), Size: size}
+			if bytes, found := location.getCachedNeedle(cacheKey); found {
+				if err = n.ReadBytes(bytes, offset.ToActualOffset(), size, localEcVolume.Version); err == nil {
+					return len(bytes), nil
+				}
+				glog.V(0).Infof("ec volume %d needle %s from read cache: %v", vid, n.Id, err)
+			}
+
 			bytes, isDeleted, err := s.readEcShardIntervals(vid, n.Id, localEcVolume, intervals)
 			if err != nil {
 				return 0, fmt.Errorf("ReadEcShardIntervals: %w", err)
@@ -171,6 +180,7 @@ func (s *Store) ReadEcShardNeedle(vid needle.VolumeId, n *needle.Needle, onReadS
 			if err != nil {
 				return 0, fmt.Errorf("readbytes: %w", err)
 			}
+			location.setCachedNeedle(cacheKey, bytes)
 
 			return len(bytes), nil
 		}
@@ -236,6 +246,22 @@ func (s *Store) readOneEcShardInterval(needleId types.NeedleId, ecVolume *erasur
 	return
 }
 
+// ReadEcVolumeDat reads a range of the .dat file an ec volume was encoded from,
+// out of the local or remote shards.
+func (s *Store) ReadEcVolumeDat(ecVolume *erasure_coding.EcVolume, buf []byte, offset int64) error {
+	if err := s.cachedLookupEcShardLocations(ecVolume); err != nil {
+		return fmt.Errorf("failed to locate shard via master grpc %s: %v", s.MasterAddress, err)
+	}
+	for _, interval := range ecVolume.LocateEcShardDatInterval(offset, types.Size(len(buf))) {
+		data, _, err := s.readOneEcShardInterval(0, ecVolume, interval)
+		if err != nil {
+			return fmt.Errorf("read ec volume %d at %d: %w", ecVolume.VolumeId, offset, err)
+		}
+		buf = buf[copy(buf, data):]
+	}
+	return nil
+}
+
 func forgetShardId(ecVolume *erasure_coding.EcVolume, shardId erasure_coding.ShardId) {
 	// failed to access the source data nodes, clear it up
 	ecVolume.ShardLocationsLock.Lock()
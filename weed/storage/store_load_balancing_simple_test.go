@@ -18,7 +18,7 @@ func TestLoadBalancingDistribution(t *testing.T) {
 		volumeId := needle.VolumeId(i)
 
 		err := store.AddVolume(volumeId, "", NeedleMapInMemory, "000", "",
-			0, needle.GetCurrentVersion(), 0, types.HardDriveType, 3)
+			0, needle.GetCurrentVersion(), 0, types.HardDriveType, 3, 0, 0)
 
 		if err != nil {
 			t.Fatalf("Failed to add volume %d: %v", volumeId, err)
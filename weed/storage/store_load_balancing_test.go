@@ -204,7 +204,7 @@ func TestVolumeLoadBalancing(t *testing.T) {
 				volumeId := needle.VolumeId(i + 1)
 
 				err := store.AddVolume(volumeId, "", NeedleMapInMemory, "000", "",
-					0, needle.GetCurrentVersion(), 0, types.HardDriveType, 3)
+					0, needle.GetCurrentVersion(), 0, types.HardDriveType, 3, 0, 0)
 
 				if err != nil {
 					t.Fatalf("Failed to add volume %d: %v", volumeId, err)
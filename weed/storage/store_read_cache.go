@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
+)
+
+// EnableReadCache adds a needle read cache to each disk location,
+// for needles read from remote tiered volumes or erasure coded volumes.
+// The cache folders and capacities are given for each disk location.
+// If only one cache folder is given, each disk location uses its own sub folder.
+func (s *Store) EnableReadCache(cacheDirs []string, capacityMBs []int64) error {
+	if len(cacheDirs) == 0 {
+		return nil
+	}
+	if len(cacheDirs) != 1 && len(cacheDirs) != len(s.Locations) {
+		return fmt.Errorf("%d read cache folders for %d volume folders", len(cacheDirs), len(s.Locations))
+	}
+	if len(capacityMBs) != 1 && len(capacityMBs) != len(s.Locations) {
+		return fmt.Errorf("%d read cache capacities for %d volume folders", len(capacityMBs), len(s.Locations))
+	}
+
+	for i, location := range s.Locations {
+		dir := cacheDirs[0]
+		if len(cacheDirs) == 1 {
+			dir = filepath.Join(dir, fmt.Sprintf("disk%d", i))
+		} else {
+			dir = cacheDirs[i]
+		}
+		capacityMB := capacityMBs[0]
+		if len(capacityMBs) > 1 {
+			capacityMB = capacityMBs[i]
+		}
+		if capacityMB <= 0 {
+			continue
+		}
+		readCache, err := needle_cache.NewNeedleCache(dir, capacityMB*1024*1024, fmt.Sprintf("%d", i))
+		if err != nil {
+			return fmt.Errorf("read cache for %s: %v", location.Directory, err)
+		}
+		location.readCache = readCache
+	}
+	return nil
+}
+
+func (l *DiskLocation) invalidateReadCache(vid needle.VolumeId) {
+	if l.readCache != nil {
+		l.readCache.InvalidateVolume(uint32(vid))
+	}
+}
+
+func (l *DiskLocation) getCachedNeedle(key needle_cache.NeedleKey) ([]byte, bool) {
+	if l.readCache == nil {
+		return nil, false
+	}
+	return l.readCache.Get(key)
+}
+
+func (l *DiskLocation) setCachedNeedle(key needle_cache.NeedleKey, blob []byte) {
+	if l.readCache != nil {
+		l.readCache.Set(key, blob)
+	}
+}
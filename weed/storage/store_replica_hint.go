@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"path/filepath"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/replica_hint"
+)
+
+// EnableReplicaHints opens the hints of writes missed by replicas, kept in the index folder of the first disk location.
+func (s *Store) EnableReplicaHints() error {
+	if s.replicaHints != nil || len(s.Locations) == 0 {
+		return nil
+	}
+	hintStore, err := replica_hint.NewHintStore(filepath.Join(s.Locations[0].IdxDirectory, "replica_hints"))
+	if err != nil {
+		return err
+	}
+	s.replicaHints = hintStore
+	return nil
+}
+
+// ReplicaHints returns the hints of writes missed by replicas, or nil if not enabled.
+func (s *Store) ReplicaHints() *replica_hint.HintStore {
+	return s.replicaHints
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
@@ -33,11 +34,16 @@ type SuperBlock struct {
 func (s *SuperBlock) BlockSize() int {
 	switch s.Version {
 	case needle.Version2, needle.Version3:
-		return SuperBlockSize + int(s.ExtraSize)
+		return PaddedBlockSize(int(s.ExtraSize))
 	}
 	return SuperBlockSize
 }
 
+// PaddedBlockSize is the super block size with the extra, padded to keep the needles aligned
+func PaddedBlockSize(extraSize int) int {
+	return (SuperBlockSize + extraSize + types.NeedlePaddingSize - 1) / types.NeedlePaddingSize * types.NeedlePaddingSize
+}
+
 func (s *SuperBlock) Bytes() []byte {
 	header := make([]byte, SuperBlockSize)
 	header[0] = byte(s.Version)
@@ -59,6 +65,7 @@ func (s *SuperBlock) Bytes() []byte {
 		util.Uint16toBytes(header[6:8], s.ExtraSize)
 
 		header = append(header, extraData...)
+		header = append(header, make([]byte, PaddedBlockSize(extraSize)-len(header))...)
 	}
 
 	return header
@@ -1,9 +1,13 @@
 package super_block
 
 import (
+	"os"
 	"testing"
 
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/backend"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 )
 
 func TestSuperBlockReadWrite(t *testing.T) {
@@ -23,3 +27,38 @@ func TestSuperBlockReadWrite(t *testing.T) {
 	}
 
 }
+
+func TestSuperBlockExtraReadWrite(t *testing.T) {
+	rp, _ := NewReplicaPlacementFromByte(byte(001))
+	s := &SuperBlock{
+		Version:          needle.GetCurrentVersion(),
+		ReplicaPlacement: rp,
+		Ttl:              needle.EMPTY_TTL,
+		Extra: &master_pb.SuperBlockExtra{
+			Worm: &master_pb.SuperBlockExtra_Worm{RetentionSeconds: 3600},
+		},
+	}
+
+	bytes := s.Bytes()
+	if len(bytes) != s.BlockSize() || len(bytes)%types.NeedlePaddingSize != 0 {
+		t.Fatalf("super block size %d, block size %d", len(bytes), s.BlockSize())
+	}
+
+	datFile, err := os.CreateTemp(t.TempDir(), "sb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	datBackend := backend.NewDiskFile(datFile)
+	defer datBackend.Close()
+	if _, err = datBackend.WriteAt(bytes, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadSuperBlock(datBackend)
+	if err != nil {
+		t.Fatalf("read super block: %v", err)
+	}
+	if read.BlockSize() != s.BlockSize() || read.Extra.GetWorm().GetRetentionSeconds() != 3600 {
+		t.Fatalf("read super block extra %+v", read.Extra)
+	}
+}
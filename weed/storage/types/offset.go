@@ -0,0 +1,131 @@
+package types
+
+import (
+	"fmt"
+)
+
+// OffsetWidth is the number of bytes of the needle offsets in the index files of a volume.
+// Offsets of 4 bytes address volumes up to 32GB, and offsets of 5 bytes volumes up to 8TB.
+// The width of each volume is recorded in its .vif file, so volumes of both widths can be served together.
+type OffsetWidth uint32
+
+const (
+	OffsetWidth4 OffsetWidth = 4
+	OffsetWidth5 OffsetWidth = 5
+
+	// DefaultOffsetWidth is the offset width of new volumes, 5 bytes with the 5BytesOffset build tag
+	DefaultOffsetWidth = OffsetWidth(OffsetSize)
+	// MaxOffsetSize is the largest offset width
+	MaxOffsetSize = 5
+)
+
+// ParseOffsetWidth reads the offset width recorded as BytesOffset, where 0 is the default width.
+func ParseOffsetWidth(bytesOffset uint32) (OffsetWidth, error) {
+	switch OffsetWidth(bytesOffset) {
+	case 0:
+		return DefaultOffsetWidth, nil
+	case OffsetWidth4, OffsetWidth5:
+		return OffsetWidth(bytesOffset), nil
+	}
+	return 0, fmt.Errorf("unsupported offset width %d", bytesOffset)
+}
+
+// OffsetWidthForVolumeSize is the smallest offset width, but not smaller than the default width,
+// which can address volumes of the size.
+func OffsetWidthForVolumeSize(volumeSize uint64) OffsetWidth {
+	if volumeSize > OffsetWidth4.MaxVolumeSize() {
+		return OffsetWidth5
+	}
+	return DefaultOffsetWidth
+}
+
+// MaxVolumeSize is the largest volume the offsets can address
+func (w OffsetWidth) MaxVolumeSize() uint64 {
+	if w == OffsetWidth5 {
+		return 4 * 1024 * 1024 * 1024 * 8 * 256 // 8TB
+	}
+	return 4 * 1024 * 1024 * 1024 * 8 // 32GB
+}
+
+// EntrySize is the size of an index file entry
+func (w OffsetWidth) EntrySize() int {
+	return NeedleIdSize + int(w) + SizeSize
+}
+
+func (w OffsetWidth) OffsetToBytes(bytes []byte, offset Offset) {
+	if w == OffsetWidth5 {
+		bytes[4] = offset.b4
+	}
+	bytes[3] = offset.b0
+	bytes[2] = offset.b1
+	bytes[1] = offset.b2
+	bytes[0] = offset.b3
+}
+
+func (w OffsetWidth) BytesToOffset(bytes []byte) Offset {
+	offset := Offset{
+		OffsetLower: OffsetLower{
+			b0: bytes[3],
+			b1: bytes[2],
+			b2: bytes[1],
+			b3: bytes[0],
+		},
+	}
+	if w == OffsetWidth5 {
+		offset.b4 = bytes[4]
+	}
+	return offset
+}
+
+type OffsetHigher struct {
+	b4 byte
+}
+
+// OffsetToBytes writes the offset with the default width
+func OffsetToBytes(bytes []byte, offset Offset) {
+	DefaultOffsetWidth.OffsetToBytes(bytes, offset)
+}
+
+// BytesToOffset reads the offset with the default width
+func BytesToOffset(bytes []byte) Offset {
+	return DefaultOffsetWidth.BytesToOffset(bytes)
+}
+
+// only for testing, will be removed later.
+func Uint32ToOffset(offset uint32) Offset {
+	return Offset{
+		OffsetLower: OffsetLower{
+			b0: byte(offset),
+			b1: byte(offset >> 8),
+			b2: byte(offset >> 16),
+			b3: byte(offset >> 24),
+		},
+	}
+}
+
+func (offset Offset) IsZero() bool {
+	return offset.b0 == 0 && offset.b1 == 0 && offset.b2 == 0 && offset.b3 == 0 && offset.b4 == 0
+}
+
+func ToOffset(offset int64) Offset {
+	smaller := offset / int64(NeedlePaddingSize)
+	return Offset{
+		OffsetHigher: OffsetHigher{
+			b4: byte(smaller >> 32),
+		},
+		OffsetLower: OffsetLower{
+			b0: byte(smaller),
+			b1: byte(smaller >> 8),
+			b2: byte(smaller >> 16),
+			b3: byte(smaller >> 24),
+		},
+	}
+}
+
+func (offset Offset) ToActualOffset() (actualOffset int64) {
+	return (int64(offset.b0) + int64(offset.b1)<<8 + int64(offset.b2)<<16 + int64(offset.b3)<<24 + int64(offset.b4)<<32) * int64(NeedlePaddingSize)
+}
+
+func (offset Offset) String() string {
+	return fmt.Sprintf("%d", int64(offset.b0)+int64(offset.b1)<<8+int64(offset.b2)<<16+int64(offset.b3)<<24+int64(offset.b4)<<32)
+}
@@ -52,15 +52,28 @@ type Volume struct {
 	volumeInfo       *volume_server_pb.VolumeInfo
 	location         *DiskLocation
 	diskId           uint32 // ID of this volume's disk in Store.Locations array
+	offsetWidth      types.OffsetWidth
+	wormChain        *wormChain // only for write-once volumes
+	ioStats          volumeIoStats
 
 	lastIoError error
 }
 
 func NewVolume(dirname string, dirIdx string, collection string, id needle.VolumeId, needleMapKind NeedleMapKind, replicaPlacement *super_block.ReplicaPlacement, ttl *needle.TTL, preallocate int64, ver needle.Version, memoryMapMaxSizeMb uint32, ldbTimeout int64) (v *Volume, e error) {
+	return NewVolumeWithOffsetWidth(dirname, dirIdx, collection, id, needleMapKind, replicaPlacement, ttl, preallocate, ver, memoryMapMaxSizeMb, ldbTimeout, types.DefaultOffsetWidth)
+}
+
+// NewVolumeWithOffsetWidth uses offsetWidth only if the volume is new, existing volumes keep the width in their .vif file
+func NewVolumeWithOffsetWidth(dirname string, dirIdx string, collection string, id needle.VolumeId, needleMapKind NeedleMapKind, replicaPlacement *super_block.ReplicaPlacement, ttl *needle.TTL, preallocate int64, ver needle.Version, memoryMapMaxSizeMb uint32, ldbTimeout int64, offsetWidth types.OffsetWidth) (v *Volume, e error) {
+	return newVolume(dirname, dirIdx, collection, id, needleMapKind, replicaPlacement, ttl, preallocate, ver, memoryMapMaxSizeMb, ldbTimeout, offsetWidth, nil)
+}
+
+// newVolume writes superBlockExtra only if the volume is new
+func newVolume(dirname string, dirIdx string, collection string, id needle.VolumeId, needleMapKind NeedleMapKind, replicaPlacement *super_block.ReplicaPlacement, ttl *needle.TTL, preallocate int64, ver needle.Version, memoryMapMaxSizeMb uint32, ldbTimeout int64, offsetWidth types.OffsetWidth, superBlockExtra *master_pb.SuperBlockExtra) (v *Volume, e error) {
 	// if replicaPlacement is nil, the superblock will be loaded from disk
 	v = &Volume{dir: dirname, dirIdx: dirIdx, Collection: collection, Id: id, MemoryMapMaxSizeMb: memoryMapMaxSizeMb,
-		asyncRequestsChan: make(chan *needle.AsyncRequest, 128)}
-	v.SuperBlock = super_block.SuperBlock{ReplicaPlacement: replicaPlacement, Ttl: ttl}
+		asyncRequestsChan: make(chan *needle.AsyncRequest, 128), offsetWidth: offsetWidth}
+	v.SuperBlock = super_block.SuperBlock{ReplicaPlacement: replicaPlacement, Ttl: ttl, Extra: superBlockExtra}
 	v.needleMapKind = needleMapKind
 	v.ldbTimeout = ldbTimeout
 	e = v.load(true, true, needleMapKind, preallocate, ver)
@@ -110,6 +123,11 @@ func (v *Volume) Version() needle.Version {
 	return v.SuperBlock.Version
 }
 
+// OffsetWidth is the width of the offsets in the index files of this volume
+func (v *Volume) OffsetWidth() types.OffsetWidth {
+	return v.offsetWidth
+}
+
 func (v *Volume) FileStat() (datSize uint64, idxSize uint64, modTime time.Time) {
 	v.dataFileAccessLock.RLock()
 	defer v.dataFileAccessLock.RUnlock()
@@ -145,7 +163,7 @@ func (v *Volume) doIsEmpty() (bool, error) {
 			glog.V(0).Infof("Failed to read file size %s %v", v.DataBackend.Name(), e)
 			return false, fmt.Errorf("v.DataBackend.GetStat(): %v", e)
 		}
-		if datFileSize > super_block.SuperBlockSize {
+		if datFileSize > int64(v.SuperBlock.BlockSize()) {
 			return false, nil
 		}
 	}
@@ -243,6 +261,10 @@ func (v *Volume) doClose() {
 		v.nm.Close()
 		v.nm = nil
 	}
+	if v.wormChain != nil {
+		v.wormChain.close()
+		v.wormChain = nil
+	}
 	if v.DataBackend != nil {
 		if err := v.DataBackend.Close(); err != nil {
 			glog.Warningf("Volume Close fail to sync volume %d", v.Id)
@@ -265,7 +287,7 @@ func (v *Volume) expired(contentSize uint64, volumeSizeLimit uint64) bool {
 		// skip if we don't know size limit
 		return false
 	}
-	if contentSize <= super_block.SuperBlockSize {
+	if contentSize <= uint64(v.SuperBlock.BlockSize()) {
 		return false
 	}
 	if v.Ttl == nil || v.Ttl.Minutes() == 0 {
@@ -342,6 +364,7 @@ func (v *Volume) ToVolumeInformationMessage() (types.NeedleId, *master_pb.Volume
 	}
 
 	volumeInfo.RemoteStorageName, volumeInfo.RemoteStorageKey = v.RemoteStorageNameKey()
+	v.fillIoStats(volumeInfo)
 
 	return maxFileKey, volumeInfo
 }
@@ -112,7 +112,13 @@ func (v *Volume) IncrementalBackup(volumeServer pb.ServerAddress, grpcDialOption
 	}
 
 	// add to needle map
-	return ScanVolumeFileFrom(v.Version(), v.DataBackend, int64(startFromOffset), &VolumeFileScanner4GenIdx{v: v})
+	if err = ScanVolumeFileFrom(v.Version(), v.DataBackend, int64(startFromOffset), &VolumeFileScanner4GenIdx{v: v}); err != nil {
+		return err
+	}
+	if v.wormChain != nil {
+		return v.wormChain.extend(v.DataBackend, v.Version(), writeOffset)
+	}
+	return nil
 
 }
 
@@ -139,19 +145,20 @@ func (v *Volume) locateLastAppendEntry() (Offset, error) {
 		return Offset{}, fmt.Errorf("file %s stat error: %v", indexFile.Name(), err)
 	}
 	fileSize := fi.Size()
-	if fileSize%NeedleMapEntrySize != 0 {
+	entrySize := int64(v.offsetWidth.EntrySize())
+	if fileSize%entrySize != 0 {
 		return Offset{}, fmt.Errorf("unexpected file %s size: %d", indexFile.Name(), fileSize)
 	}
 	if fileSize == 0 {
 		return Offset{}, nil
 	}
 
-	bytes := make([]byte, NeedleMapEntrySize)
-	n, e := indexFile.ReadAt(bytes, fileSize-NeedleMapEntrySize)
-	if n != NeedleMapEntrySize {
+	bytes := make([]byte, entrySize)
+	n, e := indexFile.ReadAt(bytes, fileSize-entrySize)
+	if int64(n) != entrySize {
 		return Offset{}, fmt.Errorf("file %s read error: %v", indexFile.Name(), e)
 	}
-	_, offset, _ := idx.IdxFileEntry(bytes)
+	_, offset, _ := idx.IdxFileEntryWithWidth(v.offsetWidth, bytes)
 
 	return offset, nil
 }
@@ -174,12 +181,13 @@ func (v *Volume) readAppendAtNs(offset Offset) (uint64, error) {
 func (v *Volume) BinarySearchByAppendAtNs(sinceNs uint64) (offset Offset, isLast bool, err error) {
 
 	fileSize := int64(v.IndexFileSize())
-	if fileSize%NeedleMapEntrySize != 0 {
+	entrySize := int64(v.offsetWidth.EntrySize())
+	if fileSize%entrySize != 0 {
 		err = fmt.Errorf("unexpected file %s.idx size: %d", v.IndexFileName(), fileSize)
 		return
 	}
 
-	entryCount := fileSize / NeedleMapEntrySize
+	entryCount := fileSize / entrySize
 	l := int64(0)
 	h := entryCount
 
@@ -290,7 +298,6 @@ func (v *Volume) readLeftNs(m int64) (index int64, offset Offset, ts uint64, err
 	return
 }
 
-// bytes is of size NeedleMapEntrySize
 func (v *Volume) readOffsetFromIndex(m int64) (Offset, error) {
 	v.dataFileAccessLock.RLock()
 	defer v.dataFileAccessLock.RUnlock()
@@ -9,25 +9,25 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/backend"
 	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
-	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 )
 
 func CheckVolumeDataIntegrity(v *Volume, indexFile *os.File) (lastAppendAtNs uint64, err error) {
 	var indexSize int64
-	if indexSize, err = verifyIndexFileIntegrity(indexFile); err != nil {
+	if indexSize, err = verifyIndexFileIntegrity(indexFile, v.offsetWidth); err != nil {
 		return 0, fmt.Errorf("verifyIndexFileIntegrity %s failed: %v", indexFile.Name(), err)
 	}
 	if indexSize == 0 {
 		return 0, nil
 	}
 	healthyIndexSize := indexSize
-	for i := 1; i <= 10 && indexSize >= int64(i)*NeedleMapEntrySize; i++ {
+	entrySize := int64(v.offsetWidth.EntrySize())
+	for i := 1; i <= 10 && indexSize >= int64(i)*entrySize; i++ {
 		// check and fix last 10 entries
-		lastAppendAtNs, err = doCheckAndFixVolumeData(v, indexFile, indexSize-int64(i)*NeedleMapEntrySize)
+		lastAppendAtNs, err = doCheckAndFixVolumeData(v, indexFile, indexSize-int64(i)*entrySize)
 		if err == io.EOF {
-			healthyIndexSize = indexSize - int64(i)*NeedleMapEntrySize
+			healthyIndexSize = indexSize - int64(i)*entrySize
 			continue
 		}
 		if err != ErrorSizeMismatch {
@@ -42,10 +42,10 @@ func CheckVolumeDataIntegrity(v *Volume, indexFile *os.File) (lastAppendAtNs uin
 
 func doCheckAndFixVolumeData(v *Volume, indexFile *os.File, indexOffset int64) (lastAppendAtNs uint64, err error) {
 	var lastIdxEntry []byte
-	if lastIdxEntry, err = readIndexEntryAtOffset(indexFile, indexOffset); err != nil {
+	if lastIdxEntry, err = readIndexEntryAtOffset(indexFile, indexOffset, v.offsetWidth); err != nil {
 		return 0, fmt.Errorf("readLastIndexEntry %s failed: %v", indexFile.Name(), err)
 	}
-	key, offset, size := idx.IdxFileEntry(lastIdxEntry)
+	key, offset, size := idx.IdxFileEntryWithWidth(v.offsetWidth, lastIdxEntry)
 	if offset.IsZero() {
 		return 0, nil
 	}
@@ -56,8 +56,8 @@ func doCheckAndFixVolumeData(v *Volume, indexFile *os.File, indexOffset int64) (
 		}
 	} else {
 		if lastAppendAtNs, err = verifyNeedleIntegrity(v.DataBackend, v.Version(), offset.ToActualOffset(), key, size); err != nil {
-			if err == ErrorSizeMismatch {
-				return verifyNeedleIntegrity(v.DataBackend, v.Version(), offset.ToActualOffset()+int64(MaxPossibleVolumeSize), key, size)
+			if err == ErrorSizeMismatch && v.offsetWidth == OffsetWidth4 {
+				return verifyNeedleIntegrity(v.DataBackend, v.Version(), offset.ToActualOffset()+int64(OffsetWidth4.MaxVolumeSize()), key, size)
 			}
 			return lastAppendAtNs, err
 		}
@@ -65,24 +65,24 @@ func doCheckAndFixVolumeData(v *Volume, indexFile *os.File, indexOffset int64) (
 	return lastAppendAtNs, nil
 }
 
-func verifyIndexFileIntegrity(indexFile *os.File) (indexSize int64, err error) {
+func verifyIndexFileIntegrity(indexFile *os.File, offsetWidth OffsetWidth) (indexSize int64, err error) {
 	if indexSize, err = util.GetFileSize(indexFile); err == nil {
-		if indexSize%NeedleMapEntrySize != 0 {
+		if indexSize%int64(offsetWidth.EntrySize()) != 0 {
 			err = fmt.Errorf("index file's size is %d bytes, maybe corrupted", indexSize)
 		}
 	}
 	return
 }
 
-func readIndexEntryAtOffset(indexFile *os.File, offset int64) (bytes []byte, err error) {
+func readIndexEntryAtOffset(indexFile *os.File, offset int64, offsetWidth OffsetWidth) (bytes []byte, err error) {
 	if offset < 0 {
 		err = fmt.Errorf("offset %d for index file is invalid", offset)
 		return
 	}
-	bytes = make([]byte, NeedleMapEntrySize)
+	bytes = make([]byte, offsetWidth.EntrySize())
 	var readCount int
 	readCount, err = indexFile.ReadAt(bytes, offset)
-	if err == io.EOF && readCount == NeedleMapEntrySize {
+	if err == io.EOF && readCount == len(bytes) {
 		err = nil
 	}
 	return
@@ -158,7 +158,7 @@ func (v *Volume) checkIdxFile() error {
 	if err != nil {
 		return fmt.Errorf("get stat %s: %v", v.FileName(".dat"), err)
 	}
-	if datFileSize <= super_block.SuperBlockSize {
+	if datFileSize <= int64(v.SuperBlock.BlockSize()) {
 		return nil
 	}
 	indexFileName := v.FileName(".idx")
@@ -26,6 +26,12 @@ type VolumeInfo struct {
 	ModifiedAtSecond  int64
 	RemoteStorageName string
 	RemoteStorageKey  string
+	ReadCount         uint64
+	ReadBytes         uint64
+	WriteCount        uint64
+	WriteBytes        uint64
+	ReadMicros        uint64
+	WriteMicros       uint64
 }
 
 func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err error) {
@@ -44,6 +50,12 @@ func NewVolumeInfo(m *master_pb.VolumeInformationMessage) (vi VolumeInfo, err er
 		RemoteStorageKey:  m.RemoteStorageKey,
 		DiskType:          m.DiskType,
 		DiskId:            m.DiskId,
+		ReadCount:         m.ReadCount,
+		ReadBytes:         m.ReadBytes,
+		WriteCount:        m.WriteCount,
+		WriteBytes:        m.WriteBytes,
+		ReadMicros:        m.ReadMicros,
+		WriteMicros:       m.WriteMicros,
 	}
 	rp, e := super_block.NewReplicaPlacementFromByte(byte(m.ReplicaPlacement))
 	if e != nil {
@@ -75,8 +87,8 @@ func (vi VolumeInfo) IsRemote() bool {
 }
 
 func (vi VolumeInfo) String() string {
-	return fmt.Sprintf("Id:%d, Size:%d, ReplicaPlacement:%s, Collection:%s, Version:%v, Ttl:%s, FileCount:%d, DeleteCount:%d, DeletedByteCount:%d, ReadOnly:%v, ModifiedAtSecond:%d",
-		vi.Id, vi.Size, vi.ReplicaPlacement, vi.Collection, vi.Version, vi.Ttl.String(), vi.FileCount, vi.DeleteCount, vi.DeletedByteCount, vi.ReadOnly, vi.ModifiedAtSecond)
+	return fmt.Sprintf("Id:%d, Size:%d, ReplicaPlacement:%s, Collection:%s, Version:%v, Ttl:%s, FileCount:%d, DeleteCount:%d, DeletedByteCount:%d, ReadOnly:%v, ModifiedAtSecond:%d, ReadCount:%d, ReadBytes:%d, WriteCount:%d, WriteBytes:%d",
+		vi.Id, vi.Size, vi.ReplicaPlacement, vi.Collection, vi.Version, vi.Ttl.String(), vi.FileCount, vi.DeleteCount, vi.DeletedByteCount, vi.ReadOnly, vi.ModifiedAtSecond, vi.ReadCount, vi.ReadBytes, vi.WriteCount, vi.WriteBytes)
 }
 
 func (vi VolumeInfo) ToVolumeInformationMessage() *master_pb.VolumeInformationMessage {
@@ -97,6 +109,12 @@ func (vi VolumeInfo) ToVolumeInformationMessage() *master_pb.VolumeInformationMe
 		RemoteStorageKey:  vi.RemoteStorageKey,
 		DiskType:          vi.DiskType,
 		DiskId:            vi.DiskId,
+		ReadCount:         vi.ReadCount,
+		ReadBytes:         vi.ReadBytes,
+		WriteCount:        vi.WriteCount,
+		WriteBytes:        vi.WriteBytes,
+		ReadMicros:        vi.ReadMicros,
+		WriteMicros:       vi.WriteMicros,
 	}
 }
 
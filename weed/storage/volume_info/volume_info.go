@@ -8,6 +8,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	_ "github.com/seaweedfs/seaweedfs/weed/storage/backend/rclone_backend"
 	_ "github.com/seaweedfs/seaweedfs/weed/storage/backend/s3_backend"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	jsonpb "google.golang.org/protobuf/encoding/protojson"
 )
@@ -70,6 +71,15 @@ func MaybeLoadVolumeInfo(fileName string) (volumeInfo *volume_server_pb.VolumeIn
 	return
 }
 
+// MaybeLoadOffsetWidth reads the offset width of the volume index, which is the default width without the file
+func MaybeLoadOffsetWidth(fileName string) (types.OffsetWidth, error) {
+	volumeInfo, _, _, err := MaybeLoadVolumeInfo(fileName)
+	if err != nil {
+		return 0, err
+	}
+	return types.ParseOffsetWidth(volumeInfo.BytesOffset)
+}
+
 func SaveVolumeInfo(fileName string, volumeInfo *volume_server_pb.VolumeInfo) error {
 
 	if exists, _, canWrite, _, _ := util.CheckFile(fileName); exists && !canWrite {
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+)
+
+// volumeIoStats counts the needle reads and writes of a volume since it is loaded.
+// The counters only grow, consumers compute the rates from two samples.
+type volumeIoStats struct {
+	readCount   atomic.Uint64
+	readBytes   atomic.Uint64
+	readMicros  atomic.Uint64
+	writeCount  atomic.Uint64
+	writeBytes  atomic.Uint64
+	writeMicros atomic.Uint64
+}
+
+func (v *Volume) recordRead(size int, elapsed time.Duration) {
+	v.ioStats.readCount.Add(1)
+	v.ioStats.readMicros.Add(uint64(elapsed.Microseconds()))
+	v.recordReadBytes(size)
+	stats.VolumeServerCollectionIoCounter.WithLabelValues(v.Collection, "read").Inc()
+}
+
+func (v *Volume) recordReadBytes(size int) {
+	if size <= 0 {
+		return
+	}
+	v.ioStats.readBytes.Add(uint64(size))
+	stats.VolumeServerCollectionIoBytesCounter.WithLabelValues(v.Collection, "read").Add(float64(size))
+}
+
+func (v *Volume) recordWrite(size int, elapsed time.Duration) {
+	v.ioStats.writeCount.Add(1)
+	v.ioStats.writeMicros.Add(uint64(elapsed.Microseconds()))
+	stats.VolumeServerCollectionIoCounter.WithLabelValues(v.Collection, "write").Inc()
+	if size > 0 {
+		v.ioStats.writeBytes.Add(uint64(size))
+		stats.VolumeServerCollectionIoBytesCounter.WithLabelValues(v.Collection, "write").Add(float64(size))
+	}
+}
+
+func (v *Volume) recordDelete(elapsed time.Duration) {
+	v.ioStats.writeCount.Add(1)
+	v.ioStats.writeMicros.Add(uint64(elapsed.Microseconds()))
+	stats.VolumeServerCollectionIoCounter.WithLabelValues(v.Collection, "delete").Inc()
+}
+
+func (v *Volume) fillIoStats(m *master_pb.VolumeInformationMessage) {
+	m.ReadCount = v.ioStats.readCount.Load()
+	m.ReadBytes = v.ioStats.readBytes.Load()
+	m.WriteCount = v.ioStats.writeCount.Load()
+	m.WriteBytes = v.ioStats.writeBytes.Load()
+	m.ReadMicros = v.ioStats.readMicros.Load()
+	m.WriteMicros = v.ioStats.writeMicros.Load()
+}
+
+func (v *Volume) IoStats() *volume_server_pb.VolumeIoStats {
+	return &volume_server_pb.VolumeIoStats{
+		VolumeId:    uint32(v.Id),
+		Collection:  v.Collection,
+		DiskType:    string(v.location.DiskType),
+		DiskId:      v.diskId,
+		ReadOnly:    v.IsReadOnly(),
+		ReadCount:   v.ioStats.readCount.Load(),
+		ReadBytes:   v.ioStats.readBytes.Load(),
+		ReadMicros:  v.ioStats.readMicros.Load(),
+		WriteCount:  v.ioStats.writeCount.Load(),
+		WriteBytes:  v.ioStats.writeBytes.Load(),
+		WriteMicros: v.ioStats.writeMicros.Load(),
+	}
+}
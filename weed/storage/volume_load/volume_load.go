@@ -0,0 +1,69 @@
+package volume_load
+
+import (
+	"fmt"
+	"time"
+)
+
+// Key identifies a volume replica by its volume server
+type Key struct {
+	Server   string
+	VolumeId uint32
+}
+
+// Load is the rate of reads and writes of a volume replica in the sampling window
+type Load struct {
+	DiskType                  string
+	DiskId                    uint32
+	ReadOps, WriteOps         float64 // per second
+	ReadBytes, WriteBytes     float64 // per second
+	ReadLatency, WriteLatency time.Duration
+}
+
+// Ops is the operations per second, or zero for an unknown load
+func (l *Load) Ops() float64 {
+	if l == nil {
+		return 0
+	}
+	return l.ReadOps + l.WriteOps
+}
+
+func (l *Load) ReadShare() float64 {
+	if l.Ops() == 0 {
+		return 0
+	}
+	return l.ReadOps / l.Ops()
+}
+
+func (l *Load) String() string {
+	return fmt.Sprintf("%.1f ops/s, %.0f%% reads, read %.2f MB/s written %.2f MB/s, latency read %v write %v",
+		l.Ops(), l.ReadShare()*100, l.ReadBytes/1024/1024, l.WriteBytes/1024/1024, l.ReadLatency, l.WriteLatency)
+}
+
+// Counters are the io counters of a volume replica, as reported by its volume server
+type Counters struct {
+	ReadCount, ReadBytes, ReadMicros    uint64
+	WriteCount, WriteBytes, WriteMicros uint64
+	SampledAt                           time.Time
+}
+
+// LoadSince returns the load between two samples, or nil if the counters are reset in between, e.g. the volume is reloaded
+func (c Counters) LoadSince(prev Counters) *Load {
+	seconds := c.SampledAt.Sub(prev.SampledAt).Seconds()
+	if seconds <= 0 || c.ReadCount < prev.ReadCount || c.WriteCount < prev.WriteCount {
+		return nil
+	}
+	load := &Load{
+		ReadOps:    float64(c.ReadCount-prev.ReadCount) / seconds,
+		WriteOps:   float64(c.WriteCount-prev.WriteCount) / seconds,
+		ReadBytes:  float64(c.ReadBytes-prev.ReadBytes) / seconds,
+		WriteBytes: float64(c.WriteBytes-prev.WriteBytes) / seconds,
+	}
+	if reads := c.ReadCount - prev.ReadCount; reads > 0 {
+		load.ReadLatency = time.Duration((c.ReadMicros-prev.ReadMicros)/reads) * time.Microsecond
+	}
+	if writes := c.WriteCount - prev.WriteCount; writes > 0 {
+		load.WriteLatency = time.Duration((c.WriteMicros-prev.WriteMicros)/writes) * time.Microsecond
+	}
+	return load
+}
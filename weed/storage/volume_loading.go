@@ -3,8 +3,7 @@ package storage
 import (
 	"fmt"
 	"os"
-
-	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"path/filepath"
 
 	"github.com/syndtr/goleveldb/leveldb/opt"
 
@@ -41,6 +40,9 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 		}
 	}()
 
+	if err = v.recoverOffsetWidthConversion(); err != nil {
+		return fmt.Errorf("recover offset width conversion of volume %d: %v", v.Id, err)
+	}
 	hasVolumeInfoFile := v.maybeLoadVolumeInfo()
 
 	if v.volumeInfo.ReadOnly && !v.HasRemoteFile() {
@@ -160,7 +162,7 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 		}
 
 		if v.noWriteOrDelete || v.noWriteCanDelete {
-			if v.nm, err = NewSortedFileNeedleMap(v.IndexFileName(), indexFile); err != nil {
+			if v.nm, err = NewSortedFileNeedleMap(v.IndexFileName(), indexFile, v.offsetWidth); err != nil {
 				glog.V(0).Infof("loading sorted db %s error: %v", v.FileName(".sdx"), err)
 			}
 		} else {
@@ -171,7 +173,7 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 					err = v.tmpNm.UpdateNeedleMap(v, indexFile, nil, 0)
 				} else {
 					glog.V(2).Infoln("loading memory index", v.FileName(".idx"), "to memory")
-					if v.nm, err = LoadCompactNeedleMap(indexFile); err != nil {
+					if v.nm, err = LoadCompactNeedleMap(indexFile, v.offsetWidth); err != nil {
 						glog.V(0).Infof("loading index %s to memory error: %v", v.FileName(".idx"), err)
 					}
 				}
@@ -186,7 +188,7 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 					err = v.tmpNm.UpdateNeedleMap(v, indexFile, opts, v.ldbTimeout)
 				} else {
 					glog.V(0).Infoln("loading leveldb index", v.FileName(".ldb"))
-					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout); err != nil {
+					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout, v.offsetWidth); err != nil {
 						glog.V(0).Infof("loading leveldb %s error: %v", v.FileName(".ldb"), err)
 					}
 				}
@@ -201,7 +203,7 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 					err = v.tmpNm.UpdateNeedleMap(v, indexFile, opts, v.ldbTimeout)
 				} else {
 					glog.V(0).Infoln("loading leveldb medium index", v.FileName(".ldb"))
-					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout); err != nil {
+					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout, v.offsetWidth); err != nil {
 						glog.V(0).Infof("loading leveldb %s error: %v", v.FileName(".ldb"), err)
 					}
 				}
@@ -216,22 +218,36 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 					err = v.tmpNm.UpdateNeedleMap(v, indexFile, opts, v.ldbTimeout)
 				} else {
 					glog.V(0).Infoln("loading leveldb large index", v.FileName(".ldb"))
-					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout); err != nil {
+					if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts, v.ldbTimeout, v.offsetWidth); err != nil {
 						glog.V(0).Infof("loading leveldb %s error: %v", v.FileName(".ldb"), err)
 					}
 				}
+			case NeedleMapPebble:
+				// the entries of compacted volumes are rebuilt when the compaction revision changes
+				glog.V(0).Infoln("loading pebble index", v.FileName(".idx"))
+				if v.nm, err = NewPebbleNeedleMap(filepath.Dir(v.FileName(".idx")), v.Id, v.SuperBlock.CompactionRevision, indexFile, v.offsetWidth); err != nil {
+					glog.V(0).Infof("loading pebble index %s error: %v", v.FileName(".idx"), err)
+				} else if util.FileExists(v.FileName(".ldb")) {
+					// migrated from leveldb, which is rebuilt from the .idx file if needed again
+					glog.V(0).Infof("remove migrated leveldb index %s", v.FileName(".ldb"))
+					os.RemoveAll(v.FileName(".ldb"))
+				}
 			}
 		}
 	}
 
 	if !hasVolumeInfoFile {
 		v.volumeInfo.Version = uint32(v.SuperBlock.Version)
-		v.volumeInfo.BytesOffset = uint32(types.OffsetSize)
+		v.volumeInfo.BytesOffset = uint32(v.offsetWidth)
 		if err := v.SaveVolumeInfo(); err != nil {
 			glog.Warningf("volume %d failed to save file info: %v", v.Id, err)
 		}
 	}
 
+	if err == nil && v.IsWorm() {
+		err = v.openWormChain()
+	}
+
 	stats.VolumeServerVolumeGauge.WithLabelValues(v.Collection, "volume").Inc()
 
 	if err == nil {
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/storage/idx"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_map"
+	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+// checkOffsetWidthConversion checks whether the volume can be converted to the offset width
+func (v *Volume) checkOffsetWidthConversion(offsetWidth OffsetWidth) error {
+	if v.isCompacting || v.isCommitCompacting {
+		return fmt.Errorf("volume %d is compacting", v.Id)
+	}
+	if datSize, _, _ := v.FileStat(); datSize > offsetWidth.MaxVolumeSize() {
+		return fmt.Errorf("volume %d size %d exceeds %d addressable by %d-byte offsets", v.Id, datSize, offsetWidth.MaxVolumeSize(), offsetWidth)
+	}
+	return nil
+}
+
+// convertOffsetWidth rewrites the index of the closed volume with the offset width,
+// and records the offset width in the .vif file.
+// Renaming the converted index over the .idx file commits the conversion. Until the .vif file
+// records the new width, a .cvw file keeps it, so loading the volume can finish or undo a
+// conversion interrupted by a crash, see recoverOffsetWidthConversion.
+func (v *Volume) convertOffsetWidth(offsetWidth OffsetWidth) error {
+	indexFileName := v.FileName(".idx")
+	tmpFileName := indexFileName + ".cvt"
+	markerFileName := v.FileName(".cvw")
+	if err := convertIndexOffsetWidth(indexFileName, v.offsetWidth, tmpFileName, offsetWidth); err != nil {
+		os.Remove(tmpFileName)
+		return err
+	}
+	if err := util.WriteFile(markerFileName, []byte(strconv.Itoa(int(offsetWidth))), 0644); err != nil {
+		os.Remove(tmpFileName)
+		os.Remove(markerFileName)
+		return fmt.Errorf("volume %d save %s: %v", v.Id, markerFileName, err)
+	}
+
+	// the needle maps derived from the index are rebuilt on loading, from either index
+	if err := os.RemoveAll(v.FileName(".ldb")); err != nil {
+		glog.Warningf("remove %s: %v", v.FileName(".ldb"), err)
+	}
+	if err := os.Remove(v.IndexFileName() + ".sdx"); err != nil && !os.IsNotExist(err) {
+		glog.Warningf("remove %s.sdx: %v", v.IndexFileName(), err)
+	}
+
+	if err := os.Rename(tmpFileName, indexFileName); err != nil {
+		// the .vif file is not changed yet
+		os.Remove(tmpFileName)
+		os.Remove(markerFileName)
+		return fmt.Errorf("rename %s: %v", tmpFileName, err)
+	}
+	v.offsetWidth = offsetWidth
+
+	if err := finishOffsetWidthConversion(v.FileName(".vif"), markerFileName, offsetWidth); err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+	return nil
+}
+
+// recoverOffsetWidthConversion finishes the offset width conversion interrupted after committing
+// the converted index, or drops the converted index if it was not committed.
+func (v *Volume) recoverOffsetWidthConversion() error {
+	tmpFileName := v.FileName(".idx") + ".cvt"
+	markerFileName := v.FileName(".cvw")
+	if !util.FileExists(markerFileName) {
+		if util.FileExists(tmpFileName) {
+			glog.V(0).Infof("volume %d: remove unfinished converted index %s", v.Id, tmpFileName)
+			return os.Remove(tmpFileName)
+		}
+		return nil
+	}
+	if util.FileExists(tmpFileName) {
+		glog.V(0).Infof("volume %d: undo offset width conversion", v.Id)
+		if err := os.Remove(tmpFileName); err != nil {
+			return err
+		}
+		return os.Remove(markerFileName)
+	}
+	data, err := os.ReadFile(markerFileName)
+	if err != nil {
+		return err
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("parse %s: %v", markerFileName, err)
+	}
+	offsetWidth, err := ParseOffsetWidth(uint32(width))
+	if err != nil {
+		return fmt.Errorf("parse %s: %v", markerFileName, err)
+	}
+	glog.V(0).Infof("volume %d: finish offset width conversion to %d", v.Id, offsetWidth)
+	return finishOffsetWidthConversion(v.FileName(".vif"), markerFileName, offsetWidth)
+}
+
+// finishOffsetWidthConversion records the offset width of the committed index in the .vif file
+func finishOffsetWidthConversion(vifFileName, markerFileName string, offsetWidth OffsetWidth) error {
+	volumeInfo, _, _, err := volume_info.MaybeLoadVolumeInfo(vifFileName)
+	if err != nil {
+		return fmt.Errorf("load %s: %v", vifFileName, err)
+	}
+	volumeInfo.BytesOffset = uint32(offsetWidth)
+	// the .vif file is replaced whole, so a crash leaves either the old or the new one
+	if err = volume_info.SaveVolumeInfo(vifFileName+".tmp", volumeInfo); err != nil {
+		os.Remove(vifFileName + ".tmp")
+		return fmt.Errorf("save %s: %v", vifFileName, err)
+	}
+	if err = os.Rename(vifFileName+".tmp", vifFileName); err != nil {
+		os.Remove(vifFileName + ".tmp")
+		return fmt.Errorf("rename %s.tmp: %v", vifFileName, err)
+	}
+	return os.Remove(markerFileName)
+}
+
+func convertIndexOffsetWidth(srcFileName string, srcWidth OffsetWidth, dstFileName string, dstWidth OffsetWidth) error {
+	srcFile, err := os.OpenFile(srcFileName, os.O_RDONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", srcFileName, err)
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dstFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", dstFileName, err)
+	}
+	defer dstFile.Close()
+
+	writer := bufio.NewWriter(dstFile)
+	err = idx.WalkIndexFileWithWidth(srcFile, srcWidth, 0, func(key NeedleId, offset Offset, size Size) error {
+		_, writeErr := writer.Write(needle_map.ToBytesWithWidth(dstWidth, key, offset, size))
+		return writeErr
+	})
+	if err != nil {
+		return fmt.Errorf("convert %s: %v", srcFileName, err)
+	}
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("flush %s: %v", dstFileName, err)
+	}
+	return dstFile.Sync()
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+func TestConvertVolumeOffsetWidth(t *testing.T) {
+	for _, needleMapKind := range []NeedleMapKind{NeedleMapInMemory, NeedleMapLevelDb} {
+		dir := t.TempDir()
+
+		v, err := NewVolumeWithOffsetWidth(dir, dir, "", 1, needleMapKind, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0, types.OffsetWidth4)
+		if err != nil {
+			t.Fatalf("volume creation: %v", err)
+		}
+		infos := make([]*needleInfo, 100)
+		for i := 1; i <= len(infos); i++ {
+			doSomeWritesDeletes(i, v, t, infos)
+		}
+		v.Close()
+
+		if err = v.convertOffsetWidth(types.OffsetWidth5); err != nil {
+			t.Fatalf("convert: %v", err)
+		}
+
+		v, err = NewVolume(dir, dir, "", 1, needleMapKind, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+		if err != nil {
+			t.Fatalf("volume reloading: %v", err)
+		}
+		if v.OffsetWidth() != types.OffsetWidth5 {
+			t.Fatalf("offset width expected %d found %d", types.OffsetWidth5, v.OffsetWidth())
+		}
+		for i, info := range infos {
+			if info.size == 0 {
+				continue
+			}
+			n := newEmptyNeedle(uint64(i + 1))
+			size, err := v.readNeedle(n, nil, nil)
+			if err != nil {
+				t.Fatalf("read file %d: %v", i+1, err)
+			}
+			if info.size != types.Size(size) || info.crc != n.Checksum {
+				t.Fatalf("read file %d mismatch", i+1)
+			}
+		}
+
+		// new writes use the converted index
+		n := newRandomNeedle(uint64(len(infos) + 1))
+		if _, _, _, err = v.writeNeedle2(n, true, false); err != nil {
+			t.Fatalf("write after conversion: %v", err)
+		}
+		v.Close()
+	}
+}
+
+func TestRecoverOffsetWidthConversion(t *testing.T) {
+	for _, committed := range []bool{false, true} {
+		dir := t.TempDir()
+
+		v, err := NewVolumeWithOffsetWidth(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0, types.OffsetWidth4)
+		if err != nil {
+			t.Fatalf("volume creation: %v", err)
+		}
+		infos := make([]*needleInfo, 100)
+		for i := 1; i <= len(infos); i++ {
+			doSomeWritesDeletes(i, v, t, infos)
+		}
+		v.Close()
+
+		// a crash before or after renaming the converted index, before saving the .vif file
+		indexFileName := v.FileName(".idx")
+		if err = convertIndexOffsetWidth(indexFileName, types.OffsetWidth4, indexFileName+".cvt", types.OffsetWidth5); err != nil {
+			t.Fatalf("convert index: %v", err)
+		}
+		if err = os.WriteFile(v.FileName(".cvw"), []byte("5"), 0644); err != nil {
+			t.Fatalf("write marker: %v", err)
+		}
+		expected := types.OffsetWidth4
+		if committed {
+			if err = os.Rename(indexFileName+".cvt", indexFileName); err != nil {
+				t.Fatalf("rename: %v", err)
+			}
+			expected = types.OffsetWidth5
+		}
+
+		v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+		if err != nil {
+			t.Fatalf("volume reloading: %v", err)
+		}
+		if v.OffsetWidth() != expected {
+			t.Fatalf("committed %v: offset width expected %d found %d", committed, expected, v.OffsetWidth())
+		}
+		if util.FileExists(v.FileName(".cvw")) || util.FileExists(indexFileName+".cvt") {
+			t.Fatalf("committed %v: conversion files left", committed)
+		}
+		for i, info := range infos {
+			if info.size == 0 {
+				continue
+			}
+			n := newEmptyNeedle(uint64(i + 1))
+			size, err := v.readNeedle(n, nil, nil)
+			if err != nil {
+				t.Fatalf("committed %v: read file %d: %v", committed, i+1, err)
+			}
+			if info.size != types.Size(size) || info.crc != n.Checksum {
+				t.Fatalf("committed %v: read file %d mismatch", committed, i+1)
+			}
+		}
+		v.Close()
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/storage/backend"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle_cache"
 	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
 	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
 )
@@ -41,12 +42,12 @@ func (v *Volume) readNeedle(n *needle.Needle, readOption *ReadOption, onReadSize
 	if onReadSizeFn != nil {
 		onReadSizeFn(readSize)
 	}
-	if readOption != nil && readOption.AttemptMetaOnly && readSize > PagedReadLimit {
+	if readOption != nil && readOption.AttemptMetaOnly && readSize > PagedReadLimit && !v.isReadCacheable(readSize) {
 		readOption.VolumeRevision = v.SuperBlock.CompactionRevision
 		err = n.ReadNeedleMeta(v.DataBackend, nv.Offset.ToActualOffset(), readSize, v.Version())
-		if err == needle.ErrorSizeMismatch && OffsetSize == 4 {
+		if err == needle.ErrorSizeMismatch && v.offsetWidth == OffsetWidth4 {
 			readOption.IsOutOfRange = true
-			err = n.ReadNeedleMeta(v.DataBackend, nv.Offset.ToActualOffset()+int64(MaxPossibleVolumeSize), readSize, v.Version())
+			err = n.ReadNeedleMeta(v.DataBackend, nv.Offset.ToActualOffset()+int64(OffsetWidth4.MaxVolumeSize()), readSize, v.Version())
 		}
 		if err != nil {
 			return 0, err
@@ -56,7 +57,7 @@ func (v *Volume) readNeedle(n *needle.Needle, readOption *ReadOption, onReadSize
 		}
 	}
 	if readOption == nil || !readOption.IsMetaOnly {
-		err = n.ReadData(v.DataBackend, nv.Offset.ToActualOffset(), readSize, v.Version())
+		err = v.readNeedleData(n, nv.Offset.ToActualOffset(), readSize)
 		v.checkReadWriteError(err)
 		if err != nil {
 			return 0, err
@@ -79,6 +80,52 @@ func (v *Volume) readNeedle(n *needle.Needle, readOption *ReadOption, onReadSize
 	return -1, ErrorNotFound
 }
 
+// readNeedleData reads the whole needle, through the local read cache if the volume is on a remote tier
+func (v *Volume) readNeedleData(n *needle.Needle, offset int64, size Size) (err error) {
+	readCache := v.remoteReadCache()
+	if readCache == nil {
+		return n.ReadDataWithOffsetWidth(v.DataBackend, offset, size, v.Version(), v.offsetWidth)
+	}
+
+	key := needle_cache.NeedleKey{VolumeId: uint32(v.Id), NeedleId: n.Id, Offset: offset, Size: size}
+	if blob, found := readCache.Get(key); found {
+		if err = n.ReadBytes(blob, offset, size, v.Version()); err == nil {
+			return nil
+		}
+		glog.V(0).Infof("volume %d needle %s from read cache: %v", v.Id, n.Id, err)
+	}
+
+	blob, err := needle.ReadNeedleBlob(v.DataBackend, offset, size, v.Version())
+	if err != nil {
+		return err
+	}
+	err = n.ReadBytes(blob, offset, size, v.Version())
+	if err == needle.ErrorSizeMismatch && v.offsetWidth == OffsetWidth4 {
+		if blob, err = needle.ReadNeedleBlob(v.DataBackend, offset+int64(OffsetWidth4.MaxVolumeSize()), size, v.Version()); err != nil {
+			return err
+		}
+		err = n.ReadBytes(blob, offset+int64(OffsetWidth4.MaxVolumeSize()), size, v.Version())
+	}
+	if err != nil {
+		return err
+	}
+	readCache.Set(key, blob)
+	return nil
+}
+
+func (v *Volume) remoteReadCache() *needle_cache.NeedleCache {
+	if v.location == nil || !v.HasRemoteFile() {
+		return nil
+	}
+	return v.location.readCache
+}
+
+// isReadCacheable tells whether the whole needle should be read, so it can be kept in the read cache
+func (v *Volume) isReadCacheable(size Size) bool {
+	readCache := v.remoteReadCache()
+	return readCache != nil && readCache.Fits(needle.GetActualSize(size, v.Version()))
+}
+
 // read needle at a specific offset
 func (v *Volume) readNeedleMetaAt(n *needle.Needle, offset int64, size int32) (err error) {
 	v.dataFileAccessLock.RLock()
@@ -88,8 +135,8 @@ func (v *Volume) readNeedleMetaAt(n *needle.Needle, offset int64, size int32) (e
 		size = 0
 	}
 	err = n.ReadNeedleMeta(v.DataBackend, offset, Size(size), v.Version())
-	if err == needle.ErrorSizeMismatch && OffsetSize == 4 {
-		err = n.ReadNeedleMeta(v.DataBackend, offset+int64(MaxPossibleVolumeSize), Size(size), v.Version())
+	if err == needle.ErrorSizeMismatch && v.offsetWidth == OffsetWidth4 {
+		err = n.ReadNeedleMeta(v.DataBackend, offset+int64(OffsetWidth4.MaxVolumeSize()), Size(size), v.Version())
 	}
 	if err != nil {
 		return err
@@ -100,6 +147,12 @@ func (v *Volume) readNeedleMetaAt(n *needle.Needle, offset int64, size int32) (e
 // read fills in Needle content by looking up n.Id from NeedleMapper
 func (v *Volume) readNeedleDataInto(n *needle.Needle, readOption *ReadOption, writer io.Writer, offset int64, size int64) (err error) {
 
+	if readOption.SendFile {
+		if sent, err := v.sendNeedleData(n, readOption, writer, offset, size); sent || err != nil {
+			return err
+		}
+	}
+
 	if !readOption.HasSlowRead {
 		v.dataFileAccessLock.RLock()
 		defer v.dataFileAccessLock.RUnlock()
@@ -108,30 +161,12 @@ func (v *Volume) readNeedleDataInto(n *needle.Needle, readOption *ReadOption, wr
 	if readOption.HasSlowRead {
 		v.dataFileAccessLock.RLock()
 	}
-	nv, ok := v.nm.Get(n.Id)
+	actualOffset, readSize, err := v.lookupNeedleData(n, readOption)
 	if readOption.HasSlowRead {
 		v.dataFileAccessLock.RUnlock()
 	}
-
-	if !ok || nv.Offset.IsZero() {
-		return ErrorNotFound
-	}
-	readSize := nv.Size
-	if readSize.IsDeleted() {
-		if readOption != nil && readOption.ReadDeleted && readSize != TombstoneFileSize {
-			glog.V(3).Infof("reading deleted %s", n.String())
-			readSize = -readSize
-		} else {
-			return ErrorDeleted
-		}
-	}
-	if readSize == 0 {
-		return nil
-	}
-
-	actualOffset := nv.Offset.ToActualOffset()
-	if readOption.IsOutOfRange {
-		actualOffset += int64(MaxPossibleVolumeSize)
+	if err != nil || readSize == 0 {
+		return err
 	}
 
 	buf := mem.Allocate(min(readOption.ReadBufferSize, int(size)))
@@ -147,7 +182,7 @@ func (v *Volume) readNeedleDataInto(n *needle.Needle, readOption *ReadOption, wr
 		// possibly re-read needle offset if volume is compacted
 		if readOption.VolumeRevision != v.SuperBlock.CompactionRevision {
 			// the volume is compacted
-			nv, ok = v.nm.Get(n.Id)
+			nv, ok := v.nm.Get(n.Id)
 			if !ok || nv.Offset.IsZero() {
 				if readOption.HasSlowRead {
 					v.dataFileAccessLock.RUnlock()
@@ -193,6 +228,59 @@ func (v *Volume) readNeedleDataInto(n *needle.Needle, readOption *ReadOption, wr
 
 }
 
+// lookupNeedleData returns the offset and size of the needle in the .dat file, with the data file lock held
+func (v *Volume) lookupNeedleData(n *needle.Needle, readOption *ReadOption) (actualOffset int64, readSize Size, err error) {
+	nv, ok := v.nm.Get(n.Id)
+	if !ok || nv.Offset.IsZero() {
+		return 0, 0, ErrorNotFound
+	}
+	readSize = nv.Size
+	if readSize.IsDeleted() {
+		if readOption != nil && readOption.ReadDeleted && readSize != TombstoneFileSize {
+			glog.V(3).Infof("reading deleted %s", n.String())
+			readSize = -readSize
+		} else {
+			return 0, 0, ErrorDeleted
+		}
+	}
+	actualOffset = nv.Offset.ToActualOffset()
+	if readOption.IsOutOfRange {
+		actualOffset += int64(OffsetWidth4.MaxVolumeSize())
+	}
+	return actualOffset, readSize, nil
+}
+
+// sendNeedleData looks up the needle and takes a .dat file handle under the lock, and copies the data after releasing it.
+// The handle keeps the looked up data readable even if the volume is compacted meanwhile.
+// Writers implementing io.ReaderFrom, e.g. http responses on plain tcp connections, let the kernel copy the data.
+func (v *Volume) sendNeedleData(n *needle.Needle, readOption *ReadOption, writer io.Writer, offset int64, size int64) (sent bool, err error) {
+	v.dataFileAccessLock.RLock()
+	diskFile, ok := v.DataBackend.(*backend.DiskFile)
+	if !ok {
+		v.dataFileAccessLock.RUnlock()
+		return false, nil
+	}
+	actualOffset, readSize, err := v.lookupNeedleData(n, readOption)
+	if err != nil || readSize == 0 {
+		v.dataFileAccessLock.RUnlock()
+		return true, err
+	}
+	datFile, err := diskFile.AcquireReader()
+	v.dataFileAccessLock.RUnlock()
+	if err != nil {
+		return true, fmt.Errorf("sendNeedleData open %s: %w", diskFile.Name(), err)
+	}
+	defer diskFile.ReleaseReader(datFile)
+
+	if _, err = datFile.Seek(actualOffset+NeedleHeaderSize+DataSizeSize+offset, io.SeekStart); err != nil {
+		return true, fmt.Errorf("sendNeedleData seek %s: %w", diskFile.Name(), err)
+	}
+	if _, err = io.CopyN(writer, datFile, size); err != nil {
+		return true, fmt.Errorf("sendNeedleData write: %w", err)
+	}
+	return true, nil
+}
+
 func min(x, y int) int {
 	if x < y {
 		return x
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
@@ -96,3 +97,45 @@ func TestReadNeedMetaWithDeletesThenWrites(t *testing.T) {
 		expectedLastUpdateTime += 2000
 	}
 }
+
+func TestReadNeedleDataIntoWithSendFile(t *testing.T) {
+	dir := t.TempDir()
+
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	defer v.Close()
+	for i := 1; i <= 3; i++ {
+		if _, _, _, err = v.writeNeedle2(newRandomNeedle(uint64(i)), true, false); err != nil {
+			t.Fatalf("write needle %d: %v", i, err)
+		}
+	}
+
+	for _, hasSlowRead := range []bool{false, true} {
+		for _, sendFile := range []bool{false, true} {
+			n := newEmptyNeedle(2)
+			readOption := &ReadOption{AttemptMetaOnly: true, MustMetaOnly: true, ReadBufferSize: 1024 * 1024, SendFile: sendFile, HasSlowRead: hasSlowRead}
+			if _, err = v.readNeedle(n, readOption, nil); err != nil {
+				t.Fatalf("read needle meta: %v", err)
+			}
+			expected := new(needle.Needle)
+			expected.Id = n.Id
+			if _, err = v.readNeedle(expected, nil, nil); err != nil {
+				t.Fatalf("read needle: %v", err)
+			}
+
+			var whole, partial bytes.Buffer
+			if err = v.readNeedleDataInto(n, readOption, &whole, 0, int64(n.DataSize)); err != nil {
+				t.Fatalf("read needle data with sendFile %v slow read %v: %v", sendFile, hasSlowRead, err)
+			}
+			assert.Equal(t, expected.Data, whole.Bytes())
+			if n.DataSize > 2 {
+				if err = v.readNeedleDataInto(n, readOption, &partial, 1, int64(n.DataSize)-2); err != nil {
+					t.Fatalf("read needle range with sendFile %v slow read %v: %v", sendFile, hasSlowRead, err)
+				}
+				assert.Equal(t, expected.Data[1:n.DataSize-1], partial.Bytes())
+			}
+		}
+	}
+}
@@ -30,22 +30,21 @@ func (v *Volume) maybeLoadVolumeInfo() (found bool) {
 	if v.hasRemoteFile {
 		glog.V(0).Infof("volume %d is tiered to %s as %s and read only", v.Id,
 			v.volumeInfo.Files[0].BackendName(), v.volumeInfo.Files[0].Key)
-	} else {
-		if v.volumeInfo.BytesOffset == 0 {
-			v.volumeInfo.BytesOffset = uint32(types.OffsetSize)
-		}
 	}
 
-	if v.volumeInfo.BytesOffset != 0 && v.volumeInfo.BytesOffset != uint32(types.OffsetSize) {
-		var m string
-		if types.OffsetSize == 5 {
-			m = "without"
-		} else {
-			m = "with"
-		}
-		glog.Exitf("BytesOffset mismatch in volume info file %s, try use binary version %s large_disk", v.FileName(".vif"), m)
+	if v.offsetWidth == 0 {
+		v.offsetWidth = types.DefaultOffsetWidth
+	}
+	if v.volumeInfo.BytesOffset == 0 {
+		// volumes without the width in the .vif file were written with the default width
+		v.volumeInfo.BytesOffset = uint32(v.offsetWidth)
+	}
+	offsetWidth, parseErr := types.ParseOffsetWidth(v.volumeInfo.BytesOffset)
+	if parseErr != nil {
+		glog.Exitf("volume info file %s: %v", v.FileName(".vif"), parseErr)
 		return
 	}
+	v.offsetWidth = offsetWidth
 
 	if err != nil {
 		glog.Warningf("load volume %d.vif file: %v", v.Id, err)
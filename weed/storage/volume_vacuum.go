@@ -50,6 +50,9 @@ func (v *Volume) Compact(preallocate int64, compactionBytePerSecond int64) error
 		glog.V(0).Infof("Volume %d is already compacting...", v.Id)
 		return nil
 	}
+	if v.IsWorm() {
+		return fmt.Errorf("write-once volume %d can not be vacuumed", v.Id)
+	}
 	v.isCompacting = true
 	defer func() {
 		v.isCompacting = false
@@ -79,6 +82,9 @@ func (v *Volume) Compact2(preallocate int64, compactionBytePerSecond int64, prog
 		glog.V(0).Infof("Volume %d is already compacting2 ...", v.Id)
 		return nil
 	}
+	if v.IsWorm() {
+		return fmt.Errorf("write-once volume %d can not be vacuumed", v.Id)
+	}
 	v.isCompacting = true
 	defer func() {
 		v.isCompacting = false
@@ -226,7 +232,7 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 	defer oldDatBackend.Close()
 
 	// skip if the old .idx file has not changed
-	if indexSize, err = verifyIndexFileIntegrity(oldIdxFile); err != nil {
+	if indexSize, err = verifyIndexFileIntegrity(oldIdxFile, v.offsetWidth); err != nil {
 		return fmt.Errorf("verifyIndexFileIntegrity %s failed: %v", oldIdxFileName, err)
 	}
 	if indexSize == 0 || uint64(indexSize) <= v.lastCompactIndexOffset {
@@ -248,12 +254,13 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 	}
 	incrementedHasUpdatedIndexEntry := make(map[NeedleId]keyField)
 
-	for idxOffset := indexSize - NeedleMapEntrySize; uint64(idxOffset) >= v.lastCompactIndexOffset; idxOffset -= NeedleMapEntrySize {
+	entrySize := int64(v.offsetWidth.EntrySize())
+	for idxOffset := indexSize - entrySize; uint64(idxOffset) >= v.lastCompactIndexOffset; idxOffset -= entrySize {
 		var IdxEntry []byte
-		if IdxEntry, err = readIndexEntryAtOffset(oldIdxFile, idxOffset); err != nil {
+		if IdxEntry, err = readIndexEntryAtOffset(oldIdxFile, idxOffset, v.offsetWidth); err != nil {
 			return fmt.Errorf("readIndexEntry %s at offset %d failed: %v", oldIdxFileName, idxOffset, err)
 		}
-		key, offset, size := idx2.IdxFileEntry(IdxEntry)
+		key, offset, size := idx2.IdxFileEntryWithWidth(v.offsetWidth, IdxEntry)
 		glog.V(4).Infof("key %d offset %d size %d", key, offset, size)
 		if _, found := incrementedHasUpdatedIndexEntry[key]; !found {
 			incrementedHasUpdatedIndexEntry[key] = keyField{
@@ -304,7 +311,7 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 
 	for key, increIdxEntry := range incrementedHasUpdatedIndexEntry {
 
-		idxEntryBytes := needle_map.ToBytes(key, increIdxEntry.offset, increIdxEntry.size)
+		idxEntryBytes := needle_map.ToBytesWithWidth(v.offsetWidth, key, increIdxEntry.offset, increIdxEntry.size)
 
 		var offset int64
 		if offset, err = dst.Seek(0, 2); err != nil {
@@ -332,18 +339,18 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 			if err := dstDatBackend.Sync(); err != nil {
 				return fmt.Errorf("cannot sync needle %s: %v", dstDatBackend.File.Name(), err)
 			}
-			util.Uint32toBytes(idxEntryBytes[8:12], uint32(offset/NeedlePaddingSize))
+			v.offsetWidth.OffsetToBytes(idxEntryBytes[NeedleIdSize:], ToOffset(offset))
 		} else { //deleted needle
 			//fakeDelNeedle's default Data field is nil
 			fakeDelNeedle := new(needle.Needle)
 			fakeDelNeedle.Id = key
 			fakeDelNeedle.Cookie = 0x12345678
 			fakeDelNeedle.AppendAtNs = uint64(time.Now().UnixNano())
-			_, _, _, err = fakeDelNeedle.Append(dstDatBackend, v.Version())
+			_, _, _, err = fakeDelNeedle.AppendWithOffsetWidth(dstDatBackend, v.Version(), v.offsetWidth)
 			if err != nil {
 				return fmt.Errorf("append deleted %d failed: %v", key, err)
 			}
-			util.Uint32toBytes(idxEntryBytes[8:12], uint32(0))
+			v.offsetWidth.OffsetToBytes(idxEntryBytes[NeedleIdSize:], Offset{})
 		}
 
 		if _, err := idx.Seek(0, 2); err != nil {
@@ -356,7 +363,10 @@ func (v *Volume) makeupDiff(newDatFileName, newIdxFileName, oldDatFileName, oldI
 		}
 	}
 
-	return v.tmpNm.DoOffsetLoading(v, idx, uint64(idxSize)/NeedleMapEntrySize)
+	if v.tmpNm == nil {
+		return nil
+	}
+	return v.tmpNm.DoOffsetLoading(v, idx, uint64(idxSize)/uint64(entrySize))
 }
 
 type VolumeFileScanner4Vacuum struct {
@@ -391,7 +401,7 @@ func (scanner *VolumeFileScanner4Vacuum) VisitNeedle(n *needle.Needle, offset in
 		if err := scanner.nm.Set(n.Id, ToOffset(scanner.newOffset), n.Size); err != nil {
 			return fmt.Errorf("cannot put needle: %s", err)
 		}
-		if _, _, _, err := n.Append(scanner.dstBackend, scanner.v.Version()); err != nil {
+		if _, _, _, err := n.AppendWithOffsetWidth(scanner.dstBackend, scanner.v.Version(), scanner.v.offsetWidth); err != nil {
 			return fmt.Errorf("cannot append needle: %s", err)
 		}
 		delta := n.DiskSize(scanner.version)
@@ -409,7 +419,7 @@ func (v *Volume) copyDataAndGenerateIndexFile(dstName, idxName string, prealloca
 	}
 	defer dst.Close()
 
-	nm := needle_map.NewMemDb()
+	nm := needle_map.NewMemDbWithOffsetWidth(v.offsetWidth)
 	defer nm.Close()
 
 	scanner := &VolumeFileScanner4Vacuum{
@@ -440,9 +450,9 @@ func (v *Volume) copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, da
 		dstDatBackend.Close()
 	}()
 
-	oldNm := needle_map.NewMemDb()
+	oldNm := needle_map.NewMemDbWithOffsetWidth(v.offsetWidth)
 	defer oldNm.Close()
-	newNm := needle_map.NewMemDb()
+	newNm := needle_map.NewMemDbWithOffsetWidth(v.offsetWidth)
 	defer newNm.Close()
 	if err = oldNm.LoadFromIdx(srcIdxName); err != nil {
 		return err
@@ -475,7 +485,7 @@ func (v *Volume) copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, da
 		}
 
 		n := new(needle.Needle)
-		if err := n.ReadData(srcDatBackend, offset.ToActualOffset(), size, version); err != nil {
+		if err := n.ReadDataWithOffsetWidth(srcDatBackend, offset.ToActualOffset(), size, version, v.offsetWidth); err != nil {
 			return fmt.Errorf("cannot hydrate needle from file: %s", err)
 		}
 
@@ -486,7 +496,7 @@ func (v *Volume) copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, da
 		if err = newNm.Set(n.Id, ToOffset(newOffset), n.Size); err != nil {
 			return fmt.Errorf("cannot put needle: %s", err)
 		}
-		if _, _, _, err = n.Append(dstDatBackend, sb.Version); err != nil {
+		if _, _, _, err = n.AppendWithOffsetWidth(dstDatBackend, sb.Version, v.offsetWidth); err != nil {
 			return fmt.Errorf("cannot append needle: %s", err)
 		}
 		delta := n.DiskSize(version)
@@ -538,14 +548,19 @@ func (v *Volume) copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, da
 		nm := &NeedleMap{
 			m: needle_map.NewCompactMap(),
 		}
+		nm.offsetWidth = v.offsetWidth
 		v.tmpNm = nm
 		//can be optimized, filling nm in oldNm.AscendingVisit
 		err = v.tmpNm.DoOffsetLoading(nil, indexFile, 0)
 		return err
+	} else if v.needleMapKind == NeedleMapPebble {
+		// the shared pebble db rebuilds the volume entries from the new .idx file after committing
+		return nil
 	} else {
 		dbFileName := v.FileName(".ldb")
 		m := &LevelDbNeedleMap{dbFileName: dbFileName}
 		m.dbFileName = dbFileName
+		m.offsetWidth = v.offsetWidth
 		mm := &mapMetric{}
 		m.mapMetric = *mm
 		v.tmpNm = m
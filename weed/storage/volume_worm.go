@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/backend"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	. "github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"github.com/seaweedfs/seaweedfs/weed/util"
+)
+
+/*
+A write-once (WORM) volume has the retention in its super block extra.
+Needles can not be deleted or overwritten, and the volume can not be vacuumed,
+or deleted until the retention after its last write expires.
+
+Every needle appended to the .dat file is chained in the .wch file:
+	needle id, offset, size, sha256(previous hash + needle bytes in the .dat file)
+Auditors can record the chain head, and later verify the .dat file still produces it.
+*/
+
+var (
+	ErrWormDelete    = errors.New("write-once volume does not allow deletes")
+	ErrWormOverwrite = errors.New("write-once volume does not allow overwrites")
+)
+
+const wormChainRecordSize = NeedleIdSize + 8 + SizeSize + sha256.Size
+
+func newWormSuperBlockExtra(retentionSeconds uint64) *master_pb.SuperBlockExtra {
+	return &master_pb.SuperBlockExtra{
+		Worm: &master_pb.SuperBlockExtra_Worm{
+			RetentionSeconds: retentionSeconds,
+		},
+	}
+}
+
+func (v *Volume) IsWorm() bool {
+	return v.SuperBlock.Extra != nil && v.SuperBlock.Extra.Worm != nil
+}
+
+func (v *Volume) WormRetention() time.Duration {
+	if !v.IsWorm() {
+		return 0
+	}
+	return time.Duration(v.SuperBlock.Extra.Worm.RetentionSeconds) * time.Second
+}
+
+// WormRetainedUntil returns when the write-once volume stops retaining its needles, or zero if it retains none.
+// Without the chain, e.g. if it failed to load, the needles are assumed to be retained.
+func (v *Volume) WormRetainedUntil() time.Time {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+	return v.wormRetainedUntil()
+}
+
+// wormRetainedUntil is WormRetainedUntil with the data file lock held
+func (v *Volume) wormRetainedUntil() time.Time {
+	if !v.IsWorm() || (v.wormChain != nil && v.wormChain.count == 0) {
+		return time.Time{}
+	}
+	lastWrite := time.Unix(0, int64(v.lastAppendAtNs))
+	if v.lastAppendAtNs == 0 && v.DataBackend != nil {
+		if _, modTime, err := v.DataBackend.GetStat(); err == nil {
+			lastWrite = modTime
+		}
+	}
+	if retainedUntil := lastWrite.Add(v.WormRetention()); time.Now().Before(retainedUntil) {
+		return retainedUntil
+	}
+	return time.Time{}
+}
+
+// checkWormRetention returns an error if the write-once volume still retains needles, with the data file lock held
+func (v *Volume) checkWormRetention() error {
+	if retainedUntil := v.wormRetainedUntil(); !retainedUntil.IsZero() {
+		return fmt.Errorf("write-once volume %d is retained until %v", v.Id, retainedUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+type wormChain struct {
+	file  *os.File
+	count int64             // number of chained needles
+	end   int64             // .dat file offset after the last chained needle
+	head  [sha256.Size]byte // hash of the last chained needle
+}
+
+// openWormChain loads the .wch file, and chains the needles appended after it
+func (v *Volume) openWormChain() error {
+	file, err := os.OpenFile(v.FileName(".wch"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", v.FileName(".wch"), err)
+	}
+	chain := &wormChain{file: file, end: int64(v.SuperBlock.BlockSize())}
+	if err = chain.load(v.Version()); err != nil {
+		file.Close()
+		return fmt.Errorf("load %s: %v", v.FileName(".wch"), err)
+	}
+	datSize, _, err := v.DataBackend.GetStat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if chain.end > datSize {
+		file.Close()
+		return fmt.Errorf("%s chains %d bytes but %s has %d bytes", v.FileName(".wch"), chain.end, v.DataBackend.Name(), datSize)
+	}
+	if err = chain.extend(v.DataBackend, v.Version(), datSize); err != nil {
+		file.Close()
+		return fmt.Errorf("extend %s: %v", v.FileName(".wch"), err)
+	}
+	v.wormChain = chain
+	return nil
+}
+
+func (c *wormChain) load(version needle.Version) error {
+	stat, err := c.file.Stat()
+	if err != nil {
+		return err
+	}
+	c.count = stat.Size() / wormChainRecordSize
+	if stat.Size()%wormChainRecordSize != 0 {
+		glog.Warningf("truncate partial record in %s", c.file.Name())
+		if err = c.file.Truncate(c.count * wormChainRecordSize); err != nil {
+			return err
+		}
+	}
+	if c.count == 0 {
+		return nil
+	}
+	record := make([]byte, wormChainRecordSize)
+	if _, err = c.file.ReadAt(record, (c.count-1)*wormChainRecordSize); err != nil {
+		return err
+	}
+	_, offset, size, hash := parseWormChainRecord(record)
+	c.end = offset + needle.GetActualSize(size, version)
+	copy(c.head[:], hash)
+	return nil
+}
+
+// extend chains the needles in the .dat file up to datSize
+func (c *wormChain) extend(datBackend backend.BackendStorageFile, version needle.Version, datSize int64) error {
+	for c.end < datSize {
+		n, blob, err := readNeedleBlobAt(datBackend, version, c.end)
+		if err != nil {
+			return fmt.Errorf("read needle at %d: %v", c.end, err)
+		}
+		hash := chainHash(c.head[:], blob)
+		if _, err = c.file.WriteAt(wormChainRecord(n.Id, c.end, n.Size, hash), c.count*wormChainRecordSize); err != nil {
+			return err
+		}
+		c.count++
+		c.end += int64(len(blob))
+		copy(c.head[:], hash)
+	}
+	return nil
+}
+
+func (c *wormChain) close() {
+	if err := c.file.Sync(); err != nil {
+		glog.Warningf("sync %s: %v", c.file.Name(), err)
+	}
+	c.file.Close()
+}
+
+// VerifyWormChain checks the needles in the .dat file against the .wch file,
+// and returns the number of chained needles and the chain head
+func (v *Volume) VerifyWormChain() (count int64, head []byte, err error) {
+	if !v.IsWorm() {
+		return 0, nil, fmt.Errorf("volume %d is not write-once", v.Id)
+	}
+
+	// needles appended after the snapshot are verified next time
+	v.dataFileAccessLock.RLock()
+	if v.wormChain == nil {
+		v.dataFileAccessLock.RUnlock()
+		return 0, nil, fmt.Errorf("volume %d is not loaded", v.Id)
+	}
+	chainFile, chainCount, chainEnd := v.wormChain.file, v.wormChain.count, v.wormChain.end
+	datBackend := v.DataBackend
+	v.dataFileAccessLock.RUnlock()
+
+	expectedOffset := int64(v.SuperBlock.BlockSize())
+	previous := make([]byte, sha256.Size)
+	record := make([]byte, wormChainRecordSize)
+	for count = 0; count < chainCount; count++ {
+		if _, err = chainFile.ReadAt(record, count*wormChainRecordSize); err != nil && err != io.EOF {
+			return count, previous, fmt.Errorf("read chain record %d: %v", count, err)
+		}
+		id, offset, size, hash := parseWormChainRecord(record)
+		if offset != expectedOffset {
+			return count, previous, fmt.Errorf("chain record %d needle %s at offset %d, expected offset %d", count, id, offset, expectedOffset)
+		}
+		n, blob, readErr := readNeedleBlobAt(datBackend, v.Version(), offset)
+		if readErr != nil {
+			return count, previous, fmt.Errorf("read needle %s at offset %d: %v", id, offset, readErr)
+		}
+		if n.Id != id || n.Size != size {
+			return count, previous, fmt.Errorf("needle at offset %d is %s size %d, chained %s size %d", offset, n.Id, n.Size, id, size)
+		}
+		if !bytes.Equal(chainHash(previous, blob), hash) {
+			return count, previous, fmt.Errorf("needle %s at offset %d does not match its chain hash", id, offset)
+		}
+		previous = append([]byte(nil), hash...)
+		expectedOffset += int64(len(blob))
+	}
+	if expectedOffset != chainEnd {
+		return count, previous, fmt.Errorf("chain ends at %d, expected %d", expectedOffset, chainEnd)
+	}
+	return count, previous, nil
+}
+
+func readNeedleBlobAt(datBackend backend.BackendStorageFile, version needle.Version, offset int64) (n *needle.Needle, blob []byte, err error) {
+	n, _, bodyLength, err := needle.ReadNeedleHeader(datBackend, version, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n == nil {
+		return nil, nil, io.ErrUnexpectedEOF
+	}
+	blob = make([]byte, NeedleHeaderSize+bodyLength)
+	count, err := datBackend.ReadAt(blob, offset)
+	if err == io.EOF && count == len(blob) {
+		err = nil
+	}
+	if err == nil && count != len(blob) {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, blob, err
+}
+
+func chainHash(previous []byte, blob []byte) []byte {
+	h := sha256.New()
+	h.Write(previous)
+	h.Write(blob)
+	return h.Sum(nil)
+}
+
+func wormChainRecord(id NeedleId, offset int64, size Size, hash []byte) []byte {
+	record := make([]byte, wormChainRecordSize)
+	NeedleIdToBytes(record[0:NeedleIdSize], id)
+	util.Uint64toBytes(record[NeedleIdSize:NeedleIdSize+8], uint64(offset))
+	SizeToBytes(record[NeedleIdSize+8:NeedleIdSize+8+SizeSize], size)
+	copy(record[NeedleIdSize+8+SizeSize:], hash)
+	return record
+}
+
+func parseWormChainRecord(record []byte) (id NeedleId, offset int64, size Size, hash []byte) {
+	id = BytesToNeedleId(record[0:NeedleIdSize])
+	offset = int64(util.BytesToUint64(record[NeedleIdSize : NeedleIdSize+8]))
+	size = BytesToSize(record[NeedleIdSize+8 : NeedleIdSize+8+SizeSize])
+	hash = record[NeedleIdSize+8+SizeSize:]
+	return
+}
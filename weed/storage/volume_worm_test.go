@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+)
+
+func TestWormVolume(t *testing.T) {
+	dir := t.TempDir()
+
+	v, err := newVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0, types.DefaultOffsetWidth, newWormSuperBlockExtra(3600))
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	if !v.IsWorm() {
+		t.Fatalf("volume is not write-once")
+	}
+	// without the chain, the needles are assumed retained
+	chain := v.wormChain
+	v.wormChain = nil
+	if err = v.Destroy(false); err == nil {
+		t.Fatalf("destroy write-once volume without the chain")
+	}
+	v.wormChain = chain
+	if err = v.Destroy(false); err != nil {
+		t.Fatalf("destroy empty write-once volume: %v", err)
+	}
+
+	v, err = newVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, needle.GetCurrentVersion(), 0, 0, types.DefaultOffsetWidth, newWormSuperBlockExtra(3600))
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	for i := 1; i <= 10; i++ {
+		if _, _, _, err = v.writeNeedle2(newRandomNeedle(uint64(i)), true, false); err != nil {
+			t.Fatalf("write needle %d: %v", i, err)
+		}
+	}
+
+	if _, _, _, err = v.writeNeedle2(newRandomNeedle(1), true, false); err != ErrWormOverwrite {
+		t.Fatalf("overwrite expected %v, found %v", ErrWormOverwrite, err)
+	}
+	if _, err = v.deleteNeedle2(newEmptyNeedle(1)); err != ErrWormDelete {
+		t.Fatalf("delete expected %v, found %v", ErrWormDelete, err)
+	}
+	if err = v.Compact2(0, 0, nil); err == nil {
+		t.Fatalf("vacuum write-once volume")
+	}
+	if err = v.Destroy(false); err == nil {
+		t.Fatalf("destroy retained write-once volume")
+	}
+
+	count, head, err := v.VerifyWormChain()
+	if err != nil || count != 10 {
+		t.Fatalf("verify chain: %d %v", count, err)
+	}
+	v.Close()
+
+	// the chain survives reloading
+	v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume reloading: %v", err)
+	}
+	reloadedCount, reloadedHead, err := v.VerifyWormChain()
+	if err != nil || reloadedCount != count || string(reloadedHead) != string(head) {
+		t.Fatalf("verify reloaded chain: %d %x %v", reloadedCount, reloadedHead, err)
+	}
+	datFileName := v.FileName(".dat")
+	superBlockSize := int64(v.SuperBlock.BlockSize())
+	v.Close()
+
+	// tamper the first needle
+	datFile, err := os.OpenFile(datFileName, os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatalf("open dat: %v", err)
+	}
+	b := make([]byte, 1)
+	tamperOffset := superBlockSize + types.NeedleHeaderSize + 4 + 1
+	datFile.ReadAt(b, tamperOffset)
+	b[0] ^= 0xff
+	datFile.WriteAt(b, tamperOffset)
+	datFile.Close()
+
+	v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, needle.GetCurrentVersion(), 0, 0)
+	if err != nil {
+		t.Fatalf("volume reloading: %v", err)
+	}
+	defer v.Close()
+	if _, _, err = v.VerifyWormChain(); err == nil {
+		t.Fatalf("tampered needle is not detected")
+	}
+}
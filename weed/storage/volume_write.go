@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
@@ -39,7 +40,7 @@ func (v *Volume) isFileUnchanged(n *needle.Needle) bool {
 	nv, ok := v.nm.Get(n.Id)
 	if ok && !nv.Offset.IsZero() && nv.Size.IsValid() {
 		oldNeedle := new(needle.Needle)
-		err := oldNeedle.ReadData(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version())
+		err := oldNeedle.ReadDataWithOffsetWidth(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version(), v.offsetWidth)
 		if err != nil {
 			glog.V(0).Infof("Failed to check updated file at offset %d size %d: %v", nv.Offset.ToActualOffset(), nv.Size, err)
 			return false
@@ -74,6 +75,9 @@ func (v *Volume) Destroy(onlyEmpty bool) (err error) {
 		err = fmt.Errorf("volume %d is compacting", v.Id)
 		return
 	}
+	if err = v.checkWormRetention(); err != nil {
+		return
+	}
 	close(v.asyncRequestsChan)
 	storageName, storageKey := v.RemoteStorageNameKey()
 	if v.HasRemoteFile() && storageName != "" && storageKey != "" {
@@ -81,6 +85,13 @@ func (v *Volume) Destroy(onlyEmpty bool) (err error) {
 			backendStorage.DeleteFile(storageKey)
 		}
 	}
+	if nm, ok := v.nm.(*PebbleNeedleMap); ok {
+		// the entries are in the shared pebble db, not in the volume files
+		if err := nm.Destroy(); err != nil {
+			glog.Warningf("destroy pebble index of volume %d: %v", v.Id, err)
+		}
+		v.nm = nil
+	}
 	v.doClose()
 	removeVolumeFiles(v.DataFileName())
 	removeVolumeFiles(v.IndexFileName())
@@ -99,8 +110,19 @@ func removeVolumeFiles(filename string) {
 	os.Remove(filename + ".cpx")
 	// level db index file
 	os.RemoveAll(filename + ".ldb")
+	// offset width conversion
+	os.Remove(filename + ".idx.cvt")
+	os.Remove(filename + ".cvw")
+	// write-once needle chain
+	os.Remove(filename + ".wch")
 	// marker for damaged or incomplete volume
 	os.Remove(filename + ".note")
+	// entries in the shared pebble db
+	if _, vid, err := parseCollectionVolumeId(filepath.Base(filename)); err == nil {
+		if err = DropPebbleNeedleMap(filepath.Dir(filename), vid); err != nil {
+			glog.Warningf("remove pebble index of volume %d: %v", vid, err)
+		}
+	}
 }
 
 func (v *Volume) asyncRequestAppend(request *needle.AsyncRequest) {
@@ -163,18 +185,28 @@ func (v *Volume) doWriteRequest(n *needle.Needle, checkCookie bool) (offset uint
 			err = fmt.Errorf("mismatching cookie %x", n.Cookie)
 			return
 		}
+		if v.wormChain != nil && !nv.Size.IsDeleted() {
+			err = ErrWormOverwrite
+			return
+		}
 	}
 
 	// append to dat file
 	n.UpdateAppendAtNs(v.lastAppendAtNs)
 	var actualSize int64
-	offset, size, actualSize, err = n.Append(v.DataBackend, v.Version())
+	offset, size, actualSize, err = n.AppendWithOffsetWidth(v.DataBackend, v.Version(), v.offsetWidth)
 	v.checkReadWriteError(err)
 	if err != nil {
 		err = fmt.Errorf("append to volume %d size %d actualSize %d: %v", v.Id, size, actualSize, err)
 		return
 	}
 	v.lastAppendAtNs = n.AppendAtNs
+	if v.wormChain != nil {
+		if err = v.wormChain.extend(v.DataBackend, v.Version(), int64(offset)+actualSize); err != nil {
+			err = fmt.Errorf("chain needle %s in volume %d: %v", n.Id, v.Id, err)
+			return
+		}
+	}
 
 	// add to needle map
 	if !ok || uint64(nv.Offset.ToActualOffset()) < offset {
@@ -219,6 +251,9 @@ func (v *Volume) deleteNeedle2(n *needle.Needle) (Size, error) {
 
 func (v *Volume) doDeleteRequest(n *needle.Needle) (Size, error) {
 	glog.V(4).Infof("delete needle %s", needle.NewFileIdFromNeedle(v.Id, n).String())
+	if v.wormChain != nil {
+		return 0, ErrWormDelete
+	}
 	nv, ok := v.nm.Get(n.Id)
 	// fmt.Println("key", n.Id, "volume offset", nv.Offset, "data_size", n.Size, "cached size", nv.Size)
 	if ok && !nv.Size.IsDeleted() {
@@ -228,7 +263,7 @@ func (v *Volume) doDeleteRequest(n *needle.Needle) (Size, error) {
 		if !v.hasRemoteFile {
 			n.Data = nil
 			n.UpdateAppendAtNs(v.lastAppendAtNs)
-			offset, _, _, err = n.Append(v.DataBackend, v.Version())
+			offset, _, _, err = n.AppendWithOffsetWidth(v.DataBackend, v.Version(), v.offsetWidth)
 			v.checkReadWriteError(err)
 			if err != nil {
 				return size, err
@@ -260,9 +295,9 @@ func (v *Volume) startWorker() {
 					chanClosed = true
 					break
 				}
-				if MaxPossibleVolumeSize < v.ContentSize()+uint64(currentBytesToWrite+request.ActualSize) {
+				if v.offsetWidth.MaxVolumeSize() < v.ContentSize()+uint64(currentBytesToWrite+request.ActualSize) {
 					request.Complete(0, 0, false,
-						fmt.Errorf("volume size limit %d exceeded! current size is %d", MaxPossibleVolumeSize, v.ContentSize()))
+						fmt.Errorf("volume size limit %d exceeded! current size is %d", v.offsetWidth.MaxVolumeSize(), v.ContentSize()))
 					break
 				}
 				currentRequests = append(currentRequests, request)
@@ -323,14 +358,14 @@ func (v *Volume) WriteNeedleBlob(needleId NeedleId, needleBlob []byte, size Size
 	v.dataFileAccessLock.Lock()
 	defer v.dataFileAccessLock.Unlock()
 
-	if MaxPossibleVolumeSize < v.nm.ContentSize()+uint64(len(needleBlob)) {
-		return fmt.Errorf("volume size limit %d exceeded! current size is %d", MaxPossibleVolumeSize, v.nm.ContentSize())
+	if v.offsetWidth.MaxVolumeSize() < v.nm.ContentSize()+uint64(len(needleBlob)) {
+		return fmt.Errorf("volume size limit %d exceeded! current size is %d", v.offsetWidth.MaxVolumeSize(), v.nm.ContentSize())
 	}
 
 	nv, ok := v.nm.Get(needleId)
 	if ok && nv.Size == size {
 		oldNeedle := new(needle.Needle)
-		err := oldNeedle.ReadData(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version())
+		err := oldNeedle.ReadDataWithOffsetWidth(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version(), v.offsetWidth)
 		if err == nil {
 			newNeedle := new(needle.Needle)
 			err = newNeedle.ReadBytes(needleBlob, nv.Offset.ToActualOffset(), size, v.Version())
@@ -340,6 +375,9 @@ func (v *Volume) WriteNeedleBlob(needleId NeedleId, needleBlob []byte, size Size
 			}
 		}
 	}
+	if v.wormChain != nil && ok && !nv.Size.IsDeleted() {
+		return ErrWormOverwrite
+	}
 	appendAtNs := needle.GetAppendAtNs(v.lastAppendAtNs)
 	offset, err := needle.WriteNeedleBlob(v.DataBackend, needleBlob, size, appendAtNs, v.Version())
 
@@ -348,6 +386,11 @@ func (v *Volume) WriteNeedleBlob(needleId NeedleId, needleBlob []byte, size Size
 		return err
 	}
 	v.lastAppendAtNs = appendAtNs
+	if v.wormChain != nil {
+		if err = v.wormChain.extend(v.DataBackend, v.Version(), int64(offset)+int64(len(needleBlob))); err != nil {
+			return fmt.Errorf("chain needle %s in volume %d: %v", needleId, v.Id, err)
+		}
+	}
 
 	// add to needle map
 	if err = v.nm.Put(needleId, ToOffset(int64(offset)), size); err != nil {
@@ -2,6 +2,7 @@ package topology
 
 import (
 	"context"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/operation"
 	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
@@ -13,19 +14,21 @@ type AllocateVolumeResult struct {
 	Error string
 }
 
-func AllocateVolume(dn *DataNode, grpcDialOption grpc.DialOption, vid needle.VolumeId, option *VolumeGrowOption) error {
+func AllocateVolume(dn *DataNode, grpcDialOption grpc.DialOption, vid needle.VolumeId, option *VolumeGrowOption, bytesOffset uint32, wormRetention time.Duration) error {
 
 	return operation.WithVolumeServerClient(false, dn.ServerAddress(), grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
 
 		_, allocateErr := client.AllocateVolume(context.Background(), &volume_server_pb.AllocateVolumeRequest{
-			VolumeId:           uint32(vid),
-			Collection:         option.Collection,
-			Replication:        option.ReplicaPlacement.String(),
-			Ttl:                option.Ttl.String(),
-			Preallocate:        option.Preallocate,
-			MemoryMapMaxSizeMb: option.MemoryMapMaxSizeMb,
-			DiskType:           string(option.DiskType),
-			Version:            option.Version,
+			VolumeId:             uint32(vid),
+			Collection:           option.Collection,
+			Replication:          option.ReplicaPlacement.String(),
+			Ttl:                  option.Ttl.String(),
+			Preallocate:          option.Preallocate,
+			MemoryMapMaxSizeMb:   option.MemoryMapMaxSizeMb,
+			DiskType:             string(option.DiskType),
+			Version:              option.Version,
+			BytesOffset:          bytesOffset,
+			WormRetentionSeconds: uint64(wormRetention / time.Second),
 		})
 		return allocateErr
 	})
@@ -25,6 +25,8 @@ type DataNode struct {
 	IsTerminating bool
 
 	MaintenanceMode bool
+
+	hotNeedles []*master_pb.HotNeedle // most read needles in the last full heartbeat
 }
 
 func NewDataNode(id string) *DataNode {
@@ -267,12 +269,25 @@ func (dn *DataNode) ToInfo() (info DataNodeInfo) {
 	return
 }
 
+func (dn *DataNode) SetHotNeedles(hotNeedles []*master_pb.HotNeedle) {
+	dn.Lock()
+	defer dn.Unlock()
+	dn.hotNeedles = hotNeedles
+}
+
+func (dn *DataNode) GetHotNeedles() []*master_pb.HotNeedle {
+	dn.RLock()
+	defer dn.RUnlock()
+	return dn.hotNeedles
+}
+
 func (dn *DataNode) ToDataNodeInfo() *master_pb.DataNodeInfo {
 	m := &master_pb.DataNodeInfo{
-		Id:        string(dn.Id()),
-		DiskInfos: make(map[string]*master_pb.DiskInfo),
-		GrpcPort:  uint32(dn.GrpcPort),
-		Address:   dn.Url(), // ip:port for connecting to the volume server
+		Id:         string(dn.Id()),
+		DiskInfos:  make(map[string]*master_pb.DiskInfo),
+		GrpcPort:   uint32(dn.GrpcPort),
+		Address:    dn.Url(), // ip:port for connecting to the volume server
+		HotNeedles: dn.GetHotNeedles(),
 	}
 	for _, c := range dn.Children() {
 		disk := c.(*Disk)
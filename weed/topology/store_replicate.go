@@ -1,6 +1,7 @@
 package topology
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,10 +14,12 @@ import (
 
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/seaweedfs/seaweedfs/weed/security"
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 	"github.com/seaweedfs/seaweedfs/weed/storage"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/replica_hint"
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/util"
 	"github.com/seaweedfs/seaweedfs/weed/util/buffer_pool"
@@ -24,16 +27,19 @@ import (
 	"google.golang.org/grpc"
 )
 
-func ReplicatedWrite(ctx context.Context, masterFn operation.GetMasterFn, grpcDialOption grpc.DialOption, s *storage.Store, volumeId needle.VolumeId, n *needle.Needle, r *http.Request, contentMd5 string) (isUnchanged bool, err error) {
+// ReplicatedWrite writes the needle to the local volume and its replicas.
+// The replicas are written over streams, if not nil, or over http.
+func ReplicatedWrite(ctx context.Context, masterFn operation.GetMasterFn, grpcDialOption grpc.DialOption, s *storage.Store, volumeId needle.VolumeId, n *needle.Needle, r *http.Request, contentMd5 string, consistency WriteConsistency, streams *ReplicationStreams) (isUnchanged bool, err error) {
 
 	//check JWT
 	jwt := security.GetJwt(r)
 
 	// check whether this is a replicated write request
 	var remoteLocations []operation.Location
+	var missingCopies, requiredAcks int
 	if r.FormValue("type") != "replicate" {
 		// this is the initial request
-		remoteLocations, err = GetWritableRemoteReplications(s, grpcDialOption, volumeId, masterFn)
+		remoteLocations, missingCopies, requiredAcks, err = getConsistentRemoteReplications(s, grpcDialOption, volumeId, masterFn, consistency)
 		if err != nil {
 			glog.V(0).Infoln(err)
 			return
@@ -47,22 +53,15 @@ func ReplicatedWrite(ctx context.Context, masterFn operation.GetMasterFn, grpcDi
 	}
 
 	if s.GetVolume(volumeId) != nil {
-		start := time.Now()
-
-		inFlightGauge := stats.VolumeServerInFlightRequestsGauge.WithLabelValues(stats.WriteToLocalDisk)
-		inFlightGauge.Inc()
-		defer inFlightGauge.Dec()
-
-		isUnchanged, err = s.WriteVolumeNeedle(volumeId, n, true, fsync)
-		stats.VolumeServerRequestHistogram.WithLabelValues(stats.WriteToLocalDisk).Observe(time.Since(start).Seconds())
-		if err != nil {
-			stats.VolumeServerHandlerCounter.WithLabelValues(stats.ErrorWriteToLocalDisk).Inc()
-			err = fmt.Errorf("failed to write to local disk: %w", err)
-			glog.V(0).Infoln(err)
+		if isUnchanged, err = writeLocalNeedle(s, volumeId, n, fsync); err != nil {
 			return
 		}
 	}
 
+	if missingCopies > 0 {
+		addReplicaHint(s, volumeId, n, "")
+	}
+
 	if len(remoteLocations) > 0 { //send to other replica locations
 		start := time.Now()
 
@@ -70,63 +69,35 @@ func ReplicatedWrite(ctx context.Context, masterFn operation.GetMasterFn, grpcDi
 		inFlightGauge.Inc()
 		defer inFlightGauge.Dec()
 
-		err = DistributedOperation(remoteLocations, func(location operation.Location) error {
-			u := url.URL{
-				Scheme: "http",
-				Host:   location.Url,
-				Path:   r.URL.Path,
-			}
-			q := url.Values{
-				"type": {"replicate"},
-				"ttl":  {n.Ttl.String()},
-			}
-			if n.LastModified > 0 {
-				q.Set("ts", strconv.FormatUint(n.LastModified, 10))
-			}
-			if n.IsChunkedManifest() {
-				q.Set("cm", "true")
-			}
-			u.RawQuery = q.Encode()
-
-			pairMap := make(map[string]string)
-			if n.HasPairs() {
-				tmpMap := make(map[string]string)
-				err := json.Unmarshal(n.Pairs, &tmpMap)
-				if err != nil {
-					stats.VolumeServerHandlerCounter.WithLabelValues(stats.ErrorUnmarshalPairs).Inc()
-					glog.V(0).Infoln("Unmarshal pairs error:", err)
-				}
-				for k, v := range tmpMap {
-					pairMap[needle.PairNamePrefix+k] = v
-				}
+		path := r.URL.Path
+		var replicate func(location operation.Location) error
+		var replicatedNeedle *volume_server_pb.ReplicatedNeedle
+		if streams != nil {
+			replicatedNeedle = newReplicatedNeedle(s, volumeId, n, fsync)
+		}
+		if replicatedNeedle != nil {
+			replicate = func(location operation.Location) error {
+				return replicateNeedleOverStream(ctx, streams, location, replicatedNeedle, func() error {
+					// the serialized needle does not use the request buffer
+					fallbackNeedle := new(needle.Needle)
+					if err := fallbackNeedle.ReadBytes(replicatedNeedle.NeedleBlob, 0, types.Size(replicatedNeedle.Size), needle.Version(replicatedNeedle.Version)); err != nil {
+						return err
+					}
+					return replicateNeedle(ctx, location, path, fallbackNeedle, jwt, contentMd5)
+				})
 			}
-			bytesBuffer := buffer_pool.SyncPoolGetBuffer()
-			defer buffer_pool.SyncPoolPutBuffer(bytesBuffer)
-
-			// volume server do not know about encryption
-			// TODO optimize here to compress data only once
-			uploadOption := &operation.UploadOption{
-				UploadUrl:         u.String(),
-				Filename:          string(n.Name),
-				Cipher:            false,
-				IsInputCompressed: n.IsCompressed(),
-				MimeType:          string(n.Mime),
-				PairMap:           pairMap,
-				Jwt:               jwt,
-				Md5:               contentMd5,
-				BytesBuffer:       bytesBuffer,
+		} else {
+			// replicas still being written after returning must not use the request buffer
+			replicaNeedle := n
+			if len(remoteLocations) > 1 || requiredAcks < len(remoteLocations) {
+				replicaNeedle = cloneNeedleForReplication(n)
 			}
-
-			uploader, err := operation.NewUploader()
-			if err != nil {
-				glog.Errorf("replication-UploadData, err:%v, url:%s", err, u.String())
-				return err
+			replicate = func(location operation.Location) error {
+				return replicateNeedle(ctx, location, path, replicaNeedle, jwt, contentMd5)
 			}
-			_, err = uploader.UploadData(ctx, n.Data, uploadOption)
-			if err != nil {
-				glog.Errorf("replication-UploadData, err:%v, url:%s", err, u.String())
-			}
-			return err
+		}
+		err = QuorumOperation(remoteLocations, requiredAcks, replicate, func(location operation.Location, err error) {
+			addReplicaHint(s, volumeId, n, location.Url)
 		})
 		stats.VolumeServerRequestHistogram.WithLabelValues(stats.WriteToReplicas).Observe(time.Since(start).Seconds())
 		if err != nil {
@@ -139,14 +110,104 @@ func ReplicatedWrite(ctx context.Context, masterFn operation.GetMasterFn, grpcDi
 	return
 }
 
-func ReplicatedDelete(masterFn operation.GetMasterFn, grpcDialOption grpc.DialOption, store *storage.Store, volumeId needle.VolumeId, n *needle.Needle, r *http.Request) (size types.Size, err error) {
+// writeLocalNeedle writes the needle to the local volume
+func writeLocalNeedle(s *storage.Store, volumeId needle.VolumeId, n *needle.Needle, fsync bool) (isUnchanged bool, err error) {
+	start := time.Now()
+
+	inFlightGauge := stats.VolumeServerInFlightRequestsGauge.WithLabelValues(stats.WriteToLocalDisk)
+	inFlightGauge.Inc()
+	defer inFlightGauge.Dec()
+
+	isUnchanged, err = s.WriteVolumeNeedle(volumeId, n, true, fsync)
+	stats.VolumeServerRequestHistogram.WithLabelValues(stats.WriteToLocalDisk).Observe(time.Since(start).Seconds())
+	if err != nil {
+		stats.VolumeServerHandlerCounter.WithLabelValues(stats.ErrorWriteToLocalDisk).Inc()
+		err = fmt.Errorf("failed to write to local disk: %w", err)
+		glog.V(0).Infoln(err)
+	}
+	return
+}
+
+func cloneNeedleForReplication(n *needle.Needle) *needle.Needle {
+	clone := *n
+	clone.Data = bytes.Clone(n.Data)
+	clone.Name = bytes.Clone(n.Name)
+	clone.Mime = bytes.Clone(n.Mime)
+	clone.Pairs = bytes.Clone(n.Pairs)
+	return &clone
+}
+
+// replicateNeedle writes the needle to one replica, the same way as the initial write request
+func replicateNeedle(ctx context.Context, location operation.Location, path string, n *needle.Needle, jwt security.EncodedJwt, contentMd5 string) error {
+	u := url.URL{
+		Scheme: "http",
+		Host:   location.Url,
+		Path:   path,
+	}
+	q := url.Values{
+		"type": {"replicate"},
+		"ttl":  {n.Ttl.String()},
+	}
+	if n.LastModified > 0 {
+		q.Set("ts", strconv.FormatUint(n.LastModified, 10))
+	}
+	if n.IsChunkedManifest() {
+		q.Set("cm", "true")
+	}
+	u.RawQuery = q.Encode()
+
+	pairMap := make(map[string]string)
+	if n.HasPairs() {
+		tmpMap := make(map[string]string)
+		err := json.Unmarshal(n.Pairs, &tmpMap)
+		if err != nil {
+			stats.VolumeServerHandlerCounter.WithLabelValues(stats.ErrorUnmarshalPairs).Inc()
+			glog.V(0).Infoln("Unmarshal pairs error:", err)
+		}
+		for k, v := range tmpMap {
+			pairMap[needle.PairNamePrefix+k] = v
+		}
+	}
+	bytesBuffer := buffer_pool.SyncPoolGetBuffer()
+	defer buffer_pool.SyncPoolPutBuffer(bytesBuffer)
+
+	// volume server do not know about encryption
+	// TODO optimize here to compress data only once
+	uploadOption := &operation.UploadOption{
+		UploadUrl:         u.String(),
+		Filename:          string(n.Name),
+		Cipher:            false,
+		IsInputCompressed: n.IsCompressed(),
+		MimeType:          string(n.Mime),
+		PairMap:           pairMap,
+		Jwt:               jwt,
+		Md5:               contentMd5,
+		BytesBuffer:       bytesBuffer,
+	}
+
+	uploader, err := operation.NewUploader()
+	if err != nil {
+		glog.Errorf("replication-UploadData, err:%v, url:%s", err, u.String())
+		return err
+	}
+	_, err = uploader.UploadData(ctx, n.Data, uploadOption)
+	if err != nil {
+		glog.Errorf("replication-UploadData, err:%v, url:%s", err, u.String())
+	}
+	return err
+}
+
+// ReplicatedDelete deletes the needle from the local volume and its replicas.
+// The replicas are deleted over streams, if not nil, or over http.
+func ReplicatedDelete(masterFn operation.GetMasterFn, grpcDialOption grpc.DialOption, store *storage.Store, volumeId needle.VolumeId, n *needle.Needle, r *http.Request, consistency WriteConsistency, streams *ReplicationStreams) (size types.Size, err error) {
 
 	//check JWT
 	jwt := security.GetJwt(r)
 
 	var remoteLocations []operation.Location
+	var missingCopies, requiredAcks int
 	if r.FormValue("type") != "replicate" {
-		remoteLocations, err = GetWritableRemoteReplications(store, grpcDialOption, volumeId, masterFn)
+		remoteLocations, missingCopies, requiredAcks, err = getConsistentRemoteReplications(store, grpcDialOption, volumeId, masterFn, consistency)
 		if err != nil {
 			glog.V(0).Infoln(err)
 			return
@@ -159,9 +220,23 @@ func ReplicatedDelete(masterFn operation.GetMasterFn, grpcDialOption grpc.DialOp
 		return
 	}
 
+	if missingCopies > 0 {
+		addReplicaHint(store, volumeId, n, "")
+	}
+
 	if len(remoteLocations) > 0 { //send to other replica locations
-		if err = DistributedOperation(remoteLocations, func(location operation.Location) error {
+		deleteOverHttp := func(location operation.Location) error {
 			return util_http.Delete("http://"+location.Url+r.URL.Path+"?type=replicate", string(jwt))
+		}
+		if err = QuorumOperation(remoteLocations, requiredAcks, func(location operation.Location) error {
+			if streams == nil {
+				return deleteOverHttp(location)
+			}
+			return replicateNeedleOverStream(context.Background(), streams, location, newReplicatedDelete(volumeId, n), func() error {
+				return deleteOverHttp(location)
+			})
+		}, func(location operation.Location, err error) {
+			addReplicaHint(store, volumeId, n, location.Url)
 		}); err != nil {
 			size = 0
 		}
@@ -169,6 +244,47 @@ func ReplicatedDelete(masterFn operation.GetMasterFn, grpcDialOption grpc.DialOp
 	return
 }
 
+// getConsistentRemoteReplications returns the remote replicas, how many copies are missing from the lookup,
+// and how many remote replicas must acknowledge for the write consistency.
+func getConsistentRemoteReplications(s *storage.Store, grpcDialOption grpc.DialOption, volumeId needle.VolumeId, masterFn operation.GetMasterFn, consistency WriteConsistency) (remoteLocations []operation.Location, missingCopies int, requiredAcks int, err error) {
+	remoteLocations, copyCount, err := getRemoteReplications(s, grpcDialOption, volumeId, masterFn)
+	if err != nil {
+		return
+	}
+	if copyCount == 0 {
+		// not on local store, all replicas must be written
+		return remoteLocations, 0, len(remoteLocations), nil
+	}
+	missingCopies = copyCount - 1 - len(remoteLocations)
+	requiredAcks = consistency.RequiredRemoteAcks(copyCount)
+	if len(remoteLocations) < requiredAcks {
+		err = fmt.Errorf("replicating operations [%d] is less than volume %d replication copy count [%d] with write consistency %v",
+			len(remoteLocations)+1, volumeId, copyCount, consistency)
+	}
+	return
+}
+
+// addReplicaHint records that the replica at target, or an unknown replica if target is empty, missed the needle.
+// It is only possible with a local copy to replay from.
+func addReplicaHint(s *storage.Store, volumeId needle.VolumeId, n *needle.Needle, target string) {
+	hintStore := s.ReplicaHints()
+	if hintStore == nil || s.GetVolume(volumeId) == nil {
+		return
+	}
+	if err := hintStore.Add(&replica_hint.Hint{
+		VolumeId:  uint32(volumeId),
+		NeedleId:  n.Id,
+		Cookie:    n.Cookie,
+		Target:    target,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		glog.Errorf("record replica hint for %d,%s to %q: %v", volumeId, n.Id.String(), target, err)
+		return
+	}
+	stats.VolumeServerReplicaHintCounter.WithLabelValues("recorded").Inc()
+	stats.VolumeServerReplicaHintGauge.Set(float64(hintStore.Count()))
+}
+
 type DistributedOperationResult map[string]error
 
 func (dr DistributedOperationResult) Error() error {
@@ -207,10 +323,20 @@ func DistributedOperation(locations []operation.Location, op func(location opera
 }
 
 func GetWritableRemoteReplications(s *storage.Store, grpcDialOption grpc.DialOption, volumeId needle.VolumeId, masterFn operation.GetMasterFn) (remoteLocations []operation.Location, err error) {
+	remoteLocations, _, _, err = getConsistentRemoteReplications(s, grpcDialOption, volumeId, masterFn, WriteConsistencyAll)
+	return
+}
+
+// getRemoteReplications looks up the other replicas of the volume.
+// copyCount is the copy count of the local volume, or 0 if the volume is not local.
+func getRemoteReplications(s *storage.Store, grpcDialOption grpc.DialOption, volumeId needle.VolumeId, masterFn operation.GetMasterFn) (remoteLocations []operation.Location, copyCount int, err error) {
 
 	v := s.GetVolume(volumeId)
-	if v != nil && v.ReplicaPlacement.GetCopyCount() == 1 {
-		return
+	if v != nil {
+		copyCount = v.ReplicaPlacement.GetCopyCount()
+		if copyCount == 1 {
+			return
+		}
 	}
 
 	// not on local store, or has replications
@@ -227,14 +353,5 @@ func GetWritableRemoteReplications(s *storage.Store, grpcDialOption grpc.DialOpt
 		return
 	}
 
-	if v != nil {
-		// has one local and has remote replications
-		copyCount := v.ReplicaPlacement.GetCopyCount()
-		if len(lookupResult.Locations) < copyCount {
-			err = fmt.Errorf("replicating operations [%d] is less than volume %d replication copy count [%d]",
-				len(lookupResult.Locations), volumeId, copyCount)
-		}
-	}
-
 	return
 }
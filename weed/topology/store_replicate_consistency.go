@@ -0,0 +1,138 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+)
+
+// WriteConsistency is how many copies of a replicated volume must acknowledge a write or delete.
+type WriteConsistency int
+
+const (
+	// WriteConsistencyAll waits for all copies, and fails if any copy is missing
+	WriteConsistencyAll WriteConsistency = iota
+	// WriteConsistencyQuorum waits for a majority of the copies, including the local one
+	WriteConsistencyQuorum
+	// WriteConsistencyOne only waits for the local copy
+	WriteConsistencyOne
+)
+
+func ParseWriteConsistency(s string) (WriteConsistency, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "all":
+		return WriteConsistencyAll, nil
+	case "quorum":
+		return WriteConsistencyQuorum, nil
+	case "one":
+		return WriteConsistencyOne, nil
+	}
+	return WriteConsistencyAll, fmt.Errorf("unknown write consistency %q, expecting all, quorum or one", s)
+}
+
+func (c WriteConsistency) String() string {
+	switch c {
+	case WriteConsistencyQuorum:
+		return "quorum"
+	case WriteConsistencyOne:
+		return "one"
+	}
+	return "all"
+}
+
+// RequiredRemoteAcks returns how many remote copies must acknowledge, for a volume with copyCount copies.
+func (c WriteConsistency) RequiredRemoteAcks(copyCount int) int {
+	if copyCount <= 1 {
+		return 0
+	}
+	switch c {
+	case WriteConsistencyQuorum:
+		// a majority of all copies, with the local copy counted
+		return copyCount / 2
+	case WriteConsistencyOne:
+		return 0
+	}
+	return copyCount - 1
+}
+
+// WriteConsistencyConfig is the write consistency of each collection.
+type WriteConsistencyConfig struct {
+	Default     WriteConsistency
+	Collections map[string]WriteConsistency
+}
+
+// ParseWriteConsistencyConfig parses a comma separated list of the default level and collection=level pairs,
+// e.g. "quorum,logs=one,important=all".
+func ParseWriteConsistencyConfig(s string) (*WriteConsistencyConfig, error) {
+	config := &WriteConsistencyConfig{
+		Collections: make(map[string]WriteConsistency),
+	}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		collection, level, found := strings.Cut(item, "=")
+		if !found {
+			level, collection = collection, ""
+		}
+		consistency, err := ParseWriteConsistency(level)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			config.Collections[strings.TrimSpace(collection)] = consistency
+		} else {
+			config.Default = consistency
+		}
+	}
+	return config, nil
+}
+
+// Get returns the write consistency of the collection. A nil config always returns WriteConsistencyAll.
+func (c *WriteConsistencyConfig) Get(collection string) WriteConsistency {
+	if c == nil {
+		return WriteConsistencyAll
+	}
+	if consistency, found := c.Collections[collection]; found {
+		return consistency
+	}
+	return c.Default
+}
+
+// QuorumOperation runs op on all locations in parallel, and returns once required operations succeeded,
+// or once so many failed that required can not be reached any more.
+// The remaining operations keep running in the background.
+// onFailure is called for every failed location, including the ones failing after QuorumOperation returned.
+func QuorumOperation(locations []operation.Location, required int, op func(location operation.Location) error, onFailure func(location operation.Location, err error)) error {
+	if required > len(locations) {
+		required = len(locations)
+	}
+	results := make(chan RemoteResult, len(locations))
+	for _, location := range locations {
+		go func(location operation.Location) {
+			err := op(location)
+			if err != nil && onFailure != nil {
+				onFailure(location, err)
+			}
+			results <- RemoteResult{location.Url, err}
+		}(location)
+	}
+
+	ret := DistributedOperationResult(make(map[string]error))
+	succeeded, failed := 0, 0
+	for succeeded < required && failed <= len(locations)-required {
+		result := <-results
+		ret[result.Host] = result.Error
+		if result.Error == nil {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+	if succeeded >= required {
+		return nil
+	}
+	return ret.Error()
+}
@@ -0,0 +1,104 @@
+package topology
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+)
+
+func TestParseWriteConsistencyConfig(t *testing.T) {
+	config, err := ParseWriteConsistencyConfig("quorum, logs=one,important=ALL")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for collection, expected := range map[string]WriteConsistency{
+		"":          WriteConsistencyQuorum,
+		"other":     WriteConsistencyQuorum,
+		"logs":      WriteConsistencyOne,
+		"important": WriteConsistencyAll,
+	} {
+		if got := config.Get(collection); got != expected {
+			t.Errorf("collection %q: got %v, expected %v", collection, got, expected)
+		}
+	}
+
+	if _, err = ParseWriteConsistencyConfig("logs=some"); err == nil {
+		t.Errorf("expected an error for an unknown level")
+	}
+
+	var nilConfig *WriteConsistencyConfig
+	if got := nilConfig.Get("logs"); got != WriteConsistencyAll {
+		t.Errorf("nil config: got %v, expected all", got)
+	}
+}
+
+func TestRequiredRemoteAcks(t *testing.T) {
+	tests := []struct {
+		consistency WriteConsistency
+		copyCount   int
+		expected    int
+	}{
+		{WriteConsistencyAll, 1, 0},
+		{WriteConsistencyAll, 2, 1},
+		{WriteConsistencyAll, 3, 2},
+		{WriteConsistencyQuorum, 2, 1},
+		{WriteConsistencyQuorum, 3, 1},
+		{WriteConsistencyQuorum, 4, 2},
+		{WriteConsistencyOne, 3, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.consistency.RequiredRemoteAcks(tt.copyCount); got != tt.expected {
+			t.Errorf("%v with %d copies: got %d, expected %d", tt.consistency, tt.copyCount, got, tt.expected)
+		}
+	}
+}
+
+func TestQuorumOperation(t *testing.T) {
+	locations := []operation.Location{{Url: "a"}, {Url: "b"}, {Url: "c"}}
+	release := make(chan struct{})
+	failures := make(chan string, len(locations))
+	onFailure := func(location operation.Location, err error) {
+		failures <- location.Url
+	}
+
+	// "c" is slow and fails after the quorum is reached
+	err := QuorumOperation(locations, 1, func(location operation.Location) error {
+		switch location.Url {
+		case "b":
+			return fmt.Errorf("down")
+		case "c":
+			<-release
+			return fmt.Errorf("timeout")
+		}
+		return nil
+	}, onFailure)
+	if err != nil {
+		t.Fatalf("expected the quorum to be reached: %v", err)
+	}
+	close(release)
+	failed := map[string]bool{}
+	for range 2 {
+		select {
+		case url := <-failures:
+			failed[url] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing failures, got %v", failed)
+		}
+	}
+	if !failed["b"] || !failed["c"] {
+		t.Errorf("failures %v, expected b and c", failed)
+	}
+
+	// the quorum can not be reached
+	err = QuorumOperation(locations, 2, func(location operation.Location) error {
+		if location.Url == "a" {
+			return nil
+		}
+		return fmt.Errorf("down")
+	}, nil)
+	if err == nil {
+		t.Errorf("expected an error without quorum")
+	}
+}
@@ -0,0 +1,121 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/security"
+	"github.com/seaweedfs/seaweedfs/weed/stats"
+	"github.com/seaweedfs/seaweedfs/weed/storage"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/replica_hint"
+	util_http "github.com/seaweedfs/seaweedfs/weed/util/http"
+	"google.golang.org/grpc"
+)
+
+// ReplicaHintReplayResult counts the outcome of replaying replica hints.
+type ReplicaHintReplayResult struct {
+	Replayed int
+	Failed   int
+	Waiting  int // the replica is still missing
+	Expired  int
+}
+
+type replicaHintVolume struct {
+	remoteLocations []operation.Location
+	copyCount       int
+	err             error
+}
+
+// ReplayReplicaHints copies the current local state of each hinted needle to the replicas which missed it,
+// for the volume or all volumes if volumeId is 0.
+// Hints older than maxAge, if positive, are dropped and left to volume.check.disk.
+// jwtFn signs the requests to the replicas for the file id.
+func ReplayReplicaHints(ctx context.Context, masterFn operation.GetMasterFn, grpcDialOption grpc.DialOption, s *storage.Store, volumeId uint32, maxAge time.Duration, jwtFn func(fileId string) security.EncodedJwt) (result ReplicaHintReplayResult, err error) {
+	hintStore := s.ReplicaHints()
+	if hintStore == nil {
+		return
+	}
+
+	// the replicas are looked up once for each volume
+	volumes := make(map[uint32]*replicaHintVolume)
+	err = hintStore.VisitVolume(volumeId, func(hint *replica_hint.Hint) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		vid := needle.VolumeId(hint.VolumeId)
+		if s.GetVolume(vid) == nil || (maxAge > 0 && time.Since(hint.CreatedAt) > maxAge) {
+			glog.Warningf("drop replica hint for %s to %q created at %v", needle.NewFileId(vid, uint64(hint.NeedleId), uint32(hint.Cookie)), hint.Target, hint.CreatedAt)
+			result.Expired++
+			stats.VolumeServerReplicaHintCounter.WithLabelValues("expired").Inc()
+			return hintStore.Remove(hint)
+		}
+
+		volume, found := volumes[hint.VolumeId]
+		if !found {
+			volume = &replicaHintVolume{}
+			volume.remoteLocations, volume.copyCount, volume.err = getRemoteReplications(s, grpcDialOption, vid, masterFn)
+			volumes[hint.VolumeId] = volume
+		}
+		if volume.err != nil {
+			result.Failed++
+			return nil
+		}
+
+		var targets []operation.Location
+		if hint.Target == "" {
+			// an unknown replica missed the needle, so all replicas are updated once all are back
+			if len(volume.remoteLocations) < volume.copyCount-1 {
+				result.Waiting++
+				return nil
+			}
+			targets = volume.remoteLocations
+		} else {
+			for _, location := range volume.remoteLocations {
+				if location.Url == hint.Target {
+					targets = append(targets, location)
+				}
+			}
+			if len(targets) == 0 {
+				result.Waiting++
+				return nil
+			}
+		}
+
+		if replayErr := replayReplicaHint(ctx, s, hint, targets, jwtFn); replayErr != nil {
+			glog.V(1).Infof("replay replica hint for volume %d needle %s to %q: %v", hint.VolumeId, hint.NeedleId, hint.Target, replayErr)
+			result.Failed++
+			stats.VolumeServerReplicaHintCounter.WithLabelValues("failed").Inc()
+			return nil
+		}
+		result.Replayed++
+		stats.VolumeServerReplicaHintCounter.WithLabelValues("replayed").Inc()
+		// kept if the replica missed the needle again during the replay, to replay the newer state
+		return hintStore.Remove(hint)
+	})
+	stats.VolumeServerReplicaHintGauge.Set(float64(hintStore.Count()))
+	return
+}
+
+func replayReplicaHint(ctx context.Context, s *storage.Store, hint *replica_hint.Hint, targets []operation.Location, jwtFn func(fileId string) security.EncodedJwt) error {
+	vid := needle.VolumeId(hint.VolumeId)
+	fileId := needle.NewFileId(vid, uint64(hint.NeedleId), uint32(hint.Cookie)).String()
+	jwt := jwtFn(fileId)
+
+	n := &needle.Needle{Id: hint.NeedleId, Cookie: hint.Cookie}
+	_, readErr := s.ReadVolumeNeedle(vid, n, nil, nil)
+	if readErr != nil && !errors.Is(readErr, storage.ErrorDeleted) && !errors.Is(readErr, storage.ErrorNotFound) {
+		return readErr
+	}
+
+	return DistributedOperation(targets, func(location operation.Location) error {
+		if readErr != nil {
+			return util_http.Delete("http://"+location.Url+"/"+fileId+"?type=replicate", string(jwt))
+		}
+		return replicateNeedle(ctx, location, "/"+fileId, n, jwt, "")
+	})
+}
@@ -0,0 +1,398 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage"
+	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	"github.com/seaweedfs/seaweedfs/weed/storage/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// larger needles are replicated over http
+	replicationStreamMaxNeedleSize = 8 * 1024 * 1024
+	replicationStreamMaxBatchCount = 128
+	replicationStreamMaxBatchBytes = 4 * 1024 * 1024
+	// needles sent to a replica and not acknowledged yet
+	replicationStreamMaxInFlight = 1024
+	replicationStreamQueueSize   = 1024
+	// how long to use http for a volume server without the replication stream, before trying again
+	replicationStreamRetryUnsupported = 10 * time.Minute
+	// a needle not applied by the replica in time fails the write, and the stream is reopened
+	replicationStreamTimeout = time.Minute
+)
+
+// errReplicationStreamUnsupported is returned for a volume server of an older version, which is then written over http.
+var errReplicationStreamUnsupported = errors.New("replication stream is not supported")
+
+// ReplicationStreams keeps one pipelined gRPC stream to each volume server holding replicas,
+// to send the serialized needles instead of re-uploading each needle over http.
+type ReplicationStreams struct {
+	grpcDialOption grpc.DialOption
+	timeout        time.Duration
+
+	lock        sync.Mutex
+	streams     map[pb.ServerAddress]*replicationStream
+	unsupported map[pb.ServerAddress]time.Time
+}
+
+func NewReplicationStreams(grpcDialOption grpc.DialOption) *ReplicationStreams {
+	return &ReplicationStreams{
+		grpcDialOption: grpcDialOption,
+		timeout:        replicationStreamTimeout,
+		streams:        make(map[pb.ServerAddress]*replicationStream),
+		unsupported:    make(map[pb.ServerAddress]time.Time),
+	}
+}
+
+// Close stops all streams, failing the needles not acknowledged yet.
+func (rs *ReplicationStreams) Close() {
+	rs.lock.Lock()
+	streams := rs.streams
+	rs.streams = make(map[pb.ServerAddress]*replicationStream)
+	rs.lock.Unlock()
+	for _, stream := range streams {
+		stream.fail(errors.New("replication streams closed"))
+	}
+}
+
+// replicate sends the needle to the replica and waits for it to be applied.
+// The needle is only read, so it can be sent to several replicas at the same time.
+func (rs *ReplicationStreams) replicate(ctx context.Context, location operation.Location, replicatedNeedle *volume_server_pb.ReplicatedNeedle) error {
+	stream, err := rs.getStream(location.ServerAddress())
+	if err != nil {
+		return err
+	}
+	timer := time.NewTimer(rs.timeout)
+	defer timer.Stop()
+
+	request := &replicationRequest{
+		needle: replicatedNeedle,
+		result: make(chan error, 1),
+	}
+	select {
+	case stream.requests <- request:
+	case <-stream.done:
+		return stream.err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		// the replica does not take more needles
+		stream.fail(fmt.Errorf("queue is full for %v", rs.timeout))
+		return stream.err
+	}
+
+	select {
+	case err = <-request.result:
+		return err
+	case <-stream.done:
+		// the result may have been delivered right before the stream stopped
+		select {
+		case err = <-request.result:
+			return err
+		default:
+			return stream.err
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		// the replica is stuck, and holds the needles in flight
+		stream.fail(fmt.Errorf("no reply for %v", rs.timeout))
+		return stream.err
+	}
+}
+
+func (rs *ReplicationStreams) getStream(address pb.ServerAddress) (*replicationStream, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	if stream, found := rs.streams[address]; found {
+		return stream, nil
+	}
+	if since, found := rs.unsupported[address]; found {
+		if time.Since(since) < replicationStreamRetryUnsupported {
+			return nil, errReplicationStreamUnsupported
+		}
+		delete(rs.unsupported, address)
+	}
+
+	stream, err := rs.openStream(address)
+	if err != nil {
+		return nil, err
+	}
+	rs.streams[address] = stream
+	return stream, nil
+}
+
+func (rs *ReplicationStreams) openStream(address pb.ServerAddress) (*replicationStream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	conn, err := pb.GrpcDial(ctx, address.ToGrpcAddress(), false, rs.grpcDialOption)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("dial %s: %w", address, err)
+	}
+	client, err := volume_server_pb.NewVolumeServerClient(conn).ReplicateNeedles(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, fmt.Errorf("open replication stream to %s: %w", address, err)
+	}
+
+	stream := &replicationStream{
+		streams:  rs,
+		address:  address,
+		conn:     conn,
+		client:   client,
+		cancel:   cancel,
+		requests: make(chan *replicationRequest, replicationStreamQueueSize),
+		inFlight: make(chan struct{}, replicationStreamMaxInFlight),
+		pending:  make(map[uint64]*replicationRequest),
+		done:     make(chan struct{}),
+	}
+	go stream.loopSend()
+	go stream.loopReceive()
+	glog.V(1).Infof("opened replication stream to %s", address)
+	return stream, nil
+}
+
+// removeStream forgets the stopped stream, so the next needle opens a new one.
+func (rs *ReplicationStreams) removeStream(stream *replicationStream, unsupported bool) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	if rs.streams[stream.address] == stream {
+		delete(rs.streams, stream.address)
+	}
+	if unsupported {
+		rs.unsupported[stream.address] = time.Now()
+	}
+}
+
+type replicationRequest struct {
+	needle   *volume_server_pb.ReplicatedNeedle // shared by the replicas, not modified
+	sequence uint64
+	result   chan error
+}
+
+// toSend returns the needle with the sequence of the request on this stream, sharing the serialized needle
+func (request *replicationRequest) toSend() *volume_server_pb.ReplicatedNeedle {
+	n := request.needle
+	return &volume_server_pb.ReplicatedNeedle{
+		Sequence:     request.sequence,
+		VolumeId:     n.VolumeId,
+		NeedleId:     n.NeedleId,
+		Cookie:       n.Cookie,
+		NeedleBlob:   n.NeedleBlob,
+		Size:         n.Size,
+		Version:      n.Version,
+		Fsync:        n.Fsync,
+		IsDelete:     n.IsDelete,
+		LastModified: n.LastModified,
+	}
+}
+
+type replicationStream struct {
+	streams *ReplicationStreams
+	address pb.ServerAddress
+	conn    *grpc.ClientConn
+	client  volume_server_pb.VolumeServer_ReplicateNeedlesClient
+	cancel  context.CancelFunc
+
+	// queued needles, blocking the writers once full
+	requests chan *replicationRequest
+	// limits the needles waiting to be acknowledged
+	inFlight chan struct{}
+
+	pendingLock sync.Mutex
+	pending     map[uint64]*replicationRequest
+	sequence    uint64
+
+	failOnce sync.Once
+	err      error
+	done     chan struct{}
+}
+
+// loopSend sends the queued needles in batches, in the order they are queued.
+func (stream *replicationStream) loopSend() {
+	for {
+		var request *replicationRequest
+		select {
+		case request = <-stream.requests:
+		case <-stream.done:
+			return
+		}
+
+		batch := &volume_server_pb.ReplicateNeedlesRequest{}
+		batchBytes := 0
+		for request != nil {
+			select {
+			case stream.inFlight <- struct{}{}:
+			case <-stream.done:
+				request.result <- stream.err
+				return
+			}
+			stream.pendingLock.Lock()
+			stream.sequence++
+			request.sequence = stream.sequence
+			stream.pending[stream.sequence] = request
+			stream.pendingLock.Unlock()
+
+			batch.Needles = append(batch.Needles, request.toSend())
+			batchBytes += len(request.needle.NeedleBlob)
+			if len(batch.Needles) >= replicationStreamMaxBatchCount || batchBytes >= replicationStreamMaxBatchBytes {
+				break
+			}
+			select {
+			case request = <-stream.requests:
+			default:
+				request = nil
+			}
+		}
+
+		if err := stream.client.Send(batch); err != nil {
+			stream.fail(err)
+			return
+		}
+	}
+}
+
+// loopReceive delivers the results of the applied needles.
+func (stream *replicationStream) loopReceive() {
+	for {
+		resp, err := stream.client.Recv()
+		if err != nil {
+			stream.fail(err)
+			return
+		}
+		for _, result := range resp.Results {
+			stream.pendingLock.Lock()
+			request, found := stream.pending[result.Sequence]
+			delete(stream.pending, result.Sequence)
+			stream.pendingLock.Unlock()
+			if !found {
+				continue
+			}
+			<-stream.inFlight
+			if result.Error != "" {
+				request.result <- errors.New(result.Error)
+			} else {
+				request.result <- nil
+			}
+		}
+	}
+}
+
+// fail stops the stream, and fails all needles not acknowledged yet.
+func (stream *replicationStream) fail(err error) {
+	stream.failOnce.Do(func() {
+		unsupported := status.Code(err) == codes.Unimplemented
+		if unsupported {
+			glog.V(0).Infof("volume server %s does not support replication streams, replicating over http", stream.address)
+			err = errReplicationStreamUnsupported
+		} else {
+			glog.V(0).Infof("replication stream to %s stopped: %v", stream.address, err)
+			err = fmt.Errorf("replication stream to %s: %w", stream.address, err)
+		}
+		stream.err = err
+		close(stream.done)
+		stream.streams.removeStream(stream, unsupported)
+		stream.cancel()
+		stream.conn.Close()
+
+		stream.pendingLock.Lock()
+		for sequence, request := range stream.pending {
+			request.result <- err
+			delete(stream.pending, sequence)
+		}
+		stream.pendingLock.Unlock()
+	})
+}
+
+// replicateNeedleOverStream writes the serialized needle to the replica over the replication stream,
+// or over http if the replica does not support it.
+func replicateNeedleOverStream(ctx context.Context, streams *ReplicationStreams, location operation.Location, replicatedNeedle *volume_server_pb.ReplicatedNeedle, httpFallback func() error) error {
+	err := streams.replicate(ctx, location, replicatedNeedle)
+	if errors.Is(err, errReplicationStreamUnsupported) {
+		return httpFallback()
+	}
+	return err
+}
+
+// newReplicatedNeedle serializes the needle to be written to the replicas, or returns nil if it should be replicated over http.
+func newReplicatedNeedle(s *storage.Store, volumeId needle.VolumeId, n *needle.Needle, fsync bool) *volume_server_pb.ReplicatedNeedle {
+	version := needle.GetCurrentVersion()
+	if v := s.GetVolume(volumeId); v != nil {
+		version = v.Version()
+	}
+	// version 1 needles do not carry the name, mime type and pairs
+	if version == needle.Version1 || len(n.Data) > replicationStreamMaxNeedleSize {
+		return nil
+	}
+	needleBlob, size, err := n.ToBytes(version)
+	if err != nil {
+		glog.V(0).Infof("serialize needle %s for replication: %v", n.Id, err)
+		return nil
+	}
+	return &volume_server_pb.ReplicatedNeedle{
+		VolumeId:   uint32(volumeId),
+		NeedleId:   uint64(n.Id),
+		Cookie:     uint32(n.Cookie),
+		NeedleBlob: needleBlob,
+		Size:       int32(size),
+		Version:    uint32(version),
+		Fsync:      fsync,
+	}
+}
+
+func newReplicatedDelete(volumeId needle.VolumeId, n *needle.Needle) *volume_server_pb.ReplicatedNeedle {
+	return &volume_server_pb.ReplicatedNeedle{
+		VolumeId:     uint32(volumeId),
+		NeedleId:     uint64(n.Id),
+		Cookie:       uint32(n.Cookie),
+		IsDelete:     true,
+		LastModified: n.LastModified,
+	}
+}
+
+// ApplyReplicatedNeedle writes or deletes a needle received from the replication stream to the local volume.
+func ApplyReplicatedNeedle(s *storage.Store, replicatedNeedle *volume_server_pb.ReplicatedNeedle) error {
+	volumeId := needle.VolumeId(replicatedNeedle.VolumeId)
+	if s.GetVolume(volumeId) == nil {
+		return fmt.Errorf("volume %d not found", volumeId)
+	}
+
+	if replicatedNeedle.IsDelete {
+		n := &needle.Needle{
+			Id:     types.NeedleId(replicatedNeedle.NeedleId),
+			Cookie: types.Cookie(replicatedNeedle.Cookie),
+		}
+		if _, err := s.ReadVolumeNeedle(volumeId, n, nil, nil); err != nil {
+			// already deleted or never written, same as a replicated delete over http
+			return nil
+		}
+		if n.Cookie != types.Cookie(replicatedNeedle.Cookie) {
+			return fmt.Errorf("delete %d,%s: cookie does not match", volumeId, n.Id)
+		}
+		// the chunks of a chunk manifest are deleted by the volume server receiving the delete
+		n.LastModified = replicatedNeedle.LastModified
+		_, err := s.DeleteVolumeNeedle(volumeId, n)
+		return err
+	}
+
+	n := new(needle.Needle)
+	if err := n.ReadBytes(replicatedNeedle.NeedleBlob, 0, types.Size(replicatedNeedle.Size), needle.Version(replicatedNeedle.Version)); err != nil {
+		return fmt.Errorf("parse replicated needle %d,%x: %v", volumeId, replicatedNeedle.NeedleId, err)
+	}
+	_, err := writeLocalNeedle(s, volumeId, n, replicatedNeedle.Fsync)
+	return err
+}
@@ -0,0 +1,222 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// fakeReplicaServer acknowledges the replicated needles in order, failing needle id 13
+type fakeReplicaServer struct {
+	volume_server_pb.UnimplementedVolumeServerServer
+	lock     sync.Mutex
+	received map[uint32][]uint64
+}
+
+func (fs *fakeReplicaServer) ReplicateNeedles(stream volume_server_pb.VolumeServer_ReplicateNeedlesServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		resp := &volume_server_pb.ReplicateNeedlesResponse{}
+		fs.lock.Lock()
+		for _, replicatedNeedle := range req.Needles {
+			fs.received[replicatedNeedle.VolumeId] = append(fs.received[replicatedNeedle.VolumeId], replicatedNeedle.NeedleId)
+			result := &volume_server_pb.ReplicatedNeedleResult{Sequence: replicatedNeedle.Sequence}
+			if replicatedNeedle.NeedleId == 13 {
+				result.Error = "broken needle"
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		fs.lock.Unlock()
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func startFakeReplica(t *testing.T, server volume_server_pb.VolumeServerServer) operation.Location {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	volume_server_pb.RegisterVolumeServerServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+	return operation.Location{
+		Url:      "127.0.0.1:1",
+		GrpcPort: listener.Addr().(*net.TCPAddr).Port,
+	}
+}
+
+func TestReplicationStreams(t *testing.T) {
+	server := &fakeReplicaServer{received: make(map[uint32][]uint64)}
+	location := startFakeReplica(t, server)
+	streams := NewReplicationStreams(grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer streams.Close()
+
+	// each volume is written in order by one writer, the volumes concurrently
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for volumeId := uint32(1); volumeId <= 4; volumeId++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for needleId := uint64(1); needleId <= 25; needleId++ {
+				err := replicateNeedleOverStream(context.Background(), streams, location, &volume_server_pb.ReplicatedNeedle{
+					VolumeId:   volumeId,
+					NeedleId:   needleId,
+					NeedleBlob: []byte("data"),
+				}, func() error {
+					return fmt.Errorf("unexpected http fallback")
+				})
+				if (err != nil) != (needleId == 13) {
+					errs <- fmt.Errorf("volume %d needle %d: %v", volumeId, needleId, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for volumeId := uint32(1); volumeId <= 4; volumeId++ {
+		received := server.received[volumeId]
+		if len(received) != 25 {
+			t.Fatalf("volume %d received %d needles, expected 25", volumeId, len(received))
+		}
+		for i, needleId := range received {
+			if needleId != uint64(i+1) {
+				t.Fatalf("volume %d received needle %d at %d, out of order", volumeId, needleId, i)
+			}
+		}
+	}
+}
+
+func TestReplicationStreamsUnsupported(t *testing.T) {
+	location := startFakeReplica(t, &volume_server_pb.UnimplementedVolumeServerServer{})
+	streams := NewReplicationStreams(grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer streams.Close()
+
+	for i := 0; i < 2; i++ {
+		fallbacks := 0
+		err := replicateNeedleOverStream(context.Background(), streams, location, &volume_server_pb.ReplicatedNeedle{
+			VolumeId: 1,
+			NeedleId: 1,
+		}, func() error {
+			fallbacks++
+			return nil
+		})
+		if err != nil || fallbacks != 1 {
+			t.Fatalf("attempt %d: err %v, fallbacks %d, expected the http fallback", i, err, fallbacks)
+		}
+	}
+
+	if _, err := streams.getStream(location.ServerAddress()); err != errReplicationStreamUnsupported {
+		t.Errorf("expected the volume server to be remembered as unsupported, got %v", err)
+	}
+}
+
+func TestReplicationStreamsSharedNeedle(t *testing.T) {
+	servers := []*fakeReplicaServer{
+		{received: make(map[uint32][]uint64)},
+		{received: make(map[uint32][]uint64)},
+		{received: make(map[uint32][]uint64)},
+	}
+	var locations []operation.Location
+	for _, server := range servers {
+		locations = append(locations, startFakeReplica(t, server))
+	}
+	streams := NewReplicationStreams(grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer streams.Close()
+
+	// the same needle is sent to all replicas at once, like ReplicatedWrite does
+	for needleId := uint64(1); needleId <= 20; needleId++ {
+		replicatedNeedle := &volume_server_pb.ReplicatedNeedle{
+			VolumeId:   1,
+			NeedleId:   needleId,
+			NeedleBlob: []byte("data"),
+		}
+		err := QuorumOperation(locations, len(locations), func(location operation.Location) error {
+			return replicateNeedleOverStream(context.Background(), streams, location, replicatedNeedle, func() error {
+				return fmt.Errorf("unexpected http fallback")
+			})
+		}, nil)
+		if (err != nil) != (needleId == 13) {
+			t.Fatalf("needle %d: %v", needleId, err)
+		}
+		if replicatedNeedle.Sequence != 0 {
+			t.Fatalf("needle %d shared by the replicas is modified", needleId)
+		}
+	}
+
+	for i, server := range servers {
+		server.lock.Lock()
+		received := len(server.received[1])
+		server.lock.Unlock()
+		if received != 20 {
+			t.Errorf("replica %d received %d needles, expected 20", i, received)
+		}
+	}
+}
+
+// stuckReplicaServer receives the needles and never acknowledges them
+type stuckReplicaServer struct {
+	volume_server_pb.UnimplementedVolumeServerServer
+}
+
+func (fs *stuckReplicaServer) ReplicateNeedles(stream volume_server_pb.VolumeServer_ReplicateNeedlesServer) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return nil
+		}
+	}
+}
+
+func TestReplicationStreamsTimeout(t *testing.T) {
+	location := startFakeReplica(t, &stuckReplicaServer{})
+	streams := NewReplicationStreams(grpc.WithTransportCredentials(insecure.NewCredentials()))
+	streams.timeout = 100 * time.Millisecond
+	defer streams.Close()
+
+	start := time.Now()
+	err := replicateNeedleOverStream(context.WithoutCancel(context.Background()), streams, location, &volume_server_pb.ReplicatedNeedle{
+		VolumeId:   1,
+		NeedleId:   1,
+		NeedleBlob: []byte("data"),
+	}, func() error {
+		return fmt.Errorf("unexpected http fallback")
+	})
+	if err == nil {
+		t.Fatalf("needle not acknowledged should fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("failed after %v", elapsed)
+	}
+
+	// the stuck stream is replaced by a new one
+	streams.lock.Lock()
+	_, found := streams.streams[location.ServerAddress()]
+	streams.lock.Unlock()
+	if found {
+		t.Errorf("stuck stream is not removed")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,9 +38,11 @@ type Topology struct {
 
 	pulse int64
 
-	volumeSizeLimit  uint64
-	replicationAsMin bool
-	isDisableVacuum  bool
+	volumeSizeLimit            uint64
+	collectionVolumeSizeLimits map[string]uint64 // overrides volumeSizeLimit for the collections
+	collectionWormRetentions   map[string]time.Duration
+	replicationAsMin           bool
+	isDisableVacuum            bool
 
 	Sequence sequence.Sequencer
 
@@ -266,9 +269,40 @@ func (t *Topology) PickForWrite(requestedCount uint64, option *VolumeGrowOption,
 	return fileId, count, volumeLocationList, shouldGrow, nil
 }
 
+// SetCollectionVolumeSizeLimits sets the volume size limits of collections, in bytes, before any volume is registered.
+// The collection names match case-insensitively, as the configuration keys are lower cased.
+func (t *Topology) SetCollectionVolumeSizeLimits(limits map[string]uint64) {
+	t.collectionVolumeSizeLimits = make(map[string]uint64, len(limits))
+	for collectionName, limit := range limits {
+		t.collectionVolumeSizeLimits[strings.ToLower(collectionName)] = limit
+	}
+}
+
+// VolumeSizeLimit returns the volume size limit of the collection
+func (t *Topology) VolumeSizeLimit(collectionName string) uint64 {
+	if limit, found := t.collectionVolumeSizeLimits[strings.ToLower(collectionName)]; found {
+		return limit
+	}
+	return t.volumeSizeLimit
+}
+
+// SetCollectionWormRetentions sets the write-once collections, and their retention after the last write.
+// The collection names match case-insensitively, as the configuration keys are lower cased.
+func (t *Topology) SetCollectionWormRetentions(retentions map[string]time.Duration) {
+	t.collectionWormRetentions = make(map[string]time.Duration, len(retentions))
+	for collectionName, retention := range retentions {
+		t.collectionWormRetentions[strings.ToLower(collectionName)] = retention
+	}
+}
+
+// WormRetention returns the retention of the write-once collection, or 0 if the collection is not write-once
+func (t *Topology) WormRetention(collectionName string) time.Duration {
+	return t.collectionWormRetentions[strings.ToLower(collectionName)]
+}
+
 func (t *Topology) GetVolumeLayout(collectionName string, rp *super_block.ReplicaPlacement, ttl *needle.TTL, diskType types.DiskType) *VolumeLayout {
 	return t.collectionMap.Get(collectionName, func() interface{} {
-		return NewCollection(collectionName, t.volumeSizeLimit, t.replicationAsMin)
+		return NewCollection(collectionName, t.VolumeSizeLimit(collectionName), t.replicationAsMin)
 	}).(*Collection).GetOrCreateVolumeLayout(rp, ttl, diskType)
 }
 
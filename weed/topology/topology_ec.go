@@ -26,6 +26,9 @@ func (t *Topology) SyncDataNodeEcShards(shardInfos []*master_pb.VolumeEcShardInf
 			DiskType:    shardInfo.DiskType,
 			DiskId:      shardInfo.DiskId,
 			ExpireAtSec: shardInfo.ExpireAtSec,
+
+			DatFileSize:      shardInfo.DatFileSize,
+			DeletedByteCount: shardInfo.DeletedByteCount,
 		}
 
 		shards = append(shards, ecVolumeInfo)
@@ -53,6 +56,9 @@ func (t *Topology) IncrementalSyncDataNodeEcShards(newEcShards, deletedEcShards
 			DiskType:    shardInfo.DiskType,
 			DiskId:      shardInfo.DiskId,
 			ExpireAtSec: shardInfo.ExpireAtSec,
+
+			DatFileSize:      shardInfo.DatFileSize,
+			DeletedByteCount: shardInfo.DeletedByteCount,
 		}
 
 		newShards = append(newShards, ecVolumeInfo)
@@ -66,6 +72,9 @@ func (t *Topology) IncrementalSyncDataNodeEcShards(newEcShards, deletedEcShards
 			DiskType:    shardInfo.DiskType,
 			DiskId:      shardInfo.DiskId,
 			ExpireAtSec: shardInfo.ExpireAtSec,
+
+			DatFileSize:      shardInfo.DatFileSize,
+			DeletedByteCount: shardInfo.DeletedByteCount,
 		}
 
 		deletedShards = append(deletedShards, ecVolumeInfo)
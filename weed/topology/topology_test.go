@@ -12,6 +12,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/storage/types"
 
 	"testing"
+	"time"
 )
 
 func TestRemoveDataCenter(t *testing.T) {
@@ -507,3 +508,33 @@ func TestDataNodeIdBasedIdentification(t *testing.T) {
 		t.Errorf("expected 4 DataNodes, got %d", len(children))
 	}
 }
+
+func TestCollectionVolumeSizeLimitsIgnoreCase(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5, false)
+	// as loaded from master.toml, with the collection names lower cased
+	topo.SetCollectionVolumeSizeLimits(map[string]uint64{"largeobjects": 1000000 * 1024 * 1024})
+
+	for _, collection := range []string{"LargeObjects", "largeobjects"} {
+		if limit := topo.VolumeSizeLimit(collection); limit != 1000000*1024*1024 {
+			t.Errorf("collection %s volume size limit %d", collection, limit)
+		}
+	}
+	if limit := topo.VolumeSizeLimit("logs"); limit != 32*1024 {
+		t.Errorf("collection logs volume size limit %d", limit)
+	}
+}
+
+func TestCollectionWormRetentionsIgnoreCase(t *testing.T) {
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5, false)
+	// as loaded from master.toml, with the collection names lower cased
+	topo.SetCollectionWormRetentions(map[string]time.Duration{"compliancerecords": 8760 * time.Hour})
+
+	for _, collection := range []string{"ComplianceRecords", "compliancerecords"} {
+		if retention := topo.WormRetention(collection); retention != 8760*time.Hour {
+			t.Errorf("collection %s retention %v", collection, retention)
+		}
+	}
+	if retention := topo.WormRetention("logs"); retention != 0 {
+		t.Errorf("collection logs retention %v", retention)
+	}
+}
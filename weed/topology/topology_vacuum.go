@@ -109,7 +109,7 @@ func (t *Topology) batchVacuumVolumeCompact(grpcDialOption grpc.DialOption, vl *
 	}
 	isVacuumSuccess := true
 
-	waitTimeout := time.NewTimer(3 * time.Minute * time.Duration(t.volumeSizeLimit/1024/1024/1000+1))
+	waitTimeout := time.NewTimer(3 * time.Minute * time.Duration(vl.volumeSizeLimit/1024/1024/1000+1))
 	defer waitTimeout.Stop()
 
 	for range locationlist.list {
@@ -137,7 +137,7 @@ func (t *Topology) batchVacuumVolumeCommit(grpcDialOption grpc.DialOption, vl *V
 				if resp.IsReadOnly {
 					isReadOnly = true
 				}
-				if resp.VolumeSize > t.volumeSizeLimit {
+				if resp.VolumeSize > vl.volumeSizeLimit {
 					isFullCapacity = true
 				}
 			}
@@ -170,7 +170,7 @@ func (t *Topology) batchVacuumVolumeCommit(grpcDialOption grpc.DialOption, vl *V
 						if resp.IsReadOnly {
 							isReadOnly = true
 						}
-						if resp.VolumeSize > t.volumeSizeLimit {
+						if resp.VolumeSize > vl.volumeSizeLimit {
 							isFullCapacity = true
 						}
 					}
@@ -360,8 +360,11 @@ func (vg *VolumeGrowth) findEmptySlotsForOneVolume(topo *Topology, option *Volum
 // grow creates volumes on the provided servers, optionally managing capacity reservations
 func (vg *VolumeGrowth) grow(grpcDialOption grpc.DialOption, topo *Topology, vid needle.VolumeId, option *VolumeGrowOption, reservation *VolumeGrowReservation, servers ...*DataNode) (growErr error) {
 	var createdVolumes []storage.VolumeInfo
+	// volumes of collections allowed to grow over the default offset width use the wider index
+	bytesOffset := uint32(types.OffsetWidthForVolumeSize(topo.VolumeSizeLimit(option.Collection)))
+	wormRetention := topo.WormRetention(option.Collection)
 	for _, server := range servers {
-		if err := AllocateVolume(server, grpcDialOption, vid, option); err == nil {
+		if err := AllocateVolume(server, grpcDialOption, vid, option, bytesOffset, wormRetention); err == nil {
 			createdVolumes = append(createdVolumes, storage.VolumeInfo{
 				Id:               vid,
 				Size:             0,
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"io"
+	"math"
 	"net"
 	"time"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/stats"
 )
 
+const connReadFromChunkSize = 4 * 1024 * 1024
+
 // Listener wraps a net.Listener, and gives a place to store the timeout
 // parameters. On Accept, it will wrap the net.Conn with our own Conn for us.
 type Listener struct {
@@ -71,6 +75,34 @@ func (c *Conn) Write(b []byte) (count int, e error) {
 	return
 }
 
+// ReadFrom lets the underlying connection copy files with sendfile or splice.
+// The copy is split into chunks to keep extending the no activity deadline.
+func (c *Conn) ReadFrom(r io.Reader) (n int64, e error) {
+	readerFrom, ok := c.Conn.(io.ReaderFrom)
+	if !ok {
+		return io.Copy(struct{ io.Writer }{c}, r)
+	}
+	// sendfile only recognizes an *os.File, optionally wrapped in one *io.LimitedReader
+	remaining, ok := r.(*io.LimitedReader)
+	if !ok {
+		remaining = &io.LimitedReader{R: r, N: math.MaxInt64}
+	}
+	for remaining.N > 0 {
+		if e = c.extendDeadline(); e != nil {
+			return
+		}
+		var written int64
+		written, e = readerFrom.ReadFrom(&io.LimitedReader{R: remaining.R, N: min(remaining.N, connReadFromChunkSize)})
+		n += written
+		remaining.N -= written
+		stats.BytesOut(written)
+		if e != nil || written == 0 {
+			return
+		}
+	}
+	return
+}
+
 func (c *Conn) Close() error {
 	err := c.Conn.Close()
 	if err == nil {
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConnReadFromFile(t *testing.T) {
+	data := make([]byte, 2*connReadFromChunkSize+123)
+	rand.Read(data)
+	fileName := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatalf("write %s: %v", fileName, err)
+	}
+
+	listener, err := NewListener("127.0.0.1:0", time.Second)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+		b, _ := io.ReadAll(conn)
+		received <- b
+	}()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	file, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("open %s: %v", fileName, err)
+	}
+	defer file.Close()
+	if _, err = file.Seek(100, io.SeekStart); err != nil {
+		t.Fatalf("seek: %v", err)
+	}
+	size := int64(len(data) - 200)
+	if written, err := io.CopyN(conn, file, size); err != nil || written != size {
+		t.Fatalf("copy %d of %d bytes: %v", written, size, err)
+	}
+	conn.Close()
+
+	if b := <-received; !bytes.Equal(b, data[100:len(data)-100]) {
+		t.Fatalf("received %d bytes, expected %d bytes", len(b), size)
+	}
+}
@@ -77,6 +77,11 @@ func (t *BalanceTask) Execute(ctx context.Context, params *worker_pb.TaskParams)
 	targetServer := pb.ServerAddress(destNode)
 	volumeId := needle.VolumeId(t.volumeID)
 
+	// the source of a retained write-once volume can not be deleted after the copy
+	if err := t.checkWormRetention(sourceServer, volumeId); err != nil {
+		return err
+	}
+
 	// Step 1: Mark volume readonly
 	t.ReportProgress(10.0)
 	t.GetLogger().Info("Marking volume readonly for move")
@@ -162,6 +167,24 @@ func (t *BalanceTask) GetProgress() float64 {
 
 // Helper methods for real balance operations
 
+// checkWormRetention returns an error if the volume is a write-once volume retaining needles
+func (t *BalanceTask) checkWormRetention(server pb.ServerAddress, volumeId needle.VolumeId) error {
+	return operation.WithVolumeServerClient(false, server, grpc.WithInsecure(),
+		func(client volume_server_pb.VolumeServerClient) error {
+			resp, err := client.ReadVolumeFileStatus(context.Background(), &volume_server_pb.ReadVolumeFileStatusRequest{
+				VolumeId: uint32(volumeId),
+			})
+			if err != nil {
+				return fmt.Errorf("read volume %d status: %v", volumeId, err)
+			}
+			if resp.WormRetainedUntilSeconds > 0 {
+				return fmt.Errorf("write-once volume %d is retained until %v and can not be moved",
+					volumeId, time.Unix(int64(resp.WormRetainedUntilSeconds), 0).Format(time.RFC3339))
+			}
+			return nil
+		})
+}
+
 // markVolumeReadonly marks the volume readonly
 func (t *BalanceTask) markVolumeReadonly(server pb.ServerAddress, volumeId needle.VolumeId) error {
 	return operation.WithVolumeServerClient(false, server, grpc.WithInsecure(),
@@ -14,6 +14,7 @@ type Config struct {
 	base.BaseConfig
 	ImbalanceThreshold float64 `json:"imbalance_threshold"`
 	MinServerCount     int     `json:"min_server_count"`
+	LoadAware          bool    `json:"load_aware"`
 }
 
 // NewDefaultConfig creates a new default balance configuration
@@ -109,6 +110,18 @@ func GetConfigSpec() base.ConfigSpec {
 				InputType:    "number",
 				CSSClasses:   "form-control",
 			},
+			{
+				Name:         "load_aware",
+				JSONName:     "load_aware",
+				Type:         config.FieldTypeBool,
+				DefaultValue: false,
+				Required:     false,
+				DisplayName:  "Balance by Load",
+				Description:  "Balance the read and write load of servers instead of volume counts",
+				HelpText:     "The load is sampled from the volume counters between scans, and hot volumes are moved to idle servers",
+				InputType:    "checkbox",
+				CSSClasses:   "form-check-input",
+			},
 		},
 	}
 }
@@ -124,6 +137,7 @@ func (c *Config) ToTaskPolicy() *worker_pb.TaskPolicy {
 			BalanceConfig: &worker_pb.BalanceTaskConfig{
 				ImbalanceThreshold: float64(c.ImbalanceThreshold),
 				MinServerCount:     int32(c.MinServerCount),
+				LoadAware:          c.LoadAware,
 			},
 		},
 	}
@@ -144,6 +158,7 @@ func (c *Config) FromTaskPolicy(policy *worker_pb.TaskPolicy) error {
 	if balanceConfig := policy.GetBalanceConfig(); balanceConfig != nil {
 		c.ImbalanceThreshold = float64(balanceConfig.ImbalanceThreshold)
 		c.MinServerCount = int(balanceConfig.MinServerCount)
+		c.LoadAware = balanceConfig.LoadAware
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/admin/topology"
 	"github.com/seaweedfs/seaweedfs/weed/glog"
 	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/base"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/util"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types"
@@ -20,6 +21,11 @@ func Detection(metrics []*types.VolumeHealthMetrics, clusterInfo *types.ClusterI
 
 	balanceConfig := config.(*Config)
 
+	var volumeLoads map[volume_load.Key]*volume_load.Load
+	if balanceConfig.LoadAware {
+		volumeLoads = loadSamples.sample(metrics, time.Now())
+	}
+
 	// Group volumes by disk type to ensure we compare apples to apples
 	volumesByDiskType := make(map[string][]*types.VolumeHealthMetrics)
 	for _, metric := range metrics {
@@ -29,7 +35,13 @@ func Detection(metrics []*types.VolumeHealthMetrics, clusterInfo *types.ClusterI
 	var allParams []*types.TaskDetectionResult
 
 	for diskType, diskMetrics := range volumesByDiskType {
-		if task := detectForDiskType(diskType, diskMetrics, balanceConfig, clusterInfo); task != nil {
+		var task *types.TaskDetectionResult
+		if balanceConfig.LoadAware {
+			task = detectLoadForDiskType(diskType, diskMetrics, volumeLoads, balanceConfig, clusterInfo)
+		} else {
+			task = detectForDiskType(diskType, diskMetrics, balanceConfig, clusterInfo)
+		}
+		if task != nil {
 			allParams = append(allParams, task)
 		}
 	}
@@ -100,10 +112,15 @@ func detectForDiskType(diskType string, diskMetrics []*types.VolumeHealthMetrics
 		return nil
 	}
 
-	// Create balance task with volume and destination planning info
 	reason := fmt.Sprintf("Cluster imbalance detected for %s: %.1f%% (max: %d on %s, min: %d on %s, avg: %.1f)",
 		diskType, imbalanceRatio*100, maxVolumes, maxServer, minVolumes, minServer, avgVolumesPerServer)
 
+	return createBalanceTask(diskType, selectedVolume, reason, clusterInfo, nil)
+}
+
+// createBalanceTask creates the balance task with volume and destination planning info.
+// The destination is chosen by volume counts, or by server loads if loadPlacement is not nil.
+func createBalanceTask(diskType string, selectedVolume *types.VolumeHealthMetrics, reason string, clusterInfo *types.ClusterInfo, loadPlacement *loadPlacement) *types.TaskDetectionResult {
 	// Generate task ID for ActiveTopology integration
 	taskID := fmt.Sprintf("balance_vol_%d_%d", selectedVolume.VolumeID, time.Now().Unix())
 
@@ -126,7 +143,7 @@ func detectForDiskType(diskType string, diskMetrics []*types.VolumeHealthMetrics
 			return nil
 		}
 
-		destinationPlan, err := planBalanceDestination(clusterInfo.ActiveTopology, selectedVolume)
+		destinationPlan, err := planBalanceDestination(clusterInfo.ActiveTopology, selectedVolume, loadPlacement)
 		if err != nil {
 			glog.Warningf("Failed to plan balance destination for volume %d: %v", selectedVolume.VolumeID, err)
 			return nil
@@ -212,7 +229,7 @@ func detectForDiskType(diskType string, diskMetrics []*types.VolumeHealthMetrics
 
 // planBalanceDestination plans the destination for a balance operation
 // This function implements destination planning logic directly in the detection phase
-func planBalanceDestination(activeTopology *topology.ActiveTopology, selectedVolume *types.VolumeHealthMetrics) (*topology.DestinationPlan, error) {
+func planBalanceDestination(activeTopology *topology.ActiveTopology, selectedVolume *types.VolumeHealthMetrics, loadPlacement *loadPlacement) (*topology.DestinationPlan, error) {
 	// Get source node information from topology
 	var sourceRack, sourceDC string
 
@@ -254,7 +271,15 @@ func planBalanceDestination(activeTopology *topology.ActiveTopology, selectedVol
 			continue
 		}
 
-		score := calculateBalanceScore(disk, sourceRack, sourceDC, selectedVolume.Size)
+		var score float64
+		if loadPlacement != nil {
+			if !loadPlacement.allows(disk, sourceDC, sourceRack) {
+				continue
+			}
+			score = loadPlacement.score(disk)
+		} else {
+			score = calculateBalanceScore(disk, sourceRack, sourceDC, selectedVolume.Size)
+		}
 		if score > bestScore {
 			bestScore = score
 			bestDisk = disk
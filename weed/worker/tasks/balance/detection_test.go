@@ -2,9 +2,11 @@ package balance
 
 import (
 	"testing"
+	"time"
 
 	"github.com/seaweedfs/seaweedfs/weed/admin/topology"
 	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
 	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/base"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types"
 )
@@ -253,3 +255,76 @@ func TestDetection_ImbalancedDiskType(t *testing.T) {
 		}
 	}
 }
+
+func TestDetection_LoadAware(t *testing.T) {
+	// Same volume counts on both SSD servers, but the hot volumes are all on ssd-server-1
+	var metrics []*types.VolumeHealthMetrics
+	for i := 0; i < 20; i++ {
+		server := "ssd-server-1"
+		if i >= 10 {
+			server = "ssd-server-2"
+		}
+		metrics = append(metrics, &types.VolumeHealthMetrics{
+			VolumeID:      uint32(i + 1),
+			Server:        server,
+			ServerAddress: server + ":8080",
+			DiskType:      "ssd",
+			Collection:    "c1",
+			Size:          1024,
+			DataCenter:    "dc1",
+			Rack:          "rack1",
+		})
+	}
+
+	conf := &Config{
+		BaseConfig: base.BaseConfig{
+			Enabled:             true,
+			ScanIntervalSeconds: 30,
+			MaxConcurrent:       1,
+		},
+		MinServerCount:     2,
+		ImbalanceThreshold: 0.2,
+		LoadAware:          true,
+	}
+	clusterInfo := &types.ClusterInfo{
+		ActiveTopology: createMockTopology(metrics...),
+	}
+
+	sampler := newLoadSampler()
+	now := time.Now()
+	if loads := sampler.sample(metrics, now); len(loads) != 0 {
+		t.Fatalf("first sample has load %v", loads)
+	}
+	if task := detectLoadForDiskType("ssd", metrics, nil, conf, clusterInfo); task != nil {
+		t.Fatalf("task %+v created without load samples", task)
+	}
+
+	// 10 seconds later: volume 3 is read 1000 times per second, volume 5 100 times
+	metrics[2].ReadCount, metrics[2].ReadMicros = 10000, 20000
+	metrics[4].ReadCount = 1000
+	metrics[12].WriteCount = 100
+	loads := sampler.sample(metrics, now.Add(10*time.Second))
+	load := loads[volume_load.Key{Server: "ssd-server-1", VolumeId: 3}]
+	if load == nil || load.ReadOps != 1000 || load.ReadLatency != 2*time.Microsecond {
+		t.Fatalf("volume 3 load %+v", load)
+	}
+
+	// moving volume 5 narrows the load gap the most
+	task := detectLoadForDiskType("ssd", metrics, loads, conf, clusterInfo)
+	if task == nil {
+		t.Fatal("Expected a task for the hot ssd-server-1")
+	}
+	if task.VolumeID != 5 {
+		t.Errorf("Expected volume 5 to move, got %d", task.VolumeID)
+	}
+	if task.TypedParams.Sources[0].Node != "ssd-server-1:8080" || task.TypedParams.Targets[0].Node != "ssd-server-2:8080" {
+		t.Errorf("Expected move from ssd-server-1:8080 to ssd-server-2:8080, got %s to %s",
+			task.TypedParams.Sources[0].Node, task.TypedParams.Targets[0].Node)
+	}
+
+	// counters reset by a restart are not a load
+	metrics[2].ReadCount = 0
+	if loads = sampler.sample(metrics, now.Add(20*time.Second)); loads[volume_load.Key{Server: "ssd-server-1", VolumeId: 3}] != nil {
+		t.Errorf("load after counter reset %+v", loads)
+	}
+}
@@ -0,0 +1,202 @@
+package balance
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/admin/topology"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/storage/super_block"
+	"github.com/seaweedfs/seaweedfs/weed/storage/volume_load"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types"
+)
+
+// loadSamples keeps the volume io counters of the previous detection, so the load is sampled between scans
+var loadSamples = newLoadSampler()
+
+type loadSampler struct {
+	sync.Mutex
+	previous map[volume_load.Key]volume_load.Counters
+}
+
+func newLoadSampler() *loadSampler {
+	return &loadSampler{
+		previous: make(map[volume_load.Key]volume_load.Counters),
+	}
+}
+
+// sample returns the load of the volumes since the previous sample.
+// Volumes seen for the first time, or with counters reset by a volume server restart, have no load yet.
+func (s *loadSampler) sample(metrics []*types.VolumeHealthMetrics, now time.Time) map[volume_load.Key]*volume_load.Load {
+	s.Lock()
+	defer s.Unlock()
+
+	loads := make(map[volume_load.Key]*volume_load.Load)
+	current := make(map[volume_load.Key]volume_load.Counters, len(metrics))
+	for _, metric := range metrics {
+		key := volume_load.Key{Server: metric.Server, VolumeId: metric.VolumeID}
+		counters := volume_load.Counters{
+			ReadCount:   metric.ReadCount,
+			ReadBytes:   metric.ReadBytes,
+			ReadMicros:  metric.ReadMicros,
+			WriteCount:  metric.WriteCount,
+			WriteBytes:  metric.WriteBytes,
+			WriteMicros: metric.WriteMicros,
+			SampledAt:   now,
+		}
+		current[key] = counters
+		if prev, found := s.previous[key]; found {
+			if load := counters.LoadSince(prev); load != nil {
+				loads[key] = load
+			}
+		}
+	}
+	s.previous = current
+	return loads
+}
+
+// detectLoadForDiskType moves a hot volume from the busiest server to an idle one,
+// so the busiest server is relieved without making the target the new busiest server
+func detectLoadForDiskType(diskType string, diskMetrics []*types.VolumeHealthMetrics, volumeLoads map[volume_load.Key]*volume_load.Load, balanceConfig *Config, clusterInfo *types.ClusterInfo) *types.TaskDetectionResult {
+	if len(volumeLoads) == 0 {
+		glog.V(1).Infof("BALANCE [%s]: No tasks created - sampling volume load until the next scan", diskType)
+		return nil
+	}
+
+	serverLoads := make(map[string]float64)
+	replicas := make(map[uint32][]*types.VolumeHealthMetrics)
+	for _, metric := range diskMetrics {
+		serverLoads[metric.Server] += volumeLoads[volume_load.Key{Server: metric.Server, VolumeId: metric.VolumeID}].Ops()
+		replicas[metric.VolumeID] = append(replicas[metric.VolumeID], metric)
+	}
+	// idle servers without volumes of this disk type can take hot volumes too
+	if clusterInfo.ActiveTopology != nil {
+		for _, disk := range clusterInfo.ActiveTopology.GetAvailableDisks(topology.TaskTypeBalance, "") {
+			if _, found := serverLoads[disk.NodeID]; !found && disk.DiskType == diskType {
+				serverLoads[disk.NodeID] = 0
+			}
+		}
+	}
+
+	if len(serverLoads) < balanceConfig.MinServerCount {
+		glog.V(1).Infof("BALANCE [%s]: No tasks created - too few servers (%d servers, need ≥%d)", diskType, len(serverLoads), balanceConfig.MinServerCount)
+		return nil
+	}
+
+	var totalLoad, maxLoad, minLoad float64
+	var maxServer, minServer string
+	for server, load := range serverLoads {
+		totalLoad += load
+		if maxServer == "" || load > maxLoad {
+			maxLoad, maxServer = load, server
+		}
+		if minServer == "" || load < minLoad {
+			minLoad, minServer = load, server
+		}
+	}
+	avgLoad := totalLoad / float64(len(serverLoads))
+	if avgLoad == 0 {
+		glog.V(1).Infof("BALANCE [%s]: No tasks created - no load", diskType)
+		return nil
+	}
+
+	imbalanceRatio := (maxLoad - minLoad) / avgLoad
+	if imbalanceRatio <= balanceConfig.ImbalanceThreshold {
+		glog.Infof("BALANCE [%s]: No tasks created - load well balanced. Imbalance=%.1f%% (threshold=%.1f%%). Max=%.1f ops/s on %s, Min=%.1f ops/s on %s, Avg=%.1f ops/s",
+			diskType, imbalanceRatio*100, balanceConfig.ImbalanceThreshold*100, maxLoad, maxServer, minLoad, minServer, avgLoad)
+		return nil
+	}
+
+	// moving a volume narrows the gap to |gap - 2*load|, so the volumes closest to half of the gap go first
+	gap := maxLoad - minLoad
+	var candidates []*types.VolumeHealthMetrics
+	for _, metric := range diskMetrics {
+		load := volumeLoads[volume_load.Key{Server: metric.Server, VolumeId: metric.VolumeID}]
+		if metric.Server == maxServer && load.Ops() > 0 && load.Ops() < gap {
+			candidates = append(candidates, metric)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b *types.VolumeHealthMetrics) int {
+		la := volumeLoads[volume_load.Key{Server: a.Server, VolumeId: a.VolumeID}].Ops()
+		lb := volumeLoads[volume_load.Key{Server: b.Server, VolumeId: b.VolumeID}].Ops()
+		return cmp.Compare(math.Abs(gap-2*la), math.Abs(gap-2*lb))
+	})
+
+	for _, selectedVolume := range candidates {
+		load := volumeLoads[volume_load.Key{Server: selectedVolume.Server, VolumeId: selectedVolume.VolumeID}]
+		placement := &loadPlacement{
+			serverLoads: serverLoads,
+			sourceLoad:  maxLoad,
+			volumeLoad:  load.Ops(),
+			volume:      selectedVolume,
+			replicas:    replicas[selectedVolume.VolumeID],
+		}
+		reason := fmt.Sprintf("Load imbalance detected for %s: %.1f%% (max: %.1f ops/s on %s, min: %.1f ops/s on %s, avg: %.1f ops/s), volume %d has %s",
+			diskType, imbalanceRatio*100, maxLoad, maxServer, minLoad, minServer, avgLoad, selectedVolume.VolumeID, load)
+		if task := createBalanceTask(diskType, selectedVolume, reason, clusterInfo, placement); task != nil {
+			return task
+		}
+	}
+
+	glog.V(1).Infof("BALANCE [%s]: No tasks created - no volume on %s can be moved to a less loaded server", diskType, maxServer)
+	return nil
+}
+
+// loadPlacement chooses the destination of a hot volume by the server loads
+type loadPlacement struct {
+	serverLoads map[string]float64
+	sourceLoad  float64
+	volumeLoad  float64
+	volume      *types.VolumeHealthMetrics
+	replicas    []*types.VolumeHealthMetrics // all replicas of the volume, including the moved one
+}
+
+// allows checks the target does not become the busiest server, and keeps the rack and data center placement of the replicas
+func (p *loadPlacement) allows(disk *topology.DiskInfo, sourceDC, sourceRack string) bool {
+	if p.serverLoads[disk.NodeID]+p.volumeLoad >= p.sourceLoad {
+		return false
+	}
+	replicaPlacement, err := super_block.NewReplicaPlacementFromByte(byte(p.volume.ExpectedReplicas))
+	if err != nil {
+		return false
+	}
+	for _, replica := range p.replicas {
+		if replica.Server == p.volume.Server {
+			continue
+		}
+		if replica.Server == disk.NodeID {
+			return false
+		}
+		if disk.DataCenter != sourceDC && replicaPlacement.DiffDataCenterCount > 0 && replica.DataCenter == disk.DataCenter {
+			return false
+		}
+		if disk.DataCenter != sourceDC && replicaPlacement.DiffDataCenterCount == 0 {
+			return false
+		}
+		if disk.Rack != sourceRack && replicaPlacement.DiffRackCount > 0 && replica.DataCenter == disk.DataCenter && replica.Rack == disk.Rack {
+			return false
+		}
+	}
+	if replicaPlacement.SameRackCount > 0 && (disk.DataCenter != sourceDC || disk.Rack != sourceRack) {
+		return false
+	}
+	return true
+}
+
+// score prefers the least loaded servers, then the less used disks
+func (p *loadPlacement) score(disk *topology.DiskInfo) float64 {
+	score := 0.0
+	if p.sourceLoad > 0 {
+		score += (1.0 - p.serverLoads[disk.NodeID]/p.sourceLoad) * 70.0
+	}
+	if disk.DiskInfo != nil && disk.DiskInfo.MaxVolumeCount > 0 {
+		utilization := float64(disk.DiskInfo.VolumeCount) / float64(disk.DiskInfo.MaxVolumeCount)
+		score += (1.0 - utilization) * 20.0
+	}
+	score += 10.0 - float64(disk.LoadCount)
+	return score
+}
@@ -0,0 +1,187 @@
+package ec_vacuum
+
+import (
+	"fmt"
+
+	"github.com/seaweedfs/seaweedfs/weed/admin/config"
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/base"
+)
+
+// Config extends BaseConfig with EC vacuum-specific settings
+type Config struct {
+	base.BaseConfig
+	GarbageThreshold float64 `json:"garbage_threshold"`
+	MinVolumeSizeMB  int     `json:"min_volume_size_mb"`
+	CollectionFilter string  `json:"collection_filter"`
+}
+
+// NewDefaultConfig creates a new default EC vacuum configuration
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseConfig: base.BaseConfig{
+			Enabled:             true,
+			ScanIntervalSeconds: 6 * 60 * 60, // 6 hours
+			MaxConcurrent:       1,
+		},
+		GarbageThreshold: 0.3, // 30%
+		MinVolumeSizeMB:  100, // 100MB
+		CollectionFilter: "",
+	}
+}
+
+// ToTaskPolicy converts configuration to a TaskPolicy protobuf message
+func (c *Config) ToTaskPolicy() *worker_pb.TaskPolicy {
+	return &worker_pb.TaskPolicy{
+		Enabled:               c.Enabled,
+		MaxConcurrent:         int32(c.MaxConcurrent),
+		RepeatIntervalSeconds: int32(c.ScanIntervalSeconds),
+		CheckIntervalSeconds:  int32(c.ScanIntervalSeconds),
+		TaskConfig: &worker_pb.TaskPolicy_EcVacuumConfig{
+			EcVacuumConfig: &worker_pb.EcVacuumTaskConfig{
+				GarbageThreshold: c.GarbageThreshold,
+				MinVolumeSizeMb:  int32(c.MinVolumeSizeMB),
+				CollectionFilter: c.CollectionFilter,
+			},
+		},
+	}
+}
+
+// FromTaskPolicy loads configuration from a TaskPolicy protobuf message
+func (c *Config) FromTaskPolicy(policy *worker_pb.TaskPolicy) error {
+	if policy == nil {
+		return fmt.Errorf("policy is nil")
+	}
+
+	// Set general TaskPolicy fields
+	c.Enabled = policy.Enabled
+	c.MaxConcurrent = int(policy.MaxConcurrent)
+	c.ScanIntervalSeconds = int(policy.RepeatIntervalSeconds)
+
+	// Set EC vacuum-specific fields from the task config
+	if ecVacuumConfig := policy.GetEcVacuumConfig(); ecVacuumConfig != nil {
+		c.GarbageThreshold = ecVacuumConfig.GarbageThreshold
+		c.MinVolumeSizeMB = int(ecVacuumConfig.MinVolumeSizeMb)
+		c.CollectionFilter = ecVacuumConfig.CollectionFilter
+	}
+
+	return nil
+}
+
+// LoadConfigFromPersistence loads configuration from the persistence layer if available
+func LoadConfigFromPersistence(configPersistence interface{}) *Config {
+	config := NewDefaultConfig()
+
+	// Try to load from persistence if available
+	if persistence, ok := configPersistence.(interface {
+		LoadEcVacuumTaskPolicy() (*worker_pb.TaskPolicy, error)
+	}); ok {
+		if policy, err := persistence.LoadEcVacuumTaskPolicy(); err == nil && policy != nil {
+			if err := config.FromTaskPolicy(policy); err == nil {
+				glog.V(1).Infof("Loaded EC vacuum configuration from persistence")
+				return config
+			}
+		}
+	}
+
+	glog.V(1).Infof("Using default EC vacuum configuration")
+	return config
+}
+
+// GetConfigSpec returns the configuration schema for EC vacuum tasks
+func GetConfigSpec() base.ConfigSpec {
+	return base.ConfigSpec{
+		Fields: []*config.Field{
+			{
+				Name:         "enabled",
+				JSONName:     "enabled",
+				Type:         config.FieldTypeBool,
+				DefaultValue: true,
+				Required:     false,
+				DisplayName:  "Enable EC Vacuum Tasks",
+				Description:  "Whether EC vacuum tasks should be automatically created",
+				HelpText:     "Toggle this to enable or disable automatic EC vacuum task generation",
+				InputType:    "checkbox",
+				CSSClasses:   "form-check-input",
+			},
+			{
+				Name:         "scan_interval_seconds",
+				JSONName:     "scan_interval_seconds",
+				Type:         config.FieldTypeInterval,
+				DefaultValue: 6 * 60 * 60,
+				MinValue:     10 * 60,
+				MaxValue:     7 * 24 * 60 * 60,
+				Required:     true,
+				DisplayName:  "Scan Interval",
+				Description:  "How often to scan for EC volumes needing vacuum",
+				HelpText:     "The system will check for EC volumes with deleted files at this interval",
+				Placeholder:  "6",
+				Unit:         config.UnitHours,
+				InputType:    "interval",
+				CSSClasses:   "form-control",
+			},
+			{
+				Name:         "max_concurrent",
+				JSONName:     "max_concurrent",
+				Type:         config.FieldTypeInt,
+				DefaultValue: 1,
+				MinValue:     1,
+				MaxValue:     5,
+				Required:     true,
+				DisplayName:  "Max Concurrent Tasks",
+				Description:  "Maximum number of EC vacuum tasks that can run simultaneously",
+				HelpText:     "Each EC vacuum reads the whole volume across the network, so keep this low",
+				Placeholder:  "1 (default)",
+				Unit:         config.UnitCount,
+				InputType:    "number",
+				CSSClasses:   "form-control",
+			},
+			{
+				Name:         "garbage_threshold",
+				JSONName:     "garbage_threshold",
+				Type:         config.FieldTypeFloat,
+				DefaultValue: 0.3,
+				MinValue:     0.0,
+				MaxValue:     1.0,
+				Required:     true,
+				DisplayName:  "Garbage Percentage Threshold",
+				Description:  "Trigger EC vacuum when garbage ratio exceeds this percentage",
+				HelpText:     "EC volumes with more deleted content than this threshold will be vacuumed",
+				Placeholder:  "0.30 (30%)",
+				Unit:         config.UnitNone,
+				InputType:    "number",
+				CSSClasses:   "form-control",
+			},
+			{
+				Name:         "min_volume_size_mb",
+				JSONName:     "min_volume_size_mb",
+				Type:         config.FieldTypeInt,
+				DefaultValue: 100,
+				MinValue:     1,
+				MaxValue:     100000,
+				Required:     true,
+				DisplayName:  "Minimum Volume Size (MB)",
+				Description:  "Only vacuum EC volumes larger than this size",
+				HelpText:     "Small EC volumes are not worth the shard rewrite",
+				Placeholder:  "100",
+				Unit:         config.UnitNone,
+				InputType:    "number",
+				CSSClasses:   "form-control",
+			},
+			{
+				Name:         "collection_filter",
+				JSONName:     "collection_filter",
+				Type:         config.FieldTypeString,
+				DefaultValue: "",
+				Required:     false,
+				DisplayName:  "Collection Filter",
+				Description:  "Only process EC volumes from specific collections",
+				HelpText:     "Leave empty to process all collections, or specify collection name",
+				Placeholder:  "my_collection",
+				InputType:    "text",
+				CSSClasses:   "form-control",
+			},
+		},
+	}
+}
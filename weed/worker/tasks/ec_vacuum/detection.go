@@ -0,0 +1,161 @@
+package ec_vacuum
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
+	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/base"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types"
+)
+
+// ecVolumeCandidate collects the shard locations and garbage statistics of one EC volume
+type ecVolumeCandidate struct {
+	volumeID         uint32
+	collection       string
+	datFileSize      uint64
+	deletedByteCount uint64
+	sources          []*worker_pb.TaskSource
+	shardCount       int
+}
+
+func (c *ecVolumeCandidate) garbageRatio() float64 {
+	if c.datFileSize == 0 {
+		return 0
+	}
+	return float64(c.deletedByteCount) / float64(c.datFileSize)
+}
+
+// Detection implements the detection logic for EC vacuum tasks
+func Detection(metrics []*types.VolumeHealthMetrics, clusterInfo *types.ClusterInfo, config base.TaskConfig) ([]*types.TaskDetectionResult, error) {
+	if !config.IsEnabled() {
+		return nil, nil
+	}
+
+	ecVacuumConfig := config.(*Config)
+
+	// EC volumes are not part of the volume metrics, so they are collected from the topology
+	if clusterInfo == nil || clusterInfo.ActiveTopology == nil {
+		glog.V(1).Infof("EC VACUUM: Topology not available, skipping detection")
+		return nil, nil
+	}
+	topologyInfo := clusterInfo.ActiveTopology.GetTopologyInfo()
+	if topologyInfo == nil {
+		return nil, nil
+	}
+	candidates := collectEcVolumes(topologyInfo)
+
+	minSize := uint64(ecVacuumConfig.MinVolumeSizeMB) * 1024 * 1024
+	var results []*types.TaskDetectionResult
+	for _, candidate := range candidates {
+		if ecVacuumConfig.CollectionFilter != "" && candidate.collection != ecVacuumConfig.CollectionFilter {
+			continue
+		}
+		if candidate.datFileSize < minSize {
+			continue
+		}
+		// the volume must be complete, or the vacuum can not regenerate the missing shards
+		if candidate.shardCount < erasure_coding.DataShardsCount {
+			glog.V(1).Infof("EC VACUUM: Skipping volume %d, only %d shards found", candidate.volumeID, candidate.shardCount)
+			continue
+		}
+		garbageRatio := candidate.garbageRatio()
+		if garbageRatio < ecVacuumConfig.GarbageThreshold {
+			continue
+		}
+
+		// Check if ANY task already exists in ActiveTopology for this volume
+		if clusterInfo.ActiveTopology.HasAnyTask(candidate.volumeID) {
+			glog.V(2).Infof("EC VACUUM: Skipping volume %d, task already exists in ActiveTopology", candidate.volumeID)
+			continue
+		}
+
+		priority := types.TaskPriorityNormal
+		if garbageRatio > 0.6 {
+			priority = types.TaskPriorityHigh
+		}
+
+		taskID := fmt.Sprintf("ec_vacuum_vol_%d_%d", candidate.volumeID, time.Now().Unix())
+		results = append(results, &types.TaskDetectionResult{
+			TaskID:     taskID,
+			TaskType:   types.TaskTypeEcVacuum,
+			VolumeID:   candidate.volumeID,
+			Server:     candidate.sources[0].Node,
+			Collection: candidate.collection,
+			Priority:   priority,
+			Reason:     fmt.Sprintf("EC volume has %.1f%% garbage requiring vacuum", garbageRatio*100),
+			ScheduleAt: time.Now(),
+			TypedParams: &worker_pb.TaskParams{
+				TaskId:     taskID,
+				VolumeId:   candidate.volumeID,
+				Collection: candidate.collection,
+				VolumeSize: candidate.datFileSize,
+				Sources:    candidate.sources,
+				TaskParams: &worker_pb.TaskParams_EcVacuumParams{
+					EcVacuumParams: &worker_pb.EcVacuumTaskParams{
+						GarbageThreshold: ecVacuumConfig.GarbageThreshold,
+					},
+				},
+			},
+		})
+	}
+
+	glog.V(1).Infof("EC VACUUM: Found %d EC volumes, created %d tasks (threshold %.2f%%)",
+		len(candidates), len(results), ecVacuumConfig.GarbageThreshold*100)
+
+	return results, nil
+}
+
+// collectEcVolumes groups the EC shards in the topology by volume, ordered by volume id.
+func collectEcVolumes(topologyInfo *master_pb.TopologyInfo) []*ecVolumeCandidate {
+	volumes := make(map[uint32]*ecVolumeCandidate)
+	for _, dc := range topologyInfo.DataCenterInfos {
+		for _, rack := range dc.RackInfos {
+			for _, dn := range rack.DataNodeInfos {
+				address := dn.Address
+				if address == "" {
+					address = dn.Id
+				}
+				for _, disk := range dn.DiskInfos {
+					for _, shardInfo := range disk.EcShardInfos {
+						candidate, found := volumes[shardInfo.Id]
+						if !found {
+							candidate = &ecVolumeCandidate{
+								volumeID:   shardInfo.Id,
+								collection: shardInfo.Collection,
+							}
+							volumes[shardInfo.Id] = candidate
+						}
+						// deletions reach each shard holder separately, so the largest count is the most recent
+						candidate.datFileSize = max(candidate.datFileSize, shardInfo.DatFileSize)
+						candidate.deletedByteCount = max(candidate.deletedByteCount, shardInfo.DeletedByteCount)
+
+						shardIds := erasure_coding.ShardsInfoFromVolumeEcShardInformationMessage(shardInfo).IdsUint32()
+						candidate.shardCount += len(shardIds)
+						candidate.sources = append(candidate.sources, &worker_pb.TaskSource{
+							Node:       address,
+							DiskId:     shardInfo.DiskId,
+							Rack:       rack.Id,
+							DataCenter: dc.Id,
+							VolumeId:   shardInfo.Id,
+							ShardIds:   shardIds,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	candidates := make([]*ecVolumeCandidate, 0, len(volumes))
+	for _, candidate := range volumes {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].volumeID < candidates[j].volumeID
+	})
+	return candidates
+}
@@ -0,0 +1,116 @@
+package ec_vacuum
+
+import (
+	"testing"
+
+	"github.com/seaweedfs/seaweedfs/weed/admin/topology"
+	"github.com/seaweedfs/seaweedfs/weed/pb/master_pb"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// createMockTopology spreads the 14 shards of each volume over two servers, 7 shards each
+func createMockTopology(volumes ...*master_pb.VolumeEcShardInformationMessage) *topology.ActiveTopology {
+	at := topology.NewActiveTopology(0)
+
+	var firstHalf, secondHalf []*master_pb.VolumeEcShardInformationMessage
+	for _, v := range volumes {
+		first := proto.Clone(v).(*master_pb.VolumeEcShardInformationMessage)
+		first.EcIndexBits = 0x007f
+		first.DiskId = 1
+		firstHalf = append(firstHalf, first)
+		second := proto.Clone(v).(*master_pb.VolumeEcShardInformationMessage)
+		second.EcIndexBits = 0x3f80
+		second.DiskId = 2
+		secondHalf = append(secondHalf, second)
+	}
+
+	topoInfo := &master_pb.TopologyInfo{
+		DataCenterInfos: []*master_pb.DataCenterInfo{
+			{
+				Id: "dc1",
+				RackInfos: []*master_pb.RackInfo{
+					{
+						Id: "rack1",
+						DataNodeInfos: []*master_pb.DataNodeInfo{
+							{
+								Id:      "server-1",
+								Address: "server-1:8080",
+								DiskInfos: map[string]*master_pb.DiskInfo{
+									"hdd": {
+										Type:           "hdd",
+										DiskId:         1,
+										EcShardInfos:   firstHalf,
+										MaxVolumeCount: 1000,
+									},
+								},
+							},
+							{
+								Id:      "server-2",
+								Address: "server-2:8080",
+								DiskInfos: map[string]*master_pb.DiskInfo{
+									"hdd": {
+										Type:           "hdd",
+										DiskId:         2,
+										EcShardInfos:   secondHalf,
+										MaxVolumeCount: 1000,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	at.UpdateTopology(topoInfo)
+	return at
+}
+
+func TestDetection_GarbageRatio(t *testing.T) {
+	const mb = 1024 * 1024
+	at := createMockTopology(
+		&master_pb.VolumeEcShardInformationMessage{Id: 1, Collection: "c1", DatFileSize: 1000 * mb, DeletedByteCount: 100 * mb},
+		&master_pb.VolumeEcShardInformationMessage{Id: 2, Collection: "c1", DatFileSize: 1000 * mb, DeletedByteCount: 500 * mb},
+		&master_pb.VolumeEcShardInformationMessage{Id: 3, Collection: "c2", DatFileSize: 1000 * mb, DeletedByteCount: 700 * mb},
+		&master_pb.VolumeEcShardInformationMessage{Id: 4, Collection: "c1", DatFileSize: 10 * mb, DeletedByteCount: 9 * mb},
+	)
+	clusterInfo := &types.ClusterInfo{ActiveTopology: at}
+
+	config := NewDefaultConfig()
+	results, err := Detection(nil, clusterInfo, config)
+	if err != nil {
+		t.Fatalf("Detection failed: %v", err)
+	}
+	if len(results) != 2 || results[0].VolumeID != 2 || results[1].VolumeID != 3 {
+		t.Fatalf("expected volumes 2 and 3, got %+v", results)
+	}
+	if results[1].Priority != types.TaskPriorityHigh {
+		t.Errorf("expected high priority for volume 3, got %v", results[1].Priority)
+	}
+
+	params := results[0].TypedParams
+	if params.GetEcVacuumParams() == nil {
+		t.Fatalf("expected EC vacuum params")
+	}
+	if len(params.Sources) != 2 {
+		t.Fatalf("expected 2 sources, got %d", len(params.Sources))
+	}
+	shardCount := 0
+	for _, source := range params.Sources {
+		shardCount += len(source.ShardIds)
+	}
+	if shardCount != 14 {
+		t.Errorf("expected 14 shards in sources, got %d", shardCount)
+	}
+
+	config.CollectionFilter = "c2"
+	results, err = Detection(nil, clusterInfo, config)
+	if err != nil {
+		t.Fatalf("Detection failed: %v", err)
+	}
+	if len(results) != 1 || results[0].VolumeID != 3 {
+		t.Fatalf("expected volume 3 only, got %+v", results)
+	}
+}
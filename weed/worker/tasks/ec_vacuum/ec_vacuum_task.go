@@ -0,0 +1,322 @@
+package ec_vacuum
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seaweedfs/seaweedfs/weed/glog"
+	"github.com/seaweedfs/seaweedfs/weed/operation"
+	"github.com/seaweedfs/seaweedfs/weed/pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types/base"
+	"google.golang.org/grpc"
+)
+
+// EcVacuumTask implements the Task interface
+//
+// The shards are rebuilt by the servers holding them, each server reading the
+// live needles from the old shards, so no server needs the whole volume on disk.
+// The volume is unavailable between unmounting the old shards and mounting the new ones.
+type EcVacuumTask struct {
+	*base.BaseTask
+	server           string
+	volumeID         uint32
+	collection       string
+	garbageThreshold float64
+	progress         float64
+
+	nodes     []string            // Servers holding shards, the index source first
+	nodeShard map[string][]uint32 // Server -> shard ids on the server
+}
+
+// NewEcVacuumTask creates a new EC vacuum task instance
+func NewEcVacuumTask(id string, server string, volumeID uint32, collection string) *EcVacuumTask {
+	return &EcVacuumTask{
+		BaseTask:         base.NewBaseTask(id, types.TaskTypeEcVacuum),
+		server:           server,
+		volumeID:         volumeID,
+		collection:       collection,
+		garbageThreshold: 0.3, // Default 30% threshold
+	}
+}
+
+// Execute implements the UnifiedTask interface
+func (t *EcVacuumTask) Execute(ctx context.Context, params *worker_pb.TaskParams) error {
+	if params == nil {
+		return fmt.Errorf("task parameters are required")
+	}
+
+	ecVacuumParams := params.GetEcVacuumParams()
+	if ecVacuumParams == nil {
+		return fmt.Errorf("EC vacuum parameters are required")
+	}
+
+	t.garbageThreshold = ecVacuumParams.GarbageThreshold
+	t.groupSources(params.Sources)
+
+	t.GetLogger().WithFields(map[string]interface{}{
+		"volume_id":         t.volumeID,
+		"server":            t.server,
+		"collection":        t.collection,
+		"garbage_threshold": t.garbageThreshold,
+		"nodes":             len(t.nodes),
+	}).Info("Starting EC vacuum task")
+
+	// Step 1: Snapshot the live needles on the index source and check the garbage ratio
+	t.ReportProgress(5.0)
+	garbageRatio, err := t.prepare(ctx)
+	if err != nil {
+		t.cleanup()
+		return fmt.Errorf("failed to prepare EC vacuum: %v", err)
+	}
+	if garbageRatio < t.garbageThreshold {
+		t.GetLogger().WithFields(map[string]interface{}{
+			"current_garbage_ratio": garbageRatio,
+			"required_threshold":    t.garbageThreshold,
+		}).Info("EC volume does not meet vacuum criteria, skipping")
+		t.cleanup()
+		t.ReportProgress(100.0)
+		return nil
+	}
+
+	// Step 2: Generate the vacuumed shards on every server
+	t.ReportProgress(10.0)
+	if err := t.generate(ctx); err != nil {
+		t.cleanup()
+		return fmt.Errorf("failed to generate vacuumed EC shards: %v", err)
+	}
+
+	// Step 3: Unmount the old shards everywhere before swapping any file
+	t.ReportProgress(80.0)
+	if err := t.unmount(ctx); err != nil {
+		t.mount(ctx)
+		t.cleanup()
+		return fmt.Errorf("failed to unmount EC shards: %v", err)
+	}
+
+	// Step 4: Swap in the vacuumed shards, keeping the old ones until every server has committed
+	t.ReportProgress(85.0)
+	commitErr := t.commit(ctx)
+	if commitErr != nil {
+		// the servers must not mix old and vacuumed shards
+		if err := t.rollback(ctx); err != nil {
+			glog.Errorf("EC vacuum rollback of volume %d failed: %v", t.volumeID, err)
+		}
+	} else if err := t.finalize(ctx); err != nil {
+		// the vacuumed shards are in place everywhere, only some backups are left
+		glog.Warningf("EC vacuum finalize of volume %d failed: %v", t.volumeID, err)
+	}
+
+	// Step 5: Mount the shards again, vacuumed or not
+	t.ReportProgress(95.0)
+	mountErr := t.mount(ctx)
+	if commitErr != nil {
+		return fmt.Errorf("failed to commit EC vacuum: %v", commitErr)
+	}
+	if mountErr != nil {
+		return fmt.Errorf("failed to mount vacuumed EC shards: %v", mountErr)
+	}
+
+	t.ReportProgress(100.0)
+	glog.Infof("EC vacuum task completed successfully: volume %d on %d servers (garbage ratio was %.2f%%)",
+		t.volumeID, len(t.nodes), garbageRatio*100)
+	return nil
+}
+
+// Validate implements the UnifiedTask interface
+func (t *EcVacuumTask) Validate(params *worker_pb.TaskParams) error {
+	if params == nil {
+		return fmt.Errorf("task parameters are required")
+	}
+
+	ecVacuumParams := params.GetEcVacuumParams()
+	if ecVacuumParams == nil {
+		return fmt.Errorf("EC vacuum parameters are required")
+	}
+
+	if params.VolumeId != t.volumeID {
+		return fmt.Errorf("volume ID mismatch: expected %d, got %d", t.volumeID, params.VolumeId)
+	}
+
+	if len(params.Sources) == 0 || params.Sources[0].Node != t.server {
+		return fmt.Errorf("first source does not match expected server %s", t.server)
+	}
+
+	if ecVacuumParams.GarbageThreshold < 0 || ecVacuumParams.GarbageThreshold > 1.0 {
+		return fmt.Errorf("invalid garbage threshold: %f (must be between 0.0 and 1.0)", ecVacuumParams.GarbageThreshold)
+	}
+
+	return nil
+}
+
+// EstimateTime implements the UnifiedTask interface
+func (t *EcVacuumTask) EstimateTime(params *worker_pb.TaskParams) time.Duration {
+	if params == nil {
+		return 10 * time.Minute
+	}
+	// every byte of the volume is read once over the network, assume 50MB/s
+	return time.Duration(params.VolumeSize/(50*1024*1024))*time.Second + time.Minute
+}
+
+// GetProgress returns current progress
+func (t *EcVacuumTask) GetProgress() float64 {
+	return t.progress
+}
+
+// groupSources merges the shards of each server, which may be reported per disk.
+func (t *EcVacuumTask) groupSources(sources []*worker_pb.TaskSource) {
+	t.nodes = nil
+	t.nodeShard = make(map[string][]uint32)
+	for _, source := range sources {
+		if _, found := t.nodeShard[source.Node]; !found {
+			t.nodes = append(t.nodes, source.Node)
+		}
+		t.nodeShard[source.Node] = append(t.nodeShard[source.Node], source.ShardIds...)
+	}
+	if len(t.nodes) == 0 {
+		t.nodes = []string{t.server}
+	}
+}
+
+// forEachNode runs fn on every server holding shards in parallel, and returns the first error.
+func (t *EcVacuumTask) forEachNode(fn func(node string, client volume_server_pb.VolumeServerClient) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(t.nodes))
+	for i, node := range t.nodes {
+		wg.Add(1)
+		go func(i int, node string) {
+			defer wg.Done()
+			errs[i] = operation.WithVolumeServerClient(false, pb.ServerAddress(node), grpc.WithInsecure(),
+				func(client volume_server_pb.VolumeServerClient) error {
+					return fn(node, client)
+				})
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %v", node, errs[i])
+			}
+		}(i, node)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepare snapshots the live needles on the first server and returns the reclaimable ratio
+func (t *EcVacuumTask) prepare(ctx context.Context) (float64, error) {
+	var garbageRatio float64
+	err := operation.WithVolumeServerClient(false, pb.ServerAddress(t.nodes[0]), grpc.WithInsecure(),
+		func(client volume_server_pb.VolumeServerClient) error {
+			resp, err := client.VolumeEcShardsVacuumPrepare(ctx, &volume_server_pb.VolumeEcShardsVacuumPrepareRequest{
+				VolumeId:   t.volumeID,
+				Collection: t.collection,
+			})
+			if err != nil {
+				return err
+			}
+			if resp.DatFileSize > 0 && resp.NewDatFileSize < resp.DatFileSize {
+				garbageRatio = float64(resp.DatFileSize-resp.NewDatFileSize) / float64(resp.DatFileSize)
+			}
+			t.GetLogger().WithFields(map[string]interface{}{
+				"dat_file_size":     resp.DatFileSize,
+				"new_dat_file_size": resp.NewDatFileSize,
+				"live_needles":      resp.LiveNeedleCount,
+				"garbage_ratio":     garbageRatio,
+			}).Info("Prepared EC vacuum")
+			return nil
+		})
+	return garbageRatio, err
+}
+
+// generate writes the vacuumed shards next to the old ones on every server
+func (t *EcVacuumTask) generate(ctx context.Context) error {
+	indexSource := t.nodes[0]
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		req := &volume_server_pb.VolumeEcShardsVacuumGenerateRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+			ShardIds:   t.nodeShard[node],
+		}
+		if node != indexSource {
+			req.IndexSourceNode = indexSource
+		}
+		if _, err := client.VolumeEcShardsVacuumGenerate(ctx, req); err != nil {
+			return err
+		}
+		glog.V(1).Infof("EC vacuum generated volume %d shards %v on %s", t.volumeID, t.nodeShard[node], node)
+		return nil
+	})
+}
+
+func (t *EcVacuumTask) unmount(ctx context.Context) error {
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsUnmount(ctx, &volume_server_pb.VolumeEcShardsUnmountRequest{
+			VolumeId: t.volumeID,
+			ShardIds: t.nodeShard[node],
+		})
+		return err
+	})
+}
+
+func (t *EcVacuumTask) commit(ctx context.Context) error {
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsVacuumCommit(ctx, &volume_server_pb.VolumeEcShardsVacuumCommitRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+		})
+		return err
+	})
+}
+
+// rollback restores the old shards on every server, whether its commit succeeded or not
+func (t *EcVacuumTask) rollback(ctx context.Context) error {
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsVacuumRollback(ctx, &volume_server_pb.VolumeEcShardsVacuumRollbackRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+		})
+		return err
+	})
+}
+
+// finalize removes the old shards kept by the commit
+func (t *EcVacuumTask) finalize(ctx context.Context) error {
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsVacuumFinalize(ctx, &volume_server_pb.VolumeEcShardsVacuumFinalizeRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+		})
+		return err
+	})
+}
+
+func (t *EcVacuumTask) mount(ctx context.Context) error {
+	return t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsMount(ctx, &volume_server_pb.VolumeEcShardsMountRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+			ShardIds:   t.nodeShard[node],
+		})
+		return err
+	})
+}
+
+// cleanup removes the vacuum files, keeping the old shards
+func (t *EcVacuumTask) cleanup() {
+	err := t.forEachNode(func(node string, client volume_server_pb.VolumeServerClient) error {
+		_, err := client.VolumeEcShardsVacuumCleanup(context.Background(), &volume_server_pb.VolumeEcShardsVacuumCleanupRequest{
+			VolumeId:   t.volumeID,
+			Collection: t.collection,
+		})
+		return err
+	})
+	if err != nil {
+		glog.Warningf("EC vacuum cleanup of volume %d failed: %v", t.volumeID, err)
+	}
+}
@@ -0,0 +1,37 @@
+package ec_vacuum
+
+import (
+	"github.com/seaweedfs/seaweedfs/weed/worker/tasks/base"
+	"github.com/seaweedfs/seaweedfs/weed/worker/types"
+)
+
+// Scheduling implements the scheduling logic for EC vacuum tasks
+func Scheduling(task *types.TaskInput, runningTasks []*types.TaskInput, availableWorkers []*types.WorkerData, config base.TaskConfig) bool {
+	ecVacuumConfig := config.(*Config)
+
+	// Count running EC vacuum tasks
+	runningEcVacuumCount := 0
+	for _, runningTask := range runningTasks {
+		if runningTask.Type == types.TaskTypeEcVacuum {
+			runningEcVacuumCount++
+		}
+	}
+
+	// Check concurrency limit
+	if runningEcVacuumCount >= ecVacuumConfig.MaxConcurrent {
+		return false
+	}
+
+	// Check for available workers with EC vacuum capability
+	for _, worker := range availableWorkers {
+		if worker.CurrentLoad < worker.MaxConcurrent {
+			for _, capability := range worker.Capabilities {
+				if capability == types.TaskTypeEcVacuum {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/seaweedfs/seaweedfs/weed/pb/worker_pb"
 	"github.com/seaweedfs/seaweedfs/weed/storage/erasure_coding"
 	"github.com/seaweedfs/seaweedfs/weed/storage/needle"
+	storage_types "github.com/seaweedfs/seaweedfs/weed/storage/types"
 	"github.com/seaweedfs/seaweedfs/weed/storage/volume_info"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types"
 	"github.com/seaweedfs/seaweedfs/weed/worker/types/base"
@@ -32,6 +33,8 @@ type ErasureCodingTask struct {
 	workDir    string
 	progress   float64
 
+	offsetWidth storage_types.OffsetWidth // of the source volume index
+
 	// EC parameters
 	dataShards      int32
 	parityShards    int32
@@ -262,6 +265,13 @@ func (t *ErasureCodingTask) copyVolumeFilesToWorker(workDir string) (map[string]
 		"working_dir": workDir,
 	}).Info("Starting volume file copy from source server")
 
+	// The index is copied as is, so the shards keep the offset width of the source volume
+	offsetWidth, err := t.readOffsetWidth()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volume offset width: %v", err)
+	}
+	t.offsetWidth = offsetWidth
+
 	// Copy .dat file
 	datFile := filepath.Join(workDir, fmt.Sprintf("%d.dat", t.volumeID))
 	if err := t.copyFileFromSource(".dat", datFile); err != nil {
@@ -299,6 +309,22 @@ func (t *ErasureCodingTask) copyVolumeFilesToWorker(workDir string) (map[string]
 	return localFiles, nil
 }
 
+// readOffsetWidth reads the offset width of the volume index from the source server
+func (t *ErasureCodingTask) readOffsetWidth() (offsetWidth storage_types.OffsetWidth, err error) {
+	err = operation.WithVolumeServerClient(false, pb.ServerAddress(t.server), grpc.WithInsecure(),
+		func(client volume_server_pb.VolumeServerClient) error {
+			resp, readErr := client.ReadVolumeFileStatus(context.Background(), &volume_server_pb.ReadVolumeFileStatusRequest{
+				VolumeId: t.volumeID,
+			})
+			if readErr != nil {
+				return readErr
+			}
+			offsetWidth, readErr = storage_types.ParseOffsetWidth(resp.GetVolumeInfo().GetBytesOffset())
+			return readErr
+		})
+	return
+}
+
 // copyFileFromSource copies a file from source server to local path using gRPC streaming
 func (t *ErasureCodingTask) copyFileFromSource(ext, localPath string) error {
 	return operation.WithVolumeServerClient(false, pb.ServerAddress(t.server), grpc.WithInsecure(),
@@ -366,7 +392,7 @@ func (t *ErasureCodingTask) generateEcShardsLocally(localFiles map[string]string
 	}
 
 	// Generate .ecx file from .idx (use baseName, not full idx path)
-	if err := erasure_coding.WriteSortedFileFromIdx(baseName, ".ecx"); err != nil {
+	if err := erasure_coding.WriteSortedFileFromIdxWithWidth(baseName, ".ecx", t.offsetWidth); err != nil {
 		return nil, fmt.Errorf("failed to generate .ecx file: %v", err)
 	}
 
@@ -408,7 +434,8 @@ func (t *ErasureCodingTask) generateEcShardsLocally(localFiles map[string]string
 	// Generate .vif file (volume info)
 	vifFile := baseName + ".vif"
 	volumeInfo := &volume_server_pb.VolumeInfo{
-		Version: uint32(needle.GetCurrentVersion()),
+		Version:     uint32(needle.GetCurrentVersion()),
+		BytesOffset: uint32(t.offsetWidth),
 	}
 	if err := volume_info.SaveVolumeInfo(vifFile, volumeInfo); err != nil {
 		glog.Warningf("Failed to create .vif file: %v", err)
@@ -36,6 +36,14 @@ type VolumeHealthMetrics struct {
 	HasRemoteCopy    bool
 	IsECVolume       bool
 	FullnessRatio    float64
+
+	// io counters since the volume is loaded, to sample the load between scans
+	ReadCount   uint64
+	ReadBytes   uint64
+	ReadMicros  uint64
+	WriteCount  uint64
+	WriteBytes  uint64
+	WriteMicros uint64
 }
 
 // VolumeServerInfo contains information about a volume server (simplified)
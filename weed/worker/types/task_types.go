@@ -15,6 +15,7 @@ const (
 	TaskTypeErasureCoding TaskType = "erasure_coding"
 	TaskTypeBalance       TaskType = "balance"
 	TaskTypeReplication   TaskType = "replication"
+	TaskTypeEcVacuum      TaskType = "ec_vacuum"
 )
 
 // TaskStatus represents the status of a maintenance task